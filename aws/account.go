@@ -0,0 +1,277 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"fmt"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/aws/aws-sdk-go/private/protocol/jsonrpc"
+	"github.com/aws/aws-sdk-go/private/protocol/restxml"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/iam"
+)
+
+var (
+	Organizations *OrganizationsClient
+	S3Control     *S3ControlClient
+)
+
+// AccountOrganization is the AWS Organization the account is member of
+type AccountOrganization struct {
+	ID                 string `json:"id"`
+	Arn                string `json:"arn"`
+	MasterAccountID    string `json:"masterAccountId"`
+	MasterAccountEmail string `json:"masterAccountEmail"`
+	FeatureSet         string `json:"featureSet"`
+}
+
+// S3PublicAccessBlock is the S3 block public access configuration of the account
+type S3PublicAccessBlock struct {
+	BlockPublicAcls       bool `json:"blockPublicAcls"`
+	IgnorePublicAcls      bool `json:"ignorePublicAcls"`
+	BlockPublicPolicy     bool `json:"blockPublicPolicy"`
+	RestrictPublicBuckets bool `json:"restrictPublicBuckets"`
+}
+
+// PasswordPolicy is the IAM password policy of the account
+type PasswordPolicy struct {
+	MinimumLength              int64 `json:"minimumLength"`
+	RequireSymbols             bool  `json:"requireSymbols"`
+	RequireNumbers             bool  `json:"requireNumbers"`
+	RequireUppercaseCharacters bool  `json:"requireUppercaseCharacters"`
+	RequireLowercaseCharacters bool  `json:"requireLowercaseCharacters"`
+	AllowUsersToChangePassword bool  `json:"allowUsersToChangePassword"`
+	MaxPasswordAge             int64 `json:"maxPasswordAge,omitempty"`
+	PasswordReusePrevention    int64 `json:"passwordReusePrevention,omitempty"`
+	HardExpiry                 bool  `json:"hardExpiry"`
+}
+
+// AccountAliases returns the aliases of the account (at most one in practice)
+func (s *Access) AccountAliases() ([]string, error) {
+	out, err := s.ListAccountAliases(&iam.ListAccountAliasesInput{})
+	if err != nil {
+		return nil, err
+	}
+	return awssdk.StringValueSlice(out.AccountAliases), nil
+}
+
+// AccountPasswordPolicy returns the IAM password policy of the account, or nil when none is set
+func (s *Access) AccountPasswordPolicy() (*PasswordPolicy, error) {
+	out, err := s.GetAccountPasswordPolicy(&iam.GetAccountPasswordPolicyInput{})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == iam.ErrCodeNoSuchEntityException {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	pol := out.PasswordPolicy
+	return &PasswordPolicy{
+		MinimumLength:              awssdk.Int64Value(pol.MinimumPasswordLength),
+		RequireSymbols:             awssdk.BoolValue(pol.RequireSymbols),
+		RequireNumbers:             awssdk.BoolValue(pol.RequireNumbers),
+		RequireUppercaseCharacters: awssdk.BoolValue(pol.RequireUppercaseCharacters),
+		RequireLowercaseCharacters: awssdk.BoolValue(pol.RequireLowercaseCharacters),
+		AllowUsersToChangePassword: awssdk.BoolValue(pol.AllowUsersToChangePassword),
+		MaxPasswordAge:             awssdk.Int64Value(pol.MaxPasswordAge),
+		PasswordReusePrevention:    awssdk.Int64Value(pol.PasswordReusePrevention),
+		HardExpiry:                 awssdk.BoolValue(pol.HardExpiry),
+	}, nil
+}
+
+// EBSEncryptionByDefault returns whether new EBS volumes of the region are encrypted by default.
+// The vendored SDK does not ship the GetEbsEncryptionByDefault call, so it is sent on top of the EC2 client
+func (s *Infra) EBSEncryptionByDefault() (bool, error) {
+	c, ok := s.EC2API.(*ec2.EC2)
+	if !ok {
+		return false, ErrMockUnsupported
+	}
+	output := &getEbsEncryptionByDefaultOutput{}
+	op := &request.Operation{Name: "GetEbsEncryptionByDefault", HTTPMethod: "POST", HTTPPath: "/"}
+	if err := c.NewRequest(op, &getEbsEncryptionByDefaultInput{}, output).Send(); err != nil {
+		return false, err
+	}
+	return awssdk.BoolValue(output.EbsEncryptionByDefault), nil
+}
+
+type getEbsEncryptionByDefaultInput struct {
+	_ struct{} `type:"structure"`
+}
+
+type getEbsEncryptionByDefaultOutput struct {
+	_ struct{} `type:"structure"`
+
+	EbsEncryptionByDefault *bool `locationName:"ebsEncryptionByDefault" type:"boolean"`
+}
+
+// OrganizationsClient reads the AWS Organization of the account.
+// The vendored SDK does not ship the Organizations service, so only the
+// DescribeOrganization call is implemented here on top of the generic SDK client
+type OrganizationsClient struct {
+	*client.Client
+}
+
+func NewOrganizations(sess client.ConfigProvider) *OrganizationsClient {
+	c := sess.ClientConfig("organizations")
+	org := &OrganizationsClient{
+		Client: client.New(
+			*c.Config,
+			metadata.ClientInfo{
+				ServiceName:   "organizations",
+				SigningName:   c.SigningName,
+				SigningRegion: c.SigningRegion,
+				Endpoint:      c.Endpoint,
+				APIVersion:    "2016-11-28",
+				JSONVersion:   "1.1",
+				TargetPrefix:  "AWSOrganizationsV20161128",
+			},
+			c.Handlers,
+		),
+	}
+	org.Handlers.Sign.PushBackNamed(v4.SignRequestHandler)
+	org.Handlers.Build.PushBackNamed(jsonrpc.BuildHandler)
+	org.Handlers.Unmarshal.PushBackNamed(jsonrpc.UnmarshalHandler)
+	org.Handlers.UnmarshalMeta.PushBackNamed(jsonrpc.UnmarshalMetaHandler)
+	org.Handlers.UnmarshalError.PushBackNamed(jsonrpc.UnmarshalErrorHandler)
+
+	return org
+}
+
+// DescribeOrganization returns the organization of the account, or nil when the account is not member of any
+func (org *OrganizationsClient) DescribeOrganization() (*AccountOrganization, error) {
+	output := &describeOrganizationOutput{}
+	op := &request.Operation{Name: "DescribeOrganization", HTTPMethod: "POST", HTTPPath: "/"}
+	err := org.NewRequest(op, &describeOrganizationInput{}, output).Send()
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "AWSOrganizationsNotInUseException" {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("organizations: %w", err)
+	}
+	if output.Organization == nil {
+		return nil, nil
+	}
+	o := output.Organization
+	return &AccountOrganization{
+		ID:                 awssdk.StringValue(o.Id),
+		Arn:                awssdk.StringValue(o.Arn),
+		MasterAccountID:    awssdk.StringValue(o.MasterAccountId),
+		MasterAccountEmail: awssdk.StringValue(o.MasterAccountEmail),
+		FeatureSet:         awssdk.StringValue(o.FeatureSet),
+	}, nil
+}
+
+type describeOrganizationInput struct {
+	_ struct{} `type:"structure"`
+}
+
+type describeOrganizationOutput struct {
+	_ struct{} `type:"structure"`
+
+	Organization *organization `type:"structure"`
+}
+
+type organization struct {
+	_ struct{} `type:"structure"`
+
+	Arn                *string `type:"string"`
+	FeatureSet         *string `type:"string"`
+	Id                 *string `type:"string"`
+	MasterAccountArn   *string `type:"string"`
+	MasterAccountEmail *string `type:"string"`
+	MasterAccountId    *string `type:"string"`
+}
+
+// S3ControlClient reads the account-level S3 settings.
+// The vendored SDK does not ship the S3 Control service, so only the
+// GetPublicAccessBlock call is implemented here on top of the generic SDK client
+type S3ControlClient struct {
+	*client.Client
+}
+
+func NewS3Control(sess client.ConfigProvider) *S3ControlClient {
+	c := sess.ClientConfig("s3")
+	region := awssdk.StringValue(c.Config.Region)
+	s3c := &S3ControlClient{
+		Client: client.New(
+			*c.Config,
+			metadata.ClientInfo{
+				ServiceName:   "s3-control",
+				SigningName:   "s3",
+				SigningRegion: region,
+				Endpoint:      fmt.Sprintf("https://s3-control.%s.amazonaws.com", region),
+				APIVersion:    "2018-08-20",
+			},
+			c.Handlers,
+		),
+	}
+	s3c.Handlers.Sign.PushBackNamed(v4.SignRequestHandler)
+	s3c.Handlers.Build.PushBackNamed(restxml.BuildHandler)
+	s3c.Handlers.Unmarshal.PushBackNamed(restxml.UnmarshalHandler)
+	s3c.Handlers.UnmarshalMeta.PushBackNamed(restxml.UnmarshalMetaHandler)
+	s3c.Handlers.UnmarshalError.PushBackNamed(restxml.UnmarshalErrorHandler)
+
+	return s3c
+}
+
+// PublicAccessBlock returns the S3 block public access configuration of the account, or nil when none is set
+func (s3c *S3ControlClient) PublicAccessBlock(account string) (*S3PublicAccessBlock, error) {
+	output := &getPublicAccessBlockOutput{}
+	op := &request.Operation{Name: "GetPublicAccessBlock", HTTPMethod: "GET", HTTPPath: "/v20180820/configuration/publicAccessBlock"}
+	req := s3c.NewRequest(op, &getPublicAccessBlockInput{AccountId: awssdk.String(account)}, output)
+	req.HTTPRequest.URL.Host = account + "." + req.HTTPRequest.URL.Host
+	err := req.Send()
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "NoSuchPublicAccessBlockConfiguration" {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("s3 control: %w", err)
+	}
+	conf := output.PublicAccessBlockConfiguration
+	if conf == nil {
+		return nil, nil
+	}
+	return &S3PublicAccessBlock{
+		BlockPublicAcls:       awssdk.BoolValue(conf.BlockPublicAcls),
+		IgnorePublicAcls:      awssdk.BoolValue(conf.IgnorePublicAcls),
+		BlockPublicPolicy:     awssdk.BoolValue(conf.BlockPublicPolicy),
+		RestrictPublicBuckets: awssdk.BoolValue(conf.RestrictPublicBuckets),
+	}, nil
+}
+
+type getPublicAccessBlockInput struct {
+	_ struct{} `type:"structure"`
+
+	AccountId *string `location:"header" locationName:"x-amz-account-id" type:"string"`
+}
+
+type getPublicAccessBlockOutput struct {
+	_ struct{} `type:"structure" payload:"PublicAccessBlockConfiguration"`
+
+	PublicAccessBlockConfiguration *publicAccessBlockConfiguration `type:"structure"`
+}
+
+type publicAccessBlockConfiguration struct {
+	_ struct{} `type:"structure"`
+
+	BlockPublicAcls       *bool `locationName:"BlockPublicAcls" type:"boolean"`
+	BlockPublicPolicy     *bool `locationName:"BlockPublicPolicy" type:"boolean"`
+	IgnorePublicAcls      *bool `locationName:"IgnorePublicAcls" type:"boolean"`
+	RestrictPublicBuckets *bool `locationName:"RestrictPublicBuckets" type:"boolean"`
+}
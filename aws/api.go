@@ -26,15 +26,21 @@ import (
 
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ecr"
 	"github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/wallix/awless/aws/driver"
 	"github.com/wallix/awless/cloud"
 	"github.com/wallix/awless/cloud/properties"
 	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/logger"
 )
 
 func GetCloudServicesForAPIs(apis ...string) (services []cloud.Service) {
@@ -67,6 +73,31 @@ func GetCloudServicesForTypes(types ...string) (services []cloud.Service) {
 	return
 }
 
+// resourceGraph builds the graph of the resource of the given id among the fetched ones,
+// with the relations to its parents
+func resourceGraph(t, id string, fetched []interface{}) (*graph.Graph, error) {
+	g := graph.NewGraph()
+	for _, f := range fetched {
+		res, err := newResource(f)
+		if err != nil {
+			return g, err
+		}
+		if res.Id() != id {
+			continue
+		}
+		if err = g.AddResource(res); err != nil {
+			return g, err
+		}
+		for _, fn := range addParentsFns[t] {
+			if err = fn(g, f); err != nil {
+				return g, err
+			}
+		}
+		return g, nil
+	}
+	return g, fmt.Errorf("%s '%s' not found", t, id)
+}
+
 func ResourceTypesPerServiceName() map[string][]string {
 	out := make(map[string][]string)
 	for rT, s := range ServicePerResourceType {
@@ -80,15 +111,24 @@ type multiError struct {
 }
 
 func (m *multiError) add(err error) {
-	if err != nil {
-		m.errs = append(m.errs, err)
+	if err == nil {
+		return
+	}
+	if code, ok := isNonFatalFetchError(err); ok {
+		logger.Verbosef("sync: fetched as empty (%s): %s", code, err)
+		return
 	}
+	m.errs = append(m.errs, err)
 }
 
 func (m *multiError) hasAny() bool {
 	return len(m.errs) > 0
 }
 
+func (m *multiError) Unwrap() []error {
+	return m.errs
+}
+
 func (m *multiError) Error() string {
 	var all []string
 	for _, e := range m.errs {
@@ -595,6 +635,14 @@ func (s *Messaging) fetch_all_queue_graph() (*graph.Graph, []*string, error) {
 					}
 				case "QueueArn":
 					res.Properties[properties.Arn] = awssdk.StringValue(v)
+				case "Policy":
+					policy, err := normalizeResourcePolicy(awssdk.StringValue(v))
+					if err != nil {
+						errc <- err
+					}
+					if policy != "" {
+						res.Properties[properties.ResourcePolicy] = policy
+					}
 				case "DelaySeconds":
 					delay, err := strconv.Atoi(awssdk.StringValue(v))
 					if err != nil {
@@ -627,6 +675,98 @@ func (s *Messaging) fetch_all_queue_graph() (*graph.Graph, []*string, error) {
 
 }
 
+// elbv2TagsBatchSize is the maximum number of resources ARNs per ELBv2 DescribeTags call
+const elbv2TagsBatchSize = 20
+
+func (s *Infra) fetch_all_loadbalancer_graph() (*graph.Graph, []*elbv2.LoadBalancer, error) {
+	g := graph.NewGraph()
+	var cloudResources []*elbv2.LoadBalancer
+	var arns []*string
+	err := s.DescribeLoadBalancersPages(&elbv2.DescribeLoadBalancersInput{},
+		func(out *elbv2.DescribeLoadBalancersOutput, lastPage bool) (shouldContinue bool) {
+			for _, lb := range out.LoadBalancers {
+				cloudResources = append(cloudResources, lb)
+				arns = append(arns, lb.LoadBalancerArn)
+			}
+			return out.NextMarker != nil
+		})
+	if err != nil {
+		return g, cloudResources, err
+	}
+
+	tags := s.elbv2Tags(arns)
+	for _, lb := range cloudResources {
+		res, err := newResource(lb)
+		if err != nil {
+			return g, cloudResources, err
+		}
+		if t, ok := tags[awssdk.StringValue(lb.LoadBalancerArn)]; ok {
+			res.Properties[properties.Tags] = t
+		}
+		if err = g.AddResource(res); err != nil {
+			return g, cloudResources, err
+		}
+	}
+	return g, cloudResources, nil
+}
+
+func (s *Infra) fetch_all_targetgroup_graph() (*graph.Graph, []*elbv2.TargetGroup, error) {
+	g := graph.NewGraph()
+	out, err := s.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{})
+	if err != nil {
+		return g, nil, err
+	}
+
+	var arns []*string
+	for _, tg := range out.TargetGroups {
+		arns = append(arns, tg.TargetGroupArn)
+	}
+	tags := s.elbv2Tags(arns)
+	for _, tg := range out.TargetGroups {
+		res, err := newResource(tg)
+		if err != nil {
+			return g, out.TargetGroups, err
+		}
+		if t, ok := tags[awssdk.StringValue(tg.TargetGroupArn)]; ok {
+			res.Properties[properties.Tags] = t
+		}
+		if err = g.AddResource(res); err != nil {
+			return g, out.TargetGroups, err
+		}
+	}
+	return g, out.TargetGroups, nil
+}
+
+// elbv2Tags fetches the tags of the ELBv2 resources in batched calls rather than one call per resource.
+// Tags are not essential to the sync: on error, resources are synced without tags
+func (s *Infra) elbv2Tags(arns []*string) map[string][]string {
+	tags, err := fetchElbv2Tags(s.ELBV2API, arns, elbv2TagsBatchSize)
+	if err != nil {
+		s.log.Warningf("sync: cannot fetch load balancing resources tags: %s", err)
+	}
+	return tags
+}
+
+func fetchElbv2Tags(api elbv2iface.ELBV2API, arns []*string, batchSize int) (map[string][]string, error) {
+	tags := make(map[string][]string)
+	for _, batch := range sliceOfSlice(arns, batchSize) {
+		out, err := api.DescribeTags(&elbv2.DescribeTagsInput{ResourceArns: batch})
+		if err != nil {
+			return tags, err
+		}
+		for _, desc := range out.TagDescriptions {
+			var resTags []string
+			for _, t := range desc.Tags {
+				resTags = append(resTags, fmt.Sprintf("%s=%s", awssdk.StringValue(t.Key), awssdk.StringValue(t.Value)))
+			}
+			if len(resTags) > 0 {
+				tags[awssdk.StringValue(desc.ResourceArn)] = resTags
+			}
+		}
+	}
+	return tags, nil
+}
+
 func (s *Infra) fetch_all_listener_graph() (*graph.Graph, []*elbv2.Listener, error) {
 	g := graph.NewGraph()
 	errc := make(chan error)
@@ -685,6 +825,330 @@ func (s *Infra) fetch_all_listener_graph() (*graph.Graph, []*elbv2.Listener, err
 	}
 }
 
+// fetch_all_prefixlist_graph fetches the AWS-managed and customer-managed prefix lists with their entries
+func (s *Infra) fetch_all_prefixlist_graph() (*graph.Graph, []*awsdriver.ManagedPrefixList, error) {
+	g := graph.NewGraph()
+	var cloudResources []*awsdriver.ManagedPrefixList
+
+	api, ok := awsdriver.ManagedPrefixLists(s.EC2API)
+	if !ok {
+		return g, cloudResources, nil
+	}
+
+	input := &awsdriver.DescribeManagedPrefixListsInput{}
+	for {
+		out, err := api.DescribeManagedPrefixLists(input)
+		if err != nil {
+			return g, cloudResources, err
+		}
+		cloudResources = append(cloudResources, out.PrefixLists...)
+		if awssdk.StringValue(out.NextToken) == "" {
+			break
+		}
+		input.NextToken = out.NextToken
+	}
+
+	for _, pl := range cloudResources {
+		entriesInput := &awsdriver.GetManagedPrefixListEntriesInput{PrefixListId: pl.PrefixListId}
+		for {
+			out, err := api.GetManagedPrefixListEntries(entriesInput)
+			if err != nil {
+				return g, cloudResources, err
+			}
+			pl.Entries = append(pl.Entries, out.Entries...)
+			if awssdk.StringValue(out.NextToken) == "" {
+				break
+			}
+			entriesInput.NextToken = out.NextToken
+		}
+		res, err := newResource(pl)
+		if err != nil {
+			return g, cloudResources, err
+		}
+		if err = g.AddResource(res); err != nil {
+			return g, cloudResources, err
+		}
+	}
+	return g, cloudResources, nil
+}
+
+func (s *Infra) fetch_all_fleet_graph() (*graph.Graph, []*awsdriver.Fleet, error) {
+	g := graph.NewGraph()
+	var cloudResources []*awsdriver.Fleet
+
+	api, ok := awsdriver.Fleets(s.EC2API)
+	if !ok {
+		return g, cloudResources, nil
+	}
+
+	sfrInput := &ec2.DescribeSpotFleetRequestsInput{}
+	for {
+		out, err := api.DescribeSpotFleetRequests(sfrInput)
+		if err != nil {
+			return g, cloudResources, err
+		}
+		for _, sfr := range out.SpotFleetRequestConfigs {
+			instances, err := spotFleetInstances(api, sfr.SpotFleetRequestId)
+			if err != nil {
+				return g, cloudResources, err
+			}
+			cloudResources = append(cloudResources, awsdriver.NewSpotFleet(sfr, instances))
+		}
+		if awssdk.StringValue(out.NextToken) == "" {
+			break
+		}
+		sfrInput.NextToken = out.NextToken
+	}
+
+	fleetInput := &awsdriver.DescribeFleetsInput{}
+	for {
+		out, err := api.DescribeFleets(fleetInput)
+		if err != nil {
+			return g, cloudResources, err
+		}
+		for _, fleet := range out.Fleets {
+			instances, err := ec2FleetInstances(api, fleet.FleetId)
+			if err != nil {
+				return g, cloudResources, err
+			}
+			cloudResources = append(cloudResources, awsdriver.NewEC2Fleet(fleet, instances))
+		}
+		if awssdk.StringValue(out.NextToken) == "" {
+			break
+		}
+		fleetInput.NextToken = out.NextToken
+	}
+
+	for _, fleet := range cloudResources {
+		res, err := newResource(fleet)
+		if err != nil {
+			return g, cloudResources, err
+		}
+		if err = g.AddResource(res); err != nil {
+			return g, cloudResources, err
+		}
+	}
+	return g, cloudResources, nil
+}
+
+// guardDutyFindingsBatch is the maximum number of findings fetched per GetFindings call
+const guardDutyFindingsBatch = 50
+
+// fetch_all_finding_graph fetches the active (not archived) findings of the GuardDuty detectors of the region.
+// Regions without GuardDuty detector have no finding
+func (s *Infra) fetch_all_finding_graph() (*graph.Graph, []*GuardDutyFinding, error) {
+	g := graph.NewGraph()
+	var cloudResources []*GuardDutyFinding
+
+	api, ok := guardDuty(s.EC2API)
+	if !ok {
+		return g, cloudResources, nil
+	}
+
+	var detectors []*string
+	detectorsInput := &listDetectorsInput{}
+	for {
+		out, err := api.ListDetectors(detectorsInput)
+		if err != nil {
+			return g, cloudResources, err
+		}
+		detectors = append(detectors, out.DetectorIds...)
+		if awssdk.StringValue(out.NextToken) == "" {
+			break
+		}
+		detectorsInput.NextToken = out.NextToken
+	}
+	if len(detectors) == 0 {
+		s.log.Verbosef("guardduty not enabled in region %s: no finding to fetch", s.region)
+		return g, cloudResources, nil
+	}
+
+	for _, detector := range detectors {
+		var ids []*string
+		input := &listFindingsInput{
+			DetectorId:      detector,
+			FindingCriteria: &guardDutyFindingCriteria{Criterion: map[string]*guardDutyCondition{"service.archived": {Eq: []*string{awssdk.String("false")}}}},
+			MaxResults:      awssdk.Int64(guardDutyFindingsBatch),
+		}
+		for {
+			out, err := api.ListFindings(input)
+			if err != nil {
+				return g, cloudResources, err
+			}
+			ids = append(ids, out.FindingIds...)
+			if awssdk.StringValue(out.NextToken) == "" {
+				break
+			}
+			input.NextToken = out.NextToken
+		}
+		for i := 0; i < len(ids); i += guardDutyFindingsBatch {
+			end := i + guardDutyFindingsBatch
+			if end > len(ids) {
+				end = len(ids)
+			}
+			out, err := api.GetFindings(&getFindingsInput{DetectorId: detector, FindingIds: ids[i:end]})
+			if err != nil {
+				return g, cloudResources, err
+			}
+			cloudResources = append(cloudResources, out.Findings...)
+		}
+	}
+
+	for _, f := range cloudResources {
+		res, err := newResource(f)
+		if err != nil {
+			return g, cloudResources, err
+		}
+		if err = g.AddResource(res); err != nil {
+			return g, cloudResources, err
+		}
+	}
+
+	return g, cloudResources, nil
+}
+
+// fetch_all_stream_graph fetches the Kinesis data streams of the region with the Lambda functions
+// consuming them through event source mappings
+func (s *Lambda) fetch_all_stream_graph() (*graph.Graph, []*awsdriver.Stream, error) {
+	g := graph.NewGraph()
+	var cloudResources []*awsdriver.Stream
+
+	api, ok := awsdriver.Streams(s.LambdaAPI)
+	if !ok {
+		return g, cloudResources, nil
+	}
+
+	var names []*string
+	input := &awsdriver.ListStreamsInput{}
+	for {
+		out, err := api.ListStreams(input)
+		if err != nil {
+			return g, cloudResources, err
+		}
+		names = append(names, out.StreamNames...)
+		if !awssdk.BoolValue(out.HasMoreStreams) || len(out.StreamNames) == 0 {
+			break
+		}
+		input.ExclusiveStartStreamName = out.StreamNames[len(out.StreamNames)-1]
+	}
+	if len(names) == 0 {
+		return g, cloudResources, nil
+	}
+
+	consumers := make(map[string][]*string)
+	err := s.ListEventSourceMappingsPages(&lambda.ListEventSourceMappingsInput{}, func(out *lambda.ListEventSourceMappingsOutput, lastPage bool) bool {
+		for _, m := range out.EventSourceMappings {
+			source := awssdk.StringValue(m.EventSourceArn)
+			consumers[source] = append(consumers[source], m.FunctionArn)
+		}
+		return out.NextMarker != nil
+	})
+	if err != nil {
+		return g, cloudResources, err
+	}
+
+	for _, name := range names {
+		out, err := api.DescribeStreamSummary(&awsdriver.DescribeStreamSummaryInput{StreamName: name})
+		if err != nil {
+			return g, cloudResources, err
+		}
+		summary := out.StreamDescriptionSummary
+		cloudResources = append(cloudResources, awsdriver.NewStream(summary, consumers[awssdk.StringValue(summary.StreamARN)]))
+	}
+
+	for _, stream := range cloudResources {
+		res, err := newResource(stream)
+		if err != nil {
+			return g, cloudResources, err
+		}
+		if err = g.AddResource(res); err != nil {
+			return g, cloudResources, err
+		}
+	}
+	return g, cloudResources, nil
+}
+
+// fetch_all_deliverystream_graph fetches the Firehose delivery streams of the region
+// with their source Kinesis stream and their destination
+func (s *Lambda) fetch_all_deliverystream_graph() (*graph.Graph, []*awsdriver.DeliveryStream, error) {
+	g := graph.NewGraph()
+	var cloudResources []*awsdriver.DeliveryStream
+
+	api, ok := awsdriver.Streams(s.LambdaAPI)
+	if !ok {
+		return g, cloudResources, nil
+	}
+
+	var names []*string
+	input := &awsdriver.ListDeliveryStreamsInput{}
+	for {
+		out, err := api.ListDeliveryStreams(input)
+		if err != nil {
+			return g, cloudResources, err
+		}
+		names = append(names, out.DeliveryStreamNames...)
+		if !awssdk.BoolValue(out.HasMoreDeliveryStreams) || len(out.DeliveryStreamNames) == 0 {
+			break
+		}
+		input.ExclusiveStartDeliveryStreamName = out.DeliveryStreamNames[len(out.DeliveryStreamNames)-1]
+	}
+
+	for _, name := range names {
+		out, err := api.DescribeDeliveryStream(&awsdriver.DescribeDeliveryStreamInput{DeliveryStreamName: name})
+		if err != nil {
+			return g, cloudResources, err
+		}
+		cloudResources = append(cloudResources, awsdriver.NewDeliveryStream(out.DeliveryStreamDescription))
+	}
+
+	for _, stream := range cloudResources {
+		res, err := newResource(stream)
+		if err != nil {
+			return g, cloudResources, err
+		}
+		if err = g.AddResource(res); err != nil {
+			return g, cloudResources, err
+		}
+	}
+	return g, cloudResources, nil
+}
+
+func spotFleetInstances(api awsdriver.FleetsAPI, id *string) ([]*string, error) {
+	var instances []*string
+	input := &ec2.DescribeSpotFleetInstancesInput{SpotFleetRequestId: id}
+	for {
+		out, err := api.DescribeSpotFleetInstances(input)
+		if err != nil {
+			return instances, err
+		}
+		for _, inst := range out.ActiveInstances {
+			instances = append(instances, inst.InstanceId)
+		}
+		if awssdk.StringValue(out.NextToken) == "" {
+			return instances, nil
+		}
+		input.NextToken = out.NextToken
+	}
+}
+
+func ec2FleetInstances(api awsdriver.FleetsAPI, id *string) ([]*string, error) {
+	var instances []*string
+	input := &awsdriver.DescribeFleetInstancesInput{FleetId: id}
+	for {
+		out, err := api.DescribeFleetInstances(input)
+		if err != nil {
+			return instances, err
+		}
+		for _, inst := range out.ActiveInstances {
+			instances = append(instances, inst.InstanceId)
+		}
+		if awssdk.StringValue(out.NextToken) == "" {
+			return instances, nil
+		}
+		input.NextToken = out.NextToken
+	}
+}
+
 func (s *Dns) fetch_all_record_graph() (*graph.Graph, []*route53.ResourceRecordSet, error) {
 	g := graph.NewGraph()
 	var cloudResources []*route53.ResourceRecordSet
@@ -768,6 +1232,51 @@ func (s *Infra) getClustersNames() (res []*string, err error) {
 	return
 }
 
+func (s *Infra) fetch_all_containerimage_graph() (*graph.Graph, []*awsdriver.ContainerImage, error) {
+	g := graph.NewGraph()
+	var cloudResources []*awsdriver.ContainerImage
+
+	api, ok := awsdriver.ContainerRegistry(s.ECRAPI)
+	if !ok {
+		return g, cloudResources, nil
+	}
+
+	var repositories []*ecr.Repository
+	err := s.DescribeRepositoriesPages(&ecr.DescribeRepositoriesInput{}, func(out *ecr.DescribeRepositoriesOutput, lastPage bool) bool {
+		repositories = append(repositories, out.Repositories...)
+		return out.NextToken != nil
+	})
+	if err != nil {
+		return g, cloudResources, err
+	}
+
+	for _, repo := range repositories {
+		input := &awsdriver.DescribeContainerImagesInput{RepositoryName: repo.RepositoryName, RegistryId: repo.RegistryId}
+		for {
+			out, err := api.DescribeContainerImages(input)
+			if err != nil {
+				return g, cloudResources, err
+			}
+			for _, img := range out.ImageDetails {
+				img.RepositoryArn = repo.RepositoryArn
+				res, err := newResource(img)
+				if err != nil {
+					return g, cloudResources, err
+				}
+				if err = g.AddResource(res); err != nil {
+					return g, cloudResources, err
+				}
+				cloudResources = append(cloudResources, img)
+			}
+			if awssdk.StringValue(out.NextToken) == "" {
+				break
+			}
+			input.NextToken = out.NextToken
+		}
+	}
+	return g, cloudResources, nil
+}
+
 func (s *Infra) fetch_all_containercluster_graph() (*graph.Graph, []*ecs.Cluster, error) {
 	s.once.Do(func() {
 		s.once.result, s.once.err = s.getClustersNames()
@@ -18,6 +18,7 @@ package aws
 
 import (
 	"fmt"
+	"net"
 	"reflect"
 	"sort"
 	"testing"
@@ -37,10 +38,12 @@ import (
 	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/wallix/awless/aws/driver"
 	"github.com/wallix/awless/cloud"
 	p "github.com/wallix/awless/cloud/properties"
 	"github.com/wallix/awless/graph"
 	"github.com/wallix/awless/graph/resourcetest"
+	"github.com/wallix/awless/logger"
 )
 
 func TestBuildAccessRdfGraph(t *testing.T) {
@@ -177,29 +180,35 @@ func TestBuildAccessRdfGraph(t *testing.T) {
 		if p, ok := res.Properties[p.InlinePolicies].([]string); ok {
 			sort.Strings(p)
 		}
+		if p, ok := res.Properties[p.ManagedPolicies].([]string); ok {
+			sort.Strings(p)
+		}
+		if p, ok := res.Properties[p.Groups].([]string); ok {
+			sort.Strings(p)
+		}
 	}
 
 	expected := map[string]*graph.Resource{
 		"managed_policy_1": resourcetest.Policy("managed_policy_1").Prop(p.Name, "nmanaged_policy_1").Build(),
 		"managed_policy_2": resourcetest.Policy("managed_policy_2").Prop(p.Name, "nmanaged_policy_2").Build(),
 		"managed_policy_3": resourcetest.Policy("managed_policy_3").Prop(p.Name, "nmanaged_policy_3").Build(),
-		"group_1":          resourcetest.Group("group_1").Prop(p.Name, "ngroup_1").Prop(p.InlinePolicies, []string{"npolicy_1"}).Build(),
-		"group_2":          resourcetest.Group("group_2").Prop(p.Name, "ngroup_2").Prop(p.InlinePolicies, []string{"npolicy_1"}).Build(),
-		"group_3":          resourcetest.Group("group_3").Prop(p.Name, "ngroup_3").Prop(p.InlinePolicies, []string{"npolicy_2"}).Build(),
+		"group_1":          resourcetest.Group("group_1").Prop(p.Name, "ngroup_1").Prop(p.InlinePolicies, []string{"npolicy_1"}).Prop(p.ManagedPolicies, []string{"nmanaged_policy_1"}).Build(),
+		"group_2":          resourcetest.Group("group_2").Prop(p.Name, "ngroup_2").Prop(p.InlinePolicies, []string{"npolicy_1"}).Prop(p.ManagedPolicies, []string{"nmanaged_policy_2"}).Build(),
+		"group_3":          resourcetest.Group("group_3").Prop(p.Name, "ngroup_3").Prop(p.InlinePolicies, []string{"npolicy_2"}).Prop(p.ManagedPolicies, []string{"nmanaged_policy_3"}).Build(),
 		"group_4":          resourcetest.Group("group_4").Prop(p.Name, "ngroup_4").Prop(p.InlinePolicies, []string{"npolicy_4"}).Build(),
-		"role_1":           resourcetest.Role("role_1").Prop(p.InlinePolicies, []string{"npolicy_1"}).Build(),
+		"role_1":           resourcetest.Role("role_1").Prop(p.InlinePolicies, []string{"npolicy_1"}).Prop(p.ManagedPolicies, []string{"nmanaged_policy_1"}).Build(),
 		"role_2":           resourcetest.Role("role_2").Prop(p.InlinePolicies, []string{"npolicy_1"}).Build(),
-		"role_3":           resourcetest.Role("role_3").Prop(p.InlinePolicies, []string{"npolicy_2"}).Build(),
+		"role_3":           resourcetest.Role("role_3").Prop(p.InlinePolicies, []string{"npolicy_2"}).Prop(p.ManagedPolicies, []string{"nmanaged_policy_2"}).Build(),
 		"role_4":           resourcetest.Role("role_4").Prop(p.InlinePolicies, []string{"npolicy_4"}).Build(),
-		"usr_1":            resourcetest.User("usr_1").Prop(p.InlinePolicies, []string{"npolicy_1", "npolicy_2"}).Prop(p.PasswordLastUsed, time.Unix(1486139077, 0).UTC()).Build(),
-		"usr_2":            resourcetest.User("usr_2").Prop(p.InlinePolicies, []string{"npolicy_1"}).Build(),
-		"usr_3":            resourcetest.User("usr_3").Prop(p.InlinePolicies, []string{"npolicy_1", "npolicy_4"}).Build(),
-		"usr_4":            resourcetest.User("usr_4").Prop(p.InlinePolicies, []string{"npolicy_2"}).Build(),
-		"usr_5":            resourcetest.User("usr_5").Prop(p.InlinePolicies, []string{"npolicy_2"}).Build(),
-		"usr_6":            resourcetest.User("usr_6").Prop(p.InlinePolicies, []string{"npolicy_2"}).Build(),
-		"usr_7":            resourcetest.User("usr_7").Prop(p.InlinePolicies, []string{"npolicy_2", "npolicy_4"}).Build(),
-		"usr_8":            resourcetest.User("usr_8").Prop(p.InlinePolicies, []string{"npolicy_4"}).Build(),
-		"usr_9":            resourcetest.User("usr_9").Prop(p.InlinePolicies, []string{"npolicy_4"}).Build(),
+		"usr_1":            resourcetest.User("usr_1").Prop(p.InlinePolicies, []string{"npolicy_1", "npolicy_2"}).Prop(p.PasswordLastUsed, time.Unix(1486139077, 0).UTC()).Prop(p.Groups, []string{"ngroup_1", "ngroup_2"}).Prop(p.ManagedPolicies, []string{"nmanaged_policy_1"}).Build(),
+		"usr_2":            resourcetest.User("usr_2").Prop(p.InlinePolicies, []string{"npolicy_1"}).Prop(p.Groups, []string{"ngroup_1"}).Build(),
+		"usr_3":            resourcetest.User("usr_3").Prop(p.InlinePolicies, []string{"npolicy_1", "npolicy_4"}).Prop(p.Groups, []string{"ngroup_1", "ngroup_4"}).Prop(p.ManagedPolicies, []string{"nmanaged_policy_1", "nmanaged_policy_2"}).Build(),
+		"usr_4":            resourcetest.User("usr_4").Prop(p.InlinePolicies, []string{"npolicy_2"}).Prop(p.Groups, []string{"ngroup_2"}).Build(),
+		"usr_5":            resourcetest.User("usr_5").Prop(p.InlinePolicies, []string{"npolicy_2"}).Prop(p.Groups, []string{"ngroup_2"}).Build(),
+		"usr_6":            resourcetest.User("usr_6").Prop(p.InlinePolicies, []string{"npolicy_2"}).Prop(p.Groups, []string{"ngroup_2"}).Prop(p.ManagedPolicies, []string{"nmanaged_policy_3"}).Build(),
+		"usr_7":            resourcetest.User("usr_7").Prop(p.InlinePolicies, []string{"npolicy_2", "npolicy_4"}).Prop(p.Groups, []string{"ngroup_2", "ngroup_4"}).Build(),
+		"usr_8":            resourcetest.User("usr_8").Prop(p.InlinePolicies, []string{"npolicy_4"}).Prop(p.Groups, []string{"ngroup_4"}).Build(),
+		"usr_9":            resourcetest.User("usr_9").Prop(p.InlinePolicies, []string{"npolicy_4"}).Prop(p.Groups, []string{"ngroup_4"}).Build(),
 		"usr_10":           resourcetest.User("usr_10").Build(),
 		"usr_11":           resourcetest.User("usr_11").Build(),
 	}
@@ -224,7 +233,7 @@ func TestBuildInfraRdfGraph(t *testing.T) {
 		{InstanceId: awssdk.String("inst_1"), SubnetId: awssdk.String("sub_1"), VpcId: awssdk.String("vpc_1"), Tags: []*ec2.Tag{{Key: awssdk.String("Name"), Value: awssdk.String("instance1-name")}}},
 		{InstanceId: awssdk.String("inst_2"), SubnetId: awssdk.String("sub_2"), VpcId: awssdk.String("vpc_1"), SecurityGroups: []*ec2.GroupIdentifier{{GroupId: awssdk.String("securitygroup_1")}}},
 		{InstanceId: awssdk.String("inst_3"), SubnetId: awssdk.String("sub_3"), VpcId: awssdk.String("vpc_2")},
-		{InstanceId: awssdk.String("inst_4"), SubnetId: awssdk.String("sub_3"), VpcId: awssdk.String("vpc_2"), SecurityGroups: []*ec2.GroupIdentifier{{GroupId: awssdk.String("securitygroup_1")}, {GroupId: awssdk.String("securitygroup_2")}}, KeyName: awssdk.String("my_key")},
+		{InstanceId: awssdk.String("inst_4"), SubnetId: awssdk.String("sub_3"), VpcId: awssdk.String("vpc_2"), SecurityGroups: []*ec2.GroupIdentifier{{GroupId: awssdk.String("securitygroup_1")}, {GroupId: awssdk.String("securitygroup_2")}}, KeyName: awssdk.String("my_key"), ImageId: awssdk.String("img_2")},
 		{InstanceId: awssdk.String("inst_5"), SubnetId: nil, VpcId: nil, KeyName: awssdk.String("unexisting_key")}, // terminated instance (no vpc, subnet ids)
 		{
 			InstanceId:         awssdk.String("inst_6"),
@@ -252,17 +261,26 @@ func TestBuildInfraRdfGraph(t *testing.T) {
 	}
 
 	vpcs := []*ec2.Vpc{
-		{VpcId: awssdk.String("vpc_1")},
-		{VpcId: awssdk.String("vpc_2")},
+		{VpcId: awssdk.String("vpc_1"), DhcpOptionsId: awssdk.String("dopt_1")},
+		{VpcId: awssdk.String("vpc_2"), DhcpOptionsId: awssdk.String("default")},
+	}
+
+	dhcpOptions := []*ec2.DhcpOptions{
+		{DhcpOptionsId: awssdk.String("dopt_1"), DhcpConfigurations: []*ec2.DhcpConfiguration{
+			{Key: awssdk.String("domain-name"), Values: []*ec2.AttributeValue{{Value: awssdk.String("corp.local")}}},
+			{Key: awssdk.String("domain-name-servers"), Values: []*ec2.AttributeValue{{Value: awssdk.String("10.0.0.2")}}},
+		}},
 	}
 
 	securityGroups := []*ec2.SecurityGroup{
 		{GroupId: awssdk.String("securitygroup_1"), GroupName: awssdk.String("my_securitygroup"), VpcId: awssdk.String("vpc_1")},
-		{GroupId: awssdk.String("securitygroup_2"), VpcId: awssdk.String("vpc_1")},
+		{GroupId: awssdk.String("securitygroup_2"), VpcId: awssdk.String("vpc_1"),
+			IpPermissionsEgress: []*ec2.IpPermission{{IpProtocol: awssdk.String("tcp"), FromPort: awssdk.Int64(443), ToPort: awssdk.Int64(443), PrefixListIds: []*ec2.PrefixListId{{PrefixListId: awssdk.String("pl_1")}}}},
+		},
 	}
 
 	subnets := []*ec2.Subnet{
-		{SubnetId: awssdk.String("sub_1"), VpcId: awssdk.String("vpc_1")},
+		{SubnetId: awssdk.String("sub_1"), VpcId: awssdk.String("vpc_1"), AvailableIpAddressCount: awssdk.Int64(250)},
 		{SubnetId: awssdk.String("sub_2"), VpcId: awssdk.String("vpc_1")},
 		{SubnetId: awssdk.String("sub_3"), VpcId: awssdk.String("vpc_2")},
 		{SubnetId: awssdk.String("sub_4"), VpcId: nil}, // edge case subnet with no vpc id
@@ -281,12 +299,48 @@ func TestBuildInfraRdfGraph(t *testing.T) {
 	}
 
 	routeTables := []*ec2.RouteTable{
-		{RouteTableId: awssdk.String("rt_1"), VpcId: awssdk.String("vpc_1"), Associations: []*ec2.RouteTableAssociation{{RouteTableId: awssdk.String("rt_1"), SubnetId: awssdk.String("sub_1")}}},
+		{RouteTableId: awssdk.String("rt_1"), VpcId: awssdk.String("vpc_1"), Associations: []*ec2.RouteTableAssociation{{RouteTableId: awssdk.String("rt_1"), SubnetId: awssdk.String("sub_1")}},
+			Routes: []*ec2.Route{{DestinationPrefixListId: awssdk.String("pl_2"), GatewayId: awssdk.String("vgw_1")}},
+		},
+	}
+
+	prefixLists := []*awsdriver.ManagedPrefixList{
+		{PrefixListId: awssdk.String("pl_1"), PrefixListName: awssdk.String("corp-vpn"), OwnerId: awssdk.String("123456789012"), AddressFamily: awssdk.String("IPv4"), State: awssdk.String("create-complete"), MaxEntries: awssdk.Int64(10)},
+		{PrefixListId: awssdk.String("pl_2"), PrefixListName: awssdk.String("com.amazonaws.us-west-1.s3"), OwnerId: awssdk.String("AWS"), AddressFamily: awssdk.String("IPv4"), State: awssdk.String("create-complete")},
+	}
+	prefixListEntries := map[string][]*awsdriver.PrefixListEntry{
+		"pl_1": {{Cidr: awssdk.String("10.10.0.0/16"), Description: awssdk.String("office")}},
+		"pl_2": {{Cidr: awssdk.String("52.92.16.0/20")}},
+	}
+
+	placementGroups := []*ec2.PlacementGroup{
+		{GroupName: awssdk.String("inst_group"), State: awssdk.String("available"), Strategy: awssdk.String("cluster")},
+	}
+
+	hosts := []*ec2.Host{
+		{HostId: awssdk.String("inst_host"), AvailabilityZone: awssdk.String("us-west-1a"), State: awssdk.String("available"),
+			HostProperties:    &ec2.HostProperties{InstanceType: awssdk.String("c4.large"), Cores: awssdk.Int64(20), Sockets: awssdk.Int64(2)},
+			AvailableCapacity: &ec2.AvailableCapacity{AvailableInstanceCapacity: []*ec2.InstanceCapacity{{InstanceType: awssdk.String("c4.large"), TotalCapacity: awssdk.Int64(4), AvailableCapacity: awssdk.Int64(3)}}},
+			Instances:         []*ec2.HostInstance{{InstanceId: awssdk.String("inst_6"), InstanceType: awssdk.String("c4.large")}},
+		},
+	}
+
+	_, vpcCidr, _ := net.ParseCIDR("10.0.0.0/16")
+	_, anyCidr, _ := net.ParseCIDR("0.0.0.0/0")
+	networkAcls := []*ec2.NetworkAcl{
+		{NetworkAclId: awssdk.String("acl_1"), VpcId: awssdk.String("vpc_1"), IsDefault: awssdk.Bool(true),
+			Associations: []*ec2.NetworkAclAssociation{{NetworkAclId: awssdk.String("acl_1"), SubnetId: awssdk.String("sub_2")}},
+			Entries: []*ec2.NetworkAclEntry{
+				{RuleNumber: awssdk.Int64(32767), RuleAction: awssdk.String("deny"), Protocol: awssdk.String("-1"), Egress: awssdk.Bool(true), CidrBlock: awssdk.String("0.0.0.0/0")},
+				{RuleNumber: awssdk.Int64(100), RuleAction: awssdk.String("allow"), Protocol: awssdk.String("6"), Egress: awssdk.Bool(false), CidrBlock: awssdk.String("10.0.0.0/16"), PortRange: &ec2.PortRange{From: awssdk.Int64(22), To: awssdk.Int64(22)}},
+			},
+		},
 	}
 
 	images := []*ec2.Image{
 		{ImageId: awssdk.String("img_1")},
-		{ImageId: awssdk.String("img_2"), Name: awssdk.String("img_2_name"), Architecture: awssdk.String("img_2_arch"), Hypervisor: awssdk.String("img_2_hyper"), CreationDate: awssdk.String("2010-04-01T12:05:01.000Z")},
+		{ImageId: awssdk.String("img_2"), Name: awssdk.String("img_2_name"), Architecture: awssdk.String("img_2_arch"), Hypervisor: awssdk.String("img_2_hyper"), CreationDate: awssdk.String("2010-04-01T12:05:01.000Z"),
+			BlockDeviceMappings: []*ec2.BlockDeviceMapping{{Ebs: &ec2.EbsBlockDevice{SnapshotId: awssdk.String("snap_1")}}, {VirtualName: awssdk.String("ephemeral0")}}},
 	}
 
 	availabilityZones := []*ec2.AvailabilityZone{
@@ -303,6 +357,10 @@ func TestBuildInfraRdfGraph(t *testing.T) {
 		{TargetGroupArn: awssdk.String("tg_1"), VpcId: awssdk.String("vpc_1"), LoadBalancerArns: []*string{awssdk.String("lb_1"), awssdk.String("lb_3")}},
 		{TargetGroupArn: awssdk.String("tg_2"), VpcId: awssdk.String("vpc_2"), LoadBalancerArns: []*string{awssdk.String("lb_2")}},
 	}
+	lbTags := []*elbv2.TagDescription{
+		{ResourceArn: awssdk.String("lb_1"), Tags: []*elbv2.Tag{{Key: awssdk.String("Env"), Value: awssdk.String("Production")}}},
+		{ResourceArn: awssdk.String("tg_2"), Tags: []*elbv2.Tag{{Key: awssdk.String("Env"), Value: awssdk.String("Test")}}},
+	}
 	listeners := []*elbv2.Listener{
 		{ListenerArn: awssdk.String("list_1"), LoadBalancerArn: awssdk.String("lb_1")}, {ListenerArn: awssdk.String("list_1.2"), LoadBalancerArn: awssdk.String("lb_1")},
 		{ListenerArn: awssdk.String("list_2"), LoadBalancerArn: awssdk.String("lb_2")},
@@ -460,16 +518,39 @@ func TestBuildInfraRdfGraph(t *testing.T) {
 		},
 	}
 
-	mock := &mockEc2{vpcs: vpcs, securitygroups: securityGroups, subnets: subnets, instances: instances, keypairinfos: keypairs, internetgateways: igws, routetables: routeTables, images: images, availabilityzones: availabilityZones, natgateways: natgws}
-	mockLb := &mockElbv2{loadbalancers: lbPages, targetgroups: targetGroups, listeners: listeners, targethealthdescriptions: targetHealths}
-	mockEcr := &mockEcr{repositorys: repositories}
+	mock := &mockEc2{vpcs: vpcs, securitygroups: securityGroups, subnets: subnets, instances: instances, keypairinfos: keypairs, internetgateways: igws, routetables: routeTables, dhcpoptionss: dhcpOptions, networkacls: networkAcls, placementgroups: placementGroups, hosts: hosts, images: images, availabilityzones: availabilityZones, natgateways: natgws}
+	mockLb := &mockElbv2{loadbalancers: lbPages, targetgroups: targetGroups, listeners: listeners, targethealthdescriptions: targetHealths, tagdescriptions: lbTags}
+	containerImages := map[string][]*awsdriver.ContainerImage{
+		"repo_name_1": {
+			{ImageDigest: awssdk.String("sha256:aaa"), RepositoryName: awssdk.String("repo_name_1"), RegistryId: awssdk.String("account_id"), ImageTags: []*string{awssdk.String("latest"), awssdk.String("v2")},
+				ImagePushedAt: awssdk.Time(now), ImageSizeInBytes: awssdk.Int64(2048), ImageScanStatus: &awsdriver.ImageScanStatus{Status: awssdk.String("COMPLETE")},
+				ImageScanFindingsSummary: &awsdriver.ImageScanFindingsSummary{FindingSeverityCounts: map[string]*int64{"HIGH": awssdk.Int64(3), "CRITICAL": awssdk.Int64(1)}}},
+			{ImageDigest: awssdk.String("sha256:bbb"), RepositoryName: awssdk.String("repo_name_1")},
+		},
+	}
+	mockEcr := &mockEcrRegistry{
+		mockEcr:    &mockEcr{repositorys: repositories},
+		scanOnPush: map[string]bool{"repo_name_1": true},
+		lifecycles: map[string]string{"repo_name_1": `{"rules": [{"rulePriority": 1, "selection": {"tagStatus": "untagged", "countType": "sinceImagePushed", "countUnit": "days", "countNumber": 14}, "action": {"type": "expire"}}]}`},
+		images:     containerImages,
+	}
 	mockEcs := &mockEcs{clusterNames: clusterNames, clusters: clusters, taskdefinitionNames: defNames, taskdefinitions: tasksDef, tasksNames: tasksNames, tasks: tasks, containerinstancesNames: containerInstancesNames, containerinstances: containerInstances}
-	InfraService = &Infra{EC2API: mock, ECRAPI: mockEcr, ECSAPI: mockEcs, ELBV2API: mockLb, RDSAPI: &mockRds{}, AutoScalingAPI: &mockAutoscaling{launchconfigurations: launchConfigs, groups: scalingGroups}, region: "eu-west-1"}
+	spotFleets := []*ec2.SpotFleetRequestConfig{
+		{SpotFleetRequestId: awssdk.String("sfr_1"), SpotFleetRequestState: awssdk.String("active"), ActivityStatus: awssdk.String("fulfilled"), CreateTime: &now,
+			SpotFleetRequestConfig: &ec2.SpotFleetRequestConfigData{TargetCapacity: awssdk.Int64(2), FulfilledCapacity: awssdk.Float64(2), AllocationStrategy: awssdk.String("lowestPrice"), Type: awssdk.String("maintain"), SpotPrice: awssdk.String("0.05"), IamFleetRole: awssdk.String("arn:fleet:role")}},
+	}
+	ec2Fleets := []*awsdriver.FleetData{
+		{FleetId: awssdk.String("fleet_1"), FleetState: awssdk.String("active"), Type: awssdk.String("maintain"), SpotOptions: &awsdriver.SpotOptions{AllocationStrategy: awssdk.String("diversified")},
+			TargetCapacitySpecification: &awsdriver.TargetCapacitySpecification{TotalTargetCapacity: awssdk.Int64(3), OnDemandTargetCapacity: awssdk.Int64(1), SpotTargetCapacity: awssdk.Int64(2)}},
+	}
+	fleetInstances := map[string][]string{"sfr_1": {"inst_1", "inst_3"}, "fleet_1": {"inst_4"}}
+	mockFleets := &mockEc2Fleets{mockEc2PrefixLists: &mockEc2PrefixLists{mockEc2: mock, prefixlists: prefixLists, entries: prefixListEntries}, spotfleets: spotFleets, fleets: ec2Fleets, instances: fleetInstances}
+	InfraService = &Infra{EC2API: mockFleets, ECRAPI: mockEcr, ECSAPI: mockEcs, ELBV2API: mockLb, RDSAPI: &mockRds{}, AutoScalingAPI: &mockAutoscaling{launchconfigurations: launchConfigs, groups: scalingGroups}, region: "eu-west-1"}
 	g, err := InfraService.FetchResources()
 	if err != nil {
 		t.Fatal(err)
 	}
-	resources, err := g.GetAllResources("region", "instance", "vpc", "securitygroup", "subnet", "keypair", "internetgateway", cloud.NatGateway, "routetable", "loadbalancer", "targetgroup", "listener", "launchconfiguration", "scalinggroup", "image", "availabilityzone", "repository", cloud.ContainerCluster, cloud.ContainerService, cloud.Container, cloud.ContainerInstance)
+	resources, err := g.GetAllResources("region", "instance", "vpc", "securitygroup", "subnet", "keypair", "internetgateway", cloud.NatGateway, "routetable", cloud.DhcpOptions, cloud.NetworkAcl, cloud.PlacementGroup, cloud.DedicatedHost, cloud.PrefixList, cloud.Fleet, "loadbalancer", "targetgroup", "listener", "launchconfiguration", "scalinggroup", "image", "availabilityzone", "repository", cloud.ContainerImage, cloud.ContainerCluster, cloud.ContainerService, cloud.Container, cloud.ContainerInstance)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -488,6 +569,15 @@ func TestBuildInfraRdfGraph(t *testing.T) {
 		if p, ok := res.Properties[p.ContainersImages].([]string); ok {
 			sort.Strings(p)
 		}
+		if p, ok := res.Properties[p.ImageTags].([]string); ok {
+			sort.Strings(p)
+		}
+		if p, ok := res.Properties[p.ScanFindings].([]string); ok {
+			sort.Strings(p)
+		}
+		if p, ok := res.Properties[p.Instances].([]string); ok {
+			sort.Strings(p)
+		}
 		if p, ok := res.Properties[p.Attributes].([]*graph.KeyValue); ok {
 			sort.Slice(p, func(i, j int) bool {
 				if p[i].KeyName == p[j].KeyName {
@@ -503,31 +593,34 @@ func TestBuildInfraRdfGraph(t *testing.T) {
 		"inst_1":    resourcetest.Instance("inst_1").Prop(p.Subnet, "sub_1").Prop(p.Vpc, "vpc_1").Prop(p.Name, "instance1-name").Prop(p.Tags, []string{"Name=instance1-name"}).Build(),
 		"inst_2":    resourcetest.Instance("inst_2").Prop(p.Subnet, "sub_2").Prop(p.Vpc, "vpc_1").Prop(p.SecurityGroups, []string{"securitygroup_1"}).Build(),
 		"inst_3":    resourcetest.Instance("inst_3").Prop(p.Subnet, "sub_3").Prop(p.Vpc, "vpc_2").Build(),
-		"inst_4":    resourcetest.Instance("inst_4").Prop(p.Subnet, "sub_3").Prop(p.Vpc, "vpc_2").Prop(p.SecurityGroups, []string{"securitygroup_1", "securitygroup_2"}).Prop(p.KeyPair, "my_key").Build(),
+		"inst_4":    resourcetest.Instance("inst_4").Prop(p.Subnet, "sub_3").Prop(p.Vpc, "vpc_2").Prop(p.SecurityGroups, []string{"securitygroup_1", "securitygroup_2"}).Prop(p.KeyPair, "my_key").Prop(p.Image, "img_2").Build(),
 		"inst_5":    resourcetest.Instance("inst_5").Prop(p.KeyPair, "unexisting_key").Build(),
 		"inst_6": resourcetest.Instance("inst_6").Prop(p.Name, "inst_6_name").Prop(p.Tags, []string{"Name=inst_6_name"}).Prop(p.Type, "t2.micro").Prop(p.Subnet, "sub_3").Prop(p.Vpc, "vpc_2").Prop(p.PublicIP, "1.2.3.4").Prop(p.PrivateIP, "10.0.0.1").
 			Prop(p.Image, "ami-1234").Prop(p.Launched, now).Prop(p.State, "running").Prop(p.KeyPair, "my_key").Prop(p.SecurityGroups, []string{"securitygroup_1"}).Prop(p.Affinity, "inst_affinity").
 			Prop(p.AvailabilityZone, "inst_az").Prop(p.PlacementGroup, "inst_group").Prop(p.Host, "inst_host").Prop(p.Architecture, "x86").Prop(p.Hypervisor, "xen").Prop(p.Profile, "arn:instance:profile").
 			Prop(p.Lifecycle, "lifecycle").Prop(p.NetworkInterfaces, []string{"my-network-interface"}).Prop(p.PublicDNS, "my-instance.dns").Prop(p.RootDevice, "/dev/xvda").Prop(p.RootDeviceType, "ebs").Build(),
-		"vpc_1":            resourcetest.VPC("vpc_1").Build(),
-		"vpc_2":            resourcetest.VPC("vpc_2").Build(),
-		"securitygroup_1":  resourcetest.SecurityGroup("securitygroup_1").Prop(p.Name, "my_securitygroup").Prop(p.Vpc, "vpc_1").Build(),
-		"securitygroup_2":  resourcetest.SecurityGroup("securitygroup_2").Prop(p.Vpc, "vpc_1").Build(),
-		"sub_1":            resourcetest.Subnet("sub_1").Prop(p.Vpc, "vpc_1").Build(),
-		"sub_2":            resourcetest.Subnet("sub_2").Prop(p.Vpc, "vpc_1").Build(),
-		"sub_3":            resourcetest.Subnet("sub_3").Prop(p.Vpc, "vpc_2").Build(),
-		"sub_4":            resourcetest.Subnet("sub_4").Build(),
-		"us-west-1a":       resourcetest.AvailabilityZone("us-west-1a").Prop(p.Name, "us-west-1a").Prop(p.State, "available").Prop(p.Region, "us-west-1").Prop(p.Messages, []string{"msg 1", "msg 2"}).Build(),
-		"us-west-1b":       resourcetest.AvailabilityZone("us-west-1b").Prop(p.Name, "us-west-1b").Build(),
-		"my_key":           resourcetest.KeyPair("my_key").Build(),
-		"igw_1":            resourcetest.InternetGw("igw_1").Prop(p.Vpcs, []string{"vpc_2"}).Build(),
-		"natgw_1":          resourcetest.NatGw("natgw_1").Prop(p.Vpc, "vpc_1").Prop(p.Subnet, "sub_1").Build(),
-		"rt_1":             resourcetest.RouteTable("rt_1").Prop(p.Vpc, "vpc_1").Prop(p.Main, false).Build(),
-		"lb_1":             resourcetest.LoadBalancer("lb_1").Prop(p.Arn, "lb_1").Prop(p.Name, "my_loadbalancer").Prop(p.Vpc, "vpc_1").Build(),
+		"vpc_1":           resourcetest.VPC("vpc_1").Build(),
+		"vpc_2":           resourcetest.VPC("vpc_2").Build(),
+		"securitygroup_1": resourcetest.SecurityGroup("securitygroup_1").Prop(p.Name, "my_securitygroup").Prop(p.Vpc, "vpc_1").Build(),
+		"securitygroup_2": resourcetest.SecurityGroup("securitygroup_2").Prop(p.Vpc, "vpc_1").
+			Prop(p.OutboundRules, []*graph.FirewallRule{{Protocol: "tcp", PortRange: graph.PortRange{FromPort: 443, ToPort: 443}, PrefixLists: []string{"pl_1"}}}).Build(),
+		"sub_1":      resourcetest.Subnet("sub_1").Prop(p.Vpc, "vpc_1").Prop(p.FreeIPs, 250).Build(),
+		"sub_2":      resourcetest.Subnet("sub_2").Prop(p.Vpc, "vpc_1").Build(),
+		"sub_3":      resourcetest.Subnet("sub_3").Prop(p.Vpc, "vpc_2").Build(),
+		"sub_4":      resourcetest.Subnet("sub_4").Build(),
+		"us-west-1a": resourcetest.AvailabilityZone("us-west-1a").Prop(p.Name, "us-west-1a").Prop(p.State, "available").Prop(p.Region, "us-west-1").Prop(p.Messages, []string{"msg 1", "msg 2"}).Build(),
+		"us-west-1b": resourcetest.AvailabilityZone("us-west-1b").Prop(p.Name, "us-west-1b").Build(),
+		"my_key":     resourcetest.KeyPair("my_key").Build(),
+		"igw_1":      resourcetest.InternetGw("igw_1").Prop(p.Vpcs, []string{"vpc_2"}).Build(),
+		"natgw_1":    resourcetest.NatGw("natgw_1").Prop(p.Vpc, "vpc_1").Prop(p.Subnet, "sub_1").Build(),
+		"rt_1": resourcetest.RouteTable("rt_1").Prop(p.Vpc, "vpc_1").Prop(p.Main, false).
+			Prop(p.Routes, []*graph.Route{{DestinationPrefixListId: "pl_2", Targets: []*graph.RouteTarget{{Type: graph.GatewayTarget, Ref: "vgw_1"}}}}).Build(),
+		"dopt_1":           resourcetest.DhcpOptions("dopt_1").Prop(p.DomainName, "corp.local").Prop(p.NameServers, []string{"10.0.0.2"}).Build(),
+		"lb_1":             resourcetest.LoadBalancer("lb_1").Prop(p.Arn, "lb_1").Prop(p.Name, "my_loadbalancer").Prop(p.Vpc, "vpc_1").Prop(p.Tags, []string{"Env=Production"}).Build(),
 		"lb_2":             resourcetest.LoadBalancer("lb_2").Prop(p.Arn, "lb_2").Prop(p.Vpc, "vpc_2").Build(),
 		"lb_3":             resourcetest.LoadBalancer("lb_3").Prop(p.Arn, "lb_3").Prop(p.Vpc, "vpc_1").Build(),
 		"tg_1":             resourcetest.TargetGroup("tg_1").Prop(p.Arn, "tg_1").Prop(p.Vpc, "vpc_1").Build(),
-		"tg_2":             resourcetest.TargetGroup("tg_2").Prop(p.Arn, "tg_2").Prop(p.Vpc, "vpc_2").Build(),
+		"tg_2":             resourcetest.TargetGroup("tg_2").Prop(p.Arn, "tg_2").Prop(p.Vpc, "vpc_2").Prop(p.Tags, []string{"Env=Test"}).Build(),
 		"list_1":           resourcetest.Listener("list_1").Prop(p.Arn, "list_1").Prop(p.LoadBalancer, "lb_1").Build(),
 		"list_1.2":         resourcetest.Listener("list_1.2").Prop(p.Arn, "list_1.2").Prop(p.LoadBalancer, "lb_1").Build(),
 		"list_2":           resourcetest.Listener("list_2").Prop(p.Arn, "list_2").Prop(p.LoadBalancer, "lb_2").Build(),
@@ -536,16 +629,20 @@ func TestBuildInfraRdfGraph(t *testing.T) {
 		"asg_arn_1":        resourcetest.ScalingGroup("asg_arn_1").Prop(p.Arn, "asg_arn_1").Prop(p.Name, "asg_name_1").Prop(p.LaunchConfigurationName, "launchconfig_name").Build(),
 		"asg_arn_2":        resourcetest.ScalingGroup("asg_arn_2").Prop(p.Arn, "asg_arn_2").Prop(p.Name, "asg_name_2").Prop(p.LaunchConfigurationName, "launchconfig_name").Build(),
 		"img_1":            resourcetest.Image("img_1").Build(),
-		"img_2":            resourcetest.Image("img_2").Prop(p.Name, "img_2_name").Prop(p.Architecture, "img_2_arch").Prop(p.Hypervisor, "img_2_hyper").Prop(p.Created, time.Unix(1270123501, 0).UTC()).Build(),
-		"repo_1":           resourcetest.Repository("repo_1").Prop(p.Created, now).Prop(p.Arn, "repo_1").Prop(p.Account, "account_id").Prop(p.Name, "repo_name_1").Prop(p.URI, "http://my.repository.url").Build(),
-		"repo_2":           resourcetest.Repository("repo_2").Prop(p.Arn, "repo_2").Build(),
-		"repo_3":           resourcetest.Repository("repo_3").Prop(p.Arn, "repo_3").Build(),
-		"clust_1":          resourcetest.ContainerCluster("clust_1").Prop(p.Arn, "clust_1").Prop(p.Name, "my_cust_1").Prop(p.PendingTasksCount, 1).Prop(p.ActiveServicesCount, 3).Prop(p.RegisteredContainerInstancesCount, 3).Prop(p.RunningTasksCount, 2).Prop(p.State, "ACTIVE").Build(),
-		"clust_2":          resourcetest.ContainerCluster("clust_2").Prop(p.Arn, "clust_2").Build(),
-		"clust_3":          resourcetest.ContainerCluster("clust_3").Prop(p.Arn, "clust_3").Prop(p.Name, "my_cust_3").Build(),
-		"cs_1:1":           resourcetest.ContainerService("cs_1:1").Prop(p.Arn, "cs_1:1").Prop(p.ContainersImages, []string{"image_1", "image_2", "image_3"}).Prop(p.Name, "cs_1").Prop(p.Version, "1").Prop(p.State, "ENABLED").Prop(p.Role, "role:arn").Build(),
-		"cs_2:1":           resourcetest.ContainerService("cs_2:1").Prop(p.Arn, "cs_2:1").Prop(p.Name, "cs_2").Prop(p.Version, "1").Build(),
-		"cs_2:2":           resourcetest.ContainerService("cs_2:2").Prop(p.Arn, "cs_2:2").Prop(p.Name, "cs_2").Prop(p.Version, "2").Build(),
+		"img_2":            resourcetest.Image("img_2").Prop(p.Name, "img_2_name").Prop(p.Architecture, "img_2_arch").Prop(p.Hypervisor, "img_2_hyper").Prop(p.Created, time.Unix(1270123501, 0).UTC()).Prop(p.Snapshots, []string{"snap_1"}).Build(),
+		"repo_1": resourcetest.Repository("repo_1").Prop(p.Created, now).Prop(p.Arn, "repo_1").Prop(p.Account, "account_id").Prop(p.Name, "repo_name_1").Prop(p.URI, "http://my.repository.url").
+			Prop(p.ScanOnPush, true).Prop(p.LifecyclePolicy, `{"rules":[{"rulePriority":1,"selection":{"tagStatus":"untagged","countType":"sinceImagePushed","countUnit":"days","countNumber":14},"action":{"type":"expire"}}]}`).Build(),
+		"repo_name_1@sha256:aaa": resourcetest.ContainerImage("repo_name_1@sha256:aaa").Prop(p.Repository, "repo_name_1").Prop(p.Hash, "sha256:aaa").Prop(p.Account, "account_id").Prop(p.ImageTags, []string{"latest", "v2"}).
+			Prop(p.Created, now).Prop(p.Size, 2048).Prop(p.ScanStatus, "COMPLETE").Prop(p.ScanFindings, []string{"CRITICAL:1", "HIGH:3"}).Prop(p.Vulnerabilities, 4).Build(),
+		"repo_name_1@sha256:bbb": resourcetest.ContainerImage("repo_name_1@sha256:bbb").Prop(p.Repository, "repo_name_1").Prop(p.Hash, "sha256:bbb").Build(),
+		"repo_2":                 resourcetest.Repository("repo_2").Prop(p.Arn, "repo_2").Build(),
+		"repo_3":                 resourcetest.Repository("repo_3").Prop(p.Arn, "repo_3").Build(),
+		"clust_1":                resourcetest.ContainerCluster("clust_1").Prop(p.Arn, "clust_1").Prop(p.Name, "my_cust_1").Prop(p.PendingTasksCount, 1).Prop(p.ActiveServicesCount, 3).Prop(p.RegisteredContainerInstancesCount, 3).Prop(p.RunningTasksCount, 2).Prop(p.State, "ACTIVE").Build(),
+		"clust_2":                resourcetest.ContainerCluster("clust_2").Prop(p.Arn, "clust_2").Build(),
+		"clust_3":                resourcetest.ContainerCluster("clust_3").Prop(p.Arn, "clust_3").Prop(p.Name, "my_cust_3").Build(),
+		"cs_1:1":                 resourcetest.ContainerService("cs_1:1").Prop(p.Arn, "cs_1:1").Prop(p.ContainersImages, []string{"image_1", "image_2", "image_3"}).Prop(p.Name, "cs_1").Prop(p.Version, "1").Prop(p.State, "ENABLED").Prop(p.Role, "role:arn").Build(),
+		"cs_2:1":                 resourcetest.ContainerService("cs_2:1").Prop(p.Arn, "cs_2:1").Prop(p.Name, "cs_2").Prop(p.Version, "1").Build(),
+		"cs_2:2":                 resourcetest.ContainerService("cs_2:2").Prop(p.Arn, "cs_2:2").Prop(p.Name, "cs_2").Prop(p.Version, "2").Build(),
 		"container_1": resourcetest.Container("container_1").Prop(p.Arn, "container_1").Prop(p.ExitCode, -1).Prop(p.State, "running").Prop(p.Name, "my_container_1").Prop(p.StateMessage, "no reason").Prop(p.Cluster, "clust_1").
 			Prop(p.ContainerInstance, "cont_inst_1").Prop(p.Created, now.Add(-2*time.Hour)).Prop(p.Launched, now.Add(-1*time.Hour)).Prop(p.Stopped, now).Prop(p.ContainerService, "cs_2:1").Build(),
 		"container_2": resourcetest.Container("container_2").Prop(p.Arn, "container_2").Prop(p.Name, "my_container_2").Prop(p.Cluster, "clust_1").Prop(p.ContainerInstance, "cont_inst_1").Prop(p.Created, now.Add(-2*time.Hour)).Prop(p.Launched, now.Add(-1*time.Hour)).Prop(p.Stopped, now).Prop(p.ContainerService, "cs_2:1").Build(),
@@ -555,23 +652,41 @@ func TestBuildInfraRdfGraph(t *testing.T) {
 			Prop(p.Instance, "inst_2").Prop(p.PendingTasksCount, 4).Prop(p.Created, now.Add(-2*time.Hour)).Prop(p.RunningTasksCount, 2).Prop(p.State, "ACTIVE").Prop(p.Version, "2").Prop(p.AgentVersion, "0.0.5").Prop(p.DockerVersion, "v1.0.12").Prop(p.Cluster, "clust_1").Build(),
 		"cont_inst_2": resourcetest.ContainerInstance("cont_inst_2").Prop(p.Arn, "cont_inst_2").Prop(p.Instance, "inst_3").Prop(p.Cluster, "clust_1").Build(),
 		"cont_inst_3": resourcetest.ContainerInstance("cont_inst_3").Prop(p.Arn, "cont_inst_3").Prop(p.Instance, "inst_1").Prop(p.Cluster, "clust_2").Build(),
+		"acl_1": resourcetest.NetworkAcl("acl_1").Prop(p.Vpc, "vpc_1").Prop(p.Default, true).Prop(p.Subnets, []string{"sub_2"}).
+			Prop(p.InboundRules, []*graph.FirewallRule{{Number: 100, Action: "allow", Protocol: "tcp", PortRange: graph.PortRange{FromPort: 22, ToPort: 22}, IPRanges: []*net.IPNet{vpcCidr}}}).
+			Prop(p.OutboundRules, []*graph.FirewallRule{{Number: 32767, Action: "deny", Protocol: "any", PortRange: graph.PortRange{Any: true}, IPRanges: []*net.IPNet{anyCidr}}}).Build(),
+		"pl_1": resourcetest.PrefixList("pl_1").Prop(p.Name, "corp-vpn").Prop(p.Owner, "123456789012").Prop(p.IPType, "IPv4").Prop(p.State, "create-complete").
+			Prop(p.MaxEntries, 10).Prop(p.Entries, []string{"10.10.0.0/16"}).Build(),
+		"pl_2": resourcetest.PrefixList("pl_2").Prop(p.Name, "com.amazonaws.us-west-1.s3").Prop(p.Owner, "AWS").Prop(p.IPType, "IPv4").Prop(p.State, "create-complete").
+			Prop(p.Entries, []string{"52.92.16.0/20"}).Build(),
+		"inst_group": resourcetest.PlacementGroup("inst_group").Prop(p.Name, "inst_group").Prop(p.State, "available").Prop(p.Strategy, "cluster").Build(),
+		"inst_host": resourcetest.DedicatedHost("inst_host").Prop(p.AvailabilityZone, "us-west-1a").Prop(p.State, "available").Prop(p.Type, "c4.large").Prop(p.Cores, 20).Prop(p.Sockets, 2).
+			Prop(p.TotalCapacity, 4).Prop(p.AvailableCapacity, 3).Prop(p.Utilization, 25).Prop(p.Instances, []string{"inst_6"}).Build(),
+		"sfr_1": resourcetest.Fleet("sfr_1").Prop(p.Kind, "spot").Prop(p.Type, "maintain").Prop(p.State, "active").Prop(p.ActivityStatus, "fulfilled").Prop(p.Strategy, "lowestPrice").Prop(p.TargetCapacity, 2).
+			Prop(p.SpotCapacity, 2).Prop(p.FulfilledCapacity, 2.0).Prop(p.SpotPrice, "0.05").Prop(p.Role, "arn:fleet:role").Prop(p.Created, now).Prop(p.Instances, []string{"inst_1", "inst_3"}).Build(),
+		"fleet_1": resourcetest.Fleet("fleet_1").Prop(p.Kind, "ec2").Prop(p.Type, "maintain").Prop(p.State, "active").Prop(p.Strategy, "diversified").Prop(p.TargetCapacity, 3).
+			Prop(p.OnDemandCapacity, 1).Prop(p.SpotCapacity, 2).Prop(p.Instances, []string{"inst_4"}).Build(),
 	}
 
 	expectedChildren := map[string][]string{
-		"eu-west-1": {"asg_arn_1", "asg_arn_2", "clust_1", "clust_2", "clust_3", "cs_1:1", "cs_2:1", "cs_2:2", "igw_1", "img_1", "img_2", "launchconfig_arn", "my_key", "natgw_1", "repo_1", "repo_2", "repo_3", "us-west-1a", "us-west-1b", "vpc_1", "vpc_2"},
-		"lb_1":      {"list_1", "list_1.2"},
-		"lb_2":      {"list_2"},
-		"lb_3":      {"list_3"},
-		"sub_1":     {"inst_1"},
-		"sub_2":     {"inst_2"},
-		"sub_3":     {"inst_3", "inst_4", "inst_6"},
-		"vpc_1":     {"lb_1", "lb_3", "natgw_1", "rt_1", "securitygroup_1", "securitygroup_2", "sub_1", "sub_2", "tg_1"},
-		"vpc_2":     {"lb_2", "sub_3", "tg_2"},
-		"clust_1":   {"cont_inst_1", "cont_inst_2", "container_1", "container_2", "container_3"},
-		"clust_2":   {"cont_inst_3", "container_4"},
+		"eu-west-1":  {"asg_arn_1", "asg_arn_2", "clust_1", "clust_2", "clust_3", "cs_1:1", "cs_2:1", "cs_2:2", "dopt_1", "fleet_1", "igw_1", "img_1", "img_2", "inst_group", "launchconfig_arn", "my_key", "natgw_1", "pl_1", "pl_2", "repo_1", "repo_2", "repo_3", "sfr_1", "us-west-1a", "us-west-1b", "vpc_1", "vpc_2"},
+		"lb_1":       {"list_1", "list_1.2"},
+		"lb_2":       {"list_2"},
+		"lb_3":       {"list_3"},
+		"sub_1":      {"inst_1"},
+		"sub_2":      {"inst_2"},
+		"sub_3":      {"inst_3", "inst_4", "inst_6"},
+		"vpc_1":      {"acl_1", "lb_1", "lb_3", "natgw_1", "rt_1", "securitygroup_1", "securitygroup_2", "sub_1", "sub_2", "tg_1"},
+		"vpc_2":      {"lb_2", "sub_3", "tg_2"},
+		"us-west-1a": {"inst_host"},
+		"clust_1":    {"cont_inst_1", "cont_inst_2", "container_1", "container_2", "container_3"},
+		"clust_2":    {"cont_inst_3", "container_4"},
+		"repo_1":     {"repo_name_1@sha256:aaa", "repo_name_1@sha256:bbb"},
 	}
 
 	expectedAppliedOn := map[string][]string{
+		"acl_1":           {"sub_2"},
+		"dopt_1":          {"vpc_1"},
 		"igw_1":           {"vpc_2"},
 		"lb_1":            {"tg_1"},
 		"lb_2":            {"tg_2"},
@@ -590,6 +705,13 @@ func TestBuildInfraRdfGraph(t *testing.T) {
 		"inst_1":          {"cont_inst_3"},
 		"inst_2":          {"cont_inst_1"},
 		"inst_3":          {"cont_inst_2"},
+		"img_2":           {"inst_4"},
+		"inst_group":      {"inst_6"},
+		"pl_1":            {"securitygroup_2"},
+		"pl_2":            {"rt_1"},
+		"inst_host":       {"inst_6"},
+		"sfr_1":           {"inst_1", "inst_3"},
+		"fleet_1":         {"inst_4"},
 		"cont_inst_1":     {"container_1", "container_2", "container_3"},
 		"cont_inst_2":     {"container_4"},
 	}
@@ -641,7 +763,24 @@ func TestBuildStorageRdfGraph(t *testing.T) {
 		},
 	}
 
-	mocks3 := &mockS3{buckets: buckets, objects: objects, grants: bucketsACL}
+	replications := map[string]*s3.ReplicationConfiguration{
+		"bucket_eu_1": {
+			Role: awssdk.String("arn:aws:iam::123456789012:role/replication"),
+			Rules: []*s3.ReplicationRule{
+				{ID: awssdk.String("dr"), Status: awssdk.String("Enabled"), Prefix: awssdk.String("logs/"), Destination: &s3.Destination{Bucket: awssdk.String("arn:aws:s3:::bucket_us_1"), StorageClass: awssdk.String("STANDARD_IA")}},
+				{ID: awssdk.String("partner"), Status: awssdk.String("Disabled"), Prefix: awssdk.String(""), Destination: &s3.Destination{Bucket: awssdk.String("arn:aws:s3:::other_account_bucket")}},
+			},
+		},
+	}
+
+	policies := map[string]string{
+		"bucket_eu_2": `{
+  "Version": "2012-10-17",
+  "Statement": {"Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::210987654321:root"}, "Action": "s3:GetObject", "Resource": "arn:aws:s3:::bucket_eu_2/*"}
+}`,
+	}
+
+	mocks3 := &mockS3{buckets: buckets, objects: objects, grants: bucketsACL, replications: replications, policies: policies}
 	StorageService = mocks3
 	storage := Storage{S3API: mocks3, region: "eu-west-1"}
 
@@ -654,17 +793,34 @@ func TestBuildStorageRdfGraph(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	// Sort slice properties in resources
+	for _, res := range resources {
+		if p, ok := res.Properties[p.ReplicationRules].([]*graph.ReplicationRule); ok {
+			sort.Slice(p, func(i, j int) bool {
+				return p[i].ID <= p[j].ID
+			})
+		}
+	}
+
 	expected := map[string]*graph.Resource{
-		"eu-west-1":   resourcetest.Region("eu-west-1").Build(),
-		"bucket_eu_1": resourcetest.Bucket("bucket_eu_1").Prop(p.Grants, []*graph.Grant{{Grantee: graph.Grantee{GranteeID: "usr_2"}, Permission: "Write"}}).Build(),
-		"bucket_eu_2": resourcetest.Bucket("bucket_eu_2").Prop(p.Grants, []*graph.Grant{{Grantee: graph.Grantee{GranteeID: "usr_1"}, Permission: "Write"}}).Build(),
+		"eu-west-1": resourcetest.Region("eu-west-1").Build(),
+		"bucket_eu_1": resourcetest.Bucket("bucket_eu_1").Prop(p.Grants, []*graph.Grant{{Grantee: graph.Grantee{GranteeID: "usr_2"}, Permission: "Write"}}).
+			Prop(p.ReplicationRole, "arn:aws:iam::123456789012:role/replication").
+			Prop(p.ReplicationRules, []*graph.ReplicationRule{
+				{ID: "dr", Status: "Enabled", Prefix: "logs/", Destination: "bucket_us_1", StorageClass: "STANDARD_IA"},
+				{ID: "partner", Status: "Disabled", Destination: "other_account_bucket"},
+			}).Build(),
+		"bucket_eu_2": resourcetest.Bucket("bucket_eu_2").Prop(p.Grants, []*graph.Grant{{Grantee: graph.Grantee{GranteeID: "usr_1"}, Permission: "Write"}}).
+			Prop(p.ResourcePolicy, `{"Version":"2012-10-17","Statement":{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::210987654321:root"},"Action":"s3:GetObject","Resource":"arn:aws:s3:::bucket_eu_2/*"}}`).Build(),
 	}
 	expectedChildren := map[string][]string{
 		"eu-west-1":   {"bucket_eu_1", "bucket_eu_2"},
 		"bucket_eu_1": {"obj_4"},
 		"bucket_eu_2": {"obj_5", "obj_6"},
 	}
-	expectedAppliedOn := map[string][]string{}
+	expectedAppliedOn := map[string][]string{
+		"bucket_eu_1": {"bucket_us_1"},
+	}
 
 	compareResources(t, g, resources, expected, expectedChildren, expectedAppliedOn)
 }
@@ -682,11 +838,22 @@ func TestBuildDnsRdfGraph(t *testing.T) {
 			{Type: awssdk.String("CNAME"), TTL: awssdk.Int64(60), Name: awssdk.String("subdomain3.my.first.domain"), ResourceRecords: []*route53.ResourceRecord{{Value: awssdk.String("4.5.6.7")}}},
 		},
 		"/hostedzone/23456": {
-			{Type: awssdk.String("A"), TTL: awssdk.Int64(30), Name: awssdk.String("subdomain1.my.second.domain"), ResourceRecords: []*route53.ResourceRecord{{Value: awssdk.String("5.6.7.8")}}},
+			{Type: awssdk.String("A"), TTL: awssdk.Int64(30), Name: awssdk.String("subdomain1.my.second.domain"), HealthCheckId: awssdk.String("check-1"), ResourceRecords: []*route53.ResourceRecord{{Value: awssdk.String("5.6.7.8")}}},
 			{Type: awssdk.String("CNAME"), TTL: awssdk.Int64(10), Name: awssdk.String("subdomain3.my.second.domain"), ResourceRecords: []*route53.ResourceRecord{{Value: awssdk.String("6.7.8.9")}}},
 		},
 	}
-	mockRoute53 := &mockRoute53{hostedzones: zonePages, resourcerecordsets: recordPages}
+	healthChecks := []*route53.HealthCheck{
+		{Id: awssdk.String("check-1"), CallerReference: awssdk.String("ref-1"), HealthCheckVersion: awssdk.Int64(2), HealthCheckConfig: &route53.HealthCheckConfig{
+			Type: awssdk.String("HTTPS"), FullyQualifiedDomainName: awssdk.String("my.second.domain"), Port: awssdk.Int64(443), ResourcePath: awssdk.String("/health"), RequestInterval: awssdk.Int64(30), FailureThreshold: awssdk.Int64(3),
+		}},
+		{Id: awssdk.String("check-2"), CallerReference: awssdk.String("ref-2"), HealthCheckVersion: awssdk.Int64(1), HealthCheckConfig: &route53.HealthCheckConfig{
+			Type: awssdk.String("TCP"), IPAddress: awssdk.String("5.6.7.8"), Port: awssdk.Int64(22), RequestInterval: awssdk.Int64(10), FailureThreshold: awssdk.Int64(2),
+		}},
+		{Id: awssdk.String("check-3"), CallerReference: awssdk.String("ref-3"), HealthCheckVersion: awssdk.Int64(1), HealthCheckConfig: &route53.HealthCheckConfig{
+			Type: awssdk.String("CALCULATED"), ChildHealthChecks: []*string{awssdk.String("check-1"), awssdk.String("check-2")},
+		}},
+	}
+	mockRoute53 := &mockRoute53{hostedzones: zonePages, resourcerecordsets: recordPages, healthchecks: healthChecks}
 
 	dns := Dns{Route53API: mockRoute53, region: "eu-west-1"}
 
@@ -695,7 +862,7 @@ func TestBuildDnsRdfGraph(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	resources, err := g.GetAllResources("zone", "record")
+	resources, err := g.GetAllResources("zone", "record", "healthcheck")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -713,14 +880,20 @@ func TestBuildDnsRdfGraph(t *testing.T) {
 		"awls-91fa0a45":     resourcetest.Record("awls-91fa0a45").Prop(p.Name, "subdomain1.my.first.domain").Prop(p.Type, "A").Prop(p.TTL, 10).Prop(p.Records, []string{"1.2.3.4", "2.3.4.5"}).Build(),
 		"awls-920c0a46":     resourcetest.Record("awls-920c0a46").Prop(p.Name, "subdomain2.my.first.domain").Prop(p.Type, "A").Prop(p.TTL, 10).Prop(p.Records, []string{"3.4.5.6"}).Build(),
 		"awls-be1e0b6a":     resourcetest.Record("awls-be1e0b6a").Prop(p.Name, "subdomain3.my.first.domain").Prop(p.Type, "CNAME").Prop(p.TTL, 60).Prop(p.Records, []string{"4.5.6.7"}).Build(),
-		"awls-9c420a99":     resourcetest.Record("awls-9c420a99").Prop(p.Name, "subdomain1.my.second.domain").Prop(p.Type, "A").Prop(p.TTL, 30).Prop(p.Records, []string{"5.6.7.8"}).Build(),
+		"awls-9c420a99":     resourcetest.Record("awls-9c420a99").Prop(p.Name, "subdomain1.my.second.domain").Prop(p.Type, "A").Prop(p.TTL, 30).Prop(p.Records, []string{"5.6.7.8"}).Prop(p.HealthCheck, "check-1").Build(),
 		"awls-c9b80bbe":     resourcetest.Record("awls-c9b80bbe").Prop(p.Name, "subdomain3.my.second.domain").Prop(p.Type, "CNAME").Prop(p.TTL, 10).Prop(p.Records, []string{"6.7.8.9"}).Build(),
+		"check-1":           resourcetest.HealthCheck("check-1").Prop(p.Type, "HTTPS").Prop(p.Endpoint, "https://my.second.domain:443/health").Prop(p.Host, "my.second.domain").Prop(p.Port, 443).Prop(p.Path, "/health").Prop(p.CheckInterval, 30).Prop(p.UnhealthyThresholdCount, 3).Prop(p.CallerReference, "ref-1").Prop(p.Version, 2).Build(),
+		"check-2":           resourcetest.HealthCheck("check-2").Prop(p.Type, "TCP").Prop(p.Endpoint, "tcp://5.6.7.8:22").Prop(p.Port, 22).Prop(p.CheckInterval, 10).Prop(p.UnhealthyThresholdCount, 2).Prop(p.CallerReference, "ref-2").Prop(p.Version, 1).Build(),
+		"check-3":           resourcetest.HealthCheck("check-3").Prop(p.Type, "CALCULATED").Prop(p.CallerReference, "ref-3").Prop(p.Version, 1).Build(),
 	}
 	expectedChildren := map[string][]string{
 		"/hostedzone/12345": {"awls-91fa0a45", "awls-920c0a46", "awls-be1e0b6a"},
 		"/hostedzone/23456": {"awls-9c420a99", "awls-c9b80bbe"},
 	}
-	expectedAppliedOn := map[string][]string{}
+	expectedAppliedOn := map[string][]string{
+		"check-1": {"awls-9c420a99", "check-3"},
+		"check-2": {"check-3"},
+	}
 
 	compareResources(t, g, resources, expected, expectedChildren, expectedAppliedOn)
 }
@@ -745,6 +918,7 @@ func TestBuildNotificationGraph(t *testing.T) {
 			"LastModifiedTimestamp":       awssdk.String("1494332859"),
 			"QueueArn":                    awssdk.String("queue_2_arn"),
 			"DelaySeconds":                awssdk.String("15"),
+			"Policy":                      awssdk.String(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"sqs:SendMessage","Resource":"queue_2_arn"}]}`),
 		},
 		"queue_3": {
 			"ApproximateNumberOfMessages": awssdk.String("12"),
@@ -789,7 +963,8 @@ func TestBuildNotificationGraph(t *testing.T) {
 
 	expected = map[string]*graph.Resource{
 		"queue_1": resourcetest.Queue("queue_1").Build(),
-		"queue_2": resourcetest.Queue("queue_2").Prop(p.ApproximateMessageCount, 4).Prop(p.Created, time.Unix(1494419259, 0).UTC()).Prop(p.Modified, time.Unix(1494332859, 0).UTC()).Prop(p.Arn, "queue_2_arn").Prop(p.Delay, 15).Build(),
+		"queue_2": resourcetest.Queue("queue_2").Prop(p.ApproximateMessageCount, 4).Prop(p.Created, time.Unix(1494419259, 0).UTC()).Prop(p.Modified, time.Unix(1494332859, 0).UTC()).Prop(p.Arn, "queue_2_arn").Prop(p.Delay, 15).
+			Prop(p.ResourcePolicy, `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"sqs:SendMessage","Resource":"queue_2_arn"}]}`).Build(),
 		"queue_3": resourcetest.Queue("queue_3").Prop(p.ApproximateMessageCount, 12).Build(),
 	}
 	expectedChildren = map[string][]string{}
@@ -849,6 +1024,101 @@ func TestBuildLambdaGraph(t *testing.T) {
 	compareResources(t, g, resources, expected, expectedChildren, expectedAppliedOn)
 }
 
+func TestBuildStreamsGraph(t *testing.T) {
+	created := time.Unix(1136214245, 0).UTC()
+	streams := []*awsdriver.StreamDescriptionSummary{
+		{
+			StreamName:              awssdk.String("stream_1"),
+			StreamARN:               awssdk.String("arn:aws:kinesis:eu-west-1:123456789012:stream/stream_1"),
+			StreamStatus:            awssdk.String("ACTIVE"),
+			StreamModeDetails:       &awsdriver.StreamModeDetails{StreamMode: awssdk.String("PROVISIONED")},
+			OpenShardCount:          awssdk.Int64(4),
+			RetentionPeriodHours:    awssdk.Int64(24),
+			EncryptionType:          awssdk.String("KMS"),
+			StreamCreationTimestamp: &created,
+		},
+		{StreamName: awssdk.String("stream_2"), StreamARN: awssdk.String("arn:aws:kinesis:eu-west-1:123456789012:stream/stream_2")},
+	}
+	deliveryStreams := []*awsdriver.DeliveryStreamDescription{
+		{
+			DeliveryStreamName:   awssdk.String("delivery_1"),
+			DeliveryStreamARN:    awssdk.String("arn:aws:firehose:eu-west-1:123456789012:deliverystream/delivery_1"),
+			DeliveryStreamStatus: awssdk.String("ACTIVE"),
+			DeliveryStreamType:   awssdk.String("KinesisStreamAsSource"),
+			Source:               &awsdriver.SourceDescription{KinesisStreamSourceDescription: &awsdriver.KinesisStreamSourceDescription{KinesisStreamARN: awssdk.String("arn:aws:kinesis:eu-west-1:123456789012:stream/stream_1")}},
+			Destinations: []*awsdriver.DestinationDescription{{ExtendedS3DestinationDescription: &awsdriver.S3DestinationDescription{
+				BucketARN:      awssdk.String("arn:aws:s3:::bucket_1"),
+				BufferingHints: &awsdriver.BufferingHints{SizeInMBs: awssdk.Int64(5), IntervalInSeconds: awssdk.Int64(300)},
+			}}},
+		},
+		{
+			DeliveryStreamName: awssdk.String("delivery_2"),
+			DeliveryStreamARN:  awssdk.String("arn:aws:firehose:eu-west-1:123456789012:deliverystream/delivery_2"),
+			DeliveryStreamType: awssdk.String("DirectPut"),
+			Destinations: []*awsdriver.DestinationDescription{{RedshiftDestinationDescription: &awsdriver.RedshiftDestinationDescription{
+				ClusterJDBCURL: awssdk.String("jdbc:redshift://cluster_1.eu-west-1.redshift.amazonaws.com:5439/db"),
+			}}},
+		},
+	}
+	mappings := []*lambda.EventSourceMappingConfiguration{
+		{EventSourceArn: awssdk.String("arn:aws:kinesis:eu-west-1:123456789012:stream/stream_1"), FunctionArn: awssdk.String("func_1_arn")},
+		{EventSourceArn: awssdk.String("arn:aws:kinesis:eu-west-1:123456789012:stream/stream_1"), FunctionArn: awssdk.String("func_2_arn")},
+		{EventSourceArn: awssdk.String("arn:aws:sqs:eu-west-1:123456789012:queue_1"), FunctionArn: awssdk.String("func_1_arn")},
+	}
+	functions := []*lambda.FunctionConfiguration{
+		{FunctionArn: awssdk.String("func_1_arn")},
+		{FunctionArn: awssdk.String("func_2_arn")},
+	}
+
+	mock := &mockLambdaStreams{mockLambda: &mockLambda{functionconfigurations: functions}, streams: streams, deliverystreams: deliveryStreams, mappings: mappings}
+	service := Lambda{LambdaAPI: mock, region: "eu-west-1"}
+
+	g, err := service.FetchResources()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stream1, stream2 := "arn:aws:kinesis:eu-west-1:123456789012:stream/stream_1", "arn:aws:kinesis:eu-west-1:123456789012:stream/stream_2"
+	delivery1, delivery2 := "arn:aws:firehose:eu-west-1:123456789012:deliverystream/delivery_1", "arn:aws:firehose:eu-west-1:123456789012:deliverystream/delivery_2"
+
+	resources, err := g.GetAllResources("stream")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, res := range resources {
+		if p, ok := res.Properties[p.Consumers].([]string); ok {
+			sort.Strings(p)
+		}
+	}
+	expected := map[string]*graph.Resource{
+		stream1: resourcetest.Stream(stream1).Prop(p.Name, "stream_1").Prop(p.Arn, stream1).Prop(p.State, "ACTIVE").Prop(p.Mode, "PROVISIONED").
+			Prop(p.Shards, 4).Prop(p.RetentionPeriod, 24).Prop(p.Encryption, "KMS").Prop(p.Created, created).Prop(p.Consumers, []string{"func_1_arn", "func_2_arn"}).Build(),
+		stream2: resourcetest.Stream(stream2).Prop(p.Name, "stream_2").Prop(p.Arn, stream2).Build(),
+	}
+	expectedChildren := map[string][]string{
+		"eu-west-1": {"func_1_arn", "func_2_arn", stream1, stream2, delivery1, delivery2},
+	}
+	expectedAppliedOn := map[string][]string{
+		stream1: {delivery1, "func_1_arn", "func_2_arn"},
+	}
+	compareResources(t, g, resources, expected, expectedChildren, expectedAppliedOn)
+
+	resources, err = g.GetAllResources("deliverystream")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = map[string]*graph.Resource{
+		delivery1: resourcetest.DeliveryStream(delivery1).Prop(p.Name, "delivery_1").Prop(p.Arn, delivery1).Prop(p.State, "ACTIVE").Prop(p.Type, "KinesisStreamAsSource").
+			Prop(p.Source, stream1).Prop(p.DestinationType, "s3").Prop(p.Destination, "arn:aws:s3:::bucket_1").Prop(p.BufferSize, 5).Prop(p.BufferInterval, 300).Build(),
+		delivery2: resourcetest.DeliveryStream(delivery2).Prop(p.Name, "delivery_2").Prop(p.Arn, delivery2).Prop(p.Type, "DirectPut").
+			Prop(p.DestinationType, "redshift").Prop(p.Destination, "jdbc:redshift://cluster_1.eu-west-1.redshift.amazonaws.com:5439/db").Build(),
+	}
+	expectedAppliedOn = map[string][]string{
+		delivery1: {"bucket_1"},
+	}
+	compareResources(t, g, resources, expected, expectedChildren, expectedAppliedOn)
+}
+
 func TestBuildMonitoringGraph(t *testing.T) {
 	now := time.Now().UTC()
 	metrics := []*cloudwatch.Metric{
@@ -949,18 +1219,33 @@ func TestBuildCdnGraph(t *testing.T) {
 				Quantity: awssdk.Int64(2),
 				Items: []*cloudfront.Origin{
 					{
-						DomainName:     awssdk.String("domain.name"),
+						DomainName:     awssdk.String("my-bucket.s3.amazonaws.com"),
 						Id:             awssdk.String("origin_1"),
 						OriginPath:     awssdk.String("my/s3/path"),
 						S3OriginConfig: &cloudfront.S3OriginConfig{OriginAccessIdentity: awssdk.String("origin-access-identity/CloudFront/ID-of-origin-access-identity")},
 					},
 					{
-						DomainName: awssdk.String("domain2.name"),
-						Id:         awssdk.String("origin_2"),
-						OriginPath: awssdk.String("my/other/path"),
+						DomainName:         awssdk.String("my-alb-1234.eu-west-1.elb.amazonaws.com"),
+						Id:                 awssdk.String("origin_2"),
+						OriginPath:         awssdk.String("my/other/path"),
+						CustomOriginConfig: &cloudfront.CustomOriginConfig{OriginProtocolPolicy: awssdk.String("https-only")},
 					},
 				},
 			},
+			DefaultCacheBehavior: &cloudfront.DefaultCacheBehavior{
+				TargetOriginId:       awssdk.String("origin_1"),
+				ViewerProtocolPolicy: awssdk.String("redirect-to-https"),
+				MinTTL:               awssdk.Int64(0),
+				DefaultTTL:           awssdk.Int64(86400),
+				MaxTTL:               awssdk.Int64(31536000),
+				Compress:             awssdk.Bool(true),
+			},
+			CacheBehaviors: &cloudfront.CacheBehaviors{
+				Quantity: awssdk.Int64(1),
+				Items: []*cloudfront.CacheBehavior{
+					{PathPattern: awssdk.String("/api/*"), TargetOriginId: awssdk.String("origin_2"), ViewerProtocolPolicy: awssdk.String("https-only"), MinTTL: awssdk.Int64(0)},
+				},
+			},
 			PriceClass: awssdk.String("expensive"),
 			Status:     awssdk.String("running"),
 			ViewerCertificate: &cloudfront.ViewerCertificate{
@@ -984,6 +1269,13 @@ func TestBuildCdnGraph(t *testing.T) {
 
 	mock := &mockCloudfront{distributionsummarys: distributions}
 
+	defer func(storage, infra cloud.Service) { StorageService, InfraService = storage, infra }(StorageService, InfraService)
+	StorageService = &mockS3{buckets: map[string][]*s3.Bucket{"eu-west-1": {{Name: awssdk.String("my-bucket")}}}}
+	InfraService = &Infra{ELBV2API: &mockElbv2{loadbalancers: []*elbv2.LoadBalancer{
+		{LoadBalancerArn: awssdk.String("lb_1"), DNSName: awssdk.String("my-alb-1234.eu-west-1.elb.amazonaws.com")},
+		{LoadBalancerArn: awssdk.String("lb_2"), DNSName: awssdk.String("other-alb.eu-west-1.elb.amazonaws.com")},
+	}}}
+
 	service := Cdn{CloudFrontAPI: mock, region: "eu-west-1"}
 
 	g, err := service.FetchResources()
@@ -1026,16 +1318,115 @@ func TestBuildCdnGraph(t *testing.T) {
 			Prop(p.TLSVersionRequired, "TLSv1").
 			Prop(p.SSLSupportMethod, "sni-only").
 			Prop(p.Origins, []*graph.DistributionOrigin{
-				{ID: "origin_1", PublicDNS: "domain.name", OriginType: "s3", PathPrefix: "my/s3/path", Config: "origin-access-identity/CloudFront/ID-of-origin-access-identity"},
-				{ID: "origin_2", PublicDNS: "domain2.name", PathPrefix: "my/other/path"},
+				{ID: "origin_1", PublicDNS: "my-bucket.s3.amazonaws.com", OriginType: "s3", PathPrefix: "my/s3/path", Config: "origin-access-identity/CloudFront/ID-of-origin-access-identity"},
+				{ID: "origin_2", PublicDNS: "my-alb-1234.eu-west-1.elb.amazonaws.com", OriginType: "custom", PathPrefix: "my/other/path", Config: "https-only"},
 			}).
+			Prop(p.DefaultCacheBehavior, "path=* origin=origin_1 viewer=redirect-to-https ttl=0/86400/31536000 compress=true").
+			Prop(p.CacheBehaviors, []string{"path=/api/* origin=origin_2 viewer=https-only ttl=0/0/0 compress=false"}).
 			Build(),
 		"ds_2": resourcetest.Distribution("ds_2").Prop(p.Arn, "ds_2_arn").Prop(p.PublicDNS, "other.domain.name").Build(),
 		"ds_3": resourcetest.Distribution("ds_3").Build(),
 	}
 
 	expectedChildren := map[string][]string{}
-	expectedAppliedOn := map[string][]string{}
+	expectedAppliedOn := map[string][]string{
+		"ds_1": {"lb_1", "my-bucket"},
+	}
+
+	compareResources(t, g, resources, expected, expectedChildren, expectedAppliedOn)
+}
+
+func TestFetchSingleResource(t *testing.T) {
+	mock := &mockEc2{subnets: []*ec2.Subnet{
+		{SubnetId: awssdk.String("sub_1"), VpcId: awssdk.String("vpc_1"), CidrBlock: awssdk.String("10.0.1.0/24")},
+		{SubnetId: awssdk.String("sub_2"), VpcId: awssdk.String("vpc_2"), CidrBlock: awssdk.String("10.0.2.0/24")},
+	}}
+	service := Infra{EC2API: mock, region: "eu-west-1"}
+
+	g, err := service.FetchResource(cloud.Subnet, "sub_2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	subnets, err := g.GetAllResources(cloud.Subnet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(subnets), 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := subnets[0].Properties[p.CIDR], "10.0.2.0/24"; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	local := graph.NewGraph()
+	local.AddResource(resourcetest.VPC("vpc_2").Build(), resourcetest.Subnet("sub_2").Prop(p.CIDR, "10.0.0.0/24").Build())
+	local.RefreshResource("sub_2", g)
+	var parents []*graph.Resource
+	if err = local.Accept(&graph.ParentsVisitor{From: subnets[0], Each: graph.VisitorCollectFunc(&parents)}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(parents), 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := parents[0].Id(), "vpc_2"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	if _, err = service.FetchResource(cloud.Subnet, "sub_3"); err == nil {
+		t.Fatal("expected error for unknown subnet")
+	}
+}
+
+func TestBuildFindingsGraph(t *testing.T) {
+	findings := map[string][]*GuardDutyFinding{
+		"detector_1": {
+			{
+				Id: awssdk.String("finding_1"), Title: awssdk.String("Unusual outbound traffic"), Type: awssdk.String("Backdoor:EC2/C&CActivity.B"), Severity: awssdk.Float64(8),
+				AccountId: awssdk.String("123456789012"), Region: awssdk.String("eu-west-1"), Arn: awssdk.String("finding_1_arn"),
+				Resource: &guardDutyFindingResource{ResourceType: awssdk.String("Instance"), InstanceDetails: &guardDutyInstanceDetails{InstanceId: awssdk.String("inst_1")}},
+				Service:  &guardDutyFindingService{Count: awssdk.Int64(3)},
+			},
+			{
+				Id: awssdk.String("finding_2"), Title: awssdk.String("API called from a Tor exit node"), Severity: awssdk.Float64(5.5),
+				Resource: &guardDutyFindingResource{ResourceType: awssdk.String("AccessKey"), AccessKeyDetails: &guardDutyAccessKeyDetails{AccessKeyId: awssdk.String("AKIAEXAMPLE"), UserName: awssdk.String("john")}},
+			},
+			{
+				Id: awssdk.String("finding_3"), Title: awssdk.String("Kubernetes anonymous access"), Severity: awssdk.Float64(2),
+				Resource: &guardDutyFindingResource{ResourceType: awssdk.String("EKSCluster")},
+			},
+		},
+	}
+
+	onlyFindings := make(config)
+	for _, typ := range ResourceTypesPerServiceName()["infra"] {
+		onlyFindings["aws.infra."+typ+".sync"] = typ == cloud.Finding
+	}
+	mock := &mockEc2GuardDuty{mockEc2: &mockEc2{}, detectors: []string{"detector_1"}, findings: findings}
+	service := Infra{EC2API: mock, region: "eu-west-1", config: onlyFindings, log: logger.DiscardLogger}
+
+	g, err := service.FetchResources()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resources, err := g.GetAllResources(cloud.Finding)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]*graph.Resource{
+		"finding_1": resourcetest.Finding("finding_1").Prop(p.Name, "Unusual outbound traffic").Prop(p.Type, "Backdoor:EC2/C&CActivity.B").
+			Prop(p.Severity, "HIGH").Prop(p.SeverityScore, "8.0").Prop(p.Occurrences, 3).Prop(p.AffectedResource, "instance:inst_1").
+			Prop(p.Account, "123456789012").Prop(p.Region, "eu-west-1").Prop(p.Arn, "finding_1_arn").Build(),
+		"finding_2": resourcetest.Finding("finding_2").Prop(p.Name, "API called from a Tor exit node").
+			Prop(p.Severity, "MEDIUM").Prop(p.SeverityScore, "5.5").Prop(p.AffectedResource, "accesskey:AKIAEXAMPLE").Build(),
+		"finding_3": resourcetest.Finding("finding_3").Prop(p.Name, "Kubernetes anonymous access").
+			Prop(p.Severity, "LOW").Prop(p.SeverityScore, "2.0").Prop(p.AffectedResource, "EKSCluster").Build(),
+	}
+	expectedChildren := map[string][]string{}
+	expectedAppliedOn := map[string][]string{
+		"finding_1": {"inst_1"},
+		"finding_2": {"AKIAEXAMPLE"},
+	}
 
 	compareResources(t, g, resources, expected, expectedChildren, expectedAppliedOn)
 }
@@ -1239,3 +1630,45 @@ func TestSliceOfSlice(t *testing.T) {
 		}
 	}
 }
+
+type countingElbv2 struct {
+	*mockElbv2
+	calls int
+}
+
+func (m *countingElbv2) DescribeTags(input *elbv2.DescribeTagsInput) (*elbv2.DescribeTagsOutput, error) {
+	m.calls++
+	return m.mockElbv2.DescribeTags(input)
+}
+
+func BenchmarkFetchElbv2Tags(b *testing.B) {
+	var arns []*string
+	var descs []*elbv2.TagDescription
+	for i := 0; i < 500; i++ {
+		arn := awssdk.String(fmt.Sprintf("arn:aws:elasticloadbalancing:eu-west-1:0123456789:loadbalancer/app/lb-%d", i))
+		arns = append(arns, arn)
+		descs = append(descs, &elbv2.TagDescription{ResourceArn: arn, Tags: []*elbv2.Tag{{Key: awssdk.String("Env"), Value: awssdk.String("Production")}}})
+	}
+
+	for _, tcase := range []struct {
+		name      string
+		batchSize int
+	}{
+		{"per resource", 1},
+		{"batched", elbv2TagsBatchSize},
+	} {
+		b.Run(tcase.name, func(b *testing.B) {
+			mock := &countingElbv2{mockElbv2: &mockElbv2{tagdescriptions: descs}}
+			for i := 0; i < b.N; i++ {
+				tags, err := fetchElbv2Tags(mock, arns, tcase.batchSize)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if len(tags) != len(arns) {
+					b.Fatalf("got %d, want %d", len(tags), len(arns))
+				}
+			}
+			b.ReportMetric(float64(mock.calls)/float64(b.N), "calls/op")
+		})
+	}
+}
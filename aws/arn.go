@@ -0,0 +1,129 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wallix/awless/cloud"
+)
+
+// ResourceArn holds the components of an Amazon Resource Name:
+// arn:partition:service:region:account:resource
+type ResourceArn struct {
+	Partition, Service, Region, Account, Resource string
+}
+
+// IsArn returns whether the given string looks like an Amazon Resource Name
+func IsArn(s string) bool {
+	return strings.HasPrefix(s, "arn:")
+}
+
+// ParseArn splits an Amazon Resource Name into its components
+func ParseArn(s string) (*ResourceArn, error) {
+	if !IsArn(s) {
+		return nil, fmt.Errorf("invalid arn '%s': missing 'arn:' prefix", s)
+	}
+	splits := strings.SplitN(s, ":", 6)
+	if len(splits) != 6 {
+		return nil, fmt.Errorf("invalid arn '%s': expecting arn:partition:service:region:account:resource", s)
+	}
+	a := &ResourceArn{Partition: splits[1], Service: splits[2], Region: splits[3], Account: splits[4], Resource: splits[5]}
+	if a.Partition == "" || a.Service == "" || a.Resource == "" {
+		return nil, fmt.Errorf("invalid arn '%s': empty partition, service or resource", s)
+	}
+	return a, nil
+}
+
+func (a *ResourceArn) String() string {
+	return strings.Join([]string{"arn", a.Partition, a.Service, a.Region, a.Account, a.Resource}, ":")
+}
+
+// arnResourceTypes maps the service and the resource type prefix found in ARNs
+// to awless resource types. The empty prefix applies to resources without prefix (ex: s3 buckets)
+var arnResourceTypes = map[string]map[string]string{
+	"ec2": {
+		"instance": cloud.Instance, "vpc": cloud.Vpc, "subnet": cloud.Subnet, "security-group": cloud.SecurityGroup,
+		"volume": cloud.Volume, "snapshot": cloud.Snapshot, "image": cloud.Image, "internet-gateway": cloud.InternetGateway,
+		"natgateway": cloud.NatGateway, "route-table": cloud.RouteTable, "network-acl": cloud.NetworkAcl,
+		"dhcp-options": cloud.DhcpOptions, "elastic-ip": cloud.ElasticIP, "dedicated-host": cloud.DedicatedHost,
+		"prefix-list": cloud.PrefixList, "fleet": cloud.Fleet,
+	},
+	"elasticloadbalancing": {"loadbalancer": cloud.LoadBalancer, "targetgroup": cloud.TargetGroup, "listener": cloud.Listener},
+	"autoscaling":          {"autoScalingGroup": cloud.ScalingGroup, "launchConfiguration": cloud.LaunchConfiguration, "scalingPolicy": cloud.ScalingPolicy},
+	"rds":                  {"db": cloud.Database, "subgrp": cloud.DbSubnetGroup},
+	"iam":                  {"user": cloud.User, "role": cloud.Role, "group": cloud.Group, "policy": cloud.Policy, "instance-profile": cloud.InstanceProfile},
+	"s3":                   {"": cloud.Bucket},
+	"sns":                  {"": cloud.Topic},
+	"sqs":                  {"": cloud.Queue},
+	"lambda":               {"function": cloud.Function},
+	"kinesis":              {"stream": cloud.Stream},
+	"firehose":             {"deliverystream": cloud.DeliveryStream},
+	"cloudwatch":           {"alarm": cloud.Alarm},
+	"cloudfront":           {"distribution": cloud.Distribution},
+	"cloudformation":       {"stack": cloud.Stack},
+	"route53":              {"hostedzone": cloud.Zone},
+	"ecr":                  {"repository": cloud.Repository},
+	"ecs":                  {"cluster": cloud.ContainerCluster, "task-definition": cloud.ContainerService, "container-instance": cloud.ContainerInstance},
+}
+
+// arnIdentifiedTypes are the resource types identified in the local graph by their ARN
+var arnIdentifiedTypes = map[string]bool{
+	cloud.LoadBalancer: true, cloud.TargetGroup: true, cloud.Listener: true,
+	cloud.ScalingGroup: true, cloud.LaunchConfiguration: true, cloud.ScalingPolicy: true,
+	cloud.Topic: true, cloud.Function: true, cloud.Alarm: true, cloud.Stack: true,
+	cloud.Repository: true, cloud.ContainerCluster: true, cloud.ContainerService: true, cloud.ContainerInstance: true,
+	cloud.Stream: true, cloud.DeliveryStream: true,
+}
+
+// ResourceTypeAndId returns the awless resource type of the ARN and, when it can be
+// derived from the ARN only, the id of the resource in the local graph.
+// The id is empty for resources only resolvable through their Arn property (ex: IAM resources).
+func (a *ResourceArn) ResourceTypeAndId() (string, string, error) {
+	types, ok := arnResourceTypes[a.Service]
+	if !ok {
+		return "", "", fmt.Errorf("unsupported arn service '%s'", a.Service)
+	}
+	prefix, rest := a.Resource, ""
+	if i := strings.IndexAny(a.Resource, "/:"); i > 0 {
+		prefix, rest = a.Resource[:i], a.Resource[i+1:]
+	}
+	typ, ok := types[prefix]
+	if !ok {
+		if typ, ok = types[""]; !ok {
+			return "", "", fmt.Errorf("unsupported resource '%s' of arn service '%s'", prefix, a.Service)
+		}
+		prefix, rest = "", a.Resource
+	}
+	switch {
+	case arnIdentifiedTypes[typ]:
+		if typ == cloud.Topic && strings.Contains(a.Resource, ":") {
+			return cloud.Subscription, "", nil
+		}
+		return typ, a.String(), nil
+	case a.Service == "ec2" || a.Service == "rds" || a.Service == "cloudfront" || typ == cloud.Bucket:
+		if strings.Contains(rest, "/") {
+			return "", "", fmt.Errorf("unsupported nested resource '%s' of arn service '%s'", rest, a.Service)
+		}
+		return typ, rest, nil
+	case typ == cloud.Zone:
+		return typ, "/hostedzone/" + rest, nil
+	default:
+		return typ, "", nil
+	}
+}
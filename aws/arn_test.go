@@ -0,0 +1,76 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"testing"
+
+	"github.com/wallix/awless/cloud"
+)
+
+func TestResourceArn(t *testing.T) {
+	tcases := []struct {
+		arn      string
+		typ, id  string
+		expError bool
+	}{
+		{arn: "arn:aws:ec2:us-east-1:123456789012:instance/i-8d43b21b", typ: cloud.Instance, id: "i-8d43b21b"},
+		{arn: "arn:aws:ec2:us-east-1:123456789012:security-group/sg-1234", typ: cloud.SecurityGroup, id: "sg-1234"},
+		{arn: "arn:aws:s3:::my-bucket", typ: cloud.Bucket, id: "my-bucket"},
+		{arn: "arn:aws:s3:::my-bucket/my/key", expError: true},
+		{arn: "arn:aws:iam::123456789012:role/deployer", typ: cloud.Role},
+		{arn: "arn:aws:iam::123456789012:user/division/jsmith", typ: cloud.User},
+		{arn: "arn:aws:sns:us-east-1:123456789012:my-topic", typ: cloud.Topic, id: "arn:aws:sns:us-east-1:123456789012:my-topic"},
+		{arn: "arn:aws:sns:us-east-1:123456789012:my-topic:6b0e71bd-7e97-4d97-80ce-4a0994e55286", typ: cloud.Subscription},
+		{arn: "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/app/my-lb/50dc6c495c0c9188", typ: cloud.LoadBalancer, id: "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/app/my-lb/50dc6c495c0c9188"},
+		{arn: "arn:aws:rds:us-east-1:123456789012:db:mydb", typ: cloud.Database, id: "mydb"},
+		{arn: "arn:aws:lambda:us-east-1:123456789012:function:my-func", typ: cloud.Function, id: "arn:aws:lambda:us-east-1:123456789012:function:my-func"},
+		{arn: "arn:aws:route53:::hostedzone/Z1D633PJN98FT9", typ: cloud.Zone, id: "/hostedzone/Z1D633PJN98FT9"},
+		{arn: "arn:aws:cloudfront::123456789012:distribution/E2QWRUHAPOMQZL", typ: cloud.Distribution, id: "E2QWRUHAPOMQZL"},
+		{arn: "arn:aws:kinesis:us-east-1:123456789012:stream/my-stream", typ: cloud.Stream, id: "arn:aws:kinesis:us-east-1:123456789012:stream/my-stream"},
+		{arn: "arn:aws:dynamodb:us-east-1:123456789012:table/my-table", expError: true},
+		{arn: "arn:aws:ec2:us-east-1:123456789012:unknown/x-1234", expError: true},
+	}
+	for _, tcase := range tcases {
+		parsed, err := ParseArn(tcase.arn)
+		if err != nil {
+			t.Fatalf("%s: %s", tcase.arn, err)
+		}
+		if got, want := parsed.String(), tcase.arn; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		typ, id, err := parsed.ResourceTypeAndId()
+		if tcase.expError {
+			if err == nil {
+				t.Fatalf("%s: expected error", tcase.arn)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %s", tcase.arn, err)
+		}
+		if typ != tcase.typ || id != tcase.id {
+			t.Fatalf("%s: got %s/%s, want %s/%s", tcase.arn, typ, id, tcase.typ, tcase.id)
+		}
+	}
+
+	for _, invalid := range []string{"i-8d43b21b", "arn:aws:s3", "arn::ec2:us-east-1:123456789012:instance/i-1"} {
+		if _, err := ParseArn(invalid); err == nil {
+			t.Fatalf("%s: expected error", invalid)
+		}
+	}
+}
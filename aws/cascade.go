@@ -0,0 +1,149 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+)
+
+// VpcCascadeTypes are the resource types fetched to find the dependents of a VPC
+var VpcCascadeTypes = []string{
+	cloud.Vpc, cloud.Instance, cloud.LoadBalancer, cloud.NatGateway, cloud.Subnet,
+	cloud.RouteTable, cloud.InternetGateway, cloud.SecurityGroup, cloud.DbSubnetGroup,
+}
+
+const cascadeWaitTimeout = 600
+
+// CascadeDeletion holds the dependents of a resource to delete before it, in deletion order,
+// and the dependents awless can not delete, to be handled before running the deletion
+type CascadeDeletion struct {
+	Resources   []*graph.Resource
+	Unsupported []string
+
+	commands []string
+}
+
+// Template returns the deletion of the dependents and of the resource as an awless template,
+// waiting for the dependents whose deletion is asynchronous to be gone before going on
+func (c *CascadeDeletion) Template() string {
+	return strings.Join(c.commands, "\n")
+}
+
+// VpcCascade returns the cascade deletion of a VPC given a graph holding the VpcCascadeTypes resources:
+// instances and load balancers are deleted first, then NAT gateways, subnets (which drops their route
+// table associations), non main route tables, internet gateways, non default security groups and the VPC.
+// The main route table and the default security group are deleted along with the VPC
+func VpcCascade(g *graph.Graph, vpc string) (*CascadeDeletion, error) {
+	res, err := g.GetResource(cloud.Vpc, vpc)
+	if err != nil {
+		return nil, fmt.Errorf("vpc '%s' not found", vpc)
+	}
+	if isDefault, _ := res.Properties[properties.Default].(bool); isDefault {
+		return nil, fmt.Errorf("vpc '%s' is the default vpc of the region: not deleting it", vpc)
+	}
+	c := &CascadeDeletion{}
+
+	instances := vpcResources(g, cloud.Instance, vpc, func(r *graph.Resource) bool {
+		state, _ := r.Properties[properties.State].(string)
+		return state != "terminated" && state != "shutting-down"
+	})
+	for _, r := range instances {
+		c.add(r, fmt.Sprintf("delete instance id=%s", r.Id()))
+	}
+	lbs := vpcResources(g, cloud.LoadBalancer, vpc, nil)
+	for _, r := range lbs {
+		c.add(r, fmt.Sprintf("delete loadbalancer id=%s", r.Id()))
+	}
+	for _, r := range instances {
+		c.commands = append(c.commands, fmt.Sprintf("check instance id=%s state=terminated timeout=%d", r.Id(), cascadeWaitTimeout))
+	}
+	for _, r := range lbs {
+		c.commands = append(c.commands, fmt.Sprintf("check loadbalancer id=%s state=not-found timeout=%d", r.Id(), cascadeWaitTimeout))
+	}
+
+	nats := vpcResources(g, cloud.NatGateway, vpc, func(r *graph.Resource) bool {
+		state, _ := r.Properties[properties.State].(string)
+		return state != "deleted" && state != "deleting"
+	})
+	for _, r := range nats {
+		c.add(r, fmt.Sprintf("delete natgateway id=%s", r.Id()))
+	}
+	for _, r := range nats {
+		c.commands = append(c.commands, fmt.Sprintf("check natgateway id=%s state=deleted timeout=%d", r.Id(), cascadeWaitTimeout))
+	}
+
+	for _, r := range vpcResources(g, cloud.DbSubnetGroup, vpc, nil) {
+		c.Unsupported = append(c.Unsupported, fmt.Sprintf("%s: delete its databases and itself first", r))
+	}
+
+	for _, r := range vpcResources(g, cloud.Subnet, vpc, nil) {
+		c.add(r, fmt.Sprintf("delete subnet id=%s", r.Id()))
+	}
+	for _, r := range vpcResources(g, cloud.RouteTable, vpc, func(r *graph.Resource) bool {
+		main, _ := r.Properties[properties.Main].(bool)
+		return !main
+	}) {
+		c.add(r, fmt.Sprintf("delete routetable id=%s", r.Id()))
+	}
+
+	igws, _ := g.GetAllResources(cloud.InternetGateway)
+	sort.Slice(igws, func(i, j int) bool { return igws[i].Id() < igws[j].Id() })
+	for _, r := range igws {
+		vpcs, _ := r.Properties[properties.Vpcs].([]string)
+		if !contains(vpcs, vpc) {
+			continue
+		}
+		c.commands = append(c.commands, fmt.Sprintf("detach internetgateway id=%s vpc=%s", r.Id(), vpc))
+		c.add(r, fmt.Sprintf("delete internetgateway id=%s", r.Id()))
+	}
+
+	for _, r := range vpcResources(g, cloud.SecurityGroup, vpc, func(r *graph.Resource) bool {
+		return r.Properties[properties.Name] != "default"
+	}) {
+		c.add(r, fmt.Sprintf("delete securitygroup id=%s", r.Id()))
+	}
+
+	c.commands = append(c.commands, fmt.Sprintf("delete vpc id=%s", vpc))
+
+	return c, nil
+}
+
+func (c *CascadeDeletion) add(r *graph.Resource, command string) {
+	c.Resources = append(c.Resources, r)
+	c.commands = append(c.commands, command)
+}
+
+// vpcResources returns the resources of the given type in the VPC selected by keep, if any, sorted by id
+func vpcResources(g *graph.Graph, typ, vpc string, keep func(*graph.Resource) bool) (found []*graph.Resource) {
+	all, _ := g.GetAllResources(typ)
+	for _, r := range all {
+		if r.Properties[properties.Vpc] != vpc {
+			continue
+		}
+		if keep == nil || keep(r) {
+			found = append(found, r)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].Id() < found[j].Id() })
+	return
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+	"github.com/wallix/awless/template"
+)
+
+func TestVpcCascade(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddResource(
+		resourcetest.VPC("vpc_1").Build(),
+		resourcetest.VPC("vpc_2").Build(),
+		resourcetest.VPC("vpc_default").Prop(properties.Default, true).Build(),
+		resourcetest.Instance("inst_1").Prop(properties.Vpc, "vpc_1").Prop(properties.State, "running").Build(),
+		resourcetest.Instance("inst_2").Prop(properties.Vpc, "vpc_1").Prop(properties.State, "terminated").Build(),
+		resourcetest.Instance("inst_3").Prop(properties.Vpc, "vpc_2").Prop(properties.State, "running").Build(),
+		resourcetest.LoadBalancer("lb_1").Prop(properties.Vpc, "vpc_1").Build(),
+		resourcetest.NatGw("nat_1").Prop(properties.Vpc, "vpc_1").Prop(properties.State, "available").Build(),
+		resourcetest.NatGw("nat_2").Prop(properties.Vpc, "vpc_1").Prop(properties.State, "deleted").Build(),
+		resourcetest.Subnet("sub_2").Prop(properties.Vpc, "vpc_1").Build(),
+		resourcetest.Subnet("sub_1").Prop(properties.Vpc, "vpc_1").Build(),
+		resourcetest.RouteTable("rt_main").Prop(properties.Vpc, "vpc_1").Prop(properties.Main, true).Build(),
+		resourcetest.RouteTable("rt_1").Prop(properties.Vpc, "vpc_1").Prop(properties.Main, false).Build(),
+		resourcetest.InternetGw("igw_1").Prop(properties.Vpcs, []string{"vpc_1"}).Build(),
+		resourcetest.InternetGw("igw_2").Prop(properties.Vpcs, []string{"vpc_2"}).Build(),
+		resourcetest.SecurityGroup("sg_default").Prop(properties.Vpc, "vpc_1").Prop(properties.Name, "default").Build(),
+		resourcetest.SecurityGroup("sg_1").Prop(properties.Vpc, "vpc_1").Prop(properties.Name, "web").Build(),
+	)
+
+	cascade, err := VpcCascade(g, "vpc_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"delete instance id=inst_1",
+		"delete loadbalancer id=lb_1",
+		"check instance id=inst_1 state=terminated timeout=600",
+		"check loadbalancer id=lb_1 state=not-found timeout=600",
+		"delete natgateway id=nat_1",
+		"check natgateway id=nat_1 state=deleted timeout=600",
+		"delete subnet id=sub_1",
+		"delete subnet id=sub_2",
+		"delete routetable id=rt_1",
+		"detach internetgateway id=igw_1 vpc=vpc_1",
+		"delete internetgateway id=igw_1",
+		"delete securitygroup id=sg_1",
+		"delete vpc id=vpc_1",
+	}
+	if got, want := cascade.Template(), strings.Join(expected, "\n"); got != want {
+		t.Fatalf("got\n%s\n\nwant\n%s", got, want)
+	}
+	if _, err := template.Parse(cascade.Template()); err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, r := range cascade.Resources {
+		ids = append(ids, r.Id())
+	}
+	if got, want := ids, []string{"inst_1", "lb_1", "nat_1", "sub_1", "sub_2", "rt_1", "igw_1", "sg_1"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if len(cascade.Unsupported) != 0 {
+		t.Fatalf("unexpected unsupported: %v", cascade.Unsupported)
+	}
+
+	g.AddResource(resourcetest.DbSubnetGroup("dbsub_1").Prop(properties.Vpc, "vpc_1").Build())
+	if cascade, err = VpcCascade(g, "vpc_1"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(cascade.Unsupported), 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+
+	if _, err = VpcCascade(g, "vpc_default"); err == nil {
+		t.Fatal("expected error for default vpc")
+	}
+	if _, err = VpcCascade(g, "vpc_unknown"); err == nil {
+		t.Fatal("expected error for unknown vpc")
+	}
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/aws/aws-sdk-go/private/protocol/jsonrpc"
+)
+
+// CloudTrailLookupMaxDays is how far back CloudTrail keeps the event history queried by LookupEvents
+const CloudTrailLookupMaxDays = 90
+
+var CloudTrail *CloudTrailClient
+
+// CloudTrailClient reads the management events history of the region from AWS CloudTrail.
+// The vendored SDK does not ship the CloudTrail service, so only the
+// LookupEvents call is implemented here on top of the generic SDK client
+type CloudTrailClient struct {
+	*client.Client
+}
+
+func NewCloudTrail(sess client.ConfigProvider) *CloudTrailClient {
+	c := sess.ClientConfig("cloudtrail")
+	ct := &CloudTrailClient{
+		Client: client.New(
+			*c.Config,
+			metadata.ClientInfo{
+				ServiceName:   "cloudtrail",
+				SigningName:   c.SigningName,
+				SigningRegion: c.SigningRegion,
+				Endpoint:      c.Endpoint,
+				APIVersion:    "2013-11-01",
+				JSONVersion:   "1.1",
+				TargetPrefix:  "com.amazonaws.cloudtrail.v20131101.CloudTrail_20131101",
+			},
+			c.Handlers,
+		),
+	}
+	ct.Handlers.Sign.PushBackNamed(v4.SignRequestHandler)
+	ct.Handlers.Build.PushBackNamed(jsonrpc.BuildHandler)
+	ct.Handlers.Unmarshal.PushBackNamed(jsonrpc.UnmarshalHandler)
+	ct.Handlers.UnmarshalMeta.PushBackNamed(jsonrpc.UnmarshalMetaHandler)
+	ct.Handlers.UnmarshalError.PushBackNamed(jsonrpc.UnmarshalErrorHandler)
+
+	return ct
+}
+
+// TrailEvent is an API call recorded by CloudTrail. SessionIssuer is the ARN
+// of the role whose temporary credentials made the call (empty for other identities)
+// and Actor the ARN of the identity that made it
+type TrailEvent struct {
+	ID            string
+	Time          time.Time
+	Source        string
+	Name          string
+	ErrorCode     string
+	SessionIssuer string
+	Actor         string
+	SourceIP      string
+	ReadOnly      bool
+}
+
+// LookupEvents returns the management events recorded from start to end
+func (ct *CloudTrailClient) LookupEvents(start, end time.Time) ([]*TrailEvent, error) {
+	return ct.lookup(&lookupEventsInput{
+		StartTime:  awssdk.Time(start),
+		EndTime:    awssdk.Time(end),
+		MaxResults: awssdk.Int64(50),
+	})
+}
+
+// ResourceTimeline returns in chronological order the write events recorded from start to end
+// on the resource known by any of the given names (ex: its id and its ARN)
+func (ct *CloudTrailClient) ResourceTimeline(names []string, start, end time.Time) ([]*TrailEvent, error) {
+	var all [][]*TrailEvent
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		events, err := ct.lookup(&lookupEventsInput{
+			LookupAttributes: []*lookupAttribute{{AttributeKey: awssdk.String("ResourceName"), AttributeValue: awssdk.String(name)}},
+			StartTime:        awssdk.Time(start),
+			EndTime:          awssdk.Time(end),
+			MaxResults:       awssdk.Int64(50),
+		})
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, events)
+	}
+	return mergeTimeline(all...), nil
+}
+
+// mergeTimeline merges events looked up with different names of a resource,
+// dropping read-only calls and events found several times
+func mergeTimeline(lists ...[]*TrailEvent) []*TrailEvent {
+	var timeline []*TrailEvent
+	seen := make(map[string]bool)
+	for _, events := range lists {
+		for _, e := range events {
+			if e.ReadOnly || seen[e.ID] {
+				continue
+			}
+			seen[e.ID] = true
+			timeline = append(timeline, e)
+		}
+	}
+	sort.SliceStable(timeline, func(i, j int) bool { return timeline[i].Time.Before(timeline[j].Time) })
+	return timeline
+}
+
+func (ct *CloudTrailClient) lookup(input *lookupEventsInput) ([]*TrailEvent, error) {
+	var events []*TrailEvent
+	for {
+		output := &lookupEventsOutput{}
+		op := &request.Operation{Name: "LookupEvents", HTTPMethod: "POST", HTTPPath: "/"}
+		if err := ct.NewRequest(op, input, output).Send(); err != nil {
+			return events, fmt.Errorf("cloudtrail: %w", err)
+		}
+		for _, e := range output.Events {
+			event, err := newTrailEvent(e)
+			if err != nil {
+				return events, err
+			}
+			events = append(events, event)
+		}
+		if awssdk.StringValue(output.NextToken) == "" {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	return events, nil
+}
+
+func newTrailEvent(e *cloudTrailEvent) (*TrailEvent, error) {
+	event := &TrailEvent{
+		ID:     awssdk.StringValue(e.EventId),
+		Time:   awssdk.TimeValue(e.EventTime),
+		Source: awssdk.StringValue(e.EventSource),
+		Name:   awssdk.StringValue(e.EventName),
+		Actor:  awssdk.StringValue(e.Username),
+	}
+	var record cloudTrailRecord
+	if err := json.Unmarshal([]byte(awssdk.StringValue(e.CloudTrailEvent)), &record); err != nil {
+		return nil, fmt.Errorf("cloudtrail: invalid event %s: %s", event.ID, err)
+	}
+	event.ErrorCode = record.ErrorCode
+	event.SessionIssuer = record.UserIdentity.SessionContext.SessionIssuer.Arn
+	event.SourceIP = record.SourceIPAddress
+	event.ReadOnly = record.ReadOnly
+	if record.UserIdentity.Arn != "" {
+		event.Actor = record.UserIdentity.Arn
+	}
+	return event, nil
+}
+
+type lookupEventsInput struct {
+	_ struct{} `type:"structure"`
+
+	LookupAttributes []*lookupAttribute `type:"list"`
+	StartTime        *time.Time         `type:"timestamp" timestampFormat:"unix"`
+	EndTime          *time.Time         `type:"timestamp" timestampFormat:"unix"`
+	MaxResults       *int64             `type:"integer"`
+	NextToken        *string            `type:"string"`
+}
+
+type lookupAttribute struct {
+	_ struct{} `type:"structure"`
+
+	AttributeKey   *string `type:"string"`
+	AttributeValue *string `type:"string"`
+}
+
+type lookupEventsOutput struct {
+	_ struct{} `type:"structure"`
+
+	Events    []*cloudTrailEvent `type:"list"`
+	NextToken *string            `type:"string"`
+}
+
+type cloudTrailEvent struct {
+	_ struct{} `type:"structure"`
+
+	EventId         *string    `type:"string"`
+	EventName       *string    `type:"string"`
+	EventSource     *string    `type:"string"`
+	EventTime       *time.Time `type:"timestamp" timestampFormat:"unix"`
+	Username        *string    `type:"string"`
+	CloudTrailEvent *string    `type:"string"`
+}
+
+// cloudTrailRecord is the subset of the JSON record of an event used by awless
+type cloudTrailRecord struct {
+	ErrorCode       string `json:"errorCode"`
+	SourceIPAddress string `json:"sourceIPAddress"`
+	ReadOnly        bool   `json:"readOnly"`
+	UserIdentity    struct {
+		Arn            string `json:"arn"`
+		SessionContext struct {
+			SessionIssuer struct {
+				Arn string `json:"arn"`
+			} `json:"sessionIssuer"`
+		} `json:"sessionContext"`
+	} `json:"userIdentity"`
+}
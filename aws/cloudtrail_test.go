@@ -0,0 +1,107 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+func TestCloudTrailResourceTimeline(t *testing.T) {
+	responses := map[string]string{
+		"i-1234": `{"Events":[
+			{"EventId":"2","EventName":"StopInstances","EventSource":"ec2.amazonaws.com","EventTime":1500000200,"Username":"jsmith",
+			 "CloudTrailEvent":"{\"readOnly\":false,\"sourceIPAddress\":\"1.2.3.4\",\"userIdentity\":{\"arn\":\"arn:aws:iam::123456789012:user/jsmith\"}}"},
+			{"EventId":"3","EventName":"DescribeInstances","EventSource":"ec2.amazonaws.com","EventTime":1500000300,
+			 "CloudTrailEvent":"{\"readOnly\":true}"},
+			{"EventId":"1","EventName":"RunInstances","EventSource":"ec2.amazonaws.com","EventTime":1500000100,"Username":"deployer",
+			 "CloudTrailEvent":"{\"readOnly\":false,\"errorCode\":\"\",\"userIdentity\":{\"arn\":\"arn:aws:sts::123456789012:assumed-role/deployer/session\"}}"}
+		]}`,
+		"arn:aws:ec2:us-west-1:123456789012:instance/i-1234": `{"Events":[
+			{"EventId":"2","EventName":"StopInstances","EventSource":"ec2.amazonaws.com","EventTime":1500000200,"CloudTrailEvent":"{}"},
+			{"EventId":"4","EventName":"TerminateInstances","EventSource":"ec2.amazonaws.com","EventTime":1500000400,"Username":"jsmith",
+			 "CloudTrailEvent":"{\"errorCode\":\"UnauthorizedOperation\"}"}
+		]}`,
+	}
+	var lookedUp []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.Header.Get("X-Amz-Target"), "com.amazonaws.cloudtrail.v20131101.CloudTrail_20131101.LookupEvents"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		b, _ := ioutil.ReadAll(r.Body)
+		var input struct {
+			LookupAttributes []struct{ AttributeKey, AttributeValue string }
+		}
+		if err := json.Unmarshal(b, &input); err != nil {
+			t.Fatal(err)
+		}
+		if len(input.LookupAttributes) != 1 || input.LookupAttributes[0].AttributeKey != "ResourceName" {
+			t.Fatalf("unexpected lookup attributes: %s", b)
+		}
+		name := input.LookupAttributes[0].AttributeValue
+		lookedUp = append(lookedUp, name)
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		w.Write([]byte(responses[name]))
+	}))
+	defer server.Close()
+
+	sess := session.New(&awssdk.Config{
+		Endpoint:    awssdk.String(server.URL),
+		Region:      awssdk.String("us-west-1"),
+		Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+	})
+	ct := NewCloudTrail(sess)
+
+	names := []string{"i-1234", "", "arn:aws:ec2:us-west-1:123456789012:instance/i-1234"}
+	timeline, err := ct.ResourceTimeline(names, time.Unix(1500000000, 0), time.Unix(1500001000, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := lookedUp, []string{"i-1234", "arn:aws:ec2:us-west-1:123456789012:instance/i-1234"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	var ids, actors []string
+	for _, e := range timeline {
+		ids = append(ids, e.ID)
+		actors = append(actors, e.Actor)
+	}
+	if got, want := ids, []string{"1", "2", "4"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := actors, []string{"arn:aws:sts::123456789012:assumed-role/deployer/session", "arn:aws:iam::123456789012:user/jsmith", "jsmith"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := timeline[1].SourceIP, "1.2.3.4"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := timeline[2].ErrorCode, "UnauthorizedOperation"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := timeline[0].Time, time.Unix(1500000100, 0); !got.Equal(want) {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
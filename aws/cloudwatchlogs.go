@@ -0,0 +1,120 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/aws/aws-sdk-go/private/protocol/jsonrpc"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+)
+
+var CloudWatchLogs *CloudWatchLogsClient
+
+// LogEventsAPI filters the events of a CloudWatch Logs log group
+type LogEventsAPI interface {
+	FilterLogEvents(*FilterLogEventsInput) (*FilterLogEventsOutput, error)
+}
+
+// CloudWatchLogsClient reads the log events of the region from AWS CloudWatch Logs.
+// The vendored SDK does not ship the CloudWatch Logs service, so only the
+// FilterLogEvents call is implemented here on top of the generic SDK client
+type CloudWatchLogsClient struct {
+	*client.Client
+}
+
+func NewCloudWatchLogs(sess client.ConfigProvider) *CloudWatchLogsClient {
+	c := sess.ClientConfig("logs")
+	cl := &CloudWatchLogsClient{
+		Client: client.New(
+			*c.Config,
+			metadata.ClientInfo{
+				ServiceName:   "logs",
+				SigningName:   c.SigningName,
+				SigningRegion: c.SigningRegion,
+				Endpoint:      c.Endpoint,
+				APIVersion:    "2014-03-28",
+				JSONVersion:   "1.1",
+				TargetPrefix:  "Logs_20140328",
+			},
+			c.Handlers,
+		),
+	}
+	cl.Handlers.Sign.PushBackNamed(v4.SignRequestHandler)
+	cl.Handlers.Build.PushBackNamed(jsonrpc.BuildHandler)
+	cl.Handlers.Unmarshal.PushBackNamed(jsonrpc.UnmarshalHandler)
+	cl.Handlers.UnmarshalMeta.PushBackNamed(jsonrpc.UnmarshalMetaHandler)
+	cl.Handlers.UnmarshalError.PushBackNamed(jsonrpc.UnmarshalErrorHandler)
+
+	return cl
+}
+
+func (cl *CloudWatchLogsClient) FilterLogEvents(input *FilterLogEventsInput) (*FilterLogEventsOutput, error) {
+	output := &FilterLogEventsOutput{}
+	op := &request.Operation{Name: "FilterLogEvents", HTTPMethod: "POST", HTTPPath: "/"}
+	return output, cl.NewRequest(op, input, output).Send()
+}
+
+// LogGroupOf returns the log group in which a resource writes its logs (ex: /aws/lambda/my-function)
+func LogGroupOf(res *graph.Resource) (string, error) {
+	if name, _ := res.Properties[properties.Name].(string); res.Type() == cloud.Function && name != "" {
+		return "/aws/lambda/" + name, nil
+	}
+	return "", fmt.Errorf("cannot resolve the log group of %s: give the log group name (ex: /aws/lambda/my-function)", res)
+}
+
+// IsLogGroupName returns true if the reference is a log group name rather than a resource reference
+func IsLogGroupName(ref string) bool {
+	return strings.HasPrefix(ref, "/")
+}
+
+type FilterLogEventsInput struct {
+	_ struct{} `type:"structure"`
+
+	EndTime        *int64    `locationName:"endTime" type:"long"`
+	FilterPattern  *string   `locationName:"filterPattern" type:"string"`
+	Interleaved    *bool     `locationName:"interleaved" type:"boolean"`
+	Limit          *int64    `locationName:"limit" type:"integer"`
+	LogGroupName   *string   `locationName:"logGroupName" type:"string" required:"true"`
+	LogStreamNames []*string `locationName:"logStreamNames" type:"list"`
+	NextToken      *string   `locationName:"nextToken" type:"string"`
+	StartTime      *int64    `locationName:"startTime" type:"long"`
+}
+
+type FilterLogEventsOutput struct {
+	_ struct{} `type:"structure"`
+
+	Events    []*FilteredLogEvent `locationName:"events" type:"list"`
+	NextToken *string             `locationName:"nextToken" type:"string"`
+}
+
+type FilteredLogEvent struct {
+	_ struct{} `type:"structure"`
+
+	EventId       *string `locationName:"eventId" type:"string"`
+	IngestionTime *int64  `locationName:"ingestionTime" type:"long"`
+	LogStreamName *string `locationName:"logStreamName" type:"string"`
+	Message       *string `locationName:"message" type:"string"`
+	Timestamp     *int64  `locationName:"timestamp" type:"long"`
+}
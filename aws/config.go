@@ -22,3 +22,10 @@ func (c config) getBool(key string, def bool) bool {
 	}
 	return def
 }
+
+func (c config) getString(key string) string {
+	if s, ok := c[key].(string); ok {
+		return s
+	}
+	return ""
+}
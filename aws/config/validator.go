@@ -11,7 +11,13 @@ import (
 	"github.com/aws/aws-sdk-go/aws/endpoints"
 )
 
+// AllEnabledRegions is the region value standing, when syncing, for every region enabled for the account
+const AllEnabledRegions = "all"
+
 func ParseRegion(i string) (interface{}, error) {
+	if i == AllEnabledRegions {
+		return i, nil
+	}
 	if !IsValidRegion(i) {
 		return i, fmt.Errorf("'%s' is not a valid region", i)
 	}
@@ -34,7 +40,7 @@ func StdinRegionSelector() string {
 	fmt.Println("Please choose one region:")
 	var region string
 
-	fmt.Println(strings.Join(allRegions(), ", "))
+	fmt.Println(strings.Join(AllRegions(), ", "))
 	fmt.Println()
 	fmt.Print("Value ? > ")
 	fmt.Scan(&region)
@@ -91,7 +97,8 @@ func isValidInstanceType(given string) bool {
 	return regexp.MustCompile("\\w+\\.\\w+").MatchString(given)
 }
 
-func allRegions() []string {
+// AllRegions returns the ids of all the regions known to the SDK, sorted
+func AllRegions() []string {
 	var regions sort.StringSlice
 	partitions := endpoints.DefaultResolver().(endpoints.EnumPartitions).Partitions()
 	for _, p := range partitions {
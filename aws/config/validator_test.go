@@ -5,19 +5,19 @@ import (
 )
 
 func TestRegionsValid(t *testing.T) {
-	if got, want := stringInSlice("eu-west-1", allRegions()), true; got != want {
+	if got, want := stringInSlice("eu-west-1", AllRegions()), true; got != want {
 		t.Errorf("got %t, want %t", got, want)
 	}
-	if got, want := stringInSlice("us-east-1", allRegions()), true; got != want {
+	if got, want := stringInSlice("us-east-1", AllRegions()), true; got != want {
 		t.Errorf("got %t, want %t", got, want)
 	}
-	if got, want := stringInSlice("us-west-1", allRegions()), true; got != want {
+	if got, want := stringInSlice("us-west-1", AllRegions()), true; got != want {
 		t.Errorf("got %t, want %t", got, want)
 	}
-	if got, want := stringInSlice("eu-test-1", allRegions()), false; got != want {
+	if got, want := stringInSlice("eu-test-1", AllRegions()), false; got != want {
 		t.Errorf("got %t, want %t", got, want)
 	}
-	for _, k := range allRegions() {
+	for _, k := range AllRegions() {
 		if got, want := IsValidRegion(k), true; got != want {
 			t.Errorf("got %t, want %t", got, want)
 		}
@@ -25,6 +25,12 @@ func TestRegionsValid(t *testing.T) {
 	if got, want := IsValidRegion("aa-test-10"), false; got != want {
 		t.Errorf("got %t, want %t", got, want)
 	}
+	if _, err := ParseRegion(AllEnabledRegions); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if _, err := ParseRegion("aa-test-10"); err == nil {
+		t.Error("expected error")
+	}
 }
 
 func TestInstanceTypeValid(t *testing.T) {
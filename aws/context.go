@@ -0,0 +1,56 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+var (
+	requestsContext atomic.Value // holds a requestsCtx
+	lastOperation   atomic.Value
+)
+
+type requestsCtx struct {
+	context.Context
+}
+
+// SetRequestsContext bounds all the AWS API calls of the services and drivers
+// (fetches, actions and waiters): calls are canceled once ctx is done
+func SetRequestsContext(ctx context.Context) {
+	requestsContext.Store(requestsCtx{ctx})
+}
+
+// LastOperation returns the last AWS API call started (ex: ec2.DescribeInstances)
+func LastOperation() string {
+	op, _ := lastOperation.Load().(string)
+	return op
+}
+
+// ContextHandler sets the requests context of the API calls and records them as last operation
+var ContextHandler = request.NamedHandler{
+	Name: "awless.ContextHandler",
+	Fn: func(r *request.Request) {
+		if ctx, ok := requestsContext.Load().(requestsCtx); ok {
+			r.SetContext(ctx.Context)
+		}
+		lastOperation.Store(r.ClientInfo.ServiceName + "." + r.Operation.Name)
+	},
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+func TestContextHandler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	SetRequestsContext(ctx)
+	defer SetRequestsContext(context.Background())
+
+	var handlers request.Handlers
+	handlers.Validate.PushBackNamed(ContextHandler)
+	req := request.New(awssdk.Config{}, metadata.ClientInfo{ServiceName: "ec2"}, handlers, nil, &request.Operation{Name: "DescribeInstances"}, nil, nil)
+
+	if err := req.Send(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := req.Context(), awssdk.Context(ctx); got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := LastOperation(), "ec2.DescribeInstances"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,235 @@
+package aws
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/aws/aws-sdk-go/private/protocol/jsonrpc"
+)
+
+// The Cost Explorer API is only served from us-east-1, whatever the region of the resources
+const costExplorerRegion = "us-east-1"
+
+var CostExplorer *CostExplorerClient
+
+// CostExplorerClient reads the account spending from AWS Cost Explorer.
+// The vendored SDK does not ship the Cost Explorer service, so only the
+// GetCostAndUsage and ListCostAllocationTags calls are implemented here
+// on top of the generic SDK client
+type CostExplorerClient struct {
+	*client.Client
+}
+
+func NewCostExplorer(sess client.ConfigProvider) *CostExplorerClient {
+	c := sess.ClientConfig("ce", awssdk.NewConfig().WithRegion(costExplorerRegion))
+	ce := &CostExplorerClient{
+		Client: client.New(
+			*c.Config,
+			metadata.ClientInfo{
+				ServiceName:   "ce",
+				SigningName:   c.SigningName,
+				SigningRegion: c.SigningRegion,
+				Endpoint:      c.Endpoint,
+				APIVersion:    "2017-10-25",
+				JSONVersion:   "1.1",
+				TargetPrefix:  "AWSInsightsIndexService",
+			},
+			c.Handlers,
+		),
+	}
+	ce.Handlers.Sign.PushBackNamed(v4.SignRequestHandler)
+	ce.Handlers.Build.PushBackNamed(jsonrpc.BuildHandler)
+	ce.Handlers.Unmarshal.PushBackNamed(jsonrpc.UnmarshalHandler)
+	ce.Handlers.UnmarshalMeta.PushBackNamed(jsonrpc.UnmarshalMetaHandler)
+	ce.Handlers.UnmarshalError.PushBackNamed(jsonrpc.UnmarshalErrorHandler)
+
+	return ce
+}
+
+// ServiceCost is the cost of a service for one day. Estimated is set while
+// Cost Explorer has not finalized the cost of the day (i.e. the last days)
+type ServiceCost struct {
+	Service   string
+	Day       time.Time
+	Amount    float64
+	Unit      string
+	Estimated bool
+}
+
+// DailyCostByService returns the unblended cost per service and per day
+// from start (inclusive) to end (exclusive)
+func (ce *CostExplorerClient) DailyCostByService(start, end time.Time) ([]*ServiceCost, error) {
+	input := &getCostAndUsageInput{
+		TimePeriod:  &costDateInterval{Start: awssdk.String(start.Format(costDayLayout)), End: awssdk.String(end.Format(costDayLayout))},
+		Granularity: awssdk.String("DAILY"),
+		Metrics:     []*string{awssdk.String(costMetric)},
+		GroupBy:     []*costGroupDefinition{{Type: awssdk.String("DIMENSION"), Key: awssdk.String("SERVICE")}},
+	}
+
+	var costs []*ServiceCost
+	for {
+		output := &getCostAndUsageOutput{}
+		op := &request.Operation{Name: "GetCostAndUsage", HTTPMethod: "POST", HTTPPath: "/"}
+		if err := ce.NewRequest(op, input, output).Send(); err != nil {
+			return costs, fmt.Errorf("cost explorer: %w", err)
+		}
+		for _, result := range output.ResultsByTime {
+			day, err := time.Parse(costDayLayout, awssdk.StringValue(result.TimePeriod.Start))
+			if err != nil {
+				return costs, fmt.Errorf("cost explorer: %s", err)
+			}
+			for _, group := range result.Groups {
+				if len(group.Keys) == 0 {
+					continue
+				}
+				metric, ok := group.Metrics[costMetric]
+				if !ok {
+					continue
+				}
+				amount, err := strconv.ParseFloat(awssdk.StringValue(metric.Amount), 64)
+				if err != nil {
+					return costs, fmt.Errorf("cost explorer: invalid amount for %s: %s", awssdk.StringValue(group.Keys[0]), err)
+				}
+				costs = append(costs, &ServiceCost{
+					Service:   awssdk.StringValue(group.Keys[0]),
+					Day:       day,
+					Amount:    amount,
+					Unit:      awssdk.StringValue(metric.Unit),
+					Estimated: awssdk.BoolValue(result.Estimated),
+				})
+			}
+		}
+		if awssdk.StringValue(output.NextPageToken) == "" {
+			break
+		}
+		input.NextPageToken = output.NextPageToken
+	}
+
+	return costs, nil
+}
+
+const (
+	costDayLayout = "2006-01-02"
+	costMetric    = "UnblendedCost"
+)
+
+type getCostAndUsageInput struct {
+	_ struct{} `type:"structure"`
+
+	TimePeriod    *costDateInterval      `type:"structure" required:"true"`
+	Granularity   *string                `type:"string"`
+	Metrics       []*string              `type:"list"`
+	GroupBy       []*costGroupDefinition `type:"list"`
+	NextPageToken *string                `type:"string"`
+}
+
+type costDateInterval struct {
+	_ struct{} `type:"structure"`
+
+	Start *string `type:"string" required:"true"`
+	End   *string `type:"string" required:"true"`
+}
+
+type costGroupDefinition struct {
+	_ struct{} `type:"structure"`
+
+	Type *string `type:"string"`
+	Key  *string `type:"string"`
+}
+
+type getCostAndUsageOutput struct {
+	_ struct{} `type:"structure"`
+
+	ResultsByTime []*costResultByTime `type:"list"`
+	NextPageToken *string             `type:"string"`
+}
+
+type costResultByTime struct {
+	_ struct{} `type:"structure"`
+
+	TimePeriod *costDateInterval `type:"structure"`
+	Groups     []*costGroup      `type:"list"`
+	Estimated  *bool             `type:"boolean"`
+}
+
+type costGroup struct {
+	_ struct{} `type:"structure"`
+
+	Keys    []*string                   `type:"list"`
+	Metrics map[string]*costMetricValue `type:"map"`
+}
+
+type costMetricValue struct {
+	_ struct{} `type:"structure"`
+
+	Amount *string `type:"string"`
+	Unit   *string `type:"string"`
+}
+
+// CostAllocationTag is a tag key known to billing. Only the keys with an Active
+// status break down the costs in Cost Explorer and in the cost and usage reports
+type CostAllocationTag struct {
+	Key    string `json:"key"`
+	Type   string `json:"type"`
+	Status string `json:"status"`
+}
+
+func (t *CostAllocationTag) Active() bool {
+	return t.Status == "Active"
+}
+
+// CostAllocationTags returns the user-defined and AWS generated tag keys
+// known to billing at the account level, with their activation status
+func (ce *CostExplorerClient) CostAllocationTags() ([]*CostAllocationTag, error) {
+	input := &listCostAllocationTagsInput{MaxResults: awssdk.Int64(1000)}
+
+	var tags []*CostAllocationTag
+	for {
+		output := &listCostAllocationTagsOutput{}
+		op := &request.Operation{Name: "ListCostAllocationTags", HTTPMethod: "POST", HTTPPath: "/"}
+		if err := ce.NewRequest(op, input, output).Send(); err != nil {
+			return tags, fmt.Errorf("cost explorer: %w", err)
+		}
+		for _, t := range output.CostAllocationTags {
+			tags = append(tags, &CostAllocationTag{
+				Key:    awssdk.StringValue(t.TagKey),
+				Type:   awssdk.StringValue(t.Type),
+				Status: awssdk.StringValue(t.Status),
+			})
+		}
+		if awssdk.StringValue(output.NextToken) == "" {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	return tags, nil
+}
+
+type listCostAllocationTagsInput struct {
+	_ struct{} `type:"structure"`
+
+	MaxResults *int64  `type:"integer"`
+	NextToken  *string `type:"string"`
+}
+
+type listCostAllocationTagsOutput struct {
+	_ struct{} `type:"structure"`
+
+	CostAllocationTags []*costAllocationTag `type:"list"`
+	NextToken          *string              `type:"string"`
+}
+
+type costAllocationTag struct {
+	_ struct{} `type:"structure"`
+
+	TagKey *string `type:"string"`
+	Type   *string `type:"string"`
+	Status *string `type:"string"`
+}
@@ -0,0 +1,359 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/go-ini/ini"
+)
+
+const (
+	processProviderName = "ProcessProvider"
+
+	defaultRoleSessionName = "awless"
+
+	// CredentialsRefreshWindowConfigKey sets how long before they actually expire credentials are renewed
+	CredentialsRefreshWindowConfigKey = "aws.credentials.refresh.window"
+
+	defaultCredentialsRefreshWindow = 5 * time.Minute
+)
+
+var (
+	credentialsRefreshWindow = defaultCredentialsRefreshWindow
+
+	// Lifetime of the credentials of the roles assumed by awless
+	assumeRoleDuration = stscreds.DefaultDuration
+)
+
+// SetCredentialsRefreshWindow sets how long before they actually expire the temporary
+// credentials of credential_process and assumed roles are renewed
+func SetCredentialsRefreshWindow(window time.Duration) {
+	credentialsRefreshWindow = window
+}
+
+// CredentialsRefreshWindow returns the credentials refresh window set in config (default to 5 minutes)
+func CredentialsRefreshWindow(conf map[string]interface{}) (time.Duration, error) {
+	str, _ := conf[CredentialsRefreshWindowConfigKey].(string)
+	if str == "" {
+		return defaultCredentialsRefreshWindow, nil
+	}
+	window, err := time.ParseDuration(str)
+	if err != nil {
+		return defaultCredentialsRefreshWindow, fmt.Errorf("config %s: %s", CredentialsRefreshWindowConfigKey, err)
+	}
+	if window < 0 {
+		return defaultCredentialsRefreshWindow, fmt.Errorf("config %s: negative window %s", CredentialsRefreshWindowConfigKey, window)
+	}
+	// the lifetime of credential_process credentials is only known once retrieved: those
+	// expiring within the window are used once then renewed on the next call
+	if err := validRefreshWindow(window, assumeRoleDuration); err != nil {
+		return defaultCredentialsRefreshWindow, fmt.Errorf("config %s: %s of assumed roles", CredentialsRefreshWindowConfigKey, err)
+	}
+	return window, nil
+}
+
+// validRefreshWindow checks that credentials valid for the given lifetime are not renewed
+// as soon as retrieved, i.e. that the refresh window is less than their lifetime
+func validRefreshWindow(window, lifetime time.Duration) error {
+	if window >= lifetime {
+		return fmt.Errorf("refresh window %s is not less than the credentials lifetime %s", window, lifetime)
+	}
+	return nil
+}
+
+// credentialProcess returns the `credential_process` command configured for the profile
+// in the AWS shared config or credentials files, if any
+func credentialProcess(profile string) string {
+	return sharedConfigValue(profile, "credential_process")
+}
+
+// ProfileRegion returns the region set for the profile in the AWS shared config, if any
+func ProfileRegion(profile string) string {
+	return sharedConfigValue(profile, "region")
+}
+
+// sharedConfigValue returns the value of the key for the profile in the AWS shared
+// credentials or config files, if any
+func sharedConfigValue(profile, key string) string {
+	profile = sharedConfigProfile(profile)
+
+	configFile := os.Getenv("AWS_CONFIG_FILE")
+	if configFile == "" {
+		configFile = filepath.Join(os.Getenv("HOME"), ".aws", "config")
+	}
+	credentialsFile := os.Getenv("AWS_SHARED_CREDENTIALS_FILE")
+	if credentialsFile == "" {
+		credentialsFile = filepath.Join(os.Getenv("HOME"), ".aws", "credentials")
+	}
+
+	lookups := []struct{ file, section string }{
+		{credentialsFile, profile},
+		{configFile, "profile " + profile},
+	}
+	if profile == "default" {
+		lookups = append(lookups, struct{ file, section string }{configFile, "default"})
+	}
+
+	for _, l := range lookups {
+		f, err := ini.Load(l.file)
+		if err != nil {
+			continue
+		}
+		section, err := f.GetSection(l.section)
+		if err != nil {
+			continue
+		}
+		if value := strings.TrimSpace(section.Key(key).String()); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// roleAssumption holds the parameters of the role a profile assumes
+// (`role_arn`, `external_id`, `role_session_name`) from its source profile
+type roleAssumption struct {
+	RoleARN       string
+	ExternalID    string
+	SessionName   string
+	SourceProfile string
+}
+
+// profileRoleAssumption returns the role assumed by the profile in the AWS shared config, if any
+func profileRoleAssumption(profile string) *roleAssumption {
+	role := &roleAssumption{
+		RoleARN:       sharedConfigValue(profile, "role_arn"),
+		ExternalID:    sharedConfigValue(profile, "external_id"),
+		SessionName:   sharedConfigValue(profile, "role_session_name"),
+		SourceProfile: sharedConfigValue(profile, "source_profile"),
+	}
+	if role.RoleARN == "" || role.SourceProfile == "" {
+		return nil
+	}
+	if role.SessionName == "" {
+		role.SessionName = defaultRoleSessionName
+	}
+	return role
+}
+
+// credentialsCacheKey identifies the cached credentials of a profile. When a role is assumed
+// all its parameters are part of the key, so that credentials of distinct roles never collide
+func credentialsCacheKey(profile string, role *roleAssumption) string {
+	profile = sharedConfigProfile(profile)
+	if role == nil {
+		return profile
+	}
+	h := sha256.New()
+	for _, param := range []string{role.SourceProfile, role.RoleARN, role.ExternalID, role.SessionName} {
+		h.Write([]byte(param))
+		h.Write([]byte{0})
+	}
+	return fmt.Sprintf("%s-%x", profile, h.Sum(nil)[:8])
+}
+
+func sharedConfigProfile(profile string) string {
+	if profile == "" {
+		profile = os.Getenv("AWS_PROFILE")
+	}
+	if profile == "" {
+		profile = "default"
+	}
+	return profile
+}
+
+// processProvider retrieves credentials from the output of an external command,
+// as specified by the `credential_process` setting of the AWS shared config
+type processProvider struct {
+	credentials.Expiry
+	command    string
+	expiration time.Time
+}
+
+type processCredentials struct {
+	Version         int
+	AccessKeyId     string
+	SecretAccessKey string
+	SessionToken    string
+	Expiration      *time.Time `json:",omitempty"`
+	ProviderName    string     `json:",omitempty"`
+}
+
+func (p *processProvider) Retrieve() (credentials.Value, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd.exe", "/C", p.command)
+	} else {
+		cmd = exec.Command("sh", "-c", p.command)
+	}
+	var stderr bytes.Buffer
+	cmd.Stdin = os.Stdin
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return credentials.Value{ProviderName: processProviderName}, fmt.Errorf("credential_process '%s': %s %s", p.command, err, strings.TrimSpace(stderr.String()))
+	}
+
+	creds := &processCredentials{}
+	if err = json.Unmarshal(out, creds); err != nil {
+		return credentials.Value{ProviderName: processProviderName}, fmt.Errorf("credential_process '%s': invalid output: %s", p.command, err)
+	}
+	if creds.Version != 1 {
+		return credentials.Value{ProviderName: processProviderName}, fmt.Errorf("credential_process '%s': unsupported version %d", p.command, creds.Version)
+	}
+	if creds.AccessKeyId == "" || creds.SecretAccessKey == "" {
+		return credentials.Value{ProviderName: processProviderName}, fmt.Errorf("credential_process '%s': missing access key id or secret access key", p.command)
+	}
+
+	p.expiration = time.Time{}
+	if creds.Expiration != nil {
+		p.expiration = *creds.Expiration
+		p.SetExpiration(p.expiration, credentialsRefreshWindow)
+	} else {
+		p.SetExpiration(time.Now().AddDate(100, 0, 0), 0)
+	}
+
+	return credentials.Value{
+		AccessKeyID:     creds.AccessKeyId,
+		SecretAccessKey: creds.SecretAccessKey,
+		SessionToken:    creds.SessionToken,
+		ProviderName:    processProviderName,
+	}, nil
+}
+
+// ExpiresAt returns the expiration of the last retrieved credentials (zero when they do not expire)
+func (p *processProvider) ExpiresAt() time.Time {
+	return p.expiration
+}
+
+type expiringProvider interface {
+	credentials.Provider
+	ExpiresAt() time.Time
+}
+
+// assumeRoleProvider retrieves the credentials of a role assumed with STS
+type assumeRoleProvider struct {
+	credentials.Expiry
+	client     stscreds.AssumeRoler
+	role       *roleAssumption
+	expiration time.Time
+}
+
+func (p *assumeRoleProvider) Retrieve() (credentials.Value, error) {
+	input := &sts.AssumeRoleInput{
+		RoleArn:         awssdk.String(p.role.RoleARN),
+		RoleSessionName: awssdk.String(p.role.SessionName),
+		DurationSeconds: awssdk.Int64(int64(assumeRoleDuration / time.Second)),
+	}
+	if p.role.ExternalID != "" {
+		input.ExternalId = awssdk.String(p.role.ExternalID)
+	}
+
+	out, err := p.client.AssumeRole(input)
+	if err != nil {
+		return credentials.Value{ProviderName: stscreds.ProviderName}, fmt.Errorf("assume role '%s': %s", p.role.RoleARN, err)
+	}
+
+	p.expiration = awssdk.TimeValue(out.Credentials.Expiration)
+	p.SetExpiration(p.expiration, credentialsRefreshWindow)
+
+	return credentials.Value{
+		AccessKeyID:     awssdk.StringValue(out.Credentials.AccessKeyId),
+		SecretAccessKey: awssdk.StringValue(out.Credentials.SecretAccessKey),
+		SessionToken:    awssdk.StringValue(out.Credentials.SessionToken),
+		ProviderName:    stscreds.ProviderName,
+	}, nil
+}
+
+func (p *assumeRoleProvider) ExpiresAt() time.Time {
+	return p.expiration
+}
+
+// fileCacheProvider caches on disk, under a key (see credentialsCacheKey), the credentials of a provider until
+// they expire, so that they are shared between awless runs. Credentials without expiration are not cached
+type fileCacheProvider struct {
+	credentials.Expiry
+	provider expiringProvider
+	path     string
+}
+
+func newFileCacheProvider(dir, key string, provider expiringProvider) *fileCacheProvider {
+	return &fileCacheProvider{provider: provider, path: filepath.Join(dir, key+".json")}
+}
+
+func (f *fileCacheProvider) Retrieve() (credentials.Value, error) {
+	if cached, err := f.readCache(); err == nil && cached.Expiration != nil && time.Now().Add(credentialsRefreshWindow).Before(*cached.Expiration) {
+		f.SetExpiration(*cached.Expiration, credentialsRefreshWindow)
+		return credentials.Value{
+			AccessKeyID:     cached.AccessKeyId,
+			SecretAccessKey: cached.SecretAccessKey,
+			SessionToken:    cached.SessionToken,
+			ProviderName:    cached.ProviderName,
+		}, nil
+	}
+
+	value, err := f.provider.Retrieve()
+	if err != nil {
+		return value, err
+	}
+
+	expiration := f.provider.ExpiresAt()
+	if expiration.IsZero() {
+		f.SetExpiration(time.Now().AddDate(100, 0, 0), 0)
+		return value, nil
+	}
+	f.SetExpiration(expiration, credentialsRefreshWindow)
+
+	f.writeCache(&processCredentials{Version: 1, AccessKeyId: value.AccessKeyID, SecretAccessKey: value.SecretAccessKey, SessionToken: value.SessionToken, Expiration: &expiration, ProviderName: value.ProviderName})
+
+	return value, nil
+}
+
+func (f *fileCacheProvider) readCache() (*processCredentials, error) {
+	b, err := ioutil.ReadFile(f.path)
+	if err != nil {
+		return nil, err
+	}
+	creds := &processCredentials{}
+	return creds, json.Unmarshal(b, creds)
+}
+
+func (f *fileCacheProvider) writeCache(creds *processCredentials) {
+	b, err := json.Marshal(creds)
+	if err != nil {
+		return
+	}
+	if err = os.MkdirAll(filepath.Dir(f.path), 0700); err != nil {
+		return
+	}
+	ioutil.WriteFile(f.path, b, 0600)
+}
@@ -0,0 +1,420 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/wallix/awless/logger"
+)
+
+func TestCredentialProcess(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake credential process is a shell script")
+	}
+	dir, err := ioutil.TempDir("", "awless-credentials")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	calls := filepath.Join(dir, "calls")
+	fakeProcess := func(name string, expiration time.Time) string {
+		path := filepath.Join(dir, name)
+		expires := ""
+		if !expiration.IsZero() {
+			expires = fmt.Sprintf(`, "Expiration": "%s"`, expiration.Format(time.RFC3339))
+		}
+		script := fmt.Sprintf(`#!/bin/sh
+echo call >> %s
+echo '{"Version": 1, "AccessKeyId": "AKID", "SecretAccessKey": "SECRET", "SessionToken": "TOKEN"%s}'
+`, calls, expires)
+		if err := ioutil.WriteFile(path, []byte(script), 0700); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	countCalls := func() int {
+		b, _ := ioutil.ReadFile(calls)
+		return strings.Count(string(b), "call")
+	}
+
+	valid := fakeProcess("valid", time.Now().Add(time.Hour))
+	expired := fakeProcess("expired", time.Now().Add(time.Minute))
+	longLived := fakeProcess("longlived", time.Time{})
+
+	config := filepath.Join(dir, "config")
+	ioutil.WriteFile(config, []byte(fmt.Sprintf("[default]\nregion = eu-west-1\n\n[profile vault]\ncredential_process = %s --profile prod\n", valid)), 0600)
+	os.Setenv("AWS_CONFIG_FILE", config)
+	defer os.Unsetenv("AWS_CONFIG_FILE")
+	os.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "credentials"))
+	defer os.Unsetenv("AWS_SHARED_CREDENTIALS_FILE")
+
+	t.Run("resolve from shared config", func(t *testing.T) {
+		if got, want := credentialProcess("vault"), valid+" --profile prod"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		if got, want := credentialProcess("default"), ""; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+	})
+
+	t.Run("cache until expiry", func(t *testing.T) {
+		cacheDir := filepath.Join(dir, "cache")
+		for i := 0; i < 3; i++ {
+			provider := newFileCacheProvider(cacheDir, "vault", &processProvider{command: valid})
+			value, err := provider.Retrieve()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := value.AccessKeyID+":"+value.SecretAccessKey+":"+value.SessionToken, "AKID:SECRET:TOKEN"; got != want {
+				t.Fatalf("got %s, want %s", got, want)
+			}
+			if provider.IsExpired() {
+				t.Fatal("expected credentials not to be expired")
+			}
+		}
+		if got, want := countCalls(), 1; got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+
+		for i := 0; i < 2; i++ {
+			provider := newFileCacheProvider(cacheDir, "other", &processProvider{command: expired})
+			if _, err := provider.Retrieve(); err != nil {
+				t.Fatal(err)
+			}
+			if !provider.IsExpired() {
+				t.Fatal("expected credentials expiring within the expiry window to be expired")
+			}
+		}
+		if got, want := countCalls(), 3; got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+
+		provider := newFileCacheProvider(cacheDir, "longlived", &processProvider{command: longLived})
+		if _, err := provider.Retrieve(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := os.Stat(filepath.Join(cacheDir, "longlived.json")); !os.IsNotExist(err) {
+			t.Fatal("expected credentials without expiration not to be cached")
+		}
+	})
+
+	t.Run("file cache disabled", func(t *testing.T) {
+		awlessHome := filepath.Join(dir, "home")
+		os.Setenv("__AWLESS_HOME", awlessHome)
+		defer os.Unsetenv("__AWLESS_HOME")
+		SetCredentialsFileCache(false)
+		defer SetCredentialsFileCache(true)
+
+		before := countCalls()
+		for i := 0; i < 2; i++ {
+			sess, err := newAWSSession("eu-west-1", "vault")
+			if err != nil {
+				t.Fatal(err)
+			}
+			value, err := sess.Config.Credentials.Get()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := value.ProviderName, processProviderName; got != want {
+				t.Fatalf("got %s, want %s", got, want)
+			}
+		}
+		if got, want := countCalls()-before, 2; got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+		if _, err := os.Stat(filepath.Join(awlessHome, "credentials")); !os.IsNotExist(err) {
+			t.Fatal("expected no credentials cache file written")
+		}
+
+		SetCredentialsFileCache(true)
+		sess, err := newAWSSession("eu-west-1", "vault")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = sess.Config.Credentials.Get(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := os.Stat(filepath.Join(awlessHome, "credentials", "vault.json")); err != nil {
+			t.Fatalf("expected credentials cache file written: %s", err)
+		}
+	})
+
+	t.Run("invalid output", func(t *testing.T) {
+		provider := &processProvider{command: "echo '{\"Version\": 2}'"}
+		if _, err := provider.Retrieve(); err == nil {
+			t.Fatal("expected error")
+		}
+		provider = &processProvider{command: "exit 1"}
+		if _, err := provider.Retrieve(); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestCredentialsRefreshWindow(t *testing.T) {
+	defer SetCredentialsRefreshWindow(defaultCredentialsRefreshWindow)
+
+	tcases := []struct {
+		conf    string
+		exp     time.Duration
+		wantErr bool
+	}{
+		{conf: "", exp: 5 * time.Minute},
+		{conf: "2m", exp: 2 * time.Minute},
+		{conf: "0s", exp: 0},
+		{conf: "14m59s", exp: 14*time.Minute + 59*time.Second},
+		{conf: "15m", wantErr: true},
+		{conf: "1h", wantErr: true},
+		{conf: "-1m", wantErr: true},
+		{conf: "soon", wantErr: true},
+	}
+	for _, tcase := range tcases {
+		window, err := CredentialsRefreshWindow(map[string]interface{}{CredentialsRefreshWindowConfigKey: tcase.conf})
+		if tcase.wantErr {
+			if err == nil {
+				t.Fatalf("%s: expected error", tcase.conf)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s: %s", tcase.conf, err)
+		}
+		if got, want := window, tcase.exp; got != want {
+			t.Fatalf("%s: got %s, want %s", tcase.conf, got, want)
+		}
+	}
+
+	stsAPI := &fakeAssumeRoler{}
+	role := &roleAssumption{RoleARN: "arn:aws:iam::123456789012:role/deploy", SessionName: "awless"}
+	for _, tcase := range []struct {
+		window, lifetime time.Duration
+		expired          bool
+	}{
+		{window: 5 * time.Minute, lifetime: time.Hour, expired: false},
+		{window: 5 * time.Minute, lifetime: 15 * time.Minute, expired: false},
+		{window: 2 * time.Minute, lifetime: 3 * time.Minute, expired: false},
+		{window: 5 * time.Minute, lifetime: 3 * time.Minute, expired: true},
+		{window: 10 * time.Minute, lifetime: 10 * time.Minute, expired: true},
+	} {
+		if got, want := validRefreshWindow(tcase.window, tcase.lifetime) != nil, tcase.expired; got != want {
+			t.Fatalf("window %s, lifetime %s: got invalid %t, want %t", tcase.window, tcase.lifetime, got, want)
+		}
+
+		SetCredentialsRefreshWindow(tcase.window)
+		stsAPI.lifetime = tcase.lifetime
+		provider := &assumeRoleProvider{client: stsAPI, role: role}
+		if _, err := provider.Retrieve(); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := provider.IsExpired(), tcase.expired; got != want {
+			t.Fatalf("window %s, lifetime %s: got expired %t, want %t", tcase.window, tcase.lifetime, got, want)
+		}
+	}
+}
+
+type fakeAssumeRoler struct {
+	calls    int
+	lifetime time.Duration
+}
+
+func (f *fakeAssumeRoler) AssumeRole(input *sts.AssumeRoleInput) (*sts.AssumeRoleOutput, error) {
+	f.calls++
+	lifetime := f.lifetime
+	if lifetime == 0 {
+		lifetime = time.Hour
+	}
+	key := awssdk.StringValue(input.RoleArn) + ":" + awssdk.StringValue(input.ExternalId) + ":" + awssdk.StringValue(input.RoleSessionName)
+	return &sts.AssumeRoleOutput{Credentials: &sts.Credentials{
+		AccessKeyId:     awssdk.String("AKID-" + key),
+		SecretAccessKey: awssdk.String("SECRET"),
+		SessionToken:    awssdk.String("TOKEN"),
+		Expiration:      awssdk.Time(time.Now().Add(lifetime)),
+	}}, nil
+}
+
+func TestAssumeRoleCredentialsCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "awless-credentials")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	config := filepath.Join(dir, "config")
+	ioutil.WriteFile(config, []byte(`[profile vault]
+credential_process = vault-login
+
+[profile admin]
+role_arn = arn:aws:iam::123456789012:role/admin
+source_profile = vault
+
+[profile partner]
+role_arn = arn:aws:iam::123456789012:role/admin
+external_id = partner-id
+role_session_name = jdoe
+source_profile = vault
+`), 0600)
+	os.Setenv("AWS_CONFIG_FILE", config)
+	defer os.Unsetenv("AWS_CONFIG_FILE")
+	os.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "credentials"))
+	defer os.Unsetenv("AWS_SHARED_CREDENTIALS_FILE")
+
+	t.Run("resolve from shared config", func(t *testing.T) {
+		if got := profileRoleAssumption("vault"); got != nil {
+			t.Fatalf("got %+v, want nil", got)
+		}
+		want := &roleAssumption{RoleARN: "arn:aws:iam::123456789012:role/admin", SessionName: "awless", SourceProfile: "vault"}
+		if got := profileRoleAssumption("admin"); !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %+v, want %+v", got, want)
+		}
+		want = &roleAssumption{RoleARN: "arn:aws:iam::123456789012:role/admin", ExternalID: "partner-id", SessionName: "jdoe", SourceProfile: "vault"}
+		if got := profileRoleAssumption("partner"); !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("cache key includes role parameters", func(t *testing.T) {
+		admin := &roleAssumption{RoleARN: "arn:aws:iam::123456789012:role/admin", SessionName: "awless", SourceProfile: "vault"}
+		keys := map[string]bool{
+			credentialsCacheKey("prod", nil):   true,
+			credentialsCacheKey("prod", admin): true,
+		}
+		for _, role := range []roleAssumption{
+			{RoleARN: "arn:aws:iam::123456789012:role/readonly", SessionName: "awless", SourceProfile: "vault"},
+			{RoleARN: "arn:aws:iam::123456789012:role/admin", ExternalID: "partner-id", SessionName: "awless", SourceProfile: "vault"},
+			{RoleARN: "arn:aws:iam::123456789012:role/admin", SessionName: "jdoe", SourceProfile: "vault"},
+			{RoleARN: "arn:aws:iam::123456789012:role/admin", SessionName: "awless", SourceProfile: "other"},
+		} {
+			role := role
+			key := credentialsCacheKey("prod", &role)
+			if keys[key] {
+				t.Fatalf("%+v: key %s already used", role, key)
+			}
+			keys[key] = true
+		}
+		if got, want := credentialsCacheKey("prod", admin), credentialsCacheKey("prod", &roleAssumption{RoleARN: admin.RoleARN, SessionName: admin.SessionName, SourceProfile: admin.SourceProfile}); got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+	})
+
+	t.Run("switching role yields different credentials", func(t *testing.T) {
+		cacheDir := filepath.Join(dir, "cache")
+		stsAPI := &fakeAssumeRoler{}
+		retrieve := func(role *roleAssumption) string {
+			provider := newFileCacheProvider(cacheDir, credentialsCacheKey("prod", role), &assumeRoleProvider{client: stsAPI, role: role})
+			value, err := provider.Retrieve()
+			if err != nil {
+				t.Fatal(err)
+			}
+			return value.AccessKeyID
+		}
+
+		admin := &roleAssumption{RoleARN: "arn:aws:iam::123456789012:role/admin", SessionName: "awless", SourceProfile: "vault"}
+		readonly := &roleAssumption{RoleARN: "arn:aws:iam::123456789012:role/readonly", SessionName: "awless", SourceProfile: "vault"}
+		partner := &roleAssumption{RoleARN: "arn:aws:iam::123456789012:role/admin", ExternalID: "partner-id", SessionName: "awless", SourceProfile: "vault"}
+
+		for i := 0; i < 2; i++ {
+			if got, want := retrieve(admin), "AKID-arn:aws:iam::123456789012:role/admin::awless"; got != want {
+				t.Fatalf("got %s, want %s", got, want)
+			}
+			if got, want := retrieve(readonly), "AKID-arn:aws:iam::123456789012:role/readonly::awless"; got != want {
+				t.Fatalf("got %s, want %s", got, want)
+			}
+			if got, want := retrieve(partner), "AKID-arn:aws:iam::123456789012:role/admin:partner-id:awless"; got != want {
+				t.Fatalf("got %s, want %s", got, want)
+			}
+		}
+		if got, want := stsAPI.calls, 3; got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+	})
+}
+
+func TestProfileRegion(t *testing.T) {
+	dir, err := ioutil.TempDir("", "awless-credentials")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	config := filepath.Join(dir, "config")
+	ioutil.WriteFile(config, []byte("[default]\nregion = eu-west-1\n\n[profile dev]\nregion = ap-south-1\n\n[profile noregion]\noutput = json\n"), 0600)
+	os.Setenv("AWS_CONFIG_FILE", config)
+	defer os.Unsetenv("AWS_CONFIG_FILE")
+	os.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "credentials"))
+	defer os.Unsetenv("AWS_SHARED_CREDENTIALS_FILE")
+
+	if got, want := ProfileRegion("dev"), "ap-south-1"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := ProfileRegion(""), "eu-west-1"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	conf := map[string]interface{}{"aws.profile": "dev", "aws.mock": true}
+	if err := InitServices(conf, logger.DiscardLogger); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := conf["aws.region"], "ap-south-1"; got != want {
+		t.Fatalf("got %v, want %s", got, want)
+	}
+
+	conf = map[string]interface{}{"aws.profile": "dev", "aws.region": "us-east-1", "aws.mock": true}
+	if err := InitServices(conf, logger.DiscardLogger); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := conf["aws.region"], "us-east-1"; got != want {
+		t.Fatalf("got %v, want %s", got, want)
+	}
+
+	err = InitServices(map[string]interface{}{"aws.profile": "noregion", "aws.mock": true}, logger.DiscardLogger)
+	if err == nil || !strings.Contains(err.Error(), "empty AWS region") {
+		t.Fatalf("got %v, want empty AWS region error", err)
+	}
+}
+
+func TestCredentialProviderErrors(t *testing.T) {
+	batch := awserr.NewBatchError("NoCredentialProviders", "no valid providers in chain", []error{
+		awserr.New("EnvAccessKeyNotFound", "AWS_ACCESS_KEY_ID or AWS_ACCESS_KEY not found in environment", nil),
+		awserr.New("SharedCredsLoad", "failed to load shared credentials file", nil),
+	})
+	errs := credentialProviderErrors(batch)
+	if got, want := len(errs), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := errs[0], "EnvAccessKeyNotFound: AWS_ACCESS_KEY_ID or AWS_ACCESS_KEY not found in environment"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := credentialProviderErrors(fmt.Errorf("expired")), []string{"expired"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got := credentialProviderErrors(nil); len(got) != 0 {
+		t.Fatalf("got %v, want none", got)
+	}
+}
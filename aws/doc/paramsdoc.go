@@ -13,6 +13,10 @@ var manualParamsDoc = map[string]map[string]string{
 		"name":       "The Name of the Alarm to update",
 		"action-arn": "The Amazon Resource Name (ARN) of the action to execute when this alarm transitions to the ALARM state from any other state",
 	},
+	"attachdhcpoptions": {
+		"id":  "The ID of the DHCP options set to associate with the VPC (or 'default' to associate no DHCP options set)",
+		"vpc": "The ID of the VPC",
+	},
 	"attachelasticip": {
 		"allow-reassociation": "Specify false to ensure the operation fails if the Elastic IP address is already associated with another resource",
 	},
@@ -20,6 +24,10 @@ var manualParamsDoc = map[string]map[string]string{
 		"id":   "The ID of the Instance",
 		"port": "The port on which the Instance is listenning",
 	},
+	"attachnetworkacl": {
+		"id":     "The ID of the network ACL to associate with the subnet, replacing its current network ACL",
+		"subnet": "The ID of the subnet",
+	},
 	"attachpolicy": {
 		"arn":   "The Amazon Resource Name (ARN) of the IAM policy you want to attach",
 		"user":  "The name (friendly name, not ARN) of the IAM user to attach the policy to",
@@ -74,6 +82,26 @@ var manualParamsDoc = map[string]map[string]string{
 		"state":   "The state of the EC2 Volume to reach (available | in-use | not-found)",
 		"timeout": "The time (in seconds) after which the check is failed",
 	},
+	"copyimage": {
+		"client-token":  "The idempotency token of the copy (default: a hash of the other params, so that a retried copy returns the image already copied). Tokens are valid for at least 24 hours",
+		"description":   "A description for the new AMI in the destination region",
+		"encrypted":     "Specifies whether the destination snapshots of the copied image should be encrypted",
+		"kmskey":        "The ID or ARN of the KMS key (of the destination region) used to encrypt the destination snapshots (implies encrypted=true)",
+		"name":          "The name of the new AMI in the destination region",
+		"source-id":     "The ID of the AMI to copy",
+		"source-region": "The name of the region that contains the AMI to copy (defaults to the current region)",
+		"to-region":     "The name of the region to copy the AMI to (defaults to the current region)",
+		"wait":          "Wait for the copied AMI to be available before returning",
+	},
+	"copysnapshot": {
+		"description":   "A description for the EBS snapshot",
+		"encrypted":     "Specifies whether the destination snapshot should be encrypted",
+		"kmskey":        "The ID or ARN of the KMS key (of the destination region) used to encrypt the destination snapshot (implies encrypted=true)",
+		"source-id":     "The ID of the EBS snapshot to copy",
+		"source-region": "The name of the region that contains the snapshot to copy (defaults to the current region)",
+		"to-region":     "The name of the region to copy the snapshot to (defaults to the current region)",
+		"wait":          "Wait for the copied snapshot to be completed before returning",
+	},
 	"createaccesskey": {
 		"user": "The name of the user for which the access key will be generated",
 	},
@@ -88,10 +116,10 @@ var manualParamsDoc = map[string]map[string]string{
 		"service-namespace": "The namespace of the AWS service (ecs | ec2 | elasticmapreduce | appstream | dynamodb)",
 	},
 	"createappscalingpolicy": {
-		"dimension":         "The scalable dimension associated with the scalable target (ecs:service:DesiredCount | ec2:spot-fleet-request:TargetCapacity | elasticmapreduce:instancegroup:InstanceCount | appstream:fleet:DesiredCapacity | dynamodb:table:ReadCapacityUnits | dynamodb:table:WriteCapacityUnits | dynamodb:index:ReadCapacityUnits | dynamodb:index:WriteCapacityUnits)",
-		"resource":          "The identifier of the resource associated with the scalable target (eg. for ECS: service/cluster-name/service-deployment-name, for EC2 spot-fleet: spot-fleet-request/sfr-73fbd2ce-aa30-494c-8788-1cee4EXAMPLE, for EMR cluster: instancegroup/j-2EEZNYKUA1NTV/ig-1791Y4E1L8YI0, for AppStream 2.0 fleet: fleet/sample-fleet, for DynamoDB table: table/my-table, for DynamoDB global secondary index: table/my-table/index/my-table-index)",
-		"service-namespace": "The namespace of the AWS service (ecs | ec2 | elasticmapreduce | appstream | dynamodb)",
-		"type":              "The policy type (StepScaling)",
+		"dimension":                            "The scalable dimension associated with the scalable target (ecs:service:DesiredCount | ec2:spot-fleet-request:TargetCapacity | elasticmapreduce:instancegroup:InstanceCount | appstream:fleet:DesiredCapacity | dynamodb:table:ReadCapacityUnits | dynamodb:table:WriteCapacityUnits | dynamodb:index:ReadCapacityUnits | dynamodb:index:WriteCapacityUnits)",
+		"resource":                             "The identifier of the resource associated with the scalable target (eg. for ECS: service/cluster-name/service-deployment-name, for EC2 spot-fleet: spot-fleet-request/sfr-73fbd2ce-aa30-494c-8788-1cee4EXAMPLE, for EMR cluster: instancegroup/j-2EEZNYKUA1NTV/ig-1791Y4E1L8YI0, for AppStream 2.0 fleet: fleet/sample-fleet, for DynamoDB table: table/my-table, for DynamoDB global secondary index: table/my-table/index/my-table-index)",
+		"service-namespace":                    "The namespace of the AWS service (ecs | ec2 | elasticmapreduce | appstream | dynamodb)",
+		"type":                                 "The policy type (StepScaling)",
 		"stepscaling-adjustment-type":          "The scalable dimension (ChangeInCapacity | ExactCapacity | PercentChangeInCapacity)",
 		"stepscaling-adjustments":              "A set of adjustments that enable you to scale based on the size of the alarm breach using this format: [from]:[to]:scaling-adjustment[,[from]:[to]:scaling-adjustment[,...]] (e.g. 75::+1 i.e. add one task when > 75%)",
 		"stepscaling-cooldown":                 "The amount of time, in seconds, after a scaling activity completes where previous trigger-related scaling activities can influence future scaling events",
@@ -137,6 +165,11 @@ var manualParamsDoc = map[string]map[string]string{
 		"name":        "The name for the DB subnet group",
 		"subnets":     "The EC2 Subnet IDs for the DB subnet group",
 	},
+	"createdhcpoptions": {
+		"domain-name":         "The domain name of the DHCP options set (e.g. corp.example.com)",
+		"domain-name-servers": "The IP addresses of up to four domain name servers, or AmazonProvidedDNS (e.g. 10.0.0.2,10.0.0.3)",
+		"ntp-servers":         "The IP addresses of up to four Network Time Protocol (NTP) servers",
+	},
 	"createdistribution": {
 		"origin-domain":   "The DNS name of the Amazon S3 bucket from which you want CloudFront to get objects for this origin, for example, myawsbucket.s3.amazonaws.com",
 		"certificate":     "The Amazon Resource Name (ARN) of the AWS Certificate Manager (ACM) certificate you want to use for TSL connection",
@@ -154,6 +187,22 @@ var manualParamsDoc = map[string]map[string]string{
 	"createelasticip": {
 		"domain": "Set to vpc to allocate the address for use with instances in a VPC else the address is for use with instances in EC2-Classic (vpc | ec2-classic)",
 	},
+	"createfleet": {
+		"target-capacity":         "The number of instances (or weighted capacity units) to keep running in the fleet",
+		"allocation-strategy":     "How spot capacity is allocated across instance pools (lowestPrice | diversified; default lowestPrice)",
+		"client-token":            "The idempotency token of the creation (default: a hash of the other params, so that a retried creation returns the fleet already created). Tokens are valid for at least 24 hours",
+		"image":                   "The AMI of the instances of a Spot Fleet",
+		"keypair":                 "The name of the keypair to access the instances of a Spot Fleet",
+		"launch-template":         "The ID (lt-...) or name of the launch template of the instances: given, an EC2 Fleet is created instead of a Spot Fleet",
+		"launch-template-version": "The version of the launch template (number, $Latest or $Default; default $Default)",
+		"on-demand-capacity":      "The part of the target capacity launched as on-demand instances, the rest being spot (EC2 Fleet only; default 0)",
+		"request-type":            "The type of fleet request (maintain | request, or instant for EC2 Fleets; default maintain): a maintain fleet replaces its interrupted instances",
+		"role":                    "The ARN of the IAM role allowing the Spot Fleet to launch and terminate instances",
+		"securitygroup":           "The security groups of the instances of a Spot Fleet",
+		"spot-price":              "The maximum price per unit hour to pay for the spot instances of a Spot Fleet",
+		"subnet":                  "The subnet in which to launch the instances",
+		"type":                    "The instance type of the instances",
+	},
 	"createfunction": {
 		"bucket":        "Amazon S3 bucket name where the .zip file containing your deployment package is stored. This bucket must reside in the same AWS region where you are creating the Lambda function",
 		"object":        "The Amazon S3 object (the deployment package) key name you want to upload",
@@ -164,11 +213,39 @@ var manualParamsDoc = map[string]map[string]string{
 	"creategroup": {
 		"name": "The name of the group to create",
 	},
+	"createhealthcheck": {
+		"type":              "The type of health check (HTTP | HTTPS | HTTP_STR_MATCH | HTTPS_STR_MATCH | TCP | CALCULATED | CLOUDWATCH_METRIC)",
+		"host":              "The domain name of the endpoint to check (also sent in the Host header of HTTP checks)",
+		"ip":                "The IPv4 or IPv6 address of the endpoint to check",
+		"port":              "The port of the endpoint to check (default: 80 for HTTP, 443 for HTTPS)",
+		"path":              "The path requested by HTTP and HTTPS checks (ex: /health)",
+		"search-string":     "The string that must appear in the first 5120 bytes of the response body of *_STR_MATCH checks",
+		"interval":          "The number of seconds between two checks of an endpoint by a health checker (10 | 30, default: 30)",
+		"failure-threshold": "The number of consecutive checks an endpoint must pass or fail to change its status (1 to 10, default: 3)",
+		"regions":           "The regions from which the endpoint is checked (at least 3, default: all)",
+		"inverted":          "Consider healthy endpoints as unhealthy and vice versa (true | false)",
+		"children":          "The health checks aggregated by a CALCULATED health check",
+		"healthy-threshold": "The number of child health checks that must be healthy for a CALCULATED health check to be healthy",
+		"alarm":             "The name of the CloudWatch alarm of a CLOUDWATCH_METRIC health check",
+		"alarm-region":      "The region of the CloudWatch alarm of a CLOUDWATCH_METRIC health check",
+		"client-token":      "The caller reference of the creation (default: a hash of the other params, so that a retried creation returns the health check already created). Reusing a reference with different params fails",
+	},
+	"createinlinepolicy": {
+		"name":     "The name of the inline policy (unique within the user, group or role)",
+		"user":     "The name (friendly name, not ARN) of the IAM user to embed the policy in",
+		"group":    "The name (friendly name, not ARN) of the IAM group to embed the policy in",
+		"role":     "The name (friendly name, not ARN) of the IAM role to embed the policy in",
+		"document": "The path to a JSON policy document file (when set, effect, action and resource are ignored)",
+		"effect":   "Whether the policy statement allows or explicitly denies the actions (Allow | Deny)",
+		"action":   "The action or actions allowed or denied by the policy statement (eg. sqs:SendMessage, s3:*)",
+		"resource": "The Amazon Resource Name (ARN) of the objects covered by the policy statement",
+	},
 	"createinstance": {
-		"count": "The number of instances to launch",
-		"name":  "The name of the instance to launch",
-		"role":  "The name of the instance profile (role) to launch the instance with",
-		"image": "The ID of the AMI of the instance to launch, which you can get by using `awless search images`",
+		"client-token": "The idempotency token of the launch (default: a hash of the other params, so that a retried launch returns the instances already launched rather than duplicates). Tokens are valid for at least 24 hours: set a distinct token to launch identical instances on purpose",
+		"count":        "The number of instances to launch",
+		"name":         "The name of the instance to launch",
+		"role":         "The name of the instance profile (role) to launch the instance with",
+		"image":        "The ID of the AMI of the instance to launch, which you can get by using `awless search images`",
 	},
 	"createkeypair": {
 		"name":      "The name of the keypair to create (it will also be the name of the file stored in ~/.awless/keys)",
@@ -187,6 +264,40 @@ var manualParamsDoc = map[string]map[string]string{
 		"scheme": "The routing range of the loadbalancer (internet-facing | internal)",
 		"iptype": "The type of IP addresses used by the subnets for your load balancer: IPv4 or IPv4 and IPv6 (ipv4 | dualstack)",
 	},
+	"createloadbalancerstack": {
+		"name":            "The name of the loadbalancer and its targetgroup",
+		"subnets":         "The IDs of the subnets to attach to the loadbalancer",
+		"instances":       "The IDs of the instances to register in the targetgroup",
+		"port":            "The port on which the registered instances receive traffic",
+		"protocol":        "The protocol of the listener (HTTP | HTTPS), defaults to HTTP",
+		"listenerport":    "The port on which the loadbalancer is listening, defaults to 80 for HTTP and 443 for HTTPS",
+		"certificate":     "The ARN of the ACM certificate of the HTTPS listener",
+		"securitygroups":  "The IDs of the security groups to assign to the loadbalancer",
+		"scheme":          "The routing range of the loadbalancer (internet-facing | internal)",
+		"healthcheckpath": "The ping path destination on the instances for health checks",
+	},
+	"createnatgateway": {
+		"client-token":  "The idempotency token of the creation (default: a hash of the other params, so that a retried creation returns the NAT gateway already created). Tokens are valid for at least 24 hours",
+		"elasticip-id":  "The allocation ID of an Elastic IP address to associate with the NAT gateway",
+		"subnet":        "The subnet in which to create the NAT gateway",
+		"update-routes": "Route the private subnets of the VPC through the NAT gateway: the default route (0.0.0.0/0) of the route tables without internet gateway route is added or replaced (not reverted on template revert)",
+	},
+	"createnetworkacl": {
+		"vpc": "The ID of the VPC",
+	},
+	"createnetworkaclrule": {
+		"action":    "Whether to allow or deny the traffic that matches the rule (allow | deny)",
+		"cidr":      "The IPv4 or IPv6 network range to allow or deny, in CIDR notation (e.g. 172.16.0.0/24)",
+		"id":        "The ID of the network ACL",
+		"number":    "The rule number (1 to 32766). Rules are evaluated in increasing order of rule number",
+		"outbound":  "Indicates whether the rule applies to traffic leaving the subnet (default false: inbound traffic)",
+		"portrange": "The port or range of ports for TCP and UDP rules (e.g. 22, 1024-65535 or any, the default)",
+		"protocol":  "The protocol (tcp | udp | icmp | any) or protocol number",
+	},
+	"createplacementgroup": {
+		"name":     "A name for the placement group, unique within the account and the region",
+		"strategy": "The placement strategy (cluster: pack instances close together for low-latency network performance)",
+	},
 	"createpolicy": {
 		"name":        "The friendly name of the policy",
 		"description": "A friendly description of the policy",
@@ -194,6 +305,12 @@ var manualParamsDoc = map[string]map[string]string{
 		"action":      "The Action element describes the specific action or actions that will be allowed or denied. You specify a value using a namespace that identifies a service followed by the name of the action to allow or deny (eg. sqs:SendMessage, s3:*)",
 		"resource":    "The Amazon Resource Name (ARN) of the Resource element which specifies the object or objects that the policy covers",
 	},
+	"createprefixlist": {
+		"name":        "A name for the prefix list (cannot start with com.amazonaws)",
+		"max-entries": "The maximum number of entries of the prefix list (counts against the rules quotas of the security groups and route tables referencing it)",
+		"cidrs":       "The CIDR blocks of the initial entries of the prefix list",
+		"family":      "The IP address family of the entries (ipv4 | ipv6; default ipv4)",
+	},
 	"createqueue": {
 		"delay":              "The length of time, in seconds, for which the delivery of all messages in the queue is delayed. Valid values: An integer from 0 to 900 seconds (15 minutes). The default is 0",
 		"max-msg-size":       "The limit of how many bytes a message can contain before Amazon SQS rejects it. Valid values: An integer from 1,024 bytes (1 KiB) to 262,144 bytes (256 KiB). The default is 262,144 (256 KiB)",
@@ -204,12 +321,14 @@ var manualParamsDoc = map[string]map[string]string{
 		"visibility-timeout": "The visibility timeout for the queue. Valid values: An integer from 0 to 43,200 (12 hours). The default is 30",
 	},
 	"createrecord": {
-		"zone":    "The ID of the hosted zone that contains the resource record sets that you want to change",
-		"name":    "The name of the domain you want to perform the action on. Enter a fully qualified domain name, for example, www.example.com. You can optionally include a trailing dot",
-		"type":    "The DNS record type. (A | AAAA | CNAME | MX | NAPTR | NS | PTR | SOA | SPF | SRV | TXT)",
-		"value":   "The current or new DNS record value",
-		"ttl":     "The resource record cache time to live (TTL), in seconds",
-		"comment": "Any comments you want to include about a change batch request",
+		"zone":       "The ID of the hosted zone that contains the resource record sets that you want to change",
+		"name":       "The name of the domain you want to perform the action on. Enter a fully qualified domain name, for example, www.example.com. You can optionally include a trailing dot",
+		"type":       "The DNS record type. (A | AAAA | CNAME | MX | NAPTR | NS | PTR | SOA | SPF | SRV | TXT)",
+		"value":      "The current or new DNS record value",
+		"ttl":        "The resource record cache time to live (TTL), in seconds",
+		"comment":    "Any comments you want to include about a change batch request",
+		"weight":     "The weight of the record in its weighted set (0-255): the record gets weight/sum of the set weights of the DNS queries (ex: 10 next to a 90 record for a canary). Requires identifier",
+		"identifier": "The identifier of the record among the records of its weighted set sharing its name and type",
 	},
 	"createrole": {
 		"name":              "The name of the role to create",
@@ -219,10 +338,11 @@ var manualParamsDoc = map[string]map[string]string{
 		"sleep-after":       "The amount of time in seconds you want to wait after creating the role (usually used to be sure that the role creation has been propagated)",
 	},
 	"creates3object": {
-		"bucket": "Name of the bucket to which object will be added",
-		"file":   "The path toward to file to upload",
-		"name":   "The name of the Object to create (by default the file name is used)",
-		"acl":    "The canned ACL to apply to the object (private | public-read | public-read-write | aws-exec-read | authenticated-read | bucket-owner-read | bucket-owner-full-control | log-delivery-write)",
+		"bucket":      "Name of the bucket to which object will be added",
+		"file":        "The path toward to file to upload, or to a directory whose files are all uploaded",
+		"name":        "The name of the Object to create (by default the file name is used). When uploading a directory, the prefix of the object names (by default the paths relative to the directory are used)",
+		"acl":         "The canned ACL to apply to the object (private | public-read | public-read-write | aws-exec-read | authenticated-read | bucket-owner-read | bucket-owner-full-control | log-delivery-write)",
+		"concurrency": "The number of files uploaded at once when uploading a directory (default 8)",
 	},
 	"createscalinggroup": {
 		"healthcheck-type": "The service to use for the health checks (EC2 | ELB)",
@@ -231,13 +351,22 @@ var manualParamsDoc = map[string]map[string]string{
 		"adjustment-type":    "The adjustment type (ChangeInCapacity | ExactCapacity | PercentChangeInCapacity)",
 		"adjustment-scaling": "The amount by which to scale, based on the specified adjustment type (e.g. '-2', '3')",
 	},
+	"createsecuritygroup": {
+		"inbound-rules":  "Ingress rules authorized at creation, as protocol:ports:source with source a CIDR, a security group ID or name (e.g. tcp:22:10.0.0.0/16,tcp:80-443:web-sg). The security group is deleted if authorization fails",
+		"outbound-rules": "Egress rules authorized at creation, as protocol:ports:destination (e.g. any:any:10.0.0.0/8,tcp:5432:sg-12345678)",
+	},
 	"createstack": {
 		"capabilities":  "A list of values that you must specify before AWS CloudFormation can create certain stacks (CAPABILITY_IAM | CAPABILITY_NAMED_IAM)",
+		"client-token":  "The idempotency token of the stack creation (default: a hash of the other params, so that a retried creation is recognized by CloudFormation). Tokens are valid for the stack operation they identify",
 		"on-failure":    "Determines what action will be taken if stack creation fails (DO_NOTHING | ROLLBACK | DELETE)",
 		"parameters":    "A list of Parameters that specify input parameters for the stack given using this format: key1:val1,key2:val2,...",
 		"policy-file":   "The path to the file containing the stack policy body",
 		"template-file": "The path to the file containing the template body with a minimum size of 1 byte and a maximum size of 51,200 bytes",
 	},
+	"createstream": {
+		"name":   "The name of the Kinesis data stream to create (unique within the account and region)",
+		"shards": "The number of shards of the stream (without it, the stream is on-demand and scales its shards automatically)",
+	},
 	"createsubnet": {
 		"name": "The 'Name' Tag for the subnet to create",
 	},
@@ -284,16 +413,32 @@ var manualParamsDoc = map[string]map[string]string{
 	"deletedbsubnetgroup": {
 		"name": "The name of the database subnet group to be deleted",
 	},
+	"deletedhcpoptions": {
+		"id": "The ID of the DHCP options set to be deleted",
+	},
 	"deletedistribution": {
 		"id": "The ID of the distribution to be deleted",
 	},
+	"deletefleet": {
+		"id":                  "The ID of the Spot Fleet request (sfr-...) or EC2 Fleet (fleet-...) to delete",
+		"terminate-instances": "Terminate the instances of the fleet (true | false; default false: they keep running)",
+	},
 	"deletefunction": {
 		"id": "The ID of the Lambda function to be deleted",
 	},
+	"deletehealthcheck": {
+		"id": "The id of the health check to delete (record sets using it must be updated first)",
+	},
 	"deleteimage": {
 		"id":               "The ID of the AMI to be deleted",
 		"delete-snapshots": "Set to 'true' to also delete the snapshots created from this image",
 	},
+	"deleteinlinepolicy": {
+		"name":  "The name of the inline policy to delete",
+		"user":  "The name (friendly name, not ARN) of the IAM user embedding the policy",
+		"group": "The name (friendly name, not ARN) of the IAM group embedding the policy",
+		"role":  "The name (friendly name, not ARN) of the IAM role embedding the policy",
+	},
 	"deleteinstance": {
 		"id": "The ID(s) of the instance(s) to be deleted",
 	},
@@ -306,12 +451,31 @@ var manualParamsDoc = map[string]map[string]string{
 	"deletelaunchconfiguration": {
 		"name": "The name of the launch configuration to be deleted",
 	},
+	"deleteloadbalancerstack": {
+		"id": "The ARN of the loadbalancer to delete along with the targetgroups its listeners forward to",
+	},
+	"deletenetworkacl": {
+		"id": "The ID of the network ACL to be deleted",
+	},
+	"deletenetworkaclrule": {
+		"id":       "The ID of the network ACL",
+		"number":   "The number of the rule to be deleted",
+		"outbound": "Indicates whether the rule is an outbound rule (default false: inbound rule)",
+	},
+	"deleteplacementgroup": {
+		"name": "The name of the placement group (must not contain instances)",
+	},
+	"deleteprefixlist": {
+		"id": "The ID of the customer-managed prefix list (must not be referenced by any security group or route table)",
+	},
 	"deleterecord": {
-		"zone":  "The ID of the hosted zone that contains the resource record sets that you want to delete",
-		"name":  "The name of the domain you want to perform the action on. Enter a fully qualified domain name, for example, www.example.com. You can optionally include a trailing dot",
-		"type":  "The DNS record type. (A | AAAA | CNAME | MX | NAPTR | NS | PTR | SOA | SPF | SRV | TXT)",
-		"value": "The DNS record value to delete",
-		"ttl":   "The resource record cache time to live (TTL), in seconds",
+		"zone":       "The ID of the hosted zone that contains the resource record sets that you want to delete",
+		"name":       "The name of the domain you want to perform the action on. Enter a fully qualified domain name, for example, www.example.com. You can optionally include a trailing dot",
+		"type":       "The DNS record type. (A | AAAA | CNAME | MX | NAPTR | NS | PTR | SOA | SPF | SRV | TXT)",
+		"value":      "The DNS record value to delete",
+		"ttl":        "The resource record cache time to live (TTL), in seconds",
+		"weight":     "The weight of the weighted record to delete",
+		"identifier": "The identifier of the weighted record to delete",
 	},
 	"deleterole": {
 		"name": "The name of the role to be deleted",
@@ -320,6 +484,10 @@ var manualParamsDoc = map[string]map[string]string{
 		"bucket": "The name of the bucket containing the object to be deleted",
 		"name":   "The name (i.e. key) of the object to be deleted",
 	},
+	"deletestream": {
+		"name":  "The name (or ARN) of the Kinesis data stream to delete",
+		"force": "Also deregister the enhanced fan-out consumers of the stream (true | false, default: false)",
+	},
 	"deletetag": {
 		"resource": "The ID of the resource on which you want to remove a tag",
 		"key":      "The Tag key",
@@ -351,31 +519,101 @@ var manualParamsDoc = map[string]map[string]string{
 		"license":      "The license type to be used for the Amazon Machine Image (AMI) after importing (AWS | BYOL)",
 		"platform":     "The operating system of the virtual machine (Windows | Linux)",
 	},
+	"rebootinstance": {
+		"id":           "One or more instance IDs",
+		"batch":        "Number of instances to reboot at once, the instances being processed by successive batches (default: all at once)",
+		"delay":        "Time to wait between two batches (e.g. 30s, 2m or a number of seconds)",
+		"wait":         "Set to 'true' to wait for the instances (of each batch) to be running with their status checks passing before returning",
+		"wait-timeout": "Maximum time to wait for the instances status checks to pass (e.g. 10m or a number of seconds; default: 5m)",
+	},
 	"startcontainerservice": {
+		"client-token":                "The idempotency token of the service creation (default: a hash of the other params, so that a retried creation returns the service already created)",
 		"cluster":                     "The short name or full Amazon Resource Name (ARN) of the cluster on which to run your service",
 		"desired-count":               "The number of instantiations of the specified service to place and keep running on your cluster",
 		"loadbalancer.container-name": "The name of the container (as it appears in a container definition) to associate with the load balancer",
 		"loadbalancer.container-port": "The port on the container to associate with the load balancer",
 		"loadbalancer.targetgroup":    "The full Amazon Resource Name (ARN) of the Elastic Load Balancing target group associated with a service",
-		"name":            "The name of the container service to start",
-		"deployment-name": "The deployment name of the service (e.g. prod, staging...)",
-		"role":            "The name or full Amazon Resource Name (ARN) of the IAM role that allows Amazon ECS to make calls to your load balancer on your behalf",
+		"name":                        "The name of the container service to start",
+		"deployment-name":             "The deployment name of the service (e.g. prod, staging...)",
+		"role":                        "The name or full Amazon Resource Name (ARN) of the IAM role that allows Amazon ECS to make calls to your load balancer on your behalf",
+	},
+	"startinstance": {
+		"batch":        "Number of instances to start at once, the instances being processed by successive batches (default: all at once)",
+		"delay":        "Time to wait between two batches (e.g. 30s, 2m or a number of seconds)",
+		"wait":         "Set to 'true' to wait for the instances (of each batch) to be running before returning",
+		"wait-timeout": "Maximum time to wait for the instances to be running (e.g. 10m or a number of seconds; default: 5m)",
+	},
+	"stopinstance": {
+		"batch":        "Number of instances to stop at once, the instances being processed by successive batches (default: all at once)",
+		"delay":        "Time to wait between two batches (e.g. 30s, 2m or a number of seconds)",
+		"wait":         "Set to 'true' to wait for the instances (of each batch) to be stopped before returning",
+		"wait-timeout": "Maximum time to wait for the instances to be stopped (e.g. 10m or a number of seconds; default: 5m)",
 	},
 	"updatebucket": {
-		"name":              "The name of the bucket to update",
-		"acl":               "The canned ACL to apply to the bucket (private | public-read | public-read-write | aws-exec-read | authenticated-read | bucket-owner-read | bucket-owner-full-control | log-delivery-write)",
-		"public-website":    "Set to 'true' if you want to publish the content of the bucket as a public HTTP website",
-		"redirect-hostname": "Hostname where HTTP requests will be redirected when publishing website",
-		"index-suffix":      "A suffix that is appended to a request that is for a directory on the website endpoint (e.g. if the suffix is index.html and you make a request to samplebucket/images/ the data that is returned will be for the object with the key name images/index.html)",
-		"enforce-https":     "Use HTTPS rather than HTTP when redirecting requests",
+		"name":                     "The name of the bucket to update",
+		"acl":                      "The canned ACL to apply to the bucket (private | public-read | public-read-write | aws-exec-read | authenticated-read | bucket-owner-read | bucket-owner-full-control | log-delivery-write)",
+		"public-website":           "Set to 'true' if you want to publish the content of the bucket as a public HTTP website",
+		"redirect-hostname":        "Hostname where HTTP requests will be redirected when publishing website",
+		"index-suffix":             "A suffix that is appended to a request that is for a directory on the website endpoint (e.g. if the suffix is index.html and you make a request to samplebucket/images/ the data that is returned will be for the object with the key name images/index.html)",
+		"enforce-https":            "Use HTTPS rather than HTTP when redirecting requests",
+		"replication":              "Set to 'true' to replicate the objects of the bucket to another bucket (with 'replication-destination' and 'replication-role'), to 'false' to remove the replication configuration. Versioning must be enabled on both buckets",
+		"replication-destination":  "The name or ARN of the bucket where objects are replicated",
+		"replication-role":         "The ARN of the IAM role assumed by S3 to replicate objects",
+		"replication-prefix":       "Replicate only the objects whose key starts with this prefix (default: all objects)",
+		"replication-storageclass": "The storage class of the replicas (STANDARD | STANDARD_IA | REDUCED_REDUNDANCY; default: same as source)",
 	},
 	"updatedistribution": {
-		"id":     "The ID of the distribution to update",
-		"enable": "Enable/Disable the distribution (True | False)",
+		"id":              "The ID of the distribution to update",
+		"enable":          "Enable/Disable the distribution (True | False)",
+		"certificate":     "The Amazon Resource Name (ARN) of the AWS Certificate Manager (ACM) certificate to serve with SNI and TLSv1.2 minimum",
+		"origin-id":       "The ID of the origin to update, or to add when it does not exist yet",
+		"origin-domain":   "The DNS name of the origin: an Amazon S3 bucket (myawsbucket.s3.amazonaws.com) or a custom origin such as a load balancer",
+		"origin-path":     "The directory, beginning with a /, from which CloudFront requests the content of the origin",
+		"path-pattern":    "The path pattern (for example, images/*.jpg) of the cache behavior to update or to add. The default cache behavior is updated when absent or '*'",
+		"target-origin":   "The ID of the origin to which CloudFront routes requests matching the cache behavior",
+		"https-behaviour": "The protocol (HTTP or HTTPS) that viewers can use to access the files (allow-all | redirect-to-https | https-only)",
+		"min-ttl":         "The minimum amount of time, in seconds, that objects stay in CloudFront caches",
+		"default-ttl":     "The default amount of time, in seconds, that objects stay in CloudFront caches when the origin does not set caching headers",
+		"max-ttl":         "The maximum amount of time, in seconds, that objects stay in CloudFront caches",
+		"compress":        "Whether CloudFront automatically compresses the files of the cache behavior (true | false)",
+		"forward-cookies": "Specifies which cookies to forward to the origin for this cache behavior (all | none | whitelist)",
+		"forward-queries": "Indicates whether you want CloudFront to forward query strings to the origin for this cache behavior (true | false)",
+	},
+	"updatefleet": {
+		"id":                          "The ID of the Spot Fleet request (sfr-...) or EC2 Fleet (fleet-...) to update",
+		"excess-capacity-termination": "Whether instances above a decreased target capacity are terminated (default | noTermination)",
+		"on-demand-capacity":          "The new part of the target capacity launched as on-demand instances (EC2 Fleet only)",
+		"target-capacity":             "The new target capacity of the fleet (required for EC2 Fleets)",
+	},
+	"updatehealthcheck": {
+		"id":                "The id of the health check to update",
+		"host":              "The domain name of the endpoint to check",
+		"ip":                "The IPv4 or IPv6 address of the endpoint to check",
+		"port":              "The port of the endpoint to check",
+		"path":              "The path requested by HTTP and HTTPS checks",
+		"search-string":     "The string that must appear in the first 5120 bytes of the response body of *_STR_MATCH checks",
+		"failure-threshold": "The number of consecutive checks an endpoint must pass or fail to change its status (1 to 10)",
+		"regions":           "The regions from which the endpoint is checked (at least 3)",
+		"inverted":          "Consider healthy endpoints as unhealthy and vice versa (true | false)",
+		"children":          "The health checks aggregated by a CALCULATED health check",
+		"healthy-threshold": "The number of child health checks that must be healthy for a CALCULATED health check to be healthy",
+		"version":           "The version of the health check to update, failing if it has changed since (see awless show)",
 	},
 	"updateinstance": {
 		"type": "Changes the instance type to the specified value",
 	},
+	"updateprefixlist": {
+		"id":           "The ID of the customer-managed prefix list to update",
+		"add-cidrs":    "The CIDR blocks to add as entries",
+		"remove-cidrs": "The CIDR blocks of the entries to remove",
+		"name":         "A new name for the prefix list",
+	},
+	"updaterepository": {
+		"name":                  "The name of the repository to update",
+		"account":               "The AWS account ID associated with the registry that contains the repository",
+		"lifecycle-policy-file": "Path to the JSON lifecycle policy expiring images of the repository",
+		"scan-on-push":          "Whether images are scanned for vulnerabilities when pushed to the repository (true | false)",
+	},
 	"updates3object": {
 		"acl":     "The canned ACL to apply to the bucket (private | public-read | public-read-write | aws-exec-read | authenticated-read | bucket-owner-read | bucket-owner-full-control | log-delivery-write)",
 		"bucket":  "The name of the bucket containing the object to be updated",
@@ -397,4 +635,8 @@ var manualParamsDoc = map[string]map[string]string{
 		"policy-update-file": "The path to the file containing the temporary overriding stack policy",
 		"template-file":      "The path to the file containing the template body with a minimum size of 1 byte and a maximum size of 51,200 bytes",
 	},
+	"updatestream": {
+		"name":   "The name (or ARN) of the provisioned Kinesis data stream to scale",
+		"shards": "The target number of shards of the stream (at most twice and at least half the current count)",
+	},
 }
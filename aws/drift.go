@@ -0,0 +1,229 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/template"
+)
+
+// DriftRemediation holds the template commands reverting drifted resources to their
+// snapshot state, and the changes no update driver can revert, to be handled manually
+type DriftRemediation struct {
+	Commands []string
+	Manual   []string
+}
+
+// Template returns the remediation as a reviewable awless template, manual changes as comments
+func (r *DriftRemediation) Template() string {
+	var b strings.Builder
+	for _, m := range r.Manual {
+		fmt.Fprintf(&b, "# manual: %s\n", m)
+	}
+	for _, c := range r.Commands {
+		fmt.Fprintln(&b, c)
+	}
+	return b.String()
+}
+
+// driftRemediators build the commands reverting a property of a resource type to its snapshot value
+var driftRemediators = map[string]map[string]func(res *graph.Resource, change *graph.PropertyChange) []string{
+	cloud.Instance: {
+		properties.Type: func(res *graph.Resource, c *graph.PropertyChange) []string {
+			return []string{fmt.Sprintf("update instance id=%s type=%v", res.Id(), c.Snapshot)}
+		},
+		properties.State: func(res *graph.Resource, c *graph.PropertyChange) []string {
+			switch c.Snapshot {
+			case "running":
+				return []string{fmt.Sprintf("start instance id=%s", res.Id())}
+			case "stopped":
+				return []string{fmt.Sprintf("stop instance id=%s", res.Id())}
+			}
+			return nil
+		},
+		properties.Tags: remediateTags,
+	},
+	cloud.Subnet: {
+		properties.Public: func(res *graph.Resource, c *graph.PropertyChange) []string {
+			if public, ok := c.Snapshot.(bool); ok {
+				return []string{fmt.Sprintf("update subnet id=%s public=%t", res.Id(), public)}
+			}
+			return nil
+		},
+		properties.Tags: remediateTags,
+	},
+	cloud.SecurityGroup: {
+		properties.InboundRules:  remediateRules("inbound"),
+		properties.OutboundRules: remediateRules("outbound"),
+	},
+	cloud.Vpc: {
+		properties.Tags: remediateTags,
+	},
+	cloud.ScalingGroup: {
+		properties.MinSize:         remediateScalingGroup("min-size"),
+		properties.MaxSize:         remediateScalingGroup("max-size"),
+		properties.DesiredCapacity: remediateScalingGroup("desired-capacity"),
+		properties.DefaultCooldown: remediateScalingGroup("cooldown"),
+	},
+}
+
+// RemediateDrift returns the commands reverting the drifted resources to their snapshot state.
+// Created or removed resources and properties with no update driver are reported as manual
+func RemediateDrift(drifts []*graph.ResourceDrift) *DriftRemediation {
+	rem := &DriftRemediation{}
+	for _, d := range drifts {
+		res := d.Resource
+		switch {
+		case d.Removed():
+			rem.Manual = append(rem.Manual, fmt.Sprintf("%s removed since snapshot", res))
+			continue
+		case d.Created():
+			rem.Manual = append(rem.Manual, fmt.Sprintf("%s created since snapshot", res))
+			continue
+		}
+		for _, c := range d.Changes {
+			if c.Property == properties.Name && tagsChanged(d) {
+				continue // reverted with the Name tag
+			}
+			var cmds []string
+			if fn, ok := driftRemediators[res.Type()][c.Property]; ok {
+				cmds = fn(res, c)
+			}
+			if len(cmds) == 0 {
+				rem.Manual = append(rem.Manual, fmt.Sprintf("%s %s changed from %v to %v", res, c.Property, c.Snapshot, c.Current))
+				continue
+			}
+			rem.Commands = append(rem.Commands, cmds...)
+		}
+	}
+	return rem
+}
+
+func tagsChanged(d *graph.ResourceDrift) bool {
+	if _, ok := driftRemediators[d.Resource.Type()][properties.Tags]; !ok {
+		return false
+	}
+	for _, c := range d.Changes {
+		if c.Property == properties.Tags {
+			return true
+		}
+	}
+	return false
+}
+
+func remediateScalingGroup(param string) func(*graph.Resource, *graph.PropertyChange) []string {
+	return func(res *graph.Resource, c *graph.PropertyChange) []string {
+		name, ok := res.Properties[properties.Name].(string)
+		if !ok || c.Snapshot == nil {
+			return nil
+		}
+		return []string{fmt.Sprintf("update scalinggroup name=%s %s=%v", name, param, c.Snapshot)}
+	}
+}
+
+// remediateTags deletes the tags added or changed since the snapshot and recreates the removed or changed ones
+func remediateTags(res *graph.Resource, c *graph.PropertyChange) []string {
+	before, after := tagsMap(c.Snapshot), tagsMap(c.Current)
+	var cmds []string
+	for _, k := range sortedKeys(after) {
+		if v, ok := before[k]; !ok || v != after[k] {
+			cmds = append(cmds, fmt.Sprintf("delete tag resource=%s key=%s value=%s", res.Id(), template.QuoteParamIfNeeded(k), template.QuoteParamIfNeeded(after[k])))
+		}
+	}
+	for _, k := range sortedKeys(before) {
+		if v, ok := after[k]; !ok || v != before[k] {
+			cmds = append(cmds, fmt.Sprintf("create tag resource=%s key=%s value=%s", res.Id(), template.QuoteParamIfNeeded(k), template.QuoteParamIfNeeded(before[k])))
+		}
+	}
+	return cmds
+}
+
+// remediateRules authorizes the rules removed since the snapshot and revokes the added ones.
+// Rules on prefix lists only have no cidr to revert, so they are left to manual remediation
+func remediateRules(direction string) func(*graph.Resource, *graph.PropertyChange) []string {
+	return func(res *graph.Resource, c *graph.PropertyChange) []string {
+		before, after := ruleCommands(c.Snapshot), ruleCommands(c.Current)
+		var cmds []string
+		for _, r := range sortedKeys(before) {
+			if _, ok := after[r]; !ok {
+				cmds = append(cmds, fmt.Sprintf("update securitygroup id=%s %s=authorize %s", res.Id(), direction, r))
+			}
+		}
+		for _, r := range sortedKeys(after) {
+			if _, ok := before[r]; !ok {
+				cmds = append(cmds, fmt.Sprintf("update securitygroup id=%s %s=revoke %s", res.Id(), direction, r))
+			}
+		}
+		return cmds
+	}
+}
+
+// ruleCommands returns the protocol, cidr and portrange params of each cidr of the rules
+func ruleCommands(v interface{}) map[string]string {
+	rules, _ := v.([]*graph.FirewallRule)
+	params := make(map[string]string)
+	for _, r := range rules {
+		for _, ipr := range r.IPRanges {
+			p := fmt.Sprintf("protocol=%s cidr=%s", r.Protocol, ipr)
+			if r.Protocol != "any" {
+				p += " portrange=" + templatePortRange(r.PortRange)
+			}
+			params[p] = p
+		}
+	}
+	return params
+}
+
+func templatePortRange(p graph.PortRange) string {
+	switch {
+	case p.Any:
+		return "any"
+	case p.FromPort == p.ToPort || p.ToPort == 0:
+		return fmt.Sprint(p.FromPort)
+	case p.FromPort == 0:
+		return fmt.Sprint(p.ToPort)
+	default:
+		return fmt.Sprintf("%d-%d", p.FromPort, p.ToPort)
+	}
+}
+
+func tagsMap(v interface{}) map[string]string {
+	tags, _ := v.([]string)
+	m := make(map[string]string)
+	for _, t := range tags {
+		kv := strings.SplitN(t, "=", 2)
+		if len(kv) == 2 {
+			m[kv[0]] = kv[1]
+		}
+	}
+	return m
+}
+
+func sortedKeys(m map[string]string) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+)
+
+func TestRemediateDrift(t *testing.T) {
+	_, anywhere, _ := net.ParseCIDR("0.0.0.0/0")
+	_, private, _ := net.ParseCIDR("10.0.0.0/16")
+	ssh := &graph.FirewallRule{Protocol: "tcp", PortRange: graph.PortRange{FromPort: 22, ToPort: 22}, IPRanges: []*net.IPNet{private}}
+	open := &graph.FirewallRule{Protocol: "any", PortRange: graph.PortRange{Any: true}, IPRanges: []*net.IPNet{anywhere}}
+	web := &graph.FirewallRule{Protocol: "tcp", PortRange: graph.PortRange{FromPort: 80, ToPort: 443}, IPRanges: []*net.IPNet{anywhere}}
+
+	drifts := []*graph.ResourceDrift{
+		{
+			Resource: resourcetest.Instance("inst_1").Build(),
+			Changes: []*graph.PropertyChange{
+				{Property: properties.Name, Snapshot: "web", Current: "old"},
+				{Property: properties.PublicIP, Snapshot: "1.2.3.4", Current: "5.6.7.8"},
+				{Property: properties.State, Snapshot: "running", Current: "stopped"},
+				{Property: properties.Tags, Snapshot: []string{"Name=web", "Env=prod"}, Current: []string{"Name=old"}},
+				{Property: properties.Type, Snapshot: "t2.micro", Current: "t2.large"},
+			},
+		},
+		{
+			Resource: resourcetest.SecurityGroup("sg_1").Build(),
+			Changes: []*graph.PropertyChange{
+				{Property: properties.InboundRules, Snapshot: []*graph.FirewallRule{ssh, web}, Current: []*graph.FirewallRule{ssh, open}},
+			},
+		},
+		{
+			Resource: resourcetest.ScalingGroup("asg_arn").Prop(properties.Name, "workers").Build(),
+			Changes: []*graph.PropertyChange{
+				{Property: properties.MinSize, Snapshot: int64(2), Current: int64(0)},
+			},
+		},
+		{Resource: resourcetest.Subnet("sub_1").Build(), Current: resourcetest.Subnet("sub_1").Build()},
+	}
+	drifts[0].Snapshot, drifts[0].Current = drifts[0].Resource, drifts[0].Resource
+	drifts[1].Snapshot, drifts[1].Current = drifts[1].Resource, drifts[1].Resource
+	drifts[2].Snapshot, drifts[2].Current = drifts[2].Resource, drifts[2].Resource
+
+	rem := RemediateDrift(drifts)
+
+	expCommands := []string{
+		"start instance id=inst_1",
+		"delete tag resource=inst_1 key=Name value=old",
+		"create tag resource=inst_1 key=Env value=prod",
+		"create tag resource=inst_1 key=Name value=web",
+		"update instance id=inst_1 type=t2.micro",
+		"update securitygroup id=sg_1 inbound=authorize protocol=tcp cidr=0.0.0.0/0 portrange=80-443",
+		"update securitygroup id=sg_1 inbound=revoke protocol=any cidr=0.0.0.0/0",
+		"update scalinggroup name=workers min-size=2",
+	}
+	if got, want := rem.Commands, expCommands; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got\n%#v\nwant\n%#v", got, want)
+	}
+	expManual := []string{
+		"inst_1[instance] PublicIP changed from 1.2.3.4 to 5.6.7.8",
+		"sub_1[subnet] created since snapshot",
+	}
+	if got, want := rem.Manual, expManual; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got\n%#v\nwant\n%#v", got, want)
+	}
+}
@@ -27,16 +27,19 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/cloudfront"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/ecr"
 	"github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/aws/aws-sdk-go/service/elbv2"
@@ -48,6 +51,8 @@ import (
 	"github.com/wallix/awless/cloud"
 	"github.com/wallix/awless/console"
 	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template"
+	"github.com/wallix/awless/template/driver"
 )
 
 const (
@@ -267,7 +272,7 @@ func (d *EcsDriver) Create_Container(params map[string]interface{}) (interface{}
 
 	taskDefOutput, err := d.RegisterTaskDefinition(taskDefinitionInput)
 	if err != nil {
-		return nil, fmt.Errorf("create container: register task definition: %s", err)
+		return nil, fmt.Errorf("create container: register task definition: %w", err)
 	}
 	d.logger.ExtraVerbosef("ecs.RegisterTaskDefinitionOutput call took %s", time.Since(start))
 	d.logger.ExtraVerbosef("create container: register task definition '%s' done", aws.StringValue(taskDefOutput.TaskDefinition.Family))
@@ -325,7 +330,7 @@ func (d *EcsDriver) Delete_Container(params map[string]interface{}) (interface{}
 		start := time.Now()
 
 		if _, err := d.RegisterTaskDefinition(taskDefinitionInput); err != nil {
-			return nil, fmt.Errorf("delete container: register task definition: %s", err)
+			return nil, fmt.Errorf("delete container: register task definition: %w", err)
 		}
 		d.logger.ExtraVerbosef("ecs.RegisterTaskDefinition call took %s", time.Since(start))
 
@@ -337,7 +342,7 @@ func (d *EcsDriver) Delete_Container(params map[string]interface{}) (interface{}
 		start := time.Now()
 
 		if _, err := d.DeregisterTaskDefinition(taskDefinitionInput); err != nil {
-			return nil, fmt.Errorf("delete container: deregister task definition: %s", err)
+			return nil, fmt.Errorf("delete container: deregister task definition: %w", err)
 		}
 		d.logger.ExtraVerbosef("ecs.DeregisterTaskDefinition call took %s", time.Since(start))
 	}
@@ -360,32 +365,9 @@ func (d *IamDriver) Create_Policy_DryRun(params map[string]interface{}) (interfa
 }
 
 func (d *IamDriver) Create_Policy(params map[string]interface{}) (interface{}, error) {
-	effect, _ := params["effect"].(string)
-	resource, _ := params["resource"].(string)
-	actions, multipleAction := params["action"].([]string)
-	action, singleAction := params["action"].(string)
-
-	if resource == "all" {
-		resource = "*"
-	}
-
-	stat := policyStatement{Effect: strings.Title(effect), Resource: resource}
-
-	if multipleAction {
-		stat.Actions = actions
-	}
-	if singleAction {
-		stat.Actions = []string{action}
-	}
-
-	policy := &policyBody{
-		Version:   "2012-10-17",
-		Statement: []policyStatement{stat},
-	}
-
-	b, err := json.MarshalIndent(policy, "", " ")
+	b, err := policyDocumentFromParams(params)
 	if err != nil {
-		return nil, errors.New("cannot marshal policy document")
+		return nil, err
 	}
 
 	d.logger.ExtraVerbosef("policy document json:\n%s\n", string(b))
@@ -435,7 +417,7 @@ func (d *IamDriver) Delete_Role(params map[string]interface{}) (interface{}, err
 	start := time.Now()
 	output, err := d.DeleteRole(input)
 	if err != nil {
-		return nil, fmt.Errorf("delete role: %s", err)
+		return nil, fmt.Errorf("delete role: %w", err)
 	}
 	d.logger.ExtraVerbosef("iam.DeleteRole call took %s", time.Since(start))
 	d.logger.Info("delete role done")
@@ -461,6 +443,37 @@ type principal struct {
 	Service interface{} `json:",omitempty"`
 }
 
+func policyDocumentFromParams(params map[string]interface{}) ([]byte, error) {
+	effect, _ := params["effect"].(string)
+	resource, _ := params["resource"].(string)
+	actions, multipleAction := params["action"].([]string)
+	action, singleAction := params["action"].(string)
+
+	if resource == "all" {
+		resource = "*"
+	}
+
+	stat := policyStatement{Effect: strings.Title(effect), Resource: resource}
+
+	if multipleAction {
+		stat.Actions = actions
+	}
+	if singleAction {
+		stat.Actions = []string{action}
+	}
+
+	policy := &policyBody{
+		Version:   "2012-10-17",
+		Statement: []policyStatement{stat},
+	}
+
+	b, err := json.MarshalIndent(policy, "", " ")
+	if err != nil {
+		return nil, errors.New("cannot marshal policy document")
+	}
+	return b, nil
+}
+
 type policyStatement struct {
 	Effect    string     `json:",omitempty"`
 	Actions   []string   `json:"Action,omitempty"`
@@ -632,6 +645,148 @@ func (d *IamDriver) Detach_Policy(params map[string]interface{}) (interface{}, e
 	return nil, errors.New("missing one of 'user, group, role' param")
 }
 
+func (d *IamDriver) Create_Inlinepolicy_DryRun(params map[string]interface{}) (interface{}, error) {
+	if _, ok := params["name"]; !ok {
+		return nil, errors.New("create inlinepolicy: missing required params 'name'")
+	}
+
+	if err := checkInlinePolicyOwner(params); err != nil {
+		return nil, fmt.Errorf("create inlinepolicy: %s", err)
+	}
+
+	_, document := params["document"]
+	_, effect := params["effect"]
+	_, action := params["action"]
+	_, resource := params["resource"]
+
+	if !document && (!effect || !action || !resource) {
+		return nil, errors.New("create inlinepolicy: either document or effect, action and resource are required values")
+	}
+
+	d.logger.Verbose("params dry run: create inlinepolicy ok")
+	return nil, nil
+}
+
+func (d *IamDriver) Create_Inlinepolicy(params map[string]interface{}) (interface{}, error) {
+	var doc []byte
+	var err error
+	if path, ok := params["document"]; ok {
+		if doc, err = ioutil.ReadFile(fmt.Sprint(path)); err != nil {
+			return nil, fmt.Errorf("create inlinepolicy: %s", err)
+		}
+		if !json.Valid(doc) {
+			return nil, fmt.Errorf("create inlinepolicy: %s is not a valid JSON policy document", path)
+		}
+	} else if doc, err = policyDocumentFromParams(params); err != nil {
+		return nil, err
+	}
+
+	d.logger.ExtraVerbosef("inline policy document json:\n%s\n", string(doc))
+
+	user, hasUser := params["user"]
+	group, hasGroup := params["group"]
+	role, hasRole := params["role"]
+
+	call := &driverCall{
+		d:      d,
+		logger: d.logger,
+		setters: []setter{
+			{val: params["name"], fieldPath: "PolicyName", fieldType: awsstr},
+			{val: string(doc), fieldPath: "PolicyDocument", fieldType: awsstr},
+		},
+	}
+
+	switch {
+	case hasUser:
+		call.desc = "put user inline policy"
+		call.fn = d.PutUserPolicy
+		call.setters = append(call.setters, setter{val: user, fieldPath: "UserName", fieldType: awsstr})
+		_, err = call.execute(&iam.PutUserPolicyInput{})
+	case hasGroup:
+		call.desc = "put group inline policy"
+		call.fn = d.PutGroupPolicy
+		call.setters = append(call.setters, setter{val: group, fieldPath: "GroupName", fieldType: awsstr})
+		_, err = call.execute(&iam.PutGroupPolicyInput{})
+	case hasRole:
+		call.desc = "put role inline policy"
+		call.fn = d.PutRolePolicy
+		call.setters = append(call.setters, setter{val: role, fieldPath: "RoleName", fieldType: awsstr})
+		_, err = call.execute(&iam.PutRolePolicyInput{})
+	default:
+		return nil, errors.New("missing one of 'user, group, role' param")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return params["name"], nil
+}
+
+func (d *IamDriver) Delete_Inlinepolicy_DryRun(params map[string]interface{}) (interface{}, error) {
+	if _, ok := params["name"]; !ok {
+		return nil, errors.New("delete inlinepolicy: missing required params 'name'")
+	}
+
+	if err := checkInlinePolicyOwner(params); err != nil {
+		return nil, fmt.Errorf("delete inlinepolicy: %s", err)
+	}
+
+	d.logger.Verbose("params dry run: delete inlinepolicy ok")
+	return nil, nil
+}
+
+func (d *IamDriver) Delete_Inlinepolicy(params map[string]interface{}) (interface{}, error) {
+	user, hasUser := params["user"]
+	group, hasGroup := params["group"]
+	role, hasRole := params["role"]
+
+	call := &driverCall{
+		d:      d,
+		logger: d.logger,
+		setters: []setter{
+			{val: params["name"], fieldPath: "PolicyName", fieldType: awsstr},
+		},
+	}
+
+	switch {
+	case hasUser:
+		call.desc = "delete user inline policy"
+		call.fn = d.DeleteUserPolicy
+		call.setters = append(call.setters, setter{val: user, fieldPath: "UserName", fieldType: awsstr})
+		return call.execute(&iam.DeleteUserPolicyInput{})
+	case hasGroup:
+		call.desc = "delete group inline policy"
+		call.fn = d.DeleteGroupPolicy
+		call.setters = append(call.setters, setter{val: group, fieldPath: "GroupName", fieldType: awsstr})
+		return call.execute(&iam.DeleteGroupPolicyInput{})
+	case hasRole:
+		call.desc = "delete role inline policy"
+		call.fn = d.DeleteRolePolicy
+		call.setters = append(call.setters, setter{val: role, fieldPath: "RoleName", fieldType: awsstr})
+		return call.execute(&iam.DeleteRolePolicyInput{})
+	}
+
+	return nil, errors.New("missing one of 'user, group, role' param")
+}
+
+// Inline policies are embedded in exactly one user, group or role
+func checkInlinePolicyOwner(params map[string]interface{}) error {
+	var count int
+	for _, k := range []string{"user", "group", "role"} {
+		if _, ok := params[k]; ok {
+			count++
+		}
+	}
+	switch count {
+	case 0:
+		return errors.New("missing one of 'user, group, role' param")
+	case 1:
+		return nil
+	default:
+		return errors.New("only one of 'user, group, role' param expected")
+	}
+}
+
 func (d *IamDriver) Create_Accesskey_DryRun(params map[string]interface{}) (interface{}, error) {
 	if _, ok := params["user"]; !ok {
 		return nil, errors.New("create accesskey: missing required params 'user'")
@@ -656,7 +811,7 @@ func (d *IamDriver) Create_Accesskey(params map[string]interface{}) (interface{}
 	output, err = d.CreateAccessKey(input)
 
 	if err != nil {
-		return nil, fmt.Errorf("create accesskey: %s", err)
+		return nil, fmt.Errorf("create accesskey: %w", err)
 	}
 	d.logger.ExtraVerbosef("iam.CreateAccessKey call took %s", time.Since(start))
 
@@ -677,92 +832,396 @@ func (d *IamDriver) Create_Accesskey(params map[string]interface{}) (interface{}
 	return id, nil
 }
 
-func (d *Ec2Driver) Check_Instance_DryRun(params map[string]interface{}) (interface{}, error) {
+func (d *Ec2Driver) Start_Instance_DryRun(params map[string]interface{}) (interface{}, error) {
+	return d.changeInstancesStateDryRun("start", params, func(ids []*string) error {
+		_, err := d.StartInstances(&ec2.StartInstancesInput{DryRun: aws.Bool(true), InstanceIds: ids})
+		return err
+	})
+}
+
+func (d *Ec2Driver) Start_Instance(params map[string]interface{}) (interface{}, error) {
+	return d.changeInstancesState("start", d.instancesStateWaiter(ec2.InstanceStateNameRunning), params, func(ids []*string) error {
+		start := time.Now()
+		_, err := d.StartInstances(&ec2.StartInstancesInput{InstanceIds: ids})
+		d.logger.ExtraVerbosef("ec2.StartInstances call took %s", time.Since(start))
+		return err
+	})
+}
+
+func (d *Ec2Driver) Stop_Instance_DryRun(params map[string]interface{}) (interface{}, error) {
+	return d.changeInstancesStateDryRun("stop", params, func(ids []*string) error {
+		_, err := d.StopInstances(&ec2.StopInstancesInput{DryRun: aws.Bool(true), InstanceIds: ids})
+		return err
+	})
+}
+
+func (d *Ec2Driver) Stop_Instance(params map[string]interface{}) (interface{}, error) {
+	return d.changeInstancesState("stop", d.instancesStateWaiter(ec2.InstanceStateNameStopped), params, func(ids []*string) error {
+		start := time.Now()
+		_, err := d.StopInstances(&ec2.StopInstancesInput{InstanceIds: ids})
+		d.logger.ExtraVerbosef("ec2.StopInstances call took %s", time.Since(start))
+		return err
+	})
+}
+
+func (d *Ec2Driver) Reboot_Instance_DryRun(params map[string]interface{}) (interface{}, error) {
+	return d.changeInstancesStateDryRun("reboot", params, func(ids []*string) error {
+		_, err := d.RebootInstances(&ec2.RebootInstancesInput{DryRun: aws.Bool(true), InstanceIds: ids})
+		return err
+	})
+}
+
+// Reboot_Instance reboots the instances in place. With 'wait', instances are polled
+// until they are running with their instance and system status checks passing
+func (d *Ec2Driver) Reboot_Instance(params map[string]interface{}) (interface{}, error) {
+	return d.changeInstancesState("reboot", d.waitInstancesStatusOk, params, func(ids []*string) error {
+		start := time.Now()
+		_, err := d.RebootInstances(&ec2.RebootInstancesInput{InstanceIds: ids})
+		d.logger.ExtraVerbosef("ec2.RebootInstances call took %s", time.Since(start))
+		return err
+	})
+}
+
+func (d *Ec2Driver) changeInstancesStateDryRun(action string, params map[string]interface{}, call func([]*string) error) (interface{}, error) {
 	if _, ok := params["id"]; !ok {
-		return nil, errors.New("check instance: missing required params 'id'")
+		return nil, fmt.Errorf("%s instance: missing required params 'id'", action)
 	}
-
-	states := map[string]struct{}{
-		"pending":       {},
-		"running":       {},
-		"shutting-down": {},
-		"terminated":    {},
-		"stopping":      {},
-		"stopped":       {},
-		notFoundState:   {},
+	if _, _, err := staggeringParams(params); err != nil {
+		return nil, fmt.Errorf("dry run: %s instance: %w", action, err)
+	}
+	if _, err := durationParam(params, "wait-timeout"); err != nil {
+		return nil, fmt.Errorf("dry run: %s instance: %w", action, err)
 	}
 
-	if state, ok := params["state"].(string); !ok {
-		return nil, errors.New("check instance: missing required params 'state'")
-	} else {
-		if _, stok := states[state]; !stok {
-			return nil, fmt.Errorf("check instance: invalid state '%s'", state)
+	err := call(aws.StringSlice(castStringSlice(params["id"])))
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound):
+			id := fakeDryRunId("instance")
+			d.logger.Verbosef("dry run: %s instance ok", action)
+			return id, nil
 		}
 	}
 
-	if _, ok := params["timeout"]; !ok {
-		return nil, errors.New("check instance: missing required params 'timeout'")
+	return nil, fmt.Errorf("dry run: %s instance: %w", action, err)
+}
+
+// changeInstancesState applies the action on the instances by batches of 'batch' instances
+// separated by 'delay', to avoid boot storms on dependent systems (databases, license servers, ...).
+// Without 'batch', all the instances are processed at once. With 'wait', each batch is polled
+// with waitFn until all its instances are ready
+func (d *Ec2Driver) changeInstancesState(action string, waitFn func([]string, time.Duration) error, params map[string]interface{}, call func([]*string) error) (interface{}, error) {
+	ids := castStringSlice(params["id"])
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%s instance: missing required params 'id'", action)
+	}
+	batch, delay, err := staggeringParams(params)
+	if err != nil {
+		return nil, fmt.Errorf("%s instance: %w", action, err)
+	}
+	wait := fmt.Sprint(params["wait"]) == "true"
+	waitTimeout, err := durationParam(params, "wait-timeout")
+	if err != nil {
+		return nil, fmt.Errorf("%s instance: %w", action, err)
+	}
+	if waitTimeout == 0 {
+		waitTimeout = instanceStateWaitTimeout
 	}
 
-	if _, ok := params["timeout"].(int); !ok {
-		return nil, errors.New("check instance: timeout param is not int")
+	batches := splitInBatches(ids, batch)
+	for i, b := range batches {
+		if i > 0 && delay > 0 {
+			d.logger.Infof("%s instance: waiting %s before next batch", action, delay)
+			time.Sleep(delay)
+		}
+		if err := call(aws.StringSlice(b)); err != nil {
+			if len(batches) > 1 {
+				return nil, fmt.Errorf("%s instance: batch %d/%d: %w", action, i+1, len(batches), err)
+			}
+			return nil, fmt.Errorf("%s instance: %w", action, err)
+		}
+		if wait {
+			if err := waitFn(b, waitTimeout); err != nil {
+				return nil, fmt.Errorf("%s instance: %w", action, err)
+			}
+		}
+		if len(batches) > 1 {
+			d.logger.Infof("%s instance: batch %d/%d done (%s)", action, i+1, len(batches), strings.Join(b, ", "))
+		}
 	}
 
-	input := &ec2.DescribeInstancesInput{}
-	input.DryRun = aws.Bool(true)
+	d.logger.Infof("%s instance '%s' done", action, strings.Join(ids, ", "))
+	if len(ids) == 1 {
+		return ids[0], nil
+	}
+	return ids, nil
+}
 
-	// Required params
-	err := setFieldWithType(params["id"], input, "InstanceIds", awsstringslice)
+func staggeringParams(params map[string]interface{}) (int, time.Duration, error) {
+	var batch int
+	if b, ok := params["batch"]; ok {
+		var err error
+		if batch, err = strconv.Atoi(fmt.Sprint(b)); err != nil || batch < 1 {
+			return 0, 0, fmt.Errorf("invalid batch '%v': expecting a positive integer", b)
+		}
+	}
+
+	delay, err := durationParam(params, "delay")
 	if err != nil {
-		return nil, err
+		return 0, 0, err
+	}
+	if delay > 0 && batch == 0 {
+		return 0, 0, errors.New("delay requires the batch param")
 	}
 
-	_, err = d.DescribeInstances(input)
-	if awsErr, ok := err.(awserr.Error); ok {
-		switch code := awsErr.Code(); {
-		case code == dryRunOperation, strings.HasSuffix(code, notFound):
-			id := fakeDryRunId("instance")
-			d.logger.Verbose("dry run: check instance ok")
-			return id, nil
+	return batch, delay, nil
+}
+
+// durationParam returns the duration of the param given as a duration (ex: 30s, 2m) or a number of seconds
+func durationParam(params map[string]interface{}, key string) (time.Duration, error) {
+	switch v := params[key].(type) {
+	case nil:
+		return 0, nil
+	case int:
+		return time.Duration(v) * time.Second, nil
+	default:
+		d, err := time.ParseDuration(fmt.Sprint(v))
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s '%v': expecting a duration (ex: 30s, 2m) or seconds", key, v)
 		}
+		return d, nil
 	}
-	return nil, fmt.Errorf("dry run: check instance: %s", err)
 }
 
-func (d *Ec2Driver) Check_Instance(params map[string]interface{}) (interface{}, error) {
-	input := &ec2.DescribeInstancesInput{}
+var (
+	instanceStateWaitTimeout   = 5 * time.Minute
+	instanceStatePollFrequency = 5 * time.Second
+)
 
-	// Required params
-	err := setFieldWithType(params["id"], input, "InstanceIds", awsstringslice)
-	if err != nil {
-		return nil, err
+func (d *Ec2Driver) instancesStateWaiter(expect string) func([]string, time.Duration) error {
+	return func(ids []string, timeout time.Duration) error {
+		return d.waitInstancesState(ids, expect, timeout)
+	}
+}
+
+// waitInstancesState polls the instances until they all reach the expected state,
+// reporting the ones that did not within the timeout
+func (d *Ec2Driver) waitInstancesState(ids []string, expect string, timeout time.Duration) error {
+	pending := make(map[string]string)
+	for _, id := range ids {
+		pending[id] = "unknown"
 	}
 	c := &checker{
-		description: fmt.Sprintf("instance %s", params["id"]),
-		timeout:     time.Duration(params["timeout"].(int)) * time.Second,
-		frequency:   5 * time.Second,
+		description: fmt.Sprintf("instances %s", strings.Join(ids, ", ")),
+		timeout:     timeout,
+		frequency:   instanceStatePollFrequency,
 		fetchFunc: func() (string, error) {
-			output, err := d.DescribeInstances(input)
+			var remaining []string
+			for id := range pending {
+				remaining = append(remaining, id)
+			}
+			out, err := d.DescribeInstances(&ec2.DescribeInstancesInput{InstanceIds: aws.StringSlice(remaining)})
 			if err != nil {
-				if awserr, ok := err.(awserr.Error); ok {
-					if awserr.Code() == "InstanceNotFound" {
-						return notFoundState, nil
+				return "", err
+			}
+			for _, res := range out.Reservations {
+				for _, inst := range res.Instances {
+					id := aws.StringValue(inst.InstanceId)
+					if _, ok := pending[id]; !ok || inst.State == nil {
+						continue
 					}
-				} else {
-					return "", err
-				}
-			} else {
-				if res := output.Reservations; len(res) > 0 {
-					if instances := output.Reservations[0].Instances; len(instances) > 0 {
-						for _, inst := range instances {
-							if aws.StringValue(inst.InstanceId) == params["id"] {
-								return aws.StringValue(inst.State.Name), nil
-							}
-						}
+					if state := aws.StringValue(inst.State.Name); state == expect {
+						d.logger.Verbosef("instance %s is %s", id, expect)
+						delete(pending, id)
+					} else {
+						pending[id] = state
 					}
 				}
 			}
-			return notFoundState, nil
+			if len(pending) == 0 {
+				return expect, nil
+			}
+			return fmt.Sprintf("%d/%d %s", len(ids)-len(pending), len(ids), expect), nil
+		},
+		expect:    expect,
+		logger:    d.logger,
+		checkName: "state",
+	}
+	if err := c.check(); err != nil {
+		var failed []string
+		for id, state := range pending {
+			failed = append(failed, fmt.Sprintf("%s (%s)", id, state))
+		}
+		sort.Strings(failed)
+		return fmt.Errorf("%s: instances not %s: %s", err, expect, strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+const instanceStatusOk = "ok"
+
+// waitInstancesStatusOk polls the instances until they are all running with their instance
+// and system status checks passing, reporting the ones that did not within the timeout
+func (d *Ec2Driver) waitInstancesStatusOk(ids []string, timeout time.Duration) error {
+	pending := make(map[string]string)
+	for _, id := range ids {
+		pending[id] = "unknown"
+	}
+	c := &checker{
+		description: fmt.Sprintf("instances %s", strings.Join(ids, ", ")),
+		timeout:     timeout,
+		frequency:   instanceStatePollFrequency,
+		fetchFunc: func() (string, error) {
+			var remaining []string
+			for id := range pending {
+				remaining = append(remaining, id)
+			}
+			out, err := d.DescribeInstanceStatus(&ec2.DescribeInstanceStatusInput{InstanceIds: aws.StringSlice(remaining), IncludeAllInstances: aws.Bool(true)})
+			if err != nil {
+				return "", err
+			}
+			for _, status := range out.InstanceStatuses {
+				id := aws.StringValue(status.InstanceId)
+				if _, ok := pending[id]; !ok {
+					continue
+				}
+				var state, instanceStatus, systemStatus string
+				if status.InstanceState != nil {
+					state = aws.StringValue(status.InstanceState.Name)
+				}
+				if status.InstanceStatus != nil {
+					instanceStatus = aws.StringValue(status.InstanceStatus.Status)
+				}
+				if status.SystemStatus != nil {
+					systemStatus = aws.StringValue(status.SystemStatus.Status)
+				}
+				if state == ec2.InstanceStateNameRunning && instanceStatus == instanceStatusOk && systemStatus == instanceStatusOk {
+					d.logger.Verbosef("instance %s is running with status checks ok", id)
+					delete(pending, id)
+				} else {
+					pending[id] = fmt.Sprintf("%s, instance status %s, system status %s", state, instanceStatus, systemStatus)
+				}
+			}
+			if len(pending) == 0 {
+				return instanceStatusOk, nil
+			}
+			return fmt.Sprintf("%d/%d %s", len(ids)-len(pending), len(ids), instanceStatusOk), nil
+		},
+		expect:    instanceStatusOk,
+		logger:    d.logger,
+		checkName: "status checks",
+	}
+	if err := c.check(); err != nil {
+		var failed []string
+		for id, status := range pending {
+			failed = append(failed, fmt.Sprintf("%s (%s)", id, status))
+		}
+		sort.Strings(failed)
+		return fmt.Errorf("%s: instances status checks not ok: %s", err, strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+func splitInBatches(ids []string, size int) (batches [][]string) {
+	if size < 1 || size > len(ids) {
+		size = len(ids)
+	}
+	for i := 0; i < len(ids); i += size {
+		end := i + size
+		if end > len(ids) {
+			end = len(ids)
+		}
+		batches = append(batches, ids[i:end])
+	}
+	return
+}
+
+func (d *Ec2Driver) Check_Instance_DryRun(params map[string]interface{}) (interface{}, error) {
+	if _, ok := params["id"]; !ok {
+		return nil, errors.New("check instance: missing required params 'id'")
+	}
+
+	states := map[string]struct{}{
+		"pending":       {},
+		"running":       {},
+		"shutting-down": {},
+		"terminated":    {},
+		"stopping":      {},
+		"stopped":       {},
+		notFoundState:   {},
+	}
+
+	if state, ok := params["state"].(string); !ok {
+		return nil, errors.New("check instance: missing required params 'state'")
+	} else {
+		if _, stok := states[state]; !stok {
+			return nil, fmt.Errorf("check instance: invalid state '%s'", state)
+		}
+	}
+
+	if _, ok := params["timeout"]; !ok {
+		return nil, errors.New("check instance: missing required params 'timeout'")
+	}
+
+	if _, ok := params["timeout"].(int); !ok {
+		return nil, errors.New("check instance: timeout param is not int")
+	}
+
+	input := &ec2.DescribeInstancesInput{}
+	input.DryRun = aws.Bool(true)
+
+	// Required params
+	err := setFieldWithType(params["id"], input, "InstanceIds", awsstringslice)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = d.DescribeInstances(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound):
+			id := fakeDryRunId("instance")
+			d.logger.Verbose("dry run: check instance ok")
+			return id, nil
+		}
+	}
+	return nil, fmt.Errorf("dry run: check instance: %w", err)
+}
+
+func (d *Ec2Driver) Check_Instance(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.DescribeInstancesInput{}
+
+	// Required params
+	err := setFieldWithType(params["id"], input, "InstanceIds", awsstringslice)
+	if err != nil {
+		return nil, err
+	}
+	c := &checker{
+		description: fmt.Sprintf("instance %s", params["id"]),
+		timeout:     time.Duration(params["timeout"].(int)) * time.Second,
+		frequency:   5 * time.Second,
+		fetchFunc: func() (string, error) {
+			output, err := d.DescribeInstances(input)
+			if err != nil {
+				if awserr, ok := err.(awserr.Error); ok {
+					if awserr.Code() == "InstanceNotFound" {
+						return notFoundState, nil
+					}
+				} else {
+					return "", err
+				}
+			} else {
+				if res := output.Reservations; len(res) > 0 {
+					if instances := output.Reservations[0].Instances; len(instances) > 0 {
+						for _, inst := range instances {
+							if aws.StringValue(inst.InstanceId) == params["id"] {
+								return aws.StringValue(inst.State.Name), nil
+							}
+						}
+					}
+				}
+			}
+			return notFoundState, nil
 		},
 		expect: fmt.Sprint(params["state"]),
 		logger: d.logger,
@@ -786,111 +1245,993 @@ func (d *Ec2Driver) Check_Securitygroup_DryRun(params map[string]interface{}) (i
 			return nil, fmt.Errorf("check securitygroup: invalid state '%s'", state)
 		}
 	}
-
-	if _, ok := params["timeout"]; !ok {
-		return nil, errors.New("check securitygroup: missing required params 'timeout'")
+
+	if _, ok := params["timeout"]; !ok {
+		return nil, errors.New("check securitygroup: missing required params 'timeout'")
+	}
+
+	if _, ok := params["timeout"].(int); !ok {
+		return nil, errors.New("check securitygroup: timeout param is not int")
+	}
+	d.logger.Verbose("dry run: check securitygroup ok")
+	return nil, nil
+}
+
+func (d *Ec2Driver) Check_Securitygroup(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.DescribeNetworkInterfacesInput{
+		Filters: []*ec2.Filter{
+			{Name: aws.String("group-id"), Values: []*string{aws.String(fmt.Sprint(params["id"]))}},
+		},
+	}
+
+	c := &checker{
+		description: fmt.Sprintf("securitygroup %s", params["id"]),
+		timeout:     time.Duration(params["timeout"].(int)) * time.Second,
+		frequency:   5 * time.Second,
+		fetchFunc: func() (string, error) {
+			output, err := d.DescribeNetworkInterfaces(input)
+			if err != nil {
+				return "", err
+			}
+			if len(output.NetworkInterfaces) == 0 {
+				return "unused", nil
+			}
+			var niIds []string
+			for _, ni := range output.NetworkInterfaces {
+				niIds = append(niIds, aws.StringValue(ni.NetworkInterfaceId))
+			}
+			return fmt.Sprintf("used by %s", strings.Join(niIds, ", ")), nil
+		},
+		expect: fmt.Sprint(params["state"]),
+		logger: d.logger,
+	}
+	return nil, c.check()
+}
+
+func (d *Ec2Driver) Check_Volume_DryRun(params map[string]interface{}) (interface{}, error) {
+	if _, ok := params["id"]; !ok {
+		return nil, errors.New("check volume: missing required params 'id'")
+	}
+
+	states := map[string]struct{}{
+		"available":   {},
+		"in-use":      {},
+		notFoundState: {},
+	}
+
+	if state, ok := params["state"].(string); !ok {
+		return nil, errors.New("check volume: missing required params 'state'")
+	} else {
+		if _, stok := states[state]; !stok {
+			return nil, fmt.Errorf("check volume: invalid state '%s'", state)
+		}
+	}
+
+	if _, ok := params["timeout"]; !ok {
+		return nil, errors.New("check volume: missing required params 'timeout'")
+	}
+
+	if _, ok := params["timeout"].(int); !ok {
+		return nil, errors.New("check volume: timeout param is not int")
+	}
+	d.logger.Verbose("dry run: check instance ok")
+	return nil, nil
+}
+
+func (d *Ec2Driver) Check_Volume(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.DescribeVolumesInput{
+		VolumeIds: []*string{aws.String(fmt.Sprint(params["id"]))},
+	}
+
+	c := &checker{
+		description: fmt.Sprintf("volume %s", params["id"]),
+		timeout:     time.Duration(params["timeout"].(int)) * time.Second,
+		frequency:   5 * time.Second,
+		fetchFunc: func() (string, error) {
+			output, err := d.DescribeVolumes(input)
+			if err != nil {
+				if awserr, ok := err.(awserr.Error); ok {
+					if awserr.Code() == "VolumeNotFound" {
+						return notFoundState, nil
+					}
+				} else {
+					return "", err
+				}
+			} else {
+				for _, vol := range output.Volumes {
+					if aws.StringValue(vol.VolumeId) == fmt.Sprint(params["id"]) {
+						return aws.StringValue(vol.State), nil
+					}
+				}
+			}
+			return notFoundState, nil
+		},
+		expect: fmt.Sprint(params["state"]),
+		logger: d.logger,
+	}
+	return nil, c.check()
+}
+
+// dhcpOptionsParams maps the template params to the keys of the DHCP configurations
+var dhcpOptionsParams = []struct{ param, key string }{
+	{"domain-name", "domain-name"},
+	{"domain-name-servers", "domain-name-servers"},
+	{"ntp-servers", "ntp-servers"},
+}
+
+func (d *Ec2Driver) Create_Dhcpoptions_DryRun(params map[string]interface{}) (interface{}, error) {
+	if _, err := dhcpConfigurations(params); err != nil {
+		return nil, fmt.Errorf("create dhcpoptions: %s", err)
+	}
+
+	d.logger.Verbose("params dry run: create dhcpoptions ok")
+	return fakeDryRunId("dhcpoptions"), nil
+}
+
+func (d *Ec2Driver) Create_Dhcpoptions(params map[string]interface{}) (interface{}, error) {
+	confs, err := dhcpConfigurations(params)
+	if err != nil {
+		return nil, fmt.Errorf("create dhcpoptions: %s", err)
+	}
+
+	start := time.Now()
+	output, err := d.CreateDhcpOptions(&ec2.CreateDhcpOptionsInput{DhcpConfigurations: confs})
+	if err != nil {
+		return nil, fmt.Errorf("create dhcpoptions: %w", err)
+	}
+	d.logger.ExtraVerbosef("ec2.CreateDhcpOptions call took %s", time.Since(start))
+	id := aws.StringValue(output.DhcpOptions.DhcpOptionsId)
+
+	d.logger.Infof("create dhcpoptions '%s' done", id)
+	return id, nil
+}
+
+func dhcpConfigurations(params map[string]interface{}) ([]*ec2.NewDhcpConfiguration, error) {
+	var confs []*ec2.NewDhcpConfiguration
+	for _, p := range dhcpOptionsParams {
+		v, ok := params[p.param]
+		if !ok {
+			continue
+		}
+		conf := &ec2.NewDhcpConfiguration{Key: aws.String(p.key)}
+		if err := setFieldWithType(v, conf, "Values", awsstringslice); err != nil {
+			return nil, err
+		}
+		confs = append(confs, conf)
+	}
+	if len(confs) == 0 {
+		return nil, errors.New("missing at least one of params 'domain-name', 'domain-name-servers' or 'ntp-servers'")
+	}
+	return confs, nil
+}
+
+func (d *Ec2Driver) Attach_Networkacl_DryRun(params map[string]interface{}) (interface{}, error) {
+	if _, ok := params["id"]; !ok {
+		return nil, errors.New("attach networkacl: missing required params 'id'")
+	}
+
+	if _, ok := params["subnet"]; !ok {
+		return nil, errors.New("attach networkacl: missing required params 'subnet'")
+	}
+
+	d.logger.Verbose("params dry run: attach networkacl ok")
+	return fakeDryRunId("networkaclassociation"), nil
+}
+
+// Attach_Networkacl replaces the network ACL association of the subnet (a subnet is always associated with a network ACL)
+func (d *Ec2Driver) Attach_Networkacl(params map[string]interface{}) (interface{}, error) {
+	subnet := fmt.Sprint(params["subnet"])
+	out, err := d.DescribeNetworkAcls(&ec2.DescribeNetworkAclsInput{
+		Filters: []*ec2.Filter{{Name: aws.String("association.subnet-id"), Values: []*string{aws.String(subnet)}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("attach networkacl: %w", err)
+	}
+	var associationID string
+	for _, acl := range out.NetworkAcls {
+		for _, assoc := range acl.Associations {
+			if aws.StringValue(assoc.SubnetId) == subnet {
+				associationID = aws.StringValue(assoc.NetworkAclAssociationId)
+			}
+		}
+	}
+	if associationID == "" {
+		return nil, fmt.Errorf("attach networkacl: no network acl association found for subnet %s", subnet)
+	}
+
+	input := &ec2.ReplaceNetworkAclAssociationInput{AssociationId: aws.String(associationID)}
+	if err = setFieldWithType(params["id"], input, "NetworkAclId", awsstr); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	output, err := d.ReplaceNetworkAclAssociation(input)
+	if err != nil {
+		return nil, fmt.Errorf("attach networkacl: %w", err)
+	}
+	d.logger.ExtraVerbosef("ec2.ReplaceNetworkAclAssociation call took %s", time.Since(start))
+	id := aws.StringValue(output.NewAssociationId)
+
+	d.logger.Infof("attach networkacl '%s' done", id)
+	return id, nil
+}
+
+func (d *Ec2Driver) Create_Networkaclrule_DryRun(params map[string]interface{}) (interface{}, error) {
+	if _, err := buildNetworkAclEntryFromParams(params); err != nil {
+		return nil, fmt.Errorf("create networkaclrule: %s", err)
+	}
+
+	d.logger.Verbose("params dry run: create networkaclrule ok")
+	return nil, nil
+}
+
+func (d *Ec2Driver) Create_Networkaclrule(params map[string]interface{}) (interface{}, error) {
+	input, err := buildNetworkAclEntryFromParams(params)
+	if err != nil {
+		return nil, fmt.Errorf("create networkaclrule: %s", err)
+	}
+
+	start := time.Now()
+	output, err := d.CreateNetworkAclEntry(input)
+	if err != nil {
+		return nil, fmt.Errorf("create networkaclrule: %w", err)
+	}
+	d.logger.ExtraVerbosef("ec2.CreateNetworkAclEntry call took %s", time.Since(start))
+
+	d.logger.Infof("create networkaclrule %d done", aws.Int64Value(input.RuleNumber))
+	return output, nil
+}
+
+func (d *Ec2Driver) Delete_Networkaclrule_DryRun(params map[string]interface{}) (interface{}, error) {
+	if _, err := buildDeleteNetworkAclEntryFromParams(params); err != nil {
+		return nil, fmt.Errorf("delete networkaclrule: %s", err)
+	}
+
+	d.logger.Verbose("params dry run: delete networkaclrule ok")
+	return nil, nil
+}
+
+func (d *Ec2Driver) Delete_Networkaclrule(params map[string]interface{}) (interface{}, error) {
+	input, err := buildDeleteNetworkAclEntryFromParams(params)
+	if err != nil {
+		return nil, fmt.Errorf("delete networkaclrule: %s", err)
+	}
+
+	start := time.Now()
+	output, err := d.DeleteNetworkAclEntry(input)
+	if err != nil {
+		return nil, fmt.Errorf("delete networkaclrule: %w", err)
+	}
+	d.logger.ExtraVerbosef("ec2.DeleteNetworkAclEntry call took %s", time.Since(start))
+
+	d.logger.Infof("delete networkaclrule %d done", aws.Int64Value(input.RuleNumber))
+	return output, nil
+}
+
+// networkAclProtocols maps protocol names to the protocol numbers expected by network ACL rules
+var networkAclProtocols = map[string]string{"any": "-1", "tcp": "6", "udp": "17", "icmp": "1"}
+
+func buildNetworkAclEntryFromParams(params map[string]interface{}) (*ec2.CreateNetworkAclEntryInput, error) {
+	input := &ec2.CreateNetworkAclEntryInput{}
+	for _, p := range []string{"id", "number", "action", "protocol", "cidr"} {
+		if _, ok := params[p]; !ok {
+			return nil, fmt.Errorf("missing required params '%s'", p)
+		}
+	}
+	if err := setFieldWithType(params["id"], input, "NetworkAclId", awsstr); err != nil {
+		return nil, err
+	}
+	if err := setFieldWithType(params["number"], input, "RuleNumber", awsint64); err != nil {
+		return nil, err
+	}
+	switch action := fmt.Sprint(params["action"]); action {
+	case "allow", "deny":
+		input.RuleAction = aws.String(action)
+	default:
+		return nil, fmt.Errorf("invalid action '%s', expecting 'allow' or 'deny'", action)
+	}
+	if outbound, ok := params["outbound"]; ok {
+		if err := setFieldWithType(outbound, input, "Egress", awsbool); err != nil {
+			return nil, err
+		}
+	} else {
+		input.Egress = aws.Bool(false)
+	}
+
+	cidr := fmt.Sprint(params["cidr"])
+	if strings.Contains(cidr, ":") {
+		input.Ipv6CidrBlock = aws.String(cidr)
+	} else {
+		input.CidrBlock = aws.String(cidr)
+	}
+
+	protocol := strings.ToLower(fmt.Sprint(params["protocol"]))
+	if number, ok := networkAclProtocols[protocol]; ok {
+		input.Protocol = aws.String(number)
+	} else {
+		input.Protocol = aws.String(protocol)
+	}
+	switch protocol {
+	case "tcp", "udp", "6", "17":
+		ports := params["portrange"]
+		if ports == nil {
+			ports = "any"
+		}
+		perms, err := buildIpPermissionsFromParams(map[string]interface{}{"cidr": cidr, "protocol": "tcp", "portrange": ports})
+		if err != nil {
+			return nil, fmt.Errorf("invalid portrange '%v': %s", ports, err)
+		}
+		input.PortRange = &ec2.PortRange{From: perms[0].FromPort, To: perms[0].ToPort}
+	case "icmp", "1":
+		input.IcmpTypeCode = &ec2.IcmpTypeCode{Code: aws.Int64(-1), Type: aws.Int64(-1)}
+	}
+	return input, nil
+}
+
+func buildDeleteNetworkAclEntryFromParams(params map[string]interface{}) (*ec2.DeleteNetworkAclEntryInput, error) {
+	input := &ec2.DeleteNetworkAclEntryInput{Egress: aws.Bool(false)}
+	for _, p := range []string{"id", "number"} {
+		if _, ok := params[p]; !ok {
+			return nil, fmt.Errorf("missing required params '%s'", p)
+		}
+	}
+	if err := setFieldWithType(params["id"], input, "NetworkAclId", awsstr); err != nil {
+		return nil, err
+	}
+	if err := setFieldWithType(params["number"], input, "RuleNumber", awsint64); err != nil {
+		return nil, err
+	}
+	if outbound, ok := params["outbound"]; ok {
+		if err := setFieldWithType(outbound, input, "Egress", awsbool); err != nil {
+			return nil, err
+		}
+	}
+	return input, nil
+}
+
+// prefixListFamilies maps the 'family' param values to the address families of prefix lists
+var prefixListFamilies = map[string]string{"ipv4": "IPv4", "ipv6": "IPv6"}
+
+func (d *Ec2Driver) Create_Prefixlist_DryRun(params map[string]interface{}) (interface{}, error) {
+	input, err := buildCreatePrefixListInput(params)
+	if err != nil {
+		return nil, fmt.Errorf("create prefixlist: %s", err)
+	}
+	api, ok := ManagedPrefixLists(d.EC2API)
+	if !ok {
+		return nil, errors.New("create prefixlist: managed prefix lists not supported by the ec2 client")
+	}
+
+	input.DryRun = aws.Bool(true)
+	_, err = api.CreateManagedPrefixList(input)
+	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == dryRunOperation {
+		d.logger.Verbose("dry run: create prefixlist ok")
+		return fakeDryRunId(cloud.PrefixList), nil
+	}
+	return nil, fmt.Errorf("dry run: create prefixlist: %w", err)
+}
+
+func (d *Ec2Driver) Create_Prefixlist(params map[string]interface{}) (interface{}, error) {
+	input, err := buildCreatePrefixListInput(params)
+	if err != nil {
+		return nil, fmt.Errorf("create prefixlist: %s", err)
+	}
+	api, ok := ManagedPrefixLists(d.EC2API)
+	if !ok {
+		return nil, errors.New("create prefixlist: managed prefix lists not supported by the ec2 client")
+	}
+
+	start := time.Now()
+	output, err := api.CreateManagedPrefixList(input)
+	if err != nil {
+		return nil, fmt.Errorf("create prefixlist: %w", err)
+	}
+	d.logger.ExtraVerbosef("ec2.CreateManagedPrefixList call took %s", time.Since(start))
+	id := aws.StringValue(output.PrefixList.PrefixListId)
+
+	d.logger.Infof("create prefixlist '%s' done", id)
+	return id, nil
+}
+
+func buildCreatePrefixListInput(params map[string]interface{}) (*CreateManagedPrefixListInput, error) {
+	input := &CreateManagedPrefixListInput{AddressFamily: aws.String("IPv4")}
+	if _, ok := params["name"]; !ok {
+		return nil, errors.New("missing required params 'name'")
+	}
+	if err := setFieldWithType(params["name"], input, "PrefixListName", awsstr); err != nil {
+		return nil, err
+	}
+	if _, ok := params["max-entries"]; !ok {
+		return nil, errors.New("missing required params 'max-entries'")
+	}
+	if err := setFieldWithType(params["max-entries"], input, "MaxEntries", awsint64); err != nil {
+		return nil, err
+	}
+	if family, ok := params["family"]; ok {
+		f, known := prefixListFamilies[strings.ToLower(fmt.Sprint(family))]
+		if !known {
+			return nil, fmt.Errorf("invalid family '%v', expect ipv4 or ipv6", family)
+		}
+		input.AddressFamily = aws.String(f)
+	}
+	if cidrs, ok := params["cidrs"]; ok {
+		for _, cidr := range castStringSlice(cidrs) {
+			input.Entries = append(input.Entries, &AddPrefixListEntry{Cidr: aws.String(cidr)})
+		}
+	}
+	if max := aws.Int64Value(input.MaxEntries); int64(len(input.Entries)) > max {
+		return nil, fmt.Errorf("%d cidrs exceed the %d max entries", len(input.Entries), max)
+	}
+	return input, nil
+}
+
+func (d *Ec2Driver) Update_Prefixlist_DryRun(params map[string]interface{}) (interface{}, error) {
+	if _, err := buildModifyPrefixListInput(params); err != nil {
+		return nil, fmt.Errorf("update prefixlist: %s", err)
+	}
+
+	d.logger.Verbose("params dry run: update prefixlist ok")
+	return nil, nil
+}
+
+// Update_Prefixlist adds and removes entries of a customer-managed prefix list, or renames it.
+// Entries are modified against the current version of the list, so that concurrent changes fail
+func (d *Ec2Driver) Update_Prefixlist(params map[string]interface{}) (interface{}, error) {
+	input, err := buildModifyPrefixListInput(params)
+	if err != nil {
+		return nil, fmt.Errorf("update prefixlist: %s", err)
+	}
+	api, ok := ManagedPrefixLists(d.EC2API)
+	if !ok {
+		return nil, errors.New("update prefixlist: managed prefix lists not supported by the ec2 client")
+	}
+
+	if len(input.AddEntries) > 0 || len(input.RemoveEntries) > 0 {
+		out, err := api.DescribeManagedPrefixLists(&DescribeManagedPrefixListsInput{PrefixListIds: []*string{input.PrefixListId}})
+		if err != nil {
+			return nil, fmt.Errorf("update prefixlist: %w", err)
+		}
+		if len(out.PrefixLists) == 0 {
+			return nil, fmt.Errorf("update prefixlist: prefix list '%s' not found", aws.StringValue(input.PrefixListId))
+		}
+		input.CurrentVersion = out.PrefixLists[0].Version
+	}
+
+	start := time.Now()
+	if _, err = api.ModifyManagedPrefixList(input); err != nil {
+		return nil, fmt.Errorf("update prefixlist: %w", err)
+	}
+	d.logger.ExtraVerbosef("ec2.ModifyManagedPrefixList call took %s", time.Since(start))
+	id := aws.StringValue(input.PrefixListId)
+
+	d.logger.Infof("update prefixlist '%s' done", id)
+	return id, nil
+}
+
+func buildModifyPrefixListInput(params map[string]interface{}) (*ModifyManagedPrefixListInput, error) {
+	input := &ModifyManagedPrefixListInput{}
+	if _, ok := params["id"]; !ok {
+		return nil, errors.New("missing required params 'id'")
+	}
+	if err := setFieldWithType(params["id"], input, "PrefixListId", awsstr); err != nil {
+		return nil, err
+	}
+	if name, ok := params["name"]; ok {
+		if err := setFieldWithType(name, input, "PrefixListName", awsstr); err != nil {
+			return nil, err
+		}
+	}
+	if cidrs, ok := params["add-cidrs"]; ok {
+		for _, cidr := range castStringSlice(cidrs) {
+			input.AddEntries = append(input.AddEntries, &AddPrefixListEntry{Cidr: aws.String(cidr)})
+		}
+	}
+	if cidrs, ok := params["remove-cidrs"]; ok {
+		for _, cidr := range castStringSlice(cidrs) {
+			input.RemoveEntries = append(input.RemoveEntries, &RemovePrefixListEntry{Cidr: aws.String(cidr)})
+		}
+	}
+	if input.PrefixListName == nil && len(input.AddEntries) == 0 && len(input.RemoveEntries) == 0 {
+		return nil, errors.New("missing at least one of params 'add-cidrs', 'remove-cidrs' or 'name'")
+	}
+	return input, nil
+}
+
+func (d *Ec2Driver) Delete_Prefixlist_DryRun(params map[string]interface{}) (interface{}, error) {
+	if _, ok := params["id"]; !ok {
+		return nil, errors.New("delete prefixlist: missing required params 'id'")
+	}
+	api, ok := ManagedPrefixLists(d.EC2API)
+	if !ok {
+		return nil, errors.New("delete prefixlist: managed prefix lists not supported by the ec2 client")
+	}
+
+	input := &DeleteManagedPrefixListInput{DryRun: aws.Bool(true)}
+	if err := setFieldWithType(params["id"], input, "PrefixListId", awsstr); err != nil {
+		return nil, err
+	}
+	_, err := api.DeleteManagedPrefixList(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound):
+			d.logger.Verbose("dry run: delete prefixlist ok")
+			return nil, nil
+		}
+	}
+	return nil, fmt.Errorf("dry run: delete prefixlist: %w", err)
+}
+
+func (d *Ec2Driver) Delete_Prefixlist(params map[string]interface{}) (interface{}, error) {
+	api, ok := ManagedPrefixLists(d.EC2API)
+	if !ok {
+		return nil, errors.New("delete prefixlist: managed prefix lists not supported by the ec2 client")
+	}
+	input := &DeleteManagedPrefixListInput{}
+	if err := setFieldWithType(params["id"], input, "PrefixListId", awsstr); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	if _, err := api.DeleteManagedPrefixList(input); err != nil {
+		return nil, fmt.Errorf("delete prefixlist: %w", err)
+	}
+	d.logger.ExtraVerbosef("ec2.DeleteManagedPrefixList call took %s", time.Since(start))
+
+	d.logger.Infof("delete prefixlist '%s' done", aws.StringValue(input.PrefixListId))
+	return nil, nil
+}
+
+func (d *Ec2Driver) Create_Fleet_DryRun(params map[string]interface{}) (interface{}, error) {
+	var err error
+	if _, ok := params["launch-template"]; ok {
+		_, err = buildCreateFleetInput(params)
+	} else {
+		_, err = buildRequestSpotFleetInput(params)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("create fleet: %s", err)
+	}
+
+	d.logger.Verbose("params dry run: create fleet ok")
+	return fakeDryRunId(cloud.Fleet), nil
+}
+
+// Create_Fleet requests a Spot Fleet launching instances of an image or, given a launch template,
+// creates an EC2 Fleet which can mix on-demand and spot capacity
+func (d *Ec2Driver) Create_Fleet(params map[string]interface{}) (interface{}, error) {
+	if _, ok := params["launch-template"]; ok {
+		return d.createEC2Fleet(params)
+	}
+
+	input, err := buildRequestSpotFleetInput(params)
+	if err != nil {
+		return nil, fmt.Errorf("create fleet: %s", err)
+	}
+	if err = setIdempotencyToken(params, "create fleet", input, "SpotFleetRequestConfig.ClientToken"); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	output, err := d.RequestSpotFleet(input)
+	if err != nil {
+		return nil, fmt.Errorf("create fleet: %w", err)
+	}
+	d.logger.ExtraVerbosef("ec2.RequestSpotFleet call took %s", time.Since(start))
+	id := aws.StringValue(output.SpotFleetRequestId)
+
+	d.logger.Infof("create fleet '%s' done", id)
+	return id, nil
+}
+
+func (d *Ec2Driver) createEC2Fleet(params map[string]interface{}) (interface{}, error) {
+	input, err := buildCreateFleetInput(params)
+	if err != nil {
+		return nil, fmt.Errorf("create fleet: %s", err)
+	}
+	api, ok := Fleets(d.EC2API)
+	if !ok {
+		return nil, errors.New("create fleet: ec2 fleets not supported by the ec2 client")
+	}
+	if err = setIdempotencyToken(params, "create fleet", input, "ClientToken"); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	output, err := api.CreateFleet(input)
+	if err != nil {
+		return nil, fmt.Errorf("create fleet: %w", err)
+	}
+	d.logger.ExtraVerbosef("ec2.CreateFleet call took %s", time.Since(start))
+	id := aws.StringValue(output.FleetId)
+
+	d.logger.Infof("create fleet '%s' done", id)
+	return id, nil
+}
+
+func buildRequestSpotFleetInput(params map[string]interface{}) (*ec2.RequestSpotFleetInput, error) {
+	for _, required := range []string{"target-capacity", "image", "type", "role", "spot-price"} {
+		if _, ok := params[required]; !ok {
+			return nil, fmt.Errorf("missing required params '%s' (or give a 'launch-template' to create an EC2 Fleet)", required)
+		}
+	}
+	if _, ok := params["on-demand-capacity"]; ok {
+		return nil, errors.New("on-demand capacity requires an EC2 Fleet: give a 'launch-template'")
+	}
+	if _, ok := params["launch-template-version"]; ok {
+		return nil, errors.New("missing 'launch-template' of the given 'launch-template-version'")
+	}
+
+	spec := &ec2.SpotFleetLaunchSpecification{}
+	input := &ec2.RequestSpotFleetInput{SpotFleetRequestConfig: &ec2.SpotFleetRequestConfigData{LaunchSpecifications: []*ec2.SpotFleetLaunchSpecification{spec}}}
+	fields := []struct {
+		param, field string
+		fieldType    int
+		i            interface{}
+	}{
+		{"target-capacity", "SpotFleetRequestConfig.TargetCapacity", awsint64, input},
+		{"role", "SpotFleetRequestConfig.IamFleetRole", awsstr, input},
+		{"spot-price", "SpotFleetRequestConfig.SpotPrice", awsstr, input},
+		{"allocation-strategy", "SpotFleetRequestConfig.AllocationStrategy", awsstr, input},
+		{"request-type", "SpotFleetRequestConfig.Type", awsstr, input},
+		{"image", "ImageId", awsstr, spec},
+		{"type", "InstanceType", awsstr, spec},
+		{"keypair", "KeyName", awsstr, spec},
+		{"subnet", "SubnetId", awsstr, spec},
+	}
+	for _, f := range fields {
+		if v, ok := params[f.param]; ok {
+			if err := setFieldWithType(v, f.i, f.field, f.fieldType); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if groups, ok := params["securitygroup"]; ok {
+		for _, group := range castStringSlice(groups) {
+			spec.SecurityGroups = append(spec.SecurityGroups, &ec2.GroupIdentifier{GroupId: aws.String(group)})
+		}
+	}
+	return input, nil
+}
+
+func buildCreateFleetInput(params map[string]interface{}) (*CreateFleetInput, error) {
+	if _, ok := params["target-capacity"]; !ok {
+		return nil, errors.New("missing required params 'target-capacity'")
+	}
+	for _, p := range []string{"image", "keypair", "securitygroup", "role", "spot-price"} {
+		if _, ok := params[p]; ok {
+			return nil, fmt.Errorf("param '%s' can not be set on an EC2 Fleet: it is given by the launch template", p)
+		}
+	}
+
+	capacity := &TargetCapacitySpecificationRequest{DefaultTargetCapacityType: aws.String("spot")}
+	template := &FleetLaunchTemplateSpecificationRequest{Version: aws.String("$Default")}
+	input := &CreateFleetInput{
+		TargetCapacitySpecification: capacity,
+		LaunchTemplateConfigs:       []*FleetLaunchTemplateConfigRequest{{LaunchTemplateSpecification: template}},
+	}
+
+	if name := fmt.Sprint(params["launch-template"]); strings.HasPrefix(name, "lt-") {
+		template.LaunchTemplateId = aws.String(name)
+	} else {
+		template.LaunchTemplateName = aws.String(name)
+	}
+	if version, ok := params["launch-template-version"]; ok {
+		if err := setFieldWithType(version, template, "Version", awsstr); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := setFieldWithType(params["target-capacity"], capacity, "TotalTargetCapacity", awsint64); err != nil {
+		return nil, err
+	}
+	if onDemand, ok := params["on-demand-capacity"]; ok {
+		if err := setFieldWithType(onDemand, capacity, "OnDemandTargetCapacity", awsint64); err != nil {
+			return nil, err
+		}
+	}
+	total, onDemand := aws.Int64Value(capacity.TotalTargetCapacity), aws.Int64Value(capacity.OnDemandTargetCapacity)
+	if onDemand > total {
+		return nil, fmt.Errorf("on-demand capacity %d exceeds the target capacity %d", onDemand, total)
+	}
+	capacity.SpotTargetCapacity = aws.Int64(total - onDemand)
+
+	override := &FleetLaunchTemplateOverridesRequest{}
+	if typ, ok := params["type"]; ok {
+		if err := setFieldWithType(typ, override, "InstanceType", awsstr); err != nil {
+			return nil, err
+		}
+	}
+	if subnet, ok := params["subnet"]; ok {
+		if err := setFieldWithType(subnet, override, "SubnetId", awsstr); err != nil {
+			return nil, err
+		}
+	}
+	if override.InstanceType != nil || override.SubnetId != nil {
+		input.LaunchTemplateConfigs[0].Overrides = []*FleetLaunchTemplateOverridesRequest{override}
+	}
+
+	if strategy, ok := params["allocation-strategy"]; ok {
+		input.SpotOptions = &SpotOptionsRequest{}
+		if err := setFieldWithType(strategy, input.SpotOptions, "AllocationStrategy", awsstr); err != nil {
+			return nil, err
+		}
+	}
+	if typ, ok := params["request-type"]; ok {
+		if err := setFieldWithType(typ, input, "Type", awsstr); err != nil {
+			return nil, err
+		}
+	}
+	return input, nil
+}
+
+func (d *Ec2Driver) Update_Fleet_DryRun(params map[string]interface{}) (interface{}, error) {
+	if _, ok := params["id"]; !ok {
+		return nil, errors.New("update fleet: missing required params 'id'")
+	}
+	var err error
+	if FleetKind(fmt.Sprint(params["id"])) == SpotFleet {
+		_, err = buildModifySpotFleetInput(params)
+	} else {
+		_, err = buildModifyFleetInput(params)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("update fleet: %s", err)
+	}
+
+	d.logger.Verbose("params dry run: update fleet ok")
+	return nil, nil
+}
+
+// Update_Fleet changes the target capacity of a fleet. Instances above the new capacity
+// are terminated unless excess-capacity-termination is 'noTermination'
+func (d *Ec2Driver) Update_Fleet(params map[string]interface{}) (interface{}, error) {
+	id := fmt.Sprint(params["id"])
+	if FleetKind(id) == SpotFleet {
+		input, err := buildModifySpotFleetInput(params)
+		if err != nil {
+			return nil, fmt.Errorf("update fleet: %s", err)
+		}
+		start := time.Now()
+		if _, err = d.ModifySpotFleetRequest(input); err != nil {
+			return nil, fmt.Errorf("update fleet: %w", err)
+		}
+		d.logger.ExtraVerbosef("ec2.ModifySpotFleetRequest call took %s", time.Since(start))
+	} else {
+		input, err := buildModifyFleetInput(params)
+		if err != nil {
+			return nil, fmt.Errorf("update fleet: %s", err)
+		}
+		api, ok := Fleets(d.EC2API)
+		if !ok {
+			return nil, errors.New("update fleet: ec2 fleets not supported by the ec2 client")
+		}
+		start := time.Now()
+		if _, err = api.ModifyFleet(input); err != nil {
+			return nil, fmt.Errorf("update fleet: %w", err)
+		}
+		d.logger.ExtraVerbosef("ec2.ModifyFleet call took %s", time.Since(start))
+	}
+
+	d.logger.Infof("update fleet '%s' done", id)
+	return id, nil
+}
+
+func buildModifySpotFleetInput(params map[string]interface{}) (*ec2.ModifySpotFleetRequestInput, error) {
+	if _, ok := params["on-demand-capacity"]; ok {
+		return nil, errors.New("on-demand capacity can only be set on EC2 Fleets")
+	}
+	input := &ec2.ModifySpotFleetRequestInput{}
+	if err := setFieldWithType(params["id"], input, "SpotFleetRequestId", awsstr); err != nil {
+		return nil, err
+	}
+	if capacity, ok := params["target-capacity"]; ok {
+		if err := setFieldWithType(capacity, input, "TargetCapacity", awsint64); err != nil {
+			return nil, err
+		}
+	}
+	if policy, ok := params["excess-capacity-termination"]; ok {
+		if err := setFieldWithType(policy, input, "ExcessCapacityTerminationPolicy", awsstr); err != nil {
+			return nil, err
+		}
+	}
+	if input.TargetCapacity == nil && input.ExcessCapacityTerminationPolicy == nil {
+		return nil, errors.New("missing at least one of params 'target-capacity' or 'excess-capacity-termination'")
+	}
+	return input, nil
+}
+
+func buildModifyFleetInput(params map[string]interface{}) (*ModifyFleetInput, error) {
+	if _, ok := params["target-capacity"]; !ok {
+		return nil, errors.New("missing required params 'target-capacity' to update an EC2 Fleet")
+	}
+	input := &ModifyFleetInput{TargetCapacitySpecification: &TargetCapacitySpecificationRequest{}}
+	if err := setFieldWithType(params["id"], input, "FleetId", awsstr); err != nil {
+		return nil, err
+	}
+	if err := setFieldWithType(params["target-capacity"], input, "TargetCapacitySpecification.TotalTargetCapacity", awsint64); err != nil {
+		return nil, err
+	}
+	if onDemand, ok := params["on-demand-capacity"]; ok {
+		if err := setFieldWithType(onDemand, input, "TargetCapacitySpecification.OnDemandTargetCapacity", awsint64); err != nil {
+			return nil, err
+		}
+	}
+	if policy, ok := params["excess-capacity-termination"]; ok {
+		if err := setFieldWithType(policy, input, "ExcessCapacityTerminationPolicy", awsstr); err != nil {
+			return nil, err
+		}
+	}
+	return input, nil
+}
+
+func (d *Ec2Driver) Delete_Fleet_DryRun(params map[string]interface{}) (interface{}, error) {
+	if _, ok := params["id"]; !ok {
+		return nil, errors.New("delete fleet: missing required params 'id'")
+	}
+
+	d.logger.Verbose("params dry run: delete fleet ok")
+	return nil, nil
+}
+
+// Delete_Fleet cancels a Spot Fleet request or deletes an EC2 Fleet.
+// Its instances keep running unless terminate-instances is true
+func (d *Ec2Driver) Delete_Fleet(params map[string]interface{}) (interface{}, error) {
+	id := fmt.Sprint(params["id"])
+	terminate := aws.Bool(false)
+	if t, ok := params["terminate-instances"]; ok {
+		terminate = aws.Bool(fmt.Sprint(t) == "true")
+	}
+
+	start := time.Now()
+	if FleetKind(id) == SpotFleet {
+		output, err := d.CancelSpotFleetRequests(&ec2.CancelSpotFleetRequestsInput{SpotFleetRequestIds: []*string{aws.String(id)}, TerminateInstances: terminate})
+		if err != nil {
+			return nil, fmt.Errorf("delete fleet: %w", err)
+		}
+		for _, failed := range output.UnsuccessfulFleetRequests {
+			if failed.Error != nil {
+				return nil, fmt.Errorf("delete fleet: %s: %s", aws.StringValue(failed.Error.Code), aws.StringValue(failed.Error.Message))
+			}
+		}
+		d.logger.ExtraVerbosef("ec2.CancelSpotFleetRequests call took %s", time.Since(start))
+	} else {
+		api, ok := Fleets(d.EC2API)
+		if !ok {
+			return nil, errors.New("delete fleet: ec2 fleets not supported by the ec2 client")
+		}
+		output, err := api.DeleteFleets(&DeleteFleetsInput{FleetIds: []*string{aws.String(id)}, TerminateInstances: terminate})
+		if err != nil {
+			return nil, fmt.Errorf("delete fleet: %w", err)
+		}
+		for _, failed := range output.UnsuccessfulFleetDeletions {
+			if failed.Error != nil {
+				return nil, fmt.Errorf("delete fleet: %s: %s", aws.StringValue(failed.Error.Code), aws.StringValue(failed.Error.Message))
+			}
+		}
+		d.logger.ExtraVerbosef("ec2.DeleteFleets call took %s", time.Since(start))
+	}
+
+	d.logger.Infof("delete fleet '%s' done", id)
+	return nil, nil
+}
+
+func (d *Ec2Driver) Create_Natgateway_DryRun(params map[string]interface{}) (interface{}, error) {
+	if _, ok := params["elasticip-id"]; !ok {
+		return nil, errors.New("create natgateway: missing required params 'elasticip-id'")
+	}
+
+	if _, ok := params["subnet"]; !ok {
+		return nil, errors.New("create natgateway: missing required params 'subnet'")
+	}
+
+	d.logger.Verbose("params dry run: create natgateway ok")
+	return fakeDryRunId("natgateway"), nil
+}
+
+func (d *Ec2Driver) Create_Natgateway(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.CreateNatGatewayInput{}
+	var err error
+
+	// Required params
+	err = setFieldWithType(params["elasticip-id"], input, "AllocationId", awsstr)
+	if err != nil {
+		return nil, err
+	}
+	err = setFieldWithType(params["subnet"], input, "SubnetId", awsstr)
+	if err != nil {
+		return nil, err
+	}
+	if err = setIdempotencyToken(params, "create natgateway", input, "ClientToken"); err != nil {
+		return nil, err
 	}
 
-	if _, ok := params["timeout"].(int); !ok {
-		return nil, errors.New("check securitygroup: timeout param is not int")
+	start := time.Now()
+	var output *ec2.CreateNatGatewayOutput
+	output, err = d.CreateNatGateway(input)
+	if err != nil {
+		return nil, fmt.Errorf("create natgateway: %w", err)
 	}
-	d.logger.Verbose("dry run: check securitygroup ok")
-	return nil, nil
-}
+	d.logger.ExtraVerbosef("ec2.CreateNatGateway call took %s", time.Since(start))
+	id := aws.StringValue(output.NatGateway.NatGatewayId)
 
-func (d *Ec2Driver) Check_Securitygroup(params map[string]interface{}) (interface{}, error) {
-	input := &ec2.DescribeNetworkInterfacesInput{
-		Filters: []*ec2.Filter{
-			{Name: aws.String("group-id"), Values: []*string{aws.String(fmt.Sprint(params["id"]))}},
-		},
-	}
+	d.logger.Infof("create natgateway '%s' done", id)
 
-	c := &checker{
-		description: fmt.Sprintf("securitygroup %s", params["id"]),
-		timeout:     time.Duration(params["timeout"].(int)) * time.Second,
-		frequency:   5 * time.Second,
-		fetchFunc: func() (string, error) {
-			output, err := d.DescribeNetworkInterfaces(input)
-			if err != nil {
-				return "", err
-			}
-			if len(output.NetworkInterfaces) == 0 {
-				return "unused", nil
-			}
-			var niIds []string
-			for _, ni := range output.NetworkInterfaces {
-				niIds = append(niIds, aws.StringValue(ni.NetworkInterfaceId))
-			}
-			return fmt.Sprintf("used by %s", strings.Join(niIds, ", ")), nil
-		},
-		expect: fmt.Sprint(params["state"]),
-		logger: d.logger,
+	if update, ok := params["update-routes"]; ok && fmt.Sprint(update) == "true" {
+		if err = d.routePrivateSubnetsThroughNat(id, aws.StringValue(output.NatGateway.VpcId), aws.StringValue(input.SubnetId)); err != nil {
+			return id, fmt.Errorf("create natgateway: update routes: %w", err)
+		}
 	}
-	return nil, c.check()
+	return id, nil
 }
 
-func (d *Ec2Driver) Check_Volume_DryRun(params map[string]interface{}) (interface{}, error) {
-	if _, ok := params["id"]; !ok {
-		return nil, errors.New("check volume: missing required params 'id'")
+// routePrivateSubnetsThroughNat points the default route of the private route tables of the VPC
+// (i.e. without default route to an internet gateway) to the given NAT gateway.
+// Existing default routes are replaced, so that it can be applied again with a new NAT gateway
+func (d *Ec2Driver) routePrivateSubnetsThroughNat(natID, vpcID, natSubnet string) error {
+	if vpcID == "" {
+		subnets, err := d.DescribeSubnets(&ec2.DescribeSubnetsInput{SubnetIds: []*string{aws.String(natSubnet)}})
+		if err != nil {
+			return err
+		}
+		if len(subnets.Subnets) == 0 {
+			return fmt.Errorf("subnet %s not found", natSubnet)
+		}
+		vpcID = aws.StringValue(subnets.Subnets[0].VpcId)
 	}
 
-	states := map[string]struct{}{
-		"available":   {},
-		"in-use":      {},
-		notFoundState: {},
+	out, err := d.DescribeRouteTables(&ec2.DescribeRouteTablesInput{
+		Filters: []*ec2.Filter{{Name: aws.String("vpc-id"), Values: []*string{aws.String(vpcID)}}},
+	})
+	if err != nil {
+		return err
 	}
 
-	if state, ok := params["state"].(string); !ok {
-		return nil, errors.New("check volume: missing required params 'state'")
-	} else {
-		if _, stok := states[state]; !stok {
-			return nil, fmt.Errorf("check volume: invalid state '%s'", state)
+	for _, table := range privateRouteTables(out.RouteTables, natSubnet) {
+		tableID := aws.StringValue(table.RouteTableId)
+		if hasDefaultRoute(table) {
+			if _, err = d.ReplaceRoute(&ec2.ReplaceRouteInput{RouteTableId: table.RouteTableId, DestinationCidrBlock: aws.String(defaultRouteCidr), NatGatewayId: aws.String(natID)}); err != nil {
+				return fmt.Errorf("routetable %s: %w", tableID, err)
+			}
+			d.logger.Infof("replaced default route of routetable '%s' with natgateway '%s'", tableID, natID)
+		} else {
+			if _, err = d.CreateRoute(&ec2.CreateRouteInput{RouteTableId: table.RouteTableId, DestinationCidrBlock: aws.String(defaultRouteCidr), NatGatewayId: aws.String(natID)}); err != nil {
+				return fmt.Errorf("routetable %s: %w", tableID, err)
+			}
+			d.logger.Infof("added default route to natgateway '%s' in routetable '%s'", natID, tableID)
 		}
 	}
+	return nil
+}
 
-	if _, ok := params["timeout"]; !ok {
-		return nil, errors.New("check volume: missing required params 'timeout'")
-	}
+const defaultRouteCidr = "0.0.0.0/0"
 
-	if _, ok := params["timeout"].(int); !ok {
-		return nil, errors.New("check volume: timeout param is not int")
+func privateRouteTables(tables []*ec2.RouteTable, natSubnet string) (private []*ec2.RouteTable) {
+	for _, table := range tables {
+		var public bool
+		for _, route := range table.Routes {
+			if aws.StringValue(route.DestinationCidrBlock) == defaultRouteCidr && strings.HasPrefix(aws.StringValue(route.GatewayId), "igw-") {
+				public = true
+			}
+		}
+		for _, assoc := range table.Associations {
+			if aws.StringValue(assoc.SubnetId) == natSubnet {
+				public = true
+			}
+		}
+		if !public {
+			private = append(private, table)
+		}
 	}
-	d.logger.Verbose("dry run: check instance ok")
-	return nil, nil
+	return
 }
 
-func (d *Ec2Driver) Check_Volume(params map[string]interface{}) (interface{}, error) {
-	input := &ec2.DescribeVolumesInput{
-		VolumeIds: []*string{aws.String(fmt.Sprint(params["id"]))},
-	}
-
-	c := &checker{
-		description: fmt.Sprintf("volume %s", params["id"]),
-		timeout:     time.Duration(params["timeout"].(int)) * time.Second,
-		frequency:   5 * time.Second,
-		fetchFunc: func() (string, error) {
-			output, err := d.DescribeVolumes(input)
-			if err != nil {
-				if awserr, ok := err.(awserr.Error); ok {
-					if awserr.Code() == "VolumeNotFound" {
-						return notFoundState, nil
-					}
-				} else {
-					return "", err
-				}
-			} else {
-				for _, vol := range output.Volumes {
-					if aws.StringValue(vol.VolumeId) == fmt.Sprint(params["id"]) {
-						return aws.StringValue(vol.State), nil
-					}
-				}
-			}
-			return notFoundState, nil
-		},
-		expect: fmt.Sprint(params["state"]),
-		logger: d.logger,
+func hasDefaultRoute(table *ec2.RouteTable) bool {
+	for _, route := range table.Routes {
+		if aws.StringValue(route.DestinationCidrBlock) == defaultRouteCidr {
+			return true
+		}
 	}
-	return nil, c.check()
+	return false
 }
 
 func (d *Ec2Driver) Check_Natgateway_DryRun(params map[string]interface{}) (interface{}, error) {
@@ -1103,6 +2444,222 @@ func (d *Elbv2Driver) Check_Loadbalancer(params map[string]interface{}) (interfa
 	return nil, c.check()
 }
 
+func (d *Elbv2Driver) Create_Loadbalancerstack_DryRun(params map[string]interface{}) (interface{}, error) {
+	for _, p := range []string{"name", "subnets", "instances", "port"} {
+		if _, ok := params[p]; !ok {
+			return nil, fmt.Errorf("create loadbalancerstack: missing required params '%s'", p)
+		}
+	}
+
+	protocol, err := loadbalancerstackProtocol(params)
+	if err != nil {
+		return nil, fmt.Errorf("create loadbalancerstack: %w", err)
+	}
+	if _, ok := params["certificate"]; protocol == "HTTPS" && !ok {
+		return nil, errors.New("create loadbalancerstack: missing 'certificate' param for HTTPS listener")
+	}
+
+	d.logger.Verbose("params dry run: create loadbalancerstack ok")
+	return &driver.Outputs{
+		Main: fakeDryRunId(cloud.LoadBalancer),
+		Named: map[string]interface{}{
+			"dns":          fmt.Sprintf("%s.elb.amazonaws.com", params["name"]),
+			"loadbalancer": fakeDryRunId(cloud.LoadBalancer),
+			"targetgroup":  fakeDryRunId(cloud.TargetGroup),
+			"listener":     fakeDryRunId(cloud.Listener),
+		},
+	}, nil
+}
+
+// Create_Loadbalancerstack creates in one step a loadbalancer, a targetgroup
+// in the loadbalancer's VPC with the given instances registered and a listener
+// forwarding to it. Already created resources are deleted if a step fails.
+func (d *Elbv2Driver) Create_Loadbalancerstack(params map[string]interface{}) (interface{}, error) {
+	protocol, err := loadbalancerstackProtocol(params)
+	if err != nil {
+		return nil, fmt.Errorf("create loadbalancerstack: %w", err)
+	}
+	port, err := castInt(params["port"])
+	if err != nil {
+		return nil, fmt.Errorf("create loadbalancerstack: port: %w", err)
+	}
+	listenerPort := 80
+	if protocol == "HTTPS" {
+		listenerPort = 443
+	}
+	if lp, ok := params["listenerport"]; ok {
+		if listenerPort, err = castInt(lp); err != nil {
+			return nil, fmt.Errorf("create loadbalancerstack: listenerport: %w", err)
+		}
+	}
+	name := fmt.Sprint(params["name"])
+
+	var rollbacks []func() error
+	rollback := func(err error) (interface{}, error) {
+		for i := len(rollbacks) - 1; i >= 0; i-- {
+			if rerr := rollbacks[i](); rerr != nil {
+				d.logger.Errorf("create loadbalancerstack: rollback: %s", rerr)
+			}
+		}
+		return nil, fmt.Errorf("create loadbalancerstack: %w", err)
+	}
+
+	lbInput := &elbv2.CreateLoadBalancerInput{Name: aws.String(name)}
+	if err = setFieldWithType(params["subnets"], lbInput, "Subnets", awsstringslice); err != nil {
+		return nil, err
+	}
+	if sgs, ok := params["securitygroups"]; ok {
+		if err = setFieldWithType(sgs, lbInput, "SecurityGroups", awsstringslice); err != nil {
+			return nil, err
+		}
+	}
+	if scheme, ok := params["scheme"]; ok {
+		if err = setFieldWithType(scheme, lbInput, "Scheme", awsstr); err != nil {
+			return nil, err
+		}
+	}
+
+	start := time.Now()
+	lbOut, err := d.CreateLoadBalancer(lbInput)
+	if err != nil {
+		return nil, fmt.Errorf("create loadbalancerstack: %w", err)
+	}
+	d.logger.ExtraVerbosef("elbv2.CreateLoadBalancer call took %s", time.Since(start))
+	lb := lbOut.LoadBalancers[0]
+	lbArn := aws.StringValue(lb.LoadBalancerArn)
+	rollbacks = append(rollbacks, func() error {
+		_, err := d.DeleteLoadBalancer(&elbv2.DeleteLoadBalancerInput{LoadBalancerArn: lb.LoadBalancerArn})
+		return err
+	})
+	d.logger.Verbosef("loadbalancer '%s' created", lbArn)
+
+	tgInput := &elbv2.CreateTargetGroupInput{
+		Name:     aws.String(name),
+		Port:     aws.Int64(int64(port)),
+		Protocol: aws.String("HTTP"),
+		VpcId:    lb.VpcId,
+	}
+	if path, ok := params["healthcheckpath"]; ok {
+		if err = setFieldWithType(path, tgInput, "HealthCheckPath", awsstr); err != nil {
+			return rollback(err)
+		}
+	}
+
+	start = time.Now()
+	tgOut, err := d.CreateTargetGroup(tgInput)
+	if err != nil {
+		return rollback(err)
+	}
+	d.logger.ExtraVerbosef("elbv2.CreateTargetGroup call took %s", time.Since(start))
+	tgArn := tgOut.TargetGroups[0].TargetGroupArn
+	rollbacks = append(rollbacks, func() error {
+		_, err := d.DeleteTargetGroup(&elbv2.DeleteTargetGroupInput{TargetGroupArn: tgArn})
+		return err
+	})
+	d.logger.Verbosef("targetgroup '%s' created", aws.StringValue(tgArn))
+
+	regInput := &elbv2.RegisterTargetsInput{TargetGroupArn: tgArn}
+	for _, inst := range castStringSlice(params["instances"]) {
+		regInput.Targets = append(regInput.Targets, &elbv2.TargetDescription{Id: aws.String(inst)})
+	}
+
+	start = time.Now()
+	if _, err = d.RegisterTargets(regInput); err != nil {
+		return rollback(err)
+	}
+	d.logger.ExtraVerbosef("elbv2.RegisterTargets call took %s", time.Since(start))
+	d.logger.Verbosef("%d instance(s) registered in targetgroup", len(regInput.Targets))
+
+	lInput := &elbv2.CreateListenerInput{
+		LoadBalancerArn: lb.LoadBalancerArn,
+		Port:            aws.Int64(int64(listenerPort)),
+		Protocol:        aws.String(protocol),
+		DefaultActions:  []*elbv2.Action{{Type: aws.String("forward"), TargetGroupArn: tgArn}},
+	}
+	if cert, ok := params["certificate"]; ok {
+		lInput.Certificates = []*elbv2.Certificate{{CertificateArn: aws.String(fmt.Sprint(cert))}}
+	}
+
+	start = time.Now()
+	lOut, err := d.CreateListener(lInput)
+	if err != nil {
+		return rollback(err)
+	}
+	d.logger.ExtraVerbosef("elbv2.CreateListener call took %s", time.Since(start))
+
+	dns := aws.StringValue(lb.DNSName)
+	d.logger.Infof("create loadbalancerstack '%s' done (%s)", lbArn, dns)
+	return &driver.Outputs{
+		Main: lbArn,
+		Named: map[string]interface{}{
+			"dns":          dns,
+			"loadbalancer": lbArn,
+			"targetgroup":  aws.StringValue(tgArn),
+			"listener":     aws.StringValue(lOut.Listeners[0].ListenerArn),
+		},
+	}, nil
+}
+
+func (d *Elbv2Driver) Delete_Loadbalancerstack_DryRun(params map[string]interface{}) (interface{}, error) {
+	if _, ok := params["id"]; !ok {
+		return nil, errors.New("delete loadbalancerstack: missing required params 'id'")
+	}
+
+	d.logger.Verbose("params dry run: delete loadbalancerstack ok")
+	return nil, nil
+}
+
+// Delete_Loadbalancerstack deletes a loadbalancer and the targetgroups
+// its listeners forward to
+func (d *Elbv2Driver) Delete_Loadbalancerstack(params map[string]interface{}) (interface{}, error) {
+	lbArn := aws.String(fmt.Sprint(params["id"]))
+
+	start := time.Now()
+	listeners, err := d.DescribeListeners(&elbv2.DescribeListenersInput{LoadBalancerArn: lbArn})
+	if err != nil {
+		return nil, fmt.Errorf("delete loadbalancerstack: %w", err)
+	}
+	d.logger.ExtraVerbosef("elbv2.DescribeListeners call took %s", time.Since(start))
+
+	targetgroups := make(map[string]struct{})
+	for _, l := range listeners.Listeners {
+		for _, a := range l.DefaultActions {
+			if a.TargetGroupArn != nil {
+				targetgroups[aws.StringValue(a.TargetGroupArn)] = struct{}{}
+			}
+		}
+	}
+
+	start = time.Now()
+	if _, err = d.DeleteLoadBalancer(&elbv2.DeleteLoadBalancerInput{LoadBalancerArn: lbArn}); err != nil {
+		return nil, fmt.Errorf("delete loadbalancerstack: %w", err)
+	}
+	d.logger.ExtraVerbosef("elbv2.DeleteLoadBalancer call took %s", time.Since(start))
+
+	for tg := range targetgroups {
+		if _, err = d.DeleteTargetGroup(&elbv2.DeleteTargetGroupInput{TargetGroupArn: aws.String(tg)}); err != nil {
+			return nil, fmt.Errorf("delete loadbalancerstack: targetgroup %s: %s", tg, err)
+		}
+		d.logger.Verbosef("targetgroup '%s' deleted", tg)
+	}
+
+	d.logger.Info("delete loadbalancerstack done")
+	return nil, nil
+}
+
+func loadbalancerstackProtocol(params map[string]interface{}) (string, error) {
+	protocol := "HTTP"
+	if p, ok := params["protocol"]; ok {
+		protocol = strings.ToUpper(fmt.Sprint(p))
+	}
+	switch protocol {
+	case "HTTP", "HTTPS":
+		return protocol, nil
+	default:
+		return "", fmt.Errorf("invalid protocol '%s', expecting HTTP or HTTPS", protocol)
+	}
+}
+
 func (d *AutoscalingDriver) Check_Scalinggroup_DryRun(params map[string]interface{}) (interface{}, error) {
 	if _, ok := params["name"].(string); !ok {
 		return nil, errors.New("check scalinggroup: missing required params 'name'")
@@ -1233,7 +2790,7 @@ func (d *Ec2Driver) Create_Tag_DryRun(params map[string]interface{}) (interface{
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: create tag: %s", err)
+	return nil, fmt.Errorf("dry run: create tag: %w", err)
 }
 
 func (d *Ec2Driver) Create_Tag(params map[string]interface{}) (interface{}, error) {
@@ -1251,7 +2808,7 @@ func (d *Ec2Driver) Create_Tag(params map[string]interface{}) (interface{}, erro
 	var output *ec2.CreateTagsOutput
 	output, err = d.CreateTags(input)
 	if err != nil {
-		return nil, fmt.Errorf("create tag: %s", err)
+		return nil, fmt.Errorf("create tag: %w", err)
 	}
 	d.logger.ExtraVerbosef("ec2.CreateTags call took %s", time.Since(start))
 	d.logger.Infof("create tag '%s=%s' on '%s' done", params["key"], params["value"], params["resource"])
@@ -1280,7 +2837,7 @@ func (d *Ec2Driver) Delete_Tag_DryRun(params map[string]interface{}) (interface{
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: delete tag: %s", err)
+	return nil, fmt.Errorf("dry run: delete tag: %w", err)
 }
 
 func (d *Ec2Driver) Delete_Tag(params map[string]interface{}) (interface{}, error) {
@@ -1298,7 +2855,7 @@ func (d *Ec2Driver) Delete_Tag(params map[string]interface{}) (interface{}, erro
 	var output *ec2.DeleteTagsOutput
 	output, err = d.DeleteTags(input)
 	if err != nil {
-		return nil, fmt.Errorf("delete tag: %s", err)
+		return nil, fmt.Errorf("delete tag: %w", err)
 	}
 	d.logger.ExtraVerbosef("ec2.DeleteTags call took %s", time.Since(start))
 	d.logger.Infof("delete tag '%s=%s' on '%s' done", params["key"], params["value"], params["resource"])
@@ -1339,37 +2896,185 @@ func (d *Ec2Driver) Create_Keypair(params map[string]interface{}) (interface{},
 	if err != nil {
 		return nil, err
 	}
-	var encrypted bool
-	var encryptedMsg string
-	if enc, _ := params["encrypted"].(string); enc == "true" {
-		encrypted = true
-		encryptedMsg = " encrypted"
+	var encrypted bool
+	var encryptedMsg string
+	if enc, _ := params["encrypted"].(string); enc == "true" {
+		encrypted = true
+		encryptedMsg = " encrypted"
+	}
+
+	d.logger.Infof("Generating locally a%s RSA 4096 bits keypair...", encryptedMsg)
+	pub, priv, err := console.GenerateSSHKeyPair(4096, encrypted)
+	if err != nil {
+		return nil, fmt.Errorf("generating key: %w", err)
+	}
+	privKeyPath := filepath.Join(os.Getenv("__AWLESS_KEYS_DIR"), fmt.Sprint(params["name"])+".pem")
+	_, err = os.Stat(privKeyPath)
+	if err == nil {
+		return nil, fmt.Errorf("saving private key: file already exists at path: %s", privKeyPath)
+	}
+	err = ioutil.WriteFile(privKeyPath, priv, 0400)
+	if err != nil {
+		return nil, fmt.Errorf("saving private key: %w", err)
+	}
+	d.logger.Infof("4096 RSA keypair generated locally and stored%s in '%s'", encryptedMsg, privKeyPath)
+	input.PublicKeyMaterial = pub
+
+	output, err := d.ImportKeyPair(input)
+	if err != nil {
+		return nil, fmt.Errorf("create key: %w", err)
+	}
+	id := aws.StringValue(output.KeyName)
+	d.logger.Infof("create keypair '%s' done", id)
+	return aws.StringValue(output.KeyName), nil
+}
+
+// SecurityGroupNameResolver resolves the id of a security group given its name and VPC.
+// It is used for rules of created security groups referencing other groups by name
+var SecurityGroupNameResolver func(name, vpc string) string
+
+func (d *Ec2Driver) Create_Securitygroup_DryRun(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.CreateSecurityGroupInput{}
+	input.DryRun = aws.Bool(true)
+
+	// Required params
+	if err := setFieldWithType(params["name"], input, "GroupName", awsstr); err != nil {
+		return nil, err
+	}
+	if err := setFieldWithType(params["vpc"], input, "VpcId", awsstr); err != nil {
+		return nil, err
+	}
+	if err := setFieldWithType(params["description"], input, "Description", awsstr); err != nil {
+		return nil, err
+	}
+
+	for _, key := range []string{"inbound-rules", "outbound-rules"} {
+		if _, err := securityGroupRulesParam(params, key); err != nil {
+			return nil, err
+		}
+	}
+
+	_, err := d.CreateSecurityGroup(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound):
+			id := fakeDryRunId("securitygroup")
+			d.logger.Verbose("dry run: create securitygroup ok")
+			return id, nil
+		}
+	}
+
+	return nil, fmt.Errorf("dry run: create securitygroup: %w", err)
+}
+
+func (d *Ec2Driver) Create_Securitygroup(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.CreateSecurityGroupInput{}
+
+	// Required params
+	if err := setFieldWithType(params["name"], input, "GroupName", awsstr); err != nil {
+		return nil, err
+	}
+	if err := setFieldWithType(params["vpc"], input, "VpcId", awsstr); err != nil {
+		return nil, err
+	}
+	if err := setFieldWithType(params["description"], input, "Description", awsstr); err != nil {
+		return nil, err
+	}
+
+	inbound, err := securityGroupRulesParam(params, "inbound-rules")
+	if err != nil {
+		return nil, err
+	}
+	outbound, err := securityGroupRulesParam(params, "outbound-rules")
+	if err != nil {
+		return nil, err
+	}
+	inboundPerms, err := securityGroupRulesPermissions(inbound, aws.StringValue(input.VpcId))
+	if err != nil {
+		return nil, fmt.Errorf("create securitygroup: %w", err)
+	}
+	outboundPerms, err := securityGroupRulesPermissions(outbound, aws.StringValue(input.VpcId))
+	if err != nil {
+		return nil, fmt.Errorf("create securitygroup: %w", err)
 	}
 
-	d.logger.Infof("Generating locally a%s RSA 4096 bits keypair...", encryptedMsg)
-	pub, priv, err := console.GenerateSSHKeyPair(4096, encrypted)
+	output, err := d.CreateSecurityGroup(input)
 	if err != nil {
-		return nil, fmt.Errorf("generating key: %s", err)
+		return nil, fmt.Errorf("create securitygroup: %w", err)
 	}
-	privKeyPath := filepath.Join(os.Getenv("__AWLESS_KEYS_DIR"), fmt.Sprint(params["name"])+".pem")
-	_, err = os.Stat(privKeyPath)
-	if err == nil {
-		return nil, fmt.Errorf("saving private key: file already exists at path: %s", privKeyPath)
+	id := aws.StringValue(output.GroupId)
+
+	if len(inboundPerms) > 0 {
+		_, err = d.AuthorizeSecurityGroupIngress(&ec2.AuthorizeSecurityGroupIngressInput{GroupId: output.GroupId, IpPermissions: inboundPerms})
+	}
+	if err == nil && len(outboundPerms) > 0 {
+		_, err = d.AuthorizeSecurityGroupEgress(&ec2.AuthorizeSecurityGroupEgressInput{GroupId: output.GroupId, IpPermissions: outboundPerms})
 	}
-	err = ioutil.WriteFile(privKeyPath, priv, 0400)
 	if err != nil {
-		return nil, fmt.Errorf("saving private key: %s", err)
+		d.logger.Warningf("rules authorization failed: deleting securitygroup '%s'", id)
+		if _, rerr := d.DeleteSecurityGroup(&ec2.DeleteSecurityGroupInput{GroupId: output.GroupId}); rerr != nil {
+			return nil, fmt.Errorf("create securitygroup: authorize rules: %s (rollback also failed, securitygroup '%s' remains: %s)", err, id, rerr)
+		}
+		return nil, fmt.Errorf("create securitygroup: authorize rules: %w", err)
 	}
-	d.logger.Infof("4096 RSA keypair generated locally and stored%s in '%s'", encryptedMsg, privKeyPath)
-	input.PublicKeyMaterial = pub
 
-	output, err := d.ImportKeyPair(input)
-	if err != nil {
-		return nil, fmt.Errorf("create key: %s", err)
+	d.logger.Infof("create securitygroup '%s' done", id)
+	return id, nil
+}
+
+func securityGroupRulesParam(params map[string]interface{}, key string) ([]string, error) {
+	var rules []string
+	switch v := params[key].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		rules = []string{v}
+	case []string:
+		rules = v
+	case []interface{}:
+		for _, r := range v {
+			rules = append(rules, fmt.Sprint(r))
+		}
+	default:
+		return nil, fmt.Errorf("invalid %s '%v': expecting rules as protocol:ports:source (ex: tcp:22:10.0.0.0/16,tcp:443:web-sg)", key, v)
 	}
-	id := aws.StringValue(output.KeyName)
-	d.logger.Infof("create keypair '%s' done", id)
-	return aws.StringValue(output.KeyName), nil
+	for _, r := range rules {
+		if len(strings.SplitN(r, ":", 3)) != 3 {
+			return nil, fmt.Errorf("invalid %s rule '%s': expecting protocol:ports:source (ex: tcp:22:10.0.0.0/16, tcp:80-443:sg-12345678, any:any:web-sg)", key, r)
+		}
+	}
+	return rules, nil
+}
+
+// securityGroupRulesPermissions builds the permissions of rules protocol:ports:source,
+// the source being a CIDR, a security group id or the name of a security group in the VPC
+func securityGroupRulesPermissions(rules []string, vpc string) ([]*ec2.IpPermission, error) {
+	var perms []*ec2.IpPermission
+	for _, rule := range rules {
+		splits := strings.SplitN(rule, ":", 3)
+		protocol, ports, source := splits[0], splits[1], splits[2]
+		ipPerms, err := buildIpPermissionsFromParams(map[string]interface{}{"cidr": source, "protocol": protocol, "portrange": ports})
+		if err != nil {
+			return nil, fmt.Errorf("rule '%s': %w", rule, err)
+		}
+		if !strings.Contains(source, "/") {
+			groupID := source
+			if !strings.HasPrefix(source, "sg-") {
+				if SecurityGroupNameResolver != nil {
+					groupID = SecurityGroupNameResolver(source, vpc)
+				}
+				if groupID == "" || groupID == source {
+					return nil, fmt.Errorf("rule '%s': cannot resolve securitygroup '%s' in %s. Maybe you need to update your local model with `awless sync` ?", rule, source, vpc)
+				}
+			}
+			for _, p := range ipPerms {
+				p.IpRanges = nil
+				p.UserIdGroupPairs = []*ec2.UserIdGroupPair{{GroupId: aws.String(groupID)}}
+			}
+		}
+		perms = append(perms, ipPerms...)
+	}
+	return perms, nil
 }
 
 func (d *Ec2Driver) Update_Securitygroup_DryRun(params map[string]interface{}) (interface{}, error) {
@@ -1425,7 +3130,7 @@ func (d *Ec2Driver) Update_Securitygroup_DryRun(params map[string]interface{}) (
 			return nil, nil
 		}
 	}
-	return nil, fmt.Errorf("dry run: update securitygroup: %s", err)
+	return nil, fmt.Errorf("dry run: update securitygroup: %w", err)
 }
 
 func (d *Ec2Driver) Update_Securitygroup(params map[string]interface{}) (interface{}, error) {
@@ -1476,7 +3181,7 @@ func (d *Ec2Driver) Update_Securitygroup(params map[string]interface{}) (interfa
 		output, err = d.RevokeSecurityGroupEgress(ii)
 	}
 	if err != nil {
-		return nil, fmt.Errorf("update securitygroup: %s", err)
+		return nil, fmt.Errorf("update securitygroup: %w", err)
 	}
 
 	d.logger.Info("update securitygroup done")
@@ -1500,7 +3205,9 @@ func (d *S3Driver) Create_S3object_DryRun(params map[string]interface{}) (interf
 		return nil, err
 	}
 	if stat.IsDir() {
-		return nil, fmt.Errorf("'%s' is a directory", params["file"])
+		if _, err := uploadConcurrency(params); err != nil {
+			return nil, fmt.Errorf("create s3object: %s", err)
+		}
 	}
 
 	d.logger.Verbose("params dry run: create s3object ok")
@@ -1546,6 +3253,10 @@ func (pr *progressReadSeeker) Seek(offset int64, whence int) (int64, error) {
 }
 
 func (d *S3Driver) Create_S3object(params map[string]interface{}) (interface{}, error) {
+	if stat, err := os.Stat(params["file"].(string)); err == nil && stat.IsDir() {
+		return nil, d.createS3objects(params["file"].(string), params)
+	}
+
 	input := &s3.PutObjectInput{}
 
 	f, err := os.Open(params["file"].(string))
@@ -1591,13 +3302,64 @@ func (d *S3Driver) Create_S3object(params map[string]interface{}) (interface{},
 
 	_, err = d.PutObject(input)
 	if err != nil {
-		return nil, fmt.Errorf("create s3object: %s", err)
+		return nil, fmt.Errorf("create s3object: %w", err)
 	}
 
 	d.logger.Info("create s3object done")
 	return fileName, nil
 }
 
+// createS3objects uploads the files of a directory, keyed by their path relative to
+// the directory prefixed with the name param, if any
+func (d *S3Driver) createS3objects(dir string, params map[string]interface{}) error {
+	concurrency, err := uploadConcurrency(params)
+	if err != nil {
+		return fmt.Errorf("create s3object: %s", err)
+	}
+	prefix, _ := params["name"].(string)
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+
+	d.logger.Infof("uploading directory '%s' (%d files at once)", dir, concurrency)
+	report := uploadTree(dir, concurrency, func(path, key string) (int64, error) {
+		f, err := os.Open(path)
+		if err != nil {
+			return 0, err
+		}
+		defer f.Close()
+		stat, err := f.Stat()
+		if err != nil {
+			return 0, err
+		}
+
+		input := &s3.PutObjectInput{Body: f, Key: aws.String(prefix + key)}
+		if mimeType := mime.TypeByExtension(filepath.Ext(path)); mimeType != "" {
+			input.ContentType = aws.String(mimeType)
+		}
+		if err = setFieldWithType(params["bucket"], input, "Bucket", awsstr); err != nil {
+			return 0, err
+		}
+		if _, ok := params["acl"]; ok {
+			if err = setFieldWithType(params["acl"], input, "ACL", awsstr); err != nil {
+				return 0, err
+			}
+		}
+		if _, err = d.PutObject(input); err != nil {
+			return 0, err
+		}
+		d.logger.Verbosef("uploaded '%s'", prefix+key)
+		return stat.Size(), nil
+	})
+
+	d.logger.Infof("uploaded %d files (%d bytes) in %s: %s", report.Files, report.Bytes, report.Elapsed.Round(time.Millisecond), report.throughput())
+	if err := report.err(); err != nil {
+		return fmt.Errorf("create s3object: %s", err)
+	}
+	d.logger.Info("create s3object done")
+	return nil
+}
+
 func (d *S3Driver) Update_Bucket_DryRun(params map[string]interface{}) (interface{}, error) {
 	if _, ok := params["name"]; !ok {
 		return nil, errors.New("update bucket: missing required param 'name'")
@@ -1606,12 +3368,24 @@ func (d *S3Driver) Update_Bucket_DryRun(params map[string]interface{}) (interfac
 	_, updatePublicWebsite := params["public-website"]
 	if updatePublicWebsite {
 		if _, err := strconv.ParseBool(fmt.Sprint(params["public-website"])); err != nil {
-			return nil, fmt.Errorf("update bucket: 'public-website' is not a bool: %s", err)
+			return nil, fmt.Errorf("update bucket: 'public-website' is not a bool: %w", err)
+		}
+	}
+	_, updateReplication := params["replication"]
+	if updateReplication {
+		replicate, err := strconv.ParseBool(fmt.Sprint(params["replication"]))
+		if err != nil {
+			return nil, fmt.Errorf("update bucket: 'replication' is not a bool: %w", err)
+		}
+		if replicate {
+			if _, err := replicationConfiguration(params); err != nil {
+				return nil, fmt.Errorf("update bucket: %w", err)
+			}
 		}
 	}
 	_, updateAcl := params["acl"]
-	if !updatePublicWebsite && !updateAcl {
-		return nil, fmt.Errorf("update bucket: must set either 'public-website' or 'acl'")
+	if !updatePublicWebsite && !updateAcl && !updateReplication {
+		return nil, fmt.Errorf("update bucket: must set either 'public-website', 'replication' or 'acl'")
 	}
 
 	d.logger.Verbose("params dry run: update buclet ok")
@@ -1633,7 +3407,7 @@ func (d *S3Driver) Update_Bucket(params map[string]interface{}) (interface{}, er
 		}
 		_, err = d.PutBucketAcl(input)
 		if err != nil {
-			return nil, fmt.Errorf("update bucket: %s", err)
+			return nil, fmt.Errorf("update bucket: %w", err)
 		}
 
 		d.logger.ExtraVerbosef("s3.PutBucketAcl call took %s", time.Since(start))
@@ -1644,7 +3418,7 @@ func (d *S3Driver) Update_Bucket(params map[string]interface{}) (interface{}, er
 	if _, ok := params["public-website"]; ok { // Set/Unset this bucket as a public website
 		publicWebsite, err := strconv.ParseBool(fmt.Sprint(params["public-website"]))
 		if err != nil {
-			return nil, fmt.Errorf("update bucket: 'public-website' is not a bool: %s", err)
+			return nil, fmt.Errorf("update bucket: 'public-website' is not a bool: %w", err)
 		}
 		if publicWebsite {
 			input := &s3.PutBucketWebsiteInput{
@@ -1665,14 +3439,14 @@ func (d *S3Driver) Update_Bucket(params map[string]interface{}) (interface{}, er
 			_, err := d.PutBucketWebsite(input)
 
 			if err != nil {
-				return nil, fmt.Errorf("update bucket: %s", err)
+				return nil, fmt.Errorf("update bucket: %w", err)
 			}
 		} else {
 			_, err := d.DeleteBucketWebsite(&s3.DeleteBucketWebsiteInput{
 				Bucket: aws.String(bucket),
 			})
 			if err != nil {
-				return nil, fmt.Errorf("update bucket: %s", err)
+				return nil, fmt.Errorf("update bucket: %w", err)
 			}
 		}
 
@@ -1680,9 +3454,68 @@ func (d *S3Driver) Update_Bucket(params map[string]interface{}) (interface{}, er
 		d.logger.Info("update bucket done")
 		return nil, nil
 	}
+
+	if _, ok := params["replication"]; ok { // Put/Remove the cross-region replication configuration of this bucket
+		replicate, err := strconv.ParseBool(fmt.Sprint(params["replication"]))
+		if err != nil {
+			return nil, fmt.Errorf("update bucket: 'replication' is not a bool: %w", err)
+		}
+		if replicate {
+			conf, err := replicationConfiguration(params)
+			if err != nil {
+				return nil, fmt.Errorf("update bucket: %w", err)
+			}
+			if _, err = d.PutBucketReplication(&s3.PutBucketReplicationInput{Bucket: aws.String(bucket), ReplicationConfiguration: conf}); err != nil {
+				return nil, fmt.Errorf("update bucket: %w", err)
+			}
+			d.logger.ExtraVerbosef("s3.PutBucketReplication call took %s", time.Since(start))
+		} else {
+			if _, err = d.DeleteBucketReplication(&s3.DeleteBucketReplicationInput{Bucket: aws.String(bucket)}); err != nil {
+				return nil, fmt.Errorf("update bucket: %w", err)
+			}
+			d.logger.ExtraVerbosef("s3.DeleteBucketReplication call took %s", time.Since(start))
+		}
+
+		d.logger.Info("update bucket done")
+		return nil, nil
+	}
 	return nil, nil
 }
 
+// replicationConfiguration builds a single rule replication configuration from the 'replication-*' params.
+// The destination can be given as a bucket name or ARN. Versioning must be enabled on both buckets
+func replicationConfiguration(params map[string]interface{}) (*s3.ReplicationConfiguration, error) {
+	destination, ok := params["replication-destination"]
+	if !ok {
+		return nil, errors.New("missing 'replication-destination' param to enable replication")
+	}
+	role, ok := params["replication-role"]
+	if !ok {
+		return nil, errors.New("missing 'replication-role' param to enable replication")
+	}
+
+	destinationArn := fmt.Sprint(destination)
+	if !strings.HasPrefix(destinationArn, "arn:") {
+		destinationArn = "arn:aws:s3:::" + destinationArn
+	}
+	rule := &s3.ReplicationRule{
+		Destination: &s3.Destination{Bucket: aws.String(destinationArn)},
+		Prefix:      aws.String(""),
+		Status:      aws.String(s3.ReplicationRuleStatusEnabled),
+	}
+	if prefix, ok := params["replication-prefix"]; ok {
+		rule.Prefix = aws.String(fmt.Sprint(prefix))
+	}
+	if class, ok := params["replication-storageclass"]; ok {
+		rule.Destination.StorageClass = aws.String(fmt.Sprint(class))
+	}
+
+	return &s3.ReplicationConfiguration{
+		Role:  aws.String(fmt.Sprint(role)),
+		Rules: []*s3.ReplicationRule{rule},
+	}, nil
+}
+
 func (d *Route53Driver) Create_Record_DryRun(params map[string]interface{}) (interface{}, error) {
 	if _, ok := params["zone"]; !ok {
 		return nil, errors.New("create record: missing required params 'zone'")
@@ -1704,6 +3537,10 @@ func (d *Route53Driver) Create_Record_DryRun(params map[string]interface{}) (int
 		return nil, errors.New("create record: missing required params 'ttl'")
 	}
 
+	if err := checkRecordRouting(params); err != nil {
+		return nil, fmt.Errorf("create record: %s", err)
+	}
+
 	d.logger.Verbose("params dry run: create record ok")
 	return nil, nil
 }
@@ -1739,6 +3576,9 @@ func (d *Route53Driver) Create_Record(params map[string]interface{}) (interface{
 	if err != nil {
 		return nil, err
 	}
+	if err = setRecordRouting(params, change); err != nil {
+		return nil, err
+	}
 
 	// Extra params
 	if _, ok := params["comment"]; ok {
@@ -1753,7 +3593,7 @@ func (d *Route53Driver) Create_Record(params map[string]interface{}) (interface{
 	output, err = d.ChangeResourceRecordSets(input)
 
 	if err != nil {
-		return nil, fmt.Errorf("create record: %s", err)
+		return nil, fmt.Errorf("create record: %w", err)
 	}
 	d.logger.ExtraVerbosef("route53.ChangeResourceRecordSets call took %s", time.Since(start))
 	d.logger.Info("create record done")
@@ -1781,6 +3621,10 @@ func (d *Route53Driver) Delete_Record_DryRun(params map[string]interface{}) (int
 		return nil, errors.New("delete record: missing required params 'value'")
 	}
 
+	if err := checkRecordRouting(params); err != nil {
+		return nil, fmt.Errorf("delete record: %s", err)
+	}
+
 	d.logger.Verbose("params dry run: delete record ok")
 	return nil, nil
 }
@@ -1816,19 +3660,48 @@ func (d *Route53Driver) Delete_Record(params map[string]interface{}) (interface{
 	if err != nil {
 		return nil, err
 	}
+	if err = setRecordRouting(params, change); err != nil {
+		return nil, err
+	}
 
 	start := time.Now()
 	var output *route53.ChangeResourceRecordSetsOutput
 	output, err = d.ChangeResourceRecordSets(input)
 
 	if err != nil {
-		return nil, fmt.Errorf("delete record: %s", err)
+		return nil, fmt.Errorf("delete record: %w", err)
 	}
 	d.logger.ExtraVerbosef("route53.ChangeResourceRecordSets call took %s", time.Since(start))
 	d.logger.Info("delete record done")
 	return aws.StringValue(output.ChangeInfo.Id), nil
 }
 
+// checkRecordRouting checks the weight of a record of a weighted set, which needs an identifier
+func checkRecordRouting(params map[string]interface{}) error {
+	weight, hasWeight := params["weight"]
+	_, hasIdentifier := params["identifier"]
+	if hasWeight != hasIdentifier {
+		return errors.New("weighted records need both 'weight' and 'identifier' params")
+	}
+	if hasWeight {
+		return template.CheckWeight(cloud.Record, weight)
+	}
+	return nil
+}
+
+func setRecordRouting(params map[string]interface{}, change *route53.Change) error {
+	if err := checkRecordRouting(params); err != nil {
+		return err
+	}
+	if _, ok := params["weight"]; !ok {
+		return nil
+	}
+	if err := setFieldWithType(params["weight"], change, "ResourceRecordSet.Weight", awsint64); err != nil {
+		return err
+	}
+	return setFieldWithType(params["identifier"], change, "ResourceRecordSet.SetIdentifier", awsstr)
+}
+
 func buildIpPermissionsFromParams(params map[string]interface{}) ([]*ec2.IpPermission, error) {
 	if _, ok := params["cidr"].(string); !ok {
 		return nil, fmt.Errorf("invalid cidr '%v'", params["cidr"])
@@ -1906,7 +3779,7 @@ func (d *CloudwatchDriver) Attach_Alarm_DryRun(params map[string]interface{}) (i
 func (d *CloudwatchDriver) Attach_Alarm(params map[string]interface{}) (interface{}, error) {
 	alarm, err := d.getAlarm(params)
 	if err != nil {
-		return nil, fmt.Errorf("attach alarm: %s", err)
+		return nil, fmt.Errorf("attach alarm: %w", err)
 	}
 	alarm.AlarmActions = append(alarm.AlarmActions, aws.String(params["action-arn"].(string)))
 
@@ -1931,7 +3804,7 @@ func (d *CloudwatchDriver) Attach_Alarm(params map[string]interface{}) (interfac
 		Unit:                             alarm.Unit,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("attach alarm: %s", err)
+		return nil, fmt.Errorf("attach alarm: %w", err)
 	}
 	return nil, nil
 }
@@ -1954,7 +3827,7 @@ func (d *CloudwatchDriver) Detach_Alarm_DryRun(params map[string]interface{}) (i
 func (d *CloudwatchDriver) Detach_Alarm(params map[string]interface{}) (interface{}, error) {
 	alarm, err := d.getAlarm(params)
 	if err != nil {
-		return nil, fmt.Errorf("detach alarm: %s", err)
+		return nil, fmt.Errorf("detach alarm: %w", err)
 	}
 	actionArn := params["action-arn"].(string)
 	var found bool
@@ -1991,7 +3864,7 @@ func (d *CloudwatchDriver) Detach_Alarm(params map[string]interface{}) (interfac
 		Unit:                             alarm.Unit,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("detach alarm: %s", err)
+		return nil, fmt.Errorf("detach alarm: %w", err)
 	}
 	return nil, nil
 }
@@ -2010,97 +3883,343 @@ func (d *CloudwatchDriver) getAlarm(params map[string]interface{}) (*cloudwatch.
 	} else if l > 1 {
 		return nil, fmt.Errorf("%d alarms found with name '%s'", l, alarm)
 	}
-	return out.MetricAlarms[0], nil
+	return out.MetricAlarms[0], nil
+}
+
+func (d *Ec2Driver) Delete_Image_DryRun(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.DeregisterImageInput{}
+	input.DryRun = aws.Bool(true)
+	var err error
+
+	// Required params
+	err = setFieldWithType(params["id"], input, "ImageId", awsstr)
+	if err != nil {
+		return nil, err
+	}
+
+	if del, ok := params["delete-snapshots"]; ok && fmt.Sprint(del) == "true" {
+		var snaps []string
+		if snaps, err = d.imageSnapshots(aws.StringValue(input.ImageId)); err != nil {
+			return nil, err
+		}
+		if len(snaps) > 0 {
+			d.logger.Infof("deleting image will also delete snapshot %s (prevent that by appending `delete-snapshots=false`)", strings.Join(snaps, ", "))
+		}
+	}
+
+	_, err = d.DeregisterImage(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound):
+			id := fakeDryRunId("image")
+			d.logger.Verbose("dry run: delete image ok")
+			return id, nil
+		}
+	}
+
+	return nil, fmt.Errorf("dry run: delete image: %w", err)
+}
+
+func (d *Ec2Driver) Delete_Image(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.DeregisterImageInput{}
+	var err error
+
+	err = setFieldWithType(params["id"], input, "ImageId", awsstr)
+	if err != nil {
+		return nil, err
+	}
+
+	var snaps []string
+	if del, ok := params["delete-snapshots"]; ok && fmt.Sprint(del) == "true" {
+		if snaps, err = d.imageSnapshots(aws.StringValue(input.ImageId)); err != nil {
+			return nil, err
+		}
+	}
+
+	start := time.Now()
+	var output *ec2.DeregisterImageOutput
+	output, err = d.DeregisterImage(input)
+	if err != nil {
+		return nil, fmt.Errorf("delete image: deregister: %w", err)
+	}
+	d.logger.ExtraVerbosef("ec2.DeregisterImage call took %s", time.Since(start))
+	d.logger.Info("delete image done")
+
+	if del, ok := params["delete-snapshots"]; ok && fmt.Sprint(del) == "true" {
+		for _, snap := range snaps {
+			snapDelParams := map[string]interface{}{"id": snap}
+			if _, err = d.Delete_Snapshot(snapDelParams); err != nil {
+				return nil, fmt.Errorf("error while deleting snapshot %s: %s", snap, err)
+			}
+		}
+	}
+	return output, nil
+}
+
+func (d *Ec2Driver) imageSnapshots(id string) ([]string, error) {
+	var snapshots []string
+	imgs, err := d.DescribeImages(&ec2.DescribeImagesInput{ImageIds: []*string{aws.String(id)}})
+	if err != nil {
+		return snapshots, err
+	}
+	if len(imgs.Images) == 0 {
+		return snapshots, fmt.Errorf("no image found with id '%s'", id)
+	}
+	if len(imgs.Images) > 1 {
+		return snapshots, fmt.Errorf("multiple images found with id '%s'", id)
+	}
+	for _, dev := range imgs.Images[0].BlockDeviceMappings {
+		if snapshot := aws.StringValue(dev.Ebs.SnapshotId); snapshot != "" {
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	return snapshots, nil
+}
+
+func (d *Ec2Driver) Copy_Image_DryRun(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.CopyImageInput{}
+	input.DryRun = aws.Bool(true)
+
+	err := setFieldWithType(params["name"], input, "Name", awsstr)
+	if err != nil {
+		return nil, err
+	}
+	err = setFieldWithType(params["source-id"], input, "SourceImageId", awsstr)
+	if err != nil {
+		return nil, err
+	}
+	if err = d.setCopyParams(params, input); err != nil {
+		return nil, fmt.Errorf("dry run: copy image: %w", err)
+	}
+	dest, err := d.regionClient(d.copyDestination(params))
+	if err != nil {
+		return nil, fmt.Errorf("dry run: copy image: %w", err)
+	}
+
+	_, err = dest.CopyImage(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound):
+			id := fakeDryRunId("image")
+			d.logger.Verbose("dry run: copy image ok")
+			return id, nil
+		}
+	}
+
+	return nil, fmt.Errorf("dry run: copy image: %w", err)
+}
+
+func (d *Ec2Driver) Copy_Image(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.CopyImageInput{}
+
+	err := setFieldWithType(params["name"], input, "Name", awsstr)
+	if err != nil {
+		return nil, err
+	}
+	err = setFieldWithType(params["source-id"], input, "SourceImageId", awsstr)
+	if err != nil {
+		return nil, err
+	}
+	if err = d.setCopyParams(params, input); err != nil {
+		return nil, fmt.Errorf("copy image: %w", err)
+	}
+	if err = setIdempotencyToken(params, "copy image", input, "ClientToken"); err != nil {
+		return nil, err
+	}
+	dest, err := d.regionClient(d.copyDestination(params))
+	if err != nil {
+		return nil, fmt.Errorf("copy image: %w", err)
+	}
+
+	start := time.Now()
+	output, err := dest.CopyImage(input)
+	if err != nil {
+		return nil, fmt.Errorf("copy image: %w", err)
+	}
+	d.logger.ExtraVerbosef("ec2.CopyImage call took %s", time.Since(start))
+	id := aws.StringValue(output.ImageId)
+	d.logger.Infof("copy image '%s' done in region %s", id, d.copyDestination(params))
+
+	if wait, ok := params["wait"]; ok && fmt.Sprint(wait) == "true" {
+		c := &checker{
+			description: fmt.Sprintf("image %s", id),
+			timeout:     copyWaitTimeout,
+			frequency:   15 * time.Second,
+			fetchFunc: func() (string, error) {
+				out, err := dest.DescribeImages(&ec2.DescribeImagesInput{ImageIds: []*string{aws.String(id)}})
+				if err != nil {
+					return "", err
+				}
+				if len(out.Images) == 0 {
+					return notFoundState, nil
+				}
+				if state := aws.StringValue(out.Images[0].State); state == ec2.ImageStateFailed {
+					return "", fmt.Errorf("copy failed: %s", aws.StringValue(out.Images[0].StateReason.Message))
+				} else {
+					return state, nil
+				}
+			},
+			expect: ec2.ImageStateAvailable,
+			logger: d.logger,
+		}
+		if err = c.check(); err != nil {
+			return id, err
+		}
+	}
+
+	return id, nil
 }
 
-func (d *Ec2Driver) Delete_Image_DryRun(params map[string]interface{}) (interface{}, error) {
-	input := &ec2.DeregisterImageInput{}
+func (d *Ec2Driver) Copy_Snapshot_DryRun(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.CopySnapshotInput{}
 	input.DryRun = aws.Bool(true)
-	var err error
 
-	// Required params
-	err = setFieldWithType(params["id"], input, "ImageId", awsstr)
+	err := setFieldWithType(params["source-id"], input, "SourceSnapshotId", awsstr)
 	if err != nil {
 		return nil, err
 	}
-
-	if del, ok := params["delete-snapshots"]; ok && fmt.Sprint(del) == "true" {
-		var snaps []string
-		if snaps, err = d.imageSnapshots(aws.StringValue(input.ImageId)); err != nil {
-			return nil, err
-		}
-		if len(snaps) > 0 {
-			d.logger.Infof("deleting image will also delete snapshot %s (prevent that by appending `delete-snapshots=false`)", strings.Join(snaps, ", "))
-		}
+	if err = d.setCopyParams(params, input); err != nil {
+		return nil, fmt.Errorf("dry run: copy snapshot: %w", err)
+	}
+	dest, err := d.regionClient(d.copyDestination(params))
+	if err != nil {
+		return nil, fmt.Errorf("dry run: copy snapshot: %w", err)
 	}
 
-	_, err = d.DeregisterImage(input)
+	_, err = dest.CopySnapshot(input)
 	if awsErr, ok := err.(awserr.Error); ok {
 		switch code := awsErr.Code(); {
 		case code == dryRunOperation, strings.HasSuffix(code, notFound):
-			id := fakeDryRunId("image")
-			d.logger.Verbose("dry run: delete image ok")
+			id := fakeDryRunId("snapshot")
+			d.logger.Verbose("dry run: copy snapshot ok")
 			return id, nil
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: delete image: %s", err)
+	return nil, fmt.Errorf("dry run: copy snapshot: %w", err)
 }
 
-func (d *Ec2Driver) Delete_Image(params map[string]interface{}) (interface{}, error) {
-	input := &ec2.DeregisterImageInput{}
-	var err error
+func (d *Ec2Driver) Copy_Snapshot(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.CopySnapshotInput{}
 
-	err = setFieldWithType(params["id"], input, "ImageId", awsstr)
+	err := setFieldWithType(params["source-id"], input, "SourceSnapshotId", awsstr)
 	if err != nil {
 		return nil, err
 	}
-
-	var snaps []string
-	if del, ok := params["delete-snapshots"]; ok && fmt.Sprint(del) == "true" {
-		if snaps, err = d.imageSnapshots(aws.StringValue(input.ImageId)); err != nil {
-			return nil, err
-		}
+	if err = d.setCopyParams(params, input); err != nil {
+		return nil, fmt.Errorf("copy snapshot: %w", err)
+	}
+	dest, err := d.regionClient(d.copyDestination(params))
+	if err != nil {
+		return nil, fmt.Errorf("copy snapshot: %w", err)
 	}
 
 	start := time.Now()
-	var output *ec2.DeregisterImageOutput
-	output, err = d.DeregisterImage(input)
+	output, err := dest.CopySnapshot(input)
 	if err != nil {
-		return nil, fmt.Errorf("delete image: deregister: %s", err)
+		return nil, fmt.Errorf("copy snapshot: %w", err)
+	}
+	d.logger.ExtraVerbosef("ec2.CopySnapshot call took %s", time.Since(start))
+	id := aws.StringValue(output.SnapshotId)
+	d.logger.Infof("copy snapshot '%s' done in region %s", id, d.copyDestination(params))
+
+	if wait, ok := params["wait"]; ok && fmt.Sprint(wait) == "true" {
+		c := &checker{
+			description: fmt.Sprintf("snapshot %s", id),
+			timeout:     copyWaitTimeout,
+			frequency:   15 * time.Second,
+			fetchFunc: func() (string, error) {
+				out, err := dest.DescribeSnapshots(&ec2.DescribeSnapshotsInput{SnapshotIds: []*string{aws.String(id)}})
+				if err != nil {
+					return "", err
+				}
+				if len(out.Snapshots) == 0 {
+					return notFoundState, nil
+				}
+				if state := aws.StringValue(out.Snapshots[0].State); state == ec2.SnapshotStateError {
+					return "", fmt.Errorf("copy failed: %s", aws.StringValue(out.Snapshots[0].StateMessage))
+				} else {
+					return state, nil
+				}
+			},
+			expect: ec2.SnapshotStateCompleted,
+			logger: d.logger,
+		}
+		if err = c.check(); err != nil {
+			return id, err
+		}
 	}
-	d.logger.ExtraVerbosef("ec2.DeregisterImage call took %s", time.Since(start))
-	d.logger.Info("delete image done")
 
-	if del, ok := params["delete-snapshots"]; ok && fmt.Sprint(del) == "true" {
-		for _, snap := range snaps {
-			snapDelParams := map[string]interface{}{"id": snap}
-			if _, err = d.Delete_Snapshot(snapDelParams); err != nil {
-				return nil, fmt.Errorf("error while deleting snapshot %s: %s", snap, err)
-			}
+	return id, nil
+}
+
+const copyWaitTimeout = 2 * time.Hour
+
+// setCopyParams fills the params shared by image and snapshot copies.
+// The source region defaults to the driver's region and giving a KMS key implies encryption
+func (d *Ec2Driver) setCopyParams(params map[string]interface{}, input interface{}) error {
+	srcRegion := d.region()
+	if src, ok := params["source-region"]; ok {
+		srcRegion = fmt.Sprint(src)
+	}
+	if srcRegion == "" {
+		return errors.New("missing required params 'source-region'")
+	}
+	if err := setFieldWithType(srcRegion, input, "SourceRegion", awsstr); err != nil {
+		return err
+	}
+	if _, ok := params["encrypted"]; ok {
+		if err := setFieldWithType(params["encrypted"], input, "Encrypted", awsbool); err != nil {
+			return err
 		}
 	}
-	return output, nil
+	if _, ok := params["kmskey"]; ok {
+		if err := setFieldWithType(params["kmskey"], input, "KmsKeyId", awsstr); err != nil {
+			return err
+		}
+		if err := setFieldWithType(true, input, "Encrypted", awsbool); err != nil {
+			return err
+		}
+	}
+	if _, ok := params["description"]; ok {
+		if err := setFieldWithType(params["description"], input, "Description", awsstr); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func (d *Ec2Driver) imageSnapshots(id string) ([]string, error) {
-	var snapshots []string
-	imgs, err := d.DescribeImages(&ec2.DescribeImagesInput{ImageIds: []*string{aws.String(id)}})
-	if err != nil {
-		return snapshots, err
+func (d *Ec2Driver) copyDestination(params map[string]interface{}) string {
+	if dest, ok := params["to-region"]; ok {
+		return fmt.Sprint(dest)
 	}
-	if len(imgs.Images) == 0 {
-		return snapshots, fmt.Errorf("no image found with id '%s'", id)
+	return d.region()
+}
+
+func (d *Ec2Driver) region() string {
+	if c, ok := d.EC2API.(*ec2.EC2); ok {
+		return aws.StringValue(c.Config.Region)
 	}
-	if len(imgs.Images) > 1 {
-		return snapshots, fmt.Errorf("multiple images found with id '%s'", id)
+	return ""
+}
+
+// regionClient returns an EC2 client for the given region sharing the driver's credentials and handlers.
+// The driver's own client is returned when no other region is given
+func (d *Ec2Driver) regionClient(region string) (ec2iface.EC2API, error) {
+	if region == "" || region == d.region() {
+		return d.EC2API, nil
 	}
-	for _, dev := range imgs.Images[0].BlockDeviceMappings {
-		if snapshot := aws.StringValue(dev.Ebs.SnapshotId); snapshot != "" {
-			snapshots = append(snapshots, snapshot)
-		}
+	c, ok := d.EC2API.(*ec2.EC2)
+	if !ok {
+		return nil, fmt.Errorf("cannot resolve session for region '%s'", region)
 	}
-	return snapshots, nil
+	sess, err := session.NewSession(c.Config.Copy(aws.NewConfig().WithRegion(region)))
+	if err != nil {
+		return nil, fmt.Errorf("session for region '%s': %s", region, err)
+	}
+	dest := ec2.New(sess)
+	dest.Handlers = c.Handlers.Copy()
+	return dest, nil
 }
 
 func (d *CloudfrontDriver) Create_Distribution_DryRun(params map[string]interface{}) (interface{}, error) {
@@ -2232,7 +4351,7 @@ func (d *CloudfrontDriver) Create_Distribution(params map[string]interface{}) (i
 	var output *cloudfront.CreateDistributionOutput
 	output, err = d.CreateDistribution(input)
 	if err != nil {
-		return nil, fmt.Errorf("create distribution: %s", err)
+		return nil, fmt.Errorf("create distribution: %w", err)
 	}
 	d.logger.ExtraVerbosef("cloudfront.CreateDistribution call took %s", time.Since(start))
 	id := aws.StringValue(output.Distribution.Id)
@@ -2245,15 +4364,97 @@ func (d *CloudfrontDriver) Update_Distribution_DryRun(params map[string]interfac
 	if _, ok := params["id"]; !ok {
 		return nil, errors.New("update distribution: missing required params 'id'")
 	}
-
-	if _, ok := params["enable"]; !ok {
-		return nil, errors.New("update distribution: missing required params 'enable'")
+	if !hasAnyParam(params, distributionUpdateParams...) {
+		return nil, fmt.Errorf("update distribution: missing at least one of params '%s'", strings.Join(distributionUpdateParams, "', '"))
+	}
+	if _, ok := params["path-pattern"]; ok && !hasAnyParam(params, distributionBehaviorParams...) {
+		return nil, errors.New("update distribution: 'path-pattern' given without any cache behavior change")
 	}
 
 	d.logger.Verbose("params dry run: update distribution ok")
 	return fakeDryRunId("distribution"), nil
 }
 
+func (d *EcrDriver) Update_Repository_DryRun(params map[string]interface{}) (interface{}, error) {
+	if _, _, err := buildRepositoryUpdateInputs(params); err != nil {
+		return nil, fmt.Errorf("update repository: %s", err)
+	}
+
+	d.logger.Verbose("params dry run: update repository ok")
+	return nil, nil
+}
+
+// Update_Repository sets the lifecycle policy and the image scanning on push of a repository
+func (d *EcrDriver) Update_Repository(params map[string]interface{}) (interface{}, error) {
+	lifecycle, scanning, err := buildRepositoryUpdateInputs(params)
+	if err != nil {
+		return nil, fmt.Errorf("update repository: %s", err)
+	}
+	api, ok := ContainerRegistry(d.ECRAPI)
+	if !ok {
+		return nil, errors.New("update repository: lifecycle policies and image scanning not supported by the ecr client")
+	}
+
+	if lifecycle != nil {
+		start := time.Now()
+		if _, err = api.PutLifecyclePolicy(lifecycle); err != nil {
+			return nil, fmt.Errorf("update repository: %w", err)
+		}
+		d.logger.ExtraVerbosef("ecr.PutLifecyclePolicy call took %s", time.Since(start))
+	}
+	if scanning != nil {
+		start := time.Now()
+		if _, err = api.PutImageScanningConfiguration(scanning); err != nil {
+			return nil, fmt.Errorf("update repository: %w", err)
+		}
+		d.logger.ExtraVerbosef("ecr.PutImageScanningConfiguration call took %s", time.Since(start))
+	}
+	name := fmt.Sprint(params["name"])
+
+	d.logger.Infof("update repository '%s' done", name)
+	return name, nil
+}
+
+func buildRepositoryUpdateInputs(params map[string]interface{}) (*PutLifecyclePolicyInput, *PutImageScanningConfigurationInput, error) {
+	if _, ok := params["name"]; !ok {
+		return nil, nil, errors.New("missing required params 'name'")
+	}
+	var lifecycle *PutLifecyclePolicyInput
+	var scanning *PutImageScanningConfigurationInput
+	if file, ok := params["lifecycle-policy-file"]; ok {
+		lifecycle = &PutLifecyclePolicyInput{}
+		if err := setFieldWithType(params["name"], lifecycle, "RepositoryName", awsstr); err != nil {
+			return nil, nil, err
+		}
+		if err := setFieldWithType(file, lifecycle, "LifecyclePolicyText", awsfiletostring); err != nil {
+			return nil, nil, err
+		}
+		if account, ok := params["account"]; ok {
+			if err := setFieldWithType(account, lifecycle, "RegistryId", awsstr); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	if scan, ok := params["scan-on-push"]; ok {
+		scanning = &PutImageScanningConfigurationInput{ImageScanningConfiguration: &ImageScanningConfiguration{}}
+		if err := setFieldWithType(params["name"], scanning, "RepositoryName", awsstr); err != nil {
+			return nil, nil, err
+		}
+		if err := setFieldWithType(scan, scanning, "ImageScanningConfiguration.ScanOnPush", awsbool); err != nil {
+			return nil, nil, err
+		}
+		if account, ok := params["account"]; ok {
+			if err := setFieldWithType(account, scanning, "RegistryId", awsstr); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	if lifecycle == nil && scanning == nil {
+		return nil, nil, errors.New("missing at least one of params 'lifecycle-policy-file' or 'scan-on-push'")
+	}
+	return lifecycle, scanning, nil
+}
+
 func (d *EcrDriver) Authenticate_Registry_DryRun(params map[string]interface{}) (interface{}, error) {
 	d.logger.Verbose("params dry run: authenticate registry ok")
 	return fakeDryRunId("registry"), nil
@@ -2274,7 +4475,7 @@ func (d *EcrDriver) Authenticate_Registry(params map[string]interface{}) (interf
 	start := time.Now()
 	output, err := d.GetAuthorizationToken(input)
 	if err != nil {
-		return nil, fmt.Errorf("authenticate registry: %s", err)
+		return nil, fmt.Errorf("authenticate registry: %w", err)
 	}
 	d.logger.ExtraVerbosef("ecr.GetAuthorizationToken call took %s", time.Since(start))
 	for _, auth := range output.AuthorizationData {
@@ -2307,7 +4508,7 @@ func (d *EcrDriver) Authenticate_Registry(params map[string]interface{}) (interf
 			if e, ok := err.(*exec.ExitError); ok {
 				return nil, fmt.Errorf("error running docker command: %s", e.Stderr)
 			}
-			return nil, fmt.Errorf("error running docker command: %s", err)
+			return nil, fmt.Errorf("error running docker command: %w", err)
 		}
 		if len(out) > 0 {
 			d.logger.Info(string(out))
@@ -2318,6 +4519,8 @@ func (d *EcrDriver) Authenticate_Registry(params map[string]interface{}) (interf
 	return nil, nil
 }
 
+// Update_Distribution enables or disables a distribution, adds or updates one of its origins
+// and updates its default cache behavior or the one of a given path pattern
 func (d *CloudfrontDriver) Update_Distribution(params map[string]interface{}) (interface{}, error) {
 	distribOutput, err := d.GetDistribution(&cloudfront.GetDistributionInput{
 		Id: aws.String(fmt.Sprint(params["id"])),
@@ -2327,7 +4530,7 @@ func (d *CloudfrontDriver) Update_Distribution(params map[string]interface{}) (i
 	}
 	distriToUpdate := distribOutput.Distribution
 	etag := distribOutput.ETag
-	if enabled := aws.BoolValue(distriToUpdate.DistributionConfig.Enabled); fmt.Sprint(params["enable"]) == fmt.Sprint(enabled) {
+	if enabled := aws.BoolValue(distriToUpdate.DistributionConfig.Enabled); !hasAnyParam(params, distributionConfigParams...) && fmt.Sprint(params["enable"]) == fmt.Sprint(enabled) {
 		d.logger.Infof("distribution '%s' is already enable=%t", params["id"], enabled)
 		return aws.StringValue(etag), nil
 	}
@@ -2339,16 +4542,15 @@ func (d *CloudfrontDriver) Update_Distribution(params map[string]interface{}) (i
 	if err != nil {
 		return nil, err
 	}
-	err = setFieldWithType(params["enable"], input, "DistributionConfig.Enabled", awsbool)
-	if err != nil {
-		return nil, err
+	if err = applyDistributionUpdates(input.DistributionConfig, params); err != nil {
+		return nil, fmt.Errorf("update distribution: %s", err)
 	}
 
 	start := time.Now()
 	var output *cloudfront.UpdateDistributionOutput
 	output, err = d.UpdateDistribution(input)
 	if err != nil {
-		return nil, fmt.Errorf("update distribution: %s", err)
+		return nil, fmt.Errorf("update distribution: %w", err)
 	}
 	d.logger.ExtraVerbosef("cloudfront.UpdateDistribution call took %s", time.Since(start))
 	id := aws.StringValue(output.ETag)
@@ -2357,6 +4559,180 @@ func (d *CloudfrontDriver) Update_Distribution(params map[string]interface{}) (i
 	return id, nil
 }
 
+var (
+	distributionOriginParams   = []string{"origin-id", "origin-domain", "origin-path"}
+	distributionBehaviorParams = []string{"target-origin", "https-behaviour", "min-ttl", "default-ttl", "max-ttl", "compress", "forward-queries", "forward-cookies"}
+	distributionConfigParams   = append(append([]string{"certificate"}, distributionOriginParams...), distributionBehaviorParams...)
+	distributionUpdateParams   = append([]string{"enable"}, distributionConfigParams...)
+)
+
+func hasAnyParam(params map[string]interface{}, names ...string) bool {
+	for _, n := range names {
+		if _, ok := params[n]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// applyDistributionUpdates modifies in place the config of a distribution according to the update params.
+// An origin is selected by 'origin-id' and created when it does not exist. A cache behavior is selected
+// by 'path-pattern' (the default cache behavior when absent or '*') and created when it does not exist.
+func applyDistributionUpdates(config *cloudfront.DistributionConfig, params map[string]interface{}) error {
+	if enable, ok := params["enable"]; ok {
+		if err := setFieldWithType(enable, config, "Enabled", awsbool); err != nil {
+			return err
+		}
+	}
+	if cert, ok := params["certificate"]; ok {
+		config.ViewerCertificate = &cloudfront.ViewerCertificate{}
+		if err := setFieldWithType(cert, config, "ViewerCertificate.ACMCertificateArn", awsstr); err != nil {
+			return err
+		}
+		if err := setFieldWithType("sni-only", config, "ViewerCertificate.SSLSupportMethod", awsstr); err != nil {
+			return err
+		}
+		if err := setFieldWithType("TLSv1.2_2018", config, "ViewerCertificate.MinimumProtocolVersion", awsstr); err != nil {
+			return err
+		}
+	}
+	if err := applyDistributionOriginUpdates(config, params); err != nil {
+		return err
+	}
+	return applyDistributionBehaviorUpdates(config, params)
+}
+
+func applyDistributionOriginUpdates(config *cloudfront.DistributionConfig, params map[string]interface{}) error {
+	if !hasAnyParam(params, distributionOriginParams...) {
+		return nil
+	}
+	if _, ok := params["origin-id"]; !ok {
+		return errors.New("missing 'origin-id' to select the origin to add or update")
+	}
+	if config.Origins == nil {
+		config.Origins = &cloudfront.Origins{}
+	}
+	originID := fmt.Sprint(params["origin-id"])
+	var origin *cloudfront.Origin
+	for _, o := range config.Origins.Items {
+		if aws.StringValue(o.Id) == originID {
+			origin = o
+		}
+	}
+	if origin == nil {
+		if _, ok := params["origin-domain"]; !ok {
+			return fmt.Errorf("missing 'origin-domain' to add origin '%s'", originID)
+		}
+		origin = &cloudfront.Origin{Id: aws.String(originID), OriginPath: aws.String("")}
+		config.Origins.Items = append(config.Origins.Items, origin)
+		config.Origins.Quantity = aws.Int64(int64(len(config.Origins.Items)))
+	}
+	if domain, ok := params["origin-domain"]; ok {
+		if err := setFieldWithType(domain, origin, "DomainName", awsstr); err != nil {
+			return err
+		}
+		if d := aws.StringValue(origin.DomainName); strings.HasSuffix(d, ".s3.amazonaws.com") {
+			origin.CustomOriginConfig = nil
+			if origin.S3OriginConfig == nil {
+				origin.S3OriginConfig = &cloudfront.S3OriginConfig{OriginAccessIdentity: aws.String("")}
+			}
+		} else {
+			origin.S3OriginConfig = nil
+			if origin.CustomOriginConfig == nil {
+				origin.CustomOriginConfig = &cloudfront.CustomOriginConfig{
+					HTTPPort:             aws.Int64(80),
+					HTTPSPort:            aws.Int64(443),
+					OriginProtocolPolicy: aws.String("match-viewer"),
+					OriginSslProtocols:   &cloudfront.OriginSslProtocols{Quantity: aws.Int64(1), Items: []*string{aws.String("TLSv1.2")}},
+				}
+			}
+		}
+	}
+	if path, ok := params["origin-path"]; ok {
+		if err := setFieldWithType(path, origin, "OriginPath", awsstr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyDistributionBehaviorUpdates(config *cloudfront.DistributionConfig, params map[string]interface{}) error {
+	if !hasAnyParam(params, distributionBehaviorParams...) {
+		return nil
+	}
+	var behavior interface{}
+	if pattern, ok := params["path-pattern"]; ok && fmt.Sprint(pattern) != "*" {
+		if config.CacheBehaviors == nil {
+			config.CacheBehaviors = &cloudfront.CacheBehaviors{}
+		}
+		for _, b := range config.CacheBehaviors.Items {
+			if aws.StringValue(b.PathPattern) == fmt.Sprint(pattern) {
+				behavior = b
+			}
+		}
+		if behavior == nil {
+			if _, ok := params["target-origin"]; !ok {
+				return fmt.Errorf("missing 'target-origin' to add cache behavior for path '%s'", pattern)
+			}
+			b := &cloudfront.CacheBehavior{
+				PathPattern: aws.String(fmt.Sprint(pattern)),
+				MinTTL:      aws.Int64(0),
+				ForwardedValues: &cloudfront.ForwardedValues{
+					Cookies:     &cloudfront.CookiePreference{Forward: aws.String("none")},
+					QueryString: aws.Bool(false),
+				},
+				TrustedSigners: &cloudfront.TrustedSigners{
+					Enabled:  aws.Bool(false),
+					Quantity: aws.Int64(0),
+				},
+				ViewerProtocolPolicy: aws.String("allow-all"),
+			}
+			config.CacheBehaviors.Items = append(config.CacheBehaviors.Items, b)
+			config.CacheBehaviors.Quantity = aws.Int64(int64(len(config.CacheBehaviors.Items)))
+			behavior = b
+		}
+	} else {
+		if config.DefaultCacheBehavior == nil {
+			return errors.New("distribution has no default cache behavior")
+		}
+		behavior = config.DefaultCacheBehavior
+	}
+
+	if target, ok := params["target-origin"]; ok {
+		var found bool
+		if config.Origins != nil {
+			for _, o := range config.Origins.Items {
+				found = found || aws.StringValue(o.Id) == fmt.Sprint(target)
+			}
+		}
+		if !found {
+			return fmt.Errorf("target origin '%s' is not an origin of the distribution", target)
+		}
+	}
+
+	fields := []struct {
+		param, field string
+		typ          int
+	}{
+		{"target-origin", "TargetOriginId", awsstr},
+		{"https-behaviour", "ViewerProtocolPolicy", awsstr},
+		{"min-ttl", "MinTTL", awsint64},
+		{"default-ttl", "DefaultTTL", awsint64},
+		{"max-ttl", "MaxTTL", awsint64},
+		{"compress", "Compress", awsbool},
+		{"forward-queries", "ForwardedValues.QueryString", awsbool},
+		{"forward-cookies", "ForwardedValues.Cookies.Forward", awsstr},
+	}
+	for _, f := range fields {
+		if v, ok := params[f.param]; ok {
+			if err := setFieldWithType(v, behavior, f.field, f.typ); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (d *CloudfrontDriver) Delete_Distribution_DryRun(params map[string]interface{}) (interface{}, error) {
 	if _, ok := params["id"]; !ok {
 		return nil, errors.New("delete distribution: missing required params 'id'")
@@ -2391,13 +4767,152 @@ func (d *CloudfrontDriver) Delete_Distribution(params map[string]interface{}) (i
 	var output *cloudfront.DeleteDistributionOutput
 	output, err = d.DeleteDistribution(input)
 	if err != nil {
-		return nil, fmt.Errorf("delete distribution: %s", err)
+		return nil, fmt.Errorf("delete distribution: %w", err)
 	}
 	d.logger.ExtraVerbosef("cloudfront.DeleteDistribution call took %s", time.Since(start))
 	d.logger.Info("delete distribution done")
 	return output, nil
 }
 
+func (d *LambdaDriver) Create_Stream_DryRun(params map[string]interface{}) (interface{}, error) {
+	if _, err := buildCreateStreamInput(params); err != nil {
+		return nil, fmt.Errorf("create stream: %s", err)
+	}
+
+	d.logger.Verbose("params dry run: create stream ok")
+	return fakeDryRunId(cloud.Stream), nil
+}
+
+// Create_Stream creates a Kinesis data stream with the given number of shards,
+// or an on-demand stream (scaling its shards automatically) without shards param
+func (d *LambdaDriver) Create_Stream(params map[string]interface{}) (interface{}, error) {
+	input, err := buildCreateStreamInput(params)
+	if err != nil {
+		return nil, fmt.Errorf("create stream: %s", err)
+	}
+	api, ok := Streams(d.LambdaAPI)
+	if !ok {
+		return nil, errors.New("create stream: kinesis streams not supported by the lambda client")
+	}
+
+	start := time.Now()
+	if _, err = api.CreateStream(input); err != nil {
+		return nil, fmt.Errorf("create stream: %w", err)
+	}
+	d.logger.ExtraVerbosef("kinesis.CreateStream call took %s", time.Since(start))
+
+	output, err := api.DescribeStreamSummary(&DescribeStreamSummaryInput{StreamName: input.StreamName})
+	if err != nil {
+		return nil, fmt.Errorf("create stream: %w", err)
+	}
+	id := aws.StringValue(output.StreamDescriptionSummary.StreamARN)
+
+	d.logger.Infof("create stream '%s' done", id)
+	return id, nil
+}
+
+func buildCreateStreamInput(params map[string]interface{}) (*CreateStreamInput, error) {
+	if _, ok := params["name"]; !ok {
+		return nil, errors.New("missing required params 'name'")
+	}
+	input := &CreateStreamInput{StreamName: aws.String(fmt.Sprint(params["name"]))}
+	if _, ok := params["shards"]; !ok {
+		input.StreamModeDetails = &StreamModeDetails{StreamMode: aws.String("ON_DEMAND")}
+		return input, nil
+	}
+	if err := setFieldWithType(params["shards"], input, "ShardCount", awsint64); err != nil {
+		return nil, err
+	}
+	if aws.Int64Value(input.ShardCount) < 1 {
+		return nil, fmt.Errorf("invalid shards %d: expect at least 1 shard", aws.Int64Value(input.ShardCount))
+	}
+	input.StreamModeDetails = &StreamModeDetails{StreamMode: aws.String("PROVISIONED")}
+	return input, nil
+}
+
+func (d *LambdaDriver) Update_Stream_DryRun(params map[string]interface{}) (interface{}, error) {
+	if _, err := buildUpdateShardCountInput(params); err != nil {
+		return nil, fmt.Errorf("update stream: %s", err)
+	}
+
+	d.logger.Verbose("params dry run: update stream ok")
+	return nil, nil
+}
+
+// Update_Stream scales a provisioned Kinesis stream to the given number of shards.
+// Kinesis splits or merges the shards uniformly and the stream stays UPDATING meanwhile
+func (d *LambdaDriver) Update_Stream(params map[string]interface{}) (interface{}, error) {
+	input, err := buildUpdateShardCountInput(params)
+	if err != nil {
+		return nil, fmt.Errorf("update stream: %s", err)
+	}
+	api, ok := Streams(d.LambdaAPI)
+	if !ok {
+		return nil, errors.New("update stream: kinesis streams not supported by the lambda client")
+	}
+
+	start := time.Now()
+	output, err := api.UpdateShardCount(input)
+	if err != nil {
+		return nil, fmt.Errorf("update stream: %w", err)
+	}
+	d.logger.ExtraVerbosef("kinesis.UpdateShardCount call took %s", time.Since(start))
+
+	d.logger.Infof("update stream '%s' from %d to %d shards done", aws.StringValue(input.StreamName), aws.Int64Value(output.CurrentShardCount), aws.Int64Value(output.TargetShardCount))
+	return nil, nil
+}
+
+func buildUpdateShardCountInput(params map[string]interface{}) (*UpdateShardCountInput, error) {
+	for _, required := range []string{"name", "shards"} {
+		if _, ok := params[required]; !ok {
+			return nil, fmt.Errorf("missing required params '%s'", required)
+		}
+	}
+	input := &UpdateShardCountInput{
+		StreamName:  aws.String(StreamNameFromARN(fmt.Sprint(params["name"]))),
+		ScalingType: aws.String("UNIFORM_SCALING"),
+	}
+	if err := setFieldWithType(params["shards"], input, "TargetShardCount", awsint64); err != nil {
+		return nil, err
+	}
+	if aws.Int64Value(input.TargetShardCount) < 1 {
+		return nil, fmt.Errorf("invalid shards %d: expect at least 1 shard", aws.Int64Value(input.TargetShardCount))
+	}
+	return input, nil
+}
+
+func (d *LambdaDriver) Delete_Stream_DryRun(params map[string]interface{}) (interface{}, error) {
+	if _, ok := params["name"]; !ok {
+		return nil, errors.New("delete stream: missing required params 'name'")
+	}
+
+	d.logger.Verbose("params dry run: delete stream ok")
+	return nil, nil
+}
+
+// Delete_Stream deletes a Kinesis stream. Streams with registered enhanced fan-out
+// consumers are only deleted, along with their consumers, when force is true
+func (d *LambdaDriver) Delete_Stream(params map[string]interface{}) (interface{}, error) {
+	api, ok := Streams(d.LambdaAPI)
+	if !ok {
+		return nil, errors.New("delete stream: kinesis streams not supported by the lambda client")
+	}
+	name := StreamNameFromARN(fmt.Sprint(params["name"]))
+	input := &DeleteStreamInput{StreamName: aws.String(name)}
+	if force, ok := params["force"]; ok {
+		input.EnforceConsumerDeletion = aws.Bool(fmt.Sprint(force) == "true")
+	}
+
+	start := time.Now()
+	if _, err := api.DeleteStream(input); err != nil {
+		return nil, fmt.Errorf("delete stream: %w", err)
+	}
+	d.logger.ExtraVerbosef("kinesis.DeleteStream call took %s", time.Since(start))
+
+	d.logger.Infof("delete stream '%s' done", name)
+	return nil, nil
+}
+
 func fakeDryRunId(entity string) string {
 	suffix := rand.Intn(1e6)
 	switch entity {
@@ -2413,6 +4928,12 @@ func fakeDryRunId(entity string) string {
 		return fmt.Sprintf("sg-%d", suffix)
 	case cloud.InternetGateway:
 		return fmt.Sprintf("igw-%d", suffix)
+	case cloud.DhcpOptions:
+		return fmt.Sprintf("dopt-%d", suffix)
+	case cloud.NetworkAcl:
+		return fmt.Sprintf("acl-%d", suffix)
+	case cloud.PrefixList:
+		return fmt.Sprintf("pl-%d", suffix)
 	default:
 		return fmt.Sprintf("dryrunid-%d", suffix)
 	}
@@ -17,15 +17,20 @@ limitations under the License.
 package awsdriver
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/sns/snsiface"
 	"github.com/aws/aws-sdk-go/service/sqs/sqsiface"
@@ -126,6 +131,9 @@ func TestDriver(t *testing.T) {
 			if got, want := aws.Int64Value(input.MaxCount), int64(countInt); got != want {
 				t.Fatalf("got %d, want %d", got, want)
 			}
+			if got, want := len(aws.StringValue(input.ClientToken)), 32; got != want {
+				t.Fatalf("got %d, want %d", got, want)
+			}
 			return nil
 		}
 
@@ -154,6 +162,354 @@ func TestDriver(t *testing.T) {
 			t.Fatalf("got %t, want %t", got, want)
 		}
 	})
+
+	t.Run("Start instances by batches", func(t *testing.T) {
+		var batches [][]string
+		awsMock.verifyStartInstancesInput = func(input *ec2.StartInstancesInput) error {
+			batches = append(batches, aws.StringValueSlice(input.InstanceIds))
+			return nil
+		}
+
+		ids, err := driv.Start_Instance(map[string]interface{}{"id": []string{"i-1", "i-2", "i-3", "i-4", "i-5"}, "batch": 2, "delay": "1ms"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := ids, []string{"i-1", "i-2", "i-3", "i-4", "i-5"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		if got, want := batches, [][]string{{"i-1", "i-2"}, {"i-3", "i-4"}, {"i-5"}}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+
+		batches = nil
+		id, err := driv.Start_Instance(map[string]interface{}{"id": "i-1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := id, "i-1"; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		if got, want := batches, [][]string{{"i-1"}}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+
+		instanceStatePollFrequency = time.Millisecond
+		defer func() { instanceStatePollFrequency = 5 * time.Second }()
+		awsMock.instancesStates = map[string][]string{
+			"i-1": {"pending", "pending", "running"},
+			"i-2": {"pending", "running"},
+			"i-3": {"pending"},
+		}
+		if _, err = driv.Start_Instance(map[string]interface{}{"id": []string{"i-1", "i-2"}, "wait": true, "wait-timeout": "1s"}); err != nil {
+			t.Fatal(err)
+		}
+		_, err = driv.Start_Instance(map[string]interface{}{"id": []string{"i-2", "i-3"}, "wait": true, "wait-timeout": "50ms"})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		if got, want := err.Error(), "instances not running: i-3 (pending)"; !strings.HasSuffix(got, want) {
+			t.Fatalf("got %s, want suffix %s", got, want)
+		}
+
+		for _, params := range []map[string]interface{}{
+			{"id": "i-1", "wait": true, "wait-timeout": "forever"},
+			{"id": "i-1", "batch": 0},
+			{"id": "i-1", "batch": "many"},
+			{"id": "i-1", "batch": 2, "delay": "soon"},
+			{"id": "i-1", "delay": 30},
+		} {
+			if _, err := driv.Start_Instance(params); err == nil {
+				t.Fatalf("%v: expected error", params)
+			}
+		}
+	})
+
+	t.Run("Reboot instances", func(t *testing.T) {
+		instanceStatePollFrequency = time.Millisecond
+		defer func() { instanceStatePollFrequency = 5 * time.Second }()
+		awsMock.instancesStatuses = map[string][]string{
+			"i-1": {"initializing", "ok"},
+			"i-2": {"ok"},
+			"i-3": {"impaired"},
+		}
+
+		ids, err := driv.Reboot_Instance(map[string]interface{}{"id": []string{"i-1", "i-2"}, "wait": true, "wait-timeout": "1s"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := ids, []string{"i-1", "i-2"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		if got, want := awsMock.rebootedInstances, []string{"i-1", "i-2"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+
+		_, err = driv.Reboot_Instance(map[string]interface{}{"id": "i-3", "wait": true, "wait-timeout": "50ms"})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		if got, want := err.Error(), "instances status checks not ok: i-3 (running, instance status impaired, system status ok)"; !strings.HasSuffix(got, want) {
+			t.Fatalf("got %s, want suffix %s", got, want)
+		}
+	})
+
+	t.Run("Create securitygroup with rules", func(t *testing.T) {
+		SecurityGroupNameResolver = func(name, vpc string) string {
+			if name == "web-sg" && vpc == "vpc-1" {
+				return "sg-web"
+			}
+			return ""
+		}
+		defer func() { SecurityGroupNameResolver = nil }()
+
+		var ingress, egress []*ec2.IpPermission
+		awsMock.verifyAuthorizeIngressInput = func(input *ec2.AuthorizeSecurityGroupIngressInput) error {
+			ingress = input.IpPermissions
+			return nil
+		}
+		awsMock.verifyAuthorizeEgressInput = func(input *ec2.AuthorizeSecurityGroupEgressInput) error {
+			egress = input.IpPermissions
+			return nil
+		}
+		params := map[string]interface{}{"name": "db-sg", "vpc": "vpc-1", "description": "database",
+			"inbound-rules": []interface{}{"tcp:5432:web-sg", "tcp:22:10.0.0.0/16"}, "outbound-rules": "any:any:sg-backup"}
+		id, err := driv.Create_Securitygroup(params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := id, "sg-new"; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		expIngress := []*ec2.IpPermission{
+			{IpProtocol: aws.String("tcp"), FromPort: aws.Int64(5432), ToPort: aws.Int64(5432), UserIdGroupPairs: []*ec2.UserIdGroupPair{{GroupId: aws.String("sg-web")}}},
+			{IpProtocol: aws.String("tcp"), FromPort: aws.Int64(22), ToPort: aws.Int64(22), IpRanges: []*ec2.IpRange{{CidrIp: aws.String("10.0.0.0/16")}}},
+		}
+		if got, want := ingress, expIngress; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		expEgress := []*ec2.IpPermission{
+			{IpProtocol: aws.String("-1"), FromPort: aws.Int64(-1), ToPort: aws.Int64(-1), UserIdGroupPairs: []*ec2.UserIdGroupPair{{GroupId: aws.String("sg-backup")}}},
+		}
+		if got, want := egress, expEgress; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		if awsMock.deletedSecurityGroup != "" {
+			t.Fatalf("got %s deleted, want none", awsMock.deletedSecurityGroup)
+		}
+
+		params["inbound-rules"] = "tcp:5432:unknown-sg"
+		if _, err = driv.Create_Securitygroup(params); err == nil || !strings.Contains(err.Error(), "cannot resolve securitygroup 'unknown-sg'") {
+			t.Fatalf("got %v, want unresolved securitygroup error", err)
+		}
+
+		params["inbound-rules"] = "tcp:5432:web-sg"
+		awsMock.verifyAuthorizeEgressInput = func(input *ec2.AuthorizeSecurityGroupEgressInput) error {
+			return errors.New("InvalidPermission.Duplicate")
+		}
+		if _, err = driv.Create_Securitygroup(params); err == nil {
+			t.Fatal("expected error")
+		}
+		if got, want := awsMock.deletedSecurityGroup, "sg-new"; got != want {
+			t.Fatalf("got %s, want %s rolled back", got, want)
+		}
+
+		params["inbound-rules"] = "tcp:5432"
+		if _, err = driv.Create_Securitygroup_DryRun(params); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("Create dhcpoptions", func(t *testing.T) {
+		awsMock.verifyDhcpOptionsInput = func(input *ec2.CreateDhcpOptionsInput) error {
+			expected := []*ec2.NewDhcpConfiguration{
+				{Key: aws.String("domain-name"), Values: []*string{aws.String("corp.local")}},
+				{Key: aws.String("domain-name-servers"), Values: []*string{aws.String("10.0.0.2"), aws.String("10.0.0.3")}},
+			}
+			if got, want := input.DhcpConfigurations, expected; !reflect.DeepEqual(got, want) {
+				return fmt.Errorf("got %v, want %v", got, want)
+			}
+			return nil
+		}
+		id, err := driv.Create_Dhcpoptions(map[string]interface{}{"domain-name": "corp.local", "domain-name-servers": []string{"10.0.0.2", "10.0.0.3"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := id, "dopt-new"; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+
+		if _, err = driv.Create_Dhcpoptions_DryRun(map[string]interface{}{}); err == nil {
+			t.Fatal("expected error with no dhcp configuration")
+		}
+	})
+
+	t.Run("Create and update prefixlist", func(t *testing.T) {
+		awsMock.verifyPrefixListInput = func(input *CreateManagedPrefixListInput) error {
+			expected := &CreateManagedPrefixListInput{PrefixListName: aws.String("corp"), MaxEntries: aws.Int64(5), AddressFamily: aws.String("IPv4"),
+				Entries: []*AddPrefixListEntry{{Cidr: aws.String("10.0.0.0/16")}, {Cidr: aws.String("10.1.0.0/16")}},
+			}
+			if got, want := input, expected; !reflect.DeepEqual(got, want) {
+				return fmt.Errorf("got %#v, want %#v", got, want)
+			}
+			return nil
+		}
+		id, err := driv.Create_Prefixlist(map[string]interface{}{"name": "corp", "max-entries": 5, "cidrs": []string{"10.0.0.0/16", "10.1.0.0/16"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := id, "pl-new"; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		if _, err = driv.Create_Prefixlist_DryRun(map[string]interface{}{"name": "corp", "max-entries": 1, "cidrs": []string{"10.0.0.0/16", "10.1.0.0/16"}}); err == nil {
+			t.Fatal("expected error with more cidrs than max entries")
+		}
+
+		awsMock.prefixListVersion = 3
+		awsMock.verifyModifyPrefixInput = func(input *ModifyManagedPrefixListInput) error {
+			expected := &ModifyManagedPrefixListInput{PrefixListId: aws.String("pl-1"), CurrentVersion: aws.Int64(3),
+				AddEntries:    []*AddPrefixListEntry{{Cidr: aws.String("10.2.0.0/16")}},
+				RemoveEntries: []*RemovePrefixListEntry{{Cidr: aws.String("10.0.0.0/16")}},
+			}
+			if got, want := input, expected; !reflect.DeepEqual(got, want) {
+				return fmt.Errorf("got %#v, want %#v", got, want)
+			}
+			return nil
+		}
+		if _, err = driv.Update_Prefixlist(map[string]interface{}{"id": "pl-1", "add-cidrs": "10.2.0.0/16", "remove-cidrs": "10.0.0.0/16"}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = driv.Update_Prefixlist_DryRun(map[string]interface{}{"id": "pl-1"}); err == nil {
+			t.Fatal("expected error with nothing to update")
+		}
+	})
+
+	t.Run("Create, update and delete fleets", func(t *testing.T) {
+		awsMock.verifySpotFleetInput = func(input *ec2.RequestSpotFleetInput) error {
+			conf := input.SpotFleetRequestConfig
+			if got, want := aws.Int64Value(conf.TargetCapacity), int64(4); got != want {
+				return fmt.Errorf("got %d, want %d", got, want)
+			}
+			if got, want := aws.StringValue(conf.IamFleetRole), "arn:role"; got != want {
+				return fmt.Errorf("got %s, want %s", got, want)
+			}
+			if aws.StringValue(conf.ClientToken) == "" {
+				return errors.New("expected client token")
+			}
+			expected := []*ec2.SpotFleetLaunchSpecification{{ImageId: aws.String("ami-1"), InstanceType: aws.String("m5.large"), SubnetId: aws.String("sub-1"), SecurityGroups: []*ec2.GroupIdentifier{{GroupId: aws.String("sg-1")}}}}
+			if got, want := conf.LaunchSpecifications, expected; !reflect.DeepEqual(got, want) {
+				return fmt.Errorf("got %#v, want %#v", got, want)
+			}
+			return nil
+		}
+		id, err := driv.Create_Fleet(map[string]interface{}{"target-capacity": 4, "image": "ami-1", "type": "m5.large", "subnet": "sub-1", "securitygroup": "sg-1", "role": "arn:role", "spot-price": "0.1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := id, "sfr-new"; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		if _, err = driv.Create_Fleet_DryRun(map[string]interface{}{"target-capacity": 4, "image": "ami-1", "type": "m5.large", "role": "arn:role", "spot-price": "0.1", "on-demand-capacity": 1}); err == nil {
+			t.Fatal("expected error with on-demand capacity in a spot fleet")
+		}
+
+		awsMock.verifyFleetInput = func(input *CreateFleetInput) error {
+			expected := &CreateFleetInput{
+				ClientToken: input.ClientToken,
+				LaunchTemplateConfigs: []*FleetLaunchTemplateConfigRequest{{
+					LaunchTemplateSpecification: &FleetLaunchTemplateSpecificationRequest{LaunchTemplateId: aws.String("lt-123"), Version: aws.String("$Default")},
+					Overrides:                   []*FleetLaunchTemplateOverridesRequest{{InstanceType: aws.String("c5.large")}},
+				}},
+				TargetCapacitySpecification: &TargetCapacitySpecificationRequest{DefaultTargetCapacityType: aws.String("spot"), TotalTargetCapacity: aws.Int64(10), OnDemandTargetCapacity: aws.Int64(3), SpotTargetCapacity: aws.Int64(7)},
+				SpotOptions:                 &SpotOptionsRequest{AllocationStrategy: aws.String("diversified")},
+			}
+			if got, want := input, expected; !reflect.DeepEqual(got, want) {
+				return fmt.Errorf("got %#v, want %#v", got, want)
+			}
+			return nil
+		}
+		id, err = driv.Create_Fleet(map[string]interface{}{"target-capacity": 10, "on-demand-capacity": 3, "launch-template": "lt-123", "type": "c5.large", "allocation-strategy": "diversified"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := id, "fleet-new"; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		if _, err = driv.Create_Fleet_DryRun(map[string]interface{}{"target-capacity": 2, "on-demand-capacity": 3, "launch-template": "my-template"}); err == nil {
+			t.Fatal("expected error with on-demand capacity exceeding target capacity")
+		}
+
+		if _, err = driv.Update_Fleet(map[string]interface{}{"id": "sfr-1", "target-capacity": 2}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = driv.Update_Fleet(map[string]interface{}{"id": "fleet-1", "target-capacity": 5, "on-demand-capacity": 1}); err != nil {
+			t.Fatal(err)
+		}
+		expectedModified := []interface{}{
+			&ec2.ModifySpotFleetRequestInput{SpotFleetRequestId: aws.String("sfr-1"), TargetCapacity: aws.Int64(2)},
+			&ModifyFleetInput{FleetId: aws.String("fleet-1"), TargetCapacitySpecification: &TargetCapacitySpecificationRequest{TotalTargetCapacity: aws.Int64(5), OnDemandTargetCapacity: aws.Int64(1)}},
+		}
+		if got, want := awsMock.modifiedFleets, expectedModified; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %#v, want %#v", got, want)
+		}
+		if _, err = driv.Update_Fleet_DryRun(map[string]interface{}{"id": "sfr-1", "on-demand-capacity": 1}); err == nil {
+			t.Fatal("expected error with on-demand capacity in a spot fleet")
+		}
+
+		if _, err = driv.Delete_Fleet(map[string]interface{}{"id": "sfr-1", "terminate-instances": true}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = driv.Delete_Fleet(map[string]interface{}{"id": "fleet-1"}); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := awsMock.deletedFleets, []string{"sfr-1:true", "fleet-1:false"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	})
+}
+
+func TestStreamsDrivers(t *testing.T) {
+	awsMock := &mockLambdaStreams{}
+	driv := NewLambdaDriver(awsMock).(*LambdaDriver)
+
+	id, err := driv.Create_Stream(map[string]interface{}{"name": "my-stream", "shards": 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := id, "arn:aws:kinesis:us-east-1:123456789012:stream/my-stream"; got != want {
+		t.Fatalf("got %v, want %s", got, want)
+	}
+	expected := &CreateStreamInput{StreamName: aws.String("my-stream"), ShardCount: aws.Int64(2), StreamModeDetails: &StreamModeDetails{StreamMode: aws.String("PROVISIONED")}}
+	if got, want := awsMock.created, expected; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if _, err = driv.Create_Stream(map[string]interface{}{"name": "on-demand"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := aws.StringValue(awsMock.created.StreamModeDetails.StreamMode), "ON_DEMAND"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if _, err = driv.Create_Stream_DryRun(map[string]interface{}{"name": "my-stream", "shards": 0}); err == nil {
+		t.Fatal("expected error with no shard")
+	}
+
+	if _, err = driv.Update_Stream(map[string]interface{}{"name": "arn:aws:kinesis:us-east-1:123456789012:stream/my-stream", "shards": 4}); err != nil {
+		t.Fatal(err)
+	}
+	expectedUpdate := &UpdateShardCountInput{StreamName: aws.String("my-stream"), TargetShardCount: aws.Int64(4), ScalingType: aws.String("UNIFORM_SCALING")}
+	if got, want := awsMock.updated, expectedUpdate; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if _, err = driv.Update_Stream_DryRun(map[string]interface{}{"name": "my-stream"}); err == nil {
+		t.Fatal("expected error with missing shards")
+	}
+
+	if _, err = driv.Delete_Stream(map[string]interface{}{"name": "my-stream", "force": true}); err != nil {
+		t.Fatal(err)
+	}
+	expectedDelete := &DeleteStreamInput{StreamName: aws.String("my-stream"), EnforceConsumerDeletion: aws.Bool(true)}
+	if got, want := awsMock.deleted, expectedDelete; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
 }
 
 func TestBuildIpPermissionsFromParams(t *testing.T) {
@@ -241,6 +597,47 @@ func TestBuildIpPermissionsFromParams(t *testing.T) {
 	}
 }
 
+func TestBuildNetworkAclEntryFromParams(t *testing.T) {
+	params := map[string]interface{}{"id": "acl-1", "number": 100, "action": "allow", "protocol": "tcp", "cidr": "10.0.0.0/16", "portrange": "1024-65535", "outbound": true}
+	expected := &ec2.CreateNetworkAclEntryInput{
+		NetworkAclId: aws.String("acl-1"),
+		RuleNumber:   aws.Int64(100),
+		RuleAction:   aws.String("allow"),
+		Egress:       aws.Bool(true),
+		Protocol:     aws.String("6"),
+		CidrBlock:    aws.String("10.0.0.0/16"),
+		PortRange:    &ec2.PortRange{From: aws.Int64(1024), To: aws.Int64(65535)},
+	}
+	input, err := buildNetworkAclEntryFromParams(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := input, expected; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+
+	params = map[string]interface{}{"id": "acl-1", "number": "32000", "action": "deny", "protocol": "any", "cidr": "::/0"}
+	expected = &ec2.CreateNetworkAclEntryInput{
+		NetworkAclId:  aws.String("acl-1"),
+		RuleNumber:    aws.Int64(32000),
+		RuleAction:    aws.String("deny"),
+		Egress:        aws.Bool(false),
+		Protocol:      aws.String("-1"),
+		Ipv6CidrBlock: aws.String("::/0"),
+	}
+	if input, err = buildNetworkAclEntryFromParams(params); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := input, expected; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+
+	params["action"] = "reject"
+	if _, err = buildNetworkAclEntryFromParams(params); err == nil {
+		t.Fatal("expected error with invalid action")
+	}
+}
+
 type mockIam struct {
 	iamiface.IAMAPI
 }
@@ -257,12 +654,60 @@ type mockSQS struct {
 	sqsiface.SQSAPI
 }
 
+type mockLambdaStreams struct {
+	lambdaiface.LambdaAPI
+	StreamsAPI
+	created *CreateStreamInput
+	updated *UpdateShardCountInput
+	deleted *DeleteStreamInput
+}
+
+func (m *mockLambdaStreams) CreateStream(input *CreateStreamInput) (*CreateStreamOutput, error) {
+	m.created = input
+	return &CreateStreamOutput{}, nil
+}
+
+func (m *mockLambdaStreams) DescribeStreamSummary(input *DescribeStreamSummaryInput) (*DescribeStreamSummaryOutput, error) {
+	arn := "arn:aws:kinesis:us-east-1:123456789012:stream/" + aws.StringValue(input.StreamName)
+	return &DescribeStreamSummaryOutput{StreamDescriptionSummary: &StreamDescriptionSummary{StreamName: input.StreamName, StreamARN: aws.String(arn)}}, nil
+}
+
+func (m *mockLambdaStreams) UpdateShardCount(input *UpdateShardCountInput) (*UpdateShardCountOutput, error) {
+	m.updated = input
+	return &UpdateShardCountOutput{StreamName: input.StreamName, CurrentShardCount: aws.Int64(2), TargetShardCount: input.TargetShardCount}, nil
+}
+
+func (m *mockLambdaStreams) DeleteStream(input *DeleteStreamInput) (*DeleteStreamOutput, error) {
+	m.deleted = input
+	return &DeleteStreamOutput{}, nil
+}
+
 type mockEc2 struct {
 	ec2iface.EC2API
 	verifyVpcInput      func(*ec2.CreateVpcInput) error
 	verifySubnetInput   func(*ec2.CreateSubnetInput) error
 	verifyInstanceInput func(*ec2.RunInstancesInput) error
 	verifyTagInput      func(*ec2.CreateTagsInput) error
+
+	verifyStartInstancesInput func(*ec2.StartInstancesInput) error
+	instancesStates           map[string][]string
+	rebootedInstances         []string
+	instancesStatuses         map[string][]string
+
+	verifyAuthorizeIngressInput func(*ec2.AuthorizeSecurityGroupIngressInput) error
+	verifyAuthorizeEgressInput  func(*ec2.AuthorizeSecurityGroupEgressInput) error
+	deletedSecurityGroup        string
+
+	verifyDhcpOptionsInput func(*ec2.CreateDhcpOptionsInput) error
+
+	prefixListVersion       int64
+	verifyPrefixListInput   func(*CreateManagedPrefixListInput) error
+	verifyModifyPrefixInput func(*ModifyManagedPrefixListInput) error
+
+	verifySpotFleetInput func(*ec2.RequestSpotFleetInput) error
+	verifyFleetInput     func(*CreateFleetInput) error
+	modifiedFleets       []interface{}
+	deletedFleets        []string
 }
 
 func (m *mockEc2) CreateVpc(input *ec2.CreateVpcInput) (*ec2.CreateVpcOutput, error) {
@@ -292,3 +737,257 @@ func (m *mockEc2) CreateTags(input *ec2.CreateTagsInput) (*ec2.CreateTagsOutput,
 	}
 	return &ec2.CreateTagsOutput{}, nil
 }
+
+func (m *mockEc2) DescribeInstances(input *ec2.DescribeInstancesInput) (*ec2.DescribeInstancesOutput, error) {
+	out := &ec2.DescribeInstancesOutput{}
+	for _, id := range aws.StringValueSlice(input.InstanceIds) {
+		state := m.instancesStates[id]
+		if len(state) > 1 {
+			m.instancesStates[id] = state[1:]
+		}
+		out.Reservations = append(out.Reservations, &ec2.Reservation{Instances: []*ec2.Instance{
+			{InstanceId: aws.String(id), State: &ec2.InstanceState{Name: aws.String(state[0])}},
+		}})
+	}
+	return out, nil
+}
+
+func (m *mockEc2) StartInstances(input *ec2.StartInstancesInput) (*ec2.StartInstancesOutput, error) {
+	if err := m.verifyStartInstancesInput(input); err != nil {
+		return nil, err
+	}
+	return &ec2.StartInstancesOutput{}, nil
+}
+
+func (m *mockEc2) RebootInstances(input *ec2.RebootInstancesInput) (*ec2.RebootInstancesOutput, error) {
+	m.rebootedInstances = append(m.rebootedInstances, aws.StringValueSlice(input.InstanceIds)...)
+	return &ec2.RebootInstancesOutput{}, nil
+}
+
+func (m *mockEc2) DescribeInstanceStatus(input *ec2.DescribeInstanceStatusInput) (*ec2.DescribeInstanceStatusOutput, error) {
+	out := &ec2.DescribeInstanceStatusOutput{}
+	for _, id := range aws.StringValueSlice(input.InstanceIds) {
+		status := m.instancesStatuses[id]
+		if len(status) > 1 {
+			m.instancesStatuses[id] = status[1:]
+		}
+		out.InstanceStatuses = append(out.InstanceStatuses, &ec2.InstanceStatus{
+			InstanceId:     aws.String(id),
+			InstanceState:  &ec2.InstanceState{Name: aws.String("running")},
+			InstanceStatus: &ec2.InstanceStatusSummary{Status: aws.String(status[0])},
+			SystemStatus:   &ec2.InstanceStatusSummary{Status: aws.String("ok")},
+		})
+	}
+	return out, nil
+}
+
+func (m *mockEc2) CreateSecurityGroup(input *ec2.CreateSecurityGroupInput) (*ec2.CreateSecurityGroupOutput, error) {
+	return &ec2.CreateSecurityGroupOutput{GroupId: aws.String("sg-new")}, nil
+}
+
+func (m *mockEc2) CreateDhcpOptions(input *ec2.CreateDhcpOptionsInput) (*ec2.CreateDhcpOptionsOutput, error) {
+	if err := m.verifyDhcpOptionsInput(input); err != nil {
+		return nil, err
+	}
+	return &ec2.CreateDhcpOptionsOutput{DhcpOptions: &ec2.DhcpOptions{DhcpOptionsId: aws.String("dopt-new")}}, nil
+}
+
+func (m *mockEc2) AuthorizeSecurityGroupIngress(input *ec2.AuthorizeSecurityGroupIngressInput) (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
+	if err := m.verifyAuthorizeIngressInput(input); err != nil {
+		return nil, err
+	}
+	return &ec2.AuthorizeSecurityGroupIngressOutput{}, nil
+}
+
+func (m *mockEc2) AuthorizeSecurityGroupEgress(input *ec2.AuthorizeSecurityGroupEgressInput) (*ec2.AuthorizeSecurityGroupEgressOutput, error) {
+	if err := m.verifyAuthorizeEgressInput(input); err != nil {
+		return nil, err
+	}
+	return &ec2.AuthorizeSecurityGroupEgressOutput{}, nil
+}
+
+func (m *mockEc2) DeleteSecurityGroup(input *ec2.DeleteSecurityGroupInput) (*ec2.DeleteSecurityGroupOutput, error) {
+	m.deletedSecurityGroup = aws.StringValue(input.GroupId)
+	return &ec2.DeleteSecurityGroupOutput{}, nil
+}
+
+func (m *mockEc2) DescribeManagedPrefixLists(input *DescribeManagedPrefixListsInput) (*DescribeManagedPrefixListsOutput, error) {
+	var lists []*ManagedPrefixList
+	for _, id := range input.PrefixListIds {
+		lists = append(lists, &ManagedPrefixList{PrefixListId: id, Version: aws.Int64(m.prefixListVersion)})
+	}
+	return &DescribeManagedPrefixListsOutput{PrefixLists: lists}, nil
+}
+
+func (m *mockEc2) GetManagedPrefixListEntries(input *GetManagedPrefixListEntriesInput) (*GetManagedPrefixListEntriesOutput, error) {
+	return &GetManagedPrefixListEntriesOutput{}, nil
+}
+
+func (m *mockEc2) CreateManagedPrefixList(input *CreateManagedPrefixListInput) (*CreateManagedPrefixListOutput, error) {
+	if m.verifyPrefixListInput != nil {
+		if err := m.verifyPrefixListInput(input); err != nil {
+			return nil, err
+		}
+	}
+	return &CreateManagedPrefixListOutput{PrefixList: &ManagedPrefixList{PrefixListId: aws.String("pl-new")}}, nil
+}
+
+func (m *mockEc2) ModifyManagedPrefixList(input *ModifyManagedPrefixListInput) (*ModifyManagedPrefixListOutput, error) {
+	if m.verifyModifyPrefixInput != nil {
+		if err := m.verifyModifyPrefixInput(input); err != nil {
+			return nil, err
+		}
+	}
+	return &ModifyManagedPrefixListOutput{}, nil
+}
+
+func (m *mockEc2) DeleteManagedPrefixList(input *DeleteManagedPrefixListInput) (*DeleteManagedPrefixListOutput, error) {
+	return &DeleteManagedPrefixListOutput{}, nil
+}
+
+func (m *mockEc2) RequestSpotFleet(input *ec2.RequestSpotFleetInput) (*ec2.RequestSpotFleetOutput, error) {
+	if err := m.verifySpotFleetInput(input); err != nil {
+		return nil, err
+	}
+	return &ec2.RequestSpotFleetOutput{SpotFleetRequestId: aws.String("sfr-new")}, nil
+}
+
+func (m *mockEc2) ModifySpotFleetRequest(input *ec2.ModifySpotFleetRequestInput) (*ec2.ModifySpotFleetRequestOutput, error) {
+	m.modifiedFleets = append(m.modifiedFleets, input)
+	return &ec2.ModifySpotFleetRequestOutput{}, nil
+}
+
+func (m *mockEc2) CancelSpotFleetRequests(input *ec2.CancelSpotFleetRequestsInput) (*ec2.CancelSpotFleetRequestsOutput, error) {
+	m.deletedFleets = append(m.deletedFleets, fmt.Sprintf("%s:%t", aws.StringValue(input.SpotFleetRequestIds[0]), aws.BoolValue(input.TerminateInstances)))
+	return &ec2.CancelSpotFleetRequestsOutput{}, nil
+}
+
+func (m *mockEc2) DescribeFleets(input *DescribeFleetsInput) (*DescribeFleetsOutput, error) {
+	return &DescribeFleetsOutput{}, nil
+}
+
+func (m *mockEc2) DescribeFleetInstances(input *DescribeFleetInstancesInput) (*DescribeFleetInstancesOutput, error) {
+	return &DescribeFleetInstancesOutput{}, nil
+}
+
+func (m *mockEc2) CreateFleet(input *CreateFleetInput) (*CreateFleetOutput, error) {
+	if err := m.verifyFleetInput(input); err != nil {
+		return nil, err
+	}
+	return &CreateFleetOutput{FleetId: aws.String("fleet-new")}, nil
+}
+
+func (m *mockEc2) ModifyFleet(input *ModifyFleetInput) (*ModifyFleetOutput, error) {
+	m.modifiedFleets = append(m.modifiedFleets, input)
+	return &ModifyFleetOutput{Return: aws.Bool(true)}, nil
+}
+
+func (m *mockEc2) DeleteFleets(input *DeleteFleetsInput) (*DeleteFleetsOutput, error) {
+	m.deletedFleets = append(m.deletedFleets, fmt.Sprintf("%s:%t", aws.StringValue(input.FleetIds[0]), aws.BoolValue(input.TerminateInstances)))
+	return &DeleteFleetsOutput{}, nil
+}
+
+func TestPrivateRouteTables(t *testing.T) {
+	route := func(cidr, gateway, nat string) *ec2.Route {
+		r := &ec2.Route{DestinationCidrBlock: aws.String(cidr)}
+		if gateway != "" {
+			r.GatewayId = aws.String(gateway)
+		}
+		if nat != "" {
+			r.NatGatewayId = aws.String(nat)
+		}
+		return r
+	}
+	tables := []*ec2.RouteTable{
+		{RouteTableId: aws.String("rtb_public"), Routes: []*ec2.Route{route("10.0.0.0/16", "local", ""), route("0.0.0.0/0", "igw-1234", "")}},
+		{RouteTableId: aws.String("rtb_private"), Routes: []*ec2.Route{route("10.0.0.0/16", "local", "")}},
+		{RouteTableId: aws.String("rtb_old_nat"), Routes: []*ec2.Route{route("10.0.0.0/16", "local", ""), route("0.0.0.0/0", "", "nat-1234")}},
+		{RouteTableId: aws.String("rtb_nat_subnet"), Routes: []*ec2.Route{route("10.0.0.0/16", "local", "")}, Associations: []*ec2.RouteTableAssociation{{SubnetId: aws.String("sub_nat")}}},
+	}
+
+	var ids []string
+	for _, table := range privateRouteTables(tables, "sub_nat") {
+		ids = append(ids, aws.StringValue(table.RouteTableId))
+	}
+	if got, want := ids, []string{"rtb_private", "rtb_old_nat"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := hasDefaultRoute(tables[1]), false; got != want {
+		t.Fatalf("got %t, want %t", got, want)
+	}
+	if got, want := hasDefaultRoute(tables[2]), true; got != want {
+		t.Fatalf("got %t, want %t", got, want)
+	}
+}
+
+func TestApplyDistributionUpdates(t *testing.T) {
+	newConfig := func() *cloudfront.DistributionConfig {
+		return &cloudfront.DistributionConfig{
+			Enabled: aws.Bool(true),
+			Origins: &cloudfront.Origins{Quantity: aws.Int64(1), Items: []*cloudfront.Origin{
+				{Id: aws.String("orig_1"), DomainName: aws.String("mybucket.s3.amazonaws.com"), S3OriginConfig: &cloudfront.S3OriginConfig{OriginAccessIdentity: aws.String("")}},
+			}},
+			DefaultCacheBehavior: &cloudfront.DefaultCacheBehavior{TargetOriginId: aws.String("orig_1"), ViewerProtocolPolicy: aws.String("allow-all"), MinTTL: aws.Int64(0)},
+		}
+	}
+
+	t.Run("default behavior", func(t *testing.T) {
+		config := newConfig()
+		err := applyDistributionUpdates(config, map[string]interface{}{"https-behaviour": "redirect-to-https", "max-ttl": 3600, "compress": true, "enable": false})
+		if err != nil {
+			t.Fatal(err)
+		}
+		b := config.DefaultCacheBehavior
+		if got, want := aws.StringValue(b.ViewerProtocolPolicy), "redirect-to-https"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		if got, want := aws.Int64Value(b.MaxTTL), int64(3600); got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+		if !aws.BoolValue(b.Compress) || aws.BoolValue(config.Enabled) {
+			t.Fatalf("got compress %t, enabled %t", aws.BoolValue(b.Compress), aws.BoolValue(config.Enabled))
+		}
+	})
+
+	t.Run("new origin and behavior", func(t *testing.T) {
+		config := newConfig()
+		err := applyDistributionUpdates(config, map[string]interface{}{"origin-id": "alb", "origin-domain": "my-alb-1234.eu-west-1.elb.amazonaws.com", "path-pattern": "/api/*", "target-origin": "alb", "forward-queries": true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := aws.Int64Value(config.Origins.Quantity), int64(2); got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+		origin := config.Origins.Items[1]
+		if origin.CustomOriginConfig == nil || origin.S3OriginConfig != nil {
+			t.Fatalf("expected custom origin, got %v", origin)
+		}
+		if got, want := aws.Int64Value(config.CacheBehaviors.Quantity), int64(1); got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+		b := config.CacheBehaviors.Items[0]
+		if got, want := aws.StringValue(b.PathPattern)+" "+aws.StringValue(b.TargetOriginId), "/api/* alb"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		if !aws.BoolValue(b.ForwardedValues.QueryString) {
+			t.Fatal("expected query strings to be forwarded")
+		}
+		if got, want := aws.StringValue(config.DefaultCacheBehavior.TargetOriginId), "orig_1"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		tcases := []map[string]interface{}{
+			{"origin-path": "/static"},
+			{"origin-id": "new"},
+			{"path-pattern": "/api/*", "compress": true},
+			{"target-origin": "unknown"},
+		}
+		for i, params := range tcases {
+			if err := applyDistributionUpdates(newConfig(), params); err == nil {
+				t.Fatalf("%d: expected error for %v", i+1, params)
+			}
+		}
+	})
+}
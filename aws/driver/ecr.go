@@ -0,0 +1,204 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsdriver
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/aws/aws-sdk-go/service/ecr/ecriface"
+)
+
+// ContainerRegistryAPI reads and edits the image scanning and lifecycle settings of ECR repositories,
+// and describes their images with their scan findings.
+// The vendored ECR SDK predates these features: the calls are sent through the ECR client
+// of the SDK with the request and response shapes below
+type ContainerRegistryAPI interface {
+	DescribeRepositoryDetails(*DescribeRepositoryDetailsInput) (*DescribeRepositoryDetailsOutput, error)
+	DescribeContainerImages(*DescribeContainerImagesInput) (*DescribeContainerImagesOutput, error)
+	GetLifecyclePolicy(*GetLifecyclePolicyInput) (*GetLifecyclePolicyOutput, error)
+	PutLifecyclePolicy(*PutLifecyclePolicyInput) (*PutLifecyclePolicyOutput, error)
+	PutImageScanningConfiguration(*PutImageScanningConfigurationInput) (*PutImageScanningConfigurationOutput, error)
+}
+
+// ContainerRegistry returns the extended API of the ECR client,
+// or false if the client can not send these calls (ex: mocks)
+func ContainerRegistry(api ecriface.ECRAPI) (ContainerRegistryAPI, bool) {
+	switch c := api.(type) {
+	case ContainerRegistryAPI:
+		return c, true
+	case *ecr.ECR:
+		return &ecrContainerRegistry{c}, true
+	}
+	return nil, false
+}
+
+const ErrCodeLifecyclePolicyNotFoundException = "LifecyclePolicyNotFoundException"
+
+type ecrContainerRegistry struct {
+	*ecr.ECR
+}
+
+func (c *ecrContainerRegistry) send(name string, input, output interface{}) error {
+	op := &request.Operation{Name: name, HTTPMethod: "POST", HTTPPath: "/"}
+	return c.NewRequest(op, input, output).Send()
+}
+
+func (c *ecrContainerRegistry) DescribeRepositoryDetails(input *DescribeRepositoryDetailsInput) (*DescribeRepositoryDetailsOutput, error) {
+	output := &DescribeRepositoryDetailsOutput{}
+	return output, c.send("DescribeRepositories", input, output)
+}
+
+func (c *ecrContainerRegistry) DescribeContainerImages(input *DescribeContainerImagesInput) (*DescribeContainerImagesOutput, error) {
+	output := &DescribeContainerImagesOutput{}
+	return output, c.send("DescribeImages", input, output)
+}
+
+func (c *ecrContainerRegistry) GetLifecyclePolicy(input *GetLifecyclePolicyInput) (*GetLifecyclePolicyOutput, error) {
+	output := &GetLifecyclePolicyOutput{}
+	return output, c.send("GetLifecyclePolicy", input, output)
+}
+
+func (c *ecrContainerRegistry) PutLifecyclePolicy(input *PutLifecyclePolicyInput) (*PutLifecyclePolicyOutput, error) {
+	output := &PutLifecyclePolicyOutput{}
+	return output, c.send("PutLifecyclePolicy", input, output)
+}
+
+func (c *ecrContainerRegistry) PutImageScanningConfiguration(input *PutImageScanningConfigurationInput) (*PutImageScanningConfigurationOutput, error) {
+	output := &PutImageScanningConfigurationOutput{}
+	return output, c.send("PutImageScanningConfiguration", input, output)
+}
+
+type ImageScanningConfiguration struct {
+	_ struct{} `type:"structure"`
+
+	ScanOnPush *bool `locationName:"scanOnPush" type:"boolean"`
+}
+
+type RepositoryDetail struct {
+	_ struct{} `type:"structure"`
+
+	ImageScanningConfiguration *ImageScanningConfiguration `locationName:"imageScanningConfiguration" type:"structure"`
+	RegistryId                 *string                     `locationName:"registryId" type:"string"`
+	RepositoryArn              *string                     `locationName:"repositoryArn" type:"string"`
+	RepositoryName             *string                     `locationName:"repositoryName" type:"string"`
+}
+
+type DescribeRepositoryDetailsInput struct {
+	_ struct{} `type:"structure"`
+
+	NextToken       *string   `locationName:"nextToken" type:"string"`
+	RegistryId      *string   `locationName:"registryId" type:"string"`
+	RepositoryNames []*string `locationName:"repositoryNames" type:"list"`
+}
+
+type DescribeRepositoryDetailsOutput struct {
+	_ struct{} `type:"structure"`
+
+	NextToken    *string             `locationName:"nextToken" type:"string"`
+	Repositories []*RepositoryDetail `locationName:"repositories" type:"list"`
+}
+
+// ContainerImage is an image of an ECR repository with the summary of its last scan
+type ContainerImage struct {
+	_ struct{} `type:"structure"`
+
+	ImageDigest              *string                   `locationName:"imageDigest" type:"string"`
+	ImagePushedAt            *time.Time                `locationName:"imagePushedAt" type:"timestamp" timestampFormat:"unix"`
+	ImageScanFindingsSummary *ImageScanFindingsSummary `locationName:"imageScanFindingsSummary" type:"structure"`
+	ImageScanStatus          *ImageScanStatus          `locationName:"imageScanStatus" type:"structure"`
+	ImageSizeInBytes         *int64                    `locationName:"imageSizeInBytes" type:"long"`
+	ImageTags                []*string                 `locationName:"imageTags" type:"list"`
+	RegistryId               *string                   `locationName:"registryId" type:"string"`
+	RepositoryName           *string                   `locationName:"repositoryName" type:"string"`
+
+	// RepositoryArn is not returned by DescribeImages: fetchers fill it to relate the image to its repository
+	RepositoryArn *string `type:"string"`
+}
+
+type ImageScanFindingsSummary struct {
+	_ struct{} `type:"structure"`
+
+	FindingSeverityCounts map[string]*int64 `locationName:"findingSeverityCounts" type:"map"`
+}
+
+type ImageScanStatus struct {
+	_ struct{} `type:"structure"`
+
+	Description *string `locationName:"description" type:"string"`
+	Status      *string `locationName:"status" type:"string"`
+}
+
+type DescribeContainerImagesInput struct {
+	_ struct{} `type:"structure"`
+
+	NextToken      *string `locationName:"nextToken" type:"string"`
+	RegistryId     *string `locationName:"registryId" type:"string"`
+	RepositoryName *string `locationName:"repositoryName" type:"string" required:"true"`
+}
+
+type DescribeContainerImagesOutput struct {
+	_ struct{} `type:"structure"`
+
+	ImageDetails []*ContainerImage `locationName:"imageDetails" type:"list"`
+	NextToken    *string           `locationName:"nextToken" type:"string"`
+}
+
+type GetLifecyclePolicyInput struct {
+	_ struct{} `type:"structure"`
+
+	RegistryId     *string `locationName:"registryId" type:"string"`
+	RepositoryName *string `locationName:"repositoryName" type:"string" required:"true"`
+}
+
+type GetLifecyclePolicyOutput struct {
+	_ struct{} `type:"structure"`
+
+	LifecyclePolicyText *string `locationName:"lifecyclePolicyText" type:"string"`
+	RepositoryName      *string `locationName:"repositoryName" type:"string"`
+}
+
+type PutLifecyclePolicyInput struct {
+	_ struct{} `type:"structure"`
+
+	LifecyclePolicyText *string `locationName:"lifecyclePolicyText" type:"string" required:"true"`
+	RegistryId          *string `locationName:"registryId" type:"string"`
+	RepositoryName      *string `locationName:"repositoryName" type:"string" required:"true"`
+}
+
+type PutLifecyclePolicyOutput struct {
+	_ struct{} `type:"structure"`
+
+	LifecyclePolicyText *string `locationName:"lifecyclePolicyText" type:"string"`
+	RepositoryName      *string `locationName:"repositoryName" type:"string"`
+}
+
+type PutImageScanningConfigurationInput struct {
+	_ struct{} `type:"structure"`
+
+	ImageScanningConfiguration *ImageScanningConfiguration `locationName:"imageScanningConfiguration" type:"structure" required:"true"`
+	RegistryId                 *string                     `locationName:"registryId" type:"string"`
+	RepositoryName             *string                     `locationName:"repositoryName" type:"string" required:"true"`
+}
+
+type PutImageScanningConfigurationOutput struct {
+	_ struct{} `type:"structure"`
+
+	ImageScanningConfiguration *ImageScanningConfiguration `locationName:"imageScanningConfiguration" type:"structure"`
+	RepositoryName             *string                     `locationName:"repositoryName" type:"string"`
+}
@@ -0,0 +1,324 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsdriver
+
+import (
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+)
+
+// Kinds of fleets: Spot Fleet requests (sfr-...) and EC2 Fleets (fleet-...)
+const (
+	SpotFleet = "spot"
+	EC2Fleet  = "ec2"
+)
+
+// FleetKind returns the kind of a fleet given its id
+func FleetKind(id string) string {
+	if strings.HasPrefix(id, "sfr-") {
+		return SpotFleet
+	}
+	return EC2Fleet
+}
+
+// Fleet is a Spot Fleet request or an EC2 Fleet with its launched instances.
+// Both are described by their own calls: fetchers build it with NewSpotFleet or NewEC2Fleet
+type Fleet struct {
+	FleetId                *string
+	Kind                   *string
+	Type                   *string
+	State                  *string
+	ActivityStatus         *string
+	AllocationStrategy     *string
+	TargetCapacity         *int64
+	OnDemandTargetCapacity *int64
+	SpotTargetCapacity     *int64
+	FulfilledCapacity      *float64
+	SpotPrice              *string
+	IamFleetRole           *string
+	CreateTime             *time.Time
+	Instances              []*string
+}
+
+func NewSpotFleet(sfr *ec2.SpotFleetRequestConfig, instances []*string) *Fleet {
+	f := &Fleet{
+		FleetId:        sfr.SpotFleetRequestId,
+		Kind:           aws.String(SpotFleet),
+		State:          sfr.SpotFleetRequestState,
+		ActivityStatus: sfr.ActivityStatus,
+		CreateTime:     sfr.CreateTime,
+		Instances:      instances,
+	}
+	if conf := sfr.SpotFleetRequestConfig; conf != nil {
+		f.Type = conf.Type
+		f.AllocationStrategy = conf.AllocationStrategy
+		f.TargetCapacity = conf.TargetCapacity
+		f.SpotTargetCapacity = conf.TargetCapacity
+		f.FulfilledCapacity = conf.FulfilledCapacity
+		f.SpotPrice = conf.SpotPrice
+		f.IamFleetRole = conf.IamFleetRole
+	}
+	return f
+}
+
+func NewEC2Fleet(fleet *FleetData, instances []*string) *Fleet {
+	f := &Fleet{
+		FleetId:           fleet.FleetId,
+		Kind:              aws.String(EC2Fleet),
+		Type:              fleet.Type,
+		State:             fleet.FleetState,
+		ActivityStatus:    fleet.ActivityStatus,
+		FulfilledCapacity: fleet.FulfilledCapacity,
+		CreateTime:        fleet.CreateTime,
+		Instances:         instances,
+	}
+	if spec := fleet.TargetCapacitySpecification; spec != nil {
+		f.TargetCapacity = spec.TotalTargetCapacity
+		f.OnDemandTargetCapacity = spec.OnDemandTargetCapacity
+		f.SpotTargetCapacity = spec.SpotTargetCapacity
+	}
+	if fleet.SpotOptions != nil {
+		f.AllocationStrategy = fleet.SpotOptions.AllocationStrategy
+	}
+	return f
+}
+
+// FleetsAPI describes and edits Spot Fleet requests and EC2 Fleets.
+// The vendored EC2 SDK predates EC2 Fleet: its calls are sent through the EC2 client
+// of the SDK with the request and response shapes below
+type FleetsAPI interface {
+	DescribeSpotFleetRequests(*ec2.DescribeSpotFleetRequestsInput) (*ec2.DescribeSpotFleetRequestsOutput, error)
+	DescribeSpotFleetInstances(*ec2.DescribeSpotFleetInstancesInput) (*ec2.DescribeSpotFleetInstancesOutput, error)
+	DescribeFleets(*DescribeFleetsInput) (*DescribeFleetsOutput, error)
+	DescribeFleetInstances(*DescribeFleetInstancesInput) (*DescribeFleetInstancesOutput, error)
+	CreateFleet(*CreateFleetInput) (*CreateFleetOutput, error)
+	ModifyFleet(*ModifyFleetInput) (*ModifyFleetOutput, error)
+	DeleteFleets(*DeleteFleetsInput) (*DeleteFleetsOutput, error)
+}
+
+// Fleets returns the fleets API of the EC2 client,
+// or false if the client can not send these calls (ex: mocks)
+func Fleets(api ec2iface.EC2API) (FleetsAPI, bool) {
+	switch c := api.(type) {
+	case FleetsAPI:
+		return c, true
+	case *ec2.EC2:
+		return &ec2FleetAPI{c}, true
+	}
+	return nil, false
+}
+
+type ec2FleetAPI struct {
+	*ec2.EC2
+}
+
+func (c *ec2FleetAPI) send(name string, input, output interface{}) error {
+	op := &request.Operation{Name: name, HTTPMethod: "POST", HTTPPath: "/"}
+	return c.NewRequest(op, input, output).Send()
+}
+
+func (c *ec2FleetAPI) DescribeFleets(input *DescribeFleetsInput) (*DescribeFleetsOutput, error) {
+	output := &DescribeFleetsOutput{}
+	return output, c.send("DescribeFleets", input, output)
+}
+
+func (c *ec2FleetAPI) DescribeFleetInstances(input *DescribeFleetInstancesInput) (*DescribeFleetInstancesOutput, error) {
+	output := &DescribeFleetInstancesOutput{}
+	return output, c.send("DescribeFleetInstances", input, output)
+}
+
+func (c *ec2FleetAPI) CreateFleet(input *CreateFleetInput) (*CreateFleetOutput, error) {
+	output := &CreateFleetOutput{}
+	return output, c.send("CreateFleet", input, output)
+}
+
+func (c *ec2FleetAPI) ModifyFleet(input *ModifyFleetInput) (*ModifyFleetOutput, error) {
+	output := &ModifyFleetOutput{}
+	return output, c.send("ModifyFleet", input, output)
+}
+
+func (c *ec2FleetAPI) DeleteFleets(input *DeleteFleetsInput) (*DeleteFleetsOutput, error) {
+	output := &DeleteFleetsOutput{}
+	return output, c.send("DeleteFleets", input, output)
+}
+
+type DescribeFleetsInput struct {
+	_ struct{} `type:"structure"`
+
+	FleetIds   []*string `locationName:"FleetId" type:"list"`
+	MaxResults *int64    `type:"integer"`
+	NextToken  *string   `type:"string"`
+}
+
+type DescribeFleetsOutput struct {
+	_ struct{} `type:"structure"`
+
+	Fleets    []*FleetData `locationName:"fleetSet" locationNameList:"item" type:"list"`
+	NextToken *string      `locationName:"nextToken" type:"string"`
+}
+
+type FleetData struct {
+	_ struct{} `type:"structure"`
+
+	ActivityStatus              *string                      `locationName:"activityStatus" type:"string"`
+	CreateTime                  *time.Time                   `locationName:"createTime" type:"timestamp" timestampFormat:"iso8601"`
+	FleetId                     *string                      `locationName:"fleetId" type:"string"`
+	FleetState                  *string                      `locationName:"fleetState" type:"string"`
+	FulfilledCapacity           *float64                     `locationName:"fulfilledCapacity" type:"double"`
+	SpotOptions                 *SpotOptions                 `locationName:"spotOptions" type:"structure"`
+	TargetCapacitySpecification *TargetCapacitySpecification `locationName:"targetCapacitySpecification" type:"structure"`
+	Type                        *string                      `locationName:"type" type:"string"`
+}
+
+type SpotOptions struct {
+	_ struct{} `type:"structure"`
+
+	AllocationStrategy *string `locationName:"allocationStrategy" type:"string"`
+}
+
+type TargetCapacitySpecification struct {
+	_ struct{} `type:"structure"`
+
+	DefaultTargetCapacityType *string `locationName:"defaultTargetCapacityType" type:"string"`
+	OnDemandTargetCapacity    *int64  `locationName:"onDemandTargetCapacity" type:"integer"`
+	SpotTargetCapacity        *int64  `locationName:"spotTargetCapacity" type:"integer"`
+	TotalTargetCapacity       *int64  `locationName:"totalTargetCapacity" type:"integer"`
+}
+
+type DescribeFleetInstancesInput struct {
+	_ struct{} `type:"structure"`
+
+	FleetId    *string `type:"string" required:"true"`
+	MaxResults *int64  `type:"integer"`
+	NextToken  *string `type:"string"`
+}
+
+type DescribeFleetInstancesOutput struct {
+	_ struct{} `type:"structure"`
+
+	ActiveInstances []*ec2.ActiveInstance `locationName:"activeInstanceSet" locationNameList:"item" type:"list"`
+	FleetId         *string               `locationName:"fleetId" type:"string"`
+	NextToken       *string               `locationName:"nextToken" type:"string"`
+}
+
+type CreateFleetInput struct {
+	_ struct{} `type:"structure"`
+
+	ClientToken                 *string                             `type:"string"`
+	LaunchTemplateConfigs       []*FleetLaunchTemplateConfigRequest `locationNameList:"item" type:"list" required:"true"`
+	SpotOptions                 *SpotOptionsRequest                 `type:"structure"`
+	TargetCapacitySpecification *TargetCapacitySpecificationRequest `type:"structure" required:"true"`
+	Type                        *string                             `type:"string"`
+}
+
+type FleetLaunchTemplateConfigRequest struct {
+	_ struct{} `type:"structure"`
+
+	LaunchTemplateSpecification *FleetLaunchTemplateSpecificationRequest `type:"structure"`
+	Overrides                   []*FleetLaunchTemplateOverridesRequest   `locationName:"Overrides" locationNameList:"item" type:"list"`
+}
+
+type FleetLaunchTemplateSpecificationRequest struct {
+	_ struct{} `type:"structure"`
+
+	LaunchTemplateId   *string `type:"string"`
+	LaunchTemplateName *string `type:"string"`
+	Version            *string `type:"string"`
+}
+
+type FleetLaunchTemplateOverridesRequest struct {
+	_ struct{} `type:"structure"`
+
+	InstanceType *string `type:"string"`
+	SubnetId     *string `type:"string"`
+}
+
+type SpotOptionsRequest struct {
+	_ struct{} `type:"structure"`
+
+	AllocationStrategy *string `type:"string"`
+}
+
+type TargetCapacitySpecificationRequest struct {
+	_ struct{} `type:"structure"`
+
+	DefaultTargetCapacityType *string `type:"string"`
+	OnDemandTargetCapacity    *int64  `type:"integer"`
+	SpotTargetCapacity        *int64  `type:"integer"`
+	TotalTargetCapacity       *int64  `type:"integer" required:"true"`
+}
+
+type CreateFleetOutput struct {
+	_ struct{} `type:"structure"`
+
+	FleetId *string `locationName:"fleetId" type:"string"`
+}
+
+type ModifyFleetInput struct {
+	_ struct{} `type:"structure"`
+
+	ExcessCapacityTerminationPolicy *string                             `type:"string"`
+	FleetId                         *string                             `type:"string" required:"true"`
+	TargetCapacitySpecification     *TargetCapacitySpecificationRequest `type:"structure"`
+}
+
+type ModifyFleetOutput struct {
+	_ struct{} `type:"structure"`
+
+	Return *bool `locationName:"return" type:"boolean"`
+}
+
+type DeleteFleetsInput struct {
+	_ struct{} `type:"structure"`
+
+	FleetIds           []*string `locationName:"FleetId" type:"list" required:"true"`
+	TerminateInstances *bool     `type:"boolean" required:"true"`
+}
+
+type DeleteFleetsOutput struct {
+	_ struct{} `type:"structure"`
+
+	SuccessfulFleetDeletions   []*DeleteFleetSuccessItem `locationName:"successfulFleetDeletionSet" locationNameList:"item" type:"list"`
+	UnsuccessfulFleetDeletions []*DeleteFleetErrorItem   `locationName:"unsuccessfulFleetDeletionSet" locationNameList:"item" type:"list"`
+}
+
+type DeleteFleetSuccessItem struct {
+	_ struct{} `type:"structure"`
+
+	CurrentFleetState  *string `locationName:"currentFleetState" type:"string"`
+	FleetId            *string `locationName:"fleetId" type:"string"`
+	PreviousFleetState *string `locationName:"previousFleetState" type:"string"`
+}
+
+type DeleteFleetErrorItem struct {
+	_ struct{} `type:"structure"`
+
+	Error   *DeleteFleetError `locationName:"error" type:"structure"`
+	FleetId *string           `locationName:"fleetId" type:"string"`
+}
+
+type DeleteFleetError struct {
+	_ struct{} `type:"structure"`
+
+	Code    *string `locationName:"code" type:"string"`
+	Message *string `locationName:"message" type:"string"`
+}
@@ -70,7 +70,7 @@ func (d *Ec2Driver) Create_Vpc_DryRun(params map[string]interface{}) (interface{
 			if v, ok := params["name"]; ok {
 				_, err = d.Create_Tag_DryRun(map[string]interface{}{"key": "Name", "value": v, "resource": id})
 				if err != nil {
-					return nil, fmt.Errorf("dry run: create vpc: adding tags: %s", err)
+					return nil, fmt.Errorf("dry run: create vpc: adding tags: %w", err)
 				}
 			}
 			d.logger.Verbose("dry run: create vpc ok")
@@ -78,7 +78,7 @@ func (d *Ec2Driver) Create_Vpc_DryRun(params map[string]interface{}) (interface{
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: create vpc: %s", err)
+	return nil, fmt.Errorf("dry run: create vpc: %w", err)
 }
 
 // This function was auto generated
@@ -99,7 +99,7 @@ func (d *Ec2Driver) Create_Vpc(params map[string]interface{}) (interface{}, erro
 	output, err = d.CreateVpc(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create vpc: %s", err)
+		return nil, fmt.Errorf("create vpc: %w", err)
 	}
 	d.logger.ExtraVerbosef("ec2.CreateVpc call took %s", time.Since(start))
 	id := aws.StringValue(output.Vpc.VpcId)
@@ -107,7 +107,7 @@ func (d *Ec2Driver) Create_Vpc(params map[string]interface{}) (interface{}, erro
 	if v, ok := params["name"]; ok {
 		_, err = d.Create_Tag(map[string]interface{}{"key": "Name", "value": v, "resource": id})
 		if err != nil {
-			return nil, fmt.Errorf("create vpc: adding tags: %s", err)
+			return nil, fmt.Errorf("create vpc: adding tags: %w", err)
 		}
 	}
 
@@ -137,7 +137,7 @@ func (d *Ec2Driver) Delete_Vpc_DryRun(params map[string]interface{}) (interface{
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: delete vpc: %s", err)
+	return nil, fmt.Errorf("dry run: delete vpc: %w", err)
 }
 
 // This function was auto generated
@@ -156,7 +156,7 @@ func (d *Ec2Driver) Delete_Vpc(params map[string]interface{}) (interface{}, erro
 	output, err = d.DeleteVpc(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete vpc: %s", err)
+		return nil, fmt.Errorf("delete vpc: %w", err)
 	}
 	d.logger.ExtraVerbosef("ec2.DeleteVpc call took %s", time.Since(start))
 	d.logger.Info("delete vpc done")
@@ -196,7 +196,7 @@ func (d *Ec2Driver) Create_Subnet_DryRun(params map[string]interface{}) (interfa
 			if v, ok := params["name"]; ok {
 				_, err = d.Create_Tag_DryRun(map[string]interface{}{"key": "Name", "value": v, "resource": id})
 				if err != nil {
-					return nil, fmt.Errorf("dry run: create subnet: adding tags: %s", err)
+					return nil, fmt.Errorf("dry run: create subnet: adding tags: %w", err)
 				}
 			}
 			d.logger.Verbose("dry run: create subnet ok")
@@ -204,7 +204,7 @@ func (d *Ec2Driver) Create_Subnet_DryRun(params map[string]interface{}) (interfa
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: create subnet: %s", err)
+	return nil, fmt.Errorf("dry run: create subnet: %w", err)
 }
 
 // This function was auto generated
@@ -235,7 +235,7 @@ func (d *Ec2Driver) Create_Subnet(params map[string]interface{}) (interface{}, e
 	output, err = d.CreateSubnet(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create subnet: %s", err)
+		return nil, fmt.Errorf("create subnet: %w", err)
 	}
 	d.logger.ExtraVerbosef("ec2.CreateSubnet call took %s", time.Since(start))
 	id := aws.StringValue(output.Subnet.SubnetId)
@@ -243,7 +243,7 @@ func (d *Ec2Driver) Create_Subnet(params map[string]interface{}) (interface{}, e
 	if v, ok := params["name"]; ok {
 		_, err = d.Create_Tag(map[string]interface{}{"key": "Name", "value": v, "resource": id})
 		if err != nil {
-			return nil, fmt.Errorf("create subnet: adding tags: %s", err)
+			return nil, fmt.Errorf("create subnet: adding tags: %w", err)
 		}
 	}
 
@@ -285,7 +285,7 @@ func (d *Ec2Driver) Update_Subnet(params map[string]interface{}) (interface{}, e
 	output, err = d.ModifySubnetAttribute(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("update subnet: %s", err)
+		return nil, fmt.Errorf("update subnet: %w", err)
 	}
 	d.logger.ExtraVerbosef("ec2.ModifySubnetAttribute call took %s", time.Since(start))
 	d.logger.Info("update subnet done")
@@ -314,7 +314,7 @@ func (d *Ec2Driver) Delete_Subnet_DryRun(params map[string]interface{}) (interfa
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: delete subnet: %s", err)
+	return nil, fmt.Errorf("dry run: delete subnet: %w", err)
 }
 
 // This function was auto generated
@@ -333,7 +333,7 @@ func (d *Ec2Driver) Delete_Subnet(params map[string]interface{}) (interface{}, e
 	output, err = d.DeleteSubnet(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete subnet: %s", err)
+		return nil, fmt.Errorf("delete subnet: %w", err)
 	}
 	d.logger.ExtraVerbosef("ec2.DeleteSubnet call took %s", time.Since(start))
 	d.logger.Info("delete subnet done")
@@ -414,14 +414,14 @@ func (d *Ec2Driver) Create_Instance_DryRun(params map[string]interface{}) (inter
 			// Required param as tag
 			_, err = d.Create_Tag_DryRun(map[string]interface{}{"key": "Name", "value": params["name"], "resource": id})
 			if err != nil {
-				return nil, fmt.Errorf("dry run: create instance: adding tags: %s", err)
+				return nil, fmt.Errorf("dry run: create instance: adding tags: %w", err)
 			}
 			d.logger.Verbose("dry run: create instance ok")
 			return id, nil
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: create instance: %s", err)
+	return nil, fmt.Errorf("dry run: create instance: %w", err)
 }
 
 // This function was auto generated
@@ -489,19 +489,25 @@ func (d *Ec2Driver) Create_Instance(params map[string]interface{}) (interface{},
 		}
 	}
 
+	// Idempotency token
+	err = setIdempotencyToken(params, "create instance", input, "ClientToken")
+	if err != nil {
+		return nil, err
+	}
+
 	start := time.Now()
 	var output *ec2.Reservation
 	output, err = d.RunInstances(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create instance: %s", err)
+		return nil, fmt.Errorf("create instance: %w", err)
 	}
 	d.logger.ExtraVerbosef("ec2.RunInstances call took %s", time.Since(start))
 	id := aws.StringValue(output.Instances[0].InstanceId)
 	// Required param as tag
 	_, err = d.Create_Tag(map[string]interface{}{"key": "Name", "value": params["name"], "resource": id})
 	if err != nil {
-		return nil, fmt.Errorf("create instance: adding tags: %s", err)
+		return nil, fmt.Errorf("create instance: adding tags: %w", err)
 	}
 
 	d.logger.Infof("create instance '%s' done", id)
@@ -544,7 +550,7 @@ func (d *Ec2Driver) Update_Instance_DryRun(params map[string]interface{}) (inter
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: update instance: %s", err)
+	return nil, fmt.Errorf("dry run: update instance: %w", err)
 }
 
 // This function was auto generated
@@ -577,7 +583,7 @@ func (d *Ec2Driver) Update_Instance(params map[string]interface{}) (interface{},
 	output, err = d.ModifyInstanceAttribute(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("update instance: %s", err)
+		return nil, fmt.Errorf("update instance: %w", err)
 	}
 	d.logger.ExtraVerbosef("ec2.ModifyInstanceAttribute call took %s", time.Since(start))
 	d.logger.Info("update instance done")
@@ -606,7 +612,7 @@ func (d *Ec2Driver) Delete_Instance_DryRun(params map[string]interface{}) (inter
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: delete instance: %s", err)
+	return nil, fmt.Errorf("dry run: delete instance: %w", err)
 }
 
 // This function was auto generated
@@ -625,179 +631,13 @@ func (d *Ec2Driver) Delete_Instance(params map[string]interface{}) (interface{},
 	output, err = d.TerminateInstances(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete instance: %s", err)
+		return nil, fmt.Errorf("delete instance: %w", err)
 	}
 	d.logger.ExtraVerbosef("ec2.TerminateInstances call took %s", time.Since(start))
 	d.logger.Info("delete instance done")
 	return output, nil
 }
 
-// This function was auto generated
-func (d *Ec2Driver) Start_Instance_DryRun(params map[string]interface{}) (interface{}, error) {
-	input := &ec2.StartInstancesInput{}
-	input.DryRun = aws.Bool(true)
-	var err error
-
-	// Required params
-	err = setFieldWithType(params["id"], input, "InstanceIds", awsstringslice)
-	if err != nil {
-		return nil, err
-	}
-
-	_, err = d.StartInstances(input)
-	if awsErr, ok := err.(awserr.Error); ok {
-		switch code := awsErr.Code(); {
-		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			id := fakeDryRunId("instance")
-			d.logger.Verbose("dry run: start instance ok")
-			return id, nil
-		}
-	}
-
-	return nil, fmt.Errorf("dry run: start instance: %s", err)
-}
-
-// This function was auto generated
-func (d *Ec2Driver) Start_Instance(params map[string]interface{}) (interface{}, error) {
-	input := &ec2.StartInstancesInput{}
-	var err error
-
-	// Required params
-	err = setFieldWithType(params["id"], input, "InstanceIds", awsstringslice)
-	if err != nil {
-		return nil, err
-	}
-
-	start := time.Now()
-	var output *ec2.StartInstancesOutput
-	output, err = d.StartInstances(input)
-	output = output
-	if err != nil {
-		return nil, fmt.Errorf("start instance: %s", err)
-	}
-	d.logger.ExtraVerbosef("ec2.StartInstances call took %s", time.Since(start))
-	id := aws.StringValue(output.StartingInstances[0].InstanceId)
-
-	d.logger.Infof("start instance '%s' done", id)
-	return id, nil
-}
-
-// This function was auto generated
-func (d *Ec2Driver) Stop_Instance_DryRun(params map[string]interface{}) (interface{}, error) {
-	input := &ec2.StopInstancesInput{}
-	input.DryRun = aws.Bool(true)
-	var err error
-
-	// Required params
-	err = setFieldWithType(params["id"], input, "InstanceIds", awsstringslice)
-	if err != nil {
-		return nil, err
-	}
-
-	_, err = d.StopInstances(input)
-	if awsErr, ok := err.(awserr.Error); ok {
-		switch code := awsErr.Code(); {
-		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			id := fakeDryRunId("instance")
-			d.logger.Verbose("dry run: stop instance ok")
-			return id, nil
-		}
-	}
-
-	return nil, fmt.Errorf("dry run: stop instance: %s", err)
-}
-
-// This function was auto generated
-func (d *Ec2Driver) Stop_Instance(params map[string]interface{}) (interface{}, error) {
-	input := &ec2.StopInstancesInput{}
-	var err error
-
-	// Required params
-	err = setFieldWithType(params["id"], input, "InstanceIds", awsstringslice)
-	if err != nil {
-		return nil, err
-	}
-
-	start := time.Now()
-	var output *ec2.StopInstancesOutput
-	output, err = d.StopInstances(input)
-	output = output
-	if err != nil {
-		return nil, fmt.Errorf("stop instance: %s", err)
-	}
-	d.logger.ExtraVerbosef("ec2.StopInstances call took %s", time.Since(start))
-	id := aws.StringValue(output.StoppingInstances[0].InstanceId)
-
-	d.logger.Infof("stop instance '%s' done", id)
-	return id, nil
-}
-
-// This function was auto generated
-func (d *Ec2Driver) Create_Securitygroup_DryRun(params map[string]interface{}) (interface{}, error) {
-	input := &ec2.CreateSecurityGroupInput{}
-	input.DryRun = aws.Bool(true)
-	var err error
-
-	// Required params
-	err = setFieldWithType(params["name"], input, "GroupName", awsstr)
-	if err != nil {
-		return nil, err
-	}
-	err = setFieldWithType(params["vpc"], input, "VpcId", awsstr)
-	if err != nil {
-		return nil, err
-	}
-	err = setFieldWithType(params["description"], input, "Description", awsstr)
-	if err != nil {
-		return nil, err
-	}
-
-	_, err = d.CreateSecurityGroup(input)
-	if awsErr, ok := err.(awserr.Error); ok {
-		switch code := awsErr.Code(); {
-		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			id := fakeDryRunId("securitygroup")
-			d.logger.Verbose("dry run: create securitygroup ok")
-			return id, nil
-		}
-	}
-
-	return nil, fmt.Errorf("dry run: create securitygroup: %s", err)
-}
-
-// This function was auto generated
-func (d *Ec2Driver) Create_Securitygroup(params map[string]interface{}) (interface{}, error) {
-	input := &ec2.CreateSecurityGroupInput{}
-	var err error
-
-	// Required params
-	err = setFieldWithType(params["name"], input, "GroupName", awsstr)
-	if err != nil {
-		return nil, err
-	}
-	err = setFieldWithType(params["vpc"], input, "VpcId", awsstr)
-	if err != nil {
-		return nil, err
-	}
-	err = setFieldWithType(params["description"], input, "Description", awsstr)
-	if err != nil {
-		return nil, err
-	}
-
-	start := time.Now()
-	var output *ec2.CreateSecurityGroupOutput
-	output, err = d.CreateSecurityGroup(input)
-	output = output
-	if err != nil {
-		return nil, fmt.Errorf("create securitygroup: %s", err)
-	}
-	d.logger.ExtraVerbosef("ec2.CreateSecurityGroup call took %s", time.Since(start))
-	id := aws.StringValue(output.GroupId)
-
-	d.logger.Infof("create securitygroup '%s' done", id)
-	return id, nil
-}
-
 // This function was auto generated
 func (d *Ec2Driver) Delete_Securitygroup_DryRun(params map[string]interface{}) (interface{}, error) {
 	input := &ec2.DeleteSecurityGroupInput{}
@@ -820,7 +660,7 @@ func (d *Ec2Driver) Delete_Securitygroup_DryRun(params map[string]interface{}) (
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: delete securitygroup: %s", err)
+	return nil, fmt.Errorf("dry run: delete securitygroup: %w", err)
 }
 
 // This function was auto generated
@@ -839,107 +679,13 @@ func (d *Ec2Driver) Delete_Securitygroup(params map[string]interface{}) (interfa
 	output, err = d.DeleteSecurityGroup(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete securitygroup: %s", err)
+		return nil, fmt.Errorf("delete securitygroup: %w", err)
 	}
 	d.logger.ExtraVerbosef("ec2.DeleteSecurityGroup call took %s", time.Since(start))
 	d.logger.Info("delete securitygroup done")
 	return output, nil
 }
 
-// This function was auto generated
-func (d *Ec2Driver) Copy_Image_DryRun(params map[string]interface{}) (interface{}, error) {
-	input := &ec2.CopyImageInput{}
-	input.DryRun = aws.Bool(true)
-	var err error
-
-	// Required params
-	err = setFieldWithType(params["name"], input, "Name", awsstr)
-	if err != nil {
-		return nil, err
-	}
-	err = setFieldWithType(params["source-id"], input, "SourceImageId", awsstr)
-	if err != nil {
-		return nil, err
-	}
-	err = setFieldWithType(params["source-region"], input, "SourceRegion", awsstr)
-	if err != nil {
-		return nil, err
-	}
-
-	// Extra params
-	if _, ok := params["encrypted"]; ok {
-		err = setFieldWithType(params["encrypted"], input, "Encrypted", awsbool)
-		if err != nil {
-			return nil, err
-		}
-	}
-	if _, ok := params["description"]; ok {
-		err = setFieldWithType(params["description"], input, "Description", awsstr)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	_, err = d.CopyImage(input)
-	if awsErr, ok := err.(awserr.Error); ok {
-		switch code := awsErr.Code(); {
-		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			id := fakeDryRunId("image")
-			d.logger.Verbose("dry run: copy image ok")
-			return id, nil
-		}
-	}
-
-	return nil, fmt.Errorf("dry run: copy image: %s", err)
-}
-
-// This function was auto generated
-func (d *Ec2Driver) Copy_Image(params map[string]interface{}) (interface{}, error) {
-	input := &ec2.CopyImageInput{}
-	var err error
-
-	// Required params
-	err = setFieldWithType(params["name"], input, "Name", awsstr)
-	if err != nil {
-		return nil, err
-	}
-	err = setFieldWithType(params["source-id"], input, "SourceImageId", awsstr)
-	if err != nil {
-		return nil, err
-	}
-	err = setFieldWithType(params["source-region"], input, "SourceRegion", awsstr)
-	if err != nil {
-		return nil, err
-	}
-
-	// Extra params
-	if _, ok := params["encrypted"]; ok {
-		err = setFieldWithType(params["encrypted"], input, "Encrypted", awsbool)
-		if err != nil {
-			return nil, err
-		}
-	}
-	if _, ok := params["description"]; ok {
-		err = setFieldWithType(params["description"], input, "Description", awsstr)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	start := time.Now()
-	var output *ec2.CopyImageOutput
-	output, err = d.CopyImage(input)
-	output = output
-	if err != nil {
-		return nil, fmt.Errorf("copy image: %s", err)
-	}
-	d.logger.ExtraVerbosef("ec2.CopyImage call took %s", time.Since(start))
-	id := aws.StringValue(output.ImageId)
-
-	d.logger.Infof("copy image '%s' done", id)
-	return id, nil
-}
-
 // This function was auto generated
 func (d *Ec2Driver) Import_Image_DryRun(params map[string]interface{}) (interface{}, error) {
 	input := &ec2.ImportImageInput{}
@@ -1012,7 +758,7 @@ func (d *Ec2Driver) Import_Image_DryRun(params map[string]interface{}) (interfac
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: import image: %s", err)
+	return nil, fmt.Errorf("dry run: import image: %w", err)
 }
 
 // This function was auto generated
@@ -1081,7 +827,7 @@ func (d *Ec2Driver) Import_Image(params map[string]interface{}) (interface{}, er
 	output, err = d.ImportImage(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("import image: %s", err)
+		return nil, fmt.Errorf("import image: %w", err)
 	}
 	d.logger.ExtraVerbosef("ec2.ImportImage call took %s", time.Since(start))
 	id := aws.StringValue(output.ImportTaskId)
@@ -1116,7 +862,7 @@ func (d *Ec2Driver) Create_Volume_DryRun(params map[string]interface{}) (interfa
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: create volume: %s", err)
+	return nil, fmt.Errorf("dry run: create volume: %w", err)
 }
 
 // This function was auto generated
@@ -1139,7 +885,7 @@ func (d *Ec2Driver) Create_Volume(params map[string]interface{}) (interface{}, e
 	output, err = d.CreateVolume(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create volume: %s", err)
+		return nil, fmt.Errorf("create volume: %w", err)
 	}
 	d.logger.ExtraVerbosef("ec2.CreateVolume call took %s", time.Since(start))
 	id := aws.StringValue(output.VolumeId)
@@ -1170,7 +916,7 @@ func (d *Ec2Driver) Delete_Volume_DryRun(params map[string]interface{}) (interfa
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: delete volume: %s", err)
+	return nil, fmt.Errorf("dry run: delete volume: %w", err)
 }
 
 // This function was auto generated
@@ -1189,7 +935,7 @@ func (d *Ec2Driver) Delete_Volume(params map[string]interface{}) (interface{}, e
 	output, err = d.DeleteVolume(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete volume: %s", err)
+		return nil, fmt.Errorf("delete volume: %w", err)
 	}
 	d.logger.ExtraVerbosef("ec2.DeleteVolume call took %s", time.Since(start))
 	d.logger.Info("delete volume done")
@@ -1226,7 +972,7 @@ func (d *Ec2Driver) Attach_Volume_DryRun(params map[string]interface{}) (interfa
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: attach volume: %s", err)
+	return nil, fmt.Errorf("dry run: attach volume: %w", err)
 }
 
 // This function was auto generated
@@ -1253,7 +999,7 @@ func (d *Ec2Driver) Attach_Volume(params map[string]interface{}) (interface{}, e
 	output, err = d.AttachVolume(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("attach volume: %s", err)
+		return nil, fmt.Errorf("attach volume: %w", err)
 	}
 	d.logger.ExtraVerbosef("ec2.AttachVolume call took %s", time.Since(start))
 	id := aws.StringValue(output.VolumeId)
@@ -1300,7 +1046,7 @@ func (d *Ec2Driver) Detach_Volume_DryRun(params map[string]interface{}) (interfa
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: detach volume: %s", err)
+	return nil, fmt.Errorf("dry run: detach volume: %w", err)
 }
 
 // This function was auto generated
@@ -1335,7 +1081,7 @@ func (d *Ec2Driver) Detach_Volume(params map[string]interface{}) (interface{}, e
 	output, err = d.DetachVolume(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("detach volume: %s", err)
+		return nil, fmt.Errorf("detach volume: %w", err)
 	}
 	d.logger.ExtraVerbosef("ec2.DetachVolume call took %s", time.Since(start))
 	id := aws.StringValue(output.VolumeId)
@@ -1374,7 +1120,7 @@ func (d *Ec2Driver) Create_Snapshot_DryRun(params map[string]interface{}) (inter
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: create snapshot: %s", err)
+	return nil, fmt.Errorf("dry run: create snapshot: %w", err)
 }
 
 // This function was auto generated
@@ -1401,7 +1147,7 @@ func (d *Ec2Driver) Create_Snapshot(params map[string]interface{}) (interface{},
 	output, err = d.CreateSnapshot(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create snapshot: %s", err)
+		return nil, fmt.Errorf("create snapshot: %w", err)
 	}
 	d.logger.ExtraVerbosef("ec2.CreateSnapshot call took %s", time.Since(start))
 	id := aws.StringValue(output.SnapshotId)
@@ -1432,7 +1178,7 @@ func (d *Ec2Driver) Delete_Snapshot_DryRun(params map[string]interface{}) (inter
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: delete snapshot: %s", err)
+	return nil, fmt.Errorf("dry run: delete snapshot: %w", err)
 }
 
 // This function was auto generated
@@ -1451,7 +1197,7 @@ func (d *Ec2Driver) Delete_Snapshot(params map[string]interface{}) (interface{},
 	output, err = d.DeleteSnapshot(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete snapshot: %s", err)
+		return nil, fmt.Errorf("delete snapshot: %w", err)
 	}
 	d.logger.ExtraVerbosef("ec2.DeleteSnapshot call took %s", time.Since(start))
 	d.logger.Info("delete snapshot done")
@@ -1459,362 +1205,541 @@ func (d *Ec2Driver) Delete_Snapshot(params map[string]interface{}) (interface{},
 }
 
 // This function was auto generated
-func (d *Ec2Driver) Copy_Snapshot_DryRun(params map[string]interface{}) (interface{}, error) {
-	input := &ec2.CopySnapshotInput{}
+func (d *Ec2Driver) Create_Internetgateway_DryRun(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.CreateInternetGatewayInput{}
+	input.DryRun = aws.Bool(true)
+	var err error
+
+	_, err = d.CreateInternetGateway(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
+			id := fakeDryRunId("internetgateway")
+			d.logger.Verbose("dry run: create internetgateway ok")
+			return id, nil
+		}
+	}
+
+	return nil, fmt.Errorf("dry run: create internetgateway: %w", err)
+}
+
+// This function was auto generated
+func (d *Ec2Driver) Create_Internetgateway(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.CreateInternetGatewayInput{}
+	var err error
+
+	start := time.Now()
+	var output *ec2.CreateInternetGatewayOutput
+	output, err = d.CreateInternetGateway(input)
+	output = output
+	if err != nil {
+		return nil, fmt.Errorf("create internetgateway: %w", err)
+	}
+	d.logger.ExtraVerbosef("ec2.CreateInternetGateway call took %s", time.Since(start))
+	id := aws.StringValue(output.InternetGateway.InternetGatewayId)
+
+	d.logger.Infof("create internetgateway '%s' done", id)
+	return id, nil
+}
+
+// This function was auto generated
+func (d *Ec2Driver) Delete_Internetgateway_DryRun(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.DeleteInternetGatewayInput{}
 	input.DryRun = aws.Bool(true)
 	var err error
 
 	// Required params
-	err = setFieldWithType(params["source-id"], input, "SourceSnapshotId", awsstr)
+	err = setFieldWithType(params["id"], input, "InternetGatewayId", awsstr)
 	if err != nil {
 		return nil, err
 	}
-	err = setFieldWithType(params["source-region"], input, "SourceRegion", awsstr)
+
+	_, err = d.DeleteInternetGateway(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
+			id := fakeDryRunId("internetgateway")
+			d.logger.Verbose("dry run: delete internetgateway ok")
+			return id, nil
+		}
+	}
+
+	return nil, fmt.Errorf("dry run: delete internetgateway: %w", err)
+}
+
+// This function was auto generated
+func (d *Ec2Driver) Delete_Internetgateway(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.DeleteInternetGatewayInput{}
+	var err error
+
+	// Required params
+	err = setFieldWithType(params["id"], input, "InternetGatewayId", awsstr)
 	if err != nil {
 		return nil, err
 	}
 
-	// Extra params
-	if _, ok := params["encrypted"]; ok {
-		err = setFieldWithType(params["encrypted"], input, "Encrypted", awsbool)
-		if err != nil {
-			return nil, err
-		}
+	start := time.Now()
+	var output *ec2.DeleteInternetGatewayOutput
+	output, err = d.DeleteInternetGateway(input)
+	output = output
+	if err != nil {
+		return nil, fmt.Errorf("delete internetgateway: %w", err)
 	}
-	if _, ok := params["description"]; ok {
-		err = setFieldWithType(params["description"], input, "Description", awsstr)
-		if err != nil {
-			return nil, err
-		}
+	d.logger.ExtraVerbosef("ec2.DeleteInternetGateway call took %s", time.Since(start))
+	d.logger.Info("delete internetgateway done")
+	return output, nil
+}
+
+// This function was auto generated
+func (d *Ec2Driver) Attach_Internetgateway_DryRun(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.AttachInternetGatewayInput{}
+	input.DryRun = aws.Bool(true)
+	var err error
+
+	// Required params
+	err = setFieldWithType(params["id"], input, "InternetGatewayId", awsstr)
+	if err != nil {
+		return nil, err
+	}
+	err = setFieldWithType(params["vpc"], input, "VpcId", awsstr)
+	if err != nil {
+		return nil, err
 	}
 
-	_, err = d.CopySnapshot(input)
+	_, err = d.AttachInternetGateway(input)
 	if awsErr, ok := err.(awserr.Error); ok {
 		switch code := awsErr.Code(); {
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			id := fakeDryRunId("snapshot")
-			d.logger.Verbose("dry run: copy snapshot ok")
+			id := fakeDryRunId("internetgateway")
+			d.logger.Verbose("dry run: attach internetgateway ok")
 			return id, nil
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: copy snapshot: %s", err)
+	return nil, fmt.Errorf("dry run: attach internetgateway: %w", err)
 }
 
 // This function was auto generated
-func (d *Ec2Driver) Copy_Snapshot(params map[string]interface{}) (interface{}, error) {
-	input := &ec2.CopySnapshotInput{}
+func (d *Ec2Driver) Attach_Internetgateway(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.AttachInternetGatewayInput{}
 	var err error
 
 	// Required params
-	err = setFieldWithType(params["source-id"], input, "SourceSnapshotId", awsstr)
+	err = setFieldWithType(params["id"], input, "InternetGatewayId", awsstr)
 	if err != nil {
 		return nil, err
 	}
-	err = setFieldWithType(params["source-region"], input, "SourceRegion", awsstr)
+	err = setFieldWithType(params["vpc"], input, "VpcId", awsstr)
 	if err != nil {
 		return nil, err
 	}
 
-	// Extra params
-	if _, ok := params["encrypted"]; ok {
-		err = setFieldWithType(params["encrypted"], input, "Encrypted", awsbool)
-		if err != nil {
-			return nil, err
-		}
+	start := time.Now()
+	var output *ec2.AttachInternetGatewayOutput
+	output, err = d.AttachInternetGateway(input)
+	output = output
+	if err != nil {
+		return nil, fmt.Errorf("attach internetgateway: %w", err)
 	}
-	if _, ok := params["description"]; ok {
-		err = setFieldWithType(params["description"], input, "Description", awsstr)
-		if err != nil {
-			return nil, err
+	d.logger.ExtraVerbosef("ec2.AttachInternetGateway call took %s", time.Since(start))
+	d.logger.Info("attach internetgateway done")
+	return output, nil
+}
+
+// This function was auto generated
+func (d *Ec2Driver) Detach_Internetgateway_DryRun(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.DetachInternetGatewayInput{}
+	input.DryRun = aws.Bool(true)
+	var err error
+
+	// Required params
+	err = setFieldWithType(params["id"], input, "InternetGatewayId", awsstr)
+	if err != nil {
+		return nil, err
+	}
+	err = setFieldWithType(params["vpc"], input, "VpcId", awsstr)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = d.DetachInternetGateway(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
+			id := fakeDryRunId("internetgateway")
+			d.logger.Verbose("dry run: detach internetgateway ok")
+			return id, nil
 		}
 	}
 
+	return nil, fmt.Errorf("dry run: detach internetgateway: %w", err)
+}
+
+// This function was auto generated
+func (d *Ec2Driver) Detach_Internetgateway(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.DetachInternetGatewayInput{}
+	var err error
+
+	// Required params
+	err = setFieldWithType(params["id"], input, "InternetGatewayId", awsstr)
+	if err != nil {
+		return nil, err
+	}
+	err = setFieldWithType(params["vpc"], input, "VpcId", awsstr)
+	if err != nil {
+		return nil, err
+	}
+
 	start := time.Now()
-	var output *ec2.CopySnapshotOutput
-	output, err = d.CopySnapshot(input)
+	var output *ec2.DetachInternetGatewayOutput
+	output, err = d.DetachInternetGateway(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("copy snapshot: %s", err)
+		return nil, fmt.Errorf("detach internetgateway: %w", err)
 	}
-	d.logger.ExtraVerbosef("ec2.CopySnapshot call took %s", time.Since(start))
-	id := aws.StringValue(output.SnapshotId)
+	d.logger.ExtraVerbosef("ec2.DetachInternetGateway call took %s", time.Since(start))
+	d.logger.Info("detach internetgateway done")
+	return output, nil
+}
 
-	d.logger.Infof("copy snapshot '%s' done", id)
-	return id, nil
+// This function was auto generated
+func (d *Ec2Driver) Delete_Natgateway_DryRun(params map[string]interface{}) (interface{}, error) {
+	if _, ok := params["id"]; !ok {
+		return nil, errors.New("delete natgateway: missing required params 'id'")
+	}
+
+	d.logger.Verbose("params dry run: delete natgateway ok")
+	return fakeDryRunId("natgateway"), nil
 }
 
 // This function was auto generated
-func (d *Ec2Driver) Create_Internetgateway_DryRun(params map[string]interface{}) (interface{}, error) {
-	input := &ec2.CreateInternetGatewayInput{}
+func (d *Ec2Driver) Delete_Natgateway(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.DeleteNatGatewayInput{}
+	var err error
+
+	// Required params
+	err = setFieldWithType(params["id"], input, "NatGatewayId", awsstr)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	var output *ec2.DeleteNatGatewayOutput
+	output, err = d.DeleteNatGateway(input)
+	output = output
+	if err != nil {
+		return nil, fmt.Errorf("delete natgateway: %w", err)
+	}
+	d.logger.ExtraVerbosef("ec2.DeleteNatGateway call took %s", time.Since(start))
+	d.logger.Info("delete natgateway done")
+	return output, nil
+}
+
+// This function was auto generated
+func (d *Ec2Driver) Delete_Dhcpoptions_DryRun(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.DeleteDhcpOptionsInput{}
 	input.DryRun = aws.Bool(true)
 	var err error
 
-	_, err = d.CreateInternetGateway(input)
+	// Required params
+	err = setFieldWithType(params["id"], input, "DhcpOptionsId", awsstr)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = d.DeleteDhcpOptions(input)
 	if awsErr, ok := err.(awserr.Error); ok {
 		switch code := awsErr.Code(); {
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			id := fakeDryRunId("internetgateway")
-			d.logger.Verbose("dry run: create internetgateway ok")
+			id := fakeDryRunId("dhcpoptions")
+			d.logger.Verbose("dry run: delete dhcpoptions ok")
 			return id, nil
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: create internetgateway: %s", err)
+	return nil, fmt.Errorf("dry run: delete dhcpoptions: %w", err)
 }
 
 // This function was auto generated
-func (d *Ec2Driver) Create_Internetgateway(params map[string]interface{}) (interface{}, error) {
-	input := &ec2.CreateInternetGatewayInput{}
+func (d *Ec2Driver) Delete_Dhcpoptions(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.DeleteDhcpOptionsInput{}
 	var err error
 
+	// Required params
+	err = setFieldWithType(params["id"], input, "DhcpOptionsId", awsstr)
+	if err != nil {
+		return nil, err
+	}
+
 	start := time.Now()
-	var output *ec2.CreateInternetGatewayOutput
-	output, err = d.CreateInternetGateway(input)
+	var output *ec2.DeleteDhcpOptionsOutput
+	output, err = d.DeleteDhcpOptions(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create internetgateway: %s", err)
+		return nil, fmt.Errorf("delete dhcpoptions: %w", err)
 	}
-	d.logger.ExtraVerbosef("ec2.CreateInternetGateway call took %s", time.Since(start))
-	id := aws.StringValue(output.InternetGateway.InternetGatewayId)
-
-	d.logger.Infof("create internetgateway '%s' done", id)
-	return id, nil
+	d.logger.ExtraVerbosef("ec2.DeleteDhcpOptions call took %s", time.Since(start))
+	d.logger.Info("delete dhcpoptions done")
+	return output, nil
 }
 
 // This function was auto generated
-func (d *Ec2Driver) Delete_Internetgateway_DryRun(params map[string]interface{}) (interface{}, error) {
-	input := &ec2.DeleteInternetGatewayInput{}
+func (d *Ec2Driver) Attach_Dhcpoptions_DryRun(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.AssociateDhcpOptionsInput{}
 	input.DryRun = aws.Bool(true)
 	var err error
 
 	// Required params
-	err = setFieldWithType(params["id"], input, "InternetGatewayId", awsstr)
+	err = setFieldWithType(params["id"], input, "DhcpOptionsId", awsstr)
+	if err != nil {
+		return nil, err
+	}
+	err = setFieldWithType(params["vpc"], input, "VpcId", awsstr)
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = d.DeleteInternetGateway(input)
+	_, err = d.AssociateDhcpOptions(input)
 	if awsErr, ok := err.(awserr.Error); ok {
 		switch code := awsErr.Code(); {
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			id := fakeDryRunId("internetgateway")
-			d.logger.Verbose("dry run: delete internetgateway ok")
+			id := fakeDryRunId("dhcpoptions")
+			d.logger.Verbose("dry run: attach dhcpoptions ok")
 			return id, nil
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: delete internetgateway: %s", err)
+	return nil, fmt.Errorf("dry run: attach dhcpoptions: %w", err)
 }
 
 // This function was auto generated
-func (d *Ec2Driver) Delete_Internetgateway(params map[string]interface{}) (interface{}, error) {
-	input := &ec2.DeleteInternetGatewayInput{}
+func (d *Ec2Driver) Attach_Dhcpoptions(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.AssociateDhcpOptionsInput{}
 	var err error
 
 	// Required params
-	err = setFieldWithType(params["id"], input, "InternetGatewayId", awsstr)
+	err = setFieldWithType(params["id"], input, "DhcpOptionsId", awsstr)
+	if err != nil {
+		return nil, err
+	}
+	err = setFieldWithType(params["vpc"], input, "VpcId", awsstr)
 	if err != nil {
 		return nil, err
 	}
 
 	start := time.Now()
-	var output *ec2.DeleteInternetGatewayOutput
-	output, err = d.DeleteInternetGateway(input)
+	var output *ec2.AssociateDhcpOptionsOutput
+	output, err = d.AssociateDhcpOptions(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete internetgateway: %s", err)
+		return nil, fmt.Errorf("attach dhcpoptions: %w", err)
 	}
-	d.logger.ExtraVerbosef("ec2.DeleteInternetGateway call took %s", time.Since(start))
-	d.logger.Info("delete internetgateway done")
+	d.logger.ExtraVerbosef("ec2.AssociateDhcpOptions call took %s", time.Since(start))
+	d.logger.Info("attach dhcpoptions done")
 	return output, nil
 }
 
 // This function was auto generated
-func (d *Ec2Driver) Attach_Internetgateway_DryRun(params map[string]interface{}) (interface{}, error) {
-	input := &ec2.AttachInternetGatewayInput{}
+func (d *Ec2Driver) Create_Placementgroup_DryRun(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.CreatePlacementGroupInput{}
 	input.DryRun = aws.Bool(true)
 	var err error
 
 	// Required params
-	err = setFieldWithType(params["id"], input, "InternetGatewayId", awsstr)
+	err = setFieldWithType(params["name"], input, "GroupName", awsstr)
 	if err != nil {
 		return nil, err
 	}
-	err = setFieldWithType(params["vpc"], input, "VpcId", awsstr)
+	err = setFieldWithType(params["strategy"], input, "Strategy", awsstr)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = d.CreatePlacementGroup(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
+			id := fakeDryRunId("placementgroup")
+			d.logger.Verbose("dry run: create placementgroup ok")
+			return id, nil
+		}
+	}
+
+	return nil, fmt.Errorf("dry run: create placementgroup: %w", err)
+}
+
+// This function was auto generated
+func (d *Ec2Driver) Create_Placementgroup(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.CreatePlacementGroupInput{}
+	var err error
+
+	// Required params
+	err = setFieldWithType(params["name"], input, "GroupName", awsstr)
+	if err != nil {
+		return nil, err
+	}
+	err = setFieldWithType(params["strategy"], input, "Strategy", awsstr)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	var output *ec2.CreatePlacementGroupOutput
+	output, err = d.CreatePlacementGroup(input)
+	output = output
+	if err != nil {
+		return nil, fmt.Errorf("create placementgroup: %w", err)
+	}
+	d.logger.ExtraVerbosef("ec2.CreatePlacementGroup call took %s", time.Since(start))
+	id := aws.StringValue(input.GroupName)
+
+	d.logger.Infof("create placementgroup '%s' done", id)
+	return id, nil
+}
+
+// This function was auto generated
+func (d *Ec2Driver) Delete_Placementgroup_DryRun(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.DeletePlacementGroupInput{}
+	input.DryRun = aws.Bool(true)
+	var err error
+
+	// Required params
+	err = setFieldWithType(params["name"], input, "GroupName", awsstr)
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = d.AttachInternetGateway(input)
+	_, err = d.DeletePlacementGroup(input)
 	if awsErr, ok := err.(awserr.Error); ok {
 		switch code := awsErr.Code(); {
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			id := fakeDryRunId("internetgateway")
-			d.logger.Verbose("dry run: attach internetgateway ok")
+			id := fakeDryRunId("placementgroup")
+			d.logger.Verbose("dry run: delete placementgroup ok")
 			return id, nil
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: attach internetgateway: %s", err)
+	return nil, fmt.Errorf("dry run: delete placementgroup: %w", err)
 }
 
 // This function was auto generated
-func (d *Ec2Driver) Attach_Internetgateway(params map[string]interface{}) (interface{}, error) {
-	input := &ec2.AttachInternetGatewayInput{}
+func (d *Ec2Driver) Delete_Placementgroup(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.DeletePlacementGroupInput{}
 	var err error
 
 	// Required params
-	err = setFieldWithType(params["id"], input, "InternetGatewayId", awsstr)
-	if err != nil {
-		return nil, err
-	}
-	err = setFieldWithType(params["vpc"], input, "VpcId", awsstr)
+	err = setFieldWithType(params["name"], input, "GroupName", awsstr)
 	if err != nil {
 		return nil, err
 	}
 
 	start := time.Now()
-	var output *ec2.AttachInternetGatewayOutput
-	output, err = d.AttachInternetGateway(input)
+	var output *ec2.DeletePlacementGroupOutput
+	output, err = d.DeletePlacementGroup(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("attach internetgateway: %s", err)
+		return nil, fmt.Errorf("delete placementgroup: %w", err)
 	}
-	d.logger.ExtraVerbosef("ec2.AttachInternetGateway call took %s", time.Since(start))
-	d.logger.Info("attach internetgateway done")
+	d.logger.ExtraVerbosef("ec2.DeletePlacementGroup call took %s", time.Since(start))
+	d.logger.Info("delete placementgroup done")
 	return output, nil
 }
 
 // This function was auto generated
-func (d *Ec2Driver) Detach_Internetgateway_DryRun(params map[string]interface{}) (interface{}, error) {
-	input := &ec2.DetachInternetGatewayInput{}
+func (d *Ec2Driver) Create_Networkacl_DryRun(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.CreateNetworkAclInput{}
 	input.DryRun = aws.Bool(true)
 	var err error
 
 	// Required params
-	err = setFieldWithType(params["id"], input, "InternetGatewayId", awsstr)
-	if err != nil {
-		return nil, err
-	}
 	err = setFieldWithType(params["vpc"], input, "VpcId", awsstr)
 	if err != nil {
 		return nil, err
 	}
 
-	_, err = d.DetachInternetGateway(input)
+	_, err = d.CreateNetworkAcl(input)
 	if awsErr, ok := err.(awserr.Error); ok {
 		switch code := awsErr.Code(); {
 		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
-			id := fakeDryRunId("internetgateway")
-			d.logger.Verbose("dry run: detach internetgateway ok")
+			id := fakeDryRunId("networkacl")
+			d.logger.Verbose("dry run: create networkacl ok")
 			return id, nil
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: detach internetgateway: %s", err)
+	return nil, fmt.Errorf("dry run: create networkacl: %w", err)
 }
 
 // This function was auto generated
-func (d *Ec2Driver) Detach_Internetgateway(params map[string]interface{}) (interface{}, error) {
-	input := &ec2.DetachInternetGatewayInput{}
+func (d *Ec2Driver) Create_Networkacl(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.CreateNetworkAclInput{}
 	var err error
 
 	// Required params
-	err = setFieldWithType(params["id"], input, "InternetGatewayId", awsstr)
-	if err != nil {
-		return nil, err
-	}
 	err = setFieldWithType(params["vpc"], input, "VpcId", awsstr)
 	if err != nil {
 		return nil, err
 	}
 
 	start := time.Now()
-	var output *ec2.DetachInternetGatewayOutput
-	output, err = d.DetachInternetGateway(input)
+	var output *ec2.CreateNetworkAclOutput
+	output, err = d.CreateNetworkAcl(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("detach internetgateway: %s", err)
-	}
-	d.logger.ExtraVerbosef("ec2.DetachInternetGateway call took %s", time.Since(start))
-	d.logger.Info("detach internetgateway done")
-	return output, nil
-}
-
-// This function was auto generated
-func (d *Ec2Driver) Create_Natgateway_DryRun(params map[string]interface{}) (interface{}, error) {
-	if _, ok := params["elasticip-id"]; !ok {
-		return nil, errors.New("create natgateway: missing required params 'elasticip-id'")
-	}
-
-	if _, ok := params["subnet"]; !ok {
-		return nil, errors.New("create natgateway: missing required params 'subnet'")
+		return nil, fmt.Errorf("create networkacl: %w", err)
 	}
+	d.logger.ExtraVerbosef("ec2.CreateNetworkAcl call took %s", time.Since(start))
+	id := aws.StringValue(output.NetworkAcl.NetworkAclId)
 
-	d.logger.Verbose("params dry run: create natgateway ok")
-	return fakeDryRunId("natgateway"), nil
+	d.logger.Infof("create networkacl '%s' done", id)
+	return id, nil
 }
 
 // This function was auto generated
-func (d *Ec2Driver) Create_Natgateway(params map[string]interface{}) (interface{}, error) {
-	input := &ec2.CreateNatGatewayInput{}
+func (d *Ec2Driver) Delete_Networkacl_DryRun(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.DeleteNetworkAclInput{}
+	input.DryRun = aws.Bool(true)
 	var err error
 
 	// Required params
-	err = setFieldWithType(params["elasticip-id"], input, "AllocationId", awsstr)
-	if err != nil {
-		return nil, err
-	}
-	err = setFieldWithType(params["subnet"], input, "SubnetId", awsstr)
+	err = setFieldWithType(params["id"], input, "NetworkAclId", awsstr)
 	if err != nil {
 		return nil, err
 	}
 
-	start := time.Now()
-	var output *ec2.CreateNatGatewayOutput
-	output, err = d.CreateNatGateway(input)
-	output = output
-	if err != nil {
-		return nil, fmt.Errorf("create natgateway: %s", err)
-	}
-	d.logger.ExtraVerbosef("ec2.CreateNatGateway call took %s", time.Since(start))
-	id := aws.StringValue(output.NatGateway.NatGatewayId)
-
-	d.logger.Infof("create natgateway '%s' done", id)
-	return id, nil
-}
-
-// This function was auto generated
-func (d *Ec2Driver) Delete_Natgateway_DryRun(params map[string]interface{}) (interface{}, error) {
-	if _, ok := params["id"]; !ok {
-		return nil, errors.New("delete natgateway: missing required params 'id'")
+	_, err = d.DeleteNetworkAcl(input)
+	if awsErr, ok := err.(awserr.Error); ok {
+		switch code := awsErr.Code(); {
+		case code == dryRunOperation, strings.HasSuffix(code, notFound), strings.Contains(awsErr.Message(), "Invalid IAM Instance Profile name"):
+			id := fakeDryRunId("networkacl")
+			d.logger.Verbose("dry run: delete networkacl ok")
+			return id, nil
+		}
 	}
 
-	d.logger.Verbose("params dry run: delete natgateway ok")
-	return fakeDryRunId("natgateway"), nil
+	return nil, fmt.Errorf("dry run: delete networkacl: %w", err)
 }
 
 // This function was auto generated
-func (d *Ec2Driver) Delete_Natgateway(params map[string]interface{}) (interface{}, error) {
-	input := &ec2.DeleteNatGatewayInput{}
+func (d *Ec2Driver) Delete_Networkacl(params map[string]interface{}) (interface{}, error) {
+	input := &ec2.DeleteNetworkAclInput{}
 	var err error
 
 	// Required params
-	err = setFieldWithType(params["id"], input, "NatGatewayId", awsstr)
+	err = setFieldWithType(params["id"], input, "NetworkAclId", awsstr)
 	if err != nil {
 		return nil, err
 	}
 
 	start := time.Now()
-	var output *ec2.DeleteNatGatewayOutput
-	output, err = d.DeleteNatGateway(input)
+	var output *ec2.DeleteNetworkAclOutput
+	output, err = d.DeleteNetworkAcl(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete natgateway: %s", err)
+		return nil, fmt.Errorf("delete networkacl: %w", err)
 	}
-	d.logger.ExtraVerbosef("ec2.DeleteNatGateway call took %s", time.Since(start))
-	d.logger.Info("delete natgateway done")
+	d.logger.ExtraVerbosef("ec2.DeleteNetworkAcl call took %s", time.Since(start))
+	d.logger.Info("delete networkacl done")
 	return output, nil
 }
 
@@ -1840,7 +1765,7 @@ func (d *Ec2Driver) Create_Routetable_DryRun(params map[string]interface{}) (int
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: create routetable: %s", err)
+	return nil, fmt.Errorf("dry run: create routetable: %w", err)
 }
 
 // This function was auto generated
@@ -1859,7 +1784,7 @@ func (d *Ec2Driver) Create_Routetable(params map[string]interface{}) (interface{
 	output, err = d.CreateRouteTable(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create routetable: %s", err)
+		return nil, fmt.Errorf("create routetable: %w", err)
 	}
 	d.logger.ExtraVerbosef("ec2.CreateRouteTable call took %s", time.Since(start))
 	id := aws.StringValue(output.RouteTable.RouteTableId)
@@ -1890,7 +1815,7 @@ func (d *Ec2Driver) Delete_Routetable_DryRun(params map[string]interface{}) (int
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: delete routetable: %s", err)
+	return nil, fmt.Errorf("dry run: delete routetable: %w", err)
 }
 
 // This function was auto generated
@@ -1909,7 +1834,7 @@ func (d *Ec2Driver) Delete_Routetable(params map[string]interface{}) (interface{
 	output, err = d.DeleteRouteTable(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete routetable: %s", err)
+		return nil, fmt.Errorf("delete routetable: %w", err)
 	}
 	d.logger.ExtraVerbosef("ec2.DeleteRouteTable call took %s", time.Since(start))
 	d.logger.Info("delete routetable done")
@@ -1942,7 +1867,7 @@ func (d *Ec2Driver) Attach_Routetable_DryRun(params map[string]interface{}) (int
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: attach routetable: %s", err)
+	return nil, fmt.Errorf("dry run: attach routetable: %w", err)
 }
 
 // This function was auto generated
@@ -1965,7 +1890,7 @@ func (d *Ec2Driver) Attach_Routetable(params map[string]interface{}) (interface{
 	output, err = d.AssociateRouteTable(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("attach routetable: %s", err)
+		return nil, fmt.Errorf("attach routetable: %w", err)
 	}
 	d.logger.ExtraVerbosef("ec2.AssociateRouteTable call took %s", time.Since(start))
 	id := aws.StringValue(output.AssociationId)
@@ -1996,7 +1921,7 @@ func (d *Ec2Driver) Detach_Routetable_DryRun(params map[string]interface{}) (int
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: detach routetable: %s", err)
+	return nil, fmt.Errorf("dry run: detach routetable: %w", err)
 }
 
 // This function was auto generated
@@ -2015,7 +1940,7 @@ func (d *Ec2Driver) Detach_Routetable(params map[string]interface{}) (interface{
 	output, err = d.DisassociateRouteTable(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("detach routetable: %s", err)
+		return nil, fmt.Errorf("detach routetable: %w", err)
 	}
 	d.logger.ExtraVerbosef("ec2.DisassociateRouteTable call took %s", time.Since(start))
 	d.logger.Info("detach routetable done")
@@ -2052,7 +1977,7 @@ func (d *Ec2Driver) Create_Route_DryRun(params map[string]interface{}) (interfac
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: create route: %s", err)
+	return nil, fmt.Errorf("dry run: create route: %w", err)
 }
 
 // This function was auto generated
@@ -2079,7 +2004,7 @@ func (d *Ec2Driver) Create_Route(params map[string]interface{}) (interface{}, er
 	output, err = d.CreateRoute(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create route: %s", err)
+		return nil, fmt.Errorf("create route: %w", err)
 	}
 	d.logger.ExtraVerbosef("ec2.CreateRoute call took %s", time.Since(start))
 	d.logger.Info("create route done")
@@ -2112,7 +2037,7 @@ func (d *Ec2Driver) Delete_Route_DryRun(params map[string]interface{}) (interfac
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: delete route: %s", err)
+	return nil, fmt.Errorf("dry run: delete route: %w", err)
 }
 
 // This function was auto generated
@@ -2135,7 +2060,7 @@ func (d *Ec2Driver) Delete_Route(params map[string]interface{}) (interface{}, er
 	output, err = d.DeleteRoute(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete route: %s", err)
+		return nil, fmt.Errorf("delete route: %w", err)
 	}
 	d.logger.ExtraVerbosef("ec2.DeleteRoute call took %s", time.Since(start))
 	d.logger.Info("delete route done")
@@ -2164,7 +2089,7 @@ func (d *Ec2Driver) Delete_Keypair_DryRun(params map[string]interface{}) (interf
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: delete keypair: %s", err)
+	return nil, fmt.Errorf("dry run: delete keypair: %w", err)
 }
 
 // This function was auto generated
@@ -2183,7 +2108,7 @@ func (d *Ec2Driver) Delete_Keypair(params map[string]interface{}) (interface{},
 	output, err = d.DeleteKeyPair(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete keypair: %s", err)
+		return nil, fmt.Errorf("delete keypair: %w", err)
 	}
 	d.logger.ExtraVerbosef("ec2.DeleteKeyPair call took %s", time.Since(start))
 	d.logger.Info("delete keypair done")
@@ -2212,7 +2137,7 @@ func (d *Ec2Driver) Create_Elasticip_DryRun(params map[string]interface{}) (inte
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: create elasticip: %s", err)
+	return nil, fmt.Errorf("dry run: create elasticip: %w", err)
 }
 
 // This function was auto generated
@@ -2231,7 +2156,7 @@ func (d *Ec2Driver) Create_Elasticip(params map[string]interface{}) (interface{}
 	output, err = d.AllocateAddress(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create elasticip: %s", err)
+		return nil, fmt.Errorf("create elasticip: %w", err)
 	}
 	d.logger.ExtraVerbosef("ec2.AllocateAddress call took %s", time.Since(start))
 	id := aws.StringValue(output.AllocationId)
@@ -2270,7 +2195,7 @@ func (d *Ec2Driver) Delete_Elasticip_DryRun(params map[string]interface{}) (inte
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: delete elasticip: %s", err)
+	return nil, fmt.Errorf("dry run: delete elasticip: %w", err)
 }
 
 // This function was auto generated
@@ -2297,7 +2222,7 @@ func (d *Ec2Driver) Delete_Elasticip(params map[string]interface{}) (interface{}
 	output, err = d.ReleaseAddress(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete elasticip: %s", err)
+		return nil, fmt.Errorf("delete elasticip: %w", err)
 	}
 	d.logger.ExtraVerbosef("ec2.ReleaseAddress call took %s", time.Since(start))
 	d.logger.Info("delete elasticip done")
@@ -2352,7 +2277,7 @@ func (d *Ec2Driver) Attach_Elasticip_DryRun(params map[string]interface{}) (inte
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: attach elasticip: %s", err)
+	return nil, fmt.Errorf("dry run: attach elasticip: %w", err)
 }
 
 // This function was auto generated
@@ -2397,7 +2322,7 @@ func (d *Ec2Driver) Attach_Elasticip(params map[string]interface{}) (interface{}
 	output, err = d.AssociateAddress(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("attach elasticip: %s", err)
+		return nil, fmt.Errorf("attach elasticip: %w", err)
 	}
 	d.logger.ExtraVerbosef("ec2.AssociateAddress call took %s", time.Since(start))
 	id := aws.StringValue(output.AssociationId)
@@ -2428,7 +2353,7 @@ func (d *Ec2Driver) Detach_Elasticip_DryRun(params map[string]interface{}) (inte
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: detach elasticip: %s", err)
+	return nil, fmt.Errorf("dry run: detach elasticip: %w", err)
 }
 
 // This function was auto generated
@@ -2447,7 +2372,7 @@ func (d *Ec2Driver) Detach_Elasticip(params map[string]interface{}) (interface{}
 	output, err = d.DisassociateAddress(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("detach elasticip: %s", err)
+		return nil, fmt.Errorf("detach elasticip: %w", err)
 	}
 	d.logger.ExtraVerbosef("ec2.DisassociateAddress call took %s", time.Since(start))
 	d.logger.Info("detach elasticip done")
@@ -2508,7 +2433,7 @@ func (d *Elbv2Driver) Create_Loadbalancer(params map[string]interface{}) (interf
 	output, err = d.CreateLoadBalancer(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create loadbalancer: %s", err)
+		return nil, fmt.Errorf("create loadbalancer: %w", err)
 	}
 	d.logger.ExtraVerbosef("elbv2.CreateLoadBalancer call took %s", time.Since(start))
 	id := aws.StringValue(output.LoadBalancers[0].LoadBalancerArn)
@@ -2543,7 +2468,7 @@ func (d *Elbv2Driver) Delete_Loadbalancer(params map[string]interface{}) (interf
 	output, err = d.DeleteLoadBalancer(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete loadbalancer: %s", err)
+		return nil, fmt.Errorf("delete loadbalancer: %w", err)
 	}
 	d.logger.ExtraVerbosef("elbv2.DeleteLoadBalancer call took %s", time.Since(start))
 	d.logger.Info("delete loadbalancer done")
@@ -2622,7 +2547,7 @@ func (d *Elbv2Driver) Create_Listener(params map[string]interface{}) (interface{
 	output, err = d.CreateListener(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create listener: %s", err)
+		return nil, fmt.Errorf("create listener: %w", err)
 	}
 	d.logger.ExtraVerbosef("elbv2.CreateListener call took %s", time.Since(start))
 	id := aws.StringValue(output.Listeners[0].ListenerArn)
@@ -2657,7 +2582,7 @@ func (d *Elbv2Driver) Delete_Listener(params map[string]interface{}) (interface{
 	output, err = d.DeleteListener(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete listener: %s", err)
+		return nil, fmt.Errorf("delete listener: %w", err)
 	}
 	d.logger.ExtraVerbosef("elbv2.DeleteListener call took %s", time.Since(start))
 	d.logger.Info("delete listener done")
@@ -2764,7 +2689,7 @@ func (d *Elbv2Driver) Create_Targetgroup(params map[string]interface{}) (interfa
 	output, err = d.CreateTargetGroup(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create targetgroup: %s", err)
+		return nil, fmt.Errorf("create targetgroup: %w", err)
 	}
 	d.logger.ExtraVerbosef("elbv2.CreateTargetGroup call took %s", time.Since(start))
 	id := aws.StringValue(output.TargetGroups[0].TargetGroupArn)
@@ -2799,7 +2724,7 @@ func (d *Elbv2Driver) Delete_Targetgroup(params map[string]interface{}) (interfa
 	output, err = d.DeleteTargetGroup(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete targetgroup: %s", err)
+		return nil, fmt.Errorf("delete targetgroup: %w", err)
 	}
 	d.logger.ExtraVerbosef("elbv2.DeleteTargetGroup call took %s", time.Since(start))
 	d.logger.Info("delete targetgroup done")
@@ -2848,7 +2773,7 @@ func (d *Elbv2Driver) Attach_Instance(params map[string]interface{}) (interface{
 	output, err = d.RegisterTargets(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("attach instance: %s", err)
+		return nil, fmt.Errorf("attach instance: %w", err)
 	}
 	d.logger.ExtraVerbosef("elbv2.RegisterTargets call took %s", time.Since(start))
 	d.logger.Info("attach instance done")
@@ -2889,7 +2814,7 @@ func (d *Elbv2Driver) Detach_Instance(params map[string]interface{}) (interface{
 	output, err = d.DeregisterTargets(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("detach instance: %s", err)
+		return nil, fmt.Errorf("detach instance: %w", err)
 	}
 	d.logger.ExtraVerbosef("elbv2.DeregisterTargets call took %s", time.Since(start))
 	d.logger.Info("detach instance done")
@@ -2976,7 +2901,7 @@ func (d *AutoscalingDriver) Create_Launchconfiguration(params map[string]interfa
 	output, err = d.CreateLaunchConfiguration(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create launchconfiguration: %s", err)
+		return nil, fmt.Errorf("create launchconfiguration: %w", err)
 	}
 	d.logger.ExtraVerbosef("autoscaling.CreateLaunchConfiguration call took %s", time.Since(start))
 	id := params["name"]
@@ -3011,7 +2936,7 @@ func (d *AutoscalingDriver) Delete_Launchconfiguration(params map[string]interfa
 	output, err = d.DeleteLaunchConfiguration(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete launchconfiguration: %s", err)
+		return nil, fmt.Errorf("delete launchconfiguration: %w", err)
 	}
 	d.logger.ExtraVerbosef("autoscaling.DeleteLaunchConfiguration call took %s", time.Since(start))
 	d.logger.Info("delete launchconfiguration done")
@@ -3114,7 +3039,7 @@ func (d *AutoscalingDriver) Create_Scalinggroup(params map[string]interface{}) (
 	output, err = d.CreateAutoScalingGroup(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create scalinggroup: %s", err)
+		return nil, fmt.Errorf("create scalinggroup: %w", err)
 	}
 	d.logger.ExtraVerbosef("autoscaling.CreateAutoScalingGroup call took %s", time.Since(start))
 	id := params["name"]
@@ -3205,7 +3130,7 @@ func (d *AutoscalingDriver) Update_Scalinggroup(params map[string]interface{}) (
 	output, err = d.UpdateAutoScalingGroup(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("update scalinggroup: %s", err)
+		return nil, fmt.Errorf("update scalinggroup: %w", err)
 	}
 	d.logger.ExtraVerbosef("autoscaling.UpdateAutoScalingGroup call took %s", time.Since(start))
 	d.logger.Info("update scalinggroup done")
@@ -3246,7 +3171,7 @@ func (d *AutoscalingDriver) Delete_Scalinggroup(params map[string]interface{}) (
 	output, err = d.DeleteAutoScalingGroup(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete scalinggroup: %s", err)
+		return nil, fmt.Errorf("delete scalinggroup: %w", err)
 	}
 	d.logger.ExtraVerbosef("autoscaling.DeleteAutoScalingGroup call took %s", time.Since(start))
 	d.logger.Info("delete scalinggroup done")
@@ -3317,7 +3242,7 @@ func (d *AutoscalingDriver) Create_Scalingpolicy(params map[string]interface{})
 	output, err = d.PutScalingPolicy(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create scalingpolicy: %s", err)
+		return nil, fmt.Errorf("create scalingpolicy: %w", err)
 	}
 	d.logger.ExtraVerbosef("autoscaling.PutScalingPolicy call took %s", time.Since(start))
 	id := aws.StringValue(output.PolicyARN)
@@ -3352,7 +3277,7 @@ func (d *AutoscalingDriver) Delete_Scalingpolicy(params map[string]interface{})
 	output, err = d.DeletePolicy(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete scalingpolicy: %s", err)
+		return nil, fmt.Errorf("delete scalingpolicy: %w", err)
 	}
 	d.logger.ExtraVerbosef("autoscaling.DeletePolicy call took %s", time.Since(start))
 	d.logger.Info("delete scalingpolicy done")
@@ -3565,7 +3490,7 @@ func (d *RdsDriver) Create_Database(params map[string]interface{}) (interface{},
 	output, err = d.CreateDBInstance(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create database: %s", err)
+		return nil, fmt.Errorf("create database: %w", err)
 	}
 	d.logger.ExtraVerbosef("rds.CreateDBInstance call took %s", time.Since(start))
 	id := aws.StringValue(output.DBInstance.DBInstanceIdentifier)
@@ -3614,7 +3539,7 @@ func (d *RdsDriver) Delete_Database(params map[string]interface{}) (interface{},
 	output, err = d.DeleteDBInstance(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete database: %s", err)
+		return nil, fmt.Errorf("delete database: %w", err)
 	}
 	d.logger.ExtraVerbosef("rds.DeleteDBInstance call took %s", time.Since(start))
 	d.logger.Info("delete database done")
@@ -3663,7 +3588,7 @@ func (d *RdsDriver) Create_Dbsubnetgroup(params map[string]interface{}) (interfa
 	output, err = d.CreateDBSubnetGroup(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create dbsubnetgroup: %s", err)
+		return nil, fmt.Errorf("create dbsubnetgroup: %w", err)
 	}
 	d.logger.ExtraVerbosef("rds.CreateDBSubnetGroup call took %s", time.Since(start))
 	id := aws.StringValue(output.DBSubnetGroup.DBSubnetGroupName)
@@ -3698,7 +3623,7 @@ func (d *RdsDriver) Delete_Dbsubnetgroup(params map[string]interface{}) (interfa
 	output, err = d.DeleteDBSubnetGroup(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete dbsubnetgroup: %s", err)
+		return nil, fmt.Errorf("delete dbsubnetgroup: %w", err)
 	}
 	d.logger.ExtraVerbosef("rds.DeleteDBSubnetGroup call took %s", time.Since(start))
 	d.logger.Info("delete dbsubnetgroup done")
@@ -3731,7 +3656,7 @@ func (d *EcrDriver) Create_Repository(params map[string]interface{}) (interface{
 	output, err = d.CreateRepository(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create repository: %s", err)
+		return nil, fmt.Errorf("create repository: %w", err)
 	}
 	d.logger.ExtraVerbosef("ecr.CreateRepository call took %s", time.Since(start))
 	id := aws.StringValue(output.Repository.RepositoryArn)
@@ -3780,7 +3705,7 @@ func (d *EcrDriver) Delete_Repository(params map[string]interface{}) (interface{
 	output, err = d.DeleteRepository(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete repository: %s", err)
+		return nil, fmt.Errorf("delete repository: %w", err)
 	}
 	d.logger.ExtraVerbosef("ecr.DeleteRepository call took %s", time.Since(start))
 	d.logger.Info("delete repository done")
@@ -3813,7 +3738,7 @@ func (d *EcsDriver) Create_Containercluster(params map[string]interface{}) (inte
 	output, err = d.CreateCluster(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create containercluster: %s", err)
+		return nil, fmt.Errorf("create containercluster: %w", err)
 	}
 	d.logger.ExtraVerbosef("ecs.CreateCluster call took %s", time.Since(start))
 	id := aws.StringValue(output.Cluster.ClusterArn)
@@ -3848,7 +3773,7 @@ func (d *EcsDriver) Delete_Containercluster(params map[string]interface{}) (inte
 	output, err = d.DeleteCluster(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete containercluster: %s", err)
+		return nil, fmt.Errorf("delete containercluster: %w", err)
 	}
 	d.logger.ExtraVerbosef("ecs.DeleteCluster call took %s", time.Since(start))
 	d.logger.Info("delete containercluster done")
@@ -3926,12 +3851,18 @@ func (d *EcsDriver) Start_Containerservice(params map[string]interface{}) (inter
 		}
 	}
 
+	// Idempotency token
+	err = setIdempotencyToken(params, "start containerservice", input, "ClientToken")
+	if err != nil {
+		return nil, err
+	}
+
 	start := time.Now()
 	var output *ecs.CreateServiceOutput
 	output, err = d.CreateService(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("start containerservice: %s", err)
+		return nil, fmt.Errorf("start containerservice: %w", err)
 	}
 	d.logger.ExtraVerbosef("ecs.CreateService call took %s", time.Since(start))
 	d.logger.Info("start containerservice done")
@@ -3972,7 +3903,7 @@ func (d *EcsDriver) Stop_Containerservice(params map[string]interface{}) (interf
 	output, err = d.DeleteService(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("stop containerservice: %s", err)
+		return nil, fmt.Errorf("stop containerservice: %w", err)
 	}
 	d.logger.ExtraVerbosef("ecs.DeleteService call took %s", time.Since(start))
 	d.logger.Info("stop containerservice done")
@@ -4027,7 +3958,7 @@ func (d *EcsDriver) Update_Containerservice(params map[string]interface{}) (inte
 	output, err = d.UpdateService(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("update containerservice: %s", err)
+		return nil, fmt.Errorf("update containerservice: %w", err)
 	}
 	d.logger.ExtraVerbosef("ecs.UpdateService call took %s", time.Since(start))
 	d.logger.Info("update containerservice done")
@@ -4082,7 +4013,7 @@ func (d *EcsDriver) Start_Containertask(params map[string]interface{}) (interfac
 	output, err = d.RunTask(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("start containertask: %s", err)
+		return nil, fmt.Errorf("start containertask: %w", err)
 	}
 	d.logger.ExtraVerbosef("ecs.RunTask call took %s", time.Since(start))
 	d.logger.Info("start containertask done")
@@ -4115,7 +4046,7 @@ func (d *IamDriver) Create_User(params map[string]interface{}) (interface{}, err
 	output, err = d.CreateUser(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create user: %s", err)
+		return nil, fmt.Errorf("create user: %w", err)
 	}
 	d.logger.ExtraVerbosef("iam.CreateUser call took %s", time.Since(start))
 	id := aws.StringValue(output.User.UserId)
@@ -4150,7 +4081,7 @@ func (d *IamDriver) Delete_User(params map[string]interface{}) (interface{}, err
 	output, err = d.DeleteUser(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete user: %s", err)
+		return nil, fmt.Errorf("delete user: %w", err)
 	}
 	d.logger.ExtraVerbosef("iam.DeleteUser call took %s", time.Since(start))
 	d.logger.Info("delete user done")
@@ -4191,7 +4122,7 @@ func (d *IamDriver) Attach_User(params map[string]interface{}) (interface{}, err
 	output, err = d.AddUserToGroup(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("attach user: %s", err)
+		return nil, fmt.Errorf("attach user: %w", err)
 	}
 	d.logger.ExtraVerbosef("iam.AddUserToGroup call took %s", time.Since(start))
 	d.logger.Info("attach user done")
@@ -4232,7 +4163,7 @@ func (d *IamDriver) Detach_User(params map[string]interface{}) (interface{}, err
 	output, err = d.RemoveUserFromGroup(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("detach user: %s", err)
+		return nil, fmt.Errorf("detach user: %w", err)
 	}
 	d.logger.ExtraVerbosef("iam.RemoveUserFromGroup call took %s", time.Since(start))
 	d.logger.Info("detach user done")
@@ -4273,7 +4204,7 @@ func (d *IamDriver) Delete_Accesskey(params map[string]interface{}) (interface{}
 	output, err = d.DeleteAccessKey(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete accesskey: %s", err)
+		return nil, fmt.Errorf("delete accesskey: %w", err)
 	}
 	d.logger.ExtraVerbosef("iam.DeleteAccessKey call took %s", time.Since(start))
 	d.logger.Info("delete accesskey done")
@@ -4322,7 +4253,7 @@ func (d *IamDriver) Create_Loginprofile(params map[string]interface{}) (interfac
 	output, err = d.CreateLoginProfile(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create loginprofile: %s", err)
+		return nil, fmt.Errorf("create loginprofile: %w", err)
 	}
 	d.logger.ExtraVerbosef("iam.CreateLoginProfile call took %s", time.Since(start))
 	id := aws.StringValue(output.LoginProfile.UserName)
@@ -4373,7 +4304,7 @@ func (d *IamDriver) Update_Loginprofile(params map[string]interface{}) (interfac
 	output, err = d.UpdateLoginProfile(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("update loginprofile: %s", err)
+		return nil, fmt.Errorf("update loginprofile: %w", err)
 	}
 	d.logger.ExtraVerbosef("iam.UpdateLoginProfile call took %s", time.Since(start))
 	d.logger.Info("update loginprofile done")
@@ -4406,7 +4337,7 @@ func (d *IamDriver) Delete_Loginprofile(params map[string]interface{}) (interfac
 	output, err = d.DeleteLoginProfile(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete loginprofile: %s", err)
+		return nil, fmt.Errorf("delete loginprofile: %w", err)
 	}
 	d.logger.ExtraVerbosef("iam.DeleteLoginProfile call took %s", time.Since(start))
 	d.logger.Info("delete loginprofile done")
@@ -4439,7 +4370,7 @@ func (d *IamDriver) Create_Group(params map[string]interface{}) (interface{}, er
 	output, err = d.CreateGroup(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create group: %s", err)
+		return nil, fmt.Errorf("create group: %w", err)
 	}
 	d.logger.ExtraVerbosef("iam.CreateGroup call took %s", time.Since(start))
 	id := aws.StringValue(output.Group.GroupId)
@@ -4474,7 +4405,7 @@ func (d *IamDriver) Delete_Group(params map[string]interface{}) (interface{}, er
 	output, err = d.DeleteGroup(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete group: %s", err)
+		return nil, fmt.Errorf("delete group: %w", err)
 	}
 	d.logger.ExtraVerbosef("iam.DeleteGroup call took %s", time.Since(start))
 	d.logger.Info("delete group done")
@@ -4515,7 +4446,7 @@ func (d *IamDriver) Attach_Role(params map[string]interface{}) (interface{}, err
 	output, err = d.AddRoleToInstanceProfile(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("attach role: %s", err)
+		return nil, fmt.Errorf("attach role: %w", err)
 	}
 	d.logger.ExtraVerbosef("iam.AddRoleToInstanceProfile call took %s", time.Since(start))
 	d.logger.Info("attach role done")
@@ -4556,7 +4487,7 @@ func (d *IamDriver) Detach_Role(params map[string]interface{}) (interface{}, err
 	output, err = d.RemoveRoleFromInstanceProfile(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("detach role: %s", err)
+		return nil, fmt.Errorf("detach role: %w", err)
 	}
 	d.logger.ExtraVerbosef("iam.RemoveRoleFromInstanceProfile call took %s", time.Since(start))
 	d.logger.Info("detach role done")
@@ -4589,7 +4520,7 @@ func (d *IamDriver) Create_Instanceprofile(params map[string]interface{}) (inter
 	output, err = d.CreateInstanceProfile(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create instanceprofile: %s", err)
+		return nil, fmt.Errorf("create instanceprofile: %w", err)
 	}
 	d.logger.ExtraVerbosef("iam.CreateInstanceProfile call took %s", time.Since(start))
 	d.logger.Info("create instanceprofile done")
@@ -4622,7 +4553,7 @@ func (d *IamDriver) Delete_Instanceprofile(params map[string]interface{}) (inter
 	output, err = d.DeleteInstanceProfile(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete instanceprofile: %s", err)
+		return nil, fmt.Errorf("delete instanceprofile: %w", err)
 	}
 	d.logger.ExtraVerbosef("iam.DeleteInstanceProfile call took %s", time.Since(start))
 	d.logger.Info("delete instanceprofile done")
@@ -4655,7 +4586,7 @@ func (d *IamDriver) Delete_Policy(params map[string]interface{}) (interface{}, e
 	output, err = d.DeletePolicy(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete policy: %s", err)
+		return nil, fmt.Errorf("delete policy: %w", err)
 	}
 	d.logger.ExtraVerbosef("iam.DeletePolicy call took %s", time.Since(start))
 	d.logger.Info("delete policy done")
@@ -4696,7 +4627,7 @@ func (d *S3Driver) Create_Bucket(params map[string]interface{}) (interface{}, er
 	output, err = d.CreateBucket(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create bucket: %s", err)
+		return nil, fmt.Errorf("create bucket: %w", err)
 	}
 	d.logger.ExtraVerbosef("s3.CreateBucket call took %s", time.Since(start))
 	id := params["name"]
@@ -4731,7 +4662,7 @@ func (d *S3Driver) Delete_Bucket(params map[string]interface{}) (interface{}, er
 	output, err = d.DeleteBucket(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete bucket: %s", err)
+		return nil, fmt.Errorf("delete bucket: %w", err)
 	}
 	d.logger.ExtraVerbosef("s3.DeleteBucket call took %s", time.Since(start))
 	d.logger.Info("delete bucket done")
@@ -4788,7 +4719,7 @@ func (d *S3Driver) Update_S3object(params map[string]interface{}) (interface{},
 	output, err = d.PutObjectAcl(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("update s3object: %s", err)
+		return nil, fmt.Errorf("update s3object: %w", err)
 	}
 	d.logger.ExtraVerbosef("s3.PutObjectAcl call took %s", time.Since(start))
 	d.logger.Info("update s3object done")
@@ -4829,7 +4760,7 @@ func (d *S3Driver) Delete_S3object(params map[string]interface{}) (interface{},
 	output, err = d.DeleteObject(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete s3object: %s", err)
+		return nil, fmt.Errorf("delete s3object: %w", err)
 	}
 	d.logger.ExtraVerbosef("s3.DeleteObject call took %s", time.Since(start))
 	d.logger.Info("delete s3object done")
@@ -4862,7 +4793,7 @@ func (d *SnsDriver) Create_Topic(params map[string]interface{}) (interface{}, er
 	output, err = d.CreateTopic(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create topic: %s", err)
+		return nil, fmt.Errorf("create topic: %w", err)
 	}
 	d.logger.ExtraVerbosef("sns.CreateTopic call took %s", time.Since(start))
 	id := aws.StringValue(output.TopicArn)
@@ -4897,7 +4828,7 @@ func (d *SnsDriver) Delete_Topic(params map[string]interface{}) (interface{}, er
 	output, err = d.DeleteTopic(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete topic: %s", err)
+		return nil, fmt.Errorf("delete topic: %w", err)
 	}
 	d.logger.ExtraVerbosef("sns.DeleteTopic call took %s", time.Since(start))
 	d.logger.Info("delete topic done")
@@ -4946,7 +4877,7 @@ func (d *SnsDriver) Create_Subscription(params map[string]interface{}) (interfac
 	output, err = d.Subscribe(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create subscription: %s", err)
+		return nil, fmt.Errorf("create subscription: %w", err)
 	}
 	d.logger.ExtraVerbosef("sns.Subscribe call took %s", time.Since(start))
 	id := aws.StringValue(output.SubscriptionArn)
@@ -4981,7 +4912,7 @@ func (d *SnsDriver) Delete_Subscription(params map[string]interface{}) (interfac
 	output, err = d.Unsubscribe(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete subscription: %s", err)
+		return nil, fmt.Errorf("delete subscription: %w", err)
 	}
 	d.logger.ExtraVerbosef("sns.Unsubscribe call took %s", time.Since(start))
 	d.logger.Info("delete subscription done")
@@ -5058,7 +4989,7 @@ func (d *SqsDriver) Create_Queue(params map[string]interface{}) (interface{}, er
 	output, err = d.CreateQueue(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create queue: %s", err)
+		return nil, fmt.Errorf("create queue: %w", err)
 	}
 	d.logger.ExtraVerbosef("sqs.CreateQueue call took %s", time.Since(start))
 	id := aws.StringValue(output.QueueUrl)
@@ -5093,7 +5024,7 @@ func (d *SqsDriver) Delete_Queue(params map[string]interface{}) (interface{}, er
 	output, err = d.DeleteQueue(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete queue: %s", err)
+		return nil, fmt.Errorf("delete queue: %w", err)
 	}
 	d.logger.ExtraVerbosef("sqs.DeleteQueue call took %s", time.Since(start))
 	d.logger.Info("delete queue done")
@@ -5166,7 +5097,7 @@ func (d *Route53Driver) Create_Zone(params map[string]interface{}) (interface{},
 	output, err = d.CreateHostedZone(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create zone: %s", err)
+		return nil, fmt.Errorf("create zone: %w", err)
 	}
 	d.logger.ExtraVerbosef("route53.CreateHostedZone call took %s", time.Since(start))
 	id := aws.StringValue(output.HostedZone.Id)
@@ -5201,13 +5132,268 @@ func (d *Route53Driver) Delete_Zone(params map[string]interface{}) (interface{},
 	output, err = d.DeleteHostedZone(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete zone: %s", err)
+		return nil, fmt.Errorf("delete zone: %w", err)
 	}
 	d.logger.ExtraVerbosef("route53.DeleteHostedZone call took %s", time.Since(start))
 	d.logger.Info("delete zone done")
 	return output, nil
 }
 
+// This function was auto generated
+func (d *Route53Driver) Create_Healthcheck_DryRun(params map[string]interface{}) (interface{}, error) {
+	if _, ok := params["type"]; !ok {
+		return nil, errors.New("create healthcheck: missing required params 'type'")
+	}
+
+	d.logger.Verbose("params dry run: create healthcheck ok")
+	return fakeDryRunId("healthcheck"), nil
+}
+
+// This function was auto generated
+func (d *Route53Driver) Create_Healthcheck(params map[string]interface{}) (interface{}, error) {
+	input := &route53.CreateHealthCheckInput{}
+	var err error
+
+	// Required params
+	err = setFieldWithType(params["type"], input, "HealthCheckConfig.Type", awsstr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extra params
+	if _, ok := params["host"]; ok {
+		err = setFieldWithType(params["host"], input, "HealthCheckConfig.FullyQualifiedDomainName", awsstr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := params["ip"]; ok {
+		err = setFieldWithType(params["ip"], input, "HealthCheckConfig.IPAddress", awsstr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := params["port"]; ok {
+		err = setFieldWithType(params["port"], input, "HealthCheckConfig.Port", awsint64)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := params["path"]; ok {
+		err = setFieldWithType(params["path"], input, "HealthCheckConfig.ResourcePath", awsstr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := params["search-string"]; ok {
+		err = setFieldWithType(params["search-string"], input, "HealthCheckConfig.SearchString", awsstr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := params["interval"]; ok {
+		err = setFieldWithType(params["interval"], input, "HealthCheckConfig.RequestInterval", awsint64)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := params["failure-threshold"]; ok {
+		err = setFieldWithType(params["failure-threshold"], input, "HealthCheckConfig.FailureThreshold", awsint64)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := params["regions"]; ok {
+		err = setFieldWithType(params["regions"], input, "HealthCheckConfig.Regions", awsstringslice)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := params["inverted"]; ok {
+		err = setFieldWithType(params["inverted"], input, "HealthCheckConfig.Inverted", awsbool)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := params["children"]; ok {
+		err = setFieldWithType(params["children"], input, "HealthCheckConfig.ChildHealthChecks", awsstringslice)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := params["healthy-threshold"]; ok {
+		err = setFieldWithType(params["healthy-threshold"], input, "HealthCheckConfig.HealthThreshold", awsint64)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := params["alarm"]; ok {
+		err = setFieldWithType(params["alarm"], input, "HealthCheckConfig.AlarmIdentifier.Name", awsstr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := params["alarm-region"]; ok {
+		err = setFieldWithType(params["alarm-region"], input, "HealthCheckConfig.AlarmIdentifier.Region", awsstr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Idempotency token
+	err = setIdempotencyToken(params, "create healthcheck", input, "CallerReference")
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	var output *route53.CreateHealthCheckOutput
+	output, err = d.CreateHealthCheck(input)
+	output = output
+	if err != nil {
+		return nil, fmt.Errorf("create healthcheck: %w", err)
+	}
+	d.logger.ExtraVerbosef("route53.CreateHealthCheck call took %s", time.Since(start))
+	id := aws.StringValue(output.HealthCheck.Id)
+
+	d.logger.Infof("create healthcheck '%s' done", id)
+	return id, nil
+}
+
+// This function was auto generated
+func (d *Route53Driver) Update_Healthcheck_DryRun(params map[string]interface{}) (interface{}, error) {
+	if _, ok := params["id"]; !ok {
+		return nil, errors.New("update healthcheck: missing required params 'id'")
+	}
+
+	d.logger.Verbose("params dry run: update healthcheck ok")
+	return fakeDryRunId("healthcheck"), nil
+}
+
+// This function was auto generated
+func (d *Route53Driver) Update_Healthcheck(params map[string]interface{}) (interface{}, error) {
+	input := &route53.UpdateHealthCheckInput{}
+	var err error
+
+	// Required params
+	err = setFieldWithType(params["id"], input, "HealthCheckId", awsstr)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extra params
+	if _, ok := params["host"]; ok {
+		err = setFieldWithType(params["host"], input, "FullyQualifiedDomainName", awsstr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := params["ip"]; ok {
+		err = setFieldWithType(params["ip"], input, "IPAddress", awsstr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := params["port"]; ok {
+		err = setFieldWithType(params["port"], input, "Port", awsint64)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := params["path"]; ok {
+		err = setFieldWithType(params["path"], input, "ResourcePath", awsstr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := params["search-string"]; ok {
+		err = setFieldWithType(params["search-string"], input, "SearchString", awsstr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := params["failure-threshold"]; ok {
+		err = setFieldWithType(params["failure-threshold"], input, "FailureThreshold", awsint64)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := params["regions"]; ok {
+		err = setFieldWithType(params["regions"], input, "Regions", awsstringslice)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := params["inverted"]; ok {
+		err = setFieldWithType(params["inverted"], input, "Inverted", awsbool)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := params["children"]; ok {
+		err = setFieldWithType(params["children"], input, "ChildHealthChecks", awsstringslice)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := params["healthy-threshold"]; ok {
+		err = setFieldWithType(params["healthy-threshold"], input, "HealthThreshold", awsint64)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := params["version"]; ok {
+		err = setFieldWithType(params["version"], input, "HealthCheckVersion", awsint64)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	start := time.Now()
+	var output *route53.UpdateHealthCheckOutput
+	output, err = d.UpdateHealthCheck(input)
+	output = output
+	if err != nil {
+		return nil, fmt.Errorf("update healthcheck: %w", err)
+	}
+	d.logger.ExtraVerbosef("route53.UpdateHealthCheck call took %s", time.Since(start))
+	d.logger.Info("update healthcheck done")
+	return output, nil
+}
+
+// This function was auto generated
+func (d *Route53Driver) Delete_Healthcheck_DryRun(params map[string]interface{}) (interface{}, error) {
+	if _, ok := params["id"]; !ok {
+		return nil, errors.New("delete healthcheck: missing required params 'id'")
+	}
+
+	d.logger.Verbose("params dry run: delete healthcheck ok")
+	return fakeDryRunId("healthcheck"), nil
+}
+
+// This function was auto generated
+func (d *Route53Driver) Delete_Healthcheck(params map[string]interface{}) (interface{}, error) {
+	input := &route53.DeleteHealthCheckInput{}
+	var err error
+
+	// Required params
+	err = setFieldWithType(params["id"], input, "HealthCheckId", awsstr)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	var output *route53.DeleteHealthCheckOutput
+	output, err = d.DeleteHealthCheck(input)
+	output = output
+	if err != nil {
+		return nil, fmt.Errorf("delete healthcheck: %w", err)
+	}
+	d.logger.ExtraVerbosef("route53.DeleteHealthCheck call took %s", time.Since(start))
+	d.logger.Info("delete healthcheck done")
+	return output, nil
+}
+
 // This function was auto generated
 func (d *LambdaDriver) Create_Function_DryRun(params map[string]interface{}) (interface{}, error) {
 	if _, ok := params["name"]; !ok {
@@ -5308,7 +5494,7 @@ func (d *LambdaDriver) Create_Function(params map[string]interface{}) (interface
 	output, err = d.CreateFunction(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create function: %s", err)
+		return nil, fmt.Errorf("create function: %w", err)
 	}
 	d.logger.ExtraVerbosef("lambda.CreateFunction call took %s", time.Since(start))
 	id := aws.StringValue(output.FunctionArn)
@@ -5351,7 +5537,7 @@ func (d *LambdaDriver) Delete_Function(params map[string]interface{}) (interface
 	output, err = d.DeleteFunction(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete function: %s", err)
+		return nil, fmt.Errorf("delete function: %w", err)
 	}
 	d.logger.ExtraVerbosef("lambda.DeleteFunction call took %s", time.Since(start))
 	d.logger.Info("delete function done")
@@ -5484,7 +5670,7 @@ func (d *CloudwatchDriver) Create_Alarm(params map[string]interface{}) (interfac
 	output, err = d.PutMetricAlarm(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create alarm: %s", err)
+		return nil, fmt.Errorf("create alarm: %w", err)
 	}
 	d.logger.ExtraVerbosef("cloudwatch.PutMetricAlarm call took %s", time.Since(start))
 	id := params["name"]
@@ -5519,7 +5705,7 @@ func (d *CloudwatchDriver) Delete_Alarm(params map[string]interface{}) (interfac
 	output, err = d.DeleteAlarms(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete alarm: %s", err)
+		return nil, fmt.Errorf("delete alarm: %w", err)
 	}
 	d.logger.ExtraVerbosef("cloudwatch.DeleteAlarms call took %s", time.Since(start))
 	d.logger.Info("delete alarm done")
@@ -5552,7 +5738,7 @@ func (d *CloudwatchDriver) Start_Alarm(params map[string]interface{}) (interface
 	output, err = d.EnableAlarmActions(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("start alarm: %s", err)
+		return nil, fmt.Errorf("start alarm: %w", err)
 	}
 	d.logger.ExtraVerbosef("cloudwatch.EnableAlarmActions call took %s", time.Since(start))
 	d.logger.Info("start alarm done")
@@ -5585,7 +5771,7 @@ func (d *CloudwatchDriver) Stop_Alarm(params map[string]interface{}) (interface{
 	output, err = d.DisableAlarmActions(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("stop alarm: %s", err)
+		return nil, fmt.Errorf("stop alarm: %w", err)
 	}
 	d.logger.ExtraVerbosef("cloudwatch.DisableAlarmActions call took %s", time.Since(start))
 	d.logger.Info("stop alarm done")
@@ -5677,12 +5863,18 @@ func (d *CloudformationDriver) Create_Stack(params map[string]interface{}) (inte
 		}
 	}
 
+	// Idempotency token
+	err = setIdempotencyToken(params, "create stack", input, "ClientRequestToken")
+	if err != nil {
+		return nil, err
+	}
+
 	start := time.Now()
 	var output *cloudformation.CreateStackOutput
 	output, err = d.CreateStack(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create stack: %s", err)
+		return nil, fmt.Errorf("create stack: %w", err)
 	}
 	d.logger.ExtraVerbosef("cloudformation.CreateStack call took %s", time.Since(start))
 	id := aws.StringValue(output.StackId)
@@ -5773,7 +5965,7 @@ func (d *CloudformationDriver) Update_Stack(params map[string]interface{}) (inte
 	output, err = d.UpdateStack(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("update stack: %s", err)
+		return nil, fmt.Errorf("update stack: %w", err)
 	}
 	d.logger.ExtraVerbosef("cloudformation.UpdateStack call took %s", time.Since(start))
 	id := aws.StringValue(output.StackId)
@@ -5816,7 +6008,7 @@ func (d *CloudformationDriver) Delete_Stack(params map[string]interface{}) (inte
 	output, err = d.DeleteStack(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete stack: %s", err)
+		return nil, fmt.Errorf("delete stack: %w", err)
 	}
 	d.logger.ExtraVerbosef("cloudformation.DeleteStack call took %s", time.Since(start))
 	d.logger.Info("delete stack done")
@@ -5889,7 +6081,7 @@ func (d *ApplicationautoscalingDriver) Create_Appscalingtarget(params map[string
 	output, err = d.RegisterScalableTarget(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create appscalingtarget: %s", err)
+		return nil, fmt.Errorf("create appscalingtarget: %w", err)
 	}
 	d.logger.ExtraVerbosef("applicationautoscaling.RegisterScalableTarget call took %s", time.Since(start))
 	d.logger.Info("create appscalingtarget done")
@@ -5938,7 +6130,7 @@ func (d *ApplicationautoscalingDriver) Delete_Appscalingtarget(params map[string
 	output, err = d.DeregisterScalableTarget(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete appscalingtarget: %s", err)
+		return nil, fmt.Errorf("delete appscalingtarget: %w", err)
 	}
 	d.logger.ExtraVerbosef("applicationautoscaling.DeregisterScalableTarget call took %s", time.Since(start))
 	d.logger.Info("delete appscalingtarget done")
@@ -6039,7 +6231,7 @@ func (d *ApplicationautoscalingDriver) Create_Appscalingpolicy(params map[string
 	output, err = d.PutScalingPolicy(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("create appscalingpolicy: %s", err)
+		return nil, fmt.Errorf("create appscalingpolicy: %w", err)
 	}
 	d.logger.ExtraVerbosef("applicationautoscaling.PutScalingPolicy call took %s", time.Since(start))
 	id := aws.StringValue(output.PolicyARN)
@@ -6098,7 +6290,7 @@ func (d *ApplicationautoscalingDriver) Delete_Appscalingpolicy(params map[string
 	output, err = d.DeleteScalingPolicy(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("delete appscalingpolicy: %s", err)
+		return nil, fmt.Errorf("delete appscalingpolicy: %w", err)
 	}
 	d.logger.ExtraVerbosef("applicationautoscaling.DeleteScalingPolicy call took %s", time.Since(start))
 	d.logger.Info("delete appscalingpolicy done")
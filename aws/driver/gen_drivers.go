@@ -116,6 +116,12 @@ func (d *Ec2Driver) Lookup(lookups ...string) (driverFn driver.DriverFn, err err
 		}
 		return d.Stop_Instance, nil
 
+	case "rebootinstance":
+		if d.dryRun {
+			return d.Reboot_Instance_DryRun, nil
+		}
+		return d.Reboot_Instance, nil
+
 	case "checkinstance":
 		if d.dryRun {
 			return d.Check_Instance_DryRun, nil
@@ -266,6 +272,102 @@ func (d *Ec2Driver) Lookup(lookups ...string) (driverFn driver.DriverFn, err err
 		}
 		return d.Check_Natgateway, nil
 
+	case "createfleet":
+		if d.dryRun {
+			return d.Create_Fleet_DryRun, nil
+		}
+		return d.Create_Fleet, nil
+
+	case "updatefleet":
+		if d.dryRun {
+			return d.Update_Fleet_DryRun, nil
+		}
+		return d.Update_Fleet, nil
+
+	case "deletefleet":
+		if d.dryRun {
+			return d.Delete_Fleet_DryRun, nil
+		}
+		return d.Delete_Fleet, nil
+
+	case "createdhcpoptions":
+		if d.dryRun {
+			return d.Create_Dhcpoptions_DryRun, nil
+		}
+		return d.Create_Dhcpoptions, nil
+
+	case "deletedhcpoptions":
+		if d.dryRun {
+			return d.Delete_Dhcpoptions_DryRun, nil
+		}
+		return d.Delete_Dhcpoptions, nil
+
+	case "attachdhcpoptions":
+		if d.dryRun {
+			return d.Attach_Dhcpoptions_DryRun, nil
+		}
+		return d.Attach_Dhcpoptions, nil
+
+	case "createplacementgroup":
+		if d.dryRun {
+			return d.Create_Placementgroup_DryRun, nil
+		}
+		return d.Create_Placementgroup, nil
+
+	case "deleteplacementgroup":
+		if d.dryRun {
+			return d.Delete_Placementgroup_DryRun, nil
+		}
+		return d.Delete_Placementgroup, nil
+
+	case "createprefixlist":
+		if d.dryRun {
+			return d.Create_Prefixlist_DryRun, nil
+		}
+		return d.Create_Prefixlist, nil
+
+	case "updateprefixlist":
+		if d.dryRun {
+			return d.Update_Prefixlist_DryRun, nil
+		}
+		return d.Update_Prefixlist, nil
+
+	case "deleteprefixlist":
+		if d.dryRun {
+			return d.Delete_Prefixlist_DryRun, nil
+		}
+		return d.Delete_Prefixlist, nil
+
+	case "createnetworkacl":
+		if d.dryRun {
+			return d.Create_Networkacl_DryRun, nil
+		}
+		return d.Create_Networkacl, nil
+
+	case "deletenetworkacl":
+		if d.dryRun {
+			return d.Delete_Networkacl_DryRun, nil
+		}
+		return d.Delete_Networkacl, nil
+
+	case "attachnetworkacl":
+		if d.dryRun {
+			return d.Attach_Networkacl_DryRun, nil
+		}
+		return d.Attach_Networkacl, nil
+
+	case "createnetworkaclrule":
+		if d.dryRun {
+			return d.Create_Networkaclrule_DryRun, nil
+		}
+		return d.Create_Networkaclrule, nil
+
+	case "deletenetworkaclrule":
+		if d.dryRun {
+			return d.Delete_Networkaclrule_DryRun, nil
+		}
+		return d.Delete_Networkaclrule, nil
+
 	case "createroutetable":
 		if d.dryRun {
 			return d.Create_Routetable_DryRun, nil
@@ -424,6 +526,18 @@ func (d *Elbv2Driver) Lookup(lookups ...string) (driverFn driver.DriverFn, err e
 		}
 		return d.Detach_Instance, nil
 
+	case "createloadbalancerstack":
+		if d.dryRun {
+			return d.Create_Loadbalancerstack_DryRun, nil
+		}
+		return d.Create_Loadbalancerstack, nil
+
+	case "deleteloadbalancerstack":
+		if d.dryRun {
+			return d.Delete_Loadbalancerstack_DryRun, nil
+		}
+		return d.Delete_Loadbalancerstack, nil
+
 	default:
 		return nil, driver.ErrDriverFnNotFound
 	}
@@ -574,6 +688,12 @@ func (d *EcrDriver) Lookup(lookups ...string) (driverFn driver.DriverFn, err err
 		}
 		return d.Delete_Repository, nil
 
+	case "updaterepository":
+		if d.dryRun {
+			return d.Update_Repository_DryRun, nil
+		}
+		return d.Update_Repository, nil
+
 	case "authenticateregistry":
 		if d.dryRun {
 			return d.Authenticate_Registry_DryRun, nil
@@ -814,6 +934,18 @@ func (d *IamDriver) Lookup(lookups ...string) (driverFn driver.DriverFn, err err
 		}
 		return d.Detach_Policy, nil
 
+	case "createinlinepolicy":
+		if d.dryRun {
+			return d.Create_Inlinepolicy_DryRun, nil
+		}
+		return d.Create_Inlinepolicy, nil
+
+	case "deleteinlinepolicy":
+		if d.dryRun {
+			return d.Delete_Inlinepolicy_DryRun, nil
+		}
+		return d.Delete_Inlinepolicy, nil
+
 	default:
 		return nil, driver.ErrDriverFnNotFound
 	}
@@ -990,6 +1122,24 @@ func (d *Route53Driver) Lookup(lookups ...string) (driverFn driver.DriverFn, err
 		}
 		return d.Delete_Record, nil
 
+	case "createhealthcheck":
+		if d.dryRun {
+			return d.Create_Healthcheck_DryRun, nil
+		}
+		return d.Create_Healthcheck, nil
+
+	case "updatehealthcheck":
+		if d.dryRun {
+			return d.Update_Healthcheck_DryRun, nil
+		}
+		return d.Update_Healthcheck, nil
+
+	case "deletehealthcheck":
+		if d.dryRun {
+			return d.Delete_Healthcheck_DryRun, nil
+		}
+		return d.Delete_Healthcheck, nil
+
 	default:
 		return nil, driver.ErrDriverFnNotFound
 	}
@@ -1022,6 +1172,24 @@ func (d *LambdaDriver) Lookup(lookups ...string) (driverFn driver.DriverFn, err
 		}
 		return d.Delete_Function, nil
 
+	case "createstream":
+		if d.dryRun {
+			return d.Create_Stream_DryRun, nil
+		}
+		return d.Create_Stream, nil
+
+	case "updatestream":
+		if d.dryRun {
+			return d.Update_Stream_DryRun, nil
+		}
+		return d.Update_Stream, nil
+
+	case "deletestream":
+		if d.dryRun {
+			return d.Delete_Stream_DryRun, nil
+		}
+		return d.Delete_Stream, nil
+
 	default:
 		return nil, driver.ErrDriverFnNotFound
 	}
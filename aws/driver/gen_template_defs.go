@@ -32,6 +32,7 @@ var APIPerTemplateDefName = map[string]string{
 	"deleteinstance":            "ec2",
 	"startinstance":             "ec2",
 	"stopinstance":              "ec2",
+	"rebootinstance":            "ec2",
 	"checkinstance":             "ec2",
 	"createsecuritygroup":       "ec2",
 	"updatesecuritygroup":       "ec2",
@@ -57,6 +58,22 @@ var APIPerTemplateDefName = map[string]string{
 	"createnatgateway":          "ec2",
 	"deletenatgateway":          "ec2",
 	"checknatgateway":           "ec2",
+	"createfleet":               "ec2",
+	"updatefleet":               "ec2",
+	"deletefleet":               "ec2",
+	"createdhcpoptions":         "ec2",
+	"deletedhcpoptions":         "ec2",
+	"attachdhcpoptions":         "ec2",
+	"createplacementgroup":      "ec2",
+	"deleteplacementgroup":      "ec2",
+	"createprefixlist":          "ec2",
+	"updateprefixlist":          "ec2",
+	"deleteprefixlist":          "ec2",
+	"createnetworkacl":          "ec2",
+	"deletenetworkacl":          "ec2",
+	"attachnetworkacl":          "ec2",
+	"createnetworkaclrule":      "ec2",
+	"deletenetworkaclrule":      "ec2",
 	"createroutetable":          "ec2",
 	"deleteroutetable":          "ec2",
 	"attachroutetable":          "ec2",
@@ -80,6 +97,8 @@ var APIPerTemplateDefName = map[string]string{
 	"deletetargetgroup":         "elbv2",
 	"attachinstance":            "elbv2",
 	"detachinstance":            "elbv2",
+	"createloadbalancerstack":   "elbv2",
+	"deleteloadbalancerstack":   "elbv2",
 	"createlaunchconfiguration": "autoscaling",
 	"deletelaunchconfiguration": "autoscaling",
 	"createscalinggroup":        "autoscaling",
@@ -95,6 +114,7 @@ var APIPerTemplateDefName = map[string]string{
 	"deletedbsubnetgroup":       "rds",
 	"createrepository":          "ecr",
 	"deleterepository":          "ecr",
+	"updaterepository":          "ecr",
 	"authenticateregistry":      "ecr",
 	"createcontainercluster":    "ecs",
 	"deletecontainercluster":    "ecs",
@@ -125,6 +145,8 @@ var APIPerTemplateDefName = map[string]string{
 	"deletepolicy":              "iam",
 	"attachpolicy":              "iam",
 	"detachpolicy":              "iam",
+	"createinlinepolicy":        "iam",
+	"deleteinlinepolicy":        "iam",
 	"createbucket":              "s3",
 	"updatebucket":              "s3",
 	"deletebucket":              "s3",
@@ -141,8 +163,14 @@ var APIPerTemplateDefName = map[string]string{
 	"deletezone":                "route53",
 	"createrecord":              "route53",
 	"deleterecord":              "route53",
+	"createhealthcheck":         "route53",
+	"updatehealthcheck":         "route53",
+	"deletehealthcheck":         "route53",
 	"createfunction":            "lambda",
 	"deletefunction":            "lambda",
+	"createstream":              "lambda",
+	"updatestream":              "lambda",
+	"deletestream":              "lambda",
 	"createalarm":               "cloudwatch",
 	"deletealarm":               "cloudwatch",
 	"startalarm":                "cloudwatch",
@@ -162,6 +190,119 @@ var APIPerTemplateDefName = map[string]string{
 	"deleteappscalingpolicy":    "applicationautoscaling",
 }
 
+// APICallsPerTemplateDefName are the AWS API calls made by the generated drivers
+// (see ManualDriverAPICalls for the drivers written by hand)
+var APICallsPerTemplateDefName = map[string][]string{
+	"createvpc":                 {"CreateVpc", "CreateTags"},
+	"deletevpc":                 {"DeleteVpc"},
+	"createsubnet":              {"CreateSubnet", "CreateTags"},
+	"updatesubnet":              {"ModifySubnetAttribute"},
+	"deletesubnet":              {"DeleteSubnet"},
+	"createinstance":            {"RunInstances", "CreateTags"},
+	"updateinstance":            {"ModifyInstanceAttribute"},
+	"deleteinstance":            {"TerminateInstances"},
+	"deletesecuritygroup":       {"DeleteSecurityGroup"},
+	"importimage":               {"ImportImage"},
+	"createvolume":              {"CreateVolume"},
+	"deletevolume":              {"DeleteVolume"},
+	"attachvolume":              {"AttachVolume"},
+	"detachvolume":              {"DetachVolume"},
+	"createsnapshot":            {"CreateSnapshot"},
+	"deletesnapshot":            {"DeleteSnapshot"},
+	"createinternetgateway":     {"CreateInternetGateway"},
+	"deleteinternetgateway":     {"DeleteInternetGateway"},
+	"attachinternetgateway":     {"AttachInternetGateway"},
+	"detachinternetgateway":     {"DetachInternetGateway"},
+	"deletenatgateway":          {"DeleteNatGateway"},
+	"deletedhcpoptions":         {"DeleteDhcpOptions"},
+	"attachdhcpoptions":         {"AssociateDhcpOptions"},
+	"createplacementgroup":      {"CreatePlacementGroup"},
+	"deleteplacementgroup":      {"DeletePlacementGroup"},
+	"createnetworkacl":          {"CreateNetworkAcl"},
+	"deletenetworkacl":          {"DeleteNetworkAcl"},
+	"createroutetable":          {"CreateRouteTable"},
+	"deleteroutetable":          {"DeleteRouteTable"},
+	"attachroutetable":          {"AssociateRouteTable"},
+	"detachroutetable":          {"DisassociateRouteTable"},
+	"createroute":               {"CreateRoute"},
+	"deleteroute":               {"DeleteRoute"},
+	"deletekeypair":             {"DeleteKeyPair"},
+	"createelasticip":           {"AllocateAddress"},
+	"deleteelasticip":           {"ReleaseAddress"},
+	"attachelasticip":           {"AssociateAddress"},
+	"detachelasticip":           {"DisassociateAddress"},
+	"createloadbalancer":        {"CreateLoadBalancer"},
+	"deleteloadbalancer":        {"DeleteLoadBalancer"},
+	"createlistener":            {"CreateListener"},
+	"deletelistener":            {"DeleteListener"},
+	"createtargetgroup":         {"CreateTargetGroup"},
+	"deletetargetgroup":         {"DeleteTargetGroup"},
+	"attachinstance":            {"RegisterTargets"},
+	"detachinstance":            {"DeregisterTargets"},
+	"createlaunchconfiguration": {"CreateLaunchConfiguration"},
+	"deletelaunchconfiguration": {"DeleteLaunchConfiguration"},
+	"createscalinggroup":        {"CreateAutoScalingGroup"},
+	"updatescalinggroup":        {"UpdateAutoScalingGroup"},
+	"deletescalinggroup":        {"DeleteAutoScalingGroup"},
+	"createscalingpolicy":       {"PutScalingPolicy"},
+	"deletescalingpolicy":       {"DeletePolicy"},
+	"createdatabase":            {"CreateDBInstance"},
+	"deletedatabase":            {"DeleteDBInstance"},
+	"createdbsubnetgroup":       {"CreateDBSubnetGroup"},
+	"deletedbsubnetgroup":       {"DeleteDBSubnetGroup"},
+	"createrepository":          {"CreateRepository"},
+	"deleterepository":          {"DeleteRepository"},
+	"createcontainercluster":    {"CreateCluster"},
+	"deletecontainercluster":    {"DeleteCluster"},
+	"startcontainerservice":     {"CreateService"},
+	"stopcontainerservice":      {"DeleteService"},
+	"updatecontainerservice":    {"UpdateService"},
+	"startcontainertask":        {"RunTask"},
+	"createuser":                {"CreateUser"},
+	"deleteuser":                {"DeleteUser"},
+	"attachuser":                {"AddUserToGroup"},
+	"detachuser":                {"RemoveUserFromGroup"},
+	"deleteaccesskey":           {"DeleteAccessKey"},
+	"createloginprofile":        {"CreateLoginProfile"},
+	"updateloginprofile":        {"UpdateLoginProfile"},
+	"deleteloginprofile":        {"DeleteLoginProfile"},
+	"creategroup":               {"CreateGroup"},
+	"deletegroup":               {"DeleteGroup"},
+	"attachrole":                {"AddRoleToInstanceProfile"},
+	"detachrole":                {"RemoveRoleFromInstanceProfile"},
+	"createinstanceprofile":     {"CreateInstanceProfile"},
+	"deleteinstanceprofile":     {"DeleteInstanceProfile"},
+	"deletepolicy":              {"DeletePolicy"},
+	"createbucket":              {"CreateBucket"},
+	"deletebucket":              {"DeleteBucket"},
+	"updates3object":            {"PutObjectAcl"},
+	"deletes3object":            {"DeleteObject"},
+	"createtopic":               {"CreateTopic"},
+	"deletetopic":               {"DeleteTopic"},
+	"createsubscription":        {"Subscribe"},
+	"deletesubscription":        {"Unsubscribe"},
+	"createqueue":               {"CreateQueue"},
+	"deletequeue":               {"DeleteQueue"},
+	"createzone":                {"CreateHostedZone"},
+	"deletezone":                {"DeleteHostedZone"},
+	"createhealthcheck":         {"CreateHealthCheck"},
+	"updatehealthcheck":         {"UpdateHealthCheck"},
+	"deletehealthcheck":         {"DeleteHealthCheck"},
+	"createfunction":            {"CreateFunction"},
+	"deletefunction":            {"DeleteFunction"},
+	"createalarm":               {"PutMetricAlarm"},
+	"deletealarm":               {"DeleteAlarms"},
+	"startalarm":                {"EnableAlarmActions"},
+	"stopalarm":                 {"DisableAlarmActions"},
+	"createstack":               {"CreateStack"},
+	"updatestack":               {"UpdateStack"},
+	"deletestack":               {"DeleteStack"},
+	"createappscalingtarget":    {"RegisterScalableTarget"},
+	"deleteappscalingtarget":    {"DeregisterScalableTarget"},
+	"createappscalingpolicy":    {"PutScalingPolicy"},
+	"deleteappscalingpolicy":    {"DeleteScalingPolicy"},
+}
+
 var AWSTemplatesDefinitions = map[string]template.Definition{
 	"createvpc": {
 		Action:         "create",
@@ -203,7 +344,7 @@ var AWSTemplatesDefinitions = map[string]template.Definition{
 		Entity:         "instance",
 		Api:            "ec2",
 		RequiredParams: []string{"count", "image", "name", "subnet", "type"},
-		ExtraParams:    []string{"ip", "keypair", "lock", "role", "securitygroup", "userdata"},
+		ExtraParams:    []string{"client-token", "ip", "keypair", "lock", "role", "securitygroup", "userdata"},
 	},
 	"updateinstance": {
 		Action:         "update",
@@ -224,14 +365,21 @@ var AWSTemplatesDefinitions = map[string]template.Definition{
 		Entity:         "instance",
 		Api:            "ec2",
 		RequiredParams: []string{"id"},
-		ExtraParams:    []string{},
+		ExtraParams:    []string{"batch", "delay", "wait", "wait-timeout"},
 	},
 	"stopinstance": {
 		Action:         "stop",
 		Entity:         "instance",
 		Api:            "ec2",
 		RequiredParams: []string{"id"},
-		ExtraParams:    []string{},
+		ExtraParams:    []string{"batch", "delay", "wait", "wait-timeout"},
+	},
+	"rebootinstance": {
+		Action:         "reboot",
+		Entity:         "instance",
+		Api:            "ec2",
+		RequiredParams: []string{"id"},
+		ExtraParams:    []string{"batch", "delay", "wait", "wait-timeout"},
 	},
 	"checkinstance": {
 		Action:         "check",
@@ -245,7 +393,7 @@ var AWSTemplatesDefinitions = map[string]template.Definition{
 		Entity:         "securitygroup",
 		Api:            "ec2",
 		RequiredParams: []string{"description", "name", "vpc"},
-		ExtraParams:    []string{},
+		ExtraParams:    []string{"inbound-rules", "outbound-rules"},
 	},
 	"updatesecuritygroup": {
 		Action:         "update",
@@ -286,8 +434,8 @@ var AWSTemplatesDefinitions = map[string]template.Definition{
 		Action:         "copy",
 		Entity:         "image",
 		Api:            "ec2",
-		RequiredParams: []string{"name", "source-id", "source-region"},
-		ExtraParams:    []string{"description", "encrypted"},
+		RequiredParams: []string{"name", "source-id"},
+		ExtraParams:    []string{"client-token", "description", "encrypted", "kmskey", "source-region", "to-region", "wait"},
 	},
 	"importimage": {
 		Action:         "import",
@@ -356,8 +504,8 @@ var AWSTemplatesDefinitions = map[string]template.Definition{
 		Action:         "copy",
 		Entity:         "snapshot",
 		Api:            "ec2",
-		RequiredParams: []string{"source-id", "source-region"},
-		ExtraParams:    []string{"description", "encrypted"},
+		RequiredParams: []string{"source-id"},
+		ExtraParams:    []string{"description", "encrypted", "kmskey", "source-region", "to-region", "wait"},
 	},
 	"createinternetgateway": {
 		Action:         "create",
@@ -392,7 +540,7 @@ var AWSTemplatesDefinitions = map[string]template.Definition{
 		Entity:         "natgateway",
 		Api:            "ec2",
 		RequiredParams: []string{"elasticip-id", "subnet"},
-		ExtraParams:    []string{},
+		ExtraParams:    []string{"client-token", "update-routes"},
 	},
 	"deletenatgateway": {
 		Action:         "delete",
@@ -408,6 +556,118 @@ var AWSTemplatesDefinitions = map[string]template.Definition{
 		RequiredParams: []string{"id", "state", "timeout"},
 		ExtraParams:    []string{},
 	},
+	"createfleet": {
+		Action:         "create",
+		Entity:         "fleet",
+		Api:            "ec2",
+		RequiredParams: []string{"target-capacity"},
+		ExtraParams:    []string{"allocation-strategy", "client-token", "image", "keypair", "launch-template", "launch-template-version", "on-demand-capacity", "request-type", "role", "securitygroup", "spot-price", "subnet", "type"},
+	},
+	"updatefleet": {
+		Action:         "update",
+		Entity:         "fleet",
+		Api:            "ec2",
+		RequiredParams: []string{"id"},
+		ExtraParams:    []string{"excess-capacity-termination", "on-demand-capacity", "target-capacity"},
+	},
+	"deletefleet": {
+		Action:         "delete",
+		Entity:         "fleet",
+		Api:            "ec2",
+		RequiredParams: []string{"id"},
+		ExtraParams:    []string{"terminate-instances"},
+	},
+	"createdhcpoptions": {
+		Action:         "create",
+		Entity:         "dhcpoptions",
+		Api:            "ec2",
+		RequiredParams: []string{},
+		ExtraParams:    []string{"domain-name", "domain-name-servers", "ntp-servers"},
+	},
+	"deletedhcpoptions": {
+		Action:         "delete",
+		Entity:         "dhcpoptions",
+		Api:            "ec2",
+		RequiredParams: []string{"id"},
+		ExtraParams:    []string{},
+	},
+	"attachdhcpoptions": {
+		Action:         "attach",
+		Entity:         "dhcpoptions",
+		Api:            "ec2",
+		RequiredParams: []string{"id", "vpc"},
+		ExtraParams:    []string{},
+	},
+	"createplacementgroup": {
+		Action:         "create",
+		Entity:         "placementgroup",
+		Api:            "ec2",
+		RequiredParams: []string{"name", "strategy"},
+		ExtraParams:    []string{},
+	},
+	"deleteplacementgroup": {
+		Action:         "delete",
+		Entity:         "placementgroup",
+		Api:            "ec2",
+		RequiredParams: []string{"name"},
+		ExtraParams:    []string{},
+	},
+	"createprefixlist": {
+		Action:         "create",
+		Entity:         "prefixlist",
+		Api:            "ec2",
+		RequiredParams: []string{"max-entries", "name"},
+		ExtraParams:    []string{"cidrs", "family"},
+	},
+	"updateprefixlist": {
+		Action:         "update",
+		Entity:         "prefixlist",
+		Api:            "ec2",
+		RequiredParams: []string{"id"},
+		ExtraParams:    []string{"add-cidrs", "name", "remove-cidrs"},
+	},
+	"deleteprefixlist": {
+		Action:         "delete",
+		Entity:         "prefixlist",
+		Api:            "ec2",
+		RequiredParams: []string{"id"},
+		ExtraParams:    []string{},
+	},
+	"createnetworkacl": {
+		Action:         "create",
+		Entity:         "networkacl",
+		Api:            "ec2",
+		RequiredParams: []string{"vpc"},
+		ExtraParams:    []string{},
+	},
+	"deletenetworkacl": {
+		Action:         "delete",
+		Entity:         "networkacl",
+		Api:            "ec2",
+		RequiredParams: []string{"id"},
+		ExtraParams:    []string{},
+	},
+	"attachnetworkacl": {
+		Action:         "attach",
+		Entity:         "networkacl",
+		Api:            "ec2",
+		RequiredParams: []string{"id", "subnet"},
+		ExtraParams:    []string{},
+	},
+	"createnetworkaclrule": {
+		Action:         "create",
+		Entity:         "networkaclrule",
+		Api:            "ec2",
+		RequiredParams: []string{"action", "cidr", "id", "number", "protocol"},
+		ExtraParams:    []string{"outbound", "portrange"},
+	},
+	"deletenetworkaclrule": {
+		Action:         "delete",
+		Entity:         "networkaclrule",
+		Api:            "ec2",
+		RequiredParams: []string{"id", "number"},
+		ExtraParams:    []string{"outbound"},
+	},
 	"createroutetable": {
 		Action:         "create",
 		Entity:         "routetable",
@@ -569,6 +829,20 @@ var AWSTemplatesDefinitions = map[string]template.Definition{
 		RequiredParams: []string{"id", "targetgroup"},
 		ExtraParams:    []string{},
 	},
+	"createloadbalancerstack": {
+		Action:         "create",
+		Entity:         "loadbalancerstack",
+		Api:            "elbv2",
+		RequiredParams: []string{"instances", "name", "port", "subnets"},
+		ExtraParams:    []string{"certificate", "healthcheckpath", "listenerport", "protocol", "scheme", "securitygroups"},
+	},
+	"deleteloadbalancerstack": {
+		Action:         "delete",
+		Entity:         "loadbalancerstack",
+		Api:            "elbv2",
+		RequiredParams: []string{"id"},
+		ExtraParams:    []string{},
+	},
 	"createlaunchconfiguration": {
 		Action:         "create",
 		Entity:         "launchconfiguration",
@@ -674,6 +948,13 @@ var AWSTemplatesDefinitions = map[string]template.Definition{
 		RequiredParams: []string{"name"},
 		ExtraParams:    []string{"account", "force"},
 	},
+	"updaterepository": {
+		Action:         "update",
+		Entity:         "repository",
+		Api:            "ecr",
+		RequiredParams: []string{"name"},
+		ExtraParams:    []string{"account", "lifecycle-policy-file", "scan-on-push"},
+	},
 	"authenticateregistry": {
 		Action:         "authenticate",
 		Entity:         "registry",
@@ -700,7 +981,7 @@ var AWSTemplatesDefinitions = map[string]template.Definition{
 		Entity:         "containerservice",
 		Api:            "ecs",
 		RequiredParams: []string{"cluster", "deployment-name", "desired-count", "name"},
-		ExtraParams:    []string{"loadbalancer.container-name", "loadbalancer.container-port", "loadbalancer.targetgroup", "role"},
+		ExtraParams:    []string{"client-token", "loadbalancer.container-name", "loadbalancer.container-port", "loadbalancer.targetgroup", "role"},
 	},
 	"stopcontainerservice": {
 		Action:         "stop",
@@ -884,6 +1165,20 @@ var AWSTemplatesDefinitions = map[string]template.Definition{
 		RequiredParams: []string{"arn"},
 		ExtraParams:    []string{"group", "role", "user"},
 	},
+	"createinlinepolicy": {
+		Action:         "create",
+		Entity:         "inlinepolicy",
+		Api:            "iam",
+		RequiredParams: []string{"name"},
+		ExtraParams:    []string{"action", "document", "effect", "group", "resource", "role", "user"},
+	},
+	"deleteinlinepolicy": {
+		Action:         "delete",
+		Entity:         "inlinepolicy",
+		Api:            "iam",
+		RequiredParams: []string{"name"},
+		ExtraParams:    []string{"group", "role", "user"},
+	},
 	"createbucket": {
 		Action:         "create",
 		Entity:         "bucket",
@@ -896,7 +1191,7 @@ var AWSTemplatesDefinitions = map[string]template.Definition{
 		Entity:         "bucket",
 		Api:            "s3",
 		RequiredParams: []string{"name"},
-		ExtraParams:    []string{"acl", "enforce-https", "index-suffix", "public-website", "redirect-hostname"},
+		ExtraParams:    []string{"acl", "enforce-https", "index-suffix", "public-website", "redirect-hostname", "replication", "replication-destination", "replication-prefix", "replication-role", "replication-storageclass"},
 	},
 	"deletebucket": {
 		Action:         "delete",
@@ -910,7 +1205,7 @@ var AWSTemplatesDefinitions = map[string]template.Definition{
 		Entity:         "s3object",
 		Api:            "s3",
 		RequiredParams: []string{"bucket", "file"},
-		ExtraParams:    []string{"acl", "name"},
+		ExtraParams:    []string{"acl", "concurrency", "name"},
 	},
 	"updates3object": {
 		Action:         "update",
@@ -987,13 +1282,34 @@ var AWSTemplatesDefinitions = map[string]template.Definition{
 		Entity:         "record",
 		Api:            "route53",
 		RequiredParams: []string{"name", "ttl", "type", "value", "zone"},
-		ExtraParams:    []string{"comment"},
+		ExtraParams:    []string{"comment", "identifier", "weight"},
 	},
 	"deleterecord": {
 		Action:         "delete",
 		Entity:         "record",
 		Api:            "route53",
 		RequiredParams: []string{"name", "ttl", "type", "value", "zone"},
+		ExtraParams:    []string{"identifier", "weight"},
+	},
+	"createhealthcheck": {
+		Action:         "create",
+		Entity:         "healthcheck",
+		Api:            "route53",
+		RequiredParams: []string{"type"},
+		ExtraParams:    []string{"alarm", "alarm-region", "children", "client-token", "failure-threshold", "healthy-threshold", "host", "interval", "inverted", "ip", "path", "port", "regions", "search-string"},
+	},
+	"updatehealthcheck": {
+		Action:         "update",
+		Entity:         "healthcheck",
+		Api:            "route53",
+		RequiredParams: []string{"id"},
+		ExtraParams:    []string{"children", "failure-threshold", "healthy-threshold", "host", "inverted", "ip", "path", "port", "regions", "search-string", "version"},
+	},
+	"deletehealthcheck": {
+		Action:         "delete",
+		Entity:         "healthcheck",
+		Api:            "route53",
+		RequiredParams: []string{"id"},
 		ExtraParams:    []string{},
 	},
 	"createfunction": {
@@ -1010,6 +1326,27 @@ var AWSTemplatesDefinitions = map[string]template.Definition{
 		RequiredParams: []string{"id"},
 		ExtraParams:    []string{"version"},
 	},
+	"createstream": {
+		Action:         "create",
+		Entity:         "stream",
+		Api:            "lambda",
+		RequiredParams: []string{"name"},
+		ExtraParams:    []string{"shards"},
+	},
+	"updatestream": {
+		Action:         "update",
+		Entity:         "stream",
+		Api:            "lambda",
+		RequiredParams: []string{"name", "shards"},
+		ExtraParams:    []string{},
+	},
+	"deletestream": {
+		Action:         "delete",
+		Entity:         "stream",
+		Api:            "lambda",
+		RequiredParams: []string{"name"},
+		ExtraParams:    []string{"force"},
+	},
 	"createalarm": {
 		Action:         "create",
 		Entity:         "alarm",
@@ -1070,8 +1407,8 @@ var AWSTemplatesDefinitions = map[string]template.Definition{
 		Action:         "update",
 		Entity:         "distribution",
 		Api:            "cloudfront",
-		RequiredParams: []string{"enable", "id"},
-		ExtraParams:    []string{},
+		RequiredParams: []string{"id"},
+		ExtraParams:    []string{"certificate", "compress", "default-ttl", "enable", "forward-cookies", "forward-queries", "https-behaviour", "max-ttl", "min-ttl", "origin-domain", "origin-id", "origin-path", "path-pattern", "target-origin"},
 	},
 	"deletedistribution": {
 		Action:         "delete",
@@ -1085,7 +1422,7 @@ var AWSTemplatesDefinitions = map[string]template.Definition{
 		Entity:         "stack",
 		Api:            "cloudformation",
 		RequiredParams: []string{"name", "template-file"},
-		ExtraParams:    []string{"capabilities", "disable-rollback", "notifications", "on-failure", "parameters", "policy-file", "resource-types", "role", "timeout"},
+		ExtraParams:    []string{"capabilities", "client-token", "disable-rollback", "notifications", "on-failure", "parameters", "policy-file", "resource-types", "role", "timeout"},
 	},
 	"updatestack": {
 		Action:         "update",
@@ -1143,6 +1480,7 @@ func DriverSupportedActions() map[string][]string {
 	supported["delete"] = append(supported["delete"], "instance")
 	supported["start"] = append(supported["start"], "instance")
 	supported["stop"] = append(supported["stop"], "instance")
+	supported["reboot"] = append(supported["reboot"], "instance")
 	supported["check"] = append(supported["check"], "instance")
 	supported["create"] = append(supported["create"], "securitygroup")
 	supported["update"] = append(supported["update"], "securitygroup")
@@ -1168,6 +1506,22 @@ func DriverSupportedActions() map[string][]string {
 	supported["create"] = append(supported["create"], "natgateway")
 	supported["delete"] = append(supported["delete"], "natgateway")
 	supported["check"] = append(supported["check"], "natgateway")
+	supported["create"] = append(supported["create"], "fleet")
+	supported["update"] = append(supported["update"], "fleet")
+	supported["delete"] = append(supported["delete"], "fleet")
+	supported["create"] = append(supported["create"], "dhcpoptions")
+	supported["delete"] = append(supported["delete"], "dhcpoptions")
+	supported["attach"] = append(supported["attach"], "dhcpoptions")
+	supported["create"] = append(supported["create"], "placementgroup")
+	supported["delete"] = append(supported["delete"], "placementgroup")
+	supported["create"] = append(supported["create"], "prefixlist")
+	supported["update"] = append(supported["update"], "prefixlist")
+	supported["delete"] = append(supported["delete"], "prefixlist")
+	supported["create"] = append(supported["create"], "networkacl")
+	supported["delete"] = append(supported["delete"], "networkacl")
+	supported["attach"] = append(supported["attach"], "networkacl")
+	supported["create"] = append(supported["create"], "networkaclrule")
+	supported["delete"] = append(supported["delete"], "networkaclrule")
 	supported["create"] = append(supported["create"], "routetable")
 	supported["delete"] = append(supported["delete"], "routetable")
 	supported["attach"] = append(supported["attach"], "routetable")
@@ -1191,6 +1545,8 @@ func DriverSupportedActions() map[string][]string {
 	supported["delete"] = append(supported["delete"], "targetgroup")
 	supported["attach"] = append(supported["attach"], "instance")
 	supported["detach"] = append(supported["detach"], "instance")
+	supported["create"] = append(supported["create"], "loadbalancerstack")
+	supported["delete"] = append(supported["delete"], "loadbalancerstack")
 	supported["create"] = append(supported["create"], "launchconfiguration")
 	supported["delete"] = append(supported["delete"], "launchconfiguration")
 	supported["create"] = append(supported["create"], "scalinggroup")
@@ -1206,6 +1562,7 @@ func DriverSupportedActions() map[string][]string {
 	supported["delete"] = append(supported["delete"], "dbsubnetgroup")
 	supported["create"] = append(supported["create"], "repository")
 	supported["delete"] = append(supported["delete"], "repository")
+	supported["update"] = append(supported["update"], "repository")
 	supported["authenticate"] = append(supported["authenticate"], "registry")
 	supported["create"] = append(supported["create"], "containercluster")
 	supported["delete"] = append(supported["delete"], "containercluster")
@@ -1236,6 +1593,8 @@ func DriverSupportedActions() map[string][]string {
 	supported["delete"] = append(supported["delete"], "policy")
 	supported["attach"] = append(supported["attach"], "policy")
 	supported["detach"] = append(supported["detach"], "policy")
+	supported["create"] = append(supported["create"], "inlinepolicy")
+	supported["delete"] = append(supported["delete"], "inlinepolicy")
 	supported["create"] = append(supported["create"], "bucket")
 	supported["update"] = append(supported["update"], "bucket")
 	supported["delete"] = append(supported["delete"], "bucket")
@@ -1252,8 +1611,14 @@ func DriverSupportedActions() map[string][]string {
 	supported["delete"] = append(supported["delete"], "zone")
 	supported["create"] = append(supported["create"], "record")
 	supported["delete"] = append(supported["delete"], "record")
+	supported["create"] = append(supported["create"], "healthcheck")
+	supported["update"] = append(supported["update"], "healthcheck")
+	supported["delete"] = append(supported["delete"], "healthcheck")
 	supported["create"] = append(supported["create"], "function")
 	supported["delete"] = append(supported["delete"], "function")
+	supported["create"] = append(supported["create"], "stream")
+	supported["update"] = append(supported["update"], "stream")
+	supported["delete"] = append(supported["delete"], "stream")
 	supported["create"] = append(supported["create"], "alarm")
 	supported["delete"] = append(supported["delete"], "alarm")
 	supported["start"] = append(supported["start"], "alarm")
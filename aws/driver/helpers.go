@@ -50,7 +50,7 @@ func (dc *driverCall) execute(input interface{}) (output interface{}, err error)
 	results := fnVal.Call(values)
 
 	if err, ok := results[1].Interface().(error); ok && err != nil {
-		return nil, fmt.Errorf("%s: %s", dc.desc, err)
+		return nil, fmt.Errorf("%s: %w", dc.desc, err)
 	}
 
 	dc.logger.ExtraVerbosef("%s call took %s", dc.desc, time.Since(start))
@@ -0,0 +1,437 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsdriver
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/aws/aws-sdk-go/private/protocol/jsonrpc"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/lambda/lambdaiface"
+)
+
+// Stream is a Kinesis data stream with the Lambda functions consuming it through event source mappings
+type Stream struct {
+	StreamName           *string
+	StreamARN            *string
+	StreamStatus         *string
+	StreamMode           *string
+	OpenShardCount       *int64
+	RetentionPeriodHours *int64
+	EncryptionType       *string
+	CreateTime           *time.Time
+	Consumers            []*string
+}
+
+func NewStream(summary *StreamDescriptionSummary, consumers []*string) *Stream {
+	s := &Stream{
+		StreamName:           summary.StreamName,
+		StreamARN:            summary.StreamARN,
+		StreamStatus:         summary.StreamStatus,
+		OpenShardCount:       summary.OpenShardCount,
+		RetentionPeriodHours: summary.RetentionPeriodHours,
+		EncryptionType:       summary.EncryptionType,
+		CreateTime:           summary.StreamCreationTimestamp,
+		Consumers:            consumers,
+	}
+	if summary.StreamModeDetails != nil {
+		s.StreamMode = summary.StreamModeDetails.StreamMode
+	}
+	return s
+}
+
+// Types of destinations of Firehose delivery streams
+const (
+	S3Destination         = "s3"
+	RedshiftDestination   = "redshift"
+	OpenSearchDestination = "opensearch"
+	HTTPDestination       = "http"
+	SplunkDestination     = "splunk"
+)
+
+// DeliveryStream is a Firehose delivery stream with its source Kinesis stream (if any) and its first
+// destination: a S3 bucket ARN, a Redshift cluster JDBC URL, an OpenSearch domain ARN or an endpoint URL
+type DeliveryStream struct {
+	DeliveryStreamName     *string
+	DeliveryStreamARN      *string
+	DeliveryStreamStatus   *string
+	DeliveryStreamType     *string
+	CreateTime             *time.Time
+	SourceStreamARN        *string
+	DestinationType        *string
+	Destination            *string
+	BufferSizeInMBs        *int64
+	BufferIntervalInSecond *int64
+}
+
+func NewDeliveryStream(desc *DeliveryStreamDescription) *DeliveryStream {
+	s := &DeliveryStream{
+		DeliveryStreamName:   desc.DeliveryStreamName,
+		DeliveryStreamARN:    desc.DeliveryStreamARN,
+		DeliveryStreamStatus: desc.DeliveryStreamStatus,
+		DeliveryStreamType:   desc.DeliveryStreamType,
+		CreateTime:           desc.CreateTimestamp,
+	}
+	if src := desc.Source; src != nil && src.KinesisStreamSourceDescription != nil {
+		s.SourceStreamARN = src.KinesisStreamSourceDescription.KinesisStreamARN
+	}
+	if len(desc.Destinations) == 0 {
+		return s
+	}
+	setDestination := func(typ string, dest *string, hints *BufferingHints) {
+		s.DestinationType, s.Destination = aws.String(typ), dest
+		if hints != nil {
+			s.BufferSizeInMBs, s.BufferIntervalInSecond = hints.SizeInMBs, hints.IntervalInSeconds
+		}
+	}
+	switch d := desc.Destinations[0]; {
+	case d.ExtendedS3DestinationDescription != nil:
+		setDestination(S3Destination, d.ExtendedS3DestinationDescription.BucketARN, d.ExtendedS3DestinationDescription.BufferingHints)
+	case d.S3DestinationDescription != nil && d.RedshiftDestinationDescription == nil:
+		setDestination(S3Destination, d.S3DestinationDescription.BucketARN, d.S3DestinationDescription.BufferingHints)
+	case d.RedshiftDestinationDescription != nil:
+		var hints *BufferingHints
+		if d.RedshiftDestinationDescription.S3DestinationDescription != nil {
+			hints = d.RedshiftDestinationDescription.S3DestinationDescription.BufferingHints
+		}
+		setDestination(RedshiftDestination, d.RedshiftDestinationDescription.ClusterJDBCURL, hints)
+	case d.AmazonopensearchserviceDestinationDescription != nil:
+		setDestination(OpenSearchDestination, d.AmazonopensearchserviceDestinationDescription.DomainARN, d.AmazonopensearchserviceDestinationDescription.BufferingHints)
+	case d.ElasticsearchDestinationDescription != nil:
+		setDestination(OpenSearchDestination, d.ElasticsearchDestinationDescription.DomainARN, d.ElasticsearchDestinationDescription.BufferingHints)
+	case d.HttpEndpointDestinationDescription != nil:
+		var url *string
+		if d.HttpEndpointDestinationDescription.EndpointConfiguration != nil {
+			url = d.HttpEndpointDestinationDescription.EndpointConfiguration.Url
+		}
+		setDestination(HTTPDestination, url, d.HttpEndpointDestinationDescription.BufferingHints)
+	case d.SplunkDestinationDescription != nil:
+		setDestination(SplunkDestination, d.SplunkDestinationDescription.HECEndpoint, nil)
+	}
+	return s
+}
+
+// StreamsAPI lists and edits Kinesis data streams and lists Firehose delivery streams.
+// The vendored SDK does not ship the Kinesis and Firehose services, so only these calls
+// are implemented here on top of the generic SDK client
+type StreamsAPI interface {
+	ListStreams(*ListStreamsInput) (*ListStreamsOutput, error)
+	DescribeStreamSummary(*DescribeStreamSummaryInput) (*DescribeStreamSummaryOutput, error)
+	CreateStream(*CreateStreamInput) (*CreateStreamOutput, error)
+	DeleteStream(*DeleteStreamInput) (*DeleteStreamOutput, error)
+	UpdateShardCount(*UpdateShardCountInput) (*UpdateShardCountOutput, error)
+	ListDeliveryStreams(*ListDeliveryStreamsInput) (*ListDeliveryStreamsOutput, error)
+	DescribeDeliveryStream(*DescribeDeliveryStreamInput) (*DescribeDeliveryStreamOutput, error)
+}
+
+// Streams returns the Kinesis and Firehose API of the region of the Lambda client,
+// or false if the client can not send these calls (ex: mocks)
+func Streams(api lambdaiface.LambdaAPI) (StreamsAPI, bool) {
+	switch c := api.(type) {
+	case StreamsAPI:
+		return c, true
+	case *lambda.Lambda:
+		return &streamsClient{
+			kinesis:  newJSONRPCClient(c.Client, "kinesis", "2013-12-02", "Kinesis_20131202"),
+			firehose: newJSONRPCClient(c.Client, "firehose", "2015-08-04", "Firehose_20150804"),
+		}, true
+	}
+	return nil, false
+}
+
+func newJSONRPCClient(c *client.Client, service, apiVersion, targetPrefix string) *client.Client {
+	region := aws.StringValue(c.Config.Region)
+	rpc := client.New(
+		c.Config,
+		metadata.ClientInfo{
+			ServiceName:   service,
+			SigningName:   service,
+			SigningRegion: region,
+			Endpoint:      fmt.Sprintf("https://%s.%s.amazonaws.com", service, region),
+			APIVersion:    apiVersion,
+			JSONVersion:   "1.1",
+			TargetPrefix:  targetPrefix,
+		},
+		c.Handlers.Copy(),
+	)
+	rpc.Handlers.Sign.Clear()
+	rpc.Handlers.Build.Clear()
+	rpc.Handlers.Unmarshal.Clear()
+	rpc.Handlers.UnmarshalMeta.Clear()
+	rpc.Handlers.UnmarshalError.Clear()
+	rpc.Handlers.Sign.PushBackNamed(v4.SignRequestHandler)
+	rpc.Handlers.Build.PushBackNamed(jsonrpc.BuildHandler)
+	rpc.Handlers.Unmarshal.PushBackNamed(jsonrpc.UnmarshalHandler)
+	rpc.Handlers.UnmarshalMeta.PushBackNamed(jsonrpc.UnmarshalMetaHandler)
+	rpc.Handlers.UnmarshalError.PushBackNamed(jsonrpc.UnmarshalErrorHandler)
+	return rpc
+}
+
+type streamsClient struct {
+	kinesis, firehose *client.Client
+}
+
+func sendJSONRPC(c *client.Client, name string, input, output interface{}) error {
+	op := &request.Operation{Name: name, HTTPMethod: "POST", HTTPPath: "/"}
+	return c.NewRequest(op, input, output).Send()
+}
+
+func (c *streamsClient) ListStreams(input *ListStreamsInput) (*ListStreamsOutput, error) {
+	output := &ListStreamsOutput{}
+	return output, sendJSONRPC(c.kinesis, "ListStreams", input, output)
+}
+
+func (c *streamsClient) DescribeStreamSummary(input *DescribeStreamSummaryInput) (*DescribeStreamSummaryOutput, error) {
+	output := &DescribeStreamSummaryOutput{}
+	return output, sendJSONRPC(c.kinesis, "DescribeStreamSummary", input, output)
+}
+
+func (c *streamsClient) CreateStream(input *CreateStreamInput) (*CreateStreamOutput, error) {
+	output := &CreateStreamOutput{}
+	return output, sendJSONRPC(c.kinesis, "CreateStream", input, output)
+}
+
+func (c *streamsClient) DeleteStream(input *DeleteStreamInput) (*DeleteStreamOutput, error) {
+	output := &DeleteStreamOutput{}
+	return output, sendJSONRPC(c.kinesis, "DeleteStream", input, output)
+}
+
+func (c *streamsClient) UpdateShardCount(input *UpdateShardCountInput) (*UpdateShardCountOutput, error) {
+	output := &UpdateShardCountOutput{}
+	return output, sendJSONRPC(c.kinesis, "UpdateShardCount", input, output)
+}
+
+func (c *streamsClient) ListDeliveryStreams(input *ListDeliveryStreamsInput) (*ListDeliveryStreamsOutput, error) {
+	output := &ListDeliveryStreamsOutput{}
+	return output, sendJSONRPC(c.firehose, "ListDeliveryStreams", input, output)
+}
+
+func (c *streamsClient) DescribeDeliveryStream(input *DescribeDeliveryStreamInput) (*DescribeDeliveryStreamOutput, error) {
+	output := &DescribeDeliveryStreamOutput{}
+	return output, sendJSONRPC(c.firehose, "DescribeDeliveryStream", input, output)
+}
+
+// StreamNameFromARN returns the name of a Kinesis stream given its ARN (arn:aws:kinesis:region:account:stream/name),
+// or the given string when not an ARN
+func StreamNameFromARN(arn string) string {
+	if !strings.HasPrefix(arn, "arn:") {
+		return arn
+	}
+	return arn[strings.LastIndex(arn, "/")+1:]
+}
+
+type ListStreamsInput struct {
+	_ struct{} `type:"structure"`
+
+	ExclusiveStartStreamName *string `type:"string"`
+	Limit                    *int64  `type:"integer"`
+}
+
+type ListStreamsOutput struct {
+	_ struct{} `type:"structure"`
+
+	StreamNames    []*string `type:"list"`
+	HasMoreStreams *bool     `type:"boolean"`
+}
+
+type DescribeStreamSummaryInput struct {
+	_ struct{} `type:"structure"`
+
+	StreamName *string `type:"string" required:"true"`
+}
+
+type DescribeStreamSummaryOutput struct {
+	_ struct{} `type:"structure"`
+
+	StreamDescriptionSummary *StreamDescriptionSummary `type:"structure"`
+}
+
+type StreamDescriptionSummary struct {
+	_ struct{} `type:"structure"`
+
+	StreamName              *string            `type:"string"`
+	StreamARN               *string            `type:"string"`
+	StreamStatus            *string            `type:"string"`
+	StreamModeDetails       *StreamModeDetails `type:"structure"`
+	OpenShardCount          *int64             `type:"integer"`
+	RetentionPeriodHours    *int64             `type:"integer"`
+	EncryptionType          *string            `type:"string"`
+	StreamCreationTimestamp *time.Time         `type:"timestamp" timestampFormat:"unix"`
+}
+
+type StreamModeDetails struct {
+	_ struct{} `type:"structure"`
+
+	StreamMode *string `type:"string"`
+}
+
+type CreateStreamInput struct {
+	_ struct{} `type:"structure"`
+
+	StreamName        *string            `type:"string" required:"true"`
+	ShardCount        *int64             `type:"integer"`
+	StreamModeDetails *StreamModeDetails `type:"structure"`
+}
+
+type CreateStreamOutput struct {
+	_ struct{} `type:"structure"`
+}
+
+type DeleteStreamInput struct {
+	_ struct{} `type:"structure"`
+
+	StreamName              *string `type:"string" required:"true"`
+	EnforceConsumerDeletion *bool   `type:"boolean"`
+}
+
+type DeleteStreamOutput struct {
+	_ struct{} `type:"structure"`
+}
+
+type UpdateShardCountInput struct {
+	_ struct{} `type:"structure"`
+
+	StreamName       *string `type:"string" required:"true"`
+	TargetShardCount *int64  `type:"integer" required:"true"`
+	ScalingType      *string `type:"string" required:"true"`
+}
+
+type UpdateShardCountOutput struct {
+	_ struct{} `type:"structure"`
+
+	StreamName        *string `type:"string"`
+	CurrentShardCount *int64  `type:"integer"`
+	TargetShardCount  *int64  `type:"integer"`
+}
+
+type ListDeliveryStreamsInput struct {
+	_ struct{} `type:"structure"`
+
+	ExclusiveStartDeliveryStreamName *string `type:"string"`
+	Limit                            *int64  `type:"integer"`
+}
+
+type ListDeliveryStreamsOutput struct {
+	_ struct{} `type:"structure"`
+
+	DeliveryStreamNames    []*string `type:"list"`
+	HasMoreDeliveryStreams *bool     `type:"boolean"`
+}
+
+type DescribeDeliveryStreamInput struct {
+	_ struct{} `type:"structure"`
+
+	DeliveryStreamName *string `type:"string" required:"true"`
+}
+
+type DescribeDeliveryStreamOutput struct {
+	_ struct{} `type:"structure"`
+
+	DeliveryStreamDescription *DeliveryStreamDescription `type:"structure"`
+}
+
+type DeliveryStreamDescription struct {
+	_ struct{} `type:"structure"`
+
+	DeliveryStreamName   *string                   `type:"string"`
+	DeliveryStreamARN    *string                   `type:"string"`
+	DeliveryStreamStatus *string                   `type:"string"`
+	DeliveryStreamType   *string                   `type:"string"`
+	CreateTimestamp      *time.Time                `type:"timestamp" timestampFormat:"unix"`
+	Source               *SourceDescription        `type:"structure"`
+	Destinations         []*DestinationDescription `type:"list"`
+}
+
+type SourceDescription struct {
+	_ struct{} `type:"structure"`
+
+	KinesisStreamSourceDescription *KinesisStreamSourceDescription `type:"structure"`
+}
+
+type KinesisStreamSourceDescription struct {
+	_ struct{} `type:"structure"`
+
+	KinesisStreamARN *string `type:"string"`
+}
+
+type DestinationDescription struct {
+	_ struct{} `type:"structure"`
+
+	DestinationId                                 *string                             `type:"string"`
+	ExtendedS3DestinationDescription              *S3DestinationDescription           `type:"structure"`
+	S3DestinationDescription                      *S3DestinationDescription           `type:"structure"`
+	RedshiftDestinationDescription                *RedshiftDestinationDescription     `type:"structure"`
+	AmazonopensearchserviceDestinationDescription *DomainDestinationDescription       `type:"structure"`
+	ElasticsearchDestinationDescription           *DomainDestinationDescription       `type:"structure"`
+	HttpEndpointDestinationDescription            *HttpEndpointDestinationDescription `type:"structure"`
+	SplunkDestinationDescription                  *SplunkDestinationDescription       `type:"structure"`
+}
+
+type BufferingHints struct {
+	_ struct{} `type:"structure"`
+
+	SizeInMBs         *int64 `type:"integer"`
+	IntervalInSeconds *int64 `type:"integer"`
+}
+
+type S3DestinationDescription struct {
+	_ struct{} `type:"structure"`
+
+	BucketARN      *string         `type:"string"`
+	BufferingHints *BufferingHints `type:"structure"`
+}
+
+type RedshiftDestinationDescription struct {
+	_ struct{} `type:"structure"`
+
+	ClusterJDBCURL           *string                   `type:"string"`
+	S3DestinationDescription *S3DestinationDescription `type:"structure"`
+}
+
+type DomainDestinationDescription struct {
+	_ struct{} `type:"structure"`
+
+	DomainARN      *string         `type:"string"`
+	BufferingHints *BufferingHints `type:"structure"`
+}
+
+type HttpEndpointDestinationDescription struct {
+	_ struct{} `type:"structure"`
+
+	EndpointConfiguration *HttpEndpointDescription `type:"structure"`
+	BufferingHints        *BufferingHints          `type:"structure"`
+}
+
+type HttpEndpointDescription struct {
+	_ struct{} `type:"structure"`
+
+	Url  *string `type:"string"`
+	Name *string `type:"string"`
+}
+
+type SplunkDestinationDescription struct {
+	_ struct{} `type:"structure"`
+
+	HECEndpoint *string `type:"string"`
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsdriver
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ManualDriverAPICalls are the AWS API calls made by the drivers written by hand, including the calls
+// of the drivers or helpers they run (see APICallsPerTemplateDefName for the generated drivers)
+var ManualDriverAPICalls = map[string][]string{
+	"startinstance":           {"StartInstances", "DescribeInstances"},
+	"stopinstance":            {"StopInstances", "DescribeInstances"},
+	"rebootinstance":          {"RebootInstances", "DescribeInstanceStatus"},
+	"checkinstance":           {"DescribeInstances"},
+	"createsecuritygroup":     {"CreateSecurityGroup", "AuthorizeSecurityGroupIngress", "AuthorizeSecurityGroupEgress", "DeleteSecurityGroup"},
+	"updatesecuritygroup":     {"AuthorizeSecurityGroupIngress", "AuthorizeSecurityGroupEgress", "RevokeSecurityGroupIngress", "RevokeSecurityGroupEgress"},
+	"checksecuritygroup":      {"DescribeNetworkInterfaces"},
+	"attachsecuritygroup":     {"DescribeInstanceAttribute", "ModifyInstanceAttribute"},
+	"detachsecuritygroup":     {"DescribeInstanceAttribute", "ModifyInstanceAttribute"},
+	"copyimage":               {"CopyImage", "DescribeImages"},
+	"deleteimage":             {"DeregisterImage", "DescribeImages", "DeleteSnapshot"},
+	"checkvolume":             {"DescribeVolumes"},
+	"copysnapshot":            {"CopySnapshot", "DescribeSnapshots"},
+	"createnatgateway":        {"CreateNatGateway", "DescribeSubnets", "DescribeRouteTables", "CreateRoute", "ReplaceRoute"},
+	"checknatgateway":         {"DescribeNatGateways"},
+	"createfleet":             {"RequestSpotFleet", "CreateFleet"},
+	"updatefleet":             {"ModifySpotFleetRequest", "ModifyFleet"},
+	"deletefleet":             {"CancelSpotFleetRequests", "DeleteFleets"},
+	"createdhcpoptions":       {"CreateDhcpOptions"},
+	"createprefixlist":        {"CreateManagedPrefixList"},
+	"updateprefixlist":        {"DescribeManagedPrefixLists", "ModifyManagedPrefixList"},
+	"deleteprefixlist":        {"DeleteManagedPrefixList"},
+	"attachnetworkacl":        {"DescribeNetworkAcls", "ReplaceNetworkAclAssociation"},
+	"createnetworkaclrule":    {"CreateNetworkAclEntry"},
+	"deletenetworkaclrule":    {"DeleteNetworkAclEntry"},
+	"createtag":               {"CreateTags"},
+	"deletetag":               {"DeleteTags"},
+	"createkeypair":           {"ImportKeyPair"},
+	"checkloadbalancer":       {"DescribeLoadBalancers"},
+	"createloadbalancerstack": {"CreateLoadBalancer", "CreateTargetGroup", "CreateListener", "RegisterTargets", "DeleteLoadBalancer", "DeleteTargetGroup"},
+	"deleteloadbalancerstack": {"DescribeListeners", "DeleteLoadBalancer", "DeleteTargetGroup"},
+	"checkscalinggroup":       {"DescribeAutoScalingGroups"},
+	"checkdatabase":           {"DescribeDBInstances"},
+	"updaterepository":        {"PutImageScanningConfiguration", "PutLifecyclePolicy"},
+	"authenticateregistry":    {"GetAuthorizationToken"},
+	"createcontainer":         {"DescribeTaskDefinition", "RegisterTaskDefinition"},
+	"deletecontainer":         {"DescribeTaskDefinition", "RegisterTaskDefinition", "DeregisterTaskDefinition"},
+	"createaccesskey":         {"CreateAccessKey"},
+	"createrole":              {"CreateRole", "CreateInstanceProfile", "AddRoleToInstanceProfile"},
+	"deleterole":              {"DeleteRole", "RemoveRoleFromInstanceProfile", "DeleteInstanceProfile"},
+	"createpolicy":            {"CreatePolicy"},
+	"attachpolicy":            {"AttachUserPolicy", "AttachGroupPolicy", "AttachRolePolicy"},
+	"detachpolicy":            {"DetachUserPolicy", "DetachGroupPolicy", "DetachRolePolicy"},
+	"createinlinepolicy":      {"PutUserPolicy", "PutGroupPolicy", "PutRolePolicy"},
+	"deleteinlinepolicy":      {"DeleteUserPolicy", "DeleteGroupPolicy", "DeleteRolePolicy"},
+	"updatebucket":            {"PutBucketAcl", "PutBucketWebsite", "DeleteBucketWebsite", "PutBucketReplication", "DeleteBucketReplication"},
+	"creates3object":          {"PutObject"},
+	"createrecord":            {"ChangeResourceRecordSets"},
+	"deleterecord":            {"ChangeResourceRecordSets"},
+	"attachalarm":             {"DescribeAlarms", "PutMetricAlarm"},
+	"detachalarm":             {"DescribeAlarms", "PutMetricAlarm"},
+	"createdistribution":      {"CreateDistribution"},
+	"checkdistribution":       {"GetDistribution"},
+	"updatedistribution":      {"GetDistribution", "UpdateDistribution"},
+	"deletedistribution":      {"GetDistribution", "UpdateDistribution", "DeleteDistribution"},
+	"createstream":            {"CreateStream", "DescribeStreamSummary"},
+	"updatestream":            {"UpdateShardCount"},
+	"deletestream":            {"DeleteStream"},
+}
+
+// passRoleTemplateDefNames are the drivers handing a role to the service (with their `role` param),
+// which requires the iam:PassRole permission
+var passRoleTemplateDefNames = map[string]bool{
+	"createinstance":            true,
+	"importimage":               true,
+	"createfleet":               true,
+	"createlaunchconfiguration": true,
+	"startcontainerservice":     true,
+	"createfunction":            true,
+	"createstack":               true,
+	"updatestack":               true,
+}
+
+// iamServicePrefixes maps the APIs whose prefix in IAM actions differs from their name
+var iamServicePrefixes = map[string]string{
+	"elbv2":                  "elasticloadbalancing",
+	"applicationautoscaling": "application-autoscaling",
+}
+
+// iamActionNames maps the API calls authorized by an IAM action of a different name
+var iamActionNames = map[string]string{
+	"s3:PutBucketReplication":    "s3:PutReplicationConfiguration",
+	"s3:DeleteBucketReplication": "s3:PutReplicationConfiguration",
+	// kinesis streams drivers are run by the lambda driver
+	"lambda:CreateStream":          "kinesis:CreateStream",
+	"lambda:DescribeStreamSummary": "kinesis:DescribeStreamSummary",
+	"lambda:UpdateShardCount":      "kinesis:UpdateShardCount",
+	"lambda:DeleteStream":          "kinesis:DeleteStream",
+}
+
+// RequiredPermissions returns the sorted IAM actions needed by the drivers of the given
+// template definitions (ex: createinstance)
+func RequiredPermissions(defNames ...string) ([]string, error) {
+	unique := make(map[string]bool)
+	for _, name := range defNames {
+		api, ok := APIPerTemplateDefName[name]
+		if !ok {
+			return nil, fmt.Errorf("required permissions: unknown template definition '%s'", name)
+		}
+		calls, ok := APICallsPerTemplateDefName[name]
+		if !ok {
+			calls = ManualDriverAPICalls[name]
+		}
+		if len(calls) == 0 {
+			return nil, fmt.Errorf("required permissions: no known API calls for '%s'", name)
+		}
+		prefix := api
+		if p, ok := iamServicePrefixes[api]; ok {
+			prefix = p
+		}
+		for _, call := range calls {
+			action := prefix + ":" + call
+			if a, ok := iamActionNames[action]; ok {
+				action = a
+			}
+			unique[action] = true
+		}
+		if passRoleTemplateDefNames[name] {
+			unique["iam:PassRole"] = true
+		}
+	}
+
+	var actions []string
+	for a := range unique {
+		actions = append(actions, a)
+	}
+	sort.Strings(actions)
+	return actions, nil
+}
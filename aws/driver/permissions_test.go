@@ -0,0 +1,129 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsdriver
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+func TestRequiredPermissions(t *testing.T) {
+	t.Run("every driver has known calls", func(t *testing.T) {
+		for name := range AWSTemplatesDefinitions {
+			if _, err := RequiredPermissions(name); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if _, err := RequiredPermissions("createunknown"); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("actions of drivers", func(t *testing.T) {
+		tcases := []struct {
+			defs []string
+			exp  []string
+		}{
+			{[]string{"createinstance"}, []string{"ec2:CreateTags", "ec2:RunInstances", "iam:PassRole"}},
+			{[]string{"createvpc", "createsubnet", "deletevpc"}, []string{"ec2:CreateSubnet", "ec2:CreateTags", "ec2:CreateVpc", "ec2:DeleteVpc"}},
+			{[]string{"createrole"}, []string{"iam:AddRoleToInstanceProfile", "iam:CreateInstanceProfile", "iam:CreateRole"}},
+			{[]string{"deleteloadbalancer", "attachinstance"}, []string{"elasticloadbalancing:DeleteLoadBalancer", "elasticloadbalancing:RegisterTargets"}},
+			{[]string{"createappscalingtarget"}, []string{"application-autoscaling:RegisterScalableTarget"}},
+			{[]string{"updatebucket"}, []string{"s3:DeleteBucketWebsite", "s3:PutBucketAcl", "s3:PutBucketWebsite", "s3:PutReplicationConfiguration"}},
+		}
+		for i, tcase := range tcases {
+			actions, err := RequiredPermissions(tcase.defs...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := actions, tcase.exp; !reflect.DeepEqual(got, want) {
+				t.Fatalf("%d: got %v, want %v", i+1, got, want)
+			}
+		}
+	})
+
+	t.Run("actions cover the calls of drivers", func(t *testing.T) {
+		instanceStatePollFrequency = time.Millisecond
+		defer func() { instanceStatePollFrequency = 5 * time.Second }()
+		var called []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			r.ParseForm()
+			action := r.Form.Get("Action")
+			called = append(called, "ec2:"+action)
+			switch action {
+			case "RunInstances":
+				fmt.Fprint(w, `<RunInstancesResponse><instancesSet><item><instanceId>i-1234</instanceId></item></instancesSet></RunInstancesResponse>`)
+			case "DescribeInstances":
+				fmt.Fprint(w, `<DescribeInstancesResponse><reservationSet><item><instancesSet><item><instanceId>i-1234</instanceId><instanceState><name>running</name></instanceState></item></instancesSet></item></reservationSet></DescribeInstancesResponse>`)
+			default:
+				fmt.Fprintf(w, "<%sResponse></%sResponse>", action, action)
+			}
+		}))
+		defer server.Close()
+
+		sess := session.New(&aws.Config{
+			Endpoint:    aws.String(server.URL),
+			Region:      aws.String("us-west-1"),
+			Credentials: credentials.NewStaticCredentials("id", "secret", ""),
+		})
+		d := NewEc2Driver(ec2.New(sess)).(*Ec2Driver)
+
+		tcases := []struct {
+			def  string
+			run  func() (interface{}, error)
+			exps []string
+		}{
+			{"createinstance", func() (interface{}, error) {
+				return d.Create_Instance(map[string]interface{}{"image": "ami-12", "type": "t2.micro", "count": 1, "subnet": "sub-1", "name": "web"})
+			}, []string{"ec2:RunInstances", "ec2:CreateTags"}},
+			{"startinstance", func() (interface{}, error) {
+				return d.Start_Instance(map[string]interface{}{"id": "i-1234", "wait": true})
+			}, []string{"ec2:StartInstances", "ec2:DescribeInstances"}},
+		}
+		for _, tcase := range tcases {
+			called = nil
+			if _, err := tcase.run(); err != nil {
+				t.Fatalf("%s: %s", tcase.def, err)
+			}
+			if got, want := called, tcase.exps; !reflect.DeepEqual(got, want) {
+				t.Fatalf("%s: got %v, want %v", tcase.def, got, want)
+			}
+			actions, err := RequiredPermissions(tcase.def)
+			if err != nil {
+				t.Fatal(err)
+			}
+			granted := make(map[string]bool)
+			for _, a := range actions {
+				granted[a] = true
+			}
+			for _, c := range called {
+				if !granted[c] {
+					t.Fatalf("%s: call %s not granted by %v", tcase.def, c, actions)
+				}
+			}
+		}
+	})
+}
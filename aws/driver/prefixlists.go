@@ -0,0 +1,198 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsdriver
+
+import (
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+)
+
+// ManagedPrefixListsAPI lists and edits the managed prefix lists (AWS-managed and customer-managed) of the region.
+// The vendored EC2 SDK predates managed prefix lists: the calls are sent through the EC2 client
+// of the SDK with the request and response shapes below
+type ManagedPrefixListsAPI interface {
+	DescribeManagedPrefixLists(*DescribeManagedPrefixListsInput) (*DescribeManagedPrefixListsOutput, error)
+	GetManagedPrefixListEntries(*GetManagedPrefixListEntriesInput) (*GetManagedPrefixListEntriesOutput, error)
+	CreateManagedPrefixList(*CreateManagedPrefixListInput) (*CreateManagedPrefixListOutput, error)
+	ModifyManagedPrefixList(*ModifyManagedPrefixListInput) (*ModifyManagedPrefixListOutput, error)
+	DeleteManagedPrefixList(*DeleteManagedPrefixListInput) (*DeleteManagedPrefixListOutput, error)
+}
+
+// ManagedPrefixLists returns the managed prefix lists API of the EC2 client,
+// or false if the client can not send these calls (ex: mocks)
+func ManagedPrefixLists(api ec2iface.EC2API) (ManagedPrefixListsAPI, bool) {
+	switch c := api.(type) {
+	case ManagedPrefixListsAPI:
+		return c, true
+	case *ec2.EC2:
+		return &ec2ManagedPrefixLists{c}, true
+	}
+	return nil, false
+}
+
+type ec2ManagedPrefixLists struct {
+	*ec2.EC2
+}
+
+func (c *ec2ManagedPrefixLists) send(name string, input, output interface{}) error {
+	op := &request.Operation{Name: name, HTTPMethod: "POST", HTTPPath: "/"}
+	return c.NewRequest(op, input, output).Send()
+}
+
+func (c *ec2ManagedPrefixLists) DescribeManagedPrefixLists(input *DescribeManagedPrefixListsInput) (*DescribeManagedPrefixListsOutput, error) {
+	output := &DescribeManagedPrefixListsOutput{}
+	return output, c.send("DescribeManagedPrefixLists", input, output)
+}
+
+func (c *ec2ManagedPrefixLists) GetManagedPrefixListEntries(input *GetManagedPrefixListEntriesInput) (*GetManagedPrefixListEntriesOutput, error) {
+	output := &GetManagedPrefixListEntriesOutput{}
+	return output, c.send("GetManagedPrefixListEntries", input, output)
+}
+
+func (c *ec2ManagedPrefixLists) CreateManagedPrefixList(input *CreateManagedPrefixListInput) (*CreateManagedPrefixListOutput, error) {
+	output := &CreateManagedPrefixListOutput{}
+	return output, c.send("CreateManagedPrefixList", input, output)
+}
+
+func (c *ec2ManagedPrefixLists) ModifyManagedPrefixList(input *ModifyManagedPrefixListInput) (*ModifyManagedPrefixListOutput, error) {
+	output := &ModifyManagedPrefixListOutput{}
+	return output, c.send("ModifyManagedPrefixList", input, output)
+}
+
+func (c *ec2ManagedPrefixLists) DeleteManagedPrefixList(input *DeleteManagedPrefixListInput) (*DeleteManagedPrefixListOutput, error) {
+	output := &DeleteManagedPrefixListOutput{}
+	return output, c.send("DeleteManagedPrefixList", input, output)
+}
+
+// ManagedPrefixList is a set of CIDR blocks referenced by security group and route table rules.
+// AWS-managed prefix lists (ex: S3 or DynamoDB ranges) are owned by 'AWS'
+type ManagedPrefixList struct {
+	_ struct{} `type:"structure"`
+
+	AddressFamily  *string    `locationName:"addressFamily" type:"string"`
+	MaxEntries     *int64     `locationName:"maxEntries" type:"integer"`
+	OwnerId        *string    `locationName:"ownerId" type:"string"`
+	PrefixListArn  *string    `locationName:"prefixListArn" type:"string"`
+	PrefixListId   *string    `locationName:"prefixListId" type:"string"`
+	PrefixListName *string    `locationName:"prefixListName" type:"string"`
+	State          *string    `locationName:"state" type:"string"`
+	StateMessage   *string    `locationName:"stateMessage" type:"string"`
+	Tags           []*ec2.Tag `locationName:"tagSet" locationNameList:"item" type:"list"`
+	Version        *int64     `locationName:"version" type:"long"`
+
+	// Entries are not returned by DescribeManagedPrefixLists: fetchers fill them with GetManagedPrefixListEntries
+	Entries []*PrefixListEntry `type:"list"`
+}
+
+type PrefixListEntry struct {
+	_ struct{} `type:"structure"`
+
+	Cidr        *string `locationName:"cidr" type:"string"`
+	Description *string `locationName:"description" type:"string"`
+}
+
+type DescribeManagedPrefixListsInput struct {
+	_ struct{} `type:"structure"`
+
+	DryRun        *bool     `type:"boolean"`
+	MaxResults    *int64    `type:"integer"`
+	NextToken     *string   `type:"string"`
+	PrefixListIds []*string `locationName:"PrefixListId" locationNameList:"item" type:"list"`
+}
+
+type DescribeManagedPrefixListsOutput struct {
+	_ struct{} `type:"structure"`
+
+	NextToken   *string              `locationName:"nextToken" type:"string"`
+	PrefixLists []*ManagedPrefixList `locationName:"prefixListSet" locationNameList:"item" type:"list"`
+}
+
+type GetManagedPrefixListEntriesInput struct {
+	_ struct{} `type:"structure"`
+
+	DryRun       *bool   `type:"boolean"`
+	MaxResults   *int64  `type:"integer"`
+	NextToken    *string `type:"string"`
+	PrefixListId *string `type:"string" required:"true"`
+}
+
+type GetManagedPrefixListEntriesOutput struct {
+	_ struct{} `type:"structure"`
+
+	Entries   []*PrefixListEntry `locationName:"entrySet" locationNameList:"item" type:"list"`
+	NextToken *string            `locationName:"nextToken" type:"string"`
+}
+
+type AddPrefixListEntry struct {
+	_ struct{} `type:"structure"`
+
+	Cidr        *string `type:"string" required:"true"`
+	Description *string `type:"string"`
+}
+
+type RemovePrefixListEntry struct {
+	_ struct{} `type:"structure"`
+
+	Cidr *string `type:"string" required:"true"`
+}
+
+type CreateManagedPrefixListInput struct {
+	_ struct{} `type:"structure"`
+
+	AddressFamily  *string               `type:"string" required:"true"`
+	DryRun         *bool                 `type:"boolean"`
+	Entries        []*AddPrefixListEntry `locationName:"Entry" type:"list"`
+	MaxEntries     *int64                `type:"integer" required:"true"`
+	PrefixListName *string               `type:"string" required:"true"`
+}
+
+type CreateManagedPrefixListOutput struct {
+	_ struct{} `type:"structure"`
+
+	PrefixList *ManagedPrefixList `locationName:"prefixList" type:"structure"`
+}
+
+type ModifyManagedPrefixListInput struct {
+	_ struct{} `type:"structure"`
+
+	AddEntries     []*AddPrefixListEntry    `locationName:"AddEntry" type:"list"`
+	CurrentVersion *int64                   `type:"long"`
+	DryRun         *bool                    `type:"boolean"`
+	PrefixListId   *string                  `type:"string" required:"true"`
+	PrefixListName *string                  `type:"string"`
+	RemoveEntries  []*RemovePrefixListEntry `locationName:"RemoveEntry" type:"list"`
+}
+
+type ModifyManagedPrefixListOutput struct {
+	_ struct{} `type:"structure"`
+
+	PrefixList *ManagedPrefixList `locationName:"prefixList" type:"structure"`
+}
+
+type DeleteManagedPrefixListInput struct {
+	_ struct{} `type:"structure"`
+
+	DryRun       *bool   `type:"boolean"`
+	PrefixListId *string `type:"string" required:"true"`
+}
+
+type DeleteManagedPrefixListOutput struct {
+	_ struct{} `type:"structure"`
+
+	PrefixList *ManagedPrefixList `locationName:"prefixList" type:"structure"`
+}
@@ -17,12 +17,15 @@ limitations under the License.
 package awsdriver
 
 import (
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -331,6 +334,35 @@ func setFieldWithType(v, i interface{}, fieldPath string, destType int) (err err
 	return nil
 }
 
+// IdempotencyTokenParam is the param overriding the idempotency token of create drivers
+// whose AWS call supports client tokens
+const IdempotencyTokenParam = "client-token"
+
+// setIdempotencyToken sets the client token of the input from the 'client-token' param, or from a stable hash
+// of the action and params, so that a retried call returns the resource already created instead of a duplicate.
+// Tokens are 32 hex characters, the shortest limit of the supported APIs (ECS)
+func setIdempotencyToken(params map[string]interface{}, action string, i interface{}, fieldPath string) error {
+	if token, ok := params[IdempotencyTokenParam]; ok {
+		return setFieldWithType(token, i, fieldPath, awsstr)
+	}
+	return setFieldWithType(idempotencyToken(action, params), i, fieldPath, awsstr)
+}
+
+func idempotencyToken(action string, params map[string]interface{}) string {
+	var keys []string
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	fmt.Fprint(h, action)
+	for _, k := range keys {
+		fmt.Fprintf(h, "\n%s=%v", k, params[k])
+	}
+	return hex.EncodeToString(h.Sum(nil))[:32]
+}
+
 func castFloat(v interface{}) (float64, error) {
 	switch vv := v.(type) {
 	case string:
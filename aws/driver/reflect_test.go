@@ -521,3 +521,37 @@ func TestSetFieldWithMultiType(t *testing.T) {
 		t.Fatalf("got %d, want %d", got, want)
 	}
 }
+
+func TestSetIdempotencyToken(t *testing.T) {
+	params := map[string]interface{}{"image": "ami-12", "count": 2, "securitygroup": []string{"sg-1", "sg-2"}}
+
+	first, second := &ec2.RunInstancesInput{}, &ec2.RunInstancesInput{}
+	if err := setIdempotencyToken(params, "create instance", first, "ClientToken"); err != nil {
+		t.Fatal(err)
+	}
+	if err := setIdempotencyToken(map[string]interface{}{"securitygroup": []string{"sg-1", "sg-2"}, "count": 2, "image": "ami-12"}, "create instance", second, "ClientToken"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := aws.StringValue(first.ClientToken), aws.StringValue(second.ClientToken); got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := len(aws.StringValue(first.ClientToken)), 32; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+
+	params["count"] = 3
+	if err := setIdempotencyToken(params, "create instance", second, "ClientToken"); err != nil {
+		t.Fatal(err)
+	}
+	if aws.StringValue(first.ClientToken) == aws.StringValue(second.ClientToken) {
+		t.Fatal("expected different tokens for different params")
+	}
+
+	params["client-token"] = "my-token"
+	if err := setIdempotencyToken(params, "create instance", second, "ClientToken"); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := aws.StringValue(second.ClientToken), "my-token"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,122 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsdriver
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultUploadConcurrency is the number of files uploaded at once when uploading a directory
+const defaultUploadConcurrency = 8
+
+type uploadReport struct {
+	Files   int
+	Bytes   int64
+	Elapsed time.Duration
+	Failed  map[string]error
+}
+
+func (r *uploadReport) throughput() string {
+	if r.Elapsed <= 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f MB/s", float64(r.Bytes)/r.Elapsed.Seconds()/(1<<20))
+}
+
+func (r *uploadReport) err() error {
+	if len(r.Failed) == 0 {
+		return nil
+	}
+	var failed []string
+	for path, err := range r.Failed {
+		failed = append(failed, fmt.Sprintf("%s: %s", path, err))
+	}
+	sort.Strings(failed)
+	return fmt.Errorf("%d of %d files failed to upload:\n%s", len(r.Failed), r.Files+len(r.Failed), strings.Join(failed, "\n"))
+}
+
+// uploadTree uploads the files of the root directory with at most concurrency uploads at once.
+// Files are queued as the directory is walked, the walk blocking while the queue is full, so that
+// large trees are never held in memory. A failed file does not stop the upload of the others
+func uploadTree(root string, concurrency int, upload func(path, key string) (int64, error)) *uploadReport {
+	type job struct{ path, key string }
+	jobs := make(chan job, concurrency)
+	report := &uploadReport{Failed: make(map[string]error)}
+	var mu sync.Mutex
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				n, err := upload(j.path, j.key)
+				mu.Lock()
+				if err != nil {
+					report.Failed[j.path] = err
+				} else {
+					report.Files++
+					report.Bytes += n
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			mu.Lock()
+			report.Failed[path] = err
+			mu.Unlock()
+			return nil
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		jobs <- job{path: path, key: filepath.ToSlash(rel)}
+		return nil
+	})
+	close(jobs)
+	wg.Wait()
+
+	report.Elapsed = time.Since(start)
+	return report
+}
+
+func uploadConcurrency(params map[string]interface{}) (int, error) {
+	c, ok := params["concurrency"]
+	if !ok {
+		return defaultUploadConcurrency, nil
+	}
+	concurrency, err := strconv.Atoi(fmt.Sprint(c))
+	if err != nil || concurrency < 1 {
+		return 0, fmt.Errorf("invalid concurrency '%v': expecting a positive integer", c)
+	}
+	return concurrency, nil
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsdriver
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+)
+
+func TestUploadTree(t *testing.T) {
+	dir := uploadTestDir(t, map[string]string{"index.html": "<html>", "css/site.css": "body{}", "img/logo.png": "png", "img/icons/a.png": "a", "broken.txt": "x"})
+	defer os.RemoveAll(dir)
+
+	var running, maxRunning int32
+	var mu sync.Mutex
+	var keys []string
+	report := uploadTree(dir, 2, func(path, key string) (int64, error) {
+		if n := atomic.AddInt32(&running, 1); n > atomic.LoadInt32(&maxRunning) {
+			atomic.StoreInt32(&maxRunning, n)
+		}
+		defer atomic.AddInt32(&running, -1)
+		time.Sleep(5 * time.Millisecond)
+		if key == "broken.txt" {
+			return 0, errors.New("access denied")
+		}
+		mu.Lock()
+		keys = append(keys, key)
+		mu.Unlock()
+		return int64(len(key)), nil
+	})
+
+	sort.Strings(keys)
+	if got, want := keys, []string{"css/site.css", "img/icons/a.png", "img/logo.png", "index.html"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := report.Files, 4; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := report.Bytes, int64(len("css/site.css")+len("img/icons/a.png")+len("img/logo.png")+len("index.html")); got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got := atomic.LoadInt32(&maxRunning); got > 2 {
+		t.Fatalf("got %d concurrent uploads, want at most 2", got)
+	}
+	if err := report.err(); err == nil || !strings.Contains(err.Error(), "1 of 5 files failed") || !strings.Contains(err.Error(), "broken.txt: access denied") {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
+func TestCreateS3objectsFromDirectory(t *testing.T) {
+	dir := uploadTestDir(t, map[string]string{"index.html": "<html>", "css/site.css": "body{}"})
+	defer os.RemoveAll(dir)
+
+	api := &mockPutObjectS3{}
+	d := NewS3Driver(api).(*S3Driver)
+	params := map[string]interface{}{"bucket": "my-bucket", "file": dir, "name": "www", "concurrency": 3}
+	if _, err := d.Create_S3object_DryRun(params); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := d.Create_S3object(params); err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(api.keys)
+	if got, want := api.keys, []string{"my-bucket/www/css/site.css:text/css; charset=utf-8", "my-bucket/www/index.html:text/html; charset=utf-8"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	params["concurrency"] = 0
+	if _, err := d.Create_S3object_DryRun(params); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+type mockPutObjectS3 struct {
+	s3iface.S3API
+	mu   sync.Mutex
+	keys []string
+}
+
+func (m *mockPutObjectS3) PutObject(input *s3.PutObjectInput) (*s3.PutObjectOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.keys = append(m.keys, aws.StringValue(input.Bucket)+"/"+aws.StringValue(input.Key)+":"+aws.StringValue(input.ContentType))
+	return &s3.PutObjectOutput{}, nil
+}
+
+func uploadTestDir(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "awless-upload")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LambdaEngine is the engine under which Lambda runtimes are referenced in the EOL versions table
+const LambdaEngine = "lambda"
+
+// EOLConfigPrefix prefixes the config keys overriding the EOL versions table.
+// Ex: aws.eol.postgres.9.6=2021-11-11 or aws.eol.lambda.python3.7=2023-11-27
+const EOLConfigPrefix = "aws.eol."
+
+// EOLVersions is the end of life (i.e. end of standard support) date, per engine, of the
+// engine versions or Lambda runtimes. Versions match an engine version equal to or
+// starting with the version followed by a dot (ex: 5.6 matches 5.6.40)
+var EOLVersions = map[string]map[string]string{
+	"mysql": {
+		"5.5": "2020-05-12",
+		"5.6": "2022-03-01",
+		"5.7": "2024-02-29",
+	},
+	"mariadb": {
+		"10.0": "2020-05-18",
+		"10.1": "2020-10-17",
+		"10.2": "2022-10-15",
+		"10.3": "2023-10-23",
+	},
+	"postgres": {
+		"9.3": "2018-09-05",
+		"9.4": "2020-02-14",
+		"9.5": "2021-03-31",
+		"9.6": "2022-04-26",
+		"10":  "2023-04-17",
+		"11":  "2024-02-29",
+	},
+	"aurora": {
+		"5.6": "2023-02-28",
+	},
+	"aurora-postgresql": {
+		"9.6": "2022-01-31",
+		"10":  "2023-01-31",
+	},
+	LambdaEngine: {
+		"nodejs":         "2016-10-31",
+		"nodejs4.3":      "2020-03-05",
+		"nodejs4.3-edge": "2019-04-30",
+		"nodejs6.10":     "2019-08-12",
+		"nodejs8.10":     "2020-03-06",
+		"nodejs10.x":     "2021-07-30",
+		"nodejs12.x":     "2023-03-31",
+		"python2.7":      "2021-07-15",
+		"python3.6":      "2022-07-18",
+		"python3.7":      "2023-11-27",
+		"dotnetcore1.0":  "2019-07-30",
+		"dotnetcore2.0":  "2019-05-30",
+		"dotnetcore2.1":  "2022-01-05",
+		"ruby2.5":        "2021-07-30",
+		"go1.x":          "2024-01-08",
+		"java8":          "2024-01-08",
+	},
+}
+
+// LookupEOL returns the end of life date of the engine version, the overrides (i.e. config
+// keys prefixed with EOLConfigPrefix) taking precedence over EOLVersions.
+// The most specific matching version wins
+func LookupEOL(engine, version string, overrides map[string]interface{}) (time.Time, bool, error) {
+	table := make(map[string]string)
+	for v, date := range EOLVersions[engine] {
+		table[v] = date
+	}
+	prefix := EOLConfigPrefix + engine + "."
+	for k, date := range overrides {
+		if strings.HasPrefix(k, prefix) {
+			table[strings.TrimPrefix(k, prefix)] = fmt.Sprint(date)
+		}
+	}
+
+	var match string
+	for v := range table {
+		if (version == v || strings.HasPrefix(version, v+".")) && len(v) > len(match) {
+			match = v
+		}
+	}
+	if match == "" {
+		return time.Time{}, false, nil
+	}
+
+	eol, err := time.Parse("2006-01-02", table[match])
+	if err != nil {
+		return eol, false, fmt.Errorf("eol date of %s %s: expected YYYY-MM-DD, got '%s'", engine, match, table[match])
+	}
+	return eol, true, nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/wallix/awless/cloud"
+)
+
+// Error is an AWS error categorized as one of cloud.ErrNotFound, cloud.ErrAccessDenied,
+// cloud.ErrThrottled or cloud.ErrValidation. It keeps the message of the underlying error and
+// still implements awserr.RequestFailure, so that it can be matched with errors.Is and errors.As
+// as well as with the AWS SDK helpers
+type Error struct {
+	Kind    error
+	Service string
+	Cause   awserr.Error
+}
+
+func (e *Error) Error() string        { return e.Cause.Error() }
+func (e *Error) Code() string         { return e.Cause.Code() }
+func (e *Error) Message() string      { return e.Cause.Message() }
+func (e *Error) OrigErr() error       { return e.Cause.OrigErr() }
+func (e *Error) Unwrap() error        { return e.Cause }
+func (e *Error) Is(target error) bool { return target == e.Kind }
+
+func (e *Error) StatusCode() int {
+	if failure, ok := e.Cause.(awserr.RequestFailure); ok {
+		return failure.StatusCode()
+	}
+	return 0
+}
+
+func (e *Error) RequestID() string {
+	if failure, ok := e.Cause.(awserr.RequestFailure); ok {
+		return failure.RequestID()
+	}
+	return ""
+}
+
+// ErrorCategoryHandler categorizes the errors of AWS requests. It is meant to
+// be pushed back on the Validate and UnmarshalError handlers of a session
+var ErrorCategoryHandler = request.NamedHandler{
+	Name: "awless.ErrorCategoryHandler",
+	Fn: func(r *request.Request) {
+		r.Error = categorizeError(r.ClientInfo.ServiceName, r.Error)
+	},
+}
+
+func categorizeError(service string, err error) error {
+	awsErr, ok := err.(awserr.Error)
+	if !ok {
+		return err
+	}
+	if _, done := awsErr.(*Error); done {
+		return err
+	}
+	var status int
+	if failure, ok := awsErr.(awserr.RequestFailure); ok {
+		status = failure.StatusCode()
+	}
+	if kind := errorKind(awsErr.Code(), status); kind != nil {
+		return &Error{Kind: kind, Service: service, Cause: awsErr}
+	}
+	return err
+}
+
+var (
+	accessDeniedCodes = []string{"AccessDenied", "AccessDeniedException", "UnauthorizedOperation", "AuthFailure", "Forbidden", "InvalidClientTokenId", "SignatureDoesNotMatch"}
+	throttledCodes    = []string{"Throttling", "ThrottlingException", "ThrottledException", "RequestThrottled", "RequestThrottledException", "RequestLimitExceeded", "TooManyRequestsException", "ProvisionedThroughputExceededException", "SlowDown", "PriorRequestNotComplete"}
+	validationCodes   = []string{"ValidationError", "ValidationException", "InvalidParameter", "InvalidParameterValue", "InvalidParameterCombination", "InvalidParameterException", "InvalidParameterValueException", "MissingParameter", "MalformedPolicyDocument", "InvalidInput"}
+)
+
+func errorKind(code string, status int) error {
+	switch {
+	case strings.Contains(code, "NotFound"), strings.HasPrefix(code, "NoSuch"):
+		return cloud.ErrNotFound
+	case contains(accessDeniedCodes, code), status == http.StatusForbidden:
+		return cloud.ErrAccessDenied
+	case contains(throttledCodes, code), status == http.StatusTooManyRequests:
+		return cloud.ErrThrottled
+	case contains(validationCodes, code), strings.HasSuffix(code, ".Malformed"):
+		return cloud.ErrValidation
+	case status == http.StatusNotFound:
+		return cloud.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/wallix/awless/cloud"
+)
+
+func TestCategorizeError(t *testing.T) {
+	tcases := []struct {
+		err  error
+		kind error
+	}{
+		{err: awserr.New("InvalidInstanceID.NotFound", "The instance ID 'i-1234' does not exist", nil), kind: cloud.ErrNotFound},
+		{err: awserr.New("NoSuchEntity", "The user with name jdoe cannot be found", nil), kind: cloud.ErrNotFound},
+		{err: awserr.New("UnauthorizedOperation", "You are not authorized to perform this operation", nil), kind: cloud.ErrAccessDenied},
+		{err: awserr.NewRequestFailure(awserr.New("Unknown", "forbidden", nil), 403, "req-1"), kind: cloud.ErrAccessDenied},
+		{err: awserr.New("RequestLimitExceeded", "Request limit exceeded", nil), kind: cloud.ErrThrottled},
+		{err: awserr.New("InvalidParameterValue", "Value (toto) for parameter instanceType is invalid", nil), kind: cloud.ErrValidation},
+		{err: awserr.New("InvalidAMIID.Malformed", "Invalid id: \"ami-toto\"", nil), kind: cloud.ErrValidation},
+		{err: awserr.New("DryRunOperation", "Request would have succeeded", nil)},
+		{err: errors.New("not an aws error")},
+	}
+
+	for i, tcase := range tcases {
+		err := categorizeError("ec2", tcase.err)
+		if got, want := err.Error(), tcase.err.Error(); got != want {
+			t.Fatalf("%d: got %s, want %s", i+1, got, want)
+		}
+		wrapped := fmt.Errorf("create instance: %w", err)
+		for _, kind := range []error{cloud.ErrNotFound, cloud.ErrAccessDenied, cloud.ErrThrottled, cloud.ErrValidation} {
+			if got, want := errors.Is(wrapped, kind), kind == tcase.kind; got != want {
+				t.Fatalf("%d: is %s: got %t, want %t", i+1, kind, got, want)
+			}
+		}
+		if tcase.kind == nil {
+			continue
+		}
+		var awsErr *Error
+		if !errors.As(wrapped, &awsErr) {
+			t.Fatalf("%d: expected an *Error", i+1)
+		}
+		if got, want := awsErr.Service, "ec2"; got != want {
+			t.Fatalf("%d: got %s, want %s", i+1, got, want)
+		}
+		if _, ok := err.(awserr.RequestFailure); !ok {
+			t.Fatalf("%d: expected categorized error to still be an awserr.RequestFailure", i+1)
+		}
+		if got, want := categorizeError("ec2", err), err; got != want {
+			t.Fatalf("%d: expected categorized error to be left as is", i+1)
+		}
+	}
+
+	if !errors.Is(cloud.ErrFetchAccessDenied, cloud.ErrAccessDenied) {
+		t.Fatal("expected fetch access denied to be an access denied error")
+	}
+}
+
+func TestNonFatalFetchErrors(t *testing.T) {
+	defer SetNonFatalFetchCodes(DefaultNonFatalFetchCodes)
+
+	if got, want := NonFatalFetchCodes(map[string]interface{}{}), DefaultNonFatalFetchCodes; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got := NonFatalFetchCodes(map[string]interface{}{NonFatalFetchCodesConfigKey: "none"}); len(got) != 0 {
+		t.Fatalf("got %v, want none", got)
+	}
+	codes := NonFatalFetchCodes(map[string]interface{}{NonFatalFetchCodesConfigKey: " OptInRequired, CustomCode ,"})
+	if got, want := codes, []string{"OptInRequired", "CustomCode"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	SetNonFatalFetchCodes(codes)
+
+	fetchError := new(multiError)
+	fetchError.add(awserr.New("CustomCode", "feature not enabled", nil))
+	fetchError.add(fmt.Errorf("list detectors: %w", categorizeError("guardduty", awserr.NewRequestFailure(awserr.New("OptInRequired", "not subscribed", nil), 403, "req-1"))))
+	if fetchError.hasAny() {
+		t.Fatalf("unexpected errors: %s", fetchError)
+	}
+	fetchError.add(awserr.New("AWSOrganizationsNotInUseException", "not in use", nil))
+	fetchError.add(errors.New("not an aws error"))
+	if got, want := len(fetchError.errs), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+}
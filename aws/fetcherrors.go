@@ -0,0 +1,77 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+)
+
+// NonFatalFetchCodesConfigKey is the config key of the comma separated AWS error codes
+// fetched as an empty result rather than as a sync failure
+const NonFatalFetchCodesConfigKey = "aws.fetch.nonfatal.codes"
+
+// DefaultNonFatalFetchCodes are returned by services not enabled or not subscribed for the account
+var DefaultNonFatalFetchCodes = []string{
+	"AWSOrganizationsNotInUseException",
+	"OptInRequired",
+	"SubscriptionRequiredException",
+	"UnsupportedOperation",
+}
+
+var nonFatalFetchCodes = DefaultNonFatalFetchCodes
+
+// SetNonFatalFetchCodes sets the AWS error codes ignored when fetching resources
+func SetNonFatalFetchCodes(codes []string) {
+	nonFatalFetchCodes = codes
+}
+
+// NonFatalFetchCodes returns the AWS error codes set in config, or the default ones when unset.
+// The value 'none' makes all fetch errors fatal
+func NonFatalFetchCodes(conf map[string]interface{}) []string {
+	str, _ := conf[NonFatalFetchCodesConfigKey].(string)
+	str = strings.TrimSpace(str)
+	switch str {
+	case "":
+		return DefaultNonFatalFetchCodes
+	case "none":
+		return nil
+	}
+	var codes []string
+	for _, code := range strings.Split(str, ",") {
+		if code = strings.TrimSpace(code); code != "" {
+			codes = append(codes, code)
+		}
+	}
+	return codes
+}
+
+// isNonFatalFetchError returns the code of an AWS error to fetch as an empty result
+func isNonFatalFetchError(err error) (string, bool) {
+	var awsErr awserr.Error
+	if !errors.As(err, &awsErr) {
+		return "", false
+	}
+	for _, code := range nonFatalFetchCodes {
+		if awsErr.Code() == code {
+			return code, true
+		}
+	}
+	return "", false
+}
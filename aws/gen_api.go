@@ -92,11 +92,18 @@ var ResourceTypes = []string{
 	"internetgateway",
 	"natgateway",
 	"routetable",
+	"dhcpoptions",
+	"placementgroup",
+	"dedicatedhost",
+	"prefixlist",
+	"networkacl",
 	"availabilityzone",
 	"image",
 	"importimagetask",
 	"elasticip",
 	"snapshot",
+	"fleet",
+	"finding",
 	"loadbalancer",
 	"targetgroup",
 	"listener",
@@ -106,6 +113,7 @@ var ResourceTypes = []string{
 	"scalinggroup",
 	"scalingpolicy",
 	"repository",
+	"containerimage",
 	"containercluster",
 	"containerservice",
 	"container",
@@ -122,7 +130,10 @@ var ResourceTypes = []string{
 	"queue",
 	"zone",
 	"record",
+	"healthcheck",
 	"function",
+	"stream",
+	"deliverystream",
 	"metric",
 	"alarm",
 	"distribution",
@@ -159,11 +170,18 @@ var ServicePerResourceType = map[string]string{
 	"internetgateway":     "infra",
 	"natgateway":          "infra",
 	"routetable":          "infra",
+	"dhcpoptions":         "infra",
+	"placementgroup":      "infra",
+	"dedicatedhost":       "infra",
+	"prefixlist":          "infra",
+	"networkacl":          "infra",
 	"availabilityzone":    "infra",
 	"image":               "infra",
 	"importimagetask":     "infra",
 	"elasticip":           "infra",
 	"snapshot":            "infra",
+	"fleet":               "infra",
+	"finding":             "infra",
 	"loadbalancer":        "infra",
 	"targetgroup":         "infra",
 	"listener":            "infra",
@@ -173,6 +191,7 @@ var ServicePerResourceType = map[string]string{
 	"scalinggroup":        "infra",
 	"scalingpolicy":       "infra",
 	"repository":          "infra",
+	"containerimage":      "infra",
 	"containercluster":    "infra",
 	"containerservice":    "infra",
 	"container":           "infra",
@@ -189,7 +208,10 @@ var ServicePerResourceType = map[string]string{
 	"queue":               "messaging",
 	"zone":                "dns",
 	"record":              "dns",
+	"healthcheck":         "dns",
 	"function":            "lambda",
+	"stream":              "lambda",
+	"deliverystream":      "lambda",
 	"metric":              "monitoring",
 	"alarm":               "monitoring",
 	"distribution":        "cdn",
@@ -206,11 +228,18 @@ var APIPerResourceType = map[string]string{
 	"internetgateway":     "ec2",
 	"natgateway":          "ec2",
 	"routetable":          "ec2",
+	"dhcpoptions":         "ec2",
+	"placementgroup":      "ec2",
+	"dedicatedhost":       "ec2",
+	"prefixlist":          "ec2",
+	"networkacl":          "ec2",
 	"availabilityzone":    "ec2",
 	"image":               "ec2",
 	"importimagetask":     "ec2",
 	"elasticip":           "ec2",
 	"snapshot":            "ec2",
+	"fleet":               "ec2",
+	"finding":             "ec2",
 	"loadbalancer":        "elbv2",
 	"targetgroup":         "elbv2",
 	"listener":            "elbv2",
@@ -220,6 +249,7 @@ var APIPerResourceType = map[string]string{
 	"scalinggroup":        "autoscaling",
 	"scalingpolicy":       "autoscaling",
 	"repository":          "ecr",
+	"containerimage":      "ecr",
 	"containercluster":    "ecs",
 	"containerservice":    "ecs",
 	"container":           "ecs",
@@ -236,7 +266,10 @@ var APIPerResourceType = map[string]string{
 	"queue":               "sqs",
 	"zone":                "route53",
 	"record":              "route53",
+	"healthcheck":         "route53",
 	"function":            "lambda",
+	"stream":              "lambda",
+	"deliverystream":      "lambda",
 	"metric":              "cloudwatch",
 	"alarm":               "cloudwatch",
 	"distribution":        "cloudfront",
@@ -300,11 +333,18 @@ func (s *Infra) ResourceTypes() []string {
 		"internetgateway",
 		"natgateway",
 		"routetable",
+		"dhcpoptions",
+		"placementgroup",
+		"dedicatedhost",
+		"prefixlist",
+		"networkacl",
 		"availabilityzone",
 		"image",
 		"importimagetask",
 		"elasticip",
 		"snapshot",
+		"fleet",
+		"finding",
 		"loadbalancer",
 		"targetgroup",
 		"listener",
@@ -314,6 +354,7 @@ func (s *Infra) ResourceTypes() []string {
 		"scalinggroup",
 		"scalingpolicy",
 		"repository",
+		"containerimage",
 		"containercluster",
 		"containerservice",
 		"container",
@@ -340,11 +381,18 @@ func (s *Infra) FetchResources() (*graph.Graph, error) {
 	var internetgatewayList []*ec2.InternetGateway
 	var natgatewayList []*ec2.NatGateway
 	var routetableList []*ec2.RouteTable
+	var dhcpoptionsList []*ec2.DhcpOptions
+	var placementgroupList []*ec2.PlacementGroup
+	var dedicatedhostList []*ec2.Host
+	var prefixlistList []*awsdriver.ManagedPrefixList
+	var networkaclList []*ec2.NetworkAcl
 	var availabilityzoneList []*ec2.AvailabilityZone
 	var imageList []*ec2.Image
 	var importimagetaskList []*ec2.ImportImageTask
 	var elasticipList []*ec2.Address
 	var snapshotList []*ec2.Snapshot
+	var fleetList []*awsdriver.Fleet
+	var findingList []*GuardDutyFinding
 	var loadbalancerList []*elbv2.LoadBalancer
 	var targetgroupList []*elbv2.TargetGroup
 	var listenerList []*elbv2.Listener
@@ -354,6 +402,7 @@ func (s *Infra) FetchResources() (*graph.Graph, error) {
 	var scalinggroupList []*autoscaling.Group
 	var scalingpolicyList []*autoscaling.ScalingPolicy
 	var repositoryList []*ecr.Repository
+	var containerimageList []*awsdriver.ContainerImage
 	var containerclusterList []*ecs.Cluster
 	var containerserviceList []*ecs.TaskDefinition
 	var containerList []*ecs.Container
@@ -508,6 +557,86 @@ func (s *Infra) FetchResources() (*graph.Graph, error) {
 	} else {
 		s.log.Verbose("sync: *disabled* for resource infra[routetable]")
 	}
+	if s.config.getBool("aws.infra.dhcpoptions.sync", true) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var resGraph *graph.Graph
+			var err error
+			resGraph, dhcpoptionsList, err = s.fetch_all_dhcpoptions_graph()
+			if err != nil {
+				errc <- err
+				return
+			}
+			g.AddGraph(resGraph)
+		}()
+	} else {
+		s.log.Verbose("sync: *disabled* for resource infra[dhcpoptions]")
+	}
+	if s.config.getBool("aws.infra.placementgroup.sync", true) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var resGraph *graph.Graph
+			var err error
+			resGraph, placementgroupList, err = s.fetch_all_placementgroup_graph()
+			if err != nil {
+				errc <- err
+				return
+			}
+			g.AddGraph(resGraph)
+		}()
+	} else {
+		s.log.Verbose("sync: *disabled* for resource infra[placementgroup]")
+	}
+	if s.config.getBool("aws.infra.dedicatedhost.sync", true) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var resGraph *graph.Graph
+			var err error
+			resGraph, dedicatedhostList, err = s.fetch_all_dedicatedhost_graph()
+			if err != nil {
+				errc <- err
+				return
+			}
+			g.AddGraph(resGraph)
+		}()
+	} else {
+		s.log.Verbose("sync: *disabled* for resource infra[dedicatedhost]")
+	}
+	if s.config.getBool("aws.infra.prefixlist.sync", true) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var resGraph *graph.Graph
+			var err error
+			resGraph, prefixlistList, err = s.fetch_all_prefixlist_graph()
+			if err != nil {
+				errc <- err
+				return
+			}
+			g.AddGraph(resGraph)
+		}()
+	} else {
+		s.log.Verbose("sync: *disabled* for resource infra[prefixlist]")
+	}
+	if s.config.getBool("aws.infra.networkacl.sync", true) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var resGraph *graph.Graph
+			var err error
+			resGraph, networkaclList, err = s.fetch_all_networkacl_graph()
+			if err != nil {
+				errc <- err
+				return
+			}
+			g.AddGraph(resGraph)
+		}()
+	} else {
+		s.log.Verbose("sync: *disabled* for resource infra[networkacl]")
+	}
 	if s.config.getBool("aws.infra.availabilityzone.sync", true) {
 		wg.Add(1)
 		go func() {
@@ -588,6 +717,38 @@ func (s *Infra) FetchResources() (*graph.Graph, error) {
 	} else {
 		s.log.Verbose("sync: *disabled* for resource infra[snapshot]")
 	}
+	if s.config.getBool("aws.infra.fleet.sync", true) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var resGraph *graph.Graph
+			var err error
+			resGraph, fleetList, err = s.fetch_all_fleet_graph()
+			if err != nil {
+				errc <- err
+				return
+			}
+			g.AddGraph(resGraph)
+		}()
+	} else {
+		s.log.Verbose("sync: *disabled* for resource infra[fleet]")
+	}
+	if s.config.getBool("aws.infra.finding.sync", true) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var resGraph *graph.Graph
+			var err error
+			resGraph, findingList, err = s.fetch_all_finding_graph()
+			if err != nil {
+				errc <- err
+				return
+			}
+			g.AddGraph(resGraph)
+		}()
+	} else {
+		s.log.Verbose("sync: *disabled* for resource infra[finding]")
+	}
 	if s.config.getBool("aws.infra.loadbalancer.sync", true) {
 		wg.Add(1)
 		go func() {
@@ -732,6 +893,22 @@ func (s *Infra) FetchResources() (*graph.Graph, error) {
 	} else {
 		s.log.Verbose("sync: *disabled* for resource infra[repository]")
 	}
+	if s.config.getBool("aws.infra.containerimage.sync", true) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var resGraph *graph.Graph
+			var err error
+			resGraph, containerimageList, err = s.fetch_all_containerimage_graph()
+			if err != nil {
+				errc <- err
+				return
+			}
+			g.AddGraph(resGraph)
+		}()
+	} else {
+		s.log.Verbose("sync: *disabled* for resource infra[containerimage]")
+	}
 	if s.config.getBool("aws.infra.containercluster.sync", true) {
 		wg.Add(1)
 		go func() {
@@ -954,6 +1131,81 @@ func (s *Infra) FetchResources() (*graph.Graph, error) {
 			}
 		}()
 	}
+	if s.config.getBool("aws.infra.dhcpoptions.sync", true) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, r := range dhcpoptionsList {
+				for _, fn := range addParentsFns["dhcpoptions"] {
+					err := fn(g, r)
+					if err != nil {
+						errc <- err
+						return
+					}
+				}
+			}
+		}()
+	}
+	if s.config.getBool("aws.infra.placementgroup.sync", true) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, r := range placementgroupList {
+				for _, fn := range addParentsFns["placementgroup"] {
+					err := fn(g, r)
+					if err != nil {
+						errc <- err
+						return
+					}
+				}
+			}
+		}()
+	}
+	if s.config.getBool("aws.infra.dedicatedhost.sync", true) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, r := range dedicatedhostList {
+				for _, fn := range addParentsFns["dedicatedhost"] {
+					err := fn(g, r)
+					if err != nil {
+						errc <- err
+						return
+					}
+				}
+			}
+		}()
+	}
+	if s.config.getBool("aws.infra.prefixlist.sync", true) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, r := range prefixlistList {
+				for _, fn := range addParentsFns["prefixlist"] {
+					err := fn(g, r)
+					if err != nil {
+						errc <- err
+						return
+					}
+				}
+			}
+		}()
+	}
+	if s.config.getBool("aws.infra.networkacl.sync", true) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, r := range networkaclList {
+				for _, fn := range addParentsFns["networkacl"] {
+					err := fn(g, r)
+					if err != nil {
+						errc <- err
+						return
+					}
+				}
+			}
+		}()
+	}
 	if s.config.getBool("aws.infra.availabilityzone.sync", true) {
 		wg.Add(1)
 		go func() {
@@ -1029,6 +1281,36 @@ func (s *Infra) FetchResources() (*graph.Graph, error) {
 			}
 		}()
 	}
+	if s.config.getBool("aws.infra.fleet.sync", true) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, r := range fleetList {
+				for _, fn := range addParentsFns["fleet"] {
+					err := fn(g, r)
+					if err != nil {
+						errc <- err
+						return
+					}
+				}
+			}
+		}()
+	}
+	if s.config.getBool("aws.infra.finding.sync", true) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, r := range findingList {
+				for _, fn := range addParentsFns["finding"] {
+					err := fn(g, r)
+					if err != nil {
+						errc <- err
+						return
+					}
+				}
+			}
+		}()
+	}
 	if s.config.getBool("aws.infra.loadbalancer.sync", true) {
 		wg.Add(1)
 		go func() {
@@ -1164,6 +1446,21 @@ func (s *Infra) FetchResources() (*graph.Graph, error) {
 			}
 		}()
 	}
+	if s.config.getBool("aws.infra.containerimage.sync", true) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, r := range containerimageList {
+				for _, fn := range addParentsFns["containerimage"] {
+					err := fn(g, r)
+					if err != nil {
+						errc <- err
+						return
+					}
+				}
+			}
+		}()
+	}
 	if s.config.getBool("aws.infra.containercluster.sync", true) {
 		wg.Add(1)
 		go func() {
@@ -1272,6 +1569,21 @@ func (s *Infra) FetchByType(t string) (*graph.Graph, error) {
 	case "routetable":
 		graph, _, err := s.fetch_all_routetable_graph()
 		return graph, err
+	case "dhcpoptions":
+		graph, _, err := s.fetch_all_dhcpoptions_graph()
+		return graph, err
+	case "placementgroup":
+		graph, _, err := s.fetch_all_placementgroup_graph()
+		return graph, err
+	case "dedicatedhost":
+		graph, _, err := s.fetch_all_dedicatedhost_graph()
+		return graph, err
+	case "prefixlist":
+		graph, _, err := s.fetch_all_prefixlist_graph()
+		return graph, err
+	case "networkacl":
+		graph, _, err := s.fetch_all_networkacl_graph()
+		return graph, err
 	case "availabilityzone":
 		graph, _, err := s.fetch_all_availabilityzone_graph()
 		return graph, err
@@ -1287,6 +1599,12 @@ func (s *Infra) FetchByType(t string) (*graph.Graph, error) {
 	case "snapshot":
 		graph, _, err := s.fetch_all_snapshot_graph()
 		return graph, err
+	case "fleet":
+		graph, _, err := s.fetch_all_fleet_graph()
+		return graph, err
+	case "finding":
+		graph, _, err := s.fetch_all_finding_graph()
+		return graph, err
 	case "loadbalancer":
 		graph, _, err := s.fetch_all_loadbalancer_graph()
 		return graph, err
@@ -1314,6 +1632,9 @@ func (s *Infra) FetchByType(t string) (*graph.Graph, error) {
 	case "repository":
 		graph, _, err := s.fetch_all_repository_graph()
 		return graph, err
+	case "containerimage":
+		graph, _, err := s.fetch_all_containerimage_graph()
+		return graph, err
 	case "containercluster":
 		graph, _, err := s.fetch_all_containercluster_graph()
 		return graph, err
@@ -1331,11 +1652,336 @@ func (s *Infra) FetchByType(t string) (*graph.Graph, error) {
 	}
 }
 
+func (s *Infra) FetchResource(t, id string) (*graph.Graph, error) {
+	var fetched []interface{}
+	switch t {
+	case "instance":
+		input := &ec2.DescribeInstancesInput{}
+		input.InstanceIds = []*string{awssdk.String(id)}
+		_, resources, err := s.fetch_instance_graph(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "subnet":
+		input := &ec2.DescribeSubnetsInput{}
+		input.SubnetIds = []*string{awssdk.String(id)}
+		_, resources, err := s.fetch_subnet_graph(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "vpc":
+		input := &ec2.DescribeVpcsInput{}
+		input.VpcIds = []*string{awssdk.String(id)}
+		_, resources, err := s.fetch_vpc_graph(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "keypair":
+		input := &ec2.DescribeKeyPairsInput{}
+		input.KeyNames = []*string{awssdk.String(id)}
+		_, resources, err := s.fetch_keypair_graph(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "securitygroup":
+		input := &ec2.DescribeSecurityGroupsInput{}
+		input.GroupIds = []*string{awssdk.String(id)}
+		_, resources, err := s.fetch_securitygroup_graph(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "volume":
+		input := &ec2.DescribeVolumesInput{}
+		input.VolumeIds = []*string{awssdk.String(id)}
+		_, resources, err := s.fetch_volume_graph(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "internetgateway":
+		input := &ec2.DescribeInternetGatewaysInput{}
+		input.InternetGatewayIds = []*string{awssdk.String(id)}
+		_, resources, err := s.fetch_internetgateway_graph(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "natgateway":
+		input := &ec2.DescribeNatGatewaysInput{}
+		input.NatGatewayIds = []*string{awssdk.String(id)}
+		_, resources, err := s.fetch_natgateway_graph(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "routetable":
+		input := &ec2.DescribeRouteTablesInput{}
+		input.RouteTableIds = []*string{awssdk.String(id)}
+		_, resources, err := s.fetch_routetable_graph(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "dhcpoptions":
+		input := &ec2.DescribeDhcpOptionsInput{}
+		input.DhcpOptionsIds = []*string{awssdk.String(id)}
+		_, resources, err := s.fetch_dhcpoptions_graph(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "placementgroup":
+		input := &ec2.DescribePlacementGroupsInput{}
+		input.GroupNames = []*string{awssdk.String(id)}
+		_, resources, err := s.fetch_placementgroup_graph(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "dedicatedhost":
+		input := &ec2.DescribeHostsInput{}
+		input.HostIds = []*string{awssdk.String(id)}
+		_, resources, err := s.fetch_dedicatedhost_graph(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "prefixlist":
+		_, resources, err := s.fetch_all_prefixlist_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "networkacl":
+		input := &ec2.DescribeNetworkAclsInput{}
+		input.NetworkAclIds = []*string{awssdk.String(id)}
+		_, resources, err := s.fetch_networkacl_graph(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "availabilityzone":
+		_, resources, err := s.fetch_all_availabilityzone_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "image":
+		input := &ec2.DescribeImagesInput{Owners: []*string{awssdk.String("self")}}
+		input.ImageIds = []*string{awssdk.String(id)}
+		_, resources, err := s.fetch_image_graph(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "importimagetask":
+		_, resources, err := s.fetch_all_importimagetask_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "elasticip":
+		input := &ec2.DescribeAddressesInput{}
+		input.AllocationIds = []*string{awssdk.String(id)}
+		_, resources, err := s.fetch_elasticip_graph(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "snapshot":
+		input := &ec2.DescribeSnapshotsInput{OwnerIds: []*string{awssdk.String("self")}}
+		input.SnapshotIds = []*string{awssdk.String(id)}
+		_, resources, err := s.fetch_snapshot_graph(input)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "fleet":
+		_, resources, err := s.fetch_all_fleet_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "finding":
+		_, resources, err := s.fetch_all_finding_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "loadbalancer":
+		_, resources, err := s.fetch_all_loadbalancer_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "targetgroup":
+		_, resources, err := s.fetch_all_targetgroup_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "listener":
+		_, resources, err := s.fetch_all_listener_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "database":
+		_, resources, err := s.fetch_all_database_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "dbsubnetgroup":
+		_, resources, err := s.fetch_all_dbsubnetgroup_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "launchconfiguration":
+		_, resources, err := s.fetch_all_launchconfiguration_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "scalinggroup":
+		_, resources, err := s.fetch_all_scalinggroup_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "scalingpolicy":
+		_, resources, err := s.fetch_all_scalingpolicy_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "repository":
+		_, resources, err := s.fetch_all_repository_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "containerimage":
+		_, resources, err := s.fetch_all_containerimage_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "containercluster":
+		_, resources, err := s.fetch_all_containercluster_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "containerservice":
+		_, resources, err := s.fetch_all_containerservice_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "container":
+		_, resources, err := s.fetch_all_container_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "containerinstance":
+		_, resources, err := s.fetch_all_containerinstance_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	default:
+		return nil, fmt.Errorf("aws infra: unsupported fetch for type %s", t)
+	}
+	return resourceGraph(t, id, fetched)
+}
+
 func (s *Infra) fetch_all_instance_graph() (*graph.Graph, []*ec2.Instance, error) {
+	return s.fetch_instance_graph(&ec2.DescribeInstancesInput{})
+}
+
+func (s *Infra) fetch_instance_graph(input *ec2.DescribeInstancesInput) (*graph.Graph, []*ec2.Instance, error) {
 	g := graph.NewGraph()
 	var cloudResources []*ec2.Instance
 	var badResErr error
-	err := s.DescribeInstancesPages(&ec2.DescribeInstancesInput{},
+	err := s.DescribeInstancesPages(input,
 		func(out *ec2.DescribeInstancesOutput, lastPage bool) (shouldContinue bool) {
 			for _, all := range out.Reservations {
 				for _, output := range all.Instances {
@@ -1362,10 +2008,14 @@ func (s *Infra) fetch_all_instance_graph() (*graph.Graph, []*ec2.Instance, error
 }
 
 func (s *Infra) fetch_all_subnet_graph() (*graph.Graph, []*ec2.Subnet, error) {
+	return s.fetch_subnet_graph(&ec2.DescribeSubnetsInput{})
+}
+
+func (s *Infra) fetch_subnet_graph(input *ec2.DescribeSubnetsInput) (*graph.Graph, []*ec2.Subnet, error) {
 	g := graph.NewGraph()
 	var cloudResources []*ec2.Subnet
 
-	out, err := s.EC2API.DescribeSubnets(&ec2.DescribeSubnetsInput{})
+	out, err := s.EC2API.DescribeSubnets(input)
 	if err != nil {
 		return nil, cloudResources, err
 	}
@@ -1386,10 +2036,14 @@ func (s *Infra) fetch_all_subnet_graph() (*graph.Graph, []*ec2.Subnet, error) {
 }
 
 func (s *Infra) fetch_all_vpc_graph() (*graph.Graph, []*ec2.Vpc, error) {
+	return s.fetch_vpc_graph(&ec2.DescribeVpcsInput{})
+}
+
+func (s *Infra) fetch_vpc_graph(input *ec2.DescribeVpcsInput) (*graph.Graph, []*ec2.Vpc, error) {
 	g := graph.NewGraph()
 	var cloudResources []*ec2.Vpc
 
-	out, err := s.EC2API.DescribeVpcs(&ec2.DescribeVpcsInput{})
+	out, err := s.EC2API.DescribeVpcs(input)
 	if err != nil {
 		return nil, cloudResources, err
 	}
@@ -1410,10 +2064,14 @@ func (s *Infra) fetch_all_vpc_graph() (*graph.Graph, []*ec2.Vpc, error) {
 }
 
 func (s *Infra) fetch_all_keypair_graph() (*graph.Graph, []*ec2.KeyPairInfo, error) {
+	return s.fetch_keypair_graph(&ec2.DescribeKeyPairsInput{})
+}
+
+func (s *Infra) fetch_keypair_graph(input *ec2.DescribeKeyPairsInput) (*graph.Graph, []*ec2.KeyPairInfo, error) {
 	g := graph.NewGraph()
 	var cloudResources []*ec2.KeyPairInfo
 
-	out, err := s.EC2API.DescribeKeyPairs(&ec2.DescribeKeyPairsInput{})
+	out, err := s.EC2API.DescribeKeyPairs(input)
 	if err != nil {
 		return nil, cloudResources, err
 	}
@@ -1434,10 +2092,14 @@ func (s *Infra) fetch_all_keypair_graph() (*graph.Graph, []*ec2.KeyPairInfo, err
 }
 
 func (s *Infra) fetch_all_securitygroup_graph() (*graph.Graph, []*ec2.SecurityGroup, error) {
+	return s.fetch_securitygroup_graph(&ec2.DescribeSecurityGroupsInput{})
+}
+
+func (s *Infra) fetch_securitygroup_graph(input *ec2.DescribeSecurityGroupsInput) (*graph.Graph, []*ec2.SecurityGroup, error) {
 	g := graph.NewGraph()
 	var cloudResources []*ec2.SecurityGroup
 
-	out, err := s.EC2API.DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{})
+	out, err := s.EC2API.DescribeSecurityGroups(input)
 	if err != nil {
 		return nil, cloudResources, err
 	}
@@ -1458,10 +2120,14 @@ func (s *Infra) fetch_all_securitygroup_graph() (*graph.Graph, []*ec2.SecurityGr
 }
 
 func (s *Infra) fetch_all_volume_graph() (*graph.Graph, []*ec2.Volume, error) {
+	return s.fetch_volume_graph(&ec2.DescribeVolumesInput{})
+}
+
+func (s *Infra) fetch_volume_graph(input *ec2.DescribeVolumesInput) (*graph.Graph, []*ec2.Volume, error) {
 	g := graph.NewGraph()
 	var cloudResources []*ec2.Volume
 	var badResErr error
-	err := s.DescribeVolumesPages(&ec2.DescribeVolumesInput{},
+	err := s.DescribeVolumesPages(input,
 		func(out *ec2.DescribeVolumesOutput, lastPage bool) (shouldContinue bool) {
 			for _, output := range out.Volumes {
 				if badResErr != nil {
@@ -1486,10 +2152,14 @@ func (s *Infra) fetch_all_volume_graph() (*graph.Graph, []*ec2.Volume, error) {
 }
 
 func (s *Infra) fetch_all_internetgateway_graph() (*graph.Graph, []*ec2.InternetGateway, error) {
+	return s.fetch_internetgateway_graph(&ec2.DescribeInternetGatewaysInput{})
+}
+
+func (s *Infra) fetch_internetgateway_graph(input *ec2.DescribeInternetGatewaysInput) (*graph.Graph, []*ec2.InternetGateway, error) {
 	g := graph.NewGraph()
 	var cloudResources []*ec2.InternetGateway
 
-	out, err := s.EC2API.DescribeInternetGateways(&ec2.DescribeInternetGatewaysInput{})
+	out, err := s.EC2API.DescribeInternetGateways(input)
 	if err != nil {
 		return nil, cloudResources, err
 	}
@@ -1510,10 +2180,14 @@ func (s *Infra) fetch_all_internetgateway_graph() (*graph.Graph, []*ec2.Internet
 }
 
 func (s *Infra) fetch_all_natgateway_graph() (*graph.Graph, []*ec2.NatGateway, error) {
+	return s.fetch_natgateway_graph(&ec2.DescribeNatGatewaysInput{})
+}
+
+func (s *Infra) fetch_natgateway_graph(input *ec2.DescribeNatGatewaysInput) (*graph.Graph, []*ec2.NatGateway, error) {
 	g := graph.NewGraph()
 	var cloudResources []*ec2.NatGateway
 
-	out, err := s.EC2API.DescribeNatGateways(&ec2.DescribeNatGatewaysInput{})
+	out, err := s.EC2API.DescribeNatGateways(input)
 	if err != nil {
 		return nil, cloudResources, err
 	}
@@ -1534,10 +2208,14 @@ func (s *Infra) fetch_all_natgateway_graph() (*graph.Graph, []*ec2.NatGateway, e
 }
 
 func (s *Infra) fetch_all_routetable_graph() (*graph.Graph, []*ec2.RouteTable, error) {
+	return s.fetch_routetable_graph(&ec2.DescribeRouteTablesInput{})
+}
+
+func (s *Infra) fetch_routetable_graph(input *ec2.DescribeRouteTablesInput) (*graph.Graph, []*ec2.RouteTable, error) {
 	g := graph.NewGraph()
 	var cloudResources []*ec2.RouteTable
 
-	out, err := s.EC2API.DescribeRouteTables(&ec2.DescribeRouteTablesInput{})
+	out, err := s.EC2API.DescribeRouteTables(input)
 	if err != nil {
 		return nil, cloudResources, err
 	}
@@ -1557,11 +2235,124 @@ func (s *Infra) fetch_all_routetable_graph() (*graph.Graph, []*ec2.RouteTable, e
 
 }
 
+func (s *Infra) fetch_all_dhcpoptions_graph() (*graph.Graph, []*ec2.DhcpOptions, error) {
+	return s.fetch_dhcpoptions_graph(&ec2.DescribeDhcpOptionsInput{})
+}
+
+func (s *Infra) fetch_dhcpoptions_graph(input *ec2.DescribeDhcpOptionsInput) (*graph.Graph, []*ec2.DhcpOptions, error) {
+	g := graph.NewGraph()
+	var cloudResources []*ec2.DhcpOptions
+
+	out, err := s.EC2API.DescribeDhcpOptions(input)
+	if err != nil {
+		return nil, cloudResources, err
+	}
+
+	for _, output := range out.DhcpOptions {
+		cloudResources = append(cloudResources, output)
+		res, err := newResource(output)
+		if err != nil {
+			return g, cloudResources, err
+		}
+		if err = g.AddResource(res); err != nil {
+			return g, cloudResources, err
+		}
+	}
+
+	return g, cloudResources, nil
+
+}
+
+func (s *Infra) fetch_all_placementgroup_graph() (*graph.Graph, []*ec2.PlacementGroup, error) {
+	return s.fetch_placementgroup_graph(&ec2.DescribePlacementGroupsInput{})
+}
+
+func (s *Infra) fetch_placementgroup_graph(input *ec2.DescribePlacementGroupsInput) (*graph.Graph, []*ec2.PlacementGroup, error) {
+	g := graph.NewGraph()
+	var cloudResources []*ec2.PlacementGroup
+
+	out, err := s.EC2API.DescribePlacementGroups(input)
+	if err != nil {
+		return nil, cloudResources, err
+	}
+
+	for _, output := range out.PlacementGroups {
+		cloudResources = append(cloudResources, output)
+		res, err := newResource(output)
+		if err != nil {
+			return g, cloudResources, err
+		}
+		if err = g.AddResource(res); err != nil {
+			return g, cloudResources, err
+		}
+	}
+
+	return g, cloudResources, nil
+
+}
+
+func (s *Infra) fetch_all_dedicatedhost_graph() (*graph.Graph, []*ec2.Host, error) {
+	return s.fetch_dedicatedhost_graph(&ec2.DescribeHostsInput{})
+}
+
+func (s *Infra) fetch_dedicatedhost_graph(input *ec2.DescribeHostsInput) (*graph.Graph, []*ec2.Host, error) {
+	g := graph.NewGraph()
+	var cloudResources []*ec2.Host
+
+	out, err := s.EC2API.DescribeHosts(input)
+	if err != nil {
+		return nil, cloudResources, err
+	}
+
+	for _, output := range out.Hosts {
+		cloudResources = append(cloudResources, output)
+		res, err := newResource(output)
+		if err != nil {
+			return g, cloudResources, err
+		}
+		if err = g.AddResource(res); err != nil {
+			return g, cloudResources, err
+		}
+	}
+
+	return g, cloudResources, nil
+
+}
+
+func (s *Infra) fetch_all_networkacl_graph() (*graph.Graph, []*ec2.NetworkAcl, error) {
+	return s.fetch_networkacl_graph(&ec2.DescribeNetworkAclsInput{})
+}
+
+func (s *Infra) fetch_networkacl_graph(input *ec2.DescribeNetworkAclsInput) (*graph.Graph, []*ec2.NetworkAcl, error) {
+	g := graph.NewGraph()
+	var cloudResources []*ec2.NetworkAcl
+
+	out, err := s.EC2API.DescribeNetworkAcls(input)
+	if err != nil {
+		return nil, cloudResources, err
+	}
+
+	for _, output := range out.NetworkAcls {
+		cloudResources = append(cloudResources, output)
+		res, err := newResource(output)
+		if err != nil {
+			return g, cloudResources, err
+		}
+		if err = g.AddResource(res); err != nil {
+			return g, cloudResources, err
+		}
+	}
+
+	return g, cloudResources, nil
+
+}
+
 func (s *Infra) fetch_all_availabilityzone_graph() (*graph.Graph, []*ec2.AvailabilityZone, error) {
+	input := &ec2.DescribeAvailabilityZonesInput{}
 	g := graph.NewGraph()
 	var cloudResources []*ec2.AvailabilityZone
 
-	out, err := s.EC2API.DescribeAvailabilityZones(&ec2.DescribeAvailabilityZonesInput{})
+	out, err := s.EC2API.DescribeAvailabilityZones(input)
 	if err != nil {
 		return nil, cloudResources, err
 	}
@@ -1582,10 +2373,14 @@ func (s *Infra) fetch_all_availabilityzone_graph() (*graph.Graph, []*ec2.Availab
 }
 
 func (s *Infra) fetch_all_image_graph() (*graph.Graph, []*ec2.Image, error) {
+	return s.fetch_image_graph(&ec2.DescribeImagesInput{Owners: []*string{awssdk.String("self")}})
+}
+
+func (s *Infra) fetch_image_graph(input *ec2.DescribeImagesInput) (*graph.Graph, []*ec2.Image, error) {
 	g := graph.NewGraph()
 	var cloudResources []*ec2.Image
 
-	out, err := s.EC2API.DescribeImages(&ec2.DescribeImagesInput{Owners: []*string{awssdk.String("self")}})
+	out, err := s.EC2API.DescribeImages(input)
 	if err != nil {
 		return nil, cloudResources, err
 	}
@@ -1606,10 +2401,11 @@ func (s *Infra) fetch_all_image_graph() (*graph.Graph, []*ec2.Image, error) {
 }
 
 func (s *Infra) fetch_all_importimagetask_graph() (*graph.Graph, []*ec2.ImportImageTask, error) {
+	input := &ec2.DescribeImportImageTasksInput{}
 	g := graph.NewGraph()
 	var cloudResources []*ec2.ImportImageTask
 
-	out, err := s.EC2API.DescribeImportImageTasks(&ec2.DescribeImportImageTasksInput{})
+	out, err := s.EC2API.DescribeImportImageTasks(input)
 	if err != nil {
 		return nil, cloudResources, err
 	}
@@ -1630,10 +2426,14 @@ func (s *Infra) fetch_all_importimagetask_graph() (*graph.Graph, []*ec2.ImportIm
 }
 
 func (s *Infra) fetch_all_elasticip_graph() (*graph.Graph, []*ec2.Address, error) {
+	return s.fetch_elasticip_graph(&ec2.DescribeAddressesInput{})
+}
+
+func (s *Infra) fetch_elasticip_graph(input *ec2.DescribeAddressesInput) (*graph.Graph, []*ec2.Address, error) {
 	g := graph.NewGraph()
 	var cloudResources []*ec2.Address
 
-	out, err := s.EC2API.DescribeAddresses(&ec2.DescribeAddressesInput{})
+	out, err := s.EC2API.DescribeAddresses(input)
 	if err != nil {
 		return nil, cloudResources, err
 	}
@@ -1649,45 +2449,21 @@ func (s *Infra) fetch_all_elasticip_graph() (*graph.Graph, []*ec2.Address, error
 		}
 	}
 
-	return g, cloudResources, nil
-
-}
-
-func (s *Infra) fetch_all_snapshot_graph() (*graph.Graph, []*ec2.Snapshot, error) {
-	g := graph.NewGraph()
-	var cloudResources []*ec2.Snapshot
-	var badResErr error
-	err := s.DescribeSnapshotsPages(&ec2.DescribeSnapshotsInput{OwnerIds: []*string{awssdk.String("self")}},
-		func(out *ec2.DescribeSnapshotsOutput, lastPage bool) (shouldContinue bool) {
-			for _, output := range out.Snapshots {
-				if badResErr != nil {
-					return false
-				}
-				cloudResources = append(cloudResources, output)
-				var res *graph.Resource
-				if res, badResErr = newResource(output); badResErr != nil {
-					return false
-				}
-				if badResErr = g.AddResource(res); badResErr != nil {
-					return false
-				}
-			}
-			return out.NextToken != nil
-		})
-	if err != nil {
-		return g, cloudResources, err
-	}
+	return g, cloudResources, nil
 
-	return g, cloudResources, badResErr
 }
 
-func (s *Infra) fetch_all_loadbalancer_graph() (*graph.Graph, []*elbv2.LoadBalancer, error) {
+func (s *Infra) fetch_all_snapshot_graph() (*graph.Graph, []*ec2.Snapshot, error) {
+	return s.fetch_snapshot_graph(&ec2.DescribeSnapshotsInput{OwnerIds: []*string{awssdk.String("self")}})
+}
+
+func (s *Infra) fetch_snapshot_graph(input *ec2.DescribeSnapshotsInput) (*graph.Graph, []*ec2.Snapshot, error) {
 	g := graph.NewGraph()
-	var cloudResources []*elbv2.LoadBalancer
+	var cloudResources []*ec2.Snapshot
 	var badResErr error
-	err := s.DescribeLoadBalancersPages(&elbv2.DescribeLoadBalancersInput{},
-		func(out *elbv2.DescribeLoadBalancersOutput, lastPage bool) (shouldContinue bool) {
-			for _, output := range out.LoadBalancers {
+	err := s.DescribeSnapshotsPages(input,
+		func(out *ec2.DescribeSnapshotsOutput, lastPage bool) (shouldContinue bool) {
+			for _, output := range out.Snapshots {
 				if badResErr != nil {
 					return false
 				}
@@ -1700,7 +2476,7 @@ func (s *Infra) fetch_all_loadbalancer_graph() (*graph.Graph, []*elbv2.LoadBalan
 					return false
 				}
 			}
-			return out.NextMarker != nil
+			return out.NextToken != nil
 		})
 	if err != nil {
 		return g, cloudResources, err
@@ -1709,35 +2485,12 @@ func (s *Infra) fetch_all_loadbalancer_graph() (*graph.Graph, []*elbv2.LoadBalan
 	return g, cloudResources, badResErr
 }
 
-func (s *Infra) fetch_all_targetgroup_graph() (*graph.Graph, []*elbv2.TargetGroup, error) {
-	g := graph.NewGraph()
-	var cloudResources []*elbv2.TargetGroup
-
-	out, err := s.ELBV2API.DescribeTargetGroups(&elbv2.DescribeTargetGroupsInput{})
-	if err != nil {
-		return nil, cloudResources, err
-	}
-
-	for _, output := range out.TargetGroups {
-		cloudResources = append(cloudResources, output)
-		res, err := newResource(output)
-		if err != nil {
-			return g, cloudResources, err
-		}
-		if err = g.AddResource(res); err != nil {
-			return g, cloudResources, err
-		}
-	}
-
-	return g, cloudResources, nil
-
-}
-
 func (s *Infra) fetch_all_database_graph() (*graph.Graph, []*rds.DBInstance, error) {
+	input := &rds.DescribeDBInstancesInput{}
 	g := graph.NewGraph()
 	var cloudResources []*rds.DBInstance
 	var badResErr error
-	err := s.DescribeDBInstancesPages(&rds.DescribeDBInstancesInput{},
+	err := s.DescribeDBInstancesPages(input,
 		func(out *rds.DescribeDBInstancesOutput, lastPage bool) (shouldContinue bool) {
 			for _, output := range out.DBInstances {
 				if badResErr != nil {
@@ -1762,10 +2515,11 @@ func (s *Infra) fetch_all_database_graph() (*graph.Graph, []*rds.DBInstance, err
 }
 
 func (s *Infra) fetch_all_dbsubnetgroup_graph() (*graph.Graph, []*rds.DBSubnetGroup, error) {
+	input := &rds.DescribeDBSubnetGroupsInput{}
 	g := graph.NewGraph()
 	var cloudResources []*rds.DBSubnetGroup
 	var badResErr error
-	err := s.DescribeDBSubnetGroupsPages(&rds.DescribeDBSubnetGroupsInput{},
+	err := s.DescribeDBSubnetGroupsPages(input,
 		func(out *rds.DescribeDBSubnetGroupsOutput, lastPage bool) (shouldContinue bool) {
 			for _, output := range out.DBSubnetGroups {
 				if badResErr != nil {
@@ -1790,10 +2544,11 @@ func (s *Infra) fetch_all_dbsubnetgroup_graph() (*graph.Graph, []*rds.DBSubnetGr
 }
 
 func (s *Infra) fetch_all_launchconfiguration_graph() (*graph.Graph, []*autoscaling.LaunchConfiguration, error) {
+	input := &autoscaling.DescribeLaunchConfigurationsInput{}
 	g := graph.NewGraph()
 	var cloudResources []*autoscaling.LaunchConfiguration
 	var badResErr error
-	err := s.DescribeLaunchConfigurationsPages(&autoscaling.DescribeLaunchConfigurationsInput{},
+	err := s.DescribeLaunchConfigurationsPages(input,
 		func(out *autoscaling.DescribeLaunchConfigurationsOutput, lastPage bool) (shouldContinue bool) {
 			for _, output := range out.LaunchConfigurations {
 				if badResErr != nil {
@@ -1818,10 +2573,11 @@ func (s *Infra) fetch_all_launchconfiguration_graph() (*graph.Graph, []*autoscal
 }
 
 func (s *Infra) fetch_all_scalinggroup_graph() (*graph.Graph, []*autoscaling.Group, error) {
+	input := &autoscaling.DescribeAutoScalingGroupsInput{}
 	g := graph.NewGraph()
 	var cloudResources []*autoscaling.Group
 	var badResErr error
-	err := s.DescribeAutoScalingGroupsPages(&autoscaling.DescribeAutoScalingGroupsInput{},
+	err := s.DescribeAutoScalingGroupsPages(input,
 		func(out *autoscaling.DescribeAutoScalingGroupsOutput, lastPage bool) (shouldContinue bool) {
 			for _, output := range out.AutoScalingGroups {
 				if badResErr != nil {
@@ -1846,10 +2602,11 @@ func (s *Infra) fetch_all_scalinggroup_graph() (*graph.Graph, []*autoscaling.Gro
 }
 
 func (s *Infra) fetch_all_scalingpolicy_graph() (*graph.Graph, []*autoscaling.ScalingPolicy, error) {
+	input := &autoscaling.DescribePoliciesInput{}
 	g := graph.NewGraph()
 	var cloudResources []*autoscaling.ScalingPolicy
 	var badResErr error
-	err := s.DescribePoliciesPages(&autoscaling.DescribePoliciesInput{},
+	err := s.DescribePoliciesPages(input,
 		func(out *autoscaling.DescribePoliciesOutput, lastPage bool) (shouldContinue bool) {
 			for _, output := range out.ScalingPolicies {
 				if badResErr != nil {
@@ -1874,10 +2631,11 @@ func (s *Infra) fetch_all_scalingpolicy_graph() (*graph.Graph, []*autoscaling.Sc
 }
 
 func (s *Infra) fetch_all_repository_graph() (*graph.Graph, []*ecr.Repository, error) {
+	input := &ecr.DescribeRepositoriesInput{}
 	g := graph.NewGraph()
 	var cloudResources []*ecr.Repository
 	var badResErr error
-	err := s.DescribeRepositoriesPages(&ecr.DescribeRepositoriesInput{},
+	err := s.DescribeRepositoriesPages(input,
 		func(out *ecr.DescribeRepositoriesOutput, lastPage bool) (shouldContinue bool) {
 			for _, output := range out.Repositories {
 				if badResErr != nil {
@@ -2186,11 +2944,61 @@ func (s *Access) FetchByType(t string) (*graph.Graph, error) {
 	}
 }
 
+func (s *Access) FetchResource(t, id string) (*graph.Graph, error) {
+	var fetched []interface{}
+	switch t {
+	case "user":
+		_, resources, err := s.fetch_all_user_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "group":
+		_, resources, err := s.fetch_all_group_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "role":
+		_, resources, err := s.fetch_all_role_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "policy":
+		_, resources, err := s.fetch_all_policy_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "accesskey":
+		_, resources, err := s.fetch_all_accesskey_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	default:
+		return nil, fmt.Errorf("aws access: unsupported fetch for type %s", t)
+	}
+	return resourceGraph(t, id, fetched)
+}
+
 func (s *Access) fetch_all_group_graph() (*graph.Graph, []*iam.GroupDetail, error) {
+	input := &iam.GetAccountAuthorizationDetailsInput{Filter: []*string{awssdk.String(iam.EntityTypeGroup)}}
 	g := graph.NewGraph()
 	var cloudResources []*iam.GroupDetail
 	var badResErr error
-	err := s.GetAccountAuthorizationDetailsPages(&iam.GetAccountAuthorizationDetailsInput{Filter: []*string{awssdk.String(iam.EntityTypeGroup)}},
+	err := s.GetAccountAuthorizationDetailsPages(input,
 		func(out *iam.GetAccountAuthorizationDetailsOutput, lastPage bool) (shouldContinue bool) {
 			for _, output := range out.GroupDetailList {
 				if badResErr != nil {
@@ -2215,10 +3023,11 @@ func (s *Access) fetch_all_group_graph() (*graph.Graph, []*iam.GroupDetail, erro
 }
 
 func (s *Access) fetch_all_role_graph() (*graph.Graph, []*iam.RoleDetail, error) {
+	input := &iam.GetAccountAuthorizationDetailsInput{Filter: []*string{awssdk.String(iam.EntityTypeRole)}}
 	g := graph.NewGraph()
 	var cloudResources []*iam.RoleDetail
 	var badResErr error
-	err := s.GetAccountAuthorizationDetailsPages(&iam.GetAccountAuthorizationDetailsInput{Filter: []*string{awssdk.String(iam.EntityTypeRole)}},
+	err := s.GetAccountAuthorizationDetailsPages(input,
 		func(out *iam.GetAccountAuthorizationDetailsOutput, lastPage bool) (shouldContinue bool) {
 			for _, output := range out.RoleDetailList {
 				if badResErr != nil {
@@ -2243,10 +3052,11 @@ func (s *Access) fetch_all_role_graph() (*graph.Graph, []*iam.RoleDetail, error)
 }
 
 func (s *Access) fetch_all_accesskey_graph() (*graph.Graph, []*iam.AccessKeyMetadata, error) {
+	input := &iam.ListAccessKeysInput{}
 	g := graph.NewGraph()
 	var cloudResources []*iam.AccessKeyMetadata
 	var badResErr error
-	err := s.ListAccessKeysPages(&iam.ListAccessKeysInput{},
+	err := s.ListAccessKeysPages(input,
 		func(out *iam.ListAccessKeysOutput, lastPage bool) (shouldContinue bool) {
 			for _, output := range out.AccessKeyMetadata {
 				if badResErr != nil {
@@ -2444,6 +3254,31 @@ func (s *Storage) FetchByType(t string) (*graph.Graph, error) {
 	}
 }
 
+func (s *Storage) FetchResource(t, id string) (*graph.Graph, error) {
+	var fetched []interface{}
+	switch t {
+	case "bucket":
+		_, resources, err := s.fetch_all_bucket_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "s3object":
+		_, resources, err := s.fetch_all_s3object_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	default:
+		return nil, fmt.Errorf("aws storage: unsupported fetch for type %s", t)
+	}
+	return resourceGraph(t, id, fetched)
+}
+
 func (s *Storage) IsSyncDisabled() bool {
 	return !s.config.getBool("aws.storage.sync", true)
 }
@@ -2657,11 +3492,45 @@ func (s *Messaging) FetchByType(t string) (*graph.Graph, error) {
 	}
 }
 
+func (s *Messaging) FetchResource(t, id string) (*graph.Graph, error) {
+	var fetched []interface{}
+	switch t {
+	case "subscription":
+		_, resources, err := s.fetch_all_subscription_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "topic":
+		_, resources, err := s.fetch_all_topic_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "queue":
+		_, resources, err := s.fetch_all_queue_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	default:
+		return nil, fmt.Errorf("aws messaging: unsupported fetch for type %s", t)
+	}
+	return resourceGraph(t, id, fetched)
+}
+
 func (s *Messaging) fetch_all_subscription_graph() (*graph.Graph, []*sns.Subscription, error) {
+	input := &sns.ListSubscriptionsInput{}
 	g := graph.NewGraph()
 	var cloudResources []*sns.Subscription
 	var badResErr error
-	err := s.ListSubscriptionsPages(&sns.ListSubscriptionsInput{},
+	err := s.ListSubscriptionsPages(input,
 		func(out *sns.ListSubscriptionsOutput, lastPage bool) (shouldContinue bool) {
 			for _, output := range out.Subscriptions {
 				if badResErr != nil {
@@ -2686,10 +3555,11 @@ func (s *Messaging) fetch_all_subscription_graph() (*graph.Graph, []*sns.Subscri
 }
 
 func (s *Messaging) fetch_all_topic_graph() (*graph.Graph, []*sns.Topic, error) {
+	input := &sns.ListTopicsInput{}
 	g := graph.NewGraph()
 	var cloudResources []*sns.Topic
 	var badResErr error
-	err := s.ListTopicsPages(&sns.ListTopicsInput{},
+	err := s.ListTopicsPages(input,
 		func(out *sns.ListTopicsOutput, lastPage bool) (shouldContinue bool) {
 			for _, output := range out.Topics {
 				if badResErr != nil {
@@ -2749,6 +3619,7 @@ func (s *Dns) ResourceTypes() []string {
 	return []string{
 		"zone",
 		"record",
+		"healthcheck",
 	}
 }
 
@@ -2764,6 +3635,7 @@ func (s *Dns) FetchResources() (*graph.Graph, error) {
 	}
 	var zoneList []*route53.HostedZone
 	var recordList []*route53.ResourceRecordSet
+	var healthcheckList []*route53.HealthCheck
 
 	fetchError := new(multiError)
 
@@ -2802,6 +3674,22 @@ func (s *Dns) FetchResources() (*graph.Graph, error) {
 	} else {
 		s.log.Verbose("sync: *disabled* for resource dns[record]")
 	}
+	if s.config.getBool("aws.dns.healthcheck.sync", true) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var resGraph *graph.Graph
+			var err error
+			resGraph, healthcheckList, err = s.fetch_all_healthcheck_graph()
+			if err != nil {
+				errc <- err
+				return
+			}
+			g.AddGraph(resGraph)
+		}()
+	} else {
+		s.log.Verbose("sync: *disabled* for resource dns[healthcheck]")
+	}
 
 	go func() {
 		wg.Wait()
@@ -2855,6 +3743,21 @@ func (s *Dns) FetchResources() (*graph.Graph, error) {
 			}
 		}()
 	}
+	if s.config.getBool("aws.dns.healthcheck.sync", true) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, r := range healthcheckList {
+				for _, fn := range addParentsFns["healthcheck"] {
+					err := fn(g, r)
+					if err != nil {
+						errc <- err
+						return
+					}
+				}
+			}
+		}()
+	}
 
 	go func() {
 		wg.Wait()
@@ -2882,16 +3785,53 @@ func (s *Dns) FetchByType(t string) (*graph.Graph, error) {
 	case "record":
 		graph, _, err := s.fetch_all_record_graph()
 		return graph, err
+	case "healthcheck":
+		graph, _, err := s.fetch_all_healthcheck_graph()
+		return graph, err
+	default:
+		return nil, fmt.Errorf("aws dns: unsupported fetch for type %s", t)
+	}
+}
+
+func (s *Dns) FetchResource(t, id string) (*graph.Graph, error) {
+	var fetched []interface{}
+	switch t {
+	case "zone":
+		_, resources, err := s.fetch_all_zone_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "record":
+		_, resources, err := s.fetch_all_record_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "healthcheck":
+		_, resources, err := s.fetch_all_healthcheck_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
 	default:
 		return nil, fmt.Errorf("aws dns: unsupported fetch for type %s", t)
 	}
+	return resourceGraph(t, id, fetched)
 }
 
 func (s *Dns) fetch_all_zone_graph() (*graph.Graph, []*route53.HostedZone, error) {
+	input := &route53.ListHostedZonesInput{}
 	g := graph.NewGraph()
 	var cloudResources []*route53.HostedZone
 	var badResErr error
-	err := s.ListHostedZonesPages(&route53.ListHostedZonesInput{},
+	err := s.ListHostedZonesPages(input,
 		func(out *route53.ListHostedZonesOutput, lastPage bool) (shouldContinue bool) {
 			for _, output := range out.HostedZones {
 				if badResErr != nil {
@@ -2915,6 +3855,35 @@ func (s *Dns) fetch_all_zone_graph() (*graph.Graph, []*route53.HostedZone, error
 	return g, cloudResources, badResErr
 }
 
+func (s *Dns) fetch_all_healthcheck_graph() (*graph.Graph, []*route53.HealthCheck, error) {
+	input := &route53.ListHealthChecksInput{}
+	g := graph.NewGraph()
+	var cloudResources []*route53.HealthCheck
+	var badResErr error
+	err := s.ListHealthChecksPages(input,
+		func(out *route53.ListHealthChecksOutput, lastPage bool) (shouldContinue bool) {
+			for _, output := range out.HealthChecks {
+				if badResErr != nil {
+					return false
+				}
+				cloudResources = append(cloudResources, output)
+				var res *graph.Resource
+				if res, badResErr = newResource(output); badResErr != nil {
+					return false
+				}
+				if badResErr = g.AddResource(res); badResErr != nil {
+					return false
+				}
+			}
+			return out.NextMarker != nil
+		})
+	if err != nil {
+		return g, cloudResources, err
+	}
+
+	return g, cloudResources, badResErr
+}
+
 func (s *Dns) IsSyncDisabled() bool {
 	return !s.config.getBool("aws.dns.sync", true)
 }
@@ -2950,6 +3919,8 @@ func (s *Lambda) Drivers() []driver.Driver {
 func (s *Lambda) ResourceTypes() []string {
 	return []string{
 		"function",
+		"stream",
+		"deliverystream",
 	}
 }
 
@@ -2964,6 +3935,8 @@ func (s *Lambda) FetchResources() (*graph.Graph, error) {
 		return g, err
 	}
 	var functionList []*lambda.FunctionConfiguration
+	var streamList []*awsdriver.Stream
+	var deliverystreamList []*awsdriver.DeliveryStream
 
 	fetchError := new(multiError)
 
@@ -2986,6 +3959,38 @@ func (s *Lambda) FetchResources() (*graph.Graph, error) {
 	} else {
 		s.log.Verbose("sync: *disabled* for resource lambda[function]")
 	}
+	if s.config.getBool("aws.lambda.stream.sync", true) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var resGraph *graph.Graph
+			var err error
+			resGraph, streamList, err = s.fetch_all_stream_graph()
+			if err != nil {
+				errc <- err
+				return
+			}
+			g.AddGraph(resGraph)
+		}()
+	} else {
+		s.log.Verbose("sync: *disabled* for resource lambda[stream]")
+	}
+	if s.config.getBool("aws.lambda.deliverystream.sync", true) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var resGraph *graph.Graph
+			var err error
+			resGraph, deliverystreamList, err = s.fetch_all_deliverystream_graph()
+			if err != nil {
+				errc <- err
+				return
+			}
+			g.AddGraph(resGraph)
+		}()
+	} else {
+		s.log.Verbose("sync: *disabled* for resource lambda[deliverystream]")
+	}
 
 	go func() {
 		wg.Wait()
@@ -3024,6 +4029,36 @@ func (s *Lambda) FetchResources() (*graph.Graph, error) {
 			}
 		}()
 	}
+	if s.config.getBool("aws.lambda.stream.sync", true) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, r := range streamList {
+				for _, fn := range addParentsFns["stream"] {
+					err := fn(g, r)
+					if err != nil {
+						errc <- err
+						return
+					}
+				}
+			}
+		}()
+	}
+	if s.config.getBool("aws.lambda.deliverystream.sync", true) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, r := range deliverystreamList {
+				for _, fn := range addParentsFns["deliverystream"] {
+					err := fn(g, r)
+					if err != nil {
+						errc <- err
+						return
+					}
+				}
+			}
+		}()
+	}
 
 	go func() {
 		wg.Wait()
@@ -3048,16 +4083,56 @@ func (s *Lambda) FetchByType(t string) (*graph.Graph, error) {
 	case "function":
 		graph, _, err := s.fetch_all_function_graph()
 		return graph, err
+	case "stream":
+		graph, _, err := s.fetch_all_stream_graph()
+		return graph, err
+	case "deliverystream":
+		graph, _, err := s.fetch_all_deliverystream_graph()
+		return graph, err
+	default:
+		return nil, fmt.Errorf("aws lambda: unsupported fetch for type %s", t)
+	}
+}
+
+func (s *Lambda) FetchResource(t, id string) (*graph.Graph, error) {
+	var fetched []interface{}
+	switch t {
+	case "function":
+		_, resources, err := s.fetch_all_function_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "stream":
+		_, resources, err := s.fetch_all_stream_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "deliverystream":
+		_, resources, err := s.fetch_all_deliverystream_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
 	default:
 		return nil, fmt.Errorf("aws lambda: unsupported fetch for type %s", t)
 	}
+	return resourceGraph(t, id, fetched)
 }
 
 func (s *Lambda) fetch_all_function_graph() (*graph.Graph, []*lambda.FunctionConfiguration, error) {
+	input := &lambda.ListFunctionsInput{}
 	g := graph.NewGraph()
 	var cloudResources []*lambda.FunctionConfiguration
 	var badResErr error
-	err := s.ListFunctionsPages(&lambda.ListFunctionsInput{},
+	err := s.ListFunctionsPages(input,
 		func(out *lambda.ListFunctionsOutput, lastPage bool) (shouldContinue bool) {
 			for _, output := range out.Functions {
 				if badResErr != nil {
@@ -3255,11 +4330,37 @@ func (s *Monitoring) FetchByType(t string) (*graph.Graph, error) {
 	}
 }
 
+func (s *Monitoring) FetchResource(t, id string) (*graph.Graph, error) {
+	var fetched []interface{}
+	switch t {
+	case "metric":
+		_, resources, err := s.fetch_all_metric_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	case "alarm":
+		_, resources, err := s.fetch_all_alarm_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	default:
+		return nil, fmt.Errorf("aws monitoring: unsupported fetch for type %s", t)
+	}
+	return resourceGraph(t, id, fetched)
+}
+
 func (s *Monitoring) fetch_all_metric_graph() (*graph.Graph, []*cloudwatch.Metric, error) {
+	input := &cloudwatch.ListMetricsInput{}
 	g := graph.NewGraph()
 	var cloudResources []*cloudwatch.Metric
 	var badResErr error
-	err := s.ListMetricsPages(&cloudwatch.ListMetricsInput{},
+	err := s.ListMetricsPages(input,
 		func(out *cloudwatch.ListMetricsOutput, lastPage bool) (shouldContinue bool) {
 			for _, output := range out.Metrics {
 				if badResErr != nil {
@@ -3284,10 +4385,11 @@ func (s *Monitoring) fetch_all_metric_graph() (*graph.Graph, []*cloudwatch.Metri
 }
 
 func (s *Monitoring) fetch_all_alarm_graph() (*graph.Graph, []*cloudwatch.MetricAlarm, error) {
+	input := &cloudwatch.DescribeAlarmsInput{}
 	g := graph.NewGraph()
 	var cloudResources []*cloudwatch.MetricAlarm
 	var badResErr error
-	err := s.DescribeAlarmsPages(&cloudwatch.DescribeAlarmsInput{},
+	err := s.DescribeAlarmsPages(input,
 		func(out *cloudwatch.DescribeAlarmsOutput, lastPage bool) (shouldContinue bool) {
 			for _, output := range out.MetricAlarms {
 				if badResErr != nil {
@@ -3449,11 +4551,29 @@ func (s *Cdn) FetchByType(t string) (*graph.Graph, error) {
 	}
 }
 
+func (s *Cdn) FetchResource(t, id string) (*graph.Graph, error) {
+	var fetched []interface{}
+	switch t {
+	case "distribution":
+		_, resources, err := s.fetch_all_distribution_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	default:
+		return nil, fmt.Errorf("aws cdn: unsupported fetch for type %s", t)
+	}
+	return resourceGraph(t, id, fetched)
+}
+
 func (s *Cdn) fetch_all_distribution_graph() (*graph.Graph, []*cloudfront.DistributionSummary, error) {
+	input := &cloudfront.ListDistributionsInput{}
 	g := graph.NewGraph()
 	var cloudResources []*cloudfront.DistributionSummary
 	var badResErr error
-	err := s.ListDistributionsPages(&cloudfront.ListDistributionsInput{},
+	err := s.ListDistributionsPages(input,
 		func(out *cloudfront.ListDistributionsOutput, lastPage bool) (shouldContinue bool) {
 			for _, output := range out.DistributionList.Items {
 				if badResErr != nil {
@@ -3615,11 +4735,29 @@ func (s *Cloudformation) FetchByType(t string) (*graph.Graph, error) {
 	}
 }
 
+func (s *Cloudformation) FetchResource(t, id string) (*graph.Graph, error) {
+	var fetched []interface{}
+	switch t {
+	case "stack":
+		_, resources, err := s.fetch_all_stack_graph()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+	default:
+		return nil, fmt.Errorf("aws cloudformation: unsupported fetch for type %s", t)
+	}
+	return resourceGraph(t, id, fetched)
+}
+
 func (s *Cloudformation) fetch_all_stack_graph() (*graph.Graph, []*cloudformation.Stack, error) {
+	input := &cloudformation.DescribeStacksInput{}
 	g := graph.NewGraph()
 	var cloudResources []*cloudformation.Stack
 	var badResErr error
-	err := s.DescribeStacksPages(&cloudformation.DescribeStacksInput{},
+	err := s.DescribeStacksPages(input,
 		func(out *cloudformation.DescribeStacksOutput, lastPage bool) (shouldContinue bool) {
 			for _, output := range out.Stacks {
 				if badResErr != nil {
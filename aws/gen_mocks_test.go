@@ -70,6 +70,10 @@ type mockEc2 struct {
 	internetgateways  []*ec2.InternetGateway
 	natgateways       []*ec2.NatGateway
 	routetables       []*ec2.RouteTable
+	dhcpoptionss      []*ec2.DhcpOptions
+	placementgroups   []*ec2.PlacementGroup
+	hosts             []*ec2.Host
+	networkacls       []*ec2.NetworkAcl
 	availabilityzones []*ec2.AvailabilityZone
 	images            []*ec2.Image
 	importimagetasks  []*ec2.ImportImageTask
@@ -156,6 +160,22 @@ func (m *mockEc2) DescribeRouteTables(input *ec2.DescribeRouteTablesInput) (*ec2
 	return &ec2.DescribeRouteTablesOutput{RouteTables: m.routetables}, nil
 }
 
+func (m *mockEc2) DescribeDhcpOptions(input *ec2.DescribeDhcpOptionsInput) (*ec2.DescribeDhcpOptionsOutput, error) {
+	return &ec2.DescribeDhcpOptionsOutput{DhcpOptions: m.dhcpoptionss}, nil
+}
+
+func (m *mockEc2) DescribePlacementGroups(input *ec2.DescribePlacementGroupsInput) (*ec2.DescribePlacementGroupsOutput, error) {
+	return &ec2.DescribePlacementGroupsOutput{PlacementGroups: m.placementgroups}, nil
+}
+
+func (m *mockEc2) DescribeHosts(input *ec2.DescribeHostsInput) (*ec2.DescribeHostsOutput, error) {
+	return &ec2.DescribeHostsOutput{Hosts: m.hosts}, nil
+}
+
+func (m *mockEc2) DescribeNetworkAcls(input *ec2.DescribeNetworkAclsInput) (*ec2.DescribeNetworkAclsOutput, error) {
+	return &ec2.DescribeNetworkAclsOutput{NetworkAcls: m.networkacls}, nil
+}
+
 func (m *mockEc2) DescribeAvailabilityZones(input *ec2.DescribeAvailabilityZonesInput) (*ec2.DescribeAvailabilityZonesOutput, error) {
 	return &ec2.DescribeAvailabilityZonesOutput{AvailabilityZones: m.availabilityzones}, nil
 }
@@ -195,6 +215,7 @@ type mockElbv2 struct {
 	targetgroups             []*elbv2.TargetGroup
 	listeners                []*elbv2.Listener
 	targethealthdescriptions map[string][]*elbv2.TargetHealthDescription
+	tagdescriptions          []*elbv2.TagDescription
 }
 
 func (m *mockElbv2) Name() string {
@@ -482,9 +503,11 @@ func (m *mockIam) ListAccessKeysPages(input *iam.ListAccessKeysInput, fn func(p
 
 type mockS3 struct {
 	s3iface.S3API
-	buckets map[string][]*s3.Bucket
-	objects map[string][]*s3.Object
-	grants  map[string][]*s3.Grant
+	buckets      map[string][]*s3.Bucket
+	objects      map[string][]*s3.Object
+	grants       map[string][]*s3.Grant
+	replications map[string]*s3.ReplicationConfiguration
+	policies     map[string]string
 }
 
 func (m *mockS3) Name() string {
@@ -643,6 +666,7 @@ type mockRoute53 struct {
 	route53iface.Route53API
 	hostedzones        []*route53.HostedZone
 	resourcerecordsets map[string][]*route53.ResourceRecordSet
+	healthchecks       []*route53.HealthCheck
 }
 
 func (m *mockRoute53) Name() string {
@@ -696,6 +720,23 @@ func (m *mockRoute53) ListHostedZonesPages(input *route53.ListHostedZonesInput,
 	return nil
 }
 
+func (m *mockRoute53) ListHealthChecksPages(input *route53.ListHealthChecksInput, fn func(p *route53.ListHealthChecksOutput, lastPage bool) (shouldContinue bool)) error {
+	var pages [][]*route53.HealthCheck
+	for i := 0; i < len(m.healthchecks); i += 2 {
+		page := []*route53.HealthCheck{m.healthchecks[i]}
+		if i+1 < len(m.healthchecks) {
+			page = append(page, m.healthchecks[i+1])
+		}
+		pages = append(pages, page)
+	}
+	for i, page := range pages {
+		fn(&route53.ListHealthChecksOutput{HealthChecks: page, NextMarker: aws.String(strconv.Itoa(i + 1))},
+			i < len(pages),
+		)
+	}
+	return nil
+}
+
 type mockLambda struct {
 	lambdaiface.LambdaAPI
 	functionconfigurations []*lambda.FunctionConfiguration
@@ -0,0 +1,263 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/aws/aws-sdk-go/private/protocol/restjson"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/wallix/awless/cloud"
+)
+
+// Severity levels of GuardDuty findings, from their severity score
+const (
+	FindingSeverityHigh   = "HIGH"
+	FindingSeverityMedium = "MEDIUM"
+	FindingSeverityLow    = "LOW"
+)
+
+// FindingSeverityLevel returns the level of a GuardDuty severity score:
+// HIGH from 7.0, MEDIUM from 4.0 and LOW otherwise
+func FindingSeverityLevel(score float64) string {
+	switch {
+	case score >= 7:
+		return FindingSeverityHigh
+	case score >= 4:
+		return FindingSeverityMedium
+	default:
+		return FindingSeverityLow
+	}
+}
+
+// FindingSeverityRank orders severity levels (LOW < MEDIUM < HIGH). Unknown levels rank 0
+func FindingSeverityRank(level string) int {
+	switch strings.ToUpper(level) {
+	case FindingSeverityHigh:
+		return 3
+	case FindingSeverityMedium:
+		return 2
+	case FindingSeverityLow:
+		return 1
+	}
+	return 0
+}
+
+// guardDutyAPI lists the active findings of the GuardDuty detectors of a region.
+// The vendored SDK does not ship the GuardDuty service, so only these calls
+// are implemented here on top of the generic SDK client
+type guardDutyAPI interface {
+	ListDetectors(*listDetectorsInput) (*listDetectorsOutput, error)
+	ListFindings(*listFindingsInput) (*listFindingsOutput, error)
+	GetFindings(*getFindingsInput) (*getFindingsOutput, error)
+}
+
+// guardDuty returns the GuardDuty API of the region of the EC2 client,
+// or false if the client can not send these calls (ex: mocks)
+func guardDuty(api ec2iface.EC2API) (guardDutyAPI, bool) {
+	switch c := api.(type) {
+	case guardDutyAPI:
+		return c, true
+	case *ec2.EC2:
+		return newGuardDutyClient(c.Client), true
+	}
+	return nil, false
+}
+
+type guardDutyClient struct {
+	*client.Client
+}
+
+func newGuardDutyClient(c *client.Client) *guardDutyClient {
+	region := awssdk.StringValue(c.Config.Region)
+	gd := &guardDutyClient{
+		Client: client.New(
+			c.Config,
+			metadata.ClientInfo{
+				ServiceName:   "guardduty",
+				SigningName:   "guardduty",
+				SigningRegion: region,
+				Endpoint:      fmt.Sprintf("https://guardduty.%s.amazonaws.com", region),
+				APIVersion:    "2017-11-28",
+			},
+			c.Handlers.Copy(),
+		),
+	}
+	gd.Handlers.Sign.Clear()
+	gd.Handlers.Build.Clear()
+	gd.Handlers.Unmarshal.Clear()
+	gd.Handlers.UnmarshalMeta.Clear()
+	gd.Handlers.UnmarshalError.Clear()
+	gd.Handlers.Sign.PushBackNamed(v4.SignRequestHandler)
+	gd.Handlers.Build.PushBackNamed(restjson.BuildHandler)
+	gd.Handlers.Unmarshal.PushBackNamed(restjson.UnmarshalHandler)
+	gd.Handlers.UnmarshalMeta.PushBackNamed(restjson.UnmarshalMetaHandler)
+	gd.Handlers.UnmarshalError.PushBackNamed(restjson.UnmarshalErrorHandler)
+
+	return gd
+}
+
+func (c *guardDutyClient) ListDetectors(input *listDetectorsInput) (*listDetectorsOutput, error) {
+	output := &listDetectorsOutput{}
+	op := &request.Operation{Name: "ListDetectors", HTTPMethod: "GET", HTTPPath: "/detector"}
+	return output, c.NewRequest(op, input, output).Send()
+}
+
+func (c *guardDutyClient) ListFindings(input *listFindingsInput) (*listFindingsOutput, error) {
+	output := &listFindingsOutput{}
+	op := &request.Operation{Name: "ListFindings", HTTPMethod: "POST", HTTPPath: "/detector/{detectorId}/findings"}
+	return output, c.NewRequest(op, input, output).Send()
+}
+
+func (c *guardDutyClient) GetFindings(input *getFindingsInput) (*getFindingsOutput, error) {
+	output := &getFindingsOutput{}
+	op := &request.Operation{Name: "GetFindings", HTTPMethod: "POST", HTTPPath: "/detector/{detectorId}/findings/get"}
+	return output, c.NewRequest(op, input, output).Send()
+}
+
+// GuardDutyFinding is a finding of a GuardDuty detector
+type GuardDutyFinding struct {
+	_ struct{} `type:"structure"`
+
+	AccountId   *string                   `locationName:"accountId" type:"string"`
+	Arn         *string                   `locationName:"arn" type:"string"`
+	CreatedAt   *string                   `locationName:"createdAt" type:"string"`
+	Description *string                   `locationName:"description" type:"string"`
+	Id          *string                   `locationName:"id" type:"string"`
+	Region      *string                   `locationName:"region" type:"string"`
+	Resource    *guardDutyFindingResource `locationName:"resource" type:"structure"`
+	Service     *guardDutyFindingService  `locationName:"service" type:"structure"`
+	Severity    *float64                  `locationName:"severity" type:"double"`
+	Title       *string                   `locationName:"title" type:"string"`
+	Type        *string                   `locationName:"type" type:"string"`
+	UpdatedAt   *string                   `locationName:"updatedAt" type:"string"`
+}
+
+// AffectedResource returns the awless type and id of the resource affected by the finding,
+// or empty values for resources not modeled (ex: EKS clusters)
+func (f *GuardDutyFinding) AffectedResource() (string, string) {
+	r := f.Resource
+	if r == nil {
+		return "", ""
+	}
+	switch {
+	case r.InstanceDetails != nil && awssdk.StringValue(r.InstanceDetails.InstanceId) != "":
+		return cloud.Instance, awssdk.StringValue(r.InstanceDetails.InstanceId)
+	case r.AccessKeyDetails != nil && awssdk.StringValue(r.AccessKeyDetails.AccessKeyId) != "":
+		return cloud.AccessKey, awssdk.StringValue(r.AccessKeyDetails.AccessKeyId)
+	case len(r.S3BucketDetails) > 0 && awssdk.StringValue(r.S3BucketDetails[0].Name) != "":
+		return cloud.Bucket, awssdk.StringValue(r.S3BucketDetails[0].Name)
+	}
+	return "", ""
+}
+
+type guardDutyFindingResource struct {
+	_ struct{} `type:"structure"`
+
+	AccessKeyDetails *guardDutyAccessKeyDetails `locationName:"accessKeyDetails" type:"structure"`
+	InstanceDetails  *guardDutyInstanceDetails  `locationName:"instanceDetails" type:"structure"`
+	ResourceType     *string                    `locationName:"resourceType" type:"string"`
+	S3BucketDetails  []*guardDutyS3BucketDetail `locationName:"s3BucketDetails" type:"list"`
+}
+
+type guardDutyAccessKeyDetails struct {
+	_ struct{} `type:"structure"`
+
+	AccessKeyId *string `locationName:"accessKeyId" type:"string"`
+	UserName    *string `locationName:"userName" type:"string"`
+}
+
+type guardDutyInstanceDetails struct {
+	_ struct{} `type:"structure"`
+
+	InstanceId *string `locationName:"instanceId" type:"string"`
+}
+
+type guardDutyS3BucketDetail struct {
+	_ struct{} `type:"structure"`
+
+	Arn  *string `locationName:"arn" type:"string"`
+	Name *string `locationName:"name" type:"string"`
+}
+
+type guardDutyFindingService struct {
+	_ struct{} `type:"structure"`
+
+	Archived *bool  `locationName:"archived" type:"boolean"`
+	Count    *int64 `locationName:"count" type:"integer"`
+}
+
+type listDetectorsInput struct {
+	_ struct{} `type:"structure"`
+
+	NextToken *string `location:"querystring" locationName:"nextToken" type:"string"`
+}
+
+type listDetectorsOutput struct {
+	_ struct{} `type:"structure"`
+
+	DetectorIds []*string `locationName:"detectorIds" type:"list"`
+	NextToken   *string   `locationName:"nextToken" type:"string"`
+}
+
+type listFindingsInput struct {
+	_ struct{} `type:"structure"`
+
+	DetectorId      *string                   `location:"uri" locationName:"detectorId" type:"string" required:"true"`
+	FindingCriteria *guardDutyFindingCriteria `locationName:"findingCriteria" type:"structure"`
+	MaxResults      *int64                    `locationName:"maxResults" type:"integer"`
+	NextToken       *string                   `locationName:"nextToken" type:"string"`
+}
+
+type guardDutyFindingCriteria struct {
+	_ struct{} `type:"structure"`
+
+	Criterion map[string]*guardDutyCondition `locationName:"criterion" type:"map"`
+}
+
+type guardDutyCondition struct {
+	_ struct{} `type:"structure"`
+
+	Eq []*string `locationName:"eq" type:"list"`
+}
+
+type listFindingsOutput struct {
+	_ struct{} `type:"structure"`
+
+	FindingIds []*string `locationName:"findingIds" type:"list"`
+	NextToken  *string   `locationName:"nextToken" type:"string"`
+}
+
+type getFindingsInput struct {
+	_ struct{} `type:"structure"`
+
+	DetectorId *string   `location:"uri" locationName:"detectorId" type:"string" required:"true"`
+	FindingIds []*string `locationName:"findingIds" type:"list" required:"true"`
+}
+
+type getFindingsOutput struct {
+	_ struct{} `type:"structure"`
+
+	Findings []*GuardDutyFinding `locationName:"findings" type:"list"`
+}
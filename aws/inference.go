@@ -0,0 +1,179 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"strings"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+)
+
+// InferConfigPrefix prefixes the config keys disabling an inference rule.
+// Ex: aws.infer.instance-role=false
+const InferConfigPrefix = "aws.infer."
+
+// InferenceRule joins resources by shared identifiers to relate resources whose relation
+// is not explicit in the API responses, possibly across services (ex: instance and role)
+type InferenceRule struct {
+	Name, Doc string
+	infer     func(*graph.Graph) ([]*InferredRelation, error)
+}
+
+// InferredRelation is a relation found by an inference rule: From applies on To
+type InferredRelation struct {
+	Rule     string
+	From, To *graph.Resource
+}
+
+// InferenceRules are the rules run by InferRelations, in order
+var InferenceRules = []*InferenceRule{
+	{
+		Name:  "instance-role",
+		Doc:   "the role of the instance profile of an instance, by name (the console and awless name instance profiles after their role)",
+		infer: inferInstanceRoles,
+	},
+	{
+		Name:  "function-role",
+		Doc:   "the execution role of a Lambda function, by ARN",
+		infer: inferRoleByArn(cloud.Function),
+	},
+	{
+		Name:  "containertask-role",
+		Doc:   "the task role of an ECS task definition, by ARN",
+		infer: inferRoleByArn(cloud.ContainerService),
+	},
+	{
+		Name:  "loadbalancer-instance",
+		Doc:   "the instances registered in the target groups of a load balancer",
+		infer: inferLoadBalancerInstances,
+	},
+}
+
+// InferRelations runs the inference rules not disabled in config on the graph,
+// adds the inferred relations to it as applies-on relations and returns them
+func InferRelations(g *graph.Graph, conf map[string]interface{}) ([]*InferredRelation, error) {
+	var all []*InferredRelation
+	for _, rule := range InferenceRules {
+		if enabled, ok := conf[InferConfigPrefix+rule.Name].(bool); ok && !enabled {
+			continue
+		}
+		relations, err := rule.infer(g)
+		if err != nil {
+			return all, err
+		}
+		for _, rel := range relations {
+			rel.Rule = rule.Name
+			if err := g.AddAppliesOnRelation(rel.From, rel.To); err != nil {
+				return all, err
+			}
+		}
+		all = append(all, relations...)
+	}
+	return all, nil
+}
+
+func inferInstanceRoles(g *graph.Graph) ([]*InferredRelation, error) {
+	roles, err := resourcesByProperty(g, cloud.Role, properties.Name)
+	if err != nil {
+		return nil, err
+	}
+	instances, err := g.GetAllResources(cloud.Instance)
+	if err != nil {
+		return nil, err
+	}
+	var relations []*InferredRelation
+	for _, inst := range instances {
+		profile, _ := inst.Properties[properties.Profile].(string)
+		if profile == "" {
+			continue
+		}
+		name := profile[strings.LastIndex(profile, "/")+1:]
+		if role, ok := roles[name]; ok {
+			relations = append(relations, &InferredRelation{From: role, To: inst})
+		}
+	}
+	return relations, nil
+}
+
+func inferRoleByArn(resourceType string) func(*graph.Graph) ([]*InferredRelation, error) {
+	return func(g *graph.Graph) ([]*InferredRelation, error) {
+		roles, err := resourcesByProperty(g, cloud.Role, properties.Arn)
+		if err != nil {
+			return nil, err
+		}
+		resources, err := g.GetAllResources(resourceType)
+		if err != nil {
+			return nil, err
+		}
+		var relations []*InferredRelation
+		for _, res := range resources {
+			arn, _ := res.Properties[properties.Role].(string)
+			if role, ok := roles[arn]; ok && arn != "" {
+				relations = append(relations, &InferredRelation{From: role, To: res})
+			}
+		}
+		return relations, nil
+	}
+}
+
+func inferLoadBalancerInstances(g *graph.Graph) ([]*InferredRelation, error) {
+	lbs, err := g.GetAllResources(cloud.LoadBalancer)
+	if err != nil {
+		return nil, err
+	}
+	var relations []*InferredRelation
+	for _, lb := range lbs {
+		groups, err := g.ListResourcesAppliedOn(lb)
+		if err != nil {
+			return nil, err
+		}
+		seen := make(map[string]bool)
+		for _, group := range groups {
+			if group.Type() != cloud.TargetGroup {
+				continue
+			}
+			targets, err := g.ListResourcesAppliedOn(group)
+			if err != nil {
+				return nil, err
+			}
+			for _, target := range targets {
+				if target.Type() == cloud.Instance && !seen[target.Id()] {
+					seen[target.Id()] = true
+					relations = append(relations, &InferredRelation{From: lb, To: target})
+				}
+			}
+		}
+	}
+	return relations, nil
+}
+
+// resourcesByProperty indexes the resources of a type by the string value of a property
+func resourcesByProperty(g *graph.Graph, resourceType, key string) (map[string]*graph.Resource, error) {
+	resources, err := g.GetAllResources(resourceType)
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[string]*graph.Resource)
+	for _, res := range resources {
+		if v, ok := res.Properties[key].(string); ok && v != "" {
+			index[v] = res
+		}
+	}
+	return index, nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	p "github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+)
+
+func TestInferRelations(t *testing.T) {
+	newGraph := func() *graph.Graph {
+		g := graph.NewGraph()
+		web := resourcetest.Role("role_web").Prop(p.Name, "web").Prop(p.Arn, "arn:aws:iam::123456789012:role/web").Build()
+		lambdaRole := resourcetest.Role("role_lambda").Prop(p.Name, "lambda-exec").Prop(p.Arn, "arn:aws:iam::123456789012:role/lambda-exec").Build()
+		inst1 := resourcetest.Instance("inst_1").Prop(p.Profile, "arn:aws:iam::123456789012:instance-profile/web").Build()
+		inst2 := resourcetest.Instance("inst_2").Prop(p.Profile, "arn:aws:iam::123456789012:instance-profile/other").Build()
+		inst3 := resourcetest.Instance("inst_3").Build()
+		fn := resourcetest.Function("fn_1").Prop(p.Role, "arn:aws:iam::123456789012:role/lambda-exec").Build()
+		task := resourcetest.ContainerService("task_1").Prop(p.Role, "arn:aws:iam::123456789012:role/unknown").Build()
+		lb := resourcetest.LoadBalancer("lb_1").Build()
+		tg1 := resourcetest.TargetGroup("tg_1").Build()
+		tg2 := resourcetest.TargetGroup("tg_2").Build()
+		g.AddResource(web, lambdaRole, inst1, inst2, inst3, fn, task, lb, tg1, tg2)
+		g.AddAppliesOnRelation(lb, tg1)
+		g.AddAppliesOnRelation(lb, tg2)
+		g.AddAppliesOnRelation(tg1, inst1)
+		g.AddAppliesOnRelation(tg2, inst1)
+		g.AddAppliesOnRelation(tg2, inst3)
+		return g
+	}
+
+	describe := func(rels []*InferredRelation) (out []string) {
+		for _, rel := range rels {
+			out = append(out, rel.Rule+": "+rel.From.Id()+" -> "+rel.To.Id())
+		}
+		sort.Strings(out)
+		return
+	}
+
+	g := newGraph()
+	relations, err := InferRelations(g, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []string{
+		"function-role: role_lambda -> fn_1",
+		"instance-role: role_web -> inst_1",
+		"loadbalancer-instance: lb_1 -> inst_1",
+		"loadbalancer-instance: lb_1 -> inst_3",
+	}
+	if got, want := describe(relations), exp; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	role, _ := g.GetResource("role", "role_web")
+	appliedOn, err := g.ListResourcesAppliedOn(role)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(appliedOn), 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+
+	relations, err = InferRelations(newGraph(), map[string]interface{}{"aws.infer.loadbalancer-instance": false, "aws.infer.function-role": true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp = []string{
+		"function-role: role_lambda -> fn_1",
+		"instance-role: role_web -> inst_1",
+	}
+	if got, want := describe(relations), exp; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
@@ -20,11 +20,17 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/wallix/awless/aws/config"
 	"github.com/wallix/awless/cloud"
 	"github.com/wallix/awless/logger"
@@ -39,9 +45,31 @@ func InitServices(conf map[string]interface{}, log *logger.Logger) error {
 	awsconf := config(conf)
 	region := awsconf.region()
 	if region == "" {
-		return errors.New("empty AWS region. Set it with `awless config set aws.region`")
+		if region = ProfileRegion(awsconf.profile()); region != "" {
+			awsconf["aws.region"] = region
+		}
+	}
+	if region == "" {
+		return errors.New("empty AWS region. Set it with `awless config set aws.region` or with a region in your AWS profile")
 	}
 
+	if IsMock(conf) {
+		return initMockServices(awsconf, log)
+	}
+
+	timeouts, err := OperationTimeouts(conf)
+	if err != nil {
+		return err
+	}
+	SetOperationTimeouts(timeouts)
+	SetCredentialsFileCache(awsconf.getBool("aws.credentials.cache", true))
+	refreshWindow, err := CredentialsRefreshWindow(conf)
+	if err != nil {
+		return err
+	}
+	SetCredentialsRefreshWindow(refreshWindow)
+	SetNonFatalFetchCodes(NonFatalFetchCodes(conf))
+
 	sess, err := initAWSSession(region, awsconf.profile())
 	if err != nil {
 		return err
@@ -49,13 +77,20 @@ func InitServices(conf map[string]interface{}, log *logger.Logger) error {
 
 	AccessService = NewAccess(sess, awsconf, log)
 	InfraService = NewInfra(sess, awsconf, log)
-	StorageService = NewStorage(sess, awsconf, log)
+	StorageService = NewStorage(s3Session(sess, awsconf), awsconf, log)
 	MessagingService = NewMessaging(sess, awsconf, log)
 	DnsService = NewDns(sess, awsconf, log)
 	LambdaService = NewLambda(sess, awsconf, log)
 	MonitoringService = NewMonitoring(sess, awsconf, log)
 	CdnService = NewCdn(sess, awsconf, log)
 	CloudformationService = NewCloudformation(sess, awsconf, log)
+	ParamStore = NewParameterStore(sess)
+	KMS = NewKeyManagement(sess)
+	CostExplorer = NewCostExplorer(sess)
+	CloudTrail = NewCloudTrail(sess)
+	CloudWatchLogs = NewCloudWatchLogs(sess)
+	Organizations = NewOrganizations(sess)
+	S3Control = NewS3Control(sess)
 
 	cloud.ServiceRegistry[InfraService.Name()] = InfraService
 	cloud.ServiceRegistry[AccessService.Name()] = AccessService
@@ -70,7 +105,45 @@ func InitServices(conf map[string]interface{}, log *logger.Logger) error {
 	return nil
 }
 
+// GlobalServiceNames are the services whose resources do not depend on the region
+var GlobalServiceNames = []string{"access", "dns", "cdn"}
+
+// RegionServices returns the services of the given region, leaving the current services untouched
+func RegionServices(conf map[string]interface{}, region string, log *logger.Logger) ([]cloud.Service, error) {
+	if IsMock(conf) {
+		return nil, ErrMockUnsupported
+	}
+	awsconf := make(config)
+	for k, v := range conf {
+		awsconf[k] = v
+	}
+	awsconf["aws.region"] = region
+
+	sess, err := initAWSSession(region, awsconf.profile())
+	if err != nil {
+		return nil, err
+	}
+	return newServices(sess, awsconf, log), nil
+}
+
+func newServices(sess *session.Session, awsconf config, log *logger.Logger) []cloud.Service {
+	return []cloud.Service{
+		NewAccess(sess, awsconf, log),
+		NewInfra(sess, awsconf, log),
+		NewStorage(s3Session(sess, awsconf), awsconf, log),
+		NewMessaging(sess, awsconf, log),
+		NewDns(sess, awsconf, log),
+		NewLambda(sess, awsconf, log),
+		NewMonitoring(sess, awsconf, log),
+		NewCdn(sess, awsconf, log),
+		NewCloudformation(sess, awsconf, log),
+	}
+}
+
 func NewDriver(region, profile string, log ...*logger.Logger) (driver.Driver, error) {
+	if region == "" {
+		region = ProfileRegion(profile)
+	}
 	if !awsconfig.IsValidRegion(region) {
 		return nil, fmt.Errorf("invalid region '%s' provided", region)
 	}
@@ -104,8 +177,38 @@ func NewDriver(region, profile string, log ...*logger.Logger) (driver.Driver, er
 }
 
 func initAWSSession(region, profile string) (*session.Session, error) {
+	session, err := newAWSSession(region, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	recorder, err := RecorderFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	if recorder != nil && recorder.Replaying() {
+		recorder.Attach(session)
+	} else if _, err = session.Config.Credentials.Get(); err != nil {
+		logCredentialProvidedErrors(logger.DefaultLogger, err)
+		return nil, errors.New("Your AWS credentials seem undefined! AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY need to be exported in your CLI environment\nInstallation documentation is at https://github.com/wallix/awless/wiki/Installation")
+	}
+	session.Config.HTTPClient = http.DefaultClient
+	if recorder != nil {
+		recorder.Attach(session)
+	}
+	session.Handlers.Validate.PushFrontNamed(ContextHandler)
+	session.Handlers.Validate.PushBackNamed(ErrorCategoryHandler)
+	session.Handlers.Validate.PushBackNamed(OperationTimeoutHandler)
+	session.Handlers.UnmarshalError.PushBackNamed(ErrorCategoryHandler)
+
+	return session, nil
+}
+
+// newAWSSession returns the session of the region and profile with its credentials providers, not yet resolved
+func newAWSSession(region, profile string) (*session.Session, error) {
 	session, err := session.NewSessionWithOptions(session.Options{
-		Config:                  awssdk.Config{Region: awssdk.String(region), HTTPClient: &http.Client{Timeout: 2 * time.Second}},
+		Config:                  awssdk.Config{Region: awssdk.String(region), HTTPClient: &http.Client{Timeout: 2 * time.Second}, CredentialsChainVerboseErrors: awssdk.Bool(true)},
 		SharedConfigState:       session.SharedConfigEnable,
 		AssumeRoleTokenProvider: stscreds.StdinTokenProvider,
 		Profile:                 profile,
@@ -115,10 +218,80 @@ func initAWSSession(region, profile string) (*session.Session, error) {
 		return nil, err
 	}
 
-	if _, err = session.Config.Credentials.Get(); err != nil {
-		return nil, errors.New("Your AWS credentials seem undefined! AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY need to be exported in your CLI environment\nInstallation documentation is at https://github.com/wallix/awless/wiki/Installation")
+	if role := profileRoleAssumption(profile); role != nil {
+		if command := credentialProcess(role.SourceProfile); command != "" {
+			source := credentials.NewCredentials(cachedCredentialsProvider(credentialsCacheKey(role.SourceProfile, nil), &processProvider{command: command}))
+			stsAPI := sts.New(session.Copy(&awssdk.Config{Credentials: source}))
+			session.Config.Credentials = credentials.NewCredentials(cachedCredentialsProvider(credentialsCacheKey(profile, role), &assumeRoleProvider{client: stsAPI, role: role}))
+		}
+	} else if command := credentialProcess(profile); command != "" {
+		session.Config.Credentials = credentials.NewCredentials(cachedCredentialsProvider(credentialsCacheKey(profile, nil), &processProvider{command: command}))
 	}
-	session.Config.HTTPClient = http.DefaultClient
 
 	return session, nil
 }
+
+// credentialProviderErrors returns the errors of each credentials provider tried when none resolved
+func credentialProviderErrors(err error) []string {
+	var errs []string
+	if batch, ok := err.(awserr.BatchedErrors); ok {
+		for _, e := range batch.OrigErrs() {
+			errs = append(errs, e.Error())
+		}
+	} else if err != nil {
+		errs = append(errs, err.Error())
+	}
+	return errs
+}
+
+func logCredentialProvidedErrors(log *logger.Logger, err error) {
+	for _, e := range credentialProviderErrors(err) {
+		log.Verbosef("credentials provider: %s", strings.Replace(e, "\n", " ", -1))
+	}
+}
+
+// CredentialsCheck is the outcome of the resolution of the credentials of a profile
+type CredentialsCheck struct {
+	Profile, Region string
+	Provider        string
+	Identity        *Identity
+	ProviderErrors  []string
+	Err             error
+}
+
+// CheckCredentials resolves the credentials of the profile as commands do, then the identity they authenticate
+func CheckCredentials(region, profile string) *CredentialsCheck {
+	check := &CredentialsCheck{Profile: sharedConfigProfile(profile), Region: region}
+
+	sess, err := newAWSSession(region, profile)
+	if err != nil {
+		check.Err = err
+		return check
+	}
+	value, err := sess.Config.Credentials.Get()
+	if err != nil {
+		check.ProviderErrors = credentialProviderErrors(err)
+		check.Err = errors.New("no credentials resolved")
+		return check
+	}
+	check.Provider = value.ProviderName
+
+	sess.Handlers.Validate.PushFrontNamed(ContextHandler)
+	check.Identity, check.Err = (&Access{STSAPI: sts.New(sess)}).GetIdentity()
+	return check
+}
+
+// SetCredentialsFileCache enables or disables the caching on disk of the credentials
+// of `credential_process` and assumed roles (see fileCacheProvider)
+func SetCredentialsFileCache(enabled bool) {
+	credentialsFileCache = enabled
+}
+
+var credentialsFileCache = true
+
+func cachedCredentialsProvider(key string, provider expiringProvider) credentials.Provider {
+	if awlessHome := os.Getenv("__AWLESS_HOME"); awlessHome != "" && credentialsFileCache {
+		return newFileCacheProvider(filepath.Join(awlessHome, "credentials"), key, provider)
+	}
+	return provider
+}
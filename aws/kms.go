@@ -0,0 +1,116 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"fmt"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/aws/aws-sdk-go/private/protocol/jsonrpc"
+)
+
+var KMS *KeyManagement
+
+// KeyManagement encrypts and decrypts small payloads with AWS KMS keys.
+// The vendored SDK does not ship the KMS service, so only the
+// Encrypt and Decrypt calls are implemented here on top of the generic SDK client
+type KeyManagement struct {
+	*client.Client
+}
+
+func NewKeyManagement(sess client.ConfigProvider) *KeyManagement {
+	c := sess.ClientConfig("kms")
+	kms := &KeyManagement{
+		Client: client.New(
+			*c.Config,
+			metadata.ClientInfo{
+				ServiceName:   "kms",
+				SigningName:   c.SigningName,
+				SigningRegion: c.SigningRegion,
+				Endpoint:      c.Endpoint,
+				APIVersion:    "2014-11-01",
+				JSONVersion:   "1.1",
+				TargetPrefix:  "TrentService",
+			},
+			c.Handlers,
+		),
+	}
+	kms.Handlers.Sign.PushBackNamed(v4.SignRequestHandler)
+	kms.Handlers.Build.PushBackNamed(jsonrpc.BuildHandler)
+	kms.Handlers.Unmarshal.PushBackNamed(jsonrpc.UnmarshalHandler)
+	kms.Handlers.UnmarshalMeta.PushBackNamed(jsonrpc.UnmarshalMetaHandler)
+	kms.Handlers.UnmarshalError.PushBackNamed(jsonrpc.UnmarshalErrorHandler)
+
+	return kms
+}
+
+// Encrypt returns the ciphertext of plaintext with the given KMS key (id, ARN or alias/...).
+// KMS encrypts at most 4096 bytes at once
+func (k *KeyManagement) Encrypt(keyID string, plaintext []byte) ([]byte, error) {
+	input := &kmsEncryptInput{KeyId: awssdk.String(keyID), Plaintext: plaintext}
+	output := &kmsEncryptOutput{}
+
+	op := &request.Operation{Name: "Encrypt", HTTPMethod: "POST", HTTPPath: "/"}
+	if err := k.NewRequest(op, input, output).Send(); err != nil {
+		return nil, fmt.Errorf("kms encrypt with key '%s': %s", keyID, err)
+	}
+	return output.CiphertextBlob, nil
+}
+
+// Decrypt returns the plaintext of a ciphertext made by Encrypt. The key
+// is read by KMS from the ciphertext
+func (k *KeyManagement) Decrypt(ciphertext []byte) ([]byte, error) {
+	input := &kmsDecryptInput{CiphertextBlob: ciphertext}
+	output := &kmsDecryptOutput{}
+
+	op := &request.Operation{Name: "Decrypt", HTTPMethod: "POST", HTTPPath: "/"}
+	if err := k.NewRequest(op, input, output).Send(); err != nil {
+		return nil, fmt.Errorf("kms decrypt: %s", err)
+	}
+	return output.Plaintext, nil
+}
+
+type kmsEncryptInput struct {
+	_ struct{} `type:"structure"`
+
+	KeyId     *string `min:"1" type:"string" required:"true"`
+	Plaintext []byte  `min:"1" type:"blob" required:"true"`
+}
+
+type kmsEncryptOutput struct {
+	_ struct{} `type:"structure"`
+
+	CiphertextBlob []byte  `type:"blob"`
+	KeyId          *string `type:"string"`
+}
+
+type kmsDecryptInput struct {
+	_ struct{} `type:"structure"`
+
+	CiphertextBlob []byte `min:"1" type:"blob" required:"true"`
+}
+
+type kmsDecryptOutput struct {
+	_ struct{} `type:"structure"`
+
+	KeyId     *string `type:"string"`
+	Plaintext []byte  `type:"blob"`
+}
@@ -0,0 +1,260 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/cloudwatch/cloudwatchiface"
+)
+
+// Limits of a single GetMetricData request
+const (
+	maxMetricDataQueries    = 500
+	maxMetricDataDatapoints = 100800
+)
+
+// DefaultMetricDataConcurrency is the number of GetMetricData requests sent in parallel
+const DefaultMetricDataConcurrency = 4
+
+// MetricDataAPI fetches metrics statistics in batch.
+// The vendored CloudWatch SDK predates GetMetricData: the call is sent through the CloudWatch
+// client of the SDK with the request and response shapes below
+type MetricDataAPI interface {
+	GetMetricData(*GetMetricDataInput) (*GetMetricDataOutput, error)
+}
+
+// MetricData returns the GetMetricData API of the CloudWatch client,
+// or false if the client can not send this call (ex: mocks)
+func MetricData(api cloudwatchiface.CloudWatchAPI) (MetricDataAPI, bool) {
+	switch c := api.(type) {
+	case MetricDataAPI:
+		return c, true
+	case *cloudwatch.CloudWatch:
+		return &cloudwatchMetricData{c}, true
+	}
+	return nil, false
+}
+
+type cloudwatchMetricData struct {
+	*cloudwatch.CloudWatch
+}
+
+func (c *cloudwatchMetricData) GetMetricData(input *GetMetricDataInput) (*GetMetricDataOutput, error) {
+	output := &GetMetricDataOutput{}
+	op := &request.Operation{Name: "GetMetricData", HTTPMethod: "POST", HTTPPath: "/"}
+	return output, c.NewRequest(op, input, output).Send()
+}
+
+// MetricQuery is the statistic of a metric of one resource (ex: the average CPUUtilization of an instance)
+type MetricQuery struct {
+	Key        string // identifies the query in the results (ex: a resource id)
+	Namespace  string
+	MetricName string
+	Dimensions map[string]string
+	Stat       string
+	Period     time.Duration
+}
+
+// MetricSeries are the datapoints of a query, sorted by time
+type MetricSeries struct {
+	Key        string
+	Timestamps []time.Time
+	Values     []float64
+}
+
+// FetchMetricData returns the datapoints between start and end of all the queries, indexed by query key.
+// Queries are batched in as few GetMetricData requests as the API limits allow (queries and datapoints per request),
+// sent at most concurrency at a time, each request being paged until complete
+func FetchMetricData(api MetricDataAPI, queries []*MetricQuery, start, end time.Time, concurrency int) (map[string]*MetricSeries, error) {
+	if concurrency < 1 {
+		concurrency = DefaultMetricDataConcurrency
+	}
+	batches, err := metricDataBatches(queries, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		errs   []error
+		sem    = make(chan struct{}, concurrency)
+		series = make(map[string]*MetricSeries)
+	)
+	for _, batch := range batches {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(batch []*MetricQuery) {
+			defer func() { <-sem; wg.Done() }()
+			res, err := fetchMetricDataBatch(api, batch, start, end)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+			}
+			for k, s := range res {
+				series[k] = s
+			}
+		}(batch)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return series, fmt.Errorf("get metric data: %s", errs[0])
+	}
+	return series, nil
+}
+
+// FetchMetricData returns the datapoints of the queries from the CloudWatch metrics of the region
+func (s *Monitoring) FetchMetricData(queries []*MetricQuery, start, end time.Time) (map[string]*MetricSeries, error) {
+	api, ok := MetricData(s.CloudWatchAPI)
+	if !ok {
+		return nil, errors.New("get metric data: unsupported by the cloudwatch client")
+	}
+	return FetchMetricData(api, queries, start, end, DefaultMetricDataConcurrency)
+}
+
+// metricDataBatches splits the queries in batches respecting the queries and datapoints limits of a request
+func metricDataBatches(queries []*MetricQuery, start, end time.Time) ([][]*MetricQuery, error) {
+	if !end.After(start) {
+		return nil, errors.New("get metric data: end time must be after start time")
+	}
+	var batches [][]*MetricQuery
+	var current []*MetricQuery
+	var points int64
+	for _, q := range queries {
+		if q.Period < time.Minute {
+			return nil, fmt.Errorf("get metric data: period of %s %s must be at least 1m", q.Key, q.MetricName)
+		}
+		qpoints := int64(end.Sub(start)/q.Period) + 1
+		if len(current) > 0 && (len(current) == maxMetricDataQueries || points+qpoints > maxMetricDataDatapoints) {
+			batches = append(batches, current)
+			current, points = nil, 0
+		}
+		current = append(current, q)
+		points += qpoints
+	}
+	if len(current) > 0 {
+		batches = append(batches, current)
+	}
+	return batches, nil
+}
+
+func fetchMetricDataBatch(api MetricDataAPI, batch []*MetricQuery, start, end time.Time) (map[string]*MetricSeries, error) {
+	input := &GetMetricDataInput{StartTime: awssdk.Time(start), EndTime: awssdk.Time(end), ScanBy: awssdk.String("TimestampAscending")}
+	keys := make(map[string]string)
+	for i, q := range batch {
+		// query ids must start with a lowercase letter
+		id := fmt.Sprintf("q%d", i)
+		keys[id] = q.Key
+		stat := &MetricStat{
+			Metric: &cloudwatch.Metric{Namespace: awssdk.String(q.Namespace), MetricName: awssdk.String(q.MetricName)},
+			Period: awssdk.Int64(int64(q.Period / time.Second)),
+			Stat:   awssdk.String(q.Stat),
+		}
+		var names []string
+		for name := range q.Dimensions {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			stat.Metric.Dimensions = append(stat.Metric.Dimensions, &cloudwatch.Dimension{Name: awssdk.String(name), Value: awssdk.String(q.Dimensions[name])})
+		}
+		input.MetricDataQueries = append(input.MetricDataQueries, &MetricDataQuery{Id: awssdk.String(id), MetricStat: stat})
+	}
+
+	series := make(map[string]*MetricSeries)
+	for {
+		output, err := api.GetMetricData(input)
+		if err != nil {
+			return series, err
+		}
+		for _, result := range output.MetricDataResults {
+			key, ok := keys[awssdk.StringValue(result.Id)]
+			if !ok {
+				continue
+			}
+			s, ok := series[key]
+			if !ok {
+				s = &MetricSeries{Key: key}
+				series[key] = s
+			}
+			for i, t := range result.Timestamps {
+				if i >= len(result.Values) {
+					break
+				}
+				s.Timestamps = append(s.Timestamps, awssdk.TimeValue(t))
+				s.Values = append(s.Values, awssdk.Float64Value(result.Values[i]))
+			}
+		}
+		if awssdk.StringValue(output.NextToken) == "" {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+	return series, nil
+}
+
+type GetMetricDataInput struct {
+	_ struct{} `type:"structure"`
+
+	EndTime           *time.Time         `type:"timestamp" timestampFormat:"iso8601" required:"true"`
+	MetricDataQueries []*MetricDataQuery `type:"list" required:"true"`
+	NextToken         *string            `type:"string"`
+	ScanBy            *string            `type:"string"`
+	StartTime         *time.Time         `type:"timestamp" timestampFormat:"iso8601" required:"true"`
+}
+
+type MetricDataQuery struct {
+	_ struct{} `type:"structure"`
+
+	Id         *string     `type:"string" required:"true"`
+	MetricStat *MetricStat `type:"structure"`
+}
+
+type MetricStat struct {
+	_ struct{} `type:"structure"`
+
+	Metric *cloudwatch.Metric `type:"structure" required:"true"`
+	Period *int64             `type:"integer" required:"true"`
+	Stat   *string            `type:"string" required:"true"`
+}
+
+type GetMetricDataOutput struct {
+	_ struct{} `type:"structure"`
+
+	MetricDataResults []*MetricDataResult `type:"list"`
+	NextToken         *string             `type:"string"`
+}
+
+type MetricDataResult struct {
+	_ struct{} `type:"structure"`
+
+	Id         *string      `type:"string"`
+	Label      *string      `type:"string"`
+	StatusCode *string      `type:"string"`
+	Timestamps []*time.Time `type:"list"`
+	Values     []*float64   `type:"list"`
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+)
+
+type mockMetricData struct {
+	mu                sync.Mutex
+	active, maxActive int
+	requests          int
+	maxQueries        int
+}
+
+func (m *mockMetricData) GetMetricData(input *GetMetricDataInput) (*GetMetricDataOutput, error) {
+	m.mu.Lock()
+	m.active++
+	m.requests++
+	if m.active > m.maxActive {
+		m.maxActive = m.active
+	}
+	if len(input.MetricDataQueries) > m.maxQueries {
+		m.maxQueries = len(input.MetricDataQueries)
+	}
+	m.mu.Unlock()
+	time.Sleep(5 * time.Millisecond)
+	defer func() {
+		m.mu.Lock()
+		m.active--
+		m.mu.Unlock()
+	}()
+
+	// first page returns the first datapoint, second page the second one
+	page := 0
+	if awssdk.StringValue(input.NextToken) != "" {
+		page = 1
+	}
+	out := &GetMetricDataOutput{}
+	for _, q := range input.MetricDataQueries {
+		ts := awssdk.TimeValue(input.StartTime).Add(time.Duration(page) * time.Hour)
+		out.MetricDataResults = append(out.MetricDataResults, &MetricDataResult{
+			Id:         q.Id,
+			Timestamps: []*time.Time{awssdk.Time(ts)},
+			Values:     []*float64{awssdk.Float64(float64(page))},
+		})
+	}
+	if page == 0 {
+		out.NextToken = awssdk.String("next")
+	}
+	return out, nil
+}
+
+func TestFetchMetricData(t *testing.T) {
+	start := time.Date(2017, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+
+	var queries []*MetricQuery
+	for i := 0; i < 1600; i++ {
+		queries = append(queries, &MetricQuery{
+			Key: fmt.Sprintf("inst_%d", i), Namespace: "AWS/EC2", MetricName: "CPUUtilization",
+			Dimensions: map[string]string{"InstanceId": fmt.Sprintf("inst_%d", i)}, Stat: "Average", Period: time.Hour,
+		})
+	}
+
+	api := &mockMetricData{}
+	series, err := FetchMetricData(api, queries, start, end, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := api.requests, 8; got != want {
+		t.Fatalf("got %d requests, want %d (4 batches of 2 pages)", got, want)
+	}
+	if got, want := api.maxQueries, maxMetricDataQueries; got != want {
+		t.Fatalf("got %d queries per request, want %d", got, want)
+	}
+	if api.maxActive > 2 {
+		t.Fatalf("got %d concurrent requests, want at most 2", api.maxActive)
+	}
+	if got, want := len(series), 1600; got != want {
+		t.Fatalf("got %d series, want %d", got, want)
+	}
+	s := series["inst_1599"]
+	if got, want := len(s.Values), 2; got != want {
+		t.Fatalf("got %d values, want %d", got, want)
+	}
+	if got, want := s.Timestamps[1], start.Add(time.Hour); !got.Equal(want) {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestMetricDataBatches(t *testing.T) {
+	start := time.Date(2017, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(14 * 24 * time.Hour)
+
+	var queries []*MetricQuery
+	for i := 0; i < 12; i++ {
+		queries = append(queries, &MetricQuery{Key: fmt.Sprint(i), Period: time.Minute})
+	}
+	batches, err := metricDataBatches(queries, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 20161 datapoints per query over 14 days: 4 queries per request
+	if got, want := len(batches), 3; got != want {
+		t.Fatalf("got %d batches, want %d", got, want)
+	}
+	if got, want := len(batches[0]), 4; got != want {
+		t.Fatalf("got %d queries, want %d", got, want)
+	}
+
+	if _, err := metricDataBatches([]*MetricQuery{{Key: "1", Period: time.Second}}, start, end); err == nil {
+		t.Fatal("expected error for period below 1m")
+	}
+	if _, err := metricDataBatches(queries, end, start); err == nil {
+		t.Fatal("expected error for end before start")
+	}
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/wallix/awless/aws/driver"
+	"github.com/wallix/awless/cloud"
+	p "github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template/driver"
+)
+
+const (
+	mockConfigKey         = "aws.mock"
+	mockFixturesConfigKey = "aws.mock.fixtures"
+)
+
+// ErrMockUnsupported is returned by commands calling AWS APIs outside of the services resources and drivers
+var ErrMockUnsupported = errors.New("not supported in mock mode")
+
+// IsMock returns whether the mock mode is enabled, either with the `aws.mock` config
+// or the AWLESS_MOCK environment variable. In mock mode, canned resources are served
+// instead of calling AWS and mutating drivers simulate success
+func IsMock(conf map[string]interface{}) bool {
+	if mock, err := strconv.ParseBool(os.Getenv("AWLESS_MOCK")); err == nil {
+		return mock
+	}
+	return config(conf).getBool(mockConfigKey, false)
+}
+
+func mockFixtures(conf config) string {
+	if path := os.Getenv("AWLESS_MOCK_FIXTURES"); path != "" {
+		return path
+	}
+	path, _ := conf[mockFixturesConfigKey].(string)
+	return path
+}
+
+func initMockServices(awsconf config, log *logger.Logger) error {
+	fixtures, err := loadMockFixtures(mockFixtures(awsconf), awsconf.region())
+	if err != nil {
+		return err
+	}
+
+	services := make(map[string]cloud.Service)
+	for _, name := range []string{"infra", "access", "storage", "messaging", "dns", "lambda", "monitoring", "cdn", "cloudformation"} {
+		services[name] = &mockService{name: name, fixtures: fixtures, config: awsconf, log: log}
+		cloud.ServiceRegistry[name] = services[name]
+	}
+
+	InfraService = services["infra"]
+	AccessService = services["access"]
+	StorageService = services["storage"]
+	MessagingService = services["messaging"]
+	DnsService = services["dns"]
+	LambdaService = services["lambda"]
+	MonitoringService = services["monitoring"]
+	CdnService = services["cdn"]
+	CloudformationService = services["cloudformation"]
+	ParamStore = nil
+	KMS = nil
+	CostExplorer = nil
+	CloudTrail = nil
+	CloudWatchLogs = nil
+	Organizations = nil
+	S3Control = nil
+
+	log.Verbosef("mock mode: serving canned resources instead of calling AWS")
+	return nil
+}
+
+// loadMockFixtures loads the resources from a graph file (.triples) or a directory of graph
+// files (ex: a copy of ~/.awless/aws/rdf synced from a real account). Without path, a built-in
+// representative infrastructure in the region is returned
+func loadMockFixtures(path, region string) (*graph.Graph, error) {
+	if path == "" {
+		return defaultMockFixtures(region)
+	}
+
+	files := []string{path}
+	if info, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("mock fixtures: %s", err)
+	} else if info.IsDir() {
+		files, _ = filepath.Glob(filepath.Join(path, "*.triples"))
+		if len(files) == 0 {
+			return nil, fmt.Errorf("mock fixtures: no .triples file in %s", path)
+		}
+	}
+
+	var readers []io.Reader
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			return nil, fmt.Errorf("mock fixtures: %s", err)
+		}
+		defer f.Close()
+		readers = append(readers, f)
+	}
+
+	g := graph.NewGraph()
+	if err := g.UnmarshalMultiple(readers...); err != nil {
+		return nil, fmt.Errorf("mock fixtures: %s", err)
+	}
+	return g, nil
+}
+
+func defaultMockFixtures(region string) (*graph.Graph, error) {
+	launched := time.Now().UTC().Add(-72 * time.Hour).Truncate(time.Second)
+	zone := region + "a"
+
+	res := func(typ, id string, props map[string]interface{}) *graph.Resource {
+		r := graph.InitResource(typ, id)
+		r.Properties[p.ID] = id
+		for k, v := range props {
+			r.Properties[k] = v
+		}
+		return r
+	}
+
+	reg := graph.InitResource(cloud.Region, region)
+	vpc := res(cloud.Vpc, "vpc-0a1b2c3d", map[string]interface{}{p.Name: "demo-vpc", p.CIDR: "10.0.0.0/16", p.Default: false, p.State: "available"})
+	public := res(cloud.Subnet, "subnet-1a2b3c4d", map[string]interface{}{p.Name: "demo-public", p.CIDR: "10.0.1.0/24", p.AvailabilityZone: zone, p.Public: true, p.State: "available", p.Vpc: "vpc-0a1b2c3d", p.FreeIPs: 250})
+	private := res(cloud.Subnet, "subnet-5e6f7a8b", map[string]interface{}{p.Name: "demo-private", p.CIDR: "10.0.2.0/24", p.AvailabilityZone: zone, p.Public: false, p.State: "available", p.Vpc: "vpc-0a1b2c3d", p.FreeIPs: 250})
+	sg := res(cloud.SecurityGroup, "sg-0f1e2d3c", map[string]interface{}{p.Name: "demo-web", p.Description: "HTTP and SSH access", p.Vpc: "vpc-0a1b2c3d"})
+	keypair := res(cloud.Keypair, "demo-keypair", map[string]interface{}{p.Name: "demo-keypair"})
+	web := res(cloud.Instance, "i-0123456789abcdef0", map[string]interface{}{
+		p.Name: "demo-web", p.State: "running", p.Type: "t2.micro", p.Image: "ami-0a1b2c3d", p.KeyPair: "demo-keypair",
+		p.PublicIP: "203.0.113.10", p.PrivateIP: "10.0.1.10", p.AvailabilityZone: zone, p.Launched: launched,
+		p.Subnet: "subnet-1a2b3c4d", p.Vpc: "vpc-0a1b2c3d", p.SecurityGroups: []string{"sg-0f1e2d3c"}, p.Tags: []string{"Env=demo", "Name=demo-web"},
+	})
+	db := res(cloud.Instance, "i-0fedcba9876543210", map[string]interface{}{
+		p.Name: "demo-db", p.State: "stopped", p.Type: "t2.small", p.Image: "ami-0a1b2c3d", p.KeyPair: "demo-keypair",
+		p.PrivateIP: "10.0.2.20", p.AvailabilityZone: zone, p.Launched: launched,
+		p.Subnet: "subnet-5e6f7a8b", p.Vpc: "vpc-0a1b2c3d", p.Tags: []string{"Env=demo", "Name=demo-db"},
+	})
+	volume := res(cloud.Volume, "vol-0a1b2c3d4e5f", map[string]interface{}{p.Size: 8, p.Type: "gp2", p.State: "in-use", p.AvailabilityZone: zone, p.Created: launched})
+	user := res(cloud.User, "AIDAMOCKUSER000000001", map[string]interface{}{p.Name: "demo-user", p.Arn: "arn:aws:iam::123456789012:user/demo-user", p.Created: launched})
+	role := res(cloud.Role, "AROAMOCKROLE000000001", map[string]interface{}{p.Name: "demo-role", p.Arn: "arn:aws:iam::123456789012:role/demo-role", p.Created: launched})
+	bucket := res(cloud.Bucket, "demo-bucket-123456789012", map[string]interface{}{p.Name: "demo-bucket-123456789012", p.Created: launched})
+	topic := res(cloud.Topic, "arn:aws:sns:"+region+":123456789012:demo-topic", map[string]interface{}{p.Name: "demo-topic", p.Arn: "arn:aws:sns:" + region + ":123456789012:demo-topic"})
+	queue := res(cloud.Queue, "https://sqs."+region+".amazonaws.com/123456789012/demo-queue", map[string]interface{}{p.Name: "demo-queue", p.Created: launched})
+	zoneRes := res(cloud.Zone, "/hostedzone/Z0MOCK0000001", map[string]interface{}{p.Name: "demo.example.com.", p.Private: false})
+	function := res(cloud.Function, "demo-function", map[string]interface{}{p.Name: "demo-function", p.Runtime: "python3.6", p.Handler: "index.handler", p.Memory: 128})
+
+	g := graph.NewGraph()
+	if err := g.AddResource(reg, vpc, public, private, sg, keypair, web, db, volume, user, role, bucket, topic, queue, zoneRes, function); err != nil {
+		return g, err
+	}
+	for _, r := range []*graph.Resource{vpc, keypair, volume, user, role, bucket, topic, queue, zoneRes, function} {
+		g.AddParentRelation(reg, r)
+	}
+	g.AddParentRelation(vpc, public)
+	g.AddParentRelation(vpc, private)
+	g.AddParentRelation(vpc, sg)
+	g.AddParentRelation(public, web)
+	g.AddParentRelation(private, db)
+	g.AddAppliesOnRelation(sg, web)
+	g.AddAppliesOnRelation(keypair, web)
+	g.AddAppliesOnRelation(keypair, db)
+	g.AddAppliesOnRelation(volume, web)
+
+	return g, nil
+}
+
+// mockService serves the resources of its types from the fixtures
+type mockService struct {
+	name     string
+	fixtures *graph.Graph
+	config   config
+	log      *logger.Logger
+}
+
+func (s *mockService) Name() string {
+	return s.name
+}
+
+func (s *mockService) Drivers() []driver.Driver {
+	return []driver.Driver{&mockDriver{service: s.name, logger: s.log}}
+}
+
+func (s *mockService) ResourceTypes() (types []string) {
+	for t, service := range ServicePerResourceType {
+		if service == s.name {
+			types = append(types, t)
+		}
+	}
+	sort.Strings(types)
+	return
+}
+
+func (s *mockService) FetchResources() (*graph.Graph, error) {
+	return s.fixtures.Subgraph(append(s.ResourceTypes(), cloud.Region)...)
+}
+
+func (s *mockService) FetchByType(t string) (*graph.Graph, error) {
+	return s.fixtures.Subgraph(t)
+}
+
+func (s *mockService) IsSyncDisabled() bool {
+	return !s.config.getBool(fmt.Sprintf("aws.%s.sync", s.name), true)
+}
+
+// mockDriver simulates the success of the template actions of its service
+type mockDriver struct {
+	service string
+	dryRun  bool
+	logger  *logger.Logger
+}
+
+func (d *mockDriver) SetDryRun(dry bool)         { d.dryRun = dry }
+func (d *mockDriver) SetLogger(l *logger.Logger) { d.logger = l }
+
+func (d *mockDriver) Lookup(lookups ...string) (driver.DriverFn, error) {
+	if len(lookups) < 2 {
+		return nil, driver.ErrDriverFnNotFound
+	}
+	action, entity := lookups[0], lookups[1]
+	def, ok := awsdriver.AWSTemplatesDefinitions[action+entity]
+	if !ok || ServicePerAPI[def.Api] != d.service {
+		return nil, driver.ErrDriverFnNotFound
+	}
+
+	return func(params map[string]interface{}) (interface{}, error) {
+		id := mockResult(action, entity, params)
+		if d.dryRun {
+			d.logger.Verbosef("dry run: %s %s ok (mock)", action, entity)
+		} else {
+			d.logger.Infof("%s %s '%v' done (mock)", action, entity, id)
+		}
+		return id, nil
+	}, nil
+}
+
+func mockResult(action, entity string, params map[string]interface{}) interface{} {
+	switch action {
+	case "create", "copy", "import":
+		b := make([]byte, 4)
+		rand.Read(b)
+		return fmt.Sprintf("mock-%s-%x", entity, b)
+	}
+	for _, key := range []string{"id", "name"} {
+		if v, ok := params[key]; ok {
+			return v
+		}
+	}
+	return fmt.Sprintf("mock-%s", entity)
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/logger"
+)
+
+func TestMockService(t *testing.T) {
+	fixtures, err := defaultMockFixtures("eu-west-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	infra := &mockService{name: "infra", fixtures: fixtures, config: config{}, log: logger.DiscardLogger}
+
+	g, err := infra.FetchResources()
+	if err != nil {
+		t.Fatal(err)
+	}
+	instances, err := g.GetAllResources(cloud.Instance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(instances), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if users, _ := g.GetAllResources(cloud.User); len(users) != 0 {
+		t.Fatalf("got %d users in infra graph, want none", len(users))
+	}
+
+	subnets, err := infra.FetchByType(cloud.Subnet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res, _ := subnets.GetAllResources(cloud.Subnet); len(res) != 2 {
+		t.Fatalf("got %d subnets, want 2", len(res))
+	}
+
+	t.Run("drivers", func(t *testing.T) {
+		d := infra.Drivers()[0]
+		d.SetLogger(logger.DiscardLogger)
+
+		create, err := d.Lookup("create", "instance")
+		if err != nil {
+			t.Fatal(err)
+		}
+		id, err := create(map[string]interface{}{"name": "demo"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(id.(string), "mock-instance-") {
+			t.Fatalf("got %v, want generated mock id", id)
+		}
+
+		stop, err := d.Lookup("stop", "instance")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id, _ := stop(map[string]interface{}{"id": "i-0123456789abcdef0"}); id != "i-0123456789abcdef0" {
+			t.Fatalf("got %v, want i-0123456789abcdef0", id)
+		}
+
+		if _, err := d.Lookup("create", "user"); err == nil {
+			t.Fatal("expected infra mock driver not to handle access actions")
+		}
+	})
+}
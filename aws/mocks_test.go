@@ -5,14 +5,18 @@ import (
 	"strconv"
 
 	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/cloudfront"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ecr"
 	"github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/lambda"
 	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/wallix/awless/aws/driver"
 )
 
 func (m *mockEc2) DescribeInstancesPages(input *ec2.DescribeInstancesInput, fn func(p *ec2.DescribeInstancesOutput, lastPage bool) (shouldContinue bool)) error {
@@ -29,6 +33,21 @@ func (m *mockElbv2) DescribeListenersPages(input *elbv2.DescribeListenersInput,
 	return nil
 }
 
+func (m *mockElbv2) DescribeTags(input *elbv2.DescribeTagsInput) (*elbv2.DescribeTagsOutput, error) {
+	if len(input.ResourceArns) > elbv2TagsBatchSize {
+		return nil, fmt.Errorf("too many resources arns: %d", len(input.ResourceArns))
+	}
+	var descs []*elbv2.TagDescription
+	for _, arn := range input.ResourceArns {
+		for _, d := range m.tagdescriptions {
+			if awssdk.StringValue(d.ResourceArn) == awssdk.StringValue(arn) {
+				descs = append(descs, d)
+			}
+		}
+	}
+	return &elbv2.DescribeTagsOutput{TagDescriptions: descs}, nil
+}
+
 func (m *mockElbv2) DescribeTargetHealth(input *elbv2.DescribeTargetHealthInput) (*elbv2.DescribeTargetHealthOutput, error) {
 	return &elbv2.DescribeTargetHealthOutput{TargetHealthDescriptions: m.targethealthdescriptions[awssdk.StringValue(input.TargetGroupArn)]}, nil
 }
@@ -62,6 +81,26 @@ func (m *mockS3) GetBucketAcl(input *s3.GetBucketAclInput) (*s3.GetBucketAclOutp
 	return &s3.GetBucketAclOutput{Grants: m.grants[awssdk.StringValue(input.Bucket)]}, nil
 }
 
+func (m *mockS3) GetBucketReplication(input *s3.GetBucketReplicationInput) (*s3.GetBucketReplicationOutput, error) {
+	conf, ok := m.replications[awssdk.StringValue(input.Bucket)]
+	if !ok {
+		return nil, awserr.New("ReplicationConfigurationNotFoundError", "The replication configuration was not found", nil)
+	}
+	return &s3.GetBucketReplicationOutput{ReplicationConfiguration: conf}, nil
+}
+
+func (m *mockS3) GetBucketPolicy(input *s3.GetBucketPolicyInput) (*s3.GetBucketPolicyOutput, error) {
+	policy, ok := m.policies[awssdk.StringValue(input.Bucket)]
+	if !ok {
+		return nil, awserr.New("NoSuchBucketPolicy", "The bucket policy does not exist", nil)
+	}
+	return &s3.GetBucketPolicyOutput{Policy: awssdk.String(policy)}, nil
+}
+
+func (m *mockEcr) GetRepositoryPolicy(input *ecr.GetRepositoryPolicyInput) (*ecr.GetRepositoryPolicyOutput, error) {
+	return nil, awserr.New(ecr.ErrCodeRepositoryPolicyNotFoundException, "Repository policy does not exist", nil)
+}
+
 func (m *mockS3) ListBuckets(input *s3.ListBucketsInput) (*s3.ListBucketsOutput, error) {
 	var buckets []*s3.Bucket
 	for _, b := range m.buckets {
@@ -176,3 +215,202 @@ func (m *mockEcs) ListContainerInstancesPages(input *ecs.ListContainerInstancesI
 func (m *mockEcs) DescribeContainerInstances(input *ecs.DescribeContainerInstancesInput) (*ecs.DescribeContainerInstancesOutput, error) {
 	return &ecs.DescribeContainerInstancesOutput{ContainerInstances: m.containerinstances[awssdk.StringValue(input.Cluster)]}, nil
 }
+
+// mockEc2PrefixLists adds to the ec2 mock the managed prefix lists calls, not in the vendored SDK
+type mockEcrRegistry struct {
+	*mockEcr
+	scanOnPush map[string]bool
+	lifecycles map[string]string
+	images     map[string][]*awsdriver.ContainerImage
+}
+
+func (m *mockEcrRegistry) DescribeRepositoryDetails(input *awsdriver.DescribeRepositoryDetailsInput) (*awsdriver.DescribeRepositoryDetailsOutput, error) {
+	out := &awsdriver.DescribeRepositoryDetailsOutput{}
+	for _, name := range input.RepositoryNames {
+		if scan, ok := m.scanOnPush[awssdk.StringValue(name)]; ok {
+			out.Repositories = append(out.Repositories, &awsdriver.RepositoryDetail{RepositoryName: name, ImageScanningConfiguration: &awsdriver.ImageScanningConfiguration{ScanOnPush: awssdk.Bool(scan)}})
+		}
+	}
+	return out, nil
+}
+
+func (m *mockEcrRegistry) DescribeContainerImages(input *awsdriver.DescribeContainerImagesInput) (*awsdriver.DescribeContainerImagesOutput, error) {
+	return &awsdriver.DescribeContainerImagesOutput{ImageDetails: m.images[awssdk.StringValue(input.RepositoryName)]}, nil
+}
+
+func (m *mockEcrRegistry) GetLifecyclePolicy(input *awsdriver.GetLifecyclePolicyInput) (*awsdriver.GetLifecyclePolicyOutput, error) {
+	policy, ok := m.lifecycles[awssdk.StringValue(input.RepositoryName)]
+	if !ok {
+		return nil, awserr.New(awsdriver.ErrCodeLifecyclePolicyNotFoundException, "Lifecycle policy does not exist", nil)
+	}
+	return &awsdriver.GetLifecyclePolicyOutput{RepositoryName: input.RepositoryName, LifecyclePolicyText: awssdk.String(policy)}, nil
+}
+
+func (m *mockEcrRegistry) PutLifecyclePolicy(input *awsdriver.PutLifecyclePolicyInput) (*awsdriver.PutLifecyclePolicyOutput, error) {
+	return nil, fmt.Errorf("unexpected call")
+}
+
+func (m *mockEcrRegistry) PutImageScanningConfiguration(input *awsdriver.PutImageScanningConfigurationInput) (*awsdriver.PutImageScanningConfigurationOutput, error) {
+	return nil, fmt.Errorf("unexpected call")
+}
+
+type mockEc2PrefixLists struct {
+	*mockEc2
+	prefixlists []*awsdriver.ManagedPrefixList
+	entries     map[string][]*awsdriver.PrefixListEntry
+}
+
+func (m *mockEc2PrefixLists) DescribeManagedPrefixLists(input *awsdriver.DescribeManagedPrefixListsInput) (*awsdriver.DescribeManagedPrefixListsOutput, error) {
+	return &awsdriver.DescribeManagedPrefixListsOutput{PrefixLists: m.prefixlists}, nil
+}
+
+func (m *mockEc2PrefixLists) GetManagedPrefixListEntries(input *awsdriver.GetManagedPrefixListEntriesInput) (*awsdriver.GetManagedPrefixListEntriesOutput, error) {
+	return &awsdriver.GetManagedPrefixListEntriesOutput{Entries: m.entries[awssdk.StringValue(input.PrefixListId)]}, nil
+}
+
+func (m *mockEc2PrefixLists) CreateManagedPrefixList(input *awsdriver.CreateManagedPrefixListInput) (*awsdriver.CreateManagedPrefixListOutput, error) {
+	return nil, fmt.Errorf("unexpected call")
+}
+
+func (m *mockEc2PrefixLists) ModifyManagedPrefixList(input *awsdriver.ModifyManagedPrefixListInput) (*awsdriver.ModifyManagedPrefixListOutput, error) {
+	return nil, fmt.Errorf("unexpected call")
+}
+
+func (m *mockEc2PrefixLists) DeleteManagedPrefixList(input *awsdriver.DeleteManagedPrefixListInput) (*awsdriver.DeleteManagedPrefixListOutput, error) {
+	return nil, fmt.Errorf("unexpected call")
+}
+
+// mockEc2Fleets adds to the ec2 mock the spot fleet calls and the EC2 Fleet calls, not in the vendored SDK
+type mockEc2Fleets struct {
+	*mockEc2PrefixLists
+	spotfleets []*ec2.SpotFleetRequestConfig
+	fleets     []*awsdriver.FleetData
+	instances  map[string][]string
+}
+
+func (m *mockEc2Fleets) activeInstances(id *string) (instances []*ec2.ActiveInstance) {
+	for _, inst := range m.instances[awssdk.StringValue(id)] {
+		instances = append(instances, &ec2.ActiveInstance{InstanceId: awssdk.String(inst)})
+	}
+	return
+}
+
+func (m *mockEc2Fleets) DescribeSpotFleetRequests(input *ec2.DescribeSpotFleetRequestsInput) (*ec2.DescribeSpotFleetRequestsOutput, error) {
+	return &ec2.DescribeSpotFleetRequestsOutput{SpotFleetRequestConfigs: m.spotfleets}, nil
+}
+
+func (m *mockEc2Fleets) DescribeSpotFleetInstances(input *ec2.DescribeSpotFleetInstancesInput) (*ec2.DescribeSpotFleetInstancesOutput, error) {
+	return &ec2.DescribeSpotFleetInstancesOutput{ActiveInstances: m.activeInstances(input.SpotFleetRequestId)}, nil
+}
+
+func (m *mockEc2Fleets) DescribeFleets(input *awsdriver.DescribeFleetsInput) (*awsdriver.DescribeFleetsOutput, error) {
+	return &awsdriver.DescribeFleetsOutput{Fleets: m.fleets}, nil
+}
+
+func (m *mockEc2Fleets) DescribeFleetInstances(input *awsdriver.DescribeFleetInstancesInput) (*awsdriver.DescribeFleetInstancesOutput, error) {
+	return &awsdriver.DescribeFleetInstancesOutput{ActiveInstances: m.activeInstances(input.FleetId)}, nil
+}
+
+func (m *mockEc2Fleets) CreateFleet(input *awsdriver.CreateFleetInput) (*awsdriver.CreateFleetOutput, error) {
+	return nil, fmt.Errorf("unexpected call")
+}
+
+func (m *mockEc2Fleets) ModifyFleet(input *awsdriver.ModifyFleetInput) (*awsdriver.ModifyFleetOutput, error) {
+	return nil, fmt.Errorf("unexpected call")
+}
+
+func (m *mockEc2Fleets) DeleteFleets(input *awsdriver.DeleteFleetsInput) (*awsdriver.DeleteFleetsOutput, error) {
+	return nil, fmt.Errorf("unexpected call")
+}
+
+// mockEc2GuardDuty adds to the ec2 mock the GuardDuty calls, not in the vendored SDK
+type mockEc2GuardDuty struct {
+	*mockEc2
+	detectors []string
+	findings  map[string][]*GuardDutyFinding
+}
+
+func (m *mockEc2GuardDuty) ListDetectors(input *listDetectorsInput) (*listDetectorsOutput, error) {
+	return &listDetectorsOutput{DetectorIds: awssdk.StringSlice(m.detectors)}, nil
+}
+
+func (m *mockEc2GuardDuty) ListFindings(input *listFindingsInput) (*listFindingsOutput, error) {
+	out := &listFindingsOutput{}
+	for _, f := range m.findings[awssdk.StringValue(input.DetectorId)] {
+		out.FindingIds = append(out.FindingIds, f.Id)
+	}
+	return out, nil
+}
+
+func (m *mockEc2GuardDuty) GetFindings(input *getFindingsInput) (*getFindingsOutput, error) {
+	wanted := make(map[string]bool)
+	for _, id := range input.FindingIds {
+		wanted[awssdk.StringValue(id)] = true
+	}
+	out := &getFindingsOutput{}
+	for _, f := range m.findings[awssdk.StringValue(input.DetectorId)] {
+		if wanted[awssdk.StringValue(f.Id)] {
+			out.Findings = append(out.Findings, f)
+		}
+	}
+	return out, nil
+}
+
+// mockLambdaStreams adds to the lambda mock the event source mappings and the Kinesis and Firehose calls, not in the vendored SDK
+type mockLambdaStreams struct {
+	*mockLambda
+	streams         []*awsdriver.StreamDescriptionSummary
+	deliverystreams []*awsdriver.DeliveryStreamDescription
+	mappings        []*lambda.EventSourceMappingConfiguration
+}
+
+func (m *mockLambdaStreams) ListEventSourceMappingsPages(input *lambda.ListEventSourceMappingsInput, fn func(p *lambda.ListEventSourceMappingsOutput, lastPage bool) (shouldContinue bool)) error {
+	fn(&lambda.ListEventSourceMappingsOutput{EventSourceMappings: m.mappings}, true)
+	return nil
+}
+
+func (m *mockLambdaStreams) ListStreams(input *awsdriver.ListStreamsInput) (*awsdriver.ListStreamsOutput, error) {
+	out := &awsdriver.ListStreamsOutput{HasMoreStreams: awssdk.Bool(false)}
+	for _, s := range m.streams {
+		out.StreamNames = append(out.StreamNames, s.StreamName)
+	}
+	return out, nil
+}
+
+func (m *mockLambdaStreams) DescribeStreamSummary(input *awsdriver.DescribeStreamSummaryInput) (*awsdriver.DescribeStreamSummaryOutput, error) {
+	for _, s := range m.streams {
+		if awssdk.StringValue(s.StreamName) == awssdk.StringValue(input.StreamName) {
+			return &awsdriver.DescribeStreamSummaryOutput{StreamDescriptionSummary: s}, nil
+		}
+	}
+	return nil, fmt.Errorf("stream %s not found", awssdk.StringValue(input.StreamName))
+}
+
+func (m *mockLambdaStreams) ListDeliveryStreams(input *awsdriver.ListDeliveryStreamsInput) (*awsdriver.ListDeliveryStreamsOutput, error) {
+	out := &awsdriver.ListDeliveryStreamsOutput{HasMoreDeliveryStreams: awssdk.Bool(false)}
+	for _, s := range m.deliverystreams {
+		out.DeliveryStreamNames = append(out.DeliveryStreamNames, s.DeliveryStreamName)
+	}
+	return out, nil
+}
+
+func (m *mockLambdaStreams) DescribeDeliveryStream(input *awsdriver.DescribeDeliveryStreamInput) (*awsdriver.DescribeDeliveryStreamOutput, error) {
+	for _, s := range m.deliverystreams {
+		if awssdk.StringValue(s.DeliveryStreamName) == awssdk.StringValue(input.DeliveryStreamName) {
+			return &awsdriver.DescribeDeliveryStreamOutput{DeliveryStreamDescription: s}, nil
+		}
+	}
+	return nil, fmt.Errorf("delivery stream %s not found", awssdk.StringValue(input.DeliveryStreamName))
+}
+
+func (m *mockLambdaStreams) CreateStream(input *awsdriver.CreateStreamInput) (*awsdriver.CreateStreamOutput, error) {
+	return nil, fmt.Errorf("unexpected call")
+}
+
+func (m *mockLambdaStreams) DeleteStream(input *awsdriver.DeleteStreamInput) (*awsdriver.DeleteStreamOutput, error) {
+	return nil, fmt.Errorf("unexpected call")
+}
+
+func (m *mockLambdaStreams) UpdateShardCount(input *awsdriver.UpdateShardCountInput) (*awsdriver.UpdateShardCountOutput, error) {
+	return nil, fmt.Errorf("unexpected call")
+}
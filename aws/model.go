@@ -63,6 +63,7 @@ var awsResourcesDef = map[string]map[string]*propertyTransform{
 		properties.CIDR:             {name: "CidrBlock", transform: extractValueFn},
 		properties.AvailabilityZone: {name: "AvailabilityZone", transform: extractValueFn},
 		properties.Default:          {name: "DefaultForAz", transform: extractValueFn},
+		properties.FreeIPs:          {name: "AvailableIpAddressCount", transform: extractValueFn},
 		properties.Tags:             {name: "Tags", transform: extractTagsFn},
 	},
 	cloud.SecurityGroup: {
@@ -97,6 +98,7 @@ var awsResourcesDef = map[string]map[string]*propertyTransform{
 		properties.State:       {name: "State", transform: extractValueFn},
 		properties.Size:        {name: "VolumeSize", transform: extractValueFn},
 		properties.Volume:      {name: "VolumeId", transform: extractValueFn},
+		properties.Public:      {fetch: fetchSnapshotPublicFn},
 		properties.Tags:        {name: "Tags", transform: extractTagsFn},
 	},
 	cloud.Image: {
@@ -110,6 +112,7 @@ var awsResourcesDef = map[string]map[string]*propertyTransform{
 		properties.State:          {name: "State", transform: extractValueFn},
 		properties.Created:        {name: "CreationDate", transform: extractTimeWithZSuffixFn},
 		properties.Virtualization: {name: "VirtualizationType", transform: extractValueFn},
+		properties.Snapshots:      {name: "BlockDeviceMappings", transform: extractImageSnapshotsFn},
 		properties.Tags:           {name: "Tags", transform: extractTagsFn},
 	},
 	cloud.ImportImageTask: {
@@ -126,6 +129,79 @@ var awsResourcesDef = map[string]map[string]*propertyTransform{
 		properties.Vpcs: {name: "Attachments", transform: extractStringSliceValues("VpcId")},
 		properties.Tags: {name: "Tags", transform: extractTagsFn},
 	},
+	cloud.DhcpOptions: {
+		properties.Name:        {name: "Tags", transform: extractTagFn("Name")},
+		properties.DomainName:  {name: "DhcpConfigurations", transform: extractDhcpDomainNameFn},
+		properties.NameServers: {name: "DhcpConfigurations", transform: extractDhcpConfigurationFn("domain-name-servers")},
+		properties.NTPServers:  {name: "DhcpConfigurations", transform: extractDhcpConfigurationFn("ntp-servers")},
+		properties.Owner:       {name: "OwnerId", transform: extractValueFn},
+		properties.Tags:        {name: "Tags", transform: extractTagsFn},
+	},
+	cloud.NetworkAcl: {
+		properties.Name:          {name: "Tags", transform: extractTagFn("Name")},
+		properties.Vpc:           {name: "VpcId", transform: extractValueFn},
+		properties.Default:       {name: "IsDefault", transform: extractValueFn},
+		properties.InboundRules:  {name: "Entries", transform: extractNetworkAclRulesFn(false)},
+		properties.OutboundRules: {name: "Entries", transform: extractNetworkAclRulesFn(true)},
+		properties.Subnets:       {name: "Associations", transform: extractStringSliceValues("SubnetId")},
+		properties.Tags:          {name: "Tags", transform: extractTagsFn},
+	},
+	cloud.PlacementGroup: {
+		properties.Name:     {name: "GroupName", transform: extractValueFn},
+		properties.State:    {name: "State", transform: extractValueFn},
+		properties.Strategy: {name: "Strategy", transform: extractValueFn},
+	},
+	cloud.PrefixList: {
+		properties.Name:         {name: "PrefixListName", transform: extractValueFn},
+		properties.Arn:          {name: "PrefixListArn", transform: extractValueFn},
+		properties.Owner:        {name: "OwnerId", transform: extractValueFn},
+		properties.IPType:       {name: "AddressFamily", transform: extractValueFn},
+		properties.State:        {name: "State", transform: extractValueFn},
+		properties.StateMessage: {name: "StateMessage", transform: extractValueFn},
+		properties.MaxEntries:   {name: "MaxEntries", transform: extractValueFn},
+		properties.Entries:      {name: "Entries", transform: extractStringSliceValues("Cidr")},
+		properties.Tags:         {name: "Tags", transform: extractTagsFn},
+	},
+	cloud.Fleet: {
+		properties.Kind:              {name: "Kind", transform: extractValueFn},
+		properties.Type:              {name: "Type", transform: extractValueFn},
+		properties.State:             {name: "State", transform: extractValueFn},
+		properties.ActivityStatus:    {name: "ActivityStatus", transform: extractValueFn},
+		properties.Strategy:          {name: "AllocationStrategy", transform: extractValueFn},
+		properties.TargetCapacity:    {name: "TargetCapacity", transform: extractValueFn},
+		properties.OnDemandCapacity:  {name: "OnDemandTargetCapacity", transform: extractValueFn},
+		properties.SpotCapacity:      {name: "SpotTargetCapacity", transform: extractValueFn},
+		properties.FulfilledCapacity: {name: "FulfilledCapacity", transform: extractValueFn},
+		properties.SpotPrice:         {name: "SpotPrice", transform: extractValueFn},
+		properties.Role:              {name: "IamFleetRole", transform: extractValueFn},
+		properties.Created:           {name: "CreateTime", transform: extractTimeFn},
+		properties.Instances:         {name: "Instances", transform: extractStringPointerSliceValues},
+	},
+	cloud.Finding: {
+		properties.Name:             {name: "Title", transform: extractValueFn},
+		properties.Type:             {name: "Type", transform: extractValueFn},
+		properties.Description:      {name: "Description", transform: extractValueFn},
+		properties.Severity:         {name: "Severity", transform: extractFindingSeverityFn},
+		properties.SeverityScore:    {name: "Severity", transform: extractFindingSeverityScoreFn},
+		properties.Occurrences:      {name: "Service", transform: extractFieldFn("Count")},
+		properties.AffectedResource: {name: "Resource", transform: extractFindingAffectedResourceFn},
+		properties.Account:          {name: "AccountId", transform: extractValueFn},
+		properties.Region:           {name: "Region", transform: extractValueFn},
+		properties.Arn:              {name: "Arn", transform: extractValueFn},
+		properties.Created:          {name: "CreatedAt", transform: extractTimeWithZSuffixFn},
+		properties.Modified:         {name: "UpdatedAt", transform: extractTimeWithZSuffixFn},
+	},
+	cloud.DedicatedHost: {
+		properties.AvailabilityZone:  {name: "AvailabilityZone", transform: extractValueFn},
+		properties.State:             {name: "State", transform: extractValueFn},
+		properties.Type:              {name: "HostProperties", transform: extractFieldFn("InstanceType")},
+		properties.Cores:             {name: "HostProperties", transform: extractFieldFn("Cores")},
+		properties.Sockets:           {name: "HostProperties", transform: extractFieldFn("Sockets")},
+		properties.TotalCapacity:     {name: "AvailableCapacity", transform: extractHostTotalCapacityFn},
+		properties.AvailableCapacity: {name: "AvailableCapacity", transform: extractHostAvailableCapacityFn},
+		properties.Utilization:       {name: "AvailableCapacity", transform: extractHostUtilizationFn},
+		properties.Instances:         {name: "Instances", transform: extractStringSliceValues("InstanceId")},
+	},
 	cloud.NatGateway: {
 		properties.Created: {name: "CreateTime", transform: extractValueFn},
 		properties.Subnet:  {name: "SubnetId", transform: extractValueFn},
@@ -279,11 +355,25 @@ var awsResourcesDef = map[string]map[string]*propertyTransform{
 	},
 	//Containers
 	cloud.Repository: {
-		properties.Name:    {name: "RepositoryName", transform: extractValueFn},
-		properties.Arn:     {name: "RepositoryArn", transform: extractValueFn},
-		properties.URI:     {name: "RepositoryUri", transform: extractValueFn},
-		properties.Created: {name: "CreatedAt", transform: extractValueFn},
-		properties.Account: {name: "RegistryId", transform: extractValueFn},
+		properties.Name:            {name: "RepositoryName", transform: extractValueFn},
+		properties.Arn:             {name: "RepositoryArn", transform: extractValueFn},
+		properties.URI:             {name: "RepositoryUri", transform: extractValueFn},
+		properties.Created:         {name: "CreatedAt", transform: extractValueFn},
+		properties.Account:         {name: "RegistryId", transform: extractValueFn},
+		properties.ResourcePolicy:  {fetch: fetchRepositoryPolicyFn},
+		properties.ScanOnPush:      {fetch: fetchRepositoryScanOnPushFn},
+		properties.LifecyclePolicy: {fetch: fetchRepositoryLifecyclePolicyFn},
+	},
+	cloud.ContainerImage: {
+		properties.Repository:      {name: "RepositoryName", transform: extractValueFn},
+		properties.Hash:            {name: "ImageDigest", transform: extractValueFn},
+		properties.ImageTags:       {name: "ImageTags", transform: extractStringPointerSliceValues},
+		properties.Created:         {name: "ImagePushedAt", transform: extractTimeFn},
+		properties.Size:            {name: "ImageSizeInBytes", transform: extractValueFn},
+		properties.Account:         {name: "RegistryId", transform: extractValueFn},
+		properties.ScanStatus:      {name: "ImageScanStatus", transform: extractFieldFn("Status")},
+		properties.ScanFindings:    {name: "ImageScanFindingsSummary", transform: extractScanFindingsFn},
+		properties.Vulnerabilities: {name: "ImageScanFindingsSummary", transform: extractVulnerabilitiesFn},
 	},
 	cloud.ContainerCluster: {
 		properties.Name:                              {name: "ClusterName", transform: extractValueFn},
@@ -331,20 +421,24 @@ var awsResourcesDef = map[string]map[string]*propertyTransform{
 		properties.Created:          {name: "CreateDate", transform: extractTimeFn},
 		properties.PasswordLastUsed: {name: "PasswordLastUsed", transform: extractTimeFn},
 		properties.InlinePolicies:   {name: "UserPolicyList", transform: extractStringSliceValues("PolicyName")},
+		properties.ManagedPolicies:  {name: "AttachedManagedPolicies", transform: extractStringSliceValues("PolicyName")},
+		properties.Groups:           {name: "GroupList", transform: extractStringPointerSliceValues},
 	},
 	cloud.Role: {
-		properties.Name:           {name: "RoleName", transform: extractValueFn},
-		properties.Arn:            {name: "Arn", transform: extractValueFn},
-		properties.Created:        {name: "CreateDate", transform: extractTimeFn},
-		properties.Path:           {name: "Path", transform: extractValueFn},
-		properties.InlinePolicies: {name: "RolePolicyList", transform: extractStringSliceValues("PolicyName")},
+		properties.Name:            {name: "RoleName", transform: extractValueFn},
+		properties.Arn:             {name: "Arn", transform: extractValueFn},
+		properties.Created:         {name: "CreateDate", transform: extractTimeFn},
+		properties.Path:            {name: "Path", transform: extractValueFn},
+		properties.InlinePolicies:  {name: "RolePolicyList", transform: extractStringSliceValues("PolicyName")},
+		properties.ManagedPolicies: {name: "AttachedManagedPolicies", transform: extractStringSliceValues("PolicyName")},
 	},
 	cloud.Group: {
-		properties.Name:           {name: "GroupName", transform: extractValueFn},
-		properties.Arn:            {name: "Arn", transform: extractValueFn},
-		properties.Created:        {name: "CreateDate", transform: extractTimeFn},
-		properties.Path:           {name: "Path", transform: extractValueFn},
-		properties.InlinePolicies: {name: "GroupPolicyList", transform: extractStringSliceValues("PolicyName")},
+		properties.Name:            {name: "GroupName", transform: extractValueFn},
+		properties.Arn:             {name: "Arn", transform: extractValueFn},
+		properties.Created:         {name: "CreateDate", transform: extractTimeFn},
+		properties.Path:            {name: "Path", transform: extractValueFn},
+		properties.InlinePolicies:  {name: "GroupPolicyList", transform: extractStringSliceValues("PolicyName")},
+		properties.ManagedPolicies: {name: "AttachedManagedPolicies", transform: extractStringSliceValues("PolicyName")},
 	},
 	cloud.Policy: {
 		properties.Name:        {name: "PolicyName", transform: extractValueFn},
@@ -362,8 +456,11 @@ var awsResourcesDef = map[string]map[string]*propertyTransform{
 	},
 	//S3
 	cloud.Bucket: {
-		properties.Created: {name: "CreationDate", transform: extractTimeFn},
-		properties.Grants:  {fetch: fetchAndExtractGrantsFn},
+		properties.Created:          {name: "CreationDate", transform: extractTimeFn},
+		properties.Grants:           {fetch: fetchAndExtractGrantsFn},
+		properties.ReplicationRole:  {fetch: fetchReplicationRoleFn},
+		properties.ReplicationRules: {fetch: fetchReplicationRulesFn},
+		properties.ResourcePolicy:   {fetch: fetchBucketPolicyFn},
 	},
 	cloud.S3Object: {
 		properties.Key:      {name: "Key", transform: extractValueFn},
@@ -381,7 +478,8 @@ var awsResourcesDef = map[string]map[string]*propertyTransform{
 		properties.Topic:    {name: "TopicArn", transform: extractValueFn},
 	},
 	cloud.Topic: {
-		properties.Arn: {name: "TopicArn", transform: extractValueFn},
+		properties.Arn:            {name: "TopicArn", transform: extractValueFn},
+		properties.ResourcePolicy: {fetch: fetchTopicPolicyFn},
 	},
 	// DNS
 	cloud.Zone: {
@@ -391,6 +489,18 @@ var awsResourcesDef = map[string]map[string]*propertyTransform{
 		properties.CallerReference: {name: "CallerReference", transform: extractValueFn},
 		properties.RecordCount:     {name: "ResourceRecordSetCount", transform: extractValueFn},
 	},
+	cloud.HealthCheck: {
+		properties.Type:                    {name: "HealthCheckConfig", transform: extractFieldFn("Type")},
+		properties.Endpoint:                {name: "HealthCheckConfig", transform: extractHealthCheckEndpointFn},
+		properties.Host:                    {name: "HealthCheckConfig", transform: extractFieldFn("FullyQualifiedDomainName")},
+		properties.Port:                    {name: "HealthCheckConfig", transform: extractFieldFn("Port")},
+		properties.Path:                    {name: "HealthCheckConfig", transform: extractFieldFn("ResourcePath")},
+		properties.CheckInterval:           {name: "HealthCheckConfig", transform: extractFieldFn("RequestInterval")},
+		properties.UnhealthyThresholdCount: {name: "HealthCheckConfig", transform: extractFieldFn("FailureThreshold")},
+		properties.CallerReference:         {name: "CallerReference", transform: extractValueFn},
+		properties.Version:                 {name: "HealthCheckVersion", transform: extractValueFn},
+		properties.Health:                  {fetch: fetchHealthCheckStatusFn},
+	},
 	cloud.Record: {
 		properties.Name:                  {name: "Name", transform: extractValueFn},
 		properties.Failover:              {name: "Failover", transform: extractValueFn},
@@ -407,18 +517,42 @@ var awsResourcesDef = map[string]map[string]*propertyTransform{
 	},
 	// Lambda
 	cloud.Function: {
-		properties.Arn:         {name: "FunctionArn", transform: extractValueFn},
-		properties.Name:        {name: "FunctionName", transform: extractValueFn},
-		properties.Hash:        {name: "CodeSha256", transform: extractValueFn},
-		properties.Size:        {name: "CodeSize", transform: extractValueFn},
-		properties.Description: {name: "Description", transform: extractValueFn},
-		properties.Handler:     {name: "Handler", transform: extractValueFn},
-		properties.Modified:    {name: "LastModified", transform: extractTimeFn},
-		properties.Memory:      {name: "MemorySize", transform: extractValueFn},
-		properties.Role:        {name: "Role", transform: extractValueFn},
-		properties.Runtime:     {name: "Runtime", transform: extractValueFn},
-		properties.Timeout:     {name: "Timeout", transform: extractValueFn},
-		properties.Version:     {name: "Version", transform: extractValueFn},
+		properties.Arn:            {name: "FunctionArn", transform: extractValueFn},
+		properties.Name:           {name: "FunctionName", transform: extractValueFn},
+		properties.Hash:           {name: "CodeSha256", transform: extractValueFn},
+		properties.Size:           {name: "CodeSize", transform: extractValueFn},
+		properties.Description:    {name: "Description", transform: extractValueFn},
+		properties.Handler:        {name: "Handler", transform: extractValueFn},
+		properties.Modified:       {name: "LastModified", transform: extractTimeFn},
+		properties.Memory:         {name: "MemorySize", transform: extractValueFn},
+		properties.Role:           {name: "Role", transform: extractValueFn},
+		properties.Runtime:        {name: "Runtime", transform: extractValueFn},
+		properties.Timeout:        {name: "Timeout", transform: extractValueFn},
+		properties.Version:        {name: "Version", transform: extractValueFn},
+		properties.ResourcePolicy: {fetch: fetchFunctionPolicyFn},
+	},
+	cloud.Stream: {
+		properties.Name:            {name: "StreamName", transform: extractValueFn},
+		properties.Arn:             {name: "StreamARN", transform: extractValueFn},
+		properties.State:           {name: "StreamStatus", transform: extractValueFn},
+		properties.Mode:            {name: "StreamMode", transform: extractValueFn},
+		properties.Shards:          {name: "OpenShardCount", transform: extractValueFn},
+		properties.RetentionPeriod: {name: "RetentionPeriodHours", transform: extractValueFn},
+		properties.Encryption:      {name: "EncryptionType", transform: extractValueFn},
+		properties.Created:         {name: "CreateTime", transform: extractTimeFn},
+		properties.Consumers:       {name: "Consumers", transform: extractStringPointerSliceValues},
+	},
+	cloud.DeliveryStream: {
+		properties.Name:            {name: "DeliveryStreamName", transform: extractValueFn},
+		properties.Arn:             {name: "DeliveryStreamARN", transform: extractValueFn},
+		properties.State:           {name: "DeliveryStreamStatus", transform: extractValueFn},
+		properties.Type:            {name: "DeliveryStreamType", transform: extractValueFn},
+		properties.Created:         {name: "CreateTime", transform: extractTimeFn},
+		properties.Source:          {name: "SourceStreamARN", transform: extractValueFn},
+		properties.DestinationType: {name: "DestinationType", transform: extractValueFn},
+		properties.Destination:     {name: "Destination", transform: extractValueFn},
+		properties.BufferSize:      {name: "BufferSizeInMBs", transform: extractValueFn},
+		properties.BufferInterval:  {name: "BufferIntervalInSecond", transform: extractValueFn},
 	},
 	// Monitoring
 	cloud.Metric: {
@@ -442,22 +576,24 @@ var awsResourcesDef = map[string]map[string]*propertyTransform{
 	},
 	// CDN
 	cloud.Distribution: {
-		properties.Arn:                {name: "ARN", transform: extractValueFn},
-		properties.Aliases:            {name: "Aliases", transform: extractFieldFn("Items")},
-		properties.Comment:            {name: "Comment", transform: extractValueFn},
-		properties.ACMCertificate:     {name: "ViewerCertificate", transform: extractFieldFn("ACMCertificateArn")},
-		properties.Certificate:        {name: "ViewerCertificate", transform: extractFieldFn("Certificate")},
-		properties.TLSVersionRequired: {name: "ViewerCertificate", transform: extractFieldFn("MinimumProtocolVersion")},
-		properties.SSLSupportMethod:   {name: "ViewerCertificate", transform: extractFieldFn("SSLSupportMethod")},
-		properties.Origins:            {name: "Origins", transform: extractDistributionOriginFn},
-		properties.PublicDNS:          {name: "DomainName", transform: extractValueFn},
-		properties.Enabled:            {name: "Enabled", transform: extractValueFn},
-		properties.HTTPVersion:        {name: "HttpVersion", transform: extractValueFn},
-		properties.IPv6Enabled:        {name: "IsIPV6Enabled", transform: extractValueFn},
-		properties.Modified:           {name: "LastModifiedTime", transform: extractValueFn},
-		properties.PriceClass:         {name: "PriceClass", transform: extractValueFn},
-		properties.State:              {name: "Status", transform: extractValueFn},
-		properties.WebACL:             {name: "WebACLId", transform: extractValueFn},
+		properties.Arn:                  {name: "ARN", transform: extractValueFn},
+		properties.Aliases:              {name: "Aliases", transform: extractFieldFn("Items")},
+		properties.Comment:              {name: "Comment", transform: extractValueFn},
+		properties.ACMCertificate:       {name: "ViewerCertificate", transform: extractFieldFn("ACMCertificateArn")},
+		properties.Certificate:          {name: "ViewerCertificate", transform: extractFieldFn("Certificate")},
+		properties.TLSVersionRequired:   {name: "ViewerCertificate", transform: extractFieldFn("MinimumProtocolVersion")},
+		properties.SSLSupportMethod:     {name: "ViewerCertificate", transform: extractFieldFn("SSLSupportMethod")},
+		properties.Origins:              {name: "Origins", transform: extractDistributionOriginFn},
+		properties.DefaultCacheBehavior: {name: "DefaultCacheBehavior", transform: extractDistributionDefaultBehaviorFn},
+		properties.CacheBehaviors:       {name: "CacheBehaviors", transform: extractDistributionBehaviorsFn},
+		properties.PublicDNS:            {name: "DomainName", transform: extractValueFn},
+		properties.Enabled:              {name: "Enabled", transform: extractValueFn},
+		properties.HTTPVersion:          {name: "HttpVersion", transform: extractValueFn},
+		properties.IPv6Enabled:          {name: "IsIPV6Enabled", transform: extractValueFn},
+		properties.Modified:             {name: "LastModifiedTime", transform: extractValueFn},
+		properties.PriceClass:           {name: "PriceClass", transform: extractValueFn},
+		properties.State:                {name: "Status", transform: extractValueFn},
+		properties.WebACL:               {name: "WebACLId", transform: extractValueFn},
 	},
 	// Cloudformation
 	cloud.Stack: {
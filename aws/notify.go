@@ -0,0 +1,175 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+)
+
+// Config keys of the notification sinks of long operations (sync, template runs)
+const (
+	NotifyTopicConfigKey   = "aws.notify.topic"
+	NotifyWebhookConfigKey = "aws.notify.webhook"
+	NotifyCommandConfigKey = "aws.notify.command"
+	NotifyOnConfigKey      = "aws.notify.on"
+)
+
+// Outcomes of a notified operation
+const (
+	OperationSucceeded = "success"
+	OperationFailed    = "failure"
+)
+
+// OperationNotification is the payload sent to the notification sinks when a long operation completes or fails
+type OperationNotification struct {
+	Operation string    `json:"operation"`
+	Outcome   string    `json:"outcome"`
+	Duration  string    `json:"duration"`
+	Seconds   float64   `json:"duration_seconds"`
+	Error     string    `json:"error,omitempty"`
+	Region    string    `json:"region,omitempty"`
+	Time      time.Time `json:"time"`
+}
+
+// NewOperationNotification returns the notification of an operation started at start and ending now, failed if err is not nil
+func NewOperationNotification(operation string, start time.Time, err error) *OperationNotification {
+	now := time.Now().UTC()
+	elapsed := now.Sub(start)
+	n := &OperationNotification{
+		Operation: operation,
+		Outcome:   OperationSucceeded,
+		Duration:  elapsed.Round(time.Millisecond).String(),
+		Seconds:   elapsed.Seconds(),
+		Time:      now,
+	}
+	if err != nil {
+		n.Outcome = OperationFailed
+		n.Error = err.Error()
+	}
+	return n
+}
+
+func (n *OperationNotification) subject() string {
+	return fmt.Sprintf("awless %s: %s", n.Operation, n.Outcome)
+}
+
+// Notifier sends the notification of an operation to a sink
+type Notifier interface {
+	Notify(*OperationNotification) error
+	fmt.Stringer
+}
+
+// Notifiers returns the sinks configured with the aws.notify.* keys that should be notified of the outcome.
+// The topic sink publishes with the given SNS client
+func Notifiers(conf map[string]interface{}, snsAPI snsiface.SNSAPI, outcome string) (notifiers []Notifier) {
+	if on, _ := conf[NotifyOnConfigKey].(string); on == OperationFailed && outcome != OperationFailed {
+		return
+	}
+	if topic, _ := conf[NotifyTopicConfigKey].(string); topic != "" && snsAPI != nil {
+		notifiers = append(notifiers, &TopicNotifier{api: snsAPI, topic: topic})
+	}
+	if url, _ := conf[NotifyWebhookConfigKey].(string); url != "" {
+		notifiers = append(notifiers, &WebhookNotifier{url: url, client: &http.Client{Timeout: 10 * time.Second}})
+	}
+	if command, _ := conf[NotifyCommandConfigKey].(string); command != "" {
+		notifiers = append(notifiers, &CommandNotifier{command: command})
+	}
+	return
+}
+
+// TopicNotifier publishes the notification as JSON on a SNS topic
+type TopicNotifier struct {
+	api   snsiface.SNSAPI
+	topic string
+}
+
+func (t *TopicNotifier) Notify(n *OperationNotification) error {
+	payload, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	_, err = t.api.Publish(&sns.PublishInput{
+		TopicArn: awssdk.String(t.topic),
+		Subject:  awssdk.String(n.subject()),
+		Message:  awssdk.String(string(payload)),
+	})
+	return err
+}
+
+func (t *TopicNotifier) String() string { return "topic " + t.topic }
+
+// WebhookNotifier posts the notification as JSON to an URL
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func (w *WebhookNotifier) Notify(n *OperationNotification) error {
+	payload, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	resp, err := w.client.Post(w.url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return nil
+}
+
+func (w *WebhookNotifier) String() string { return "webhook " + w.url }
+
+// CommandNotifier runs a shell command with the JSON notification on its standard input
+// and the notification fields in AWLESS_NOTIFY_* environment variables
+type CommandNotifier struct {
+	command string
+}
+
+func (c *CommandNotifier) Notify(n *OperationNotification) error {
+	payload, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command("sh", "-c", c.command)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(),
+		"AWLESS_NOTIFY_OPERATION="+n.Operation,
+		"AWLESS_NOTIFY_OUTCOME="+n.Outcome,
+		"AWLESS_NOTIFY_DURATION="+n.Duration,
+		"AWLESS_NOTIFY_ERROR="+n.Error,
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func (c *CommandNotifier) String() string { return "command " + c.command }
@@ -0,0 +1,155 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sns"
+)
+
+type mockSnsPublish struct {
+	*mockSns
+	published []*sns.PublishInput
+}
+
+func (m *mockSnsPublish) Publish(input *sns.PublishInput) (*sns.PublishOutput, error) {
+	m.published = append(m.published, input)
+	return &sns.PublishOutput{}, nil
+}
+
+func TestNotifiers(t *testing.T) {
+	snsAPI := &mockSnsPublish{mockSns: &mockSns{}}
+	conf := map[string]interface{}{
+		NotifyTopicConfigKey:   "arn:aws:sns:eu-west-1:123456789012:deploys",
+		NotifyWebhookConfigKey: "https://hooks.example.com/awless",
+		NotifyCommandConfigKey: "",
+		NotifyOnConfigKey:      "always",
+	}
+
+	var names []string
+	for _, n := range Notifiers(conf, snsAPI, OperationSucceeded) {
+		names = append(names, n.String())
+	}
+	if got, want := strings.Join(names, ", "), "topic arn:aws:sns:eu-west-1:123456789012:deploys, webhook https://hooks.example.com/awless"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := len(Notifiers(conf, nil, OperationSucceeded)), 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+
+	conf[NotifyOnConfigKey] = "failure"
+	if got, want := len(Notifiers(conf, snsAPI, OperationSucceeded)), 0; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := len(Notifiers(conf, snsAPI, OperationFailed)), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+}
+
+func TestNotifySinks(t *testing.T) {
+	n := NewOperationNotification("sync", time.Now().Add(-90*time.Second), errors.New("access denied"))
+	if got, want := n.Outcome, OperationFailed; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if n.Seconds < 90 {
+		t.Fatalf("got %f, want at least 90 seconds", n.Seconds)
+	}
+
+	t.Run("topic", func(t *testing.T) {
+		snsAPI := &mockSnsPublish{mockSns: &mockSns{}}
+		notifier := Notifiers(map[string]interface{}{NotifyTopicConfigKey: "my-topic"}, snsAPI, n.Outcome)[0]
+		if err := notifier.Notify(n); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := len(snsAPI.published), 1; got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+		if got, want := awssdk.StringValue(snsAPI.published[0].Subject), "awless sync: failure"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		var received OperationNotification
+		if err := json.Unmarshal([]byte(awssdk.StringValue(snsAPI.published[0].Message)), &received); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := received.Error, "access denied"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+	})
+
+	t.Run("webhook", func(t *testing.T) {
+		var received OperationNotification
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+				t.Fatal(err)
+			}
+		}))
+		defer server.Close()
+
+		notifier := Notifiers(map[string]interface{}{NotifyWebhookConfigKey: server.URL}, nil, n.Outcome)[0]
+		if err := notifier.Notify(n); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := received.Operation, "sync"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer failing.Close()
+		notifier = Notifiers(map[string]interface{}{NotifyWebhookConfigKey: failing.URL}, nil, n.Outcome)[0]
+		if err := notifier.Notify(n); err == nil {
+			t.Fatal("expected error got none")
+		}
+	})
+
+	t.Run("command", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "awless-notify")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		out := filepath.Join(dir, "out")
+		notifier := Notifiers(map[string]interface{}{NotifyCommandConfigKey: `echo "$AWLESS_NOTIFY_OUTCOME" > ` + out + ` && cat >> ` + out}, nil, n.Outcome)[0]
+		if err := notifier.Notify(n); err != nil {
+			t.Fatal(err)
+		}
+		content, err := ioutil.ReadFile(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := string(content), "failure\n{\"operation\":\"sync\""; !strings.HasPrefix(got, want) {
+			t.Fatalf("got %s, want prefix %s", got, want)
+		}
+
+		notifier = Notifiers(map[string]interface{}{NotifyCommandConfigKey: "exit 3"}, nil, n.Outcome)[0]
+		if err := notifier.Notify(n); err == nil {
+			t.Fatal("expected error got none")
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package aws
+
+import (
+	"fmt"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/signer/v4"
+	"github.com/aws/aws-sdk-go/private/protocol/jsonrpc"
+)
+
+var ParamStore *ParameterStore
+
+// ParameterStore reads parameters from the AWS SSM Parameter Store.
+// The vendored SDK does not ship the SSM service, so only the
+// GetParameter call is implemented here on top of the generic SDK client
+type ParameterStore struct {
+	*client.Client
+}
+
+func NewParameterStore(sess client.ConfigProvider) *ParameterStore {
+	c := sess.ClientConfig("ssm")
+	store := &ParameterStore{
+		Client: client.New(
+			*c.Config,
+			metadata.ClientInfo{
+				ServiceName:   "ssm",
+				SigningName:   c.SigningName,
+				SigningRegion: c.SigningRegion,
+				Endpoint:      c.Endpoint,
+				APIVersion:    "2014-11-06",
+				JSONVersion:   "1.1",
+				TargetPrefix:  "AmazonSSM",
+			},
+			c.Handlers,
+		),
+	}
+	store.Handlers.Sign.PushBackNamed(v4.SignRequestHandler)
+	store.Handlers.Build.PushBackNamed(jsonrpc.BuildHandler)
+	store.Handlers.Unmarshal.PushBackNamed(jsonrpc.UnmarshalHandler)
+	store.Handlers.UnmarshalMeta.PushBackNamed(jsonrpc.UnmarshalMetaHandler)
+	store.Handlers.UnmarshalError.PushBackNamed(jsonrpc.UnmarshalErrorHandler)
+
+	return store
+}
+
+// Get returns the value of the parameter with the given name. SecureString
+// parameters are returned encrypted unless decrypt is set
+func (s *ParameterStore) Get(name string, decrypt bool) (string, error) {
+	input := &getParameterInput{Name: awssdk.String(name), WithDecryption: awssdk.Bool(decrypt)}
+	output := &getParameterOutput{}
+
+	op := &request.Operation{Name: "GetParameter", HTTPMethod: "POST", HTTPPath: "/"}
+	if err := s.NewRequest(op, input, output).Send(); err != nil {
+		if awserr, ok := err.(awserr.Error); ok && awserr.Code() == "ParameterNotFound" {
+			return "", fmt.Errorf("ssm parameter '%s' not found", name)
+		}
+		return "", fmt.Errorf("ssm parameter '%s': %s", name, err)
+	}
+	if output.Parameter == nil {
+		return "", fmt.Errorf("ssm parameter '%s' not found", name)
+	}
+
+	return awssdk.StringValue(output.Parameter.Value), nil
+}
+
+type getParameterInput struct {
+	_ struct{} `type:"structure"`
+
+	Name           *string `min:"1" type:"string" required:"true"`
+	WithDecryption *bool   `type:"boolean"`
+}
+
+type getParameterOutput struct {
+	_ struct{} `type:"structure"`
+
+	Parameter *ssmParameter `type:"structure"`
+}
+
+type ssmParameter struct {
+	_ struct{} `type:"structure"`
+
+	Name    *string `type:"string"`
+	Type    *string `type:"string"`
+	Value   *string `type:"string"`
+	Version *int64  `type:"long"`
+}
@@ -25,10 +25,17 @@ import (
 
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/cloudfront"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/elbv2"
 	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/route53"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/wallix/awless/aws/driver"
 	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
 	"github.com/wallix/awless/graph"
 )
 
@@ -55,9 +62,12 @@ var addParentsFns = map[string][]addParentFn{
 		funcBuilder{parent: cloud.Subnet, fieldName: "SubnetId"}.build(),
 		funcBuilder{parent: cloud.SecurityGroup, fieldName: "GroupId", listName: "SecurityGroups", relation: APPLIES_ON}.build(),
 		funcBuilder{parent: cloud.Keypair, fieldName: "KeyName", relation: APPLIES_ON}.build(),
+		funcBuilder{parent: cloud.Image, fieldName: "ImageId", relation: APPLIES_ON}.build(),
+		addInstancePlacementGroupRelation,
 	},
 	cloud.SecurityGroup: {
 		funcBuilder{parent: cloud.Vpc, fieldName: "VpcId"}.build(),
+		addSecurityGroupPrefixListsRelations,
 	},
 	cloud.InternetGateway: {
 		addRegionParent,
@@ -68,9 +78,18 @@ var addParentsFns = map[string][]addParentFn{
 		funcBuilder{parent: cloud.Vpc, fieldName: "VpcId"}.build(),
 		funcBuilder{parent: cloud.Subnet, fieldName: "SubnetId", relation: DEPENDING_ON}.build(),
 	},
+	cloud.DedicatedHost: {
+		funcBuilder{parent: cloud.AvailabilityZone, fieldName: "AvailabilityZone"}.build(),
+		funcBuilder{parent: cloud.Instance, fieldName: "InstanceId", listName: "Instances", relation: DEPENDING_ON}.build(),
+	},
+	cloud.NetworkAcl: {
+		funcBuilder{parent: cloud.Subnet, fieldName: "SubnetId", listName: "Associations", relation: DEPENDING_ON}.build(),
+		funcBuilder{parent: cloud.Vpc, fieldName: "VpcId"}.build(),
+	},
 	cloud.RouteTable: {
 		funcBuilder{parent: cloud.Subnet, fieldName: "SubnetId", listName: "Associations", relation: DEPENDING_ON}.build(),
 		funcBuilder{parent: cloud.Vpc, fieldName: "VpcId"}.build(),
+		addRouteTablePrefixListsRelations,
 	},
 	cloud.Volume: {
 		funcBuilder{parent: cloud.AvailabilityZone, fieldName: "AvailabilityZone"}.build(),
@@ -117,28 +136,49 @@ var addParentsFns = map[string][]addParentFn{
 		addScalingGroupSubnets,
 	},
 	// Container
+	cloud.Fleet: {
+		addRegionParent,
+		funcBuilder{parent: cloud.Instance, stringListName: "Instances", relation: DEPENDING_ON}.build(),
+	},
 	cloud.ContainerInstance: {
 		funcBuilder{parent: cloud.Instance, fieldName: "Ec2InstanceId", relation: APPLIES_ON}.build(),
 	},
 	cloud.Subscription: {
 		funcBuilder{parent: cloud.Topic, fieldName: "TopicArn"}.build(),
 	},
-	cloud.Vpc:              {addRegionParent},
+	cloud.Vpc:              {addRegionParent, addVpcDhcpOptionsRelation},
+	cloud.DhcpOptions:      {addRegionParent},
+	cloud.PlacementGroup:   {addRegionParent},
+	cloud.PrefixList:       {addRegionParent},
+	cloud.Finding:          {addRegionParent, addFindingAffectedResource},
 	cloud.AvailabilityZone: {addRegionParent},
 	cloud.Keypair:          {addRegionParent},
 	cloud.Image:            {addRegionParent},
 	cloud.Repository:       {addRegionParent},
+	cloud.ContainerImage: {
+		funcBuilder{parent: cloud.Repository, fieldName: "RepositoryArn"}.build(),
+	},
 	cloud.ContainerCluster: {addRegionParent},
 	cloud.ContainerService: {addRegionParent},
 	cloud.User:             {userAddGroupsRelations, addManagedPoliciesRelations},
 	cloud.Role:             {addManagedPoliciesRelations},
 	cloud.Group:            {addManagedPoliciesRelations},
-	cloud.Bucket:           {addRegionParent},
+	cloud.Bucket:           {addRegionParent, addBucketReplicationRelations},
 	cloud.Function:         {addRegionParent},
-	cloud.Topic:            {addRegionParent},
-	cloud.Alarm:            {addRegionParent, addAlarmMetric},
-	cloud.Metric:           {addRegionParent},
-	cloud.Stack:            {addRegionParent},
+	cloud.Record: {
+		funcBuilder{parent: cloud.HealthCheck, fieldName: "HealthCheckId", relation: APPLIES_ON}.build(),
+	},
+	cloud.HealthCheck: {addHealthCheckChildrenRelations},
+	cloud.Stream: {
+		addRegionParent,
+		funcBuilder{parent: cloud.Function, stringListName: "Consumers", relation: DEPENDING_ON}.build(),
+	},
+	cloud.DeliveryStream: {addRegionParent, addDeliveryStreamRelations},
+	cloud.Topic:          {addRegionParent},
+	cloud.Alarm:          {addRegionParent, addAlarmMetric},
+	cloud.Metric:         {addRegionParent},
+	cloud.Stack:          {addRegionParent},
+	cloud.Distribution:   {addDistributionOriginsRelations},
 }
 
 func (fb funcBuilder) build() addParentFn {
@@ -309,6 +349,148 @@ func addRegionParent(g *graph.Graph, i interface{}) error {
 	return nil
 }
 
+// addFindingAffectedResource relates a GuardDuty finding to the instance, access key or bucket it affects
+func addFindingAffectedResource(g *graph.Graph, i interface{}) error {
+	f, ok := i.(*GuardDutyFinding)
+	if !ok {
+		return fmt.Errorf("add finding relation: not a finding, but a %T", i)
+	}
+	typ, id := f.AffectedResource()
+	if id == "" {
+		return nil
+	}
+	res, err := initResource(f)
+	if err != nil {
+		return err
+	}
+	return g.AddAppliesOnRelation(res, graph.InitResource(typ, id))
+}
+
+// addDeliveryStreamRelations relates a Firehose delivery stream to its source Kinesis stream and to
+// its destination bucket. Other destinations (Redshift, OpenSearch, ...) are not modeled as resources
+func addDeliveryStreamRelations(g *graph.Graph, i interface{}) error {
+	ds, ok := i.(*awsdriver.DeliveryStream)
+	if !ok {
+		return fmt.Errorf("add delivery stream relations: not a delivery stream but a %T", i)
+	}
+	res, err := initResource(ds)
+	if err != nil {
+		return err
+	}
+	if source := awssdk.StringValue(ds.SourceStreamARN); source != "" {
+		if err = addRelation(g, graph.InitResource(cloud.Stream, source), res, APPLIES_ON); err != nil {
+			return err
+		}
+	}
+	if awssdk.StringValue(ds.DestinationType) == awsdriver.S3Destination {
+		if bucket := strings.TrimPrefix(awssdk.StringValue(ds.Destination), "arn:aws:s3:::"); bucket != "" {
+			return addRelation(g, res, graph.InitResource(cloud.Bucket, bucket), APPLIES_ON)
+		}
+	}
+	return nil
+}
+
+// addHealthCheckChildrenRelations relates a calculated health check to the health checks it aggregates
+func addHealthCheckChildrenRelations(g *graph.Graph, i interface{}) error {
+	hc, ok := i.(*route53.HealthCheck)
+	if !ok {
+		return fmt.Errorf("add health check relations: not a health check but a %T", i)
+	}
+	if hc.HealthCheckConfig == nil {
+		return nil
+	}
+	res, err := initResource(hc)
+	if err != nil {
+		return err
+	}
+	for _, child := range hc.HealthCheckConfig.ChildHealthChecks {
+		if err = addRelation(g, graph.InitResource(cloud.HealthCheck, awssdk.StringValue(child)), res, APPLIES_ON); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addVpcDhcpOptionsRelation relates the VPC to its DHCP options set, if any
+// (VPCs without DHCP options set reference the 'default' id)
+func addVpcDhcpOptionsRelation(g *graph.Graph, i interface{}) error {
+	vpc, ok := i.(*ec2.Vpc)
+	if !ok {
+		return fmt.Errorf("add dhcp options relation: not a vpc but a %T", i)
+	}
+	id := awssdk.StringValue(vpc.DhcpOptionsId)
+	if id == "" || id == "default" {
+		return nil
+	}
+	res, err := initResource(i)
+	if err != nil {
+		return err
+	}
+	return addRelation(g, graph.InitResource(cloud.DhcpOptions, id), res, APPLIES_ON)
+}
+
+// addInstancePlacementGroupRelation relates the instance to the placement group it is launched in, if any
+func addInstancePlacementGroupRelation(g *graph.Graph, i interface{}) error {
+	inst, ok := i.(*ec2.Instance)
+	if !ok {
+		return fmt.Errorf("add placement group relation: not an instance but a %T", i)
+	}
+	if inst.Placement == nil || awssdk.StringValue(inst.Placement.GroupName) == "" {
+		return nil
+	}
+	res, err := initResource(i)
+	if err != nil {
+		return err
+	}
+	return addRelation(g, graph.InitResource(cloud.PlacementGroup, awssdk.StringValue(inst.Placement.GroupName)), res, APPLIES_ON)
+}
+
+// addSecurityGroupPrefixListsRelations relates the security group to the prefix lists its inbound and outbound rules apply to
+func addSecurityGroupPrefixListsRelations(g *graph.Graph, i interface{}) error {
+	sg, ok := i.(*ec2.SecurityGroup)
+	if !ok {
+		return fmt.Errorf("add prefix lists relations: not a security group but a %T", i)
+	}
+	res, err := initResource(i)
+	if err != nil {
+		return err
+	}
+	done := make(map[string]bool)
+	for _, perm := range append(append([]*ec2.IpPermission{}, sg.IpPermissions...), sg.IpPermissionsEgress...) {
+		for _, pl := range perm.PrefixListIds {
+			id := awssdk.StringValue(pl.PrefixListId)
+			if id == "" || done[id] {
+				continue
+			}
+			done[id] = true
+			if err = addRelation(g, graph.InitResource(cloud.PrefixList, id), res, APPLIES_ON); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// addRouteTablePrefixListsRelations relates the route table to the prefix lists its routes have as destination
+func addRouteTablePrefixListsRelations(g *graph.Graph, i interface{}) error {
+	rt, ok := i.(*ec2.RouteTable)
+	if !ok {
+		return fmt.Errorf("add prefix lists relations: not a route table but a %T", i)
+	}
+	res, err := initResource(i)
+	if err != nil {
+		return err
+	}
+	for _, r := range rt.Routes {
+		if id := awssdk.StringValue(r.DestinationPrefixListId); id != "" {
+			if err = addRelation(g, graph.InitResource(cloud.PrefixList, id), res, APPLIES_ON); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func addManagedPoliciesRelations(g *graph.Graph, i interface{}) error {
 	res, err := initResource(i)
 	if err != nil {
@@ -339,7 +521,7 @@ func addManagedPoliciesRelations(g *graph.Graph, i interface{}) error {
 		}
 		if len(policies) != 1 {
 			fmt.Fprintf(os.Stderr, "add parent to '%s/%s': unknown policy named '%s'. Ignoring it.\n", res.Type(), res.Id(), awssdk.StringValue(policy.PolicyName))
-			return nil
+			continue
 		}
 		g.AddAppliesOnRelation(policies[0], res)
 	}
@@ -402,6 +584,126 @@ func fetchTargetsAndAddRelations(g *graph.Graph, i interface{}) error {
 	return nil
 }
 
+// addBucketReplicationRelations relates a bucket to the destination buckets of its replication rules,
+// when they belong to the same account
+func addBucketReplicationRelations(g *graph.Graph, i interface{}) error {
+	b, ok := i.(*s3.Bucket)
+	if !ok {
+		return fmt.Errorf("add replication relations: not a bucket, but a %T", i)
+	}
+	res, err := g.GetResource(cloud.Bucket, awssdk.StringValue(b.Name))
+	if err != nil {
+		return err
+	}
+	rules, ok := res.Properties[properties.ReplicationRules].([]*graph.ReplicationRule)
+	if !ok || len(rules) == 0 {
+		return nil
+	}
+
+	out, err := StorageService.(s3iface.S3API).ListBuckets(&s3.ListBucketsInput{})
+	if err != nil {
+		return err
+	}
+	accountBuckets := make(map[string]bool)
+	for _, ab := range out.Buckets {
+		accountBuckets[awssdk.StringValue(ab.Name)] = true
+	}
+
+	for _, rule := range rules {
+		if !accountBuckets[rule.Destination] {
+			continue
+		}
+		if err = g.AddAppliesOnRelation(res, graph.InitResource(cloud.Bucket, rule.Destination)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addDistributionOriginsRelations relates a distribution to the buckets and load balancers
+// of the account serving as its origins
+func addDistributionOriginsRelations(g *graph.Graph, i interface{}) error {
+	d, ok := i.(*cloudfront.DistributionSummary)
+	if !ok {
+		return fmt.Errorf("add distribution origins relations: not a distribution, but a %T", i)
+	}
+	if d.Origins == nil || len(d.Origins.Items) == 0 {
+		return nil
+	}
+	res, err := initResource(d)
+	if err != nil {
+		return err
+	}
+
+	var buckets, lbDomains []string
+	for _, o := range d.Origins.Items {
+		domain := strings.ToLower(awssdk.StringValue(o.DomainName))
+		if bucket, ok := bucketOfOriginDomain(domain); ok {
+			buckets = append(buckets, bucket)
+		} else if strings.HasSuffix(domain, ".elb.amazonaws.com") {
+			lbDomains = append(lbDomains, domain)
+		}
+	}
+
+	if s3api, ok := StorageService.(s3iface.S3API); ok && len(buckets) > 0 {
+		out, err := s3api.ListBuckets(&s3.ListBucketsInput{})
+		if err != nil {
+			return err
+		}
+		accountBuckets := make(map[string]bool)
+		for _, ab := range out.Buckets {
+			accountBuckets[awssdk.StringValue(ab.Name)] = true
+		}
+		for _, bucket := range buckets {
+			if !accountBuckets[bucket] {
+				continue
+			}
+			if err = g.AddAppliesOnRelation(res, graph.InitResource(cloud.Bucket, bucket)); err != nil {
+				return err
+			}
+		}
+	}
+
+	if infra, ok := InfraService.(*Infra); ok && len(lbDomains) > 0 {
+		lbArns := make(map[string]string)
+		err := infra.DescribeLoadBalancersPages(&elbv2.DescribeLoadBalancersInput{}, func(out *elbv2.DescribeLoadBalancersOutput, lastPage bool) bool {
+			for _, lb := range out.LoadBalancers {
+				lbArns[strings.ToLower(awssdk.StringValue(lb.DNSName))] = awssdk.StringValue(lb.LoadBalancerArn)
+			}
+			return true
+		})
+		if err != nil {
+			return err
+		}
+		for _, domain := range lbDomains {
+			arn, ok := lbArns[domain]
+			if !ok {
+				continue
+			}
+			if err = g.AddAppliesOnRelation(res, graph.InitResource(cloud.LoadBalancer, arn)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// bucketOfOriginDomain returns the bucket name of S3 REST or website endpoints
+// (e.g. mybucket.s3.amazonaws.com, mybucket.s3-website.eu-west-1.amazonaws.com)
+func bucketOfOriginDomain(domain string) (string, bool) {
+	if !strings.HasSuffix(domain, ".amazonaws.com") {
+		return "", false
+	}
+	idx := strings.LastIndex(domain, ".s3.")
+	if dash := strings.LastIndex(domain, ".s3-"); dash > idx {
+		idx = dash
+	}
+	if idx <= 0 {
+		return "", false
+	}
+	return domain[:idx], true
+}
+
 func addScalingGroupSubnets(g *graph.Graph, i interface{}) error {
 	group, ok := i.(*autoscaling.Group)
 	if !ok {
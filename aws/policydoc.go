@@ -0,0 +1,209 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/sergi/go-diff/diffmatchpatch"
+	"github.com/wallix/awless/cloud"
+)
+
+// NormalizePolicyDocument returns a policy document, URL encoded or not, in a canonical
+// indented form: statements are a sorted list, their actions, resources, principals and
+// condition values are sorted lists without duplicates and keys are sorted
+func NormalizePolicyDocument(doc string) (string, error) {
+	doc = strings.TrimSpace(doc)
+	if doc != "" && !strings.HasPrefix(doc, "{") {
+		decoded, err := url.QueryUnescape(doc)
+		if err != nil {
+			return "", fmt.Errorf("policy document: %s", err)
+		}
+		doc = decoded
+	}
+	var policy map[string]interface{}
+	if err := json.Unmarshal([]byte(doc), &policy); err != nil {
+		return "", fmt.Errorf("policy document: %s", err)
+	}
+
+	var statements []interface{}
+	switch st := policy["Statement"].(type) {
+	case []interface{}:
+		statements = st
+	case map[string]interface{}:
+		statements = []interface{}{st}
+	}
+	type sortable struct {
+		sid, key string
+		st       interface{}
+	}
+	var sorted []sortable
+	for _, s := range statements {
+		st, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, k := range []string{"Action", "NotAction", "Resource", "NotResource"} {
+			if v, ok := st[k]; ok {
+				st[k] = sortedUnique(stringOrSlice(v))
+			}
+		}
+		if principals, ok := st["Principal"].(map[string]interface{}); ok {
+			for k, v := range principals {
+				principals[k] = sortedUnique(stringOrSlice(v))
+			}
+		}
+		if conditions, ok := st["Condition"].(map[string]interface{}); ok {
+			for _, c := range conditions {
+				if keys, ok := c.(map[string]interface{}); ok {
+					for k, v := range keys {
+						if values := stringOrSlice(v); len(values) > 0 {
+							keys[k] = sortedUnique(values)
+						}
+					}
+				}
+			}
+		}
+		key, _ := json.Marshal(st)
+		sid, _ := st["Sid"].(string)
+		sorted = append(sorted, sortable{sid: sid, key: string(key), st: st})
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].sid != sorted[j].sid {
+			return sorted[i].sid < sorted[j].sid
+		}
+		return sorted[i].key < sorted[j].key
+	})
+	normalized := make([]interface{}, len(sorted))
+	for i, s := range sorted {
+		normalized[i] = s.st
+	}
+	policy["Statement"] = normalized
+
+	var buff bytes.Buffer
+	enc := json.NewEncoder(&buff)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(policy); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buff.String()), nil
+}
+
+func sortedUnique(values []string) []string {
+	unique := make(map[string]struct{})
+	var out []string
+	for _, v := range values {
+		if _, done := unique[v]; !done {
+			unique[v] = struct{}{}
+			out = append(out, v)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// DiffLines returns the lines of the to text prefixed with '  ' when unchanged or '+ '
+// when added, and the lines only in the from text prefixed with '- '
+func DiffLines(from, to string) []string {
+	dmp := diffmatchpatch.New()
+	a, b, lines := dmp.DiffLinesToChars(from+"\n", to+"\n")
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(a, b, false), lines)
+
+	var out []string
+	for _, d := range diffs {
+		prefix := "  "
+		switch d.Type {
+		case diffmatchpatch.DiffInsert:
+			prefix = "+ "
+		case diffmatchpatch.DiffDelete:
+			prefix = "- "
+		}
+		for _, line := range strings.Split(strings.TrimSuffix(d.Text, "\n"), "\n") {
+			out = append(out, prefix+line)
+		}
+	}
+	return out
+}
+
+// PolicyVersion is a version of a managed policy
+type PolicyVersion struct {
+	ID       string
+	Default  bool
+	Document string
+}
+
+// PolicyVersionDocument returns the given version of a managed policy, its default version when empty
+func (s *Access) PolicyVersionDocument(arn, version string) (*PolicyVersion, error) {
+	if version == "" {
+		out, err := s.GetPolicy(&iam.GetPolicyInput{PolicyArn: awssdk.String(arn)})
+		if err != nil {
+			return nil, err
+		}
+		version = awssdk.StringValue(out.Policy.DefaultVersionId)
+	}
+	out, err := s.GetPolicyVersion(&iam.GetPolicyVersionInput{PolicyArn: awssdk.String(arn), VersionId: awssdk.String(version)})
+	if err != nil {
+		return nil, err
+	}
+	return &PolicyVersion{
+		ID:       awssdk.StringValue(out.PolicyVersion.VersionId),
+		Default:  awssdk.BoolValue(out.PolicyVersion.IsDefaultVersion),
+		Document: awssdk.StringValue(out.PolicyVersion.Document),
+	}, nil
+}
+
+// InlinePolicyDocument returns the document of an inline policy of a user, group or role
+func (s *Access) InlinePolicyDocument(resourceType, name, policy string) (string, error) {
+	switch resourceType {
+	case cloud.User:
+		out, err := s.GetUserPolicy(&iam.GetUserPolicyInput{UserName: awssdk.String(name), PolicyName: awssdk.String(policy)})
+		if err != nil {
+			return "", err
+		}
+		return awssdk.StringValue(out.PolicyDocument), nil
+	case cloud.Group:
+		out, err := s.GetGroupPolicy(&iam.GetGroupPolicyInput{GroupName: awssdk.String(name), PolicyName: awssdk.String(policy)})
+		if err != nil {
+			return "", err
+		}
+		return awssdk.StringValue(out.PolicyDocument), nil
+	case cloud.Role:
+		out, err := s.GetRolePolicy(&iam.GetRolePolicyInput{RoleName: awssdk.String(name), PolicyName: awssdk.String(policy)})
+		if err != nil {
+			return "", err
+		}
+		return awssdk.StringValue(out.PolicyDocument), nil
+	}
+	return "", fmt.Errorf("no inline policies for %s", resourceType)
+}
+
+// RoleTrustPolicy returns the trust policy document of a role, defining who can assume it
+func (s *Access) RoleTrustPolicy(name string) (string, error) {
+	out, err := s.GetRole(&iam.GetRoleInput{RoleName: awssdk.String(name)})
+	if err != nil {
+		return "", err
+	}
+	return awssdk.StringValue(out.Role.AssumeRolePolicyDocument), nil
+}
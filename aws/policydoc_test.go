@@ -0,0 +1,76 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalizePolicyDocument(t *testing.T) {
+	encoded := "%7B%22Version%22%3A%222012-10-17%22%2C%22Statement%22%3A%7B%22Effect%22%3A%22Allow%22%2C%22Principal%22%3A%7B%22Service%22%3A%22ec2.amazonaws.com%22%7D%2C%22Action%22%3A%22sts%3AAssumeRole%22%7D%7D"
+	got, err := NormalizePolicyDocument(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{
+  "Statement": [
+    {
+      "Action": [
+        "sts:AssumeRole"
+      ],
+      "Effect": "Allow",
+      "Principal": {
+        "Service": [
+          "ec2.amazonaws.com"
+        ]
+      }
+    }
+  ],
+  "Version": "2012-10-17"
+}`
+	if got != expected {
+		t.Fatalf("got\n%s\nwant\n%s", got, expected)
+	}
+
+	first := `{"Version":"2012-10-17","Statement":[{"Sid":"B","Effect":"Allow","Action":["s3:PutObject","s3:GetObject","s3:GetObject"],"Resource":"arn:aws:s3:::bucket/*"},{"Sid":"A","Effect":"Deny","Action":"s3:DeleteBucket","Resource":"*","Condition":{"StringEquals":{"aws:SourceVpc":["vpc-2","vpc-1"]}}}]}`
+	second := `{"Statement":[{"Sid":"A","Effect":"Deny","Action":"s3:DeleteBucket","Resource":["*"],"Condition":{"StringEquals":{"aws:SourceVpc":["vpc-1","vpc-2"]}}},{"Sid":"B","Effect":"Allow","Action":["s3:GetObject","s3:PutObject"],"Resource":["arn:aws:s3:::bucket/*"]}],"Version":"2012-10-17"}`
+	a, err := NormalizePolicyDocument(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := NormalizePolicyDocument(second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a != b {
+		t.Fatalf("expected equivalent documents to normalize identically, got\n%s\nand\n%s", a, b)
+	}
+
+	if _, err := NormalizePolicyDocument("not a policy"); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	from := "{\n  \"a\": 1,\n  \"b\": 2\n}"
+	to := "{\n  \"a\": 1,\n  \"c\": 3\n}"
+	expected := []string{"  {", "    \"a\": 1,", "-   \"b\": 2", "+   \"c\": 3", "  }"}
+	if got, want := DiffLines(from, to), expected; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+}
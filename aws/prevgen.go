@@ -0,0 +1,77 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PreviousGenConfigPrefix prefixes the config keys overriding the previous generation types table.
+// Ex: aws.prevgen.m5=m6i, aws.prevgen.db.r5=db.r6g or aws.prevgen.t2=none to stop flagging t2
+const PreviousGenConfigPrefix = "aws.prevgen."
+
+// PreviousGenFamilies maps the previous generation EC2 instance families and RDS
+// instance class families (prefixed with db.) to their suggested current equivalent
+var PreviousGenFamilies = map[string]string{
+	"t1":    "t3",
+	"t2":    "t3",
+	"m1":    "m5",
+	"m2":    "r5",
+	"m3":    "m5",
+	"m4":    "m5",
+	"c1":    "c5",
+	"c3":    "c5",
+	"c4":    "c5",
+	"cc2":   "c5",
+	"cr1":   "r5",
+	"r3":    "r5",
+	"r4":    "r5",
+	"i2":    "i3",
+	"hi1":   "i3",
+	"hs1":   "d2",
+	"g2":    "g4dn",
+	"p2":    "p3",
+	"db.t2": "db.t3",
+	"db.m1": "db.m5",
+	"db.m2": "db.r5",
+	"db.m3": "db.m5",
+	"db.m4": "db.m5",
+	"db.r3": "db.r5",
+	"db.r4": "db.r5",
+}
+
+// SuggestCurrentGenType returns the current generation equivalent of a previous generation
+// instance type or class (ex: m4.large gives m5.large), the overrides (i.e. config keys
+// prefixed with PreviousGenConfigPrefix) taking precedence over PreviousGenFamilies.
+// An override to 'none' stops flagging the family
+func SuggestCurrentGenType(instanceType string, overrides map[string]interface{}) (string, bool) {
+	dot := strings.LastIndex(instanceType, ".")
+	if dot < 1 {
+		return "", false
+	}
+	family, size := instanceType[:dot], instanceType[dot+1:]
+
+	current, ok := PreviousGenFamilies[family]
+	if override, found := overrides[PreviousGenConfigPrefix+family]; found {
+		current, ok = fmt.Sprint(override), true
+	}
+	if !ok || current == "" || current == "none" {
+		return "", false
+	}
+	return current + "." + size, true
+}
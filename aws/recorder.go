@@ -0,0 +1,262 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+// The AWS API interactions of the sessions are recorded into a fixture file or replayed
+// from it given the AWLESS_RECORD ("record" or "replay") and AWLESS_RECORD_FIXTURE environment variables
+const (
+	RecordModeEnv    = "AWLESS_RECORD"
+	RecordFixtureEnv = "AWLESS_RECORD_FIXTURE"
+
+	RecordMode = "record"
+	ReplayMode = "replay"
+)
+
+const scrubbedValue = "<scrubbed>"
+
+// scrubbedAccount replaces the account ids in the recordings
+const scrubbedAccount = "123456789012"
+
+// Interaction is an AWS API request and its response, as recorded in fixtures
+type Interaction struct {
+	Request struct {
+		Method string `json:"method"`
+		URL    string `json:"url"`
+		Target string `json:"target,omitempty"`
+		Body   string `json:"body,omitempty"`
+	} `json:"request"`
+	Response struct {
+		StatusCode  int    `json:"status_code"`
+		ContentType string `json:"content_type,omitempty"`
+		Body        string `json:"body,omitempty"`
+	} `json:"response"`
+}
+
+// Recorder is an HTTP transport recording the AWS API interactions into a fixture file, or
+// replaying them from it without calling AWS. Credentials, secrets and account ids are
+// scrubbed from the recordings: replayed requests are matched once scrubbed the same way
+type Recorder struct {
+	mode, fixture string
+	transport     http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []*Interaction
+	replayed     []bool
+}
+
+// NewRecorder returns a recorder of the given mode on the fixture file. In replay mode,
+// the fixture is loaded and transport is not used
+func NewRecorder(mode, fixture string, transport http.RoundTripper) (*Recorder, error) {
+	if fixture == "" {
+		return nil, fmt.Errorf("recorder: missing fixture file (set %s)", RecordFixtureEnv)
+	}
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	r := &Recorder{mode: mode, fixture: fixture, transport: transport}
+	switch mode {
+	case RecordMode:
+	case ReplayMode:
+		b, err := ioutil.ReadFile(fixture)
+		if err != nil {
+			return nil, fmt.Errorf("recorder: %s", err)
+		}
+		if err = json.Unmarshal(b, &r.interactions); err != nil {
+			return nil, fmt.Errorf("recorder: fixture %s: %s", fixture, err)
+		}
+		r.replayed = make([]bool, len(r.interactions))
+	default:
+		return nil, fmt.Errorf("recorder: invalid mode '%s', expecting '%s' or '%s'", mode, RecordMode, ReplayMode)
+	}
+	return r, nil
+}
+
+var envRecorder struct {
+	once sync.Once
+	rec  *Recorder
+	err  error
+}
+
+// RecorderFromEnv returns the recorder set up with the environment variables, if any.
+// All the sessions share the same recorder
+func RecorderFromEnv() (*Recorder, error) {
+	envRecorder.once.Do(func() {
+		if mode := os.Getenv(RecordModeEnv); mode != "" {
+			envRecorder.rec, envRecorder.err = NewRecorder(mode, os.Getenv(RecordFixtureEnv), nil)
+		}
+	})
+	return envRecorder.rec, envRecorder.err
+}
+
+// Replaying returns whether the recorder serves the fixture instead of calling AWS
+func (r *Recorder) Replaying() bool {
+	return r.mode == ReplayMode
+}
+
+// Attach records or replays the API calls of the session. Replay sessions get static credentials
+func (r *Recorder) Attach(sess *session.Session) {
+	sess.Config.HTTPClient = &http.Client{Transport: r}
+	if r.Replaying() {
+		sess.Config.Credentials = credentials.NewStaticCredentials("AKIDREPLAY", "replay", "")
+	}
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	in := &Interaction{}
+	in.Request.Method = req.Method
+	in.Request.URL = scrubRecording(req.URL.String())
+	in.Request.Target = req.Header.Get("X-Amz-Target")
+	if req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		in.Request.Body = scrubRecording(string(body))
+	}
+
+	if r.Replaying() {
+		return r.replay(req, in)
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	in.Response.StatusCode = resp.StatusCode
+	in.Response.ContentType = resp.Header.Get("Content-Type")
+	in.Response.Body = scrubRecording(string(body))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interactions = append(r.interactions, in)
+	return resp, r.save()
+}
+
+// replay returns the response of the first interaction matching the request not yet
+// replayed, or of the last matching one when all were (ex: polling the same resource)
+func (r *Recorder) replay(req *http.Request, in *Interaction) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	found := -1
+	for i, recorded := range r.interactions {
+		if recorded.Request != in.Request {
+			continue
+		}
+		found = i
+		if !r.replayed[i] {
+			break
+		}
+	}
+	if found < 0 {
+		return nil, fmt.Errorf("recorder: no interaction recorded in %s for %s %s %s", r.fixture, in.Request.Method, in.Request.URL, in.Request.Target)
+	}
+	r.replayed[found] = true
+	recorded := r.interactions[found].Response
+	header := make(http.Header)
+	if recorded.ContentType != "" {
+		header.Set("Content-Type", recorded.ContentType)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}, nil
+}
+
+func (r *Recorder) save() error {
+	b, err := json.MarshalIndent(r.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.fixture, b, 0600)
+}
+
+// RecordedSession returns a session of the region recording into or replaying the fixture, for tests.
+// Recording needs the credentials of the environment
+func RecordedSession(mode, fixture, region string) (*session.Session, error) {
+	rec, err := NewRecorder(mode, fixture, nil)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := session.NewSession(&awssdk.Config{Region: awssdk.String(region)})
+	if err != nil {
+		return nil, err
+	}
+	rec.Attach(sess)
+	return sess, nil
+}
+
+var (
+	accessKeyIDRegex     = regexp.MustCompile(`\b(AKIA|ASIA)[A-Z0-9]{16}\b`)
+	recordedAccountRegex = regexp.MustCompile(`\b\d{12}\b`)
+	sensitiveXMLRegex    = regexp.MustCompile(`<(\w*(?:Password|Secret|Token|PrivateKey|KeyMaterial|Plaintext)\w*)>[^<]*</\w+>`)
+	sensitiveJSONRegex   = regexp.MustCompile(`"(\w*(?:Password|Secret|Token|PrivateKey|KeyMaterial|Plaintext)\w*)"\s*:\s*"(?:[^"\\]|\\.)*"`)
+	sensitiveFormRegex   = regexp.MustCompile(`\b([\w.]*(?:Password|Secret|Token|PrivateKey|KeyMaterial|Plaintext)[\w.]*)=[^&]*`)
+)
+
+// paginationTokens are kept in recordings so that paginated calls are replayed in order
+var paginationTokens = map[string]bool{"NextToken": true, "nextToken": true, "ContinuationToken": true, "NextContinuationToken": true}
+
+// scrubRecording removes the credentials, secrets and account ids of a recorded URL or body
+func scrubRecording(s string) string {
+	s = accessKeyIDRegex.ReplaceAllString(s, "AKIA"+strings.Repeat("X", 16))
+	s = recordedAccountRegex.ReplaceAllString(s, scrubbedAccount)
+	s = scrubMatches(sensitiveXMLRegex, s, func(key string) string { return fmt.Sprintf("<%s>%s</%s>", key, scrubbedValue, key) })
+	s = scrubMatches(sensitiveJSONRegex, s, func(key string) string { return fmt.Sprintf("%q:%q", key, scrubbedValue) })
+	s = scrubMatches(sensitiveFormRegex, s, func(key string) string { return key + "=" + scrubbedValue })
+	return s
+}
+
+func scrubMatches(regex *regexp.Regexp, s string, scrubbed func(key string) string) string {
+	return regex.ReplaceAllStringFunc(s, func(match string) string {
+		key := regex.FindStringSubmatch(match)[1]
+		if paginationTokens[key] || paginationTokens[key[strings.LastIndex(key, ".")+1:]] {
+			return match
+		}
+		return scrubbed(key)
+	})
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// EnabledRegions returns the regions enabled for the account, sorted.
+// Opt-in regions not enabled for the account are not returned by EC2
+func (s *Infra) EnabledRegions() ([]string, error) {
+	out, err := s.DescribeRegions(&ec2.DescribeRegionsInput{})
+	if err != nil {
+		return nil, err
+	}
+
+	var regions []string
+	for _, r := range out.Regions {
+		regions = append(regions, awssdk.StringValue(r.RegionName))
+	}
+	sort.Strings(regions)
+
+	return regions, nil
+}
+
+// RegionLatency measures the time to open a TCP connection to the EC2 endpoint of the region
+func RegionLatency(region string, timeout time.Duration) (time.Duration, error) {
+	endpoint, err := endpoints.DefaultResolver().EndpointFor("ec2", region)
+	if err != nil {
+		return 0, err
+	}
+	u, err := url.Parse(endpoint.URL)
+	if err != nil {
+		return 0, err
+	}
+	host := u.Host
+	if u.Port() == "" {
+		host = net.JoinHostPort(u.Hostname(), "443")
+	}
+
+	start := time.Now()
+	conn, err := net.DialTimeout("tcp", host, timeout)
+	if err != nil {
+		return 0, fmt.Errorf("region %s: %s", region, err)
+	}
+	latency := time.Since(start)
+	conn.Close()
+
+	return latency, nil
+}
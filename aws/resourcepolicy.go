@@ -0,0 +1,199 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// PolicyGrant is an Allow statement of a resource-based policy
+type PolicyGrant struct {
+	Principals  []string
+	Actions     []string
+	Conditional bool
+}
+
+// Public returns true when the grant applies to anyone without condition
+func (g *PolicyGrant) Public() bool {
+	if g.Conditional {
+		return false
+	}
+	for _, p := range g.Principals {
+		if p == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// ResourcePolicyGrants returns the Allow statements of a resource-based policy document
+// (S3 bucket, SQS queue, SNS topic, Lambda function or ECR repository policy)
+func ResourcePolicyGrants(doc string) ([]*PolicyGrant, error) {
+	var policy struct {
+		Statement statements
+	}
+	if err := json.Unmarshal([]byte(doc), &policy); err != nil {
+		return nil, fmt.Errorf("resource policy: %s", err)
+	}
+
+	var grants []*PolicyGrant
+	for _, st := range policy.Statement {
+		if st.Effect != "Allow" {
+			continue
+		}
+		grant := &PolicyGrant{Actions: st.Action, Conditional: len(st.Condition) > 0}
+		switch p := st.Principal.(type) {
+		case string:
+			grant.Principals = append(grant.Principals, p)
+		case map[string]interface{}:
+			// AWS accounts and ARNs, services (ex: sns.amazonaws.com), federated users
+			for _, v := range p {
+				grant.Principals = append(grant.Principals, stringOrSlice(v)...)
+			}
+		}
+		sort.Strings(grant.Principals)
+		grants = append(grants, grant)
+	}
+	return grants, nil
+}
+
+// CrossAccountPrincipals returns the principals of the Allow statements of the policy
+// belonging to another AWS account than the given one. Anonymous principals ('*') are included
+func CrossAccountPrincipals(doc, account string) ([]string, error) {
+	grants, err := ResourcePolicyGrants(doc)
+	if err != nil {
+		return nil, err
+	}
+	unique := make(map[string]struct{})
+	for _, g := range grants {
+		for _, p := range g.Principals {
+			if p == "*" {
+				unique[p] = struct{}{}
+				continue
+			}
+			if princAccount := principalAccount(p); princAccount != "" && princAccount != account {
+				unique[p] = struct{}{}
+			}
+		}
+	}
+	var principals []string
+	for p := range unique {
+		principals = append(principals, p)
+	}
+	sort.Strings(principals)
+	return principals, nil
+}
+
+// ArnAccount returns the account of an ARN, empty for global resources (ex: S3 buckets)
+func ArnAccount(arn string) string {
+	if splits := strings.SplitN(arn, ":", 6); len(splits) == 6 {
+		return splits[4]
+	}
+	return ""
+}
+
+var accountIDRegex = regexp.MustCompile(`^\d{12}$`)
+
+func isAccountID(s string) bool {
+	return accountIDRegex.MatchString(s)
+}
+
+func principalAccount(principal string) string {
+	if isAccountID(principal) {
+		return principal
+	}
+	if strings.HasPrefix(principal, "arn:") {
+		return ArnAccount(principal)
+	}
+	return ""
+}
+
+// normalizeResourcePolicy returns the compacted policy document, URL decoded if needed
+func normalizeResourcePolicy(doc string) (string, error) {
+	doc = strings.TrimSpace(doc)
+	if doc == "" {
+		return "", nil
+	}
+	if !strings.HasPrefix(doc, "{") {
+		decoded, err := url.QueryUnescape(doc)
+		if err != nil {
+			return "", fmt.Errorf("resource policy: %s", err)
+		}
+		doc = decoded
+	}
+	var buff bytes.Buffer
+	if err := json.Compact(&buff, []byte(doc)); err != nil {
+		return "", fmt.Errorf("resource policy: %s", err)
+	}
+	return buff.String(), nil
+}
+
+type statement struct {
+	Effect    string
+	Principal interface{}
+	Action    stringSlice
+	Condition map[string]interface{}
+}
+
+// statements decodes a policy Statement given as a single statement or a list of statements
+type statements []*statement
+
+func (s *statements) UnmarshalJSON(b []byte) error {
+	var list []*statement
+	if err := json.Unmarshal(b, &list); err == nil {
+		*s = list
+		return nil
+	}
+	var single statement
+	if err := json.Unmarshal(b, &single); err != nil {
+		return err
+	}
+	*s = []*statement{&single}
+	return nil
+}
+
+// stringSlice decodes a policy value given as a string or a list of strings
+type stringSlice []string
+
+func (s *stringSlice) UnmarshalJSON(b []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*s = stringOrSlice(v)
+	return nil
+}
+
+func stringOrSlice(v interface{}) (out []string) {
+	switch vv := v.(type) {
+	case string:
+		out = append(out, vv)
+	case []interface{}:
+		for _, e := range vv {
+			if str, ok := e.(string); ok {
+				out = append(out, str)
+			}
+		}
+	}
+	return
+}
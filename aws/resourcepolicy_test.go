@@ -0,0 +1,86 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResourcePolicyAnalysis(t *testing.T) {
+	doc := `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Effect": "Allow", "Principal": {"AWS": ["arn:aws:iam::123456789012:role/app", "210987654321"]}, "Action": ["s3:GetObject", "s3:PutObject"]},
+    {"Effect": "Allow", "Principal": "*", "Action": "s3:GetObject"},
+    {"Effect": "Allow", "Principal": {"AWS": "*"}, "Action": "s3:ListBucket", "Condition": {"StringEquals": {"aws:SourceVpce": "vpce-1a2b3c4d"}}},
+    {"Effect": "Allow", "Principal": {"Service": "sns.amazonaws.com"}, "Action": "sqs:SendMessage"},
+    {"Effect": "Deny", "Principal": {"AWS": "arn:aws:iam::555555555555:root"}, "Action": "*"}
+  ]
+}`
+	grants, err := ResourcePolicyGrants(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(grants), 4; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := grants[0].Principals, []string{"210987654321", "arn:aws:iam::123456789012:role/app"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := grants[0].Actions, []string{"s3:GetObject", "s3:PutObject"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	var public []bool
+	for _, g := range grants {
+		public = append(public, g.Public())
+	}
+	if got, want := public, []bool{false, true, false, false}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	principals, err := CrossAccountPrincipals(doc, "123456789012")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := principals, []string{"*", "210987654321"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	if _, err := ResourcePolicyGrants("not json"); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestNormalizeResourcePolicy(t *testing.T) {
+	tcases := []struct {
+		doc, exp string
+	}{
+		{doc: "", exp: ""},
+		{doc: "{\n  \"Version\": \"2012-10-17\"\n}", exp: `{"Version":"2012-10-17"}`},
+		{doc: "%7B%22Version%22%3A%222012-10-17%22%7D", exp: `{"Version":"2012-10-17"}`},
+	}
+	for i, tcase := range tcases {
+		got, err := normalizeResourcePolicy(tcase.doc)
+		if err != nil {
+			t.Fatalf("%d: %s", i, err)
+		}
+		if got != tcase.exp {
+			t.Fatalf("%d: got %s, want %s", i, got, tcase.exp)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/logger"
+)
+
+// RoleMapConfigKey is the config key of the file mapping account ids to the role assumed in them
+const RoleMapConfigKey = "aws.accounts.rolemap"
+
+// DefaultAccountRole is the entry of the role map applying to the accounts not mapped explicitly
+const DefaultAccountRole = "default"
+
+// ErrAccountNotMapped is returned for an account neither in the role map nor covered by a default role
+var ErrAccountNotMapped = errors.New("account not in the role map and no default role")
+
+// AccountRole is the role assumed to operate in an account
+type AccountRole struct {
+	RoleARN    string `json:"role_arn"`
+	ExternalID string `json:"external_id,omitempty"`
+}
+
+// RoleMap maps account ids to the role assumed in them. The role ARN of the default entry
+// may hold an {account} placeholder (ex: arn:aws:iam::{account}:role/Audit)
+type RoleMap map[string]*AccountRole
+
+// LoadRoleMap reads a JSON role map file. Ex:
+//
+//	{
+//	  "123456789012": {"role_arn": "arn:aws:iam::123456789012:role/Admin", "external_id": "xyz"},
+//	  "default": {"role_arn": "arn:aws:iam::{account}:role/Audit"}
+//	}
+func LoadRoleMap(path string) (RoleMap, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("role map: %s", err)
+	}
+	m := make(RoleMap)
+	if err = json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("role map %s: %s", path, err)
+	}
+	for account, role := range m {
+		if account != DefaultAccountRole && !isAccountID(account) {
+			return nil, fmt.Errorf("role map %s: invalid account id '%s'", path, account)
+		}
+		if role == nil || !strings.HasPrefix(role.RoleARN, "arn:") {
+			return nil, fmt.Errorf("role map %s: account %s: missing or invalid role_arn", path, account)
+		}
+	}
+	return m, nil
+}
+
+// RoleFor returns the role assumed in the account: the one mapped to it, or else the default one
+func (m RoleMap) RoleFor(account string) (*AccountRole, error) {
+	if role, ok := m[account]; ok {
+		return role, nil
+	}
+	if def, ok := m[DefaultAccountRole]; ok {
+		return &AccountRole{RoleARN: strings.Replace(def.RoleARN, "{account}", account, -1), ExternalID: def.ExternalID}, nil
+	}
+	return nil, ErrAccountNotMapped
+}
+
+// ConfigRoleMap loads the role map file set in the config
+func ConfigRoleMap(conf map[string]interface{}) (RoleMap, error) {
+	path := config(conf).getString(RoleMapConfigKey)
+	if path == "" {
+		return nil, fmt.Errorf("no role map: set one with `awless config set %s FILE`", RoleMapConfigKey)
+	}
+	return LoadRoleMap(path)
+}
+
+// AccountServices returns the services of the given account, assuming from the credentials of
+// the profile the role mapped to the account in the role map file of the config
+func AccountServices(conf map[string]interface{}, account string, log *logger.Logger) ([]cloud.Service, error) {
+	if IsMock(conf) {
+		return nil, ErrMockUnsupported
+	}
+	awsconf := config(conf)
+	roles, err := ConfigRoleMap(conf)
+	if err != nil {
+		return nil, err
+	}
+	role, err := roles.RoleFor(account)
+	if err != nil {
+		return nil, err
+	}
+
+	sess, err := initAWSSession(awsconf.region(), awsconf.profile())
+	if err != nil {
+		return nil, err
+	}
+	assumption := &roleAssumption{RoleARN: role.RoleARN, ExternalID: role.ExternalID, SessionName: defaultRoleSessionName, SourceProfile: awsconf.profile()}
+	provider := &assumeRoleProvider{client: sts.New(sess), role: assumption}
+	accountSess := sess.Copy(&awssdk.Config{Credentials: credentials.NewCredentials(cachedCredentialsProvider(credentialsCacheKey(awsconf.profile(), assumption), provider))})
+
+	return newServices(accountSess, awsconf, log), nil
+}
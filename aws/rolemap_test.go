@@ -0,0 +1,87 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRoleMap(t *testing.T) {
+	dir, err := ioutil.TempDir("", "awless-rolemap")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	write := func(content string) string {
+		path := filepath.Join(dir, "rolemap.json")
+		if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	t.Run("mapped and default accounts", func(t *testing.T) {
+		roles, err := LoadRoleMap(write(`{
+			"123456789012": {"role_arn": "arn:aws:iam::123456789012:role/Admin", "external_id": "xyz"},
+			"default": {"role_arn": "arn:aws:iam::{account}:role/Audit"}
+		}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		role, err := roles.RoleFor("123456789012")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := *role, (AccountRole{RoleARN: "arn:aws:iam::123456789012:role/Admin", ExternalID: "xyz"}); got != want {
+			t.Fatalf("got %+v, want %+v", got, want)
+		}
+		role, err = roles.RoleFor("210987654321")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := role.RoleARN, "arn:aws:iam::210987654321:role/Audit"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+	})
+
+	t.Run("unmapped account without default", func(t *testing.T) {
+		roles, err := LoadRoleMap(write(`{"123456789012": {"role_arn": "arn:aws:iam::123456789012:role/Admin"}}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := roles.RoleFor("210987654321"); err != ErrAccountNotMapped {
+			t.Fatalf("got %v, want %v", err, ErrAccountNotMapped)
+		}
+	})
+
+	t.Run("invalid entries", func(t *testing.T) {
+		for _, content := range []string{
+			`{"prod": {"role_arn": "arn:aws:iam::123456789012:role/Admin"}}`,
+			`{"123456789012": {"external_id": "xyz"}}`,
+			`{"123456789012": null}`,
+			`not json`,
+		} {
+			if _, err := LoadRoleMap(write(content)); err == nil {
+				t.Fatalf("expected error for %s", content)
+			}
+		}
+	})
+}
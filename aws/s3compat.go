@@ -0,0 +1,50 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+)
+
+// s3Session returns the session of the S3 API, targeting a S3 compatible storage (ex: MinIO, Ceph)
+// when the aws.s3.endpoint config is set. Requests are signed (SigV4) for the aws.s3.signing.region
+// config when set, whatever the endpoint, and address buckets in the URL path with aws.s3.pathstyle
+func s3Session(sess *session.Session, awsconf config) *session.Session {
+	cfg := &awssdk.Config{}
+	if endpoint := awsconf.getString("aws.s3.endpoint"); endpoint != "" {
+		cfg.Endpoint = awssdk.String(endpoint)
+	}
+	if awsconf.getBool("aws.s3.pathstyle", false) {
+		cfg.S3ForcePathStyle = awssdk.Bool(true)
+	}
+	s3Sess := sess.Copy(cfg)
+	if region := awsconf.getString("aws.s3.signing.region"); region != "" {
+		s3Sess.Handlers.Sign.PushFrontNamed(signingRegionHandler(region))
+	}
+	return s3Sess
+}
+
+func signingRegionHandler(region string) request.NamedHandler {
+	return request.NamedHandler{
+		Name: "awless.SigningRegionHandler",
+		Fn: func(r *request.Request) {
+			r.ClientInfo.SigningRegion = region
+		},
+	}
+}
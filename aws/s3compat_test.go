@@ -0,0 +1,73 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+func TestS3CompatibleEndpoint(t *testing.T) {
+	var path, scope string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		if m := regexp.MustCompile(`^AWS4-HMAC-SHA256 Credential=AKID/\d{8}/([^,]+), SignedHeaders=[^,]+, Signature=[0-9a-f]{64}$`).FindStringSubmatch(r.Header.Get("Authorization")); len(m) > 1 {
+			scope = m[1]
+		}
+		w.Write([]byte(`<ListBucketResult><Name>mybucket</Name></ListBucketResult>`))
+	}))
+	defer server.Close()
+
+	sess := session.New(&awssdk.Config{Region: awssdk.String("us-east-1"), Credentials: credentials.NewStaticCredentials("AKID", "SECRET", "")})
+
+	tcases := []struct {
+		conf              config
+		expPath, expScope string
+	}{
+		{conf: config{"aws.s3.endpoint": server.URL, "aws.s3.pathstyle": true}, expPath: "/mybucket", expScope: "us-east-1/s3/aws4_request"},
+		{conf: config{"aws.s3.endpoint": server.URL, "aws.s3.pathstyle": true, "aws.s3.signing.region": "eu-minio-1"}, expPath: "/mybucket", expScope: "eu-minio-1/s3/aws4_request"},
+	}
+	for i, tcase := range tcases {
+		path, scope = "", ""
+		if _, err := s3.New(s3Session(sess, tcase.conf)).ListObjects(&s3.ListObjectsInput{Bucket: awssdk.String("mybucket")}); err != nil {
+			t.Fatalf("%d: %s", i, err)
+		}
+		if got, want := path, tcase.expPath; got != want {
+			t.Fatalf("%d: got %s, want %s", i, got, want)
+		}
+		if got, want := scope, tcase.expScope; got != want {
+			t.Fatalf("%d: got %s, want %s", i, got, want)
+		}
+	}
+
+	t.Run("default to AWS S3", func(t *testing.T) {
+		req, _ := s3.New(s3Session(sess, config{})).ListObjectsRequest(&s3.ListObjectsInput{Bucket: awssdk.String("mybucket")})
+		if err := req.Build(); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := req.HTTPRequest.URL.Host, "mybucket.s3.amazonaws.com"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+	})
+}
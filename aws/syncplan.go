@@ -0,0 +1,147 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"sort"
+	"time"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/graph"
+)
+
+// EstimatedCallLatency is the average duration of an AWS API call used to estimate sync durations
+const EstimatedCallLatency = 200 * time.Millisecond
+
+// fetchCost models the API calls fetching the resources of a type: fixed calls, plus a call per page
+// of resources, plus calls per fetched resource (ex: the location of each bucket). Resources listed
+// per resource of another type (ex: objects per bucket) cost at least a call per such resource
+type fetchCost struct {
+	calls, pageSize, perResource int
+	per                          string
+}
+
+var defaultFetchCost = fetchCost{calls: 1, pageSize: 1000}
+
+var fetchCosts = map[string]fetchCost{
+	cloud.User:              {calls: 1, pageSize: 100, perResource: 1},
+	cloud.Group:             {calls: 1, pageSize: 100},
+	cloud.Role:              {calls: 1, pageSize: 100},
+	cloud.Policy:            {calls: 1, pageSize: 100},
+	cloud.AccessKey:         {calls: 1, pageSize: 100},
+	cloud.Bucket:            {calls: 1, perResource: 2},
+	cloud.S3Object:          {pageSize: 1000, per: cloud.Bucket},
+	cloud.Queue:             {calls: 1, perResource: 1},
+	cloud.Zone:              {calls: 1, pageSize: 100},
+	cloud.Record:            {pageSize: 300, per: cloud.Zone},
+	cloud.HealthCheck:       {calls: 1, pageSize: 100, perResource: 1},
+	cloud.Repository:        {calls: 1, pageSize: 100},
+	cloud.ContainerImage:    {pageSize: 100, per: cloud.Repository},
+	cloud.LoadBalancer:      {calls: 1, pageSize: 400},
+	cloud.TargetGroup:       {calls: 1, pageSize: 400, perResource: 1},
+	cloud.Listener:          {pageSize: 400, per: cloud.LoadBalancer},
+	cloud.PrefixList:        {calls: 1, pageSize: 100, perResource: 1},
+	cloud.Fleet:             {calls: 2, pageSize: 1000, perResource: 1},
+	cloud.Finding:           {calls: 2, pageSize: 25},
+	cloud.ContainerCluster:  {calls: 1, pageSize: 100, perResource: 1},
+	cloud.ContainerService:  {calls: 1, pageSize: 100, perResource: 1},
+	cloud.Container:         {pageSize: 100, per: cloud.ContainerCluster},
+	cloud.ContainerInstance: {pageSize: 100, per: cloud.ContainerCluster},
+	cloud.Function:          {calls: 1, pageSize: 50},
+	cloud.Stream:            {calls: 2, pageSize: 100, perResource: 1},
+	cloud.DeliveryStream:    {calls: 1, pageSize: 10, perResource: 1},
+	cloud.Metric:            {calls: 1, pageSize: 500},
+	cloud.Alarm:             {calls: 1, pageSize: 100},
+	cloud.Stack:             {calls: 1, pageSize: 100},
+}
+
+// SyncEstimate is the estimated cost of syncing the resources of a service
+type SyncEstimate struct {
+	Service   string
+	Resources int
+	Calls     int
+	Duration  time.Duration
+	// Heaviest is the resource type costing the most calls
+	Heaviest      string
+	HeaviestCalls int
+	// Known is false when the service was never synced: the estimate is then a lower bound
+	Known bool
+}
+
+// EstimateSync estimates the API calls and the duration of syncing a service given the resources of
+// its last sync in regions (fixed calls repeat in each region). The resource types fetched concurrently,
+// the duration is the one of the longest resource type fetch
+func EstimateSync(srv cloud.Service, last *graph.Graph, conf map[string]interface{}, regions int) *SyncEstimate {
+	est := &SyncEstimate{Service: srv.Name()}
+	if srv.IsSyncDisabled() {
+		return est
+	}
+	if regions < 1 || isGlobalService(srv.Name()) {
+		regions = 1
+	}
+
+	counts := make(map[string]int)
+	for _, t := range srv.ResourceTypes() {
+		if res, err := last.GetAllResources(t); err == nil {
+			counts[t] = len(res)
+			est.Resources += len(res)
+		}
+	}
+	if regionsRes, err := last.GetAllResources(cloud.Region); err == nil {
+		est.Known = len(regionsRes) > 0 // every synced service graph has its region
+	}
+
+	types := srv.ResourceTypes()
+	sort.Strings(types)
+	var longest int
+	for _, t := range types {
+		if !config(conf).getBool("aws."+srv.Name()+"."+t+".sync", true) {
+			continue
+		}
+		cost, ok := fetchCosts[t]
+		if !ok {
+			cost = defaultFetchCost
+		}
+		calls := cost.calls * regions
+		if cost.per != "" {
+			calls += counts[cost.per]
+		}
+		if cost.pageSize > 0 {
+			calls += counts[t] / cost.pageSize
+		}
+		calls += cost.perResource * counts[t]
+
+		est.Calls += calls
+		if calls > est.HeaviestCalls {
+			est.Heaviest, est.HeaviestCalls = t, calls
+		}
+		if calls > longest {
+			longest = calls
+		}
+	}
+	est.Duration = time.Duration(longest) * EstimatedCallLatency
+	return est
+}
+
+func isGlobalService(name string) bool {
+	for _, global := range GlobalServiceNames {
+		if global == name {
+			return true
+		}
+	}
+	return false
+}
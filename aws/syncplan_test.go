@@ -0,0 +1,78 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/logger"
+)
+
+func TestEstimateSync(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddResource(graph.InitResource(cloud.Region, "eu-west-1"))
+	for i := 0; i < 3; i++ {
+		g.AddResource(graph.InitResource(cloud.Bucket, fmt.Sprintf("bucket_%d", i)))
+	}
+	for i := 0; i < 2500; i++ {
+		g.AddResource(graph.InitResource(cloud.S3Object, fmt.Sprintf("obj_%d", i)))
+	}
+	storage := &Storage{config: config{}, log: logger.DiscardLogger}
+
+	est := EstimateSync(storage, g, config{}, 1)
+	if got, want := est.Resources, 2503; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if !est.Known {
+		t.Fatal("expected known estimate")
+	}
+	// buckets: list + location and tags of each, objects: list per bucket + pages
+	if got, want := est.Calls, 7+5; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := est.Heaviest, cloud.Bucket; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := est.Duration, 7*EstimatedCallLatency; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	est = EstimateSync(storage, g, config{"aws.storage.s3object.sync": false}, 3)
+	if got, want := est.Calls, 3+6; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+
+	est = EstimateSync(storage, graph.NewGraph(), config{}, 1)
+	if est.Known {
+		t.Fatal("expected unknown estimate for never synced service")
+	}
+	if got, want := est.Calls, 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+
+	est = EstimateSync(&Storage{config: config{"aws.storage.sync": false}}, g, config{}, 1)
+	if got, want := est.Calls, 0; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := est.Duration, time.Duration(0); got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
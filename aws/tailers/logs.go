@@ -0,0 +1,104 @@
+package awstailers
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/wallix/awless/aws"
+)
+
+// logsTailer prints the events of a CloudWatch Logs log group from start (to end when not following).
+// When following, the log group is polled with FilterLogEvents: each poll starts from the timestamp
+// of the last event seen, the events already printed at that timestamp being skipped
+type logsTailer struct {
+	api              aws.LogEventsAPI
+	group, pattern   string
+	start, end       time.Time
+	refresh          bool
+	refreshFrequency time.Duration
+
+	lastTimestamp int64
+	lastIDs       map[string]bool
+}
+
+func NewLogsTailer(api aws.LogEventsAPI, group, pattern string, start, end time.Time, enableRefresh bool, frequency time.Duration) *logsTailer {
+	return &logsTailer{api: api, group: group, pattern: pattern, start: start, end: end, refresh: enableRefresh, refreshFrequency: frequency}
+}
+
+func (t *logsTailer) Name() string {
+	return "logs"
+}
+
+func (t *logsTailer) Tail(w io.Writer) error {
+	t.lastTimestamp = toMillis(t.start)
+	if err := t.displayNewEvents(w); err != nil || !t.refresh {
+		return err
+	}
+
+	if t.refreshFrequency < time.Second {
+		return fmt.Errorf("invalid refresh frequency: %s", t.refreshFrequency)
+	}
+	ticker := time.NewTicker(t.refreshFrequency)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := t.displayNewEvents(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *logsTailer) displayNewEvents(w io.Writer) error {
+	input := &aws.FilterLogEventsInput{
+		LogGroupName: awssdk.String(t.group),
+		StartTime:    awssdk.Int64(t.lastTimestamp),
+		Interleaved:  awssdk.Bool(true),
+	}
+	if t.pattern != "" {
+		input.FilterPattern = awssdk.String(t.pattern)
+	}
+	if !t.refresh && !t.end.IsZero() {
+		input.EndTime = awssdk.Int64(toMillis(t.end))
+	}
+
+	for {
+		out, err := t.api.FilterLogEvents(input)
+		if err != nil {
+			return err
+		}
+		for _, e := range out.Events {
+			stamp, id := awssdk.Int64Value(e.Timestamp), awssdk.StringValue(e.EventId)
+			if stamp < t.lastTimestamp || (stamp == t.lastTimestamp && t.lastIDs[id]) {
+				continue
+			}
+			if stamp > t.lastTimestamp {
+				t.lastTimestamp = stamp
+				t.lastIDs = make(map[string]bool)
+			}
+			if t.lastIDs == nil {
+				t.lastIDs = make(map[string]bool)
+			}
+			t.lastIDs[id] = true
+			if err := printLogEvent(w, e); err != nil {
+				return err
+			}
+		}
+		if awssdk.StringValue(out.NextToken) == "" {
+			return nil
+		}
+		input.NextToken = out.NextToken
+	}
+}
+
+func printLogEvent(w io.Writer, e *aws.FilteredLogEvent) error {
+	stamp := time.Unix(0, awssdk.Int64Value(e.Timestamp)*int64(time.Millisecond)).UTC()
+	_, err := fmt.Fprintf(w, "%s %s %s\n", stamp.Format(time.RFC3339), awssdk.StringValue(e.LogStreamName), strings.TrimRight(awssdk.StringValue(e.Message), "\n"))
+	return err
+}
+
+func toMillis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
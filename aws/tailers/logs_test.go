@@ -0,0 +1,95 @@
+package awstailers
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/wallix/awless/aws"
+)
+
+type mockLogEvents struct {
+	pages  [][]*aws.FilteredLogEvent
+	inputs []aws.FilterLogEventsInput
+}
+
+func (m *mockLogEvents) FilterLogEvents(input *aws.FilterLogEventsInput) (*aws.FilterLogEventsOutput, error) {
+	m.inputs = append(m.inputs, *input)
+	out := &aws.FilterLogEventsOutput{}
+	if len(m.pages) > 0 {
+		out.Events, m.pages = m.pages[0], m.pages[1:]
+		if len(m.pages) > 0 {
+			out.NextToken = awssdk.String("next")
+		}
+	}
+	return out, nil
+}
+
+func logEvent(id string, stamp int64, msg string) *aws.FilteredLogEvent {
+	return &aws.FilteredLogEvent{EventId: awssdk.String(id), Timestamp: awssdk.Int64(stamp), LogStreamName: awssdk.String("stream"), Message: awssdk.String(msg + "\n")}
+}
+
+func TestLogsTailer(t *testing.T) {
+	start := time.Unix(1500000000, 0)
+	end := start.Add(time.Hour)
+	mock := &mockLogEvents{pages: [][]*aws.FilteredLogEvent{
+		{logEvent("1", 1500000000000, "first"), logEvent("2", 1500000001000, "second")},
+		{logEvent("3", 1500000001000, "third")},
+	}}
+
+	tailer := NewLogsTailer(mock, "/aws/lambda/fn", "ERROR", start, end, false, time.Second)
+	var buf bytes.Buffer
+	if err := tailer.Tail(&buf); err != nil {
+		t.Fatal(err)
+	}
+	expected := "2017-07-14T02:40:00Z stream first\n2017-07-14T02:40:01Z stream second\n2017-07-14T02:40:01Z stream third\n"
+	if got, want := buf.String(), expected; got != want {
+		t.Fatalf("got \n%s\nwant\n%s", got, want)
+	}
+	if got, want := len(mock.inputs), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	first := mock.inputs[0]
+	if got, want := awssdk.StringValue(first.LogGroupName), "/aws/lambda/fn"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := awssdk.StringValue(first.FilterPattern), "ERROR"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := awssdk.Int64Value(first.StartTime), int64(1500000000000); got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := awssdk.Int64Value(first.EndTime), int64(1500003600000); got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := awssdk.StringValue(mock.inputs[1].NextToken), "next"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	t.Run("skip events already printed when polling again", func(t *testing.T) {
+		mock := &mockLogEvents{pages: [][]*aws.FilteredLogEvent{
+			{logEvent("1", 1500000000000, "first"), logEvent("2", 1500000001000, "second")},
+		}}
+		tailer := NewLogsTailer(mock, "/aws/lambda/fn", "", start, end, true, time.Second)
+		tailer.lastTimestamp = toMillis(start)
+		var buf bytes.Buffer
+		if err := tailer.displayNewEvents(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if mock.inputs[0].EndTime != nil {
+			t.Fatalf("unexpected end time when following: %d", awssdk.Int64Value(mock.inputs[0].EndTime))
+		}
+		mock.pages = [][]*aws.FilteredLogEvent{{logEvent("2", 1500000001000, "second"), logEvent("3", 1500000001000, "third"), logEvent("4", 1500000002000, "fourth")}}
+		buf.Reset()
+		if err := tailer.displayNewEvents(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := awssdk.Int64Value(mock.inputs[1].StartTime), int64(1500000001000); got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+		if got, want := buf.String(), "2017-07-14T02:40:01Z stream third\n2017-07-14T02:40:02Z stream fourth\n"; got != want {
+			t.Fatalf("got \n%s\nwant\n%s", got, want)
+		}
+	})
+}
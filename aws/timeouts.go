@@ -0,0 +1,110 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+// Categories of the AWS API calls bounded by their own timeout
+const (
+	ReadOperation   = "read"
+	WriteOperation  = "write"
+	UploadOperation = "upload"
+)
+
+// TimeoutConfigPrefix prefixes the config keys of the timeout of each category of API calls (ex: aws.timeout.read=20s)
+const TimeoutConfigPrefix = "aws.timeout."
+
+var operationTimeouts atomic.Value // holds a map[string]time.Duration
+
+// SetOperationTimeouts bounds each AWS API call (retries included) by the timeout of its category.
+// Categories without timeout are only bounded by the requests context (see SetRequestsContext)
+func SetOperationTimeouts(timeouts map[string]time.Duration) {
+	operationTimeouts.Store(timeouts)
+}
+
+// OperationTimeouts returns the timeout per category of API calls set in config with the aws.timeout.* keys
+func OperationTimeouts(conf map[string]interface{}) (map[string]time.Duration, error) {
+	timeouts := make(map[string]time.Duration)
+	for _, category := range []string{ReadOperation, WriteOperation, UploadOperation} {
+		key := TimeoutConfigPrefix + category
+		str, _ := conf[key].(string)
+		if str == "" {
+			continue
+		}
+		d, err := time.ParseDuration(str)
+		if err != nil {
+			return timeouts, fmt.Errorf("config %s: %s", key, err)
+		}
+		if d > 0 {
+			timeouts[category] = d
+		}
+	}
+	return timeouts, nil
+}
+
+// uploadOperations send a potentially large body: their duration depends on its size
+var uploadOperations = map[string]bool{
+	"s3.PutObject":              true,
+	"s3.UploadPart":             true,
+	"lambda.CreateFunction":     true,
+	"lambda.UpdateFunctionCode": true,
+}
+
+// streamingOperations return a body read after the call completes: a timeout would cut its reading
+var streamingOperations = map[string]bool{
+	"s3.GetObject": true,
+}
+
+// OperationCategory returns the category of an API call given its service and operation names (ex: ec2, DescribeInstances)
+func OperationCategory(service, operation string) string {
+	if uploadOperations[service+"."+operation] {
+		return UploadOperation
+	}
+	for _, prefix := range []string{"Describe", "List", "Get", "Head", "Lookup", "Search", "Query", "Scan"} {
+		if strings.HasPrefix(operation, prefix) {
+			return ReadOperation
+		}
+	}
+	return WriteOperation
+}
+
+// OperationTimeoutHandler bounds the API calls by the timeout of their category. It runs after
+// the ContextHandler so that the timeout applies on top of the requests context
+var OperationTimeoutHandler = request.NamedHandler{
+	Name: "awless.OperationTimeoutHandler",
+	Fn: func(r *request.Request) {
+		timeouts, _ := operationTimeouts.Load().(map[string]time.Duration)
+		if len(timeouts) == 0 || streamingOperations[r.ClientInfo.ServiceName+"."+r.Operation.Name] {
+			return
+		}
+		timeout, ok := timeouts[OperationCategory(r.ClientInfo.ServiceName, r.Operation.Name)]
+		if !ok {
+			return
+		}
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		r.SetContext(ctx)
+		r.Handlers.Complete.PushBack(func(*request.Request) { cancel() })
+	},
+}
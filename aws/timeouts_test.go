@@ -0,0 +1,104 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+func TestOperationCategory(t *testing.T) {
+	tcases := []struct {
+		service, operation, exp string
+	}{
+		{"ec2", "DescribeInstances", ReadOperation},
+		{"iam", "ListUsers", ReadOperation},
+		{"s3", "GetBucketPolicy", ReadOperation},
+		{"s3", "HeadObject", ReadOperation},
+		{"ec2", "RunInstances", WriteOperation},
+		{"cloudformation", "CreateStack", WriteOperation},
+		{"s3", "PutObject", UploadOperation},
+		{"s3", "UploadPart", UploadOperation},
+		{"lambda", "UpdateFunctionCode", UploadOperation},
+	}
+	for i, tcase := range tcases {
+		if got, want := OperationCategory(tcase.service, tcase.operation), tcase.exp; got != want {
+			t.Fatalf("%d: got %s, want %s", i+1, got, want)
+		}
+	}
+}
+
+func TestOperationTimeouts(t *testing.T) {
+	timeouts, err := OperationTimeouts(map[string]interface{}{"aws.timeout.read": "20s", "aws.timeout.write": "", "aws.timeout.upload": "30m"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(timeouts), 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := timeouts[ReadOperation], 20*time.Second; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := timeouts[UploadOperation], 30*time.Minute; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	if _, err := OperationTimeouts(map[string]interface{}{"aws.timeout.write": "soon"}); err == nil {
+		t.Fatal("expected error got none")
+	}
+}
+
+func TestOperationTimeoutHandler(t *testing.T) {
+	SetOperationTimeouts(map[string]time.Duration{ReadOperation: time.Minute})
+	defer SetOperationTimeouts(nil)
+
+	send := func(service, operation string) (time.Time, bool) {
+		var handlers request.Handlers
+		handlers.Validate.PushBackNamed(OperationTimeoutHandler)
+		var deadline time.Time
+		var hasDeadline bool
+		handlers.Send.PushBack(func(r *request.Request) {
+			deadline, hasDeadline = r.Context().Deadline()
+		})
+		req := request.New(awssdk.Config{}, metadata.ClientInfo{ServiceName: service}, handlers, nil, &request.Operation{Name: operation}, nil, nil)
+		if err := req.Send(); err != nil {
+			t.Fatal(err)
+		}
+		if err := req.Context().Err(); hasDeadline && err == nil {
+			t.Fatal("expected context canceled once the call completed")
+		}
+		return deadline, hasDeadline
+	}
+
+	deadline, ok := send("ec2", "DescribeInstances")
+	if !ok {
+		t.Fatal("expected a deadline")
+	}
+	if until := time.Until(deadline); until <= 0 || until > time.Minute {
+		t.Fatalf("unexpected deadline in %s", until)
+	}
+	if _, ok := send("ec2", "RunInstances"); ok {
+		t.Fatal("unexpected deadline for write operation")
+	}
+	if _, ok := send("s3", "GetObject"); ok {
+		t.Fatal("unexpected deadline for streaming operation")
+	}
+}
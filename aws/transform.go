@@ -24,15 +24,18 @@ import (
 	"hash/adler32"
 	"net"
 	"reflect"
+	"strings"
 	"sync"
 	"time"
 
 	awssdk "github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/service/autoscaling"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/aws/aws-sdk-go/service/cloudfront"
 	"github.com/aws/aws-sdk-go/service/cloudwatch"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/ecr"
 	"github.com/aws/aws-sdk-go/service/ecs"
 	"github.com/aws/aws-sdk-go/service/elbv2"
@@ -43,6 +46,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/wallix/awless/aws/driver"
 	"github.com/wallix/awless/cloud"
 	"github.com/wallix/awless/cloud/properties"
 	"github.com/wallix/awless/graph"
@@ -74,6 +78,20 @@ func initResource(source interface{}) (*graph.Resource, error) {
 		res = graph.InitResource(cloud.NatGateway, awssdk.StringValue(ss.NatGatewayId))
 	case *ec2.RouteTable:
 		res = graph.InitResource(cloud.RouteTable, awssdk.StringValue(ss.RouteTableId))
+	case *ec2.DhcpOptions:
+		res = graph.InitResource(cloud.DhcpOptions, awssdk.StringValue(ss.DhcpOptionsId))
+	case *ec2.NetworkAcl:
+		res = graph.InitResource(cloud.NetworkAcl, awssdk.StringValue(ss.NetworkAclId))
+	case *ec2.PlacementGroup:
+		res = graph.InitResource(cloud.PlacementGroup, awssdk.StringValue(ss.GroupName))
+	case *ec2.Host:
+		res = graph.InitResource(cloud.DedicatedHost, awssdk.StringValue(ss.HostId))
+	case *awsdriver.ManagedPrefixList:
+		res = graph.InitResource(cloud.PrefixList, awssdk.StringValue(ss.PrefixListId))
+	case *GuardDutyFinding:
+		res = graph.InitResource(cloud.Finding, awssdk.StringValue(ss.Id))
+	case *awsdriver.Fleet:
+		res = graph.InitResource(cloud.Fleet, awssdk.StringValue(ss.FleetId))
 	case *ec2.AvailabilityZone:
 		res = graph.InitResource(cloud.AvailabilityZone, awssdk.StringValue(ss.ZoneName))
 	case *ec2.Address:
@@ -106,6 +124,8 @@ func initResource(source interface{}) (*graph.Resource, error) {
 	// Container
 	case *ecr.Repository:
 		res = graph.InitResource(cloud.Repository, awssdk.StringValue(ss.RepositoryArn))
+	case *awsdriver.ContainerImage:
+		res = graph.InitResource(cloud.ContainerImage, awssdk.StringValue(ss.RepositoryName)+"@"+awssdk.StringValue(ss.ImageDigest))
 	case *ecs.Cluster:
 		res = graph.InitResource(cloud.ContainerCluster, awssdk.StringValue(ss.ClusterArn))
 	case *ecs.TaskDefinition:
@@ -144,10 +164,19 @@ func initResource(source interface{}) (*graph.Resource, error) {
 		res = graph.InitResource(cloud.Zone, awssdk.StringValue(ss.Id))
 	case *route53.ResourceRecordSet:
 		id := hashFields(awssdk.StringValue(ss.Name), awssdk.StringValue(ss.Type))
+		if ss.SetIdentifier != nil { // records of a weighted (or failover, latency, ...) set share name and type
+			id = hashFields(awssdk.StringValue(ss.Name), awssdk.StringValue(ss.Type), awssdk.StringValue(ss.SetIdentifier))
+		}
 		res = graph.InitResource(cloud.Record, id)
+	case *route53.HealthCheck:
+		res = graph.InitResource(cloud.HealthCheck, awssdk.StringValue(ss.Id))
 		// Lambda
 	case *lambda.FunctionConfiguration:
 		res = graph.InitResource(cloud.Function, awssdk.StringValue(ss.FunctionArn))
+	case *awsdriver.Stream:
+		res = graph.InitResource(cloud.Stream, awssdk.StringValue(ss.StreamARN))
+	case *awsdriver.DeliveryStream:
+		res = graph.InitResource(cloud.DeliveryStream, awssdk.StringValue(ss.DeliveryStreamARN))
 		// Monitoring
 	case *cloudwatch.Metric:
 		id := hashFields(awssdk.StringValue(ss.Namespace), awssdk.StringValue(ss.MetricName))
@@ -196,7 +225,7 @@ func newResource(source interface{}) (*graph.Resource, error) {
 				sourceField := nodeV.FieldByName(t.name)
 				if sourceField.IsValid() && !sourceField.IsNil() {
 					val, err := t.transform(sourceField.Interface())
-					if err == ErrTagNotFound {
+					if err == ErrTagNotFound || err == errDhcpConfigurationNotFound {
 						return
 					}
 					if err != nil {
@@ -288,6 +317,20 @@ var extractTimeFn = func(i interface{}) (interface{}, error) {
 
 // Extract time that have a Z directly after the time without a space which means UTC
 // (https://en.wikipedia.org/wiki/ISO_8601#UTC)
+var extractImageSnapshotsFn = func(i interface{}) (interface{}, error) {
+	mappings, ok := i.([]*ec2.BlockDeviceMapping)
+	if !ok {
+		return nil, fmt.Errorf("extract image snapshots: not a block device mapping slice but a %T", i)
+	}
+	var snapshots []string
+	for _, m := range mappings {
+		if m.Ebs != nil && awssdk.StringValue(m.Ebs.SnapshotId) != "" {
+			snapshots = append(snapshots, awssdk.StringValue(m.Ebs.SnapshotId))
+		}
+	}
+	return snapshots, nil
+}
+
 var extractTimeWithZSuffixFn = func(i interface{}) (interface{}, error) {
 	t, ok := i.(*time.Time)
 	if ok {
@@ -345,6 +388,9 @@ var extractIpPermissionSliceFn = func(i interface{}) (interface{}, error) {
 			}
 			rule.IPRanges = append(rule.IPRanges, net)
 		}
+		for _, pl := range ipPerm.PrefixListIds {
+			rule.PrefixLists = append(rule.PrefixLists, awssdk.StringValue(pl.PrefixListId))
+		}
 
 		rules = append(rules, rule)
 	}
@@ -352,6 +398,52 @@ var extractIpPermissionSliceFn = func(i interface{}) (interface{}, error) {
 
 }
 
+var extractNetworkAclRulesFn = func(egress bool) transformFn {
+	return func(i interface{}) (interface{}, error) {
+		entries, ok := i.([]*ec2.NetworkAclEntry)
+		if !ok {
+			return nil, fmt.Errorf("extract network acl rules: not an entry slice but a %T", i)
+		}
+		var rules []*graph.FirewallRule
+		for _, e := range entries {
+			if awssdk.BoolValue(e.Egress) != egress {
+				continue
+			}
+			rule := &graph.FirewallRule{Number: awssdk.Int64Value(e.RuleNumber), Action: awssdk.StringValue(e.RuleAction)}
+			switch protocol := awssdk.StringValue(e.Protocol); protocol {
+			case "-1":
+				rule.Protocol = "any"
+				rule.PortRange = graph.PortRange{Any: true}
+			case "6", "17":
+				rule.Protocol = map[string]string{"6": "tcp", "17": "udp"}[protocol]
+				if e.PortRange != nil {
+					rule.PortRange = graph.PortRange{FromPort: awssdk.Int64Value(e.PortRange.From), ToPort: awssdk.Int64Value(e.PortRange.To)}
+				} else {
+					rule.PortRange = graph.PortRange{Any: true}
+				}
+			case "1":
+				rule.Protocol = "icmp"
+				rule.PortRange = graph.PortRange{Any: true}
+			default:
+				rule.Protocol = protocol
+				rule.PortRange = graph.PortRange{Any: true}
+			}
+			for _, cidr := range []*string{e.CidrBlock, e.Ipv6CidrBlock} {
+				if awssdk.StringValue(cidr) == "" {
+					continue
+				}
+				_, net, err := net.ParseCIDR(awssdk.StringValue(cidr))
+				if err != nil {
+					return rules, err
+				}
+				rule.IPRanges = append(rule.IPRanges, net)
+			}
+			rules = append(rules, rule)
+		}
+		return rules, nil
+	}
+}
+
 var extractNameValueFn = func(i interface{}) (interface{}, error) {
 	if _, ok := i.([]*cloudwatch.Dimension); !ok {
 		return nil, fmt.Errorf("extract ip namevalue: not a dimension slice but a %T", i)
@@ -431,6 +523,67 @@ var extractTagFn = func(key string) transformFn {
 	}
 }
 
+var errDhcpConfigurationNotFound = errors.New("dhcp configuration key not found")
+
+var extractDhcpConfigurationFn = func(key string) transformFn {
+	return func(i interface{}) (interface{}, error) {
+		confs, ok := i.([]*ec2.DhcpConfiguration)
+		if !ok {
+			return nil, fmt.Errorf("extract dhcp configuration: not a dhcp configuration slice, but a %T", i)
+		}
+		for _, c := range confs {
+			if key == awssdk.StringValue(c.Key) {
+				var values []string
+				for _, v := range c.Values {
+					values = append(values, awssdk.StringValue(v.Value))
+				}
+				return values, nil
+			}
+		}
+		return nil, errDhcpConfigurationNotFound
+	}
+}
+
+var extractDhcpDomainNameFn = func(i interface{}) (interface{}, error) {
+	values, err := extractDhcpConfigurationFn("domain-name")(i)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Join(values.([]string), " "), nil
+}
+
+// hostInstanceCapacity sums the total and available instance capacity of a dedicated host over its instance types
+func hostInstanceCapacity(i interface{}) (total, available int64, err error) {
+	capacity, ok := i.(*ec2.AvailableCapacity)
+	if !ok {
+		return 0, 0, fmt.Errorf("extract host capacity: not an available capacity but a %T", i)
+	}
+	for _, c := range capacity.AvailableInstanceCapacity {
+		total += awssdk.Int64Value(c.TotalCapacity)
+		available += awssdk.Int64Value(c.AvailableCapacity)
+	}
+	return total, available, nil
+}
+
+var extractHostTotalCapacityFn = func(i interface{}) (interface{}, error) {
+	total, _, err := hostInstanceCapacity(i)
+	return total, err
+}
+
+var extractHostAvailableCapacityFn = func(i interface{}) (interface{}, error) {
+	_, available, err := hostInstanceCapacity(i)
+	return available, err
+}
+
+// extractHostUtilizationFn returns the percentage of the instance capacity of a dedicated host in use
+var extractHostUtilizationFn = func(i interface{}) (interface{}, error) {
+	total, available, err := hostInstanceCapacity(i)
+	if err != nil || total == 0 {
+		return int64(0), err
+	}
+	return (total - available) * 100 / total, nil
+}
+
 var extractStringPointerSliceValues = func(i interface{}) (interface{}, error) {
 	pointers, ok := i.([]*string)
 	if !ok {
@@ -576,6 +729,310 @@ var fetchAndExtractGrantsFn = func(i interface{}) (interface{}, error) {
 	return grants, nil
 }
 
+var fetchReplicationRoleFn = func(i interface{}) (interface{}, error) {
+	conf, err := fetchBucketReplication(i)
+	if err != nil || conf == nil {
+		return nil, err
+	}
+	return awssdk.StringValue(conf.Role), nil
+}
+
+var fetchReplicationRulesFn = func(i interface{}) (interface{}, error) {
+	conf, err := fetchBucketReplication(i)
+	if err != nil || conf == nil {
+		return nil, err
+	}
+	var rules []*graph.ReplicationRule
+	for _, r := range conf.Rules {
+		rule := &graph.ReplicationRule{
+			ID:     awssdk.StringValue(r.ID),
+			Status: awssdk.StringValue(r.Status),
+			Prefix: awssdk.StringValue(r.Prefix),
+		}
+		if r.Destination != nil {
+			rule.Destination = bucketNameFromArn(awssdk.StringValue(r.Destination.Bucket))
+			rule.StorageClass = awssdk.StringValue(r.Destination.StorageClass)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// fetchBucketReplication returns the replication configuration of a bucket, or nil when it has none
+func fetchBucketReplication(i interface{}) (*s3.ReplicationConfiguration, error) {
+	b, ok := i.(*s3.Bucket)
+	if !ok {
+		return nil, fmt.Errorf("fetch replication: not a bucket but a %T", i)
+	}
+
+	out, err := StorageService.(s3iface.S3API).GetBucketReplication(&s3.GetBucketReplicationInput{Bucket: b.Name})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "ReplicationConfigurationNotFoundError" {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return out.ReplicationConfiguration, nil
+}
+
+func bucketNameFromArn(arn string) string {
+	return strings.TrimPrefix(arn, "arn:aws:s3:::")
+}
+
+var fetchBucketPolicyFn = func(i interface{}) (interface{}, error) {
+	b, ok := i.(*s3.Bucket)
+	if !ok {
+		return nil, fmt.Errorf("fetch bucket policy: not a bucket but a %T", i)
+	}
+
+	out, err := StorageService.(s3iface.S3API).GetBucketPolicy(&s3.GetBucketPolicyInput{Bucket: b.Name})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "NoSuchBucketPolicy" {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resourcePolicyOrNil(awssdk.StringValue(out.Policy))
+}
+
+var fetchTopicPolicyFn = func(i interface{}) (interface{}, error) {
+	t, ok := i.(*sns.Topic)
+	if !ok {
+		return nil, fmt.Errorf("fetch topic policy: not a topic but a %T", i)
+	}
+	messaging, ok := MessagingService.(*Messaging)
+	if !ok {
+		return nil, nil
+	}
+
+	out, err := messaging.GetTopicAttributes(&sns.GetTopicAttributesInput{TopicArn: t.TopicArn})
+	if err != nil {
+		return nil, err
+	}
+	return resourcePolicyOrNil(awssdk.StringValue(out.Attributes["Policy"]))
+}
+
+// healthyCheckersShare is the share of health checkers above which Route53
+// considers the endpoint of a health check healthy
+const healthyCheckersShare = 0.18
+
+// fetchHealthCheckStatusFn returns 'healthy' or 'unhealthy' from the last status reported by
+// the Route53 health checkers. Calculated health checks have no status of their own
+var fetchHealthCheckStatusFn = func(i interface{}) (interface{}, error) {
+	hc, ok := i.(*route53.HealthCheck)
+	if !ok {
+		return nil, fmt.Errorf("fetch health check status: not a health check but a %T", i)
+	}
+	if hc.HealthCheckConfig != nil && awssdk.StringValue(hc.HealthCheckConfig.Type) == route53.HealthCheckTypeCalculated {
+		return nil, nil
+	}
+	dnsService, ok := DnsService.(*Dns)
+	if !ok {
+		return nil, nil
+	}
+
+	out, err := dnsService.GetHealthCheckStatus(&route53.GetHealthCheckStatusInput{HealthCheckId: hc.Id})
+	if err != nil {
+		return nil, err
+	}
+	return healthCheckStatus(out.HealthCheckObservations), nil
+}
+
+func healthCheckStatus(observations []*route53.HealthCheckObservation) interface{} {
+	if len(observations) == 0 {
+		return nil
+	}
+	var healthy int
+	for _, o := range observations {
+		if o.StatusReport != nil && strings.HasPrefix(awssdk.StringValue(o.StatusReport.Status), "Success") {
+			healthy++
+		}
+	}
+	if float64(healthy)/float64(len(observations)) > healthyCheckersShare {
+		return "healthy"
+	}
+	return "unhealthy"
+}
+
+// extractHealthCheckEndpointFn returns the endpoint checked (ex: https://example.com:443/health),
+// the alarm of metric based checks or nothing for calculated checks
+var extractHealthCheckEndpointFn = func(i interface{}) (interface{}, error) {
+	conf, ok := i.(*route53.HealthCheckConfig)
+	if !ok {
+		return nil, fmt.Errorf("extract health check endpoint: not a config but a %T", i)
+	}
+	typ := awssdk.StringValue(conf.Type)
+	switch typ {
+	case route53.HealthCheckTypeCalculated:
+		return nil, nil
+	case route53.HealthCheckTypeCloudwatchMetric:
+		if conf.AlarmIdentifier == nil {
+			return nil, nil
+		}
+		return fmt.Sprintf("alarm:%s", awssdk.StringValue(conf.AlarmIdentifier.Name)), nil
+	}
+	host := awssdk.StringValue(conf.FullyQualifiedDomainName)
+	if ip := awssdk.StringValue(conf.IPAddress); ip != "" {
+		host = ip
+	}
+	scheme := strings.ToLower(strings.TrimSuffix(typ, "_STR_MATCH"))
+	endpoint := fmt.Sprintf("%s://%s", scheme, host)
+	if conf.Port != nil {
+		endpoint = fmt.Sprintf("%s:%d", endpoint, awssdk.Int64Value(conf.Port))
+	}
+	if path := awssdk.StringValue(conf.ResourcePath); path != "" && scheme != "tcp" {
+		endpoint += "/" + strings.TrimPrefix(path, "/")
+	}
+	return endpoint, nil
+}
+
+var fetchFunctionPolicyFn = func(i interface{}) (interface{}, error) {
+	f, ok := i.(*lambda.FunctionConfiguration)
+	if !ok {
+		return nil, fmt.Errorf("fetch function policy: not a function but a %T", i)
+	}
+	lambdaService, ok := LambdaService.(*Lambda)
+	if !ok {
+		return nil, nil
+	}
+
+	out, err := lambdaService.GetPolicy(&lambda.GetPolicyInput{FunctionName: f.FunctionArn})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == lambda.ErrCodeResourceNotFoundException {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resourcePolicyOrNil(awssdk.StringValue(out.Policy))
+}
+
+var fetchRepositoryPolicyFn = func(i interface{}) (interface{}, error) {
+	r, ok := i.(*ecr.Repository)
+	if !ok {
+		return nil, fmt.Errorf("fetch repository policy: not a repository but a %T", i)
+	}
+	infra, ok := InfraService.(*Infra)
+	if !ok {
+		return nil, nil
+	}
+
+	out, err := infra.GetRepositoryPolicy(&ecr.GetRepositoryPolicyInput{RepositoryName: r.RepositoryName, RegistryId: r.RegistryId})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == ecr.ErrCodeRepositoryPolicyNotFoundException {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resourcePolicyOrNil(awssdk.StringValue(out.PolicyText))
+}
+
+var fetchRepositoryScanOnPushFn = func(i interface{}) (interface{}, error) {
+	r, ok := i.(*ecr.Repository)
+	if !ok {
+		return nil, fmt.Errorf("fetch repository scan on push: not a repository but a %T", i)
+	}
+	api, ok := containerRegistryAPI()
+	if !ok {
+		return nil, nil
+	}
+
+	out, err := api.DescribeRepositoryDetails(&awsdriver.DescribeRepositoryDetailsInput{RepositoryNames: []*string{r.RepositoryName}, RegistryId: r.RegistryId})
+	if err != nil {
+		return nil, err
+	}
+	for _, detail := range out.Repositories {
+		if detail.ImageScanningConfiguration != nil {
+			return awssdk.BoolValue(detail.ImageScanningConfiguration.ScanOnPush), nil
+		}
+	}
+	return nil, nil
+}
+
+var fetchRepositoryLifecyclePolicyFn = func(i interface{}) (interface{}, error) {
+	r, ok := i.(*ecr.Repository)
+	if !ok {
+		return nil, fmt.Errorf("fetch repository lifecycle policy: not a repository but a %T", i)
+	}
+	api, ok := containerRegistryAPI()
+	if !ok {
+		return nil, nil
+	}
+
+	out, err := api.GetLifecyclePolicy(&awsdriver.GetLifecyclePolicyInput{RepositoryName: r.RepositoryName, RegistryId: r.RegistryId})
+	if aerr, ok := err.(awserr.Error); ok && aerr.Code() == awsdriver.ErrCodeLifecyclePolicyNotFoundException {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resourcePolicyOrNil(awssdk.StringValue(out.LifecyclePolicyText))
+}
+
+func containerRegistryAPI() (awsdriver.ContainerRegistryAPI, bool) {
+	infra, ok := InfraService.(*Infra)
+	if !ok {
+		return nil, false
+	}
+	return awsdriver.ContainerRegistry(infra.ECRAPI)
+}
+
+var extractScanFindingsFn = func(i interface{}) (interface{}, error) {
+	summary, ok := i.(*awsdriver.ImageScanFindingsSummary)
+	if !ok {
+		return nil, fmt.Errorf("extract scan findings: not a scan findings summary but a %T", i)
+	}
+	var findings []string
+	for _, severity := range scanFindingSeverities {
+		if count := awssdk.Int64Value(summary.FindingSeverityCounts[severity]); count > 0 {
+			findings = append(findings, fmt.Sprintf("%s:%d", severity, count))
+		}
+	}
+	return findings, nil
+}
+
+var extractVulnerabilitiesFn = func(i interface{}) (interface{}, error) {
+	summary, ok := i.(*awsdriver.ImageScanFindingsSummary)
+	if !ok {
+		return nil, fmt.Errorf("extract vulnerabilities: not a scan findings summary but a %T", i)
+	}
+	var count int64
+	for _, c := range summary.FindingSeverityCounts {
+		count += awssdk.Int64Value(c)
+	}
+	return count, nil
+}
+
+var scanFindingSeverities = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW", "INFORMATIONAL", "UNDEFINED"}
+
+func resourcePolicyOrNil(doc string) (interface{}, error) {
+	policy, err := normalizeResourcePolicy(doc)
+	if err != nil || policy == "" {
+		return nil, err
+	}
+	return policy, nil
+}
+
+var fetchSnapshotPublicFn = func(i interface{}) (interface{}, error) {
+	snap, ok := i.(*ec2.Snapshot)
+	if !ok {
+		return nil, fmt.Errorf("fetch snapshot public: not a snapshot but a %T", i)
+	}
+
+	attr, err := InfraService.(ec2iface.EC2API).DescribeSnapshotAttribute(&ec2.DescribeSnapshotAttributeInput{
+		Attribute:  awssdk.String("createVolumePermission"),
+		SnapshotId: snap.SnapshotId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, perm := range attr.CreateVolumePermissions {
+		if awssdk.StringValue(perm.Group) == "all" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 var extractDistributionOriginFn = func(i interface{}) (interface{}, error) {
 	if _, ok := i.(*cloudfront.Origins); !ok {
 		return nil, fmt.Errorf("extract origins: not a origins pointer but a %T", i)
@@ -587,9 +1044,13 @@ var extractDistributionOriginFn = func(i interface{}) (interface{}, error) {
 			PublicDNS:  awssdk.StringValue(o.DomainName),
 			PathPrefix: awssdk.StringValue(o.OriginPath),
 		}
-		if o.S3OriginConfig != nil && awssdk.StringValue(o.S3OriginConfig.OriginAccessIdentity) != "" {
+		switch {
+		case o.S3OriginConfig != nil:
 			origin.OriginType = "s3"
 			origin.Config = awssdk.StringValue(o.S3OriginConfig.OriginAccessIdentity)
+		case o.CustomOriginConfig != nil:
+			origin.OriginType = "custom"
+			origin.Config = awssdk.StringValue(o.CustomOriginConfig.OriginProtocolPolicy)
 		}
 
 		origins = append(origins, origin)
@@ -597,6 +1058,59 @@ var extractDistributionOriginFn = func(i interface{}) (interface{}, error) {
 	return origins, nil
 }
 
+var extractDistributionDefaultBehaviorFn = func(i interface{}) (interface{}, error) {
+	b, ok := i.(*cloudfront.DefaultCacheBehavior)
+	if !ok {
+		return nil, fmt.Errorf("extract default cache behavior: not a default cache behavior pointer but a %T", i)
+	}
+	return formatCacheBehavior("*", b.TargetOriginId, b.ViewerProtocolPolicy, b.MinTTL, b.DefaultTTL, b.MaxTTL, b.Compress), nil
+}
+
+var extractDistributionBehaviorsFn = func(i interface{}) (interface{}, error) {
+	behaviors, ok := i.(*cloudfront.CacheBehaviors)
+	if !ok {
+		return nil, fmt.Errorf("extract cache behaviors: not a cache behaviors pointer but a %T", i)
+	}
+	var res []string
+	for _, b := range behaviors.Items {
+		res = append(res, formatCacheBehavior(awssdk.StringValue(b.PathPattern), b.TargetOriginId, b.ViewerProtocolPolicy, b.MinTTL, b.DefaultTTL, b.MaxTTL, b.Compress))
+	}
+	return res, nil
+}
+
+func formatCacheBehavior(path string, origin, viewer *string, minTTL, defaultTTL, maxTTL *int64, compress *bool) string {
+	return fmt.Sprintf("path=%s origin=%s viewer=%s ttl=%d/%d/%d compress=%t", path, awssdk.StringValue(origin), awssdk.StringValue(viewer),
+		awssdk.Int64Value(minTTL), awssdk.Int64Value(defaultTTL), awssdk.Int64Value(maxTTL), awssdk.BoolValue(compress))
+}
+
+var extractFindingSeverityFn = func(i interface{}) (interface{}, error) {
+	score, ok := i.(*float64)
+	if !ok {
+		return nil, fmt.Errorf("extract finding severity: not a float pointer but a %T", i)
+	}
+	return FindingSeverityLevel(*score), nil
+}
+
+var extractFindingSeverityScoreFn = func(i interface{}) (interface{}, error) {
+	score, ok := i.(*float64)
+	if !ok {
+		return nil, fmt.Errorf("extract finding severity score: not a float pointer but a %T", i)
+	}
+	return fmt.Sprintf("%.1f", *score), nil
+}
+
+var extractFindingAffectedResourceFn = func(i interface{}) (interface{}, error) {
+	r, ok := i.(*guardDutyFindingResource)
+	if !ok {
+		return nil, fmt.Errorf("extract finding resource: not a finding resource pointer but a %T", i)
+	}
+	typ, id := (&GuardDutyFinding{Resource: r}).AffectedResource()
+	if id == "" {
+		return awssdk.StringValue(r.ResourceType), nil
+	}
+	return typ + ":" + id, nil
+}
+
 var extractStackOutputsFn = func(i interface{}) (interface{}, error) {
 	if _, ok := i.([]*cloudformation.Output); !ok {
 		return nil, fmt.Errorf("extract ouutputs not an output slice but a %T", i)
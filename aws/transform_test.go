@@ -26,6 +26,7 @@ import (
 	"github.com/wallix/awless/graph"
 
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/aws/aws-sdk-go/service/s3"
 )
 
@@ -337,6 +338,50 @@ func TestTransformFunctions(t *testing.T) {
 			t.Fatalf("got %t, want %t", got, want)
 		}
 	})
+
+	t.Run("healthCheckStatus", func(t *testing.T) {
+		t.Parallel()
+		observation := func(status string) *route53.HealthCheckObservation {
+			return &route53.HealthCheckObservation{StatusReport: &route53.StatusReport{Status: awssdk.String(status)}}
+		}
+		tcases := []struct {
+			observations []*route53.HealthCheckObservation
+			exp          interface{}
+		}{
+			{observations: nil, exp: nil},
+			{observations: []*route53.HealthCheckObservation{observation("Success: HTTP Status Code 200, OK"), observation("Success: HTTP Status Code 200, OK")}, exp: "healthy"},
+			{observations: []*route53.HealthCheckObservation{observation("Success: HTTP Status Code 200, OK"), observation("Failure: Connection timed out"), observation("Failure: Connection timed out"), observation("Failure: Connection timed out"), observation("Failure: Connection timed out"), observation("Failure: Connection timed out")}, exp: "unhealthy"},
+			{observations: []*route53.HealthCheckObservation{observation("Failure: Connection timed out"), {}}, exp: "unhealthy"},
+		}
+		for i, tcase := range tcases {
+			if got, want := healthCheckStatus(tcase.observations), tcase.exp; got != want {
+				t.Fatalf("%d: got %v, want %v", i+1, got, want)
+			}
+		}
+	})
+
+	t.Run("extractHealthCheckEndpoint", func(t *testing.T) {
+		t.Parallel()
+		tcases := []struct {
+			conf *route53.HealthCheckConfig
+			exp  interface{}
+		}{
+			{conf: &route53.HealthCheckConfig{Type: awssdk.String("HTTP"), IPAddress: awssdk.String("1.2.3.4"), Port: awssdk.Int64(80), ResourcePath: awssdk.String("status")}, exp: "http://1.2.3.4:80/status"},
+			{conf: &route53.HealthCheckConfig{Type: awssdk.String("HTTPS_STR_MATCH"), FullyQualifiedDomainName: awssdk.String("example.com"), Port: awssdk.Int64(443), ResourcePath: awssdk.String("/health")}, exp: "https://example.com:443/health"},
+			{conf: &route53.HealthCheckConfig{Type: awssdk.String("TCP"), IPAddress: awssdk.String("1.2.3.4"), Port: awssdk.Int64(22), ResourcePath: awssdk.String("/ignored")}, exp: "tcp://1.2.3.4:22"},
+			{conf: &route53.HealthCheckConfig{Type: awssdk.String("CLOUDWATCH_METRIC"), AlarmIdentifier: &route53.AlarmIdentifier{Name: awssdk.String("cpu-high")}}, exp: "alarm:cpu-high"},
+			{conf: &route53.HealthCheckConfig{Type: awssdk.String("CALCULATED")}, exp: nil},
+		}
+		for i, tcase := range tcases {
+			val, err := extractHealthCheckEndpointFn(tcase.conf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := val, tcase.exp; got != want {
+				t.Fatalf("%d: got %v, want %v", i+1, got, want)
+			}
+		}
+	})
 }
 
 func TestFetchFunctions(t *testing.T) {
@@ -25,7 +25,15 @@ import (
 	"github.com/wallix/awless/template/driver"
 )
 
-var ErrFetchAccessDenied = errors.New("access denied to cloud resource")
+// Categories of the errors returned by services and drivers, to be matched with errors.Is
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrAccessDenied = errors.New("access denied")
+	ErrThrottled    = errors.New("throttled")
+	ErrValidation   = errors.New("validation error")
+)
+
+var ErrFetchAccessDenied = fmt.Errorf("%w to cloud resource", ErrAccessDenied)
 
 // Resources
 const (
@@ -44,8 +52,14 @@ const (
 	InternetGateway  string = "internetgateway"
 	NatGateway       string = "natgateway"
 	RouteTable       string = "routetable"
+	DhcpOptions      string = "dhcpoptions"
+	NetworkAcl       string = "networkacl"
+	PlacementGroup   string = "placementgroup"
+	DedicatedHost    string = "dedicatedhost"
+	PrefixList       string = "prefixlist"
 	ElasticIP        string = "elasticip"
 	Snapshot         string = "snapshot"
+	Finding          string = "finding"
 	//loadbalancer
 	LoadBalancer string = "loadbalancer"
 	TargetGroup  string = "targetgroup"
@@ -70,14 +84,18 @@ const (
 	//queue
 	Queue string = "queue"
 	//dns
-	Zone   string = "zone"
-	Record string = "record"
+	Zone        string = "zone"
+	Record      string = "record"
+	HealthCheck string = "healthcheck"
 	//lambda
-	Function string = "function"
+	Function       string = "function"
+	Stream         string = "stream"
+	DeliveryStream string = "deliverystream"
 	//autoscaling
 	LaunchConfiguration string = "launchconfiguration"
 	ScalingGroup        string = "scalinggroup"
 	ScalingPolicy       string = "scalingpolicy"
+	Fleet               string = "fleet"
 	//monitoring
 	Metric string = "metric"
 	Alarm  string = "alarm"
@@ -87,6 +105,7 @@ const (
 	Stack string = "stack"
 	//container
 	Repository        string = "repository"
+	ContainerImage    string = "containerimage"
 	Registry          string = "registry"
 	ContainerCluster  string = "containercluster"
 	ContainerService  string = "containerservice"
@@ -107,6 +126,12 @@ type Service interface {
 	FetchByType(t string) (*graph.Graph, error)
 }
 
+// ResourceFetcher is implemented by the services able to fetch a single resource
+// and its relations, without fetching all the resources of the service
+type ResourceFetcher interface {
+	FetchResource(t, id string) (*graph.Graph, error)
+}
+
 type Services []Service
 
 func (srvs Services) Names() (names []string) {
@@ -130,6 +155,9 @@ func GetServiceForType(t string) (Service, error) {
 }
 
 func PluralizeResource(singular string) string {
+	if singular == DhcpOptions {
+		return singular
+	}
 	if strings.HasSuffix(singular, "cy") || strings.HasSuffix(singular, "ry") {
 		return strings.TrimSuffix(singular, "y") + "ies"
 	}
@@ -137,6 +165,9 @@ func PluralizeResource(singular string) string {
 }
 
 func SingularizeResource(plural string) string {
+	if plural == DhcpOptions {
+		return plural
+	}
 	if strings.HasSuffix(plural, "ies") {
 		return strings.TrimSuffix(plural, "ies") + "y"
 	}
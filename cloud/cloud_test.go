@@ -33,6 +33,7 @@ func TestResourceTypePluralizeName(t *testing.T) {
 		{in: "internetgateway", out: "internetgateways"},
 		{in: "repository", out: "repositories"},
 		{in: "registry", out: "registries"},
+		{in: "dhcpoptions", out: "dhcpoptions"},
 	}
 	for _, tc := range tcases {
 		if got, want := PluralizeResource(tc.in), tc.out; got != want {
@@ -57,6 +58,7 @@ func TestResourceTypeSingularizeName(t *testing.T) {
 		{out: "internetgateway", in: "internetgateways"},
 		{out: "repository", in: "repositories"},
 		{out: "registry", in: "registries"},
+		{out: "dhcpoptions", in: "dhcpoptions"},
 	}
 	for _, tc := range tcases {
 		if got, want := SingularizeResource(tc.in), tc.out; got != want {
@@ -23,7 +23,9 @@ const (
 	ActionsEnabled                    = "ActionsEnabled"
 	ActiveServicesCount               = "ActiveServicesCount"
 	ACMCertificate                    = "ACMCertificate"
+	ActivityStatus                    = "ActivityStatus"
 	AdjustmentType                    = "AdjustmentType"
+	AffectedResource                  = "AffectedResource"
 	Affinity                          = "Affinity"
 	AgentConnected                    = "AgentConnected"
 	AgentState                        = "AgentState"
@@ -38,11 +40,25 @@ const (
 	Attachable                        = "Attachable"
 	Attributes                        = "Attributes"
 	AutoUpgrade                       = "AutoUpgrade"
+	BufferInterval                    = "BufferInterval"
+	BufferSize                        = "BufferSize"
+	Consumers                         = "Consumers"
+	Destination                       = "Destination"
+	DestinationType                   = "DestinationType"
+	Encryption                        = "Encryption"
+	FulfilledCapacity                 = "FulfilledCapacity"
+	Kind                              = "Kind"
+	Mode                              = "Mode"
+	OnDemandCapacity                  = "OnDemandCapacity"
+	RetentionPeriod                   = "RetentionPeriod"
 	ScalingGroupName                  = "ScalingGroupName"
 	AvailabilityZone                  = "AvailabilityZone"
 	AvailabilityZones                 = "AvailabilityZones"
+	AvailableCapacity                 = "AvailableCapacity"
+	FreeIPs                           = "FreeIPs"
 	BackupRetentionPeriod             = "BackupRetentionPeriod"
 	Bucket                            = "Bucket"
+	CacheBehaviors                    = "CacheBehaviors"
 	CallerReference                   = "CallerReference"
 	Capabilities                      = "Capabilities"
 	Certificate                       = "Certificate"
@@ -66,6 +82,7 @@ const (
 	Continent                         = "Continent"
 	Config                            = "Config"
 	Cooldown                          = "Cooldown"
+	Cores                             = "Cores"
 	CopyTagsToSnapshot                = "CopyTagsToSnapshot"
 	ContainersImages                  = "ContainersImages"
 	ContainerService                  = "ContainerService"
@@ -74,6 +91,7 @@ const (
 	DBSecurityGroups                  = "DBSecurityGroups"
 	DBSubnetGroup                     = "DBSubnetGroup"
 	Default                           = "Default"
+	DefaultCacheBehavior              = "DefaultCacheBehavior"
 	DefaultCooldown                   = "DefaultCooldown"
 	Delay                             = "Delay"
 	Description                       = "Description"
@@ -82,19 +100,23 @@ const (
 	Dimensions                        = "Dimensions"
 	DisableRollback                   = "DisableRollback"
 	DockerVersion                     = "DockerVersion"
+	DomainName                        = "DomainName"
 	Enabled                           = "Enabled"
 	Encrypted                         = "Encrypted"
 	Endpoint                          = "Endpoint"
 	Engine                            = "Engine"
 	EngineVersion                     = "EngineVersion"
+	Entries                           = "Entries"
 	ExitCode                          = "ExitCode"
 	Failover                          = "Failover"
 	Fingerprint                       = "Fingerprint"
 	GlobalID                          = "GlobalID"
 	GranteeType                       = "GranteeType"
 	Grants                            = "Grants"
+	Groups                            = "Groups"
 	Handler                           = "Handler"
 	Hash                              = "Hash"
+	Health                            = "Health"
 	HealthCheck                       = "HealthCheck"
 	HealthCheckType                   = "HealthCheckType"
 	HealthCheckGracePeriod            = "HealthCheckGracePeriod"
@@ -104,6 +126,7 @@ const (
 	Hypervisor                        = "Hypervisor"
 	ID                                = "ID"
 	Image                             = "Image"
+	ImageTags                         = "ImageTags"
 	InboundRules                      = "InboundRules"
 	InlinePolicies                    = "InlinePolicies"
 	Instance                          = "Instance"
@@ -119,9 +142,12 @@ const (
 	LaunchConfigurationName           = "LaunchConfigurationName"
 	License                           = "License"
 	Lifecycle                         = "Lifecycle"
+	LifecyclePolicy                   = "LifecyclePolicy"
 	LoadBalancer                      = "LoadBalancer"
 	Location                          = "Location"
 	Main                              = "Main"
+	ManagedPolicies                   = "ManagedPolicies"
+	MaxEntries                        = "MaxEntries"
 	MaxSize                           = "MaxSize"
 	Memory                            = "Memory"
 	Messages                          = "Messages"
@@ -132,10 +158,13 @@ const (
 	MonitoringRole                    = "MonitoringRole"
 	MultiAZ                           = "MultiAZ"
 	Name                              = "Name"
+	NameServers                       = "NameServers"
+	NTPServers                        = "NTPServers"
 	Namespace                         = "Namespace"
 	NewInstancesProtected             = "NewInstancesProtected"
 	NetworkInterfaces                 = "NetworkInterfaces"
 	Notifications                     = "Notifications"
+	Occurrences                       = "Occurrences"
 	OKActions                         = "OKActions"
 	OptionGroups                      = "OptionGroups"
 	OutboundRules                     = "OutboundRules"
@@ -166,6 +195,10 @@ const (
 	Records                           = "Records"
 	Region                            = "Region"
 	RegisteredContainerInstancesCount = "RegisteredContainerInstancesCount"
+	ReplicationRole                   = "ReplicationRole"
+	ReplicationRules                  = "ReplicationRules"
+	Repository                        = "Repository"
+	ResourcePolicy                    = "ResourcePolicy"
 	Role                              = "Role"
 	RootDevice                        = "RootDevice"
 	RootDeviceType                    = "RootDeviceType"
@@ -173,11 +206,21 @@ const (
 	Runtime                           = "Runtime"
 	RunningTasksCount                 = "RunningTasksCount"
 	ScalingAdjustment                 = "ScalingAdjustment"
+	ScanFindings                      = "ScanFindings"
+	ScanOnPush                        = "ScanOnPush"
+	ScanStatus                        = "ScanStatus"
 	Scheme                            = "Scheme"
 	SecondaryAvailabilityZone         = "SecondaryAvailabilityZone"
 	SecurityGroups                    = "SecurityGroups"
+	Severity                          = "Severity"
+	SeverityScore                     = "SeverityScore"
 	Set                               = "Set"
+	Shards                            = "Shards"
 	Size                              = "Size"
+	Snapshots                         = "Snapshots"
+	Sockets                           = "Sockets"
+	Source                            = "Source"
+	SpotCapacity                      = "SpotCapacity"
 	SpotInstanceRequestId             = "SpotInstanceRequestId"
 	SpotPrice                         = "SpotPrice"
 	SSLSupportMethod                  = "SSLSupportMethod"
@@ -186,13 +229,16 @@ const (
 	Stopped                           = "Stopped"
 	Storage                           = "Storage"
 	StorageType                       = "StorageType"
+	Strategy                          = "Strategy"
 	Subnet                            = "Subnet"
 	Subnets                           = "Subnets"
 	Tags                              = "Tags"
+	TargetCapacity                    = "TargetCapacity"
 	Timeout                           = "Timeout"
 	Timezone                          = "Timezone"
 	TLSVersionRequired                = "TLSVersionRequired"
 	Topic                             = "Topic"
+	TotalCapacity                     = "TotalCapacity"
 	TrafficPolicyInstance             = "TrafficPolicyInstance"
 	TTL                               = "TTL"
 	Type                              = "Type"
@@ -201,12 +247,14 @@ const (
 	UserData                          = "UserData"
 	Username                          = "Username"
 	URI                               = "URI"
+	Utilization                       = "Utilization"
 	Value                             = "Value"
 	Version                           = "Version"
 	Virtualization                    = "Virtualization"
 	Volume                            = "Volume"
 	Vpc                               = "Vpc"
 	Vpcs                              = "Vpcs"
+	Vulnerabilities                   = "Vulnerabilities"
 	Weight                            = "Weight"
 	WebACL                            = "WebACL"
 	Zone                              = "Zone"
@@ -25,7 +25,9 @@ const (
 	ActionsEnabled                    = "cloud:actionsEnabled"
 	ActiveServicesCount               = "cloud:activeServicesCount"
 	ACMCertificate                    = "cloud:acmCertificate"
+	ActivityStatus                    = "cloud:activityStatus"
 	AdjustmentType                    = "cloud:adjustmentType"
+	AffectedResource                  = "cloud:affectedResource"
 	Affinity                          = "cloud:affinity"
 	AgentConnected                    = "cloud:agentConnected"
 	AgentState                        = "cloud:agentState"
@@ -40,11 +42,25 @@ const (
 	Attachable                        = "cloud:attachable"
 	Attributes                        = "cloud:attributes"
 	AutoUpgrade                       = "cloud:autoUpgrade"
+	BufferInterval                    = "cloud:bufferInterval"
+	BufferSize                        = "cloud:bufferSize"
+	Consumers                         = "cloud:consumers"
+	Destination                       = "cloud:destination"
+	DestinationType                   = "cloud:destinationType"
+	Encryption                        = "cloud:encryption"
+	FulfilledCapacity                 = "cloud:fulfilledCapacity"
+	Kind                              = "cloud:kind"
+	Mode                              = "cloud:mode"
+	OnDemandCapacity                  = "cloud:onDemandCapacity"
+	RetentionPeriod                   = "cloud:retentionPeriod"
 	ScalingGroupName                  = "cloud:scalingGroupName"
 	AvailabilityZone                  = "cloud:availabilityZone"
 	AvailabilityZones                 = "cloud:availabilityZones"
+	AvailableCapacity                 = "cloud:availableCapacity"
+	FreeIPs                           = "cloud:freeIPs"
 	BackupRetentionPeriod             = "cloud:backupRetentionPeriod"
 	Bucket                            = "cloud:bucketName"
+	CacheBehaviors                    = "cloud:cacheBehaviors"
 	CallerReference                   = "cloud:callerReference"
 	Capabilities                      = "cloud:capabilities"
 	Certificate                       = "cloud:certificate"
@@ -68,6 +84,7 @@ const (
 	Continent                         = "cloud:continent"
 	Config                            = "cloud:config"
 	Cooldown                          = "cloud:cooldown"
+	Cores                             = "cloud:cores"
 	CopyTagsToSnapshot                = "cloud:copyTagsToSnapshot"
 	ContainersImages                  = "cloud:containersImages"
 	ContainerService                  = "cloud:containerService"
@@ -76,6 +93,7 @@ const (
 	DBSecurityGroups                  = "cloud:dbSecurityGroups"
 	DBSubnetGroup                     = "cloud:dbSubnetGroup"
 	Default                           = "cloud:default"
+	DefaultCacheBehavior              = "cloud:defaultCacheBehavior"
 	DefaultCooldown                   = "cloud:defaultCooldown"
 	Delay                             = "cloud:delaySeconds"
 	Description                       = "cloud:description"
@@ -84,19 +102,23 @@ const (
 	Dimensions                        = "cloud:dimensions"
 	DisableRollback                   = "cloud:disableRollback"
 	DockerVersion                     = "cloud:dockerVersion"
+	DomainName                        = "cloud:domainName"
 	Enabled                           = "cloud:enabled"
 	Encrypted                         = "cloud:encrypted"
 	Endpoint                          = "cloud:endpoint"
 	Engine                            = "cloud:engine"
 	EngineVersion                     = "cloud:engineVersion"
+	Entries                           = "cloud:entries"
 	ExitCode                          = "cloud:exitCode"
 	Failover                          = "cloud:failover"
 	Fingerprint                       = "cloud:fingerprint"
 	GlobalID                          = "cloud:globalID"
 	GranteeType                       = "cloud:granteeType"
 	Grants                            = "cloud:grants"
+	Groups                            = "cloud:groups"
 	Handler                           = "cloud:handler"
 	Hash                              = "cloud:hash"
+	Health                            = "cloud:health"
 	HealthCheck                       = "cloud:healthCheck"
 	HealthCheckType                   = "cloud:healthCheckType"
 	HealthCheckGracePeriod            = "cloud:healthCheckGracePeriod"
@@ -106,6 +128,7 @@ const (
 	Hypervisor                        = "cloud:hypervisor"
 	ID                                = "cloud:id"
 	Image                             = "cloud:image"
+	ImageTags                         = "cloud:imageTags"
 	InboundRules                      = "net:inboundRules"
 	InlinePolicies                    = "cloud:inlinePolicies"
 	Instance                          = "cloud:instance"
@@ -121,9 +144,12 @@ const (
 	LaunchConfigurationName           = "cloud:launchConfigurationName"
 	License                           = "cloud:license"
 	Lifecycle                         = "cloud:lifecycle"
+	LifecyclePolicy                   = "cloud:lifecyclePolicy"
 	LoadBalancer                      = "cloud:loadBalancer"
 	Location                          = "cloud:location"
 	Main                              = "cloud:main"
+	ManagedPolicies                   = "cloud:managedPolicies"
+	MaxEntries                        = "cloud:maxEntries"
 	MaxSize                           = "cloud:maxSize"
 	Memory                            = "cloud:memory"
 	Messages                          = "cloud:messages"
@@ -134,10 +160,13 @@ const (
 	MonitoringRole                    = "cloud:monitoringRole"
 	MultiAZ                           = "cloud:multiAZ"
 	Name                              = "cloud:name"
+	NameServers                       = "cloud:nameServers"
+	NTPServers                        = "cloud:ntpServers"
 	Namespace                         = "cloud:namemespace"
 	NewInstancesProtected             = "cloud:newInstancesProtected"
 	NetworkInterfaces                 = "cloud:networkInterfaces"
 	Notifications                     = "cloud:notifications"
+	Occurrences                       = "cloud:occurrences"
 	OKActions                         = "cloud:okActions"
 	OptionGroups                      = "cloud:optionGroups"
 	OutboundRules                     = "net:outboundRules"
@@ -168,6 +197,10 @@ const (
 	Records                           = "cloud:recordCount"
 	Region                            = "cloud:region"
 	RegisteredContainerInstancesCount = "cloud:registeredContainerInstancesCount"
+	ReplicationRole                   = "cloud:replicationRole"
+	ReplicationRules                  = "cloud:replicationRules"
+	Repository                        = "cloud:repository"
+	ResourcePolicy                    = "cloud:resourcePolicy"
 	Role                              = "cloud:rootDeviceType"
 	RootDevice                        = "cloud:role"
 	RootDeviceType                    = "cloud:rootDevice"
@@ -175,11 +208,21 @@ const (
 	Runtime                           = "cloud:runtime"
 	RunningTasksCount                 = "cloud:runningTasksCount"
 	ScalingAdjustment                 = "cloud:scalingAdjustment"
+	ScanFindings                      = "cloud:scanFindings"
+	ScanOnPush                        = "cloud:scanOnPush"
+	ScanStatus                        = "cloud:scanStatus"
 	Scheme                            = "net:scheme"
 	SecondaryAvailabilityZone         = "cloud:secondaryAvailabilityZone"
 	SecurityGroups                    = "cloud:securityGroups"
+	Severity                          = "cloud:severity"
+	SeverityScore                     = "cloud:severityScore"
 	Set                               = "cloud:set"
+	Shards                            = "cloud:shards"
 	Size                              = "cloud:size"
+	Snapshots                         = "cloud:snapshots"
+	Sockets                           = "cloud:sockets"
+	Source                            = "cloud:source"
+	SpotCapacity                      = "cloud:spotCapacity"
 	SpotInstanceRequestId             = "cloud:spotInstanceRequestId"
 	SpotPrice                         = "cloud:spotPrice"
 	SSLSupportMethod                  = "cloud:sslSupportMethod"
@@ -188,13 +231,16 @@ const (
 	Stopped                           = "cloud:stopped"
 	Storage                           = "cloud:storage"
 	StorageType                       = "cloud:storageType"
+	Strategy                          = "cloud:strategy"
 	Subnet                            = "cloud:subnet"
 	Subnets                           = "cloud:subnets"
 	Tags                              = "cloud:tags"
+	TargetCapacity                    = "cloud:targetCapacity"
 	Timeout                           = "cloud:timezone"
 	Timezone                          = "cloud:timeout"
 	TLSVersionRequired                = "cloud:tlsVersionRequired"
 	Topic                             = "cloud:topic"
+	TotalCapacity                     = "cloud:totalCapacity"
 	TrafficPolicyInstance             = "cloud:trafficPolicyInstance"
 	TTL                               = "cloud:ttl"
 	Type                              = "cloud:type"
@@ -203,12 +249,14 @@ const (
 	UserData                          = "cloud:userData"
 	Username                          = "cloud:username"
 	URI                               = "cloud:uri"
+	Utilization                       = "cloud:utilization"
 	Value                             = "cloud:value"
 	Version                           = "cloud:version"
 	Virtualization                    = "cloud:virtualization"
 	Volume                            = "cloud:volume"
 	Vpc                               = "cloud:vpc"
 	Vpcs                              = "cloud:vpcs"
+	Vulnerabilities                   = "cloud:vulnerabilities"
 	Weight                            = "cloud:weight"
 	WebACL                            = "cloud:webACL"
 	Zone                              = "cloud:zone"
@@ -220,7 +268,9 @@ var Labels = map[string]string{
 	properties.ActionsEnabled:                    ActionsEnabled,
 	properties.ActiveServicesCount:               ActiveServicesCount,
 	properties.ACMCertificate:                    ACMCertificate,
+	properties.ActivityStatus:                    ActivityStatus,
 	properties.AdjustmentType:                    AdjustmentType,
+	properties.AffectedResource:                  AffectedResource,
 	properties.Affinity:                          Affinity,
 	properties.AgentConnected:                    AgentConnected,
 	properties.AgentState:                        AgentState,
@@ -235,11 +285,25 @@ var Labels = map[string]string{
 	properties.Attachable:                        Attachable,
 	properties.Attributes:                        Attributes,
 	properties.AutoUpgrade:                       AutoUpgrade,
+	properties.BufferInterval:                    BufferInterval,
+	properties.BufferSize:                        BufferSize,
+	properties.Consumers:                         Consumers,
+	properties.Destination:                       Destination,
+	properties.DestinationType:                   DestinationType,
+	properties.Encryption:                        Encryption,
+	properties.FulfilledCapacity:                 FulfilledCapacity,
+	properties.Kind:                              Kind,
+	properties.Mode:                              Mode,
+	properties.OnDemandCapacity:                  OnDemandCapacity,
+	properties.RetentionPeriod:                   RetentionPeriod,
 	properties.ScalingGroupName:                  ScalingGroupName,
 	properties.AvailabilityZone:                  AvailabilityZone,
 	properties.AvailabilityZones:                 AvailabilityZones,
+	properties.AvailableCapacity:                 AvailableCapacity,
+	properties.FreeIPs:                           FreeIPs,
 	properties.BackupRetentionPeriod:             BackupRetentionPeriod,
 	properties.Bucket:                            Bucket,
+	properties.CacheBehaviors:                    CacheBehaviors,
 	properties.CallerReference:                   CallerReference,
 	properties.Capabilities:                      Capabilities,
 	properties.Certificate:                       Certificate,
@@ -263,6 +327,7 @@ var Labels = map[string]string{
 	properties.Continent:                         Continent,
 	properties.Config:                            Config,
 	properties.Cooldown:                          Cooldown,
+	properties.Cores:                             Cores,
 	properties.CopyTagsToSnapshot:                CopyTagsToSnapshot,
 	properties.ContainersImages:                  ContainersImages,
 	properties.ContainerService:                  ContainerService,
@@ -271,6 +336,7 @@ var Labels = map[string]string{
 	properties.DBSecurityGroups:                  DBSecurityGroups,
 	properties.DBSubnetGroup:                     DBSubnetGroup,
 	properties.Default:                           Default,
+	properties.DefaultCacheBehavior:              DefaultCacheBehavior,
 	properties.DefaultCooldown:                   DefaultCooldown,
 	properties.Delay:                             Delay,
 	properties.Description:                       Description,
@@ -279,19 +345,23 @@ var Labels = map[string]string{
 	properties.Dimensions:                        Dimensions,
 	properties.DisableRollback:                   DisableRollback,
 	properties.DockerVersion:                     DockerVersion,
+	properties.DomainName:                        DomainName,
 	properties.Enabled:                           Enabled,
 	properties.Encrypted:                         Encrypted,
 	properties.Endpoint:                          Endpoint,
 	properties.Engine:                            Engine,
 	properties.EngineVersion:                     EngineVersion,
+	properties.Entries:                           Entries,
 	properties.ExitCode:                          ExitCode,
 	properties.Failover:                          Failover,
 	properties.Fingerprint:                       Fingerprint,
 	properties.GlobalID:                          GlobalID,
 	properties.GranteeType:                       GranteeType,
 	properties.Grants:                            Grants,
+	properties.Groups:                            Groups,
 	properties.Handler:                           Handler,
 	properties.Hash:                              Hash,
+	properties.Health:                            Health,
 	properties.HealthCheck:                       HealthCheck,
 	properties.HealthCheckType:                   HealthCheckType,
 	properties.HealthCheckGracePeriod:            HealthCheckGracePeriod,
@@ -301,6 +371,7 @@ var Labels = map[string]string{
 	properties.Hypervisor:                        Hypervisor,
 	properties.ID:                                ID,
 	properties.Image:                             Image,
+	properties.ImageTags:                         ImageTags,
 	properties.InboundRules:                      InboundRules,
 	properties.InlinePolicies:                    InlinePolicies,
 	properties.Instance:                          Instance,
@@ -316,9 +387,12 @@ var Labels = map[string]string{
 	properties.LaunchConfigurationName:           LaunchConfigurationName,
 	properties.License:                           License,
 	properties.Lifecycle:                         Lifecycle,
+	properties.LifecyclePolicy:                   LifecyclePolicy,
 	properties.LoadBalancer:                      LoadBalancer,
 	properties.Location:                          Location,
 	properties.Main:                              Main,
+	properties.ManagedPolicies:                   ManagedPolicies,
+	properties.MaxEntries:                        MaxEntries,
 	properties.MaxSize:                           MaxSize,
 	properties.Memory:                            Memory,
 	properties.Messages:                          Messages,
@@ -329,10 +403,13 @@ var Labels = map[string]string{
 	properties.MonitoringRole:                    MonitoringRole,
 	properties.MultiAZ:                           MultiAZ,
 	properties.Name:                              Name,
+	properties.NameServers:                       NameServers,
+	properties.NTPServers:                        NTPServers,
 	properties.Namespace:                         Namespace,
 	properties.NewInstancesProtected:             NewInstancesProtected,
 	properties.NetworkInterfaces:                 NetworkInterfaces,
 	properties.Notifications:                     Notifications,
+	properties.Occurrences:                       Occurrences,
 	properties.OKActions:                         OKActions,
 	properties.OptionGroups:                      OptionGroups,
 	properties.OutboundRules:                     OutboundRules,
@@ -363,6 +440,10 @@ var Labels = map[string]string{
 	properties.Records:                           Records,
 	properties.Region:                            Region,
 	properties.RegisteredContainerInstancesCount: RegisteredContainerInstancesCount,
+	properties.ReplicationRole:                   ReplicationRole,
+	properties.ReplicationRules:                  ReplicationRules,
+	properties.Repository:                        Repository,
+	properties.ResourcePolicy:                    ResourcePolicy,
 	properties.Role:                              Role,
 	properties.RootDevice:                        RootDevice,
 	properties.RootDeviceType:                    RootDeviceType,
@@ -370,11 +451,21 @@ var Labels = map[string]string{
 	properties.Runtime:                           Runtime,
 	properties.RunningTasksCount:                 RunningTasksCount,
 	properties.ScalingAdjustment:                 ScalingAdjustment,
+	properties.ScanFindings:                      ScanFindings,
+	properties.ScanOnPush:                        ScanOnPush,
+	properties.ScanStatus:                        ScanStatus,
 	properties.Scheme:                            Scheme,
 	properties.SecondaryAvailabilityZone:         SecondaryAvailabilityZone,
 	properties.SecurityGroups:                    SecurityGroups,
+	properties.Severity:                          Severity,
+	properties.SeverityScore:                     SeverityScore,
 	properties.Set:                               Set,
+	properties.Shards:                            Shards,
 	properties.Size:                              Size,
+	properties.Snapshots:                         Snapshots,
+	properties.Sockets:                           Sockets,
+	properties.Source:                            Source,
+	properties.SpotCapacity:                      SpotCapacity,
 	properties.SpotInstanceRequestId:             SpotInstanceRequestId,
 	properties.SpotPrice:                         SpotPrice,
 	properties.SSLSupportMethod:                  SSLSupportMethod,
@@ -383,13 +474,16 @@ var Labels = map[string]string{
 	properties.Stopped:                           Stopped,
 	properties.Storage:                           Storage,
 	properties.StorageType:                       StorageType,
+	properties.Strategy:                          Strategy,
 	properties.Subnet:                            Subnet,
 	properties.Subnets:                           Subnets,
 	properties.Tags:                              Tags,
+	properties.TargetCapacity:                    TargetCapacity,
 	properties.Timeout:                           Timeout,
 	properties.Timezone:                          Timezone,
 	properties.TLSVersionRequired:                TLSVersionRequired,
 	properties.Topic:                             Topic,
+	properties.TotalCapacity:                     TotalCapacity,
 	properties.TrafficPolicyInstance:             TrafficPolicyInstance,
 	properties.TTL:                               TTL,
 	properties.Type:                              Type,
@@ -398,12 +492,14 @@ var Labels = map[string]string{
 	properties.UserData:                          UserData,
 	properties.Username:                          Username,
 	properties.URI:                               URI,
+	properties.Utilization:                       Utilization,
 	properties.Value:                             Value,
 	properties.Version:                           Version,
 	properties.Virtualization:                    Virtualization,
 	properties.Volume:                            Volume,
 	properties.Vpc:                               Vpc,
 	properties.Vpcs:                              Vpcs,
+	properties.Vulnerabilities:                   Vulnerabilities,
 	properties.Weight:                            Weight,
 	properties.WebACL:                            WebACL,
 	properties.Zone:                              Zone,
@@ -415,7 +511,9 @@ var Properties = RDFProperties{
 	ActionsEnabled:          {ID: ActionsEnabled, RdfType: "rdf:Property", RdfsLabel: "ActionsEnabled", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:boolean"},
 	ActiveServicesCount:     {ID: ActiveServicesCount, RdfType: "rdf:Property", RdfsLabel: "ActiveServicesCount", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	ACMCertificate:          {ID: ACMCertificate, RdfType: "rdf:Property", RdfsLabel: "ACMCertificate", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
+	ActivityStatus:                    {ID: ActivityStatus, RdfType: "rdf:Property", RdfsLabel: "ActivityStatus", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	AdjustmentType:          {ID: AdjustmentType, RdfType: "rdf:Property", RdfsLabel: "AdjustmentType", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
+	AffectedResource:                  {ID: AffectedResource, RdfType: "rdf:Property", RdfsLabel: "AffectedResource", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Affinity:                {ID: Affinity, RdfType: "rdf:Property", RdfsLabel: "Affinity", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	AgentConnected:          {ID: AgentConnected, RdfType: "rdf:Property", RdfsLabel: "AgentConnected", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:boolean"},
 	AgentState:              {ID: AgentState, RdfType: "rdf:Property", RdfsLabel: "AgentState", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
@@ -430,11 +528,25 @@ var Properties = RDFProperties{
 	Attachable:              {ID: Attachable, RdfType: "rdf:Property", RdfsLabel: "Attachable", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:boolean"},
 	Attributes:              {ID: Attributes, RdfType: "rdf:Property", RdfsLabel: "Attributes", RdfsDefinedBy: "rdfs:list", RdfsDataType: "cloud-owl:KeyValue"},
 	AutoUpgrade:             {ID: AutoUpgrade, RdfType: "rdf:Property", RdfsLabel: "AutoUpgrade", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:boolean"},
+	BufferInterval:                    {ID: BufferInterval, RdfType: "rdf:Property", RdfsLabel: "BufferInterval", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
+	BufferSize:                        {ID: BufferSize, RdfType: "rdf:Property", RdfsLabel: "BufferSize", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
+	Consumers:                         {ID: Consumers, RdfType: "rdf:Property", RdfsLabel: "Consumers", RdfsDefinedBy: "rdfs:list", RdfsDataType: "rdfs:Class"},
+	Destination:                       {ID: Destination, RdfType: "rdf:Property", RdfsLabel: "Destination", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
+	DestinationType:                   {ID: DestinationType, RdfType: "rdf:Property", RdfsLabel: "DestinationType", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
+	Encryption:                        {ID: Encryption, RdfType: "rdf:Property", RdfsLabel: "Encryption", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
+	FulfilledCapacity:                 {ID: FulfilledCapacity, RdfType: "rdf:Property", RdfsLabel: "FulfilledCapacity", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
+	Kind:                              {ID: Kind, RdfType: "rdf:Property", RdfsLabel: "Kind", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
+	Mode:                              {ID: Mode, RdfType: "rdf:Property", RdfsLabel: "Mode", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
+	OnDemandCapacity:                  {ID: OnDemandCapacity, RdfType: "rdf:Property", RdfsLabel: "OnDemandCapacity", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
+	RetentionPeriod:                   {ID: RetentionPeriod, RdfType: "rdf:Property", RdfsLabel: "RetentionPeriod", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	ScalingGroupName:        {ID: ScalingGroupName, RdfType: "rdf:Property", RdfsLabel: "ScalingGroupName", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	AvailabilityZone:        {ID: AvailabilityZone, RdfType: "rdf:Property", RdfsLabel: "AvailabilityZone", RdfsDefinedBy: "rdfs:Class", RdfsDataType: "xsd:string"},
 	AvailabilityZones:       {ID: AvailabilityZones, RdfType: "rdf:Property", RdfsLabel: "AvailabilityZones", RdfsDefinedBy: "rdfs:list", RdfsDataType: "rdfs:Class"},
+	AvailableCapacity:                 {ID: AvailableCapacity, RdfType: "rdf:Property", RdfsLabel: "AvailableCapacity", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
+	FreeIPs:                           {ID: FreeIPs, RdfType: "rdf:Property", RdfsLabel: "FreeIPs", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	BackupRetentionPeriod:   {ID: BackupRetentionPeriod, RdfType: "rdf:Property", RdfsLabel: "BackupRetentionPeriod", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:dateTime"},
 	Bucket:                  {ID: Bucket, RdfType: "rdf:Property", RdfsLabel: "Bucket", RdfsDefinedBy: "rdfs:Class", RdfsDataType: "xsd:string"},
+	CacheBehaviors:                    {ID: CacheBehaviors, RdfType: "rdf:Property", RdfsLabel: "CacheBehaviors", RdfsDefinedBy: "rdfs:list", RdfsDataType: "xsd:string"},
 	CallerReference:         {ID: CallerReference, RdfType: "rdf:Property", RdfsLabel: "CallerReference", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Capabilities:            {ID: Capabilities, RdfType: "rdf:Property", RdfsLabel: "Capabilities", RdfsDefinedBy: "rdfs:list", RdfsDataType: "xsd:string"},
 	Certificate:             {ID: Certificate, RdfType: "rdf:Property", RdfsLabel: "Certificate", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
@@ -458,6 +570,7 @@ var Properties = RDFProperties{
 	Continent:               {ID: Continent, RdfType: "rdf:Property", RdfsLabel: "Continent", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Config:                  {ID: Config, RdfType: "rdf:Property", RdfsLabel: "Config", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Cooldown:                {ID: Cooldown, RdfType: "rdf:Property", RdfsLabel: "Cooldown", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
+	Cores:                             {ID: Cores, RdfType: "rdf:Property", RdfsLabel: "Cores", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	CopyTagsToSnapshot:      {ID: CopyTagsToSnapshot, RdfType: "rdf:Property", RdfsLabel: "CopyTagsToSnapshot", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	ContainersImages:        {ID: ContainersImages, RdfType: "rdf:Property", RdfsLabel: "ContainersImages", RdfsDefinedBy: "rdfs:list", RdfsDataType: "xsd:string"},
 	ContainerService:        {ID: ContainerService, RdfType: "rdf:Property", RdfsLabel: "ContainerService", RdfsDefinedBy: "rdfs:Class", RdfsDataType: "xsd:string"},
@@ -466,6 +579,7 @@ var Properties = RDFProperties{
 	DBSecurityGroups:        {ID: DBSecurityGroups, RdfType: "rdf:Property", RdfsLabel: "DBSecurityGroups", RdfsDefinedBy: "rdfs:list", RdfsDataType: "xsd:string"},
 	DBSubnetGroup:           {ID: DBSubnetGroup, RdfType: "rdf:Property", RdfsLabel: "DBSubnetGroup", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Default:                 {ID: Default, RdfType: "rdf:Property", RdfsLabel: "Default", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:boolean"},
+	DefaultCacheBehavior:              {ID: DefaultCacheBehavior, RdfType: "rdf:Property", RdfsLabel: "DefaultCacheBehavior", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	DefaultCooldown:         {ID: DefaultCooldown, RdfType: "rdf:Property", RdfsLabel: "DefaultCooldown", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	Delay:                   {ID: Delay, RdfType: "rdf:Property", RdfsLabel: "Delay", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	Description:             {ID: Description, RdfType: "rdf:Property", RdfsLabel: "Description", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
@@ -474,19 +588,23 @@ var Properties = RDFProperties{
 	Dimensions:              {ID: Dimensions, RdfType: "rdf:Property", RdfsLabel: "Dimensions", RdfsDefinedBy: "rdfs:list", RdfsDataType: "cloud-owl:KeyValue"},
 	DisableRollback:         {ID: DisableRollback, RdfType: "rdf:Property", RdfsLabel: "DisableRollback", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:boolean"},
 	DockerVersion:           {ID: DockerVersion, RdfType: "rdf:Property", RdfsLabel: "DockerVersion", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
+	DomainName:                        {ID: DomainName, RdfType: "rdf:Property", RdfsLabel: "DomainName", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Enabled:                 {ID: Enabled, RdfType: "rdf:Property", RdfsLabel: "Enabled", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:boolean"},
 	Encrypted:               {ID: Encrypted, RdfType: "rdf:Property", RdfsLabel: "Encrypted", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:boolean"},
 	Endpoint:                {ID: Endpoint, RdfType: "rdf:Property", RdfsLabel: "Endpoint", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Engine:                  {ID: Engine, RdfType: "rdf:Property", RdfsLabel: "Engine", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	EngineVersion:           {ID: EngineVersion, RdfType: "rdf:Property", RdfsLabel: "EngineVersion", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
+	Entries:                           {ID: Entries, RdfType: "rdf:Property", RdfsLabel: "Entries", RdfsDefinedBy: "rdfs:list", RdfsDataType: "xsd:string"},
 	ExitCode:                {ID: ExitCode, RdfType: "rdf:Property", RdfsLabel: "ExitCode", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	Failover:                {ID: Failover, RdfType: "rdf:Property", RdfsLabel: "Failover", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Fingerprint:             {ID: Fingerprint, RdfType: "rdf:Property", RdfsLabel: "Fingerprint", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	GlobalID:                {ID: GlobalID, RdfType: "rdf:Property", RdfsLabel: "GlobalID", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	GranteeType:             {ID: GranteeType, RdfType: "rdf:Property", RdfsLabel: "GranteeType", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Grants:                  {ID: Grants, RdfType: "rdf:Property", RdfsLabel: "Grants", RdfsDefinedBy: "rdfs:list", RdfsDataType: "cloud-owl:Grant"},
+	Groups:                            {ID: Groups, RdfType: "rdf:Property", RdfsLabel: "Groups", RdfsDefinedBy: "rdfs:list", RdfsDataType: "xsd:string"},
 	Handler:                 {ID: Handler, RdfType: "rdf:Property", RdfsLabel: "Handler", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Hash:                    {ID: Hash, RdfType: "rdf:Property", RdfsLabel: "Hash", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
+	Health:                            {ID: Health, RdfType: "rdf:Property", RdfsLabel: "Health", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	HealthCheck:             {ID: HealthCheck, RdfType: "rdf:Property", RdfsLabel: "HealthCheck", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	HealthCheckType:         {ID: HealthCheckType, RdfType: "rdf:Property", RdfsLabel: "HealthCheckType", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	HealthCheckGracePeriod:  {ID: HealthCheckGracePeriod, RdfType: "rdf:Property", RdfsLabel: "HealthCheckGracePeriod", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
@@ -496,6 +614,7 @@ var Properties = RDFProperties{
 	Hypervisor:              {ID: Hypervisor, RdfType: "rdf:Property", RdfsLabel: "Hypervisor", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	ID:                      {ID: ID, RdfType: "rdf:Property", RdfsLabel: "ID", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Image:                   {ID: Image, RdfType: "rdf:Property", RdfsLabel: "Image", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
+	ImageTags:                         {ID: ImageTags, RdfType: "rdf:Property", RdfsLabel: "ImageTags", RdfsDefinedBy: "rdfs:list", RdfsDataType: "xsd:string"},
 	InboundRules:            {ID: InboundRules, RdfType: "rdf:Property", RdfsLabel: "InboundRules", RdfsDefinedBy: "rdfs:list", RdfsDataType: "net-owl:FirewallRule"},
 	InlinePolicies:          {ID: InlinePolicies, RdfType: "rdf:Property", RdfsLabel: "InlinePolicies", RdfsDefinedBy: "rdfs:list", RdfsDataType: "rdfs:Class"},
 	Instance:                {ID: Instance, RdfType: "rdf:Property", RdfsLabel: "Instance", RdfsDefinedBy: "rdfs:Class", RdfsDataType: "xsd:string"},
@@ -511,9 +630,12 @@ var Properties = RDFProperties{
 	LaunchConfigurationName:  {ID: LaunchConfigurationName, RdfType: "rdf:Property", RdfsLabel: "LaunchConfigurationName", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	License:                  {ID: License, RdfType: "rdf:Property", RdfsLabel: "License", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Lifecycle:                {ID: Lifecycle, RdfType: "rdf:Property", RdfsLabel: "Lifecycle", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
+	LifecyclePolicy:                   {ID: LifecyclePolicy, RdfType: "rdf:Property", RdfsLabel: "LifecyclePolicy", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	LoadBalancer:             {ID: LoadBalancer, RdfType: "rdf:Property", RdfsLabel: "LoadBalancer", RdfsDefinedBy: "rdfs:Class", RdfsDataType: "xsd:string"},
 	Location:                 {ID: Location, RdfType: "rdf:Property", RdfsLabel: "Location", RdfsDefinedBy: "rdfs:Class", RdfsDataType: "xsd:string"},
 	Main:                     {ID: Main, RdfType: "rdf:Property", RdfsLabel: "Main", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:boolean"},
+	ManagedPolicies:                   {ID: ManagedPolicies, RdfType: "rdf:Property", RdfsLabel: "ManagedPolicies", RdfsDefinedBy: "rdfs:list", RdfsDataType: "xsd:string"},
+	MaxEntries:                        {ID: MaxEntries, RdfType: "rdf:Property", RdfsLabel: "MaxEntries", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	MaxSize:                  {ID: MaxSize, RdfType: "rdf:Property", RdfsLabel: "MaxSize", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	Memory:                   {ID: Memory, RdfType: "rdf:Property", RdfsLabel: "Memory", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	Messages:                 {ID: Messages, RdfType: "rdf:Property", RdfsLabel: "Messages", RdfsDefinedBy: "rdfs:list", RdfsDataType: "xsd:string"},
@@ -524,10 +646,13 @@ var Properties = RDFProperties{
 	MonitoringRole:           {ID: MonitoringRole, RdfType: "rdf:Property", RdfsLabel: "MonitoringRole", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	MultiAZ:                  {ID: MultiAZ, RdfType: "rdf:Property", RdfsLabel: "MultiAZ", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Name:                     {ID: Name, RdfType: "rdf:Property", RdfsLabel: "Name", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
+	NameServers:                       {ID: NameServers, RdfType: "rdf:Property", RdfsLabel: "NameServers", RdfsDefinedBy: "rdfs:list", RdfsDataType: "xsd:string"},
+	NTPServers:                        {ID: NTPServers, RdfType: "rdf:Property", RdfsLabel: "NTPServers", RdfsDefinedBy: "rdfs:list", RdfsDataType: "xsd:string"},
 	Namespace:                {ID: Namespace, RdfType: "rdf:Property", RdfsLabel: "Namespace", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	NewInstancesProtected:    {ID: NewInstancesProtected, RdfType: "rdf:Property", RdfsLabel: "NewInstancesProtected", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:boolean"},
 	NetworkInterfaces:        {ID: NetworkInterfaces, RdfType: "rdf:Property", RdfsLabel: "NetworkInterfaces", RdfsDefinedBy: "rdfs:list", RdfsDataType: "xsd:string"},
 	Notifications:            {ID: Notifications, RdfType: "rdf:Property", RdfsLabel: "Notifications", RdfsDefinedBy: "rdfs:list", RdfsDataType: "rdfs:Class"},
+	Occurrences:                       {ID: Occurrences, RdfType: "rdf:Property", RdfsLabel: "Occurrences", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	OKActions:                {ID: OKActions, RdfType: "rdf:Property", RdfsLabel: "OKActions", RdfsDefinedBy: "rdfs:list", RdfsDataType: "xsd:string"},
 	OptionGroups:             {ID: OptionGroups, RdfType: "rdf:Property", RdfsLabel: "OptionGroups", RdfsDefinedBy: "rdfs:list", RdfsDataType: "xsd:string"},
 	OutboundRules:            {ID: OutboundRules, RdfType: "rdf:Property", RdfsLabel: "OutboundRules", RdfsDefinedBy: "rdfs:list", RdfsDataType: "net-owl:FirewallRule"},
@@ -558,6 +683,10 @@ var Properties = RDFProperties{
 	Records:                  {ID: Records, RdfType: "rdf:Property", RdfsLabel: "Records", RdfsDefinedBy: "rdfs:list", RdfsDataType: "xsd:string"},
 	Region:                   {ID: Region, RdfType: "rdf:Property", RdfsLabel: "Region", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	RegisteredContainerInstancesCount: {ID: RegisteredContainerInstancesCount, RdfType: "rdf:Property", RdfsLabel: "RegisteredContainerInstancesCount", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
+	ReplicationRole:                   {ID: ReplicationRole, RdfType: "rdf:Property", RdfsLabel: "ReplicationRole", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
+	ReplicationRules:                  {ID: ReplicationRules, RdfType: "rdf:Property", RdfsLabel: "ReplicationRules", RdfsDefinedBy: "rdfs:list", RdfsDataType: "cloud-owl:ReplicationRule"},
+	Repository:                        {ID: Repository, RdfType: "rdf:Property", RdfsLabel: "Repository", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
+	ResourcePolicy:                    {ID: ResourcePolicy, RdfType: "rdf:Property", RdfsLabel: "ResourcePolicy", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Role:              {ID: Role, RdfType: "rdf:Property", RdfsLabel: "Role", RdfsDefinedBy: "rdfs:Class", RdfsDataType: "xsd:string"},
 	RootDevice:        {ID: RootDevice, RdfType: "rdf:Property", RdfsLabel: "RootDevice", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	RootDeviceType:    {ID: RootDeviceType, RdfType: "rdf:Property", RdfsLabel: "RootDeviceType", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
@@ -565,11 +694,21 @@ var Properties = RDFProperties{
 	Runtime:           {ID: Runtime, RdfType: "rdf:Property", RdfsLabel: "Runtime", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	RunningTasksCount: {ID: RunningTasksCount, RdfType: "rdf:Property", RdfsLabel: "RunningTasksCount", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	ScalingAdjustment: {ID: ScalingAdjustment, RdfType: "rdf:Property", RdfsLabel: "ScalingAdjustment", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
+	ScanFindings:                      {ID: ScanFindings, RdfType: "rdf:Property", RdfsLabel: "ScanFindings", RdfsDefinedBy: "rdfs:list", RdfsDataType: "xsd:string"},
+	ScanOnPush:                        {ID: ScanOnPush, RdfType: "rdf:Property", RdfsLabel: "ScanOnPush", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:boolean"},
+	ScanStatus:                        {ID: ScanStatus, RdfType: "rdf:Property", RdfsLabel: "ScanStatus", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Scheme:            {ID: Scheme, RdfType: "rdf:Property", RdfsLabel: "Scheme", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	SecondaryAvailabilityZone: {ID: SecondaryAvailabilityZone, RdfType: "rdf:Property", RdfsLabel: "SecondaryAvailabilityZone", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	SecurityGroups:            {ID: SecurityGroups, RdfType: "rdf:Property", RdfsLabel: "SecurityGroups", RdfsDefinedBy: "rdfs:list", RdfsDataType: "rdfs:Class"},
+	Severity:                          {ID: Severity, RdfType: "rdf:Property", RdfsLabel: "Severity", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
+	SeverityScore:                     {ID: SeverityScore, RdfType: "rdf:Property", RdfsLabel: "SeverityScore", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Set:                       {ID: Set, RdfType: "rdf:Property", RdfsLabel: "Set", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
+	Shards:                            {ID: Shards, RdfType: "rdf:Property", RdfsLabel: "Shards", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	Size:                      {ID: Size, RdfType: "rdf:Property", RdfsLabel: "Size", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
+	Snapshots:                 {ID: Snapshots, RdfType: "rdf:Property", RdfsLabel: "Snapshots", RdfsDefinedBy: "rdfs:list", RdfsDataType: "rdfs:Class"},
+	Sockets:                           {ID: Sockets, RdfType: "rdf:Property", RdfsLabel: "Sockets", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
+	Source:                            {ID: Source, RdfType: "rdf:Property", RdfsLabel: "Source", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
+	SpotCapacity:                      {ID: SpotCapacity, RdfType: "rdf:Property", RdfsLabel: "SpotCapacity", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	SpotInstanceRequestId: {ID: SpotInstanceRequestId, RdfType: "rdf:Property", RdfsLabel: "SpotInstanceRequestId", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	SpotPrice:             {ID: SpotPrice, RdfType: "rdf:Property", RdfsLabel: "SpotPrice", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	SSLSupportMethod:      {ID: SSLSupportMethod, RdfType: "rdf:Property", RdfsLabel: "SSLSupportMethod", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
@@ -578,13 +717,16 @@ var Properties = RDFProperties{
 	Stopped:               {ID: Stopped, RdfType: "rdf:Property", RdfsLabel: "Stopped", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:dateTime"},
 	Storage:               {ID: Storage, RdfType: "rdf:Property", RdfsLabel: "Storage", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	StorageType:           {ID: StorageType, RdfType: "rdf:Property", RdfsLabel: "StorageType", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
+	Strategy:                          {ID: Strategy, RdfType: "rdf:Property", RdfsLabel: "Strategy", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Subnet:                {ID: Subnet, RdfType: "rdf:Property", RdfsLabel: "Subnet", RdfsDefinedBy: "rdfs:Class", RdfsDataType: "xsd:string"},
 	Subnets:               {ID: Subnets, RdfType: "rdf:Property", RdfsLabel: "Subnets", RdfsDefinedBy: "rdfs:list", RdfsDataType: "rdfs:Class"},
 	Tags:                  {ID: Tags, RdfType: "rdf:Property", RdfsLabel: "Tags", RdfsDefinedBy: "rdfs:list", RdfsDataType: "xsd:string"},
+	TargetCapacity:                    {ID: TargetCapacity, RdfType: "rdf:Property", RdfsLabel: "TargetCapacity", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	Timeout:               {ID: Timeout, RdfType: "rdf:Property", RdfsLabel: "Timeout", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	Timezone:              {ID: Timezone, RdfType: "rdf:Property", RdfsLabel: "Timezone", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	TLSVersionRequired:    {ID: TLSVersionRequired, RdfType: "rdf:Property", RdfsLabel: "TLSVersionRequired", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Topic:                 {ID: Topic, RdfType: "rdf:Property", RdfsLabel: "Topic", RdfsDefinedBy: "rdfs:Class", RdfsDataType: "xsd:string"},
+	TotalCapacity:                     {ID: TotalCapacity, RdfType: "rdf:Property", RdfsLabel: "TotalCapacity", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	TrafficPolicyInstance: {ID: TrafficPolicyInstance, RdfType: "rdf:Property", RdfsLabel: "TrafficPolicyInstance", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	TTL:  {ID: TTL, RdfType: "rdf:Property", RdfsLabel: "TTL", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	Type: {ID: Type, RdfType: "rdf:Property", RdfsLabel: "Type", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
@@ -593,12 +735,14 @@ var Properties = RDFProperties{
 	UserData:                {ID: UserData, RdfType: "rdf:Property", RdfsLabel: "UserData", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Username:                {ID: Username, RdfType: "rdf:Property", RdfsLabel: "Username", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	URI:                     {ID: URI, RdfType: "rdf:Property", RdfsLabel: "URI", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
+	Utilization:                       {ID: Utilization, RdfType: "rdf:Property", RdfsLabel: "Utilization", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	Value:                   {ID: Value, RdfType: "rdf:Property", RdfsLabel: "Value", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Version:                 {ID: Version, RdfType: "rdf:Property", RdfsLabel: "Version", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Virtualization:          {ID: Virtualization, RdfType: "rdf:Property", RdfsLabel: "Virtualization", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Volume:                  {ID: Volume, RdfType: "rdf:Property", RdfsLabel: "Volume", RdfsDefinedBy: "rdfs:Class", RdfsDataType: "xsd:string"},
 	Vpc:                     {ID: Vpc, RdfType: "rdf:Property", RdfsLabel: "Vpc", RdfsDefinedBy: "rdfs:Class", RdfsDataType: "xsd:string"},
 	Vpcs:                    {ID: Vpcs, RdfType: "rdf:Property", RdfsLabel: "Vpcs", RdfsDefinedBy: "rdfs:list", RdfsDataType: "rdfs:Class"},
+	Vulnerabilities:                   {ID: Vulnerabilities, RdfType: "rdf:Property", RdfsLabel: "Vulnerabilities", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:int"},
 	Weight:                  {ID: Weight, RdfType: "rdf:Property", RdfsLabel: "Weight", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	WebACL:                  {ID: WebACL, RdfType: "rdf:Property", RdfsLabel: "WebACL", RdfsDefinedBy: "rdfs:Literal", RdfsDataType: "xsd:string"},
 	Zone:                    {ID: Zone, RdfType: "rdf:Property", RdfsLabel: "Zone", RdfsDefinedBy: "rdfs:Class", RdfsDataType: "xsd:string"},
@@ -44,12 +44,16 @@ var (
 	CloudGrantee       = fmt.Sprintf("%s:Grantee", CloudOwlNS)
 	KeyValue           = fmt.Sprintf("%s:KeyValue", CloudOwlNS)
 	DistributionOrigin = fmt.Sprintf("%s:DistributionOrigin", CloudOwlNS)
+	ReplicationRule    = fmt.Sprintf("%s:ReplicationRule", CloudOwlNS)
 
 	Permission = fmt.Sprintf("%s:permission", CloudNS)
 	Grantee    = fmt.Sprintf("%s:grantee", CloudNS)
 
 	NetRouteTargets          = fmt.Sprintf("%s:routeTargets", NetNS)
 	NetDestinationPrefixList = fmt.Sprintf("%s:routeDestinationPrefixList", NetNS)
+	NetRuleNumber            = fmt.Sprintf("%s:ruleNumber", NetNS)
+	NetRuleAction            = fmt.Sprintf("%s:ruleAction", NetNS)
+	NetRulePrefixList        = fmt.Sprintf("%s:rulePrefixList", NetNS)
 )
 
 // Relations
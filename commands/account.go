@@ -0,0 +1,209 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/database"
+	"github.com/wallix/awless/logger"
+)
+
+const accountSummaryCacheTTL = time.Hour
+
+var showAccountRefreshFlag bool
+
+func init() {
+	showAccountCmd.Flags().BoolVar(&showAccountRefreshFlag, "refresh", false, "Fetch the account settings instead of using the cached ones")
+	outputFormatFlag(showAccountCmd.Flags(), &listingFormat, "table", "json")
+	showCmd.AddCommand(showAccountCmd)
+}
+
+var showAccountCmd = &cobra.Command{
+	Use:     "account",
+	Short:   "Show the account-level settings: id, alias, organization, EBS encryption by default, S3 block public access, password policy and enabled regions",
+	Long:    fmt.Sprintf("Show at a glance the security and configuration posture of the account: id and alias, organization membership, EBS encryption by default (in the current region), S3 block public access, IAM password policy and enabled regions.\n\nThe settings are cached locally for %s.", accountSummaryCacheTTL),
+	Example: "  awless show account\n  awless show account --refresh --format json",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		summary, err := accountSettings(config.GetAWSProfile(), config.GetAWSRegion(), showAccountRefreshFlag)
+		exitOn(err)
+		exitOn(printAccountSummary(os.Stdout, summary))
+	},
+}
+
+type accountSummary struct {
+	Time                   time.Time                `json:"time"`
+	Account                string                   `json:"account"`
+	Aliases                []string                 `json:"aliases,omitempty"`
+	Organization           *aws.AccountOrganization `json:"organization,omitempty"`
+	Region                 string                   `json:"region"`
+	EBSEncryptionByDefault bool                     `json:"ebsEncryptionByDefault"`
+	S3PublicAccessBlock    *aws.S3PublicAccessBlock `json:"s3PublicAccessBlock,omitempty"`
+	PasswordPolicy         *aws.PasswordPolicy      `json:"passwordPolicy,omitempty"`
+	EnabledRegions         []string                 `json:"enabledRegions,omitempty"`
+	Errors                 map[string]string        `json:"errors,omitempty"`
+}
+
+// accountSettings returns the account-level settings of the profile, from the local cache
+// when fresh enough, or fetched from each service otherwise. Settings that could not be
+// fetched are reported in Errors and the summary is then not cached
+func accountSettings(profile, region string, refresh bool) (*accountSummary, error) {
+	key := fmt.Sprintf("account.summary.%s.%s", profile, region)
+
+	if !refresh {
+		cache := &accountSummary{}
+		if err := database.Execute(func(db *database.DB) error {
+			b, err := db.GetBytes(key)
+			if err != nil || len(b) == 0 {
+				return err
+			}
+			return json.Unmarshal(b, cache)
+		}); err != nil {
+			logger.Verbosef("cannot read cached account settings: %s", err)
+		}
+		if cache.Account != "" && time.Since(cache.Time) < accountSummaryCacheTTL {
+			logger.ExtraVerbosef("using account settings cached at %s", cache.Time.Format(time.Stamp))
+			return cache, nil
+		}
+	}
+
+	access, ok := aws.AccessService.(*aws.Access)
+	if !ok {
+		return nil, aws.ErrMockUnsupported
+	}
+	infra, ok := aws.InfraService.(*aws.Infra)
+	if !ok {
+		return nil, aws.ErrMockUnsupported
+	}
+	me, err := access.GetIdentity()
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &accountSummary{Time: time.Now().UTC(), Account: me.Account, Region: region, Errors: make(map[string]string)}
+	failed := func(item string, err error) {
+		logger.Verbosef("account %s: %s", item, err)
+		summary.Errors[item] = err.Error()
+	}
+
+	if summary.Aliases, err = access.AccountAliases(); err != nil {
+		failed("aliases", err)
+	}
+	if summary.Organization, err = aws.Organizations.DescribeOrganization(); err != nil {
+		failed("organization", err)
+	}
+	if summary.EBSEncryptionByDefault, err = infra.EBSEncryptionByDefault(); err != nil {
+		failed("ebs encryption by default", err)
+	}
+	if summary.S3PublicAccessBlock, err = aws.S3Control.PublicAccessBlock(me.Account); err != nil {
+		failed("s3 block public access", err)
+	}
+	if summary.PasswordPolicy, err = access.AccountPasswordPolicy(); err != nil {
+		failed("password policy", err)
+	}
+	if summary.EnabledRegions, err = enabledRegions(profile, refresh); err != nil {
+		failed("enabled regions", err)
+	}
+
+	if len(summary.Errors) > 0 {
+		return summary, nil
+	}
+	b, err := json.Marshal(summary)
+	if err != nil {
+		return summary, nil
+	}
+	if err = database.Execute(func(db *database.DB) error {
+		return db.SetBytes(key, b)
+	}); err != nil {
+		logger.Verbosef("cannot cache account settings: %s", err)
+	}
+
+	return summary, nil
+}
+
+func printAccountSummary(w io.Writer, summary *accountSummary) error {
+	switch listingFormat {
+	case "json":
+		return json.NewEncoder(w).Encode(summary)
+	case "table":
+	default:
+		return fmt.Errorf("unsupported format '%s' for account: use table or json", listingFormat)
+	}
+
+	yesNo := func(b bool) string {
+		if b {
+			return "yes"
+		}
+		return renderRedFn("no")
+	}
+	item := func(name, value string) {
+		if err, ok := summary.Errors[name]; ok {
+			value = renderRedFn("unknown: " + err)
+		}
+		fmt.Fprintf(w, "%-27s %s\n", name+":", value)
+	}
+
+	item("account", summary.Account)
+	item("aliases", strings.Join(summary.Aliases, ", "))
+	if org := summary.Organization; org != nil {
+		item("organization", fmt.Sprintf("%s (master account %s %s, features %s)", org.ID, org.MasterAccountID, org.MasterAccountEmail, org.FeatureSet))
+	} else {
+		item("organization", "none")
+	}
+	item("ebs encryption by default", fmt.Sprintf("%s (%s)", yesNo(summary.EBSEncryptionByDefault), summary.Region))
+	if block := summary.S3PublicAccessBlock; block != nil {
+		item("s3 block public access", fmt.Sprintf("block public acls: %s, ignore public acls: %s, block public policy: %s, restrict public buckets: %s",
+			yesNo(block.BlockPublicAcls), yesNo(block.IgnorePublicAcls), yesNo(block.BlockPublicPolicy), yesNo(block.RestrictPublicBuckets)))
+	} else {
+		item("s3 block public access", renderRedFn("not configured"))
+	}
+	if pol := summary.PasswordPolicy; pol != nil {
+		rules := []string{fmt.Sprintf("min length %d", pol.MinimumLength)}
+		for _, r := range []struct {
+			set  bool
+			rule string
+		}{
+			{pol.RequireUppercaseCharacters, "uppercase"}, {pol.RequireLowercaseCharacters, "lowercase"},
+			{pol.RequireNumbers, "numbers"}, {pol.RequireSymbols, "symbols"},
+		} {
+			if r.set {
+				rules = append(rules, r.rule)
+			}
+		}
+		if pol.MaxPasswordAge > 0 {
+			rules = append(rules, fmt.Sprintf("expires after %d days", pol.MaxPasswordAge))
+		}
+		if pol.PasswordReusePrevention > 0 {
+			rules = append(rules, fmt.Sprintf("last %d not reusable", pol.PasswordReusePrevention))
+		}
+		item("password policy", strings.Join(rules, ", "))
+	} else {
+		item("password policy", renderRedFn("none"))
+	}
+	item("enabled regions", strings.Join(summary.EnabledRegions, ", "))
+
+	return nil
+}
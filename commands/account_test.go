@@ -0,0 +1,91 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/wallix/awless/aws"
+	"github.com/wallix/awless/database"
+)
+
+func TestAccountSummary(t *testing.T) {
+	home, err := ioutil.TempDir("", "awless-account")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(home)
+	os.Setenv("__AWLESS_HOME", home)
+	defer os.Unsetenv("__AWLESS_HOME")
+
+	cached := &accountSummary{
+		Time:                   time.Now().Add(-time.Minute).UTC(),
+		Account:                "123456789012",
+		Aliases:                []string{"acme-prod"},
+		Organization:           &aws.AccountOrganization{ID: "o-abc", MasterAccountID: "210987654321", MasterAccountEmail: "root@acme.com", FeatureSet: "ALL"},
+		Region:                 "eu-west-1",
+		EBSEncryptionByDefault: true,
+		PasswordPolicy:         &aws.PasswordPolicy{MinimumLength: 14, RequireSymbols: true, RequireNumbers: true, MaxPasswordAge: 90},
+		EnabledRegions:         []string{"eu-west-1", "us-east-1"},
+	}
+	b, _ := json.Marshal(cached)
+	if err := database.Execute(func(db *database.DB) error {
+		return db.SetBytes("account.summary.myprofile.eu-west-1", b)
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	summary, err := accountSettings("myprofile", "eu-west-1", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := summary.Organization, cached.Organization; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	if got, want := summary.EnabledRegions, cached.EnabledRegions; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	defer func(noColor bool) { color.NoColor = noColor }(color.NoColor)
+	color.NoColor = true
+	listingFormat = "table"
+	summary.Errors = map[string]string{"aliases": "AccessDenied"}
+	var w bytes.Buffer
+	if err := printAccountSummary(&w, summary); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"account:                    123456789012",
+		"aliases:                    unknown: AccessDenied",
+		"organization:               o-abc (master account 210987654321 root@acme.com, features ALL)",
+		"ebs encryption by default:  yes (eu-west-1)",
+		"s3 block public access:     not configured",
+		"password policy:            min length 14, numbers, symbols, expires after 90 days",
+		"enabled regions:            eu-west-1, us-east-1",
+	}
+	if got, want := strings.Split(strings.TrimSpace(w.String()), "\n"), expected; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
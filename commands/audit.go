@@ -0,0 +1,134 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/wallix/awless/database"
+	"github.com/wallix/awless/template"
+)
+
+const redactedValue = "<redacted>"
+
+var redactedParamKeys = []string{"password", "secret", "token", "privatekey", "credential"}
+
+func isSensitiveKey(k string) bool {
+	for _, sensitive := range redactedParamKeys {
+		if strings.Contains(strings.ToLower(k), sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// auditEntries returns the audit entries of the mutating actions run by an executed template,
+// whether they failed or not. Sensitive params values are redacted
+func auditEntries(tplExec *template.TemplateExecution, at time.Time) (entries []*database.AuditEntry) {
+	for _, cmd := range tplExec.Template.CommandNodesIterator() {
+		if cmd.Action == "check" {
+			continue
+		}
+		e := &database.AuditEntry{
+			Time:       at,
+			TemplateID: tplExec.Template.ID,
+			Identity:   tplExec.Author,
+			Region:     tplExec.Locale,
+			Action:     cmd.Action,
+			Entity:     cmd.Entity,
+			Params:     make(map[string]string),
+		}
+		for k, v := range cmd.Params {
+			e.Params[k] = fmt.Sprint(v)
+			if isSensitiveKey(k) {
+				e.Params[k] = redactedValue
+			}
+		}
+		for k, ref := range cmd.Refs {
+			if _, done := e.Params[k]; !done {
+				e.Params[k] = "$" + ref
+			}
+		}
+		if cmd.CmdErr != nil {
+			e.Error = cmd.CmdErr.Error()
+		} else if cmd.CmdResult != nil {
+			e.ResourceID = fmt.Sprint(cmd.CmdResult)
+		}
+		entries = append(entries, e)
+	}
+	return
+}
+
+func printAuditEntries(w io.Writer, entries []*database.AuditEntry, format string) error {
+	switch format {
+	case "json":
+		return json.NewEncoder(w).Encode(entries)
+	case "csv":
+		csvw := csv.NewWriter(w)
+		if err := csvw.Write([]string{"Time", "TemplateID", "Identity", "Region", "Action", "Entity", "Params", "ResourceID", "Error"}); err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if err := csvw.Write([]string{e.Time.Format(time.RFC3339), e.TemplateID, e.Identity, e.Region, e.Action, e.Entity, auditParamsString(e.Params), e.ResourceID, e.Error}); err != nil {
+				return err
+			}
+		}
+		csvw.Flush()
+		return csvw.Error()
+	case "table":
+	default:
+		return fmt.Errorf("unsupported format '%s' for history: use table, csv or json", format)
+	}
+
+	if len(entries) == 0 {
+		fmt.Fprintln(w, "No action recorded yet")
+		return nil
+	}
+
+	table := tablewriter.NewWriter(w)
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAutoWrapText(false)
+	table.SetHeader([]string{"Time", "Identity", "Region", "Action", "Params", "Result"})
+	for _, e := range entries {
+		result := e.ResourceID
+		if e.Error != "" {
+			result = renderRedFn("KO: " + e.Error)
+		}
+		table.Append([]string{e.Time.Local().Format("2006-01-02 15:04:05"), e.Identity, e.Region, fmt.Sprintf("%s %s", e.Action, e.Entity), auditParamsString(e.Params), result})
+	}
+	table.Render()
+
+	return nil
+}
+
+func auditParamsString(params map[string]string) string {
+	var all []string
+	for k, v := range params {
+		all = append(all, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(all)
+	return strings.Join(all, " ")
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/wallix/awless/database"
+	"github.com/wallix/awless/template"
+)
+
+func TestAuditEntries(t *testing.T) {
+	tpl := template.MustParse(`inst = create instance name=my_inst subnet=$sub
+check instance id=$inst state=running timeout=180
+create loginprofile username=jdoe password=s3cr3t
+delete bucket name=my_bucket`)
+	cmds := tpl.CommandNodesIterator()
+	cmds[0].CmdResult = "i-1234"
+	cmds[1].CmdResult = nil
+	cmds[2].CmdResult = "jdoe"
+	cmds[3].CmdErr = errors.New("delete bucket: access denied")
+
+	now := time.Date(2017, 6, 15, 10, 0, 0, 0, time.UTC)
+	tplExec := &template.TemplateExecution{Template: tpl, Author: "user/jdoe", Locale: "eu-west-1"}
+	entries := auditEntries(tplExec, now)
+
+	expected := []*database.AuditEntry{
+		{Time: now, TemplateID: tpl.ID, Identity: "user/jdoe", Region: "eu-west-1", Action: "create", Entity: "instance", Params: map[string]string{"name": "my_inst", "subnet": "$sub"}, ResourceID: "i-1234"},
+		{Time: now, TemplateID: tpl.ID, Identity: "user/jdoe", Region: "eu-west-1", Action: "create", Entity: "loginprofile", Params: map[string]string{"username": "jdoe", "password": "<redacted>"}, ResourceID: "jdoe"},
+		{Time: now, TemplateID: tpl.ID, Identity: "user/jdoe", Region: "eu-west-1", Action: "delete", Entity: "bucket", Params: map[string]string{"name": "my_bucket"}, Error: "delete bucket: access denied"},
+	}
+	if got, want := entries, expected; !reflect.DeepEqual(got, want) {
+		for _, e := range got {
+			t.Logf("%+v", e)
+		}
+		t.Fatalf("got %d entries, want %d", len(got), len(want))
+	}
+
+	var buff bytes.Buffer
+	if err := printAuditEntries(&buff, entries, "csv"); err != nil {
+		t.Fatal(err)
+	}
+	exp := `Time,TemplateID,Identity,Region,Action,Entity,Params,ResourceID,Error
+2017-06-15T10:00:00Z,,user/jdoe,eu-west-1,create,instance,name=my_inst subnet=$sub,i-1234,
+2017-06-15T10:00:00Z,,user/jdoe,eu-west-1,create,loginprofile,password=<redacted> username=jdoe,jdoe,
+2017-06-15T10:00:00Z,,user/jdoe,eu-west-1,delete,bucket,name=my_bucket,,delete bucket: access denied
+`
+	if got, want := buff.String(), exp; got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+}
@@ -19,14 +19,19 @@ package commands
 import (
 	"bufio"
 	"bytes"
+	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/wallix/awless/sync"
 )
 
 func init() {
 	autocompleteCmd.AddCommand(bashAutocompleteCmd)
 	autocompleteCmd.AddCommand(zshAutocompleteCmd)
+	autocompleteCmd.AddCommand(tagKeysAutocompleteCmd)
+	autocompleteCmd.AddCommand(tagValuesAutocompleteCmd)
 
 	RootCmd.AddCommand(autocompleteCmd)
 }
@@ -89,6 +94,48 @@ zsh completions are only supported in versions of zsh >= 5.2`,
 	RunE: runCompletionZsh,
 }
 
+var tagKeysAutocompleteCmd = &cobra.Command{
+	Use:               "tag-keys",
+	Short:             "List the tag keys of the resources in the local graph, for shell completion",
+	Hidden:            true,
+	PersistentPreRunE: initAwlessEnvHook,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		g, err := sync.LoadAllGraphs()
+		if err != nil {
+			return err
+		}
+		keys, err := tagKeys(g)
+		if err != nil {
+			return err
+		}
+		fmt.Println(strings.Join(keys, "\n"))
+		return nil
+	},
+}
+
+var tagValuesAutocompleteCmd = &cobra.Command{
+	Use:               "tag-values [KEY]",
+	Short:             "List the values of the given tag key (or of all tags) in the local graph, for shell completion",
+	Hidden:            true,
+	PersistentPreRunE: initAwlessEnvHook,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var key string
+		if len(args) > 0 {
+			key = args[0]
+		}
+		g, err := sync.LoadAllGraphs()
+		if err != nil {
+			return err
+		}
+		values, err := tagValues(g, key)
+		if err != nil {
+			return err
+		}
+		fmt.Println(strings.Join(values, "\n"))
+		return nil
+	},
+}
+
 func runCompletionBash(cmd *cobra.Command, args []string) error {
 	out := bufio.NewWriter(os.Stdout)
 	defer out.Flush()
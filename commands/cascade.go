@@ -0,0 +1,118 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/wallix/awless/aws"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template"
+)
+
+var cascadeDeleteFlag bool
+
+// cascadeVpcID returns the VPC to delete given as id=ID, as ID or as @name
+func cascadeVpcID(args []string) (string, error) {
+	if len(args) != 1 {
+		return "", errors.New("--cascade expects a single vpc (ex: awless delete vpc id=vpc-12345678 --cascade)")
+	}
+	ref := strings.TrimPrefix(args[0], "id=")
+	if strings.HasPrefix(ref, "@") {
+		id := resolveAliasFunc(cloud.Vpc, "id", strings.TrimPrefix(ref, "@"))
+		if id == "" {
+			return "", fmt.Errorf("no vpc named '%s' in local graph (run `awless sync` if needed)", ref[1:])
+		}
+		return id, nil
+	}
+	return ref, nil
+}
+
+// fetchVpcCascadeGraph fetches the resources that may depend on a VPC
+func fetchVpcCascadeGraph() (*graph.Graph, error) {
+	g := graph.NewGraph()
+	for _, typ := range aws.VpcCascadeTypes {
+		fetched, err := fetchResourcesOfType(typ)
+		if err != nil {
+			return nil, fmt.Errorf("fetching %s resources: %s", typ, err)
+		}
+		g.AddGraph(fetched)
+	}
+	return g, nil
+}
+
+// runVpcCascadeDeletion deletes a VPC after its dependents, once the full list is confirmed,
+// and reports the dependents left when the VPC could not be deleted
+func runVpcCascadeDeletion(vpc string) error {
+	g, err := fetchVpcCascadeGraph()
+	if err != nil {
+		return err
+	}
+	cascade, err := aws.VpcCascade(g, vpc)
+	if err != nil {
+		return err
+	}
+	if len(cascade.Unsupported) > 0 {
+		for _, u := range cascade.Unsupported {
+			logger.Errorf("cannot delete %s", u)
+		}
+		return fmt.Errorf("vpc %s has dependents awless can not delete", vpc)
+	}
+
+	fmt.Printf("Deleting vpc %s and its %d dependents:\n", vpc, len(cascade.Resources))
+	printCascadeResources(cascade.Resources)
+	fmt.Println()
+	logger.Info("network interfaces and endpoints not owned by these resources (ex: lambda functions, vpc endpoints) are not detected and would prevent the vpc deletion")
+
+	tpl, err := template.Parse(cascade.Template())
+	if err != nil {
+		return err
+	}
+	tplExec := &template.TemplateExecution{
+		Template: tpl,
+		Locale:   config.GetAWSRegion(),
+		Source:   tpl.String(),
+	}
+	if err = runTemplate(tplExec, config.Defaults); err != nil {
+		return err
+	}
+
+	if g, err = fetchVpcCascadeGraph(); err != nil {
+		return err
+	}
+	if remaining, err := aws.VpcCascade(g, vpc); err == nil {
+		logger.Warningf("vpc %s still exists with %d dependents:", vpc, len(remaining.Resources))
+		printCascadeResources(remaining.Resources)
+	}
+	return nil
+}
+
+func printCascadeResources(resources []*graph.Resource) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	for _, r := range resources {
+		name, _ := r.Properties["Name"].(string)
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", r.Type(), r.Id(), name)
+	}
+	w.Flush()
+}
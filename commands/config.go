@@ -18,9 +18,13 @@ package commands
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws"
 	"github.com/wallix/awless/config"
 )
 
@@ -32,11 +36,15 @@ func init() {
 	configCmd.AddCommand(configGetCmd)
 	configCmd.AddCommand(configSetCmd)
 	configCmd.AddCommand(configUnsetCmd)
+	configCmd.AddCommand(configCheckCmd)
+	configCmd.AddCommand(configEffectiveCmd)
+	configCmd.AddCommand(configDiffCmd)
 }
 
 var configCmd = &cobra.Command{
 	Use:               "config",
 	Short:             "get, set, unset configuration values",
+	Long:              "get, set, unset configuration values\n\nAn awless.yaml (or .awless) file in the working directory or one of its parents overrides, for this project, the region, profile, output format and sync settings of the global config (ex: 'aws.region: eu-west-1' lines, or 'region: eu-west-1' indented under 'aws:').\nPrecedence: flags > environment (AWS_DEFAULT_REGION, AWS_DEFAULT_PROFILE) > project file > global config. See the resulting values with `awless config effective`.",
 	Example:           "  awless config        # list all your config\n  awless config set aws.region eu-west-1\n  awless config unset instance.count",
 	PersistentPreRunE: initAwlessEnvHook,
 
@@ -113,3 +121,116 @@ var configUnsetCmd = &cobra.Command{
 		return nil
 	},
 }
+
+var configCheckCmd = &cobra.Command{
+	Use:              "check",
+	Short:            "Check your AWS credentials resolve and display the identity they authenticate",
+	Long:             "Check your AWS credentials resolve as they would for any other command, and display the provider that supplied them and the identity they authenticate.\nOn failure, the errors of each credentials provider tried are displayed and the command exits with a non-zero status.",
+	Example:          "  awless config check\n  awless config check --aws-profile admin",
+	PersistentPreRun: applyHooks(initLoggerHook, initAwlessEnvHook),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		profile := config.GetAWSProfile()
+		region := config.GetAWSRegion()
+		if region == "" {
+			region = aws.ProfileRegion(profile)
+		}
+
+		if cache, ok := config.Get("aws.credentials.cache"); ok {
+			if enabled, isBool := cache.(bool); isBool {
+				aws.SetCredentialsFileCache(enabled)
+			}
+		}
+		check := aws.CheckCredentials(region, profile)
+		fmt.Printf("profile:  %s\n", check.Profile)
+		fmt.Printf("region:   %s\n", check.Region)
+		if check.Provider != "" {
+			fmt.Printf("provider: %s\n", check.Provider)
+		}
+		if check.Identity != nil {
+			fmt.Printf("account:  %s\n", check.Identity.Account)
+			fmt.Printf("identity: %s\n", check.Identity.Arn)
+		}
+		if check.Err != nil {
+			fmt.Fprintf(os.Stderr, "\ncredentials check failed: %s\n", check.Err)
+			for _, e := range check.ProviderErrors {
+				fmt.Fprintf(os.Stderr, "\t%s\n", strings.Replace(e, "\n", "\n\t", -1))
+			}
+			os.Exit(1)
+		}
+		fmt.Println("\ncredentials OK")
+	},
+}
+
+var configEffectiveCmd = &cobra.Command{
+	Use:              "effective",
+	Short:            "Show the config values used by a run, and the source each value came from",
+	Long:             "Show the config values used by a run once flags, environment, project file and global config are merged, and the source each value came from.\nThe config holds no credentials, but the values of keys that may embed tokens (ex: aws.notify.webhook) are redacted.",
+	Example:          "  awless config effective\n  awless config effective -r us-west-2",
+	PersistentPreRun: applyHooks(initLoggerHook, initAwlessEnvHook),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		applyProfileRegionIfUnset()
+		printEffectiveConfig(os.Stdout, config.EffectiveConfig(), false)
+	},
+}
+
+var configDiffCmd = &cobra.Command{
+	Use:              "diff",
+	Short:            "Show the config values of a run differing from the global config, and where they came from",
+	Example:          "  awless config diff\n  AWS_DEFAULT_REGION=us-east-1 awless config diff",
+	PersistentPreRun: applyHooks(initLoggerHook, initAwlessEnvHook),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		applyProfileRegionIfUnset()
+		printEffectiveConfig(os.Stdout, config.EffectiveConfig(), true)
+	},
+}
+
+// applyProfileRegionIfUnset falls back on the region of the AWS profile, as done when loading the cloud services
+func applyProfileRegionIfUnset() {
+	if config.GetAWSRegion() != "" {
+		return
+	}
+	profile := config.GetAWSProfile()
+	if region := aws.ProfileRegion(profile); region != "" {
+		exitOn(config.SetVolatileFrom(fmt.Sprintf("AWS profile '%s'", profile), config.RegionConfigKey, region))
+	}
+}
+
+func printEffectiveConfig(w io.Writer, values []*config.EffectiveValue, diffOnly bool) {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	if diffOnly {
+		fmt.Fprintln(tw, "KEY\tGLOBAL CONFIG\tEFFECTIVE\tSOURCE")
+	} else {
+		fmt.Fprintln(tw, "KEY\tVALUE\tSOURCE")
+	}
+	var count int
+	for _, v := range values {
+		if diffOnly && !v.Overridden {
+			continue
+		}
+		count++
+		if diffOnly {
+			saved := "<unset>"
+			if v.Saved != nil {
+				saved = effectiveConfigDisplay(v.Key, v.Saved)
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", v.Key, saved, effectiveConfigDisplay(v.Key, v.Value), v.Source)
+		} else {
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", v.Key, effectiveConfigDisplay(v.Key, v.Value), v.Source)
+		}
+	}
+	if diffOnly && count == 0 {
+		fmt.Fprintln(w, "no difference: the global config is used as is")
+		return
+	}
+	tw.Flush()
+}
+
+func effectiveConfigDisplay(k string, v interface{}) string {
+	if _, isBool := v.(bool); !isBool && fmt.Sprint(v) != "" && (isExportExcludedConfigKey(k) || isSensitiveKey(k)) {
+		return "<redacted>"
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws"
+	"github.com/wallix/awless/logger"
+)
+
+var (
+	costRecentDaysFlag   int
+	costTrailingDaysFlag int
+	costThresholdFlag    float64
+	costMinAmountFlag    float64
+)
+
+func init() {
+	RootCmd.AddCommand(costCmd)
+	costCmd.AddCommand(costAnomaliesCmd)
+
+	outputFormatFlag(costCmd.PersistentFlags(), &listingFormat, "table", "json")
+	costCmd.PersistentFlags().BoolVar(&noHeadersFlag, "no-headers", false, "Do not display headers")
+
+	costAnomaliesCmd.Flags().IntVar(&costRecentDaysFlag, "days", 1, "Number of most recent complete days to check")
+	costAnomaliesCmd.Flags().IntVar(&costTrailingDaysFlag, "trailing-days", 14, "Number of days before the checked days used to compute the average daily cost")
+	costAnomaliesCmd.Flags().Float64Var(&costThresholdFlag, "threshold", 50, "Minimum deviation (in percent) from the average daily cost to flag a service")
+	costAnomaliesCmd.Flags().Float64Var(&costMinAmountFlag, "min-amount", 1, "Minimum deviation (in cost unit, i.e. USD) from the average daily cost to flag a service")
+}
+
+var costCmd = &cobra.Command{
+	Use:               "cost",
+	Short:             "Analyze the spending of your AWS account (Cost Explorer)",
+	PersistentPreRun:  applyHooks(initAwlessEnvHook, initLoggerHook, initOutputFormatHook, initCloudServicesHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade),
+}
+
+var costAnomaliesCmd = &cobra.Command{
+	Use:     "anomalies",
+	Short:   "Flag services whose recent daily cost deviates significantly from their trailing average",
+	Long:    "Flag services whose recent daily cost deviates significantly from their trailing average.\n\nCost Explorer data is only complete after about a day: the current day is never checked and costs still estimated by AWS are marked as such.",
+	Example: "  awless cost anomalies\n  awless cost anomalies --days 3 --trailing-days 30 --threshold 25",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		if costRecentDaysFlag < 1 || costTrailingDaysFlag < 1 {
+			exitOn(errors.New("--days and --trailing-days must be positive"))
+		}
+		end := time.Now().UTC().Truncate(24 * time.Hour)
+		start := end.AddDate(0, 0, -(costRecentDaysFlag + costTrailingDaysFlag))
+
+		logger.Verbosef("fetching daily cost by service from %s to %s", start.Format("2006-01-02"), end.AddDate(0, 0, -1).Format("2006-01-02"))
+		if aws.CostExplorer == nil {
+			exitOn(aws.ErrMockUnsupported)
+		}
+		costs, err := aws.CostExplorer.DailyCostByService(start, end)
+		exitOn(err)
+
+		anomalies := findCostAnomalies(costs, end, costRecentDaysFlag, costTrailingDaysFlag, costThresholdFlag, costMinAmountFlag)
+		exitOn(printCostAnomalies(os.Stdout, anomalies))
+	},
+}
+
+type costAnomaly struct {
+	Service   string  `json:"service"`
+	Daily     float64 `json:"daily"`
+	Average   float64 `json:"average"`
+	Change    float64 `json:"change"`
+	New       bool    `json:"new,omitempty"`
+	Unit      string  `json:"unit"`
+	Estimated bool    `json:"estimated,omitempty"`
+}
+
+// findCostAnomalies compares, for each service, the average daily cost of the recent days
+// before end (exclusive) to the average daily cost of the trailing days preceding them.
+// Days without cost count as zero. Change is in percent, and a spending without trailing cost is flagged as new
+func findCostAnomalies(costs []*aws.ServiceCost, end time.Time, recentDays, trailingDays int, threshold, minAmount float64) []*costAnomaly {
+	recentStart := end.AddDate(0, 0, -recentDays)
+	trailingStart := recentStart.AddDate(0, 0, -trailingDays)
+
+	perService := make(map[string]*costAnomaly)
+	for _, c := range costs {
+		if c.Day.Before(trailingStart) || !c.Day.Before(end) {
+			continue
+		}
+		a, ok := perService[c.Service]
+		if !ok {
+			a = &costAnomaly{Service: c.Service, Unit: c.Unit}
+			perService[c.Service] = a
+		}
+		if c.Day.Before(recentStart) {
+			a.Average += c.Amount / float64(trailingDays)
+		} else {
+			a.Daily += c.Amount / float64(recentDays)
+			a.Estimated = a.Estimated || c.Estimated
+		}
+	}
+
+	var anomalies []*costAnomaly
+	for _, a := range perService {
+		if math.Abs(a.Daily-a.Average) < minAmount {
+			continue
+		}
+		if a.Average == 0 {
+			a.New = true
+		} else {
+			a.Change = (a.Daily - a.Average) / a.Average * 100
+		}
+		if a.New || math.Abs(a.Change) >= threshold {
+			anomalies = append(anomalies, a)
+		}
+	}
+
+	sort.Slice(anomalies, func(i, j int) bool {
+		di, dj := math.Abs(anomalies[i].Daily-anomalies[i].Average), math.Abs(anomalies[j].Daily-anomalies[j].Average)
+		if di != dj {
+			return di > dj
+		}
+		return anomalies[i].Service < anomalies[j].Service
+	})
+
+	return anomalies
+}
+
+func printCostAnomalies(w io.Writer, anomalies []*costAnomaly) error {
+	switch listingFormat {
+	case "json":
+		return json.NewEncoder(w).Encode(anomalies)
+	case "table":
+	default:
+		return fmt.Errorf("unsupported format '%s' for cost anomalies: use table or json", listingFormat)
+	}
+
+	if len(anomalies) == 0 {
+		fmt.Fprintln(w, "No cost anomaly found")
+		return nil
+	}
+
+	table := tablewriter.NewWriter(w)
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAutoWrapText(false)
+	if !noHeadersFlag {
+		table.SetHeader([]string{"Service", "Daily cost", "Average daily cost", "Change"})
+	}
+	for _, a := range anomalies {
+		daily := fmt.Sprintf("%.2f %s", a.Daily, a.Unit)
+		if a.Estimated {
+			daily += " (estimated)"
+		}
+		change := fmt.Sprintf("%+.0f%%", a.Change)
+		if a.New {
+			change = "new"
+		}
+		table.Append([]string{a.Service, daily, fmt.Sprintf("%.2f %s", a.Average, a.Unit), change})
+	}
+	table.Render()
+
+	return nil
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/wallix/awless/aws"
+	p "github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+)
+
+func TestFindCostAnomalies(t *testing.T) {
+	end := time.Date(2017, 6, 15, 0, 0, 0, 0, time.UTC)
+	day := func(before int) time.Time { return end.AddDate(0, 0, -before) }
+
+	var costs []*aws.ServiceCost
+	for i := 2; i <= 5; i++ {
+		costs = append(costs,
+			&aws.ServiceCost{Service: "Amazon EC2", Day: day(i), Amount: 10, Unit: "USD"},
+			&aws.ServiceCost{Service: "Amazon S3", Day: day(i), Amount: 4, Unit: "USD"},
+			&aws.ServiceCost{Service: "Amazon RDS", Day: day(i), Amount: 8, Unit: "USD"},
+			&aws.ServiceCost{Service: "AWS Lambda", Day: day(i), Amount: 0.1, Unit: "USD"},
+		)
+	}
+	costs = append(costs,
+		&aws.ServiceCost{Service: "Amazon EC2", Day: day(1), Amount: 25, Unit: "USD", Estimated: true},
+		&aws.ServiceCost{Service: "Amazon S3", Day: day(1), Amount: 4.5, Unit: "USD", Estimated: true},
+		&aws.ServiceCost{Service: "Amazon RDS", Day: day(1), Amount: 2, Unit: "USD", Estimated: true},
+		&aws.ServiceCost{Service: "AWS Lambda", Day: day(1), Amount: 0.5, Unit: "USD", Estimated: true},
+		&aws.ServiceCost{Service: "Amazon Redshift", Day: day(1), Amount: 30, Unit: "USD", Estimated: true},
+		&aws.ServiceCost{Service: "Amazon EC2", Day: day(0), Amount: 1000, Unit: "USD", Estimated: true},
+		&aws.ServiceCost{Service: "Amazon EC2", Day: day(10), Amount: 1000, Unit: "USD"},
+	)
+
+	anomalies := findCostAnomalies(costs, end, 1, 4, 50, 1)
+
+	expected := []*costAnomaly{
+		{Service: "Amazon Redshift", Daily: 30, Average: 0, New: true, Unit: "USD", Estimated: true},
+		{Service: "Amazon EC2", Daily: 25, Average: 10, Change: 150, Unit: "USD", Estimated: true},
+		{Service: "Amazon RDS", Daily: 2, Average: 8, Change: -75, Unit: "USD", Estimated: true},
+	}
+	if got, want := anomalies, expected; !reflect.DeepEqual(got, want) {
+		for _, a := range got {
+			t.Logf("%#v", a)
+		}
+		t.Fatalf("got %d anomalies, want %d", len(got), len(want))
+	}
+}
+
+func TestCostTagsStatuses(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddResource(
+		resourcetest.Instance("inst_1").Prop(p.Tags, []string{"Name=web", "CostCenter=42", "aws:cloudformation:stack-name=web"}).Build(),
+		resourcetest.Instance("inst_2").Prop(p.Tags, []string{"Team=front", "CostCenter=43"}).Build(),
+		resourcetest.Bucket("bucket_1").Prop(p.Tags, []string{"Team=data"}).Build(),
+		resourcetest.Volume("vol_1").Build(),
+	)
+	billing := []*aws.CostAllocationTag{
+		{Key: "CostCenter", Type: "UserDefined", Status: "Active"},
+		{Key: "Team", Type: "UserDefined", Status: "Inactive"},
+		{Key: "aws:createdBy", Type: "AWSGenerated", Status: "Inactive"},
+	}
+
+	statuses, err := costTagsStatuses(g, billing, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []*costTagStatus{
+		{Key: "Name", Status: "unknown", Resources: []string{"instance[inst_1]"}},
+		{Key: "Team", Status: "inactive", Resources: []string{"bucket[bucket_1]", "instance[inst_2]"}},
+		{Key: "CostCenter", Status: "active", Active: true, Resources: []string{"instance[inst_1]", "instance[inst_2]"}},
+	}
+	if got, want := statuses, expected; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+
+	statuses, err = costTagsStatuses(g, billing, []string{"CostCenter", "Project"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = []*costTagStatus{
+		{Key: "Project", Status: "unknown"},
+		{Key: "CostCenter", Status: "active", Active: true, Resources: []string{"instance[inst_1]", "instance[inst_2]"}},
+	}
+	if got, want := statuses, expected; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestTruncateList(t *testing.T) {
+	if got, want := truncateList([]string{"a", "b"}, 3), []string{"a", "b"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := truncateList([]string{"a", "b", "c", "d"}, 3), []string{"a", "b", "... 2 more"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
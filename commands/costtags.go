@@ -0,0 +1,177 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws"
+	p "github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/sync"
+)
+
+func init() {
+	outputFormatFlag(showCostTagsCmd.Flags(), &listingFormat, "table", "json")
+	showCostTagsCmd.Flags().BoolVar(&noHeadersFlag, "no-headers", false, "Do not display headers")
+	showCmd.AddCommand(showCostTagsCmd)
+}
+
+var showCostTagsCmd = &cobra.Command{
+	Use:   "cost-tags",
+	Short: "Show whether the tag keys of your resources are activated as cost allocation tags",
+	Long: fmt.Sprintf(`Show whether the tag keys of your resources are activated as cost allocation tags, i.e. whether they break down the costs in Cost Explorer and in the cost and usage reports.
+
+The checked keys are the ones of config %s or, when not set, all the tag keys of the resources of the local graphs (last sync). Keys not yet seen by billing are reported as unknown: AWS may take a day to list a new tag key.`, config.CostTagsConfigKey),
+	Example: "  awless show cost-tags\n  awless config set cost.tags CostCenter,Team\n  awless show cost-tags --format json",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		if aws.CostExplorer == nil {
+			exitOn(aws.ErrMockUnsupported)
+		}
+		billing, err := aws.CostExplorer.CostAllocationTags()
+		exitOn(err)
+
+		g, err := sync.LoadAllGraphs()
+		exitOn(err)
+
+		statuses, err := costTagsStatuses(g, billing, config.GetCostTags())
+		exitOn(err)
+
+		exitOn(printCostTagsStatuses(os.Stdout, statuses))
+	},
+}
+
+type costTagStatus struct {
+	Key       string   `json:"key"`
+	Status    string   `json:"status"`
+	Active    bool     `json:"active"`
+	Resources []string `json:"resources,omitempty"`
+}
+
+// costTagsStatuses returns the activation status of the expected tag keys (or of all the tag keys
+// of the resources when none expected) with the resources carrying them. Inactive keys come first
+func costTagsStatuses(g *graph.Graph, billing []*aws.CostAllocationTag, expected []string) ([]*costTagStatus, error) {
+	resources, err := g.GetAllResources(aws.ResourceTypes...)
+	if err != nil {
+		return nil, err
+	}
+
+	resourcesByKey := make(map[string][]string)
+	for _, res := range resources {
+		tags, _ := res.Properties[p.Tags].([]string)
+		for _, t := range tags {
+			key := strings.SplitN(t, "=", 2)[0]
+			if key == "" || strings.HasPrefix(key, "aws:") {
+				continue
+			}
+			resourcesByKey[key] = append(resourcesByKey[key], fmt.Sprintf("%s[%s]", res.Type(), res.Id()))
+		}
+	}
+
+	keys := expected
+	if len(keys) == 0 {
+		for k := range resourcesByKey {
+			keys = append(keys, k)
+		}
+	}
+
+	billingByKey := make(map[string]*aws.CostAllocationTag)
+	for _, t := range billing {
+		billingByKey[t.Key] = t
+	}
+
+	var statuses []*costTagStatus
+	for _, k := range keys {
+		status := &costTagStatus{Key: k, Status: "unknown", Resources: resourcesByKey[k]}
+		if t, ok := billingByKey[k]; ok {
+			status.Status = strings.ToLower(t.Status)
+			status.Active = t.Active()
+		}
+		sort.Strings(status.Resources)
+		statuses = append(statuses, status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].Active != statuses[j].Active {
+			return !statuses[i].Active
+		}
+		return statuses[i].Key < statuses[j].Key
+	})
+
+	return statuses, nil
+}
+
+func printCostTagsStatuses(w io.Writer, statuses []*costTagStatus) error {
+	switch listingFormat {
+	case "json":
+		return json.NewEncoder(w).Encode(statuses)
+	case "table":
+	default:
+		return fmt.Errorf("unsupported format '%s' for cost tags: use table or json", listingFormat)
+	}
+
+	if len(statuses) == 0 {
+		fmt.Fprintln(w, "No tag key found on resources: sync first or set the expected keys in config "+config.CostTagsConfigKey)
+		return nil
+	}
+
+	table := tablewriter.NewWriter(w)
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAutoWrapText(false)
+	if !noHeadersFlag {
+		table.SetHeader([]string{"Tag key", "Status", "Resources"})
+	}
+	var inactive int
+	for _, s := range statuses {
+		if s.Active {
+			table.Append([]string{s.Key, renderGreenFn(s.Status), fmt.Sprint(len(s.Resources))})
+			continue
+		}
+		inactive++
+		resources := fmt.Sprint(len(s.Resources))
+		if len(s.Resources) > 0 {
+			resources += ": " + strings.Join(truncateList(s.Resources, 5), ", ")
+		}
+		table.Append([]string{s.Key, renderRedFn(s.Status), resources})
+	}
+	table.Render()
+
+	if inactive > 0 {
+		fmt.Fprintf(w, "\n%d tag key(s) not activated: their costs will not appear in cost reports. Activate them in the Billing console (Cost allocation tags)\n", inactive)
+	}
+
+	return nil
+}
+
+// truncateList returns at most max elements of the list, the last one telling how many were left out
+func truncateList(l []string, max int) []string {
+	if len(l) <= max {
+		return l
+	}
+	return append(append([]string{}, l[:max-1]...), fmt.Sprintf("... %d more", len(l)-max+1))
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/sync"
+)
+
+var driftRemediateFlag bool
+
+func init() {
+	RootCmd.AddCommand(driftCmd)
+	driftCmd.Flags().BoolVar(&driftRemediateFlag, "remediate", false, "Print a template of the update commands reverting the drifted resources to their snapshot state (not run)")
+}
+
+var driftCmd = &cobra.Command{
+	Use:   "drift [RESOURCE_TYPE...]",
+	Short: "Show the infra resources changed in the cloud since your last sync (the snapshot), optionally with the commands reverting them",
+	Example: `  awless drift
+  awless drift securitygroup instance
+  awless drift --remediate > revert.aws    # review, then: awless run revert.aws`,
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, initSyncerHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		srv, ok := cloud.ServiceRegistry["infra"]
+		if !ok {
+			return fmt.Errorf("infra service not available")
+		}
+		typs := srv.ResourceTypes()
+		if len(args) > 0 {
+			typs = nil
+			for _, arg := range args {
+				t := strings.ToLower(arg)
+				if aws.ServicePerResourceType[t] != srv.Name() {
+					return fmt.Errorf("'%s' is not an infra resource type", arg)
+				}
+				typs = append(typs, t)
+			}
+		}
+
+		snapshot := sync.LoadCurrentLocalGraph(srv.Name())
+		fetched, err := srv.FetchResources()
+		exitOn(err)
+		current, err := roundTripGraph(fetched)
+		exitOn(err)
+
+		drifts, err := graph.Drift(snapshot, current, typs...)
+		exitOn(err)
+
+		if driftRemediateFlag {
+			fmt.Print(aws.RemediateDrift(drifts).Template())
+			return nil
+		}
+		printDrifts(os.Stdout, drifts)
+		return nil
+	},
+}
+
+// roundTripGraph marshals and unmarshals a fetched graph, so that its property values
+// have the types of the ones loaded from the local store they are compared with
+func roundTripGraph(g *graph.Graph) (*graph.Graph, error) {
+	b, err := g.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	out := graph.NewGraph()
+	return out, out.Unmarshal(b)
+}
+
+func printDrifts(w io.Writer, drifts []*graph.ResourceDrift) {
+	if len(drifts) == 0 {
+		fmt.Fprintln(w, "no drift since last sync")
+		return
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "RESOURCE\tPROPERTY\tSNAPSHOT\tCURRENT")
+	for _, d := range drifts {
+		switch {
+		case d.Removed():
+			fmt.Fprintf(tw, "%s\t-\texists\tremoved\n", d.Resource)
+		case d.Created():
+			fmt.Fprintf(tw, "%s\t-\t-\tcreated\n", d.Resource)
+		default:
+			for _, c := range d.Changes {
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", d.Resource, c.Property, driftValue(c.Snapshot), driftValue(c.Current))
+			}
+		}
+	}
+	tw.Flush()
+}
+
+func driftValue(v interface{}) string {
+	switch vv := v.(type) {
+	case nil:
+		return "-"
+	case []*graph.FirewallRule:
+		var rules []string
+		for _, r := range vv {
+			rules = append(rules, r.String())
+		}
+		return strings.Join(rules, " | ")
+	case []string:
+		return strings.Join(vv, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
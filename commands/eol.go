@@ -0,0 +1,165 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/sync"
+)
+
+var eolWithinDaysFlag int
+
+func init() {
+	listCmd.AddCommand(listEOLCmd)
+	listEOLCmd.Flags().IntVar(&eolWithinDaysFlag, "within-days", 180, "Also flag versions reaching their end of life within the given number of days")
+}
+
+var listEOLCmd = &cobra.Command{
+	Use:     "eol",
+	Short:   "List databases and functions running engine versions or runtimes nearing or past their end of life",
+	Long:    fmt.Sprintf("List RDS databases and Lambda functions running engine versions or runtimes nearing or past their end of life.\n\nThe built-in versions table can be overridden or extended with config keys prefixed with '%s' followed by the engine (or '%s' for runtimes) and the version.", aws.EOLConfigPrefix, aws.LambdaEngine),
+	Example: "  awless list eol\n  awless list eol --within-days 365 --format json\n  awless config set aws.eol.postgres.9.6 2021-11-11",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		var graphs []*graph.Graph
+		for _, resType := range []string{cloud.Database, cloud.Function} {
+			if localGlobalFlag {
+				graphs = append(graphs, sync.LoadCurrentLocalGraph(aws.ServicePerResourceType[resType]))
+				continue
+			}
+			srv, err := cloud.GetServiceForType(resType)
+			exitOn(err)
+			g, err := srv.FetchByType(resType)
+			exitOn(err)
+			graphs = append(graphs, g)
+		}
+
+		findings, err := findEOLVersions(graphs, config.GetConfigWithPrefix(aws.EOLConfigPrefix), time.Now().UTC(), eolWithinDaysFlag)
+		exitOn(err)
+		exitOn(printEOLFindings(os.Stdout, findings))
+	},
+}
+
+type eolFinding struct {
+	Type    string    `json:"type"`
+	ID      string    `json:"id"`
+	Name    string    `json:"name,omitempty"`
+	Engine  string    `json:"engine"`
+	Version string    `json:"version"`
+	EOL     time.Time `json:"eol"`
+	Past    bool      `json:"past"`
+}
+
+// findEOLVersions returns the databases and functions whose engine version (or runtime)
+// reached its end of life before now or will within the given days, soonest end of life first
+func findEOLVersions(graphs []*graph.Graph, overrides map[string]interface{}, now time.Time, withinDays int) ([]*eolFinding, error) {
+	limit := now.AddDate(0, 0, withinDays)
+
+	var findings []*eolFinding
+	for _, g := range graphs {
+		for _, resType := range []string{cloud.Database, cloud.Function} {
+			resources, err := g.GetAllResources(resType)
+			if err != nil {
+				return nil, err
+			}
+			for _, r := range resources {
+				engine, _ := r.Properties[properties.Engine].(string)
+				version, _ := r.Properties[properties.EngineVersion].(string)
+				if resType == cloud.Function {
+					engine = aws.LambdaEngine
+					version, _ = r.Properties[properties.Runtime].(string)
+				}
+				if engine == "" || version == "" {
+					continue
+				}
+				eol, ok, err := aws.LookupEOL(engine, version, overrides)
+				if err != nil {
+					return nil, err
+				}
+				if !ok || eol.After(limit) {
+					continue
+				}
+				name, _ := r.Properties[properties.Name].(string)
+				findings = append(findings, &eolFinding{
+					Type: resType, ID: r.Id(), Name: name, Engine: engine, Version: version, EOL: eol, Past: !eol.After(now),
+				})
+			}
+		}
+	}
+
+	sort.Slice(findings, func(i, j int) bool {
+		if !findings[i].EOL.Equal(findings[j].EOL) {
+			return findings[i].EOL.Before(findings[j].EOL)
+		}
+		return findings[i].ID < findings[j].ID
+	})
+
+	return findings, nil
+}
+
+func printEOLFindings(w io.Writer, findings []*eolFinding) error {
+	if listIDsOnlyFlag {
+		for _, f := range findings {
+			fmt.Fprintln(w, f.ID)
+		}
+		return nil
+	}
+	switch listingFormat {
+	case "json":
+		return json.NewEncoder(w).Encode(findings)
+	case "table":
+	default:
+		return fmt.Errorf("unsupported format '%s' for eol: use table or json", listingFormat)
+	}
+
+	if len(findings) == 0 {
+		fmt.Fprintln(w, "No version nearing or past end of life found")
+		return nil
+	}
+
+	table := tablewriter.NewWriter(w)
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAutoWrapText(false)
+	if !noHeadersFlag {
+		table.SetHeader([]string{"Type", "Id", "Name", "Engine", "Version", "End of life"})
+	}
+	for _, f := range findings {
+		eol := f.EOL.Format("2006-01-02")
+		if f.Past {
+			eol += " (past)"
+		}
+		table.Append([]string{f.Type, f.ID, f.Name, f.Engine, f.Version, eol})
+	}
+	table.Render()
+
+	return nil
+}
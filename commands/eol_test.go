@@ -0,0 +1,65 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+)
+
+func TestFindEOLVersions(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddResource(
+		resourcetest.Database("db-old").Prop(properties.Engine, "mysql").Prop(properties.EngineVersion, "5.5.61").Build(),
+		resourcetest.Database("db-soon").Prop(properties.Engine, "postgres").Prop(properties.EngineVersion, "9.6.11").Build(),
+		resourcetest.Database("db-recent").Prop(properties.Engine, "postgres").Prop(properties.EngineVersion, "13.4").Build(),
+		resourcetest.Database("db-custom").Prop(properties.Engine, "oracle-ee").Prop(properties.EngineVersion, "12.1.0.2.v1").Build(),
+		resourcetest.Function("fn-old").Prop(properties.Name, "fn-old").Prop(properties.Runtime, "python2.7").Build(),
+		resourcetest.Function("fn-recent").Prop(properties.Runtime, "python3.9").Build(),
+	)
+	overrides := map[string]interface{}{"aws.eol.oracle-ee.12.1": "2022-07-31", "aws.eol.postgres.9.6": "2022-06-01"}
+	now := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	findings, err := findEOLVersions([]*graph.Graph{g}, overrides, now, 180)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	date := func(s string) time.Time { d, _ := time.Parse("2006-01-02", s); return d }
+	expected := []*eolFinding{
+		{Type: cloud.Database, ID: "db-old", Engine: "mysql", Version: "5.5.61", EOL: date("2020-05-12"), Past: true},
+		{Type: cloud.Function, ID: "fn-old", Name: "fn-old", Engine: "lambda", Version: "python2.7", EOL: date("2021-07-15"), Past: true},
+		{Type: cloud.Database, ID: "db-soon", Engine: "postgres", Version: "9.6.11", EOL: date("2022-06-01")},
+		{Type: cloud.Database, ID: "db-custom", Engine: "oracle-ee", Version: "12.1.0.2.v1", EOL: date("2022-07-31")},
+	}
+	if got, want := findings, expected; !reflect.DeepEqual(got, want) {
+		for _, f := range got {
+			t.Logf("%#v", f)
+		}
+		t.Fatalf("got %d findings, want %d", len(got), len(want))
+	}
+
+	if _, err := findEOLVersions([]*graph.Graph{g}, map[string]interface{}{"aws.eol.mysql.5.5": "soon"}, now, 180); err == nil {
+		t.Fatal("expected error with invalid eol date")
+	}
+}
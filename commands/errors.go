@@ -21,11 +21,17 @@ import (
 	"os"
 
 	"github.com/fatih/color"
+	"github.com/wallix/awless/logger"
 )
 
 func exitOn(err error) {
 	if err != nil {
+		if commandTimedOut() {
+			logger.ExtraVerbosef("timeout error: %s", err)
+			err = timeoutError()
+		}
 		fmt.Fprintln(os.Stderr, color.RedString("[error]  "), err)
+		endNotifiedOperation(err)
 		os.Exit(1)
 	}
 }
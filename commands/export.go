@@ -0,0 +1,347 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/database"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/sync"
+	"github.com/wallix/awless/sync/repo"
+	"github.com/wallix/awless/template"
+)
+
+// bundleFormatVersion is the version of the archive layout written by export.
+// Import refuses bundles of a greater version, and ignores the entries it does not know
+const bundleFormatVersion = 1
+
+const (
+	bundleManifestFile = "manifest.json"
+	bundleConfigFile   = "config.json"
+	bundleGraphDir     = "graph/"
+	bundleTemplateDir  = "templates/"
+	bundleGraphExt     = ".triples"
+)
+
+// exportExcludedConfigKeys are not exported as their values may embed secrets (ex: webhook tokens)
+var exportExcludedConfigKeys = []string{"aws.notify.webhook", "aws.notify.command"}
+
+var importConfigFlag bool
+
+func init() {
+	RootCmd.AddCommand(exportCmd)
+	RootCmd.AddCommand(importCmd)
+
+	importCmd.Flags().BoolVar(&importConfigFlag, "config", false, "Also apply the config of the bundle to the local config")
+}
+
+type bundleManifest struct {
+	FormatVersion int       `json:"format_version"`
+	AwlessVersion string    `json:"awless_version"`
+	Created       time.Time `json:"created"`
+	Region        string    `json:"region"`
+	Profile       string    `json:"profile"`
+}
+
+type bundle struct {
+	Manifest  bundleManifest
+	Config    map[string]string
+	Graphs    map[string][]byte
+	Templates []*template.TemplateExecution
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export FILE",
+	Short: "Bundle the local graph, the templates run and the config (without secrets) in an archive to share or archive them",
+	Example: `  awless export infra.tar.gz
+  awless import infra.tar.gz`,
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("FILE required")
+		}
+		b := &bundle{
+			Manifest: bundleManifest{
+				FormatVersion: bundleFormatVersion,
+				AwlessVersion: config.Version,
+				Created:       time.Now().UTC(),
+				Region:        config.GetAWSRegion(),
+				Profile:       config.GetAWSProfile(),
+			},
+			Config: sanitizeConfig(config.Config, config.Defaults),
+			Graphs: make(map[string][]byte),
+		}
+		for _, file := range sync.LocalGraphFiles() {
+			content, err := ioutil.ReadFile(file)
+			exitOn(err)
+			b.Graphs[filepath.Base(file)] = content
+		}
+		exitOn(database.Execute(func(db *database.DB) error {
+			loaded, err := db.ListTemplates()
+			if err != nil {
+				return err
+			}
+			for _, l := range loaded {
+				if l.Err != nil {
+					logger.Warningf("skipping template %s: %s", l.Key, l.Err)
+					continue
+				}
+				b.Templates = append(b.Templates, redactTemplateExecution(l.TplExec))
+			}
+			return nil
+		}))
+
+		f, err := os.Create(args[0])
+		exitOn(err)
+		defer f.Close()
+		exitOn(writeBundle(f, b))
+		logger.Infof("exported %d graph files, %d templates and %d config values to %s", len(b.Graphs), len(b.Templates), len(b.Config), args[0])
+		return nil
+	},
+}
+
+var importCmd = &cobra.Command{
+	Use:               "import FILE",
+	Short:             "Restore the local graph and the templates (and with --config the config) of an archive made with `awless export`",
+	Example:           "  awless import infra.tar.gz\n  awless import infra.tar.gz --config",
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initSyncerHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("FILE required")
+		}
+		f, err := os.Open(args[0])
+		exitOn(err)
+		defer f.Close()
+		b, err := readBundle(f)
+		exitOn(err)
+
+		if region := config.GetAWSRegion(); b.Manifest.Region != region {
+			logger.Warningf("the bundle was exported from region %s, you are currently in region %s", b.Manifest.Region, region)
+		}
+
+		var names []string
+		for name, content := range b.Graphs {
+			exitOn(ioutil.WriteFile(filepath.Join(repo.Dir(), name), content, 0600))
+			names = append(names, name)
+		}
+		if len(names) > 0 {
+			sort.Strings(names)
+			exitOn(sync.DefaultSyncer.Commit(names...))
+		}
+
+		var imported int
+		exitOn(database.Execute(func(db *database.DB) error {
+			for _, tplExec := range b.Templates {
+				if _, err := db.GetTemplate(tplExec.ID); err == nil {
+					logger.Verbosef("template %s already stored", tplExec.ID)
+					continue
+				}
+				if err := db.AddTemplate(tplExec); err != nil {
+					return err
+				}
+				imported++
+			}
+			return nil
+		}))
+
+		if importConfigFlag {
+			for k, v := range b.Config {
+				if err := config.Set(k, v); err != nil {
+					logger.Warningf("config %s: %s", k, err)
+				}
+			}
+		}
+		logger.Infof("imported %d graph files and %d templates exported on %s by awless %s", len(names), imported, b.Manifest.Created.Format(time.RFC3339), b.Manifest.AwlessVersion)
+		return nil
+	},
+}
+
+// sanitizeConfig returns the config values as strings, without the keys that may hold secrets
+func sanitizeConfig(confs ...map[string]interface{}) map[string]string {
+	sanitized := make(map[string]string)
+	for _, conf := range confs {
+		for k, v := range conf {
+			if !isSensitiveKey(k) && !isExportExcludedConfigKey(k) {
+				sanitized[k] = fmt.Sprint(v)
+			}
+		}
+	}
+	return sanitized
+}
+
+func isExportExcludedConfigKey(k string) bool {
+	for _, excluded := range exportExcludedConfigKeys {
+		if k == excluded {
+			return true
+		}
+	}
+	return false
+}
+
+// redactTemplateExecution replaces the values of sensitive params and fillers (ex: password)
+// in the commands, the source and the fillers of an executed template
+func redactTemplateExecution(tplExec *template.TemplateExecution) *template.TemplateExecution {
+	var redacted bool
+	for _, cmd := range tplExec.CommandNodesIterator() {
+		redacted = redactParams(cmd.Params) || redacted
+	}
+	for k := range tplExec.Fillers {
+		if isSensitiveKey(k) {
+			tplExec.Fillers[k] = redactedValue
+		}
+	}
+	if !redacted {
+		return tplExec
+	}
+	if source, err := template.Parse(tplExec.Source); err == nil {
+		for _, cmd := range source.CommandNodesIterator() {
+			redactParams(cmd.Params)
+		}
+		tplExec.Source = source.String()
+	} else {
+		tplExec.Source = tplExec.Template.String()
+	}
+	return tplExec
+}
+
+func redactParams(params map[string]interface{}) (redacted bool) {
+	for k := range params {
+		if isSensitiveKey(k) {
+			params[k] = redactedValue
+			redacted = true
+		}
+	}
+	return
+}
+
+func writeBundle(w io.Writer, b *bundle) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	add := func(name string, content []byte) error {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(content)), ModTime: b.Manifest.Created}); err != nil {
+			return err
+		}
+		_, err := tw.Write(content)
+		return err
+	}
+	addJSON := func(name string, v interface{}) error {
+		content, err := json.MarshalIndent(v, "", " ")
+		if err != nil {
+			return fmt.Errorf("%s: %s", name, err)
+		}
+		return add(name, content)
+	}
+
+	if err := addJSON(bundleManifestFile, b.Manifest); err != nil {
+		return err
+	}
+	if err := addJSON(bundleConfigFile, b.Config); err != nil {
+		return err
+	}
+	var names []string
+	for name := range b.Graphs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := add(bundleGraphDir+name, b.Graphs[name]); err != nil {
+			return err
+		}
+	}
+	for _, tplExec := range b.Templates {
+		if err := addJSON(bundleTemplateDir+tplExec.ID+".json", tplExec); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func readBundle(r io.Reader) (*bundle, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("read bundle: %s", err)
+	}
+	tr := tar.NewReader(gz)
+
+	b := &bundle{Config: make(map[string]string), Graphs: make(map[string][]byte)}
+	var hasManifest bool
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read bundle: %s", err)
+		}
+		content, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("read bundle %s: %s", header.Name, err)
+		}
+		name := path.Clean(header.Name)
+		switch {
+		case name == bundleManifestFile:
+			if err := json.Unmarshal(content, &b.Manifest); err != nil {
+				return nil, fmt.Errorf("read bundle %s: %s", name, err)
+			}
+			hasManifest = true
+		case name == bundleConfigFile:
+			if err := json.Unmarshal(content, &b.Config); err != nil {
+				return nil, fmt.Errorf("read bundle %s: %s", name, err)
+			}
+		case strings.HasPrefix(name, bundleGraphDir) && strings.HasSuffix(name, bundleGraphExt):
+			b.Graphs[path.Base(name)] = content
+		case strings.HasPrefix(name, bundleTemplateDir):
+			tplExec := &template.TemplateExecution{}
+			if err := tplExec.UnmarshalJSON(content); err != nil {
+				return nil, fmt.Errorf("read bundle %s: %s", name, err)
+			}
+			b.Templates = append(b.Templates, tplExec)
+		}
+	}
+
+	if !hasManifest {
+		return nil, fmt.Errorf("read bundle: missing %s, not an awless export", bundleManifestFile)
+	}
+	if v := b.Manifest.FormatVersion; v > bundleFormatVersion {
+		return nil, fmt.Errorf("bundle format version %d unsupported by this awless (up to %d): upgrade awless", v, bundleFormatVersion)
+	}
+	return b, nil
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wallix/awless/template"
+)
+
+func TestBundleRoundTrip(t *testing.T) {
+	tpl, err := template.Parse("create user name=bob password=s3cr3t\ncreate accesskey user=bob")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tplExec := &template.TemplateExecution{
+		Template: tpl,
+		Locale:   "eu-west-1",
+		Source:   "create user name=bob password=s3cr3t\ncreate accesskey user=bob",
+		Fillers:  map[string]interface{}{"user.password": "s3cr3t", "user.name": "bob"},
+	}
+	tplExec.ID = "01BA7RV6ES86PZYCM3H28WM6KZ"
+
+	b := &bundle{
+		Manifest: bundleManifest{FormatVersion: bundleFormatVersion, AwlessVersion: "v0.1.1", Created: time.Unix(1500000000, 0).UTC(), Region: "eu-west-1", Profile: "default"},
+		Config: sanitizeConfig(
+			map[string]interface{}{"aws.region": "eu-west-1", "aws.notify.webhook": "https://hooks.slack.com/services/T0/B0/token", "autosync": true},
+			map[string]interface{}{"instance.type": "t2.micro"},
+		),
+		Graphs:    map[string][]byte{"infra.triples": []byte("<a> <b> <c> .\n")},
+		Templates: []*template.TemplateExecution{redactTemplateExecution(tplExec)},
+	}
+
+	var buff bytes.Buffer
+	if err := writeBundle(&buff, b); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buff.String(), "s3cr3t") {
+		t.Fatal("secret exported")
+	}
+
+	read, err := readBundle(&buff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := read.Manifest, b.Manifest; got != want {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	expConfig := map[string]string{"aws.region": "eu-west-1", "autosync": "true", "instance.type": "t2.micro"}
+	if got, want := read.Config, expConfig; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := read.Graphs, b.Graphs; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	if got, want := len(read.Templates), 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	imported := read.Templates[0]
+	if got, want := imported.ID, tplExec.ID; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := imported.Fillers["user.password"], redactedValue; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := imported.Fillers["user.name"], "bob"; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if got, want := imported.CommandNodesIterator()[0].Params["password"], redactedValue; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if strings.Contains(imported.Source, "s3cr3t") || !strings.Contains(imported.Source, "create accesskey user=bob") {
+		t.Fatalf("unexpected source %q", imported.Source)
+	}
+
+	t.Run("newer format version", func(t *testing.T) {
+		b.Manifest.FormatVersion = bundleFormatVersion + 1
+		var buff bytes.Buffer
+		if err := writeBundle(&buff, b); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := readBundle(&buff); err == nil || !strings.Contains(err.Error(), "unsupported") {
+			t.Fatalf("expected unsupported version error, got %v", err)
+		}
+	})
+}
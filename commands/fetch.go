@@ -0,0 +1,69 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/sync"
+)
+
+func init() {
+	RootCmd.AddCommand(fetchCmd)
+	outputFormatFlag(fetchCmd.Flags(), &listingFormat, "table", "json")
+}
+
+var fetchCmd = &cobra.Command{
+	Use:   "fetch RESOURCE_TYPE ID",
+	Short: "Fetch a single resource from the cloud by type and id, refresh it in your local rdf store and show it. Much faster than a sync for a quick lookup",
+	Example: `  awless fetch instance i-8d43b21b
+  awless fetch securitygroup sg-0a1b2c3d -o json`,
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initOutputFormatHook, initCloudServicesHook, initSyncerHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 2 {
+			return errors.New("RESOURCE_TYPE and ID required. See examples.")
+		}
+		resType, id := strings.ToLower(args[0]), args[1]
+		if _, ok := aws.ServicePerResourceType[resType]; !ok {
+			return fmt.Errorf("unknown resource type '%s'", resType)
+		}
+
+		srv, err := cloud.GetServiceForType(resType)
+		exitOn(err)
+
+		start := time.Now()
+		_, err = sync.DefaultSyncer.SyncResource(srv, resType, id)
+		exitOn(err)
+		logger.Verbosef("fetched %s %s in %s", resType, id, time.Since(start))
+
+		gph := sync.LoadCurrentLocalGraph(srv.Name())
+		resource, err := gph.GetResource(resType, id)
+		exitOn(err)
+		showResource(resource, gph)
+
+		return nil
+	},
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws"
+	"github.com/wallix/awless/logger"
+)
+
+var (
+	generatePolicyRoleFlag string
+	generatePolicyDaysFlag int
+)
+
+func init() {
+	RootCmd.AddCommand(generateCmd)
+	generateCmd.AddCommand(generatePolicyCmd)
+
+	generatePolicyCmd.Flags().StringVar(&generatePolicyRoleFlag, "role", "", "ARN of the role to generate the policy for")
+	generatePolicyCmd.Flags().IntVar(&generatePolicyDaysFlag, "days", 30, fmt.Sprintf("Number of days of CloudTrail events to analyze (at most %d)", aws.CloudTrailLookupMaxDays))
+}
+
+var generateCmd = &cobra.Command{
+	Use:               "generate",
+	Short:             "Generate drafts (policies, ...) from the activity of your AWS account",
+	PersistentPreRun:  applyHooks(initAwlessEnvHook, initLoggerHook, initCloudServicesHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade),
+}
+
+var generatePolicyCmd = &cobra.Command{
+	Use:   "policy",
+	Short: "Generate a least-privilege policy draft from the actions a role actually called (CloudTrail)",
+	Long: fmt.Sprintf(`Generate a least-privilege policy draft from the actions a role actually called, as recorded in the CloudTrail event history of the current region.
+
+The policy is a starting draft that requires review before use:
+  - the event history only covers the last %d days of management events of the region: data events (ex: S3 object reads), calls made in other regions or less often than the lookback window are missed
+  - calls denied to the role are not granted
+  - actions are granted on all resources ("*"): restrict them to the resources the role needs`, aws.CloudTrailLookupMaxDays),
+	Example: "  awless generate policy --role arn:aws:iam::123456789012:role/deployer\n  awless generate policy --role arn:aws:iam::123456789012:role/deployer --days 90 > policy.json",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		if generatePolicyRoleFlag == "" {
+			exitOn(errors.New("missing required --role flag"))
+		}
+		if !strings.HasPrefix(generatePolicyRoleFlag, "arn:") || !strings.Contains(generatePolicyRoleFlag, ":role/") {
+			exitOn(fmt.Errorf("invalid role ARN '%s' (ex: arn:aws:iam::123456789012:role/deployer)", generatePolicyRoleFlag))
+		}
+		if generatePolicyDaysFlag < 1 || generatePolicyDaysFlag > aws.CloudTrailLookupMaxDays {
+			exitOn(fmt.Errorf("--days must be between 1 and %d (CloudTrail event history lookback)", aws.CloudTrailLookupMaxDays))
+		}
+		if aws.CloudTrail == nil {
+			exitOn(aws.ErrMockUnsupported)
+		}
+
+		end := time.Now().UTC()
+		start := end.AddDate(0, 0, -generatePolicyDaysFlag)
+		logger.Verbosef("looking up CloudTrail events from %s to %s", start.Format(time.RFC3339), end.Format(time.RFC3339))
+		events, err := aws.CloudTrail.LookupEvents(start, end)
+		exitOn(err)
+
+		policy, count := generateRolePolicy(events, generatePolicyRoleFlag)
+		if count == 0 {
+			exitOn(fmt.Errorf("no CloudTrail event found for role %s in the last %d days", generatePolicyRoleFlag, generatePolicyDaysFlag))
+		}
+		logger.Verbosef("%d events of role %s analyzed", count, generatePolicyRoleFlag)
+
+		b, err := json.MarshalIndent(policy, "", "  ")
+		exitOn(err)
+		fmt.Println(string(b))
+
+		logger.Warningf("this policy is a DRAFT to review before use: it is built from the last %d days of management events of the current region only (data events, other regions and rarer calls are missed) and grants the actions on all resources", generatePolicyDaysFlag)
+	},
+}
+
+type policyDocument struct {
+	Version   string             `json:"Version"`
+	Statement []*policyStatement `json:"Statement"`
+}
+
+type policyStatement struct {
+	Effect   string   `json:"Effect"`
+	Action   []string `json:"Action"`
+	Resource string   `json:"Resource"`
+}
+
+// eventSourceServices maps the CloudTrail event sources whose service prefix in IAM actions differs
+var eventSourceServices = map[string]string{
+	"monitoring": "cloudwatch",
+	"email":      "ses",
+}
+
+// deniedErrorCodes are the error codes of calls denied to the caller
+var deniedErrorCodes = map[string]bool{
+	"AccessDenied":                     true,
+	"AccessDeniedException":            true,
+	"UnauthorizedOperation":            true,
+	"Client.UnauthorizedOperation":     true,
+	"Client.AccessDenied":              true,
+	"UnauthorizedAccess":               true,
+	"Client.UnauthorizedAccess":        true,
+	"AuthorizationError":               true,
+	"NotAuthorizedException":           true,
+	"InsufficientPermissionsException": true,
+}
+
+// versionedEventName matches the API version suffix of some event names (ex: lambda ListFunctions20150331)
+var versionedEventName = regexp.MustCompile(`\d{8}(v\d+)?$`)
+
+// generateRolePolicy builds a policy allowing, per service, the actions called with
+// the credentials of the role. Calls denied to the role are ignored. It returns
+// the policy and the number of events of the role
+func generateRolePolicy(events []*aws.TrailEvent, roleArn string) (*policyDocument, int) {
+	var count int
+	actions := make(map[string]map[string]struct{})
+	for _, e := range events {
+		if e.SessionIssuer != roleArn {
+			continue
+		}
+		count++
+		if deniedErrorCodes[e.ErrorCode] {
+			continue
+		}
+		service := strings.TrimSuffix(e.Source, ".amazonaws.com")
+		if s, ok := eventSourceServices[service]; ok {
+			service = s
+		}
+		if actions[service] == nil {
+			actions[service] = make(map[string]struct{})
+		}
+		actions[service][service+":"+versionedEventName.ReplaceAllString(e.Name, "")] = struct{}{}
+	}
+
+	var services []string
+	for s := range actions {
+		services = append(services, s)
+	}
+	sort.Strings(services)
+
+	policy := &policyDocument{Version: "2012-10-17", Statement: []*policyStatement{}}
+	for _, s := range services {
+		stmt := &policyStatement{Effect: "Allow", Resource: "*"}
+		for a := range actions[s] {
+			stmt.Action = append(stmt.Action, a)
+		}
+		sort.Strings(stmt.Action)
+		policy.Statement = append(policy.Statement, stmt)
+	}
+
+	return policy, count
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/wallix/awless/aws"
+)
+
+func TestGenerateRolePolicy(t *testing.T) {
+	role := "arn:aws:iam::123456789012:role/deployer"
+	events := []*aws.TrailEvent{
+		{Source: "ec2.amazonaws.com", Name: "RunInstances", SessionIssuer: role},
+		{Source: "ec2.amazonaws.com", Name: "DescribeInstances", SessionIssuer: role},
+		{Source: "ec2.amazonaws.com", Name: "RunInstances", SessionIssuer: role},
+		{Source: "ec2.amazonaws.com", Name: "TerminateInstances", SessionIssuer: role, ErrorCode: "Client.UnauthorizedOperation"},
+		{Source: "ec2.amazonaws.com", Name: "StopInstances", SessionIssuer: role, ErrorCode: "Client.IncorrectInstanceState"},
+		{Source: "lambda.amazonaws.com", Name: "UpdateFunctionConfiguration20150331v2", SessionIssuer: role},
+		{Source: "monitoring.amazonaws.com", Name: "PutMetricData", SessionIssuer: role},
+		{Source: "s3.amazonaws.com", Name: "DeleteBucket", SessionIssuer: "arn:aws:iam::123456789012:role/admin"},
+		{Source: "iam.amazonaws.com", Name: "CreateUser"},
+	}
+
+	policy, count := generateRolePolicy(events, role)
+	if got, want := count, 7; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	expected := &policyDocument{
+		Version: "2012-10-17",
+		Statement: []*policyStatement{
+			{Effect: "Allow", Action: []string{"cloudwatch:PutMetricData"}, Resource: "*"},
+			{Effect: "Allow", Action: []string{"ec2:DescribeInstances", "ec2:RunInstances", "ec2:StopInstances"}, Resource: "*"},
+			{Effect: "Allow", Action: []string{"lambda:UpdateFunctionConfiguration"}, Resource: "*"},
+		},
+	}
+	if got, want := policy, expected; !reflect.DeepEqual(got, want) {
+		for _, s := range got.Statement {
+			t.Logf("%#v", s)
+		}
+		t.Fatalf("got %d statements, want %d", len(got.Statement), len(want.Statement))
+	}
+}
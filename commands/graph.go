@@ -0,0 +1,76 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/console"
+	"github.com/wallix/awless/sync"
+)
+
+var graphCompactRetentionFlag int
+
+func init() {
+	RootCmd.AddCommand(graphCmd)
+	graphCmd.AddCommand(graphCompactCmd)
+	graphCompactCmd.Flags().IntVar(&graphCompactRetentionFlag, "retention", -1, "Number of days of sync snapshots to keep; 0 keeps them all (default to the 'snapshots.retention' config)")
+}
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Maintain the local graph store of your synced resources",
+}
+
+var graphCompactCmd = &cobra.Command{
+	Use:   "compact",
+	Short: "Compact the local graph store: remove dangling and duplicate triples, and prune the sync snapshots beyond retention",
+	Example: `  awless graph compact
+  awless graph compact --retention 7`,
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initSyncerHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		retention := config.GetSnapshotsRetention()
+		if graphCompactRetentionFlag >= 0 {
+			retention = time.Duration(graphCompactRetentionFlag) * 24 * time.Hour
+		}
+		stats, err := sync.CompactLocalStore(retention)
+		exitOn(err)
+		printCompactStats(os.Stdout, stats, retention)
+		return nil
+	},
+}
+
+func printCompactStats(w io.Writer, stats *sync.CompactStats, retention time.Duration) {
+	fmt.Fprintf(w, "graphs: %d triples removed out of %d (%d files)\n", stats.TriplesRemoved, stats.TriplesBefore, stats.Files)
+	if retention > 0 {
+		fmt.Fprintf(w, "snapshots: %d older than %d days pruned\n", stats.SnapshotsPruned, int(retention.Hours()/24))
+	} else {
+		fmt.Fprintln(w, "snapshots: all kept (no retention)")
+	}
+	reclaimed := stats.BytesBefore - stats.BytesAfter
+	if reclaimed < 0 {
+		reclaimed = 0
+	}
+	fmt.Fprintf(w, "space reclaimed: %s (store now %s)\n", console.HumanizeStorage(uint64(reclaimed), 0), console.HumanizeStorage(uint64(stats.BytesAfter), 0))
+}
@@ -25,28 +25,49 @@ import (
 	"github.com/wallix/awless/cloud"
 	"github.com/wallix/awless/config"
 	"github.com/wallix/awless/console"
+	"github.com/wallix/awless/database"
 	"github.com/wallix/awless/graph"
 	"github.com/wallix/awless/sync"
 	"github.com/wallix/awless/sync/repo"
 )
 
 var (
-	showProperties bool
+	showProperties    bool
+	historyFormatFlag string
 )
 
 func init() {
 	RootCmd.AddCommand(historyCmd)
+	historyCmd.AddCommand(historyInfraCmd)
 
-	historyCmd.Flags().BoolVarP(&showProperties, "properties", "p", false, "Full diff with resources properties")
+	outputFormatFlag(historyCmd.Flags(), &historyFormatFlag, "table", "csv", "json")
+	historyInfraCmd.Flags().BoolVarP(&showProperties, "properties", "p", false, "Full diff with resources properties")
 }
 
 var historyCmd = &cobra.Command{
 	Use:               "history",
-	Hidden:            true,
-	Short:             "(in progress) Show a infra resource history & changes using your locally sync snapshots",
-	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, initSyncerHook),
+	Short:             "Show the audit log of the actions run through awless (ex: export it with `awless history --format csv`)",
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initOutputFormatHook),
 	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade),
 
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var entries []*database.AuditEntry
+		exitOn(database.Execute(func(db *database.DB) (dberr error) {
+			entries, dberr = db.ListAuditEntries()
+			return
+		}))
+
+		exitOn(printAuditEntries(os.Stdout, entries, historyFormatFlag))
+		return nil
+	},
+}
+
+var historyInfraCmd = &cobra.Command{
+	Use:              "infra",
+	Hidden:           true,
+	Short:            "(in progress) Show a infra resource history & changes using your locally sync snapshots",
+	PersistentPreRun: applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, initSyncerHook),
+
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if !repo.IsGitInstalled() {
 			fmt.Printf("No history available. You need to install git")
@@ -19,9 +19,13 @@ package commands
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/wallix/awless/aws"
+	awsconfig "github.com/wallix/awless/aws/config"
 	"github.com/wallix/awless/config"
 	"github.com/wallix/awless/database"
 	"github.com/wallix/awless/logger"
@@ -38,25 +42,59 @@ func applyHooks(funcs ...func(*cobra.Command, []string) error) func(*cobra.Comma
 	}
 }
 
+const outputFormatsAnnotation = "awless_output_formats"
+
+// outputFormatFlag defines the `--format` (or `-o`) flag of a command given its supported output formats,
+// the first one being the default
+func outputFormatFlag(flags *pflag.FlagSet, p *string, formats ...string) {
+	flags.StringVarP(p, "format", "o", formats[0], fmt.Sprintf("Output format: %s (default to %s, or to config %s)", strings.Join(formats, ", "), formats[0], config.OutputFormatConfigKey))
+	flags.SetAnnotation("format", outputFormatsAnnotation, formats)
+}
+
+// initOutputFormatHook applies the output format set in config when not given on the command line
+// and supported by the command
+func initOutputFormatHook(cmd *cobra.Command, args []string) error {
+	flag := cmd.Flags().Lookup("format")
+	if flag == nil || flag.Changed {
+		return nil
+	}
+	format, err := config.GetOutputFormat()
+	if err != nil {
+		return err
+	}
+	for _, supported := range flag.Annotations[outputFormatsAnnotation] {
+		if supported == format {
+			return flag.Value.Set(format)
+		}
+	}
+	return nil
+}
+
 func initAwlessEnvHook(cmd *cobra.Command, args []string) error {
+	initCommandDeadline()
 	if err := config.InitAwlessEnv(); err != nil {
 		return fmt.Errorf("cannot init awless environment: %s", err)
 	}
+	if wd, err := os.Getwd(); err == nil {
+		if _, err := config.LoadProjectConfig(wd); err != nil {
+			return err
+		}
+	}
 	if awsRegionGlobalFlag != "" {
-		if err := config.SetVolatile(config.RegionConfigKey, awsRegionGlobalFlag); err != nil {
+		if err := config.SetVolatileFrom(config.FlagSource+" --aws-region", config.RegionConfigKey, awsRegionGlobalFlag); err != nil {
 			return err
 		}
 	} else if envRegion := os.Getenv("AWS_DEFAULT_REGION"); envRegion != "" {
-		if err := config.SetVolatile(config.RegionConfigKey, envRegion); err != nil {
+		if err := config.SetVolatileFrom(config.EnvSource+" AWS_DEFAULT_REGION", config.RegionConfigKey, envRegion); err != nil {
 			return err
 		}
 	}
 	if awsProfileGlobalFlag != "" {
-		if err := config.SetVolatile(config.ProfileConfigKey, awsProfileGlobalFlag); err != nil {
+		if err := config.SetVolatileFrom(config.FlagSource+" --aws-profile", config.ProfileConfigKey, awsProfileGlobalFlag); err != nil {
 			return err
 		}
 	} else if envProfile := os.Getenv("AWS_DEFAULT_PROFILE"); envProfile != "" {
-		if err := config.SetVolatile(config.ProfileConfigKey, envProfile); err != nil {
+		if err := config.SetVolatileFrom(config.EnvSource+" AWS_DEFAULT_PROFILE", config.ProfileConfigKey, envProfile); err != nil {
 			return err
 		}
 	}
@@ -64,10 +102,33 @@ func initAwlessEnvHook(cmd *cobra.Command, args []string) error {
 }
 
 func initCloudServicesHook(cmd *cobra.Command, args []string) error {
+	awsConf := config.GetConfigWithPrefix("aws.")
+	if aws.IsMock(awsConf) {
+		os.Setenv("__AWLESS_RDF_DIR", filepath.Join(os.Getenv("__AWLESS_HOME"), "aws", "mock"))
+	}
+	if region, _ := awsConf[config.RegionConfigKey].(string); region == "" {
+		profile, _ := awsConf[config.ProfileConfigKey].(string)
+		if region = aws.ProfileRegion(profile); region != "" {
+			logger.Verbosef("no region configured: using region '%s' of the AWS profile", region)
+			if err := config.SetVolatileFrom(fmt.Sprintf("AWS profile '%s'", profile), config.RegionConfigKey, region); err != nil {
+				return err
+			}
+			awsConf[config.RegionConfigKey] = region
+		}
+	}
+	if region, _ := awsConf[config.RegionConfigKey].(string); region == awsconfig.AllEnabledRegions {
+		if cmd.Name() != syncCmdName {
+			return fmt.Errorf("region '%s' is only supported by sync: give a region with -r", region)
+		}
+		profile, _ := awsConf[config.ProfileConfigKey].(string)
+		if awsConf[config.RegionConfigKey] = aws.ProfileRegion(profile); awsConf[config.RegionConfigKey] == "" {
+			awsConf[config.RegionConfigKey] = "us-east-1"
+		}
+		logger.ExtraVerbosef("syncing all enabled regions: looking up regions from region '%v'", awsConf[config.RegionConfigKey])
+	}
 	if localGlobalFlag {
 		return nil
 	}
-	awsConf := config.GetConfigWithPrefix("aws.")
 	_, ok := awsConf[config.ProfileConfigKey]
 	if !ok {
 		awsConf[config.ProfileConfigKey] = "default"
@@ -20,13 +20,18 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/wallix/awless/aws"
 	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/config"
 	"github.com/wallix/awless/console"
 	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/logger"
 	"github.com/wallix/awless/sync"
 )
 
@@ -37,8 +42,23 @@ var (
 	listingTagKeyFiltersFlag   []string
 	listingTagValueFiltersFlag []string
 	listOnlyIDs                bool
+	listIDsOnlyFlag            bool
 	noHeadersFlag              bool
 	sortBy                     []string
+	listWithRelationsFlag      bool
+	listPageFlag               int
+	listPageSizeFlag           int
+	listPagerFlag              bool
+	listSinceFlag              string
+	listUntilFlag              string
+
+	listUnusedImagesFlag        bool
+	listImagesOlderThanDaysFlag int
+	listImagesRepositoryFlag    string
+
+	listFindingsSeverityFlag string
+
+	listUnhealthyHealthChecksFlag bool
 )
 
 func init() {
@@ -57,25 +77,48 @@ func init() {
 		}
 		sort.Strings(resources)
 		for _, resType := range resources {
-			listCmd.AddCommand(listSpecificResourceCmd(resType))
+			cmd := listSpecificResourceCmd(resType)
+			if resType == cloud.Image {
+				cmd.Flags().BoolVar(&listUnusedImagesFlag, "unused", false, "List only images not used by any instance or launch configuration")
+				cmd.Flags().IntVar(&listImagesOlderThanDaysFlag, "older-than-days", 0, "List only images created more than the given number of days ago")
+				cmd.Flags().StringVar(&listImagesRepositoryFlag, "repo", "", "List the container images of the given ECR repository (by name) instead of AMIs")
+			}
+			if resType == cloud.Finding {
+				cmd.Flags().StringVar(&listFindingsSeverityFlag, "severity", "", "List only findings of at least the given severity: low, medium or high")
+			}
+			if resType == cloud.HealthCheck {
+				cmd.Flags().BoolVar(&listUnhealthyHealthChecksFlag, "unhealthy", false, "List only health checks currently reported unhealthy by the Route53 health checkers")
+			}
+			listCmd.AddCommand(cmd)
 		}
 	}
 
-	listCmd.PersistentFlags().StringVar(&listingFormat, "format", "table", "Output format: table, csv, tsv, json (default to table)")
-	listCmd.PersistentFlags().StringSliceVar(&listingFiltersFlag, "filter", []string{}, "Filter resources given key/values fields (case insensitive). Ex: --filter type=t2.micro")
-	listCmd.PersistentFlags().StringSliceVar(&listingTagFiltersFlag, "tag", []string{}, "Filter EC2 resources given tags (case sensitive!). Ex: --tag Env=Production")
+	outputFormatFlag(listCmd.PersistentFlags(), &listingFormat, "table", "wide", "csv", "tsv", "json")
+	listCmd.PersistentFlags().StringSliceVar(&listingFiltersFlag, "filter", []string{}, "Filter resources given key/values fields (case insensitive) or tags with tag.<key>. Values of a same key are OR'ed, keys are AND'ed. Ex: --filter type=t2.micro, --filter tag.Env=dev,staging")
+	listCmd.PersistentFlags().StringSliceVar(&listingTagFiltersFlag, "tag", []string{}, "Filter EC2 resources given tags (case sensitive!). Values of a same key are OR'ed. Ex: --tag Env=Production, --tag Env=dev,staging")
 	listCmd.PersistentFlags().StringSliceVar(&listingTagKeyFiltersFlag, "tag-key", []string{}, "Filter EC2 resources given a tag key only (case sensitive!). Ex: --tag-key Env")
 	listCmd.PersistentFlags().StringSliceVar(&listingTagValueFiltersFlag, "tag-value", []string{}, "Filter EC2 resources given a tag value only (case sensitive!). Ex: --tag-value Staging")
 	listCmd.PersistentFlags().BoolVar(&listOnlyIDs, "ids", false, "List only ids")
+	listCmd.PersistentFlags().BoolVarP(&listIDsOnlyFlag, "ids-only", "q", false, "Print only the ids of the listed resources, one per line, for piping (ex: awless list instances -q | xargs -I{} awless stop instance id={})")
 	listCmd.PersistentFlags().BoolVar(&noHeadersFlag, "no-headers", false, "Do not display headers")
 	listCmd.PersistentFlags().StringSliceVar(&sortBy, "sort", []string{"Id"}, "Sort tables by column(s) name(s)")
+	listCmd.PersistentFlags().BoolVar(&listWithRelationsFlag, "with-relations", false, "Add columns with the ids of the related resources (ex: subnet and security groups of instances), drawn from the local synced graph")
+	listCmd.PersistentFlags().IntVar(&listPageFlag, "page", 0, "Display only the given page of the table (from 1), of --page-size rows")
+	listCmd.PersistentFlags().IntVar(&listPageSizeFlag, "page-size", 50, "Number of rows of the pages of --page")
+	listCmd.PersistentFlags().BoolVar(&listPagerFlag, "pager", false, "Page the table through $PAGER (or less) when displayed on a terminal")
+	listCmd.PersistentFlags().StringVar(&listSinceFlag, "since", "", "List only resources created since a duration ago (ex: 24h, 7d) or a date (ex: 2024-01-01, 2024-01-01T15:04:05Z)")
+	listCmd.PersistentFlags().StringVar(&listUntilFlag, "until", "", "List only resources created before a duration ago (ex: 1h, 2d) or a date (ex: 2024-02-01)")
+
+	listCmd.PersistentFlags().SetAnnotation("tag", cobra.BashCompCustom, []string{"__awless_get_tags"})
+	listCmd.PersistentFlags().SetAnnotation("tag-key", cobra.BashCompCustom, []string{"__awless_get_tag_keys"})
+	listCmd.PersistentFlags().SetAnnotation("tag-value", cobra.BashCompCustom, []string{"__awless_get_tag_values"})
 }
 
 var listCmd = &cobra.Command{
 	Use:               "list",
 	Aliases:           []string{"ls"},
-	Example:           "  awless list instances --sort uptime\n  awless list users --format csv\n  awless list volumes --filter state=use --filter type=gp2\n  awless list volumes --tag-value Purchased\n  awless list vpcs --tag-key Dept --tag-key Internal\n  awless list instances --tag Env=Production,Dept=Marketing\n  awless list instances --filter state=running,type=micro\n  awless list s3objects --filter bucket=pdf-bucket ",
-	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook),
+	Example:           "  awless list instances --sort uptime\n  awless list users --format csv\n  awless list volumes --filter state=use --filter type=gp2\n  awless list volumes --tag-value Purchased\n  awless list vpcs --tag-key Dept --tag-key Internal\n  awless list instances --tag Env=Production,Dept=Marketing\n  awless list instances --filter state=running,type=micro\n  awless list instances --filter tag.Env=dev,staging --filter state=running\n  awless list s3objects --filter bucket=pdf-bucket\n  awless list images --unused --older-than-days 90\n  awless list images --repo my-app\n  awless list instances --with-relations\n  awless list instances --since 24h\n  awless list volumes --since 2024-01-01 --until 2024-02-01\n  awless list instances --filter state=stopped -q | xargs -I{} awless start instance id={}",
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initOutputFormatHook, initCloudServicesHook),
 	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade),
 	Short:             "List various type of resources",
 }
@@ -88,6 +131,20 @@ var listSpecificResourceCmd = func(resType string) *cobra.Command {
 		Run: func(cmd *cobra.Command, args []string) {
 			var g *graph.Graph
 
+			now := time.Now()
+			since, err := parseTimeBound(listSinceFlag, now)
+			exitOn(err)
+			until, err := parseTimeBound(listUntilFlag, now)
+			exitOn(err)
+			if !since.IsZero() && !until.IsZero() && !since.Before(until) {
+				exitOn(fmt.Errorf("--since (%s) must be before --until (%s)", since.Format(time.RFC3339), until.Format(time.RFC3339)))
+			}
+
+			resType := resType
+			if resType == cloud.Image && listImagesRepositoryFlag != "" {
+				resType = cloud.ContainerImage
+			}
+
 			if localGlobalFlag {
 				if srvName, ok := aws.ServicePerResourceType[resType]; ok {
 					g = sync.LoadCurrentLocalGraph(srvName)
@@ -101,11 +158,75 @@ var listSpecificResourceCmd = func(resType string) *cobra.Command {
 				exitOn(err)
 			}
 
+			if resType == cloud.Image && (listUnusedImagesFlag || listImagesOlderThanDaysFlag > 0) {
+				var used map[string]bool
+				var err error
+				if listUnusedImagesFlag {
+					used, err = usedImages()
+					exitOn(err)
+				}
+				g, err = filterImages(g, listImagesOlderThanDaysFlag, used)
+				exitOn(err)
+			}
+
+			if resType == cloud.ContainerImage && listImagesRepositoryFlag != "" {
+				var err error
+				g, err = filterContainerImages(g, listImagesRepositoryFlag)
+				exitOn(err)
+			}
+
+			if resType == cloud.Finding && listFindingsSeverityFlag != "" {
+				var err error
+				g, err = filterFindings(g, listFindingsSeverityFlag)
+				exitOn(err)
+			}
+
+			if resType == cloud.HealthCheck && listUnhealthyHealthChecksFlag {
+				var err error
+				g, err = filterUnhealthyHealthChecks(g)
+				exitOn(err)
+			}
+
+			if !since.IsZero() || !until.IsZero() {
+				var undated int
+				g, undated, err = filterCreatedWithin(g, resType, since, until)
+				exitOn(err)
+				if undated > 0 {
+					logger.Infof("%d %s without creation time excluded", undated, cloud.PluralizeResource(resType))
+				}
+			}
+
+			if resType == cloud.Subnet {
+				console.SubnetFreeIPsThreshold = config.GetSubnetFreeIPsThreshold()
+			}
 			printResources(g, resType)
+			if resType == cloud.Subnet {
+				low, err := lowFreeIPsSubnets(g, console.SubnetFreeIPsThreshold)
+				exitOn(err)
+				if len(low) > 0 {
+					logger.Warningf("%d subnets with less than %d free IP addresses: %s", len(low), console.SubnetFreeIPsThreshold, strings.Join(low, ", "))
+				}
+			}
 		},
 	}
 }
 
+// lowFreeIPsSubnets returns the subnets with less free IP addresses than threshold
+func lowFreeIPsSubnets(g *graph.Graph, threshold int) ([]string, error) {
+	subnets, err := g.GetAllResources(cloud.Subnet)
+	if err != nil {
+		return nil, err
+	}
+	var low []string
+	for _, subnet := range subnets {
+		if free, ok := subnet.Properties[properties.FreeIPs].(int); ok && free < threshold {
+			low = append(low, subnet.Id())
+		}
+	}
+	sort.Strings(low)
+	return low, nil
+}
+
 var listAllResourceInServiceCmd = func(srvName string) *cobra.Command {
 	return &cobra.Command{
 		Use:    srvName,
@@ -118,6 +239,7 @@ var listAllResourceInServiceCmd = func(srvName string) *cobra.Command {
 				console.WithFormat(listingFormat),
 				console.WithMaxWidth(console.GetTerminalWidth()),
 				console.WithIDsOnly(listOnlyIDs),
+				console.WithIDsFormat(listIDsOnlyFlag),
 			).SetSource(g).Build()
 			exitOn(err)
 			exitOn(displayer.Print(os.Stdout))
@@ -126,6 +248,10 @@ var listAllResourceInServiceCmd = func(srvName string) *cobra.Command {
 }
 
 func printResources(g *graph.Graph, resType string) {
+	var relations *graph.Graph
+	if listWithRelationsFlag {
+		relations = relationsGraph(g, resType)
+	}
 	displayer, err := console.BuildOptions(
 		console.WithRdfType(resType),
 		console.WithHeaders(console.DefaultsColumnDefinitions[resType]),
@@ -136,10 +262,212 @@ func printResources(g *graph.Graph, resType string) {
 		console.WithMaxWidth(console.GetTerminalWidth()),
 		console.WithFormat(listingFormat),
 		console.WithIDsOnly(listOnlyIDs),
+		console.WithIDsFormat(listIDsOnlyFlag),
 		console.WithSortBy(sortBy...),
 		console.WithNoHeaders(noHeadersFlag),
+		console.WithRelations(relations),
+		console.WithPage(listPageFlag, listPageSizeFlag),
 	).SetSource(g).Build()
 	exitOn(err)
 
+	if listPagerFlag && (listingFormat == "table" || listingFormat == "wide") && !listOnlyIDs && !listIDsOnlyFlag {
+		w, wait := console.StartPager(os.Stdout)
+		err = displayer.Print(w)
+		wait()
+		exitOn(err)
+		return
+	}
 	exitOn(displayer.Print(os.Stdout))
 }
+
+// relationsGraph returns the graph holding the relations of the listed resources.
+// Resources fetched by type come without relations: they are read from the local graph of the last sync
+func relationsGraph(g *graph.Graph, resType string) *graph.Graph {
+	if localGlobalFlag {
+		return g
+	}
+	return sync.LoadCurrentLocalGraph(aws.ServicePerResourceType[resType])
+}
+
+// filterImages keeps images created more than olderThanDays ago (when positive)
+// and not in the given used images (when not nil)
+func filterImages(g *graph.Graph, olderThanDays int, used map[string]bool) (*graph.Graph, error) {
+	images, err := g.GetAllResources(cloud.Image)
+	if err != nil {
+		return g, err
+	}
+
+	filtered := graph.NewGraph()
+	limit := time.Now().AddDate(0, 0, -olderThanDays)
+	for _, img := range images {
+		if used[img.Id()] {
+			continue
+		}
+		if olderThanDays > 0 {
+			created, ok := img.Properties[properties.Created].(time.Time)
+			if !ok || created.After(limit) {
+				continue
+			}
+		}
+		if err := filtered.AddResource(img); err != nil {
+			return g, err
+		}
+	}
+
+	return filtered, nil
+}
+
+// parseTimeBound returns the time given as a duration ago (ex: 24h, 7d) or as a date (ex: 2024-01-01,
+// 2024-01-01T15:04:05Z). An empty value returns the zero time
+func parseTimeBound(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if strings.HasSuffix(s, "d") {
+		if days, err := strconv.Atoi(strings.TrimSuffix(s, "d")); err == nil && days >= 0 {
+			return now.AddDate(0, 0, -days), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		if d < 0 {
+			return time.Time{}, fmt.Errorf("invalid negative duration '%s'", s)
+		}
+		return now.Add(-d), nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time '%s': expecting a duration (ex: 24h, 7d) or a date (ex: 2024-01-01)", s)
+}
+
+// creationTime returns when the resource was created (launched for instances), if known
+func creationTime(res *graph.Resource) (time.Time, bool) {
+	for _, prop := range []string{properties.Created, properties.Launched} {
+		if t, ok := res.Properties[prop].(time.Time); ok && !t.IsZero() {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// filterCreatedWithin keeps the resources created at or after since and before until (when not zero),
+// also returning the number of resources excluded for lacking a creation time
+func filterCreatedWithin(g *graph.Graph, resType string, since, until time.Time) (*graph.Graph, int, error) {
+	resources, err := g.GetAllResources(resType)
+	if err != nil {
+		return g, 0, err
+	}
+
+	var undated int
+	filtered := graph.NewGraph()
+	for _, res := range resources {
+		created, ok := creationTime(res)
+		if !ok {
+			undated++
+			continue
+		}
+		if (!since.IsZero() && created.Before(since)) || (!until.IsZero() && !created.Before(until)) {
+			continue
+		}
+		if err := filtered.AddResource(res); err != nil {
+			return g, undated, err
+		}
+	}
+
+	return filtered, undated, nil
+}
+
+// filterContainerImages keeps the container images of the given repository
+func filterContainerImages(g *graph.Graph, repository string) (*graph.Graph, error) {
+	images, err := g.GetAllResources(cloud.ContainerImage)
+	if err != nil {
+		return g, err
+	}
+
+	filtered := graph.NewGraph()
+	for _, img := range images {
+		if repo, _ := img.Properties[properties.Repository].(string); repo != repository {
+			continue
+		}
+		if err := filtered.AddResource(img); err != nil {
+			return g, err
+		}
+	}
+
+	return filtered, nil
+}
+
+func usedImages() (map[string]bool, error) {
+	used := make(map[string]bool)
+	for _, resType := range []string{cloud.Instance, cloud.LaunchConfiguration} {
+		var g *graph.Graph
+		if localGlobalFlag {
+			g = sync.LoadCurrentLocalGraph(aws.ServicePerResourceType[resType])
+		} else {
+			srv, err := cloud.GetServiceForType(resType)
+			if err != nil {
+				return used, err
+			}
+			if g, err = srv.FetchByType(resType); err != nil {
+				return used, err
+			}
+		}
+		resources, err := g.GetAllResources(resType)
+		if err != nil {
+			return used, err
+		}
+		for _, res := range resources {
+			if img, ok := res.Properties[properties.Image].(string); ok {
+				used[img] = true
+			}
+		}
+	}
+	return used, nil
+}
+
+// filterUnhealthyHealthChecks keeps the health checks whose last observed status is unhealthy
+func filterUnhealthyHealthChecks(g *graph.Graph) (*graph.Graph, error) {
+	checks, err := g.GetAllResources(cloud.HealthCheck)
+	if err != nil {
+		return g, err
+	}
+
+	filtered := graph.NewGraph()
+	for _, c := range checks {
+		if health, _ := c.Properties[properties.Health].(string); health != "unhealthy" {
+			continue
+		}
+		if err := filtered.AddResource(c); err != nil {
+			return g, err
+		}
+	}
+
+	return filtered, nil
+}
+
+// filterFindings keeps the findings of at least the given severity level
+func filterFindings(g *graph.Graph, minSeverity string) (*graph.Graph, error) {
+	min := aws.FindingSeverityRank(strings.ToUpper(minSeverity))
+	if min == 0 {
+		return g, fmt.Errorf("invalid severity '%s': expecting low, medium or high", minSeverity)
+	}
+	findings, err := g.GetAllResources(cloud.Finding)
+	if err != nil {
+		return g, err
+	}
+
+	filtered := graph.NewGraph()
+	for _, f := range findings {
+		severity, _ := f.Properties[properties.Severity].(string)
+		if aws.FindingSeverityRank(severity) < min {
+			continue
+		}
+		if err := filtered.AddResource(f); err != nil {
+			return g, err
+		}
+	}
+
+	return filtered, nil
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/cloud"
+	p "github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+)
+
+func TestFilterImages(t *testing.T) {
+	now := time.Now().UTC()
+	g := graph.NewGraph()
+	g.AddResource(
+		resourcetest.Image("img_1").Prop(p.Created, now.AddDate(0, 0, -200)).Build(),
+		resourcetest.Image("img_2").Prop(p.Created, now.AddDate(0, 0, -100)).Build(),
+		resourcetest.Image("img_3").Prop(p.Created, now.AddDate(0, 0, -10)).Build(),
+		resourcetest.Image("img_4").Build(),
+	)
+
+	tcases := []struct {
+		olderThan int
+		used      map[string]bool
+		exp       []string
+	}{
+		{olderThan: 0, used: nil, exp: []string{"img_1", "img_2", "img_3", "img_4"}},
+		{olderThan: 30, used: nil, exp: []string{"img_1", "img_2"}},
+		{olderThan: 0, used: map[string]bool{"img_1": true, "img_4": true}, exp: []string{"img_2", "img_3"}},
+		{olderThan: 150, used: map[string]bool{"img_2": true}, exp: []string{"img_1"}},
+	}
+
+	for i, tcase := range tcases {
+		filtered, err := filterImages(g, tcase.olderThan, tcase.used)
+		if err != nil {
+			t.Fatal(err)
+		}
+		images, err := filtered.GetAllResources(cloud.Image)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []string
+		for _, img := range images {
+			ids = append(ids, img.Id())
+		}
+		sort.Strings(ids)
+		if got, want := ids, tcase.exp; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%d: got %v, want %v", i+1, got, want)
+		}
+	}
+}
+
+func TestParseTimeBound(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	tcases := []struct {
+		in  string
+		exp time.Time
+	}{
+		{in: "", exp: time.Time{}},
+		{in: "24h", exp: time.Date(2024, 3, 9, 12, 0, 0, 0, time.UTC)},
+		{in: "90m", exp: time.Date(2024, 3, 10, 10, 30, 0, 0, time.UTC)},
+		{in: "7d", exp: time.Date(2024, 3, 3, 12, 0, 0, 0, time.UTC)},
+		{in: "2024-01-01", exp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)},
+		{in: "2024-01-01T15:04", exp: time.Date(2024, 1, 1, 15, 4, 0, 0, time.Local)},
+		{in: "2024-01-01T15:04:05Z", exp: time.Date(2024, 1, 1, 15, 4, 5, 0, time.UTC)},
+	}
+	for _, tcase := range tcases {
+		got, err := parseTimeBound(tcase.in, now)
+		if err != nil {
+			t.Fatalf("%s: %s", tcase.in, err)
+		}
+		if !got.Equal(tcase.exp) {
+			t.Fatalf("%s: got %s, want %s", tcase.in, got, tcase.exp)
+		}
+	}
+	for _, in := range []string{"yesterday", "-2h", "01/02/2024"} {
+		if _, err := parseTimeBound(in, now); err == nil {
+			t.Fatalf("%s: expected error", in)
+		}
+	}
+}
+
+func TestFilterCreatedWithin(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	g := graph.NewGraph()
+	g.AddResource(
+		resourcetest.Instance("inst_1").Prop(p.Launched, day(1)).Build(),
+		resourcetest.Instance("inst_2").Prop(p.Launched, day(10)).Build(),
+		resourcetest.Instance("inst_3").Prop(p.Created, day(20)).Build(),
+		resourcetest.Instance("inst_4").Build(),
+	)
+
+	tcases := []struct {
+		since, until time.Time
+		exp          []string
+	}{
+		{since: day(5), exp: []string{"inst_2", "inst_3"}},
+		{until: day(10), exp: []string{"inst_1"}},
+		{since: day(1), until: day(20), exp: []string{"inst_1", "inst_2"}},
+		{since: day(21), exp: nil},
+	}
+	for i, tcase := range tcases {
+		filtered, undated, err := filterCreatedWithin(g, cloud.Instance, tcase.since, tcase.until)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := undated, 1; got != want {
+			t.Fatalf("%d: got %d, want %d", i+1, got, want)
+		}
+		instances, err := filtered.GetAllResources(cloud.Instance)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []string
+		for _, inst := range instances {
+			ids = append(ids, inst.Id())
+		}
+		sort.Strings(ids)
+		if got, want := ids, tcase.exp; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%d: got %v, want %v", i+1, got, want)
+		}
+	}
+}
+
+func TestFilterContainerImages(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddResource(
+		resourcetest.ContainerImage("app@sha256:1").Prop(p.Repository, "app").Build(),
+		resourcetest.ContainerImage("app@sha256:2").Prop(p.Repository, "app").Build(),
+		resourcetest.ContainerImage("web@sha256:3").Prop(p.Repository, "web").Build(),
+	)
+
+	tcases := []struct {
+		repository string
+		exp        []string
+	}{
+		{repository: "app", exp: []string{"app@sha256:1", "app@sha256:2"}},
+		{repository: "web", exp: []string{"web@sha256:3"}},
+		{repository: "none", exp: nil},
+	}
+
+	for i, tcase := range tcases {
+		filtered, err := filterContainerImages(g, tcase.repository)
+		if err != nil {
+			t.Fatal(err)
+		}
+		images, err := filtered.GetAllResources(cloud.ContainerImage)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var ids []string
+		for _, img := range images {
+			ids = append(ids, img.Id())
+		}
+		sort.Strings(ids)
+		if got, want := ids, tcase.exp; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%d: got %v, want %v", i+1, got, want)
+		}
+	}
+}
+
+func TestFilterUnhealthyHealthChecks(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddResource(
+		resourcetest.HealthCheck("check-1").Prop(p.Health, "healthy").Build(),
+		resourcetest.HealthCheck("check-2").Prop(p.Health, "unhealthy").Build(),
+		resourcetest.HealthCheck("check-3").Build(),
+		resourcetest.HealthCheck("check-4").Prop(p.Health, "unhealthy").Build(),
+	)
+
+	filtered, err := filterUnhealthyHealthChecks(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checks, err := filtered.GetAllResources(cloud.HealthCheck)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, c := range checks {
+		ids = append(ids, c.Id())
+	}
+	sort.Strings(ids)
+	if got, want := ids, []string{"check-2", "check-4"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestOutputFormatHook(t *testing.T) {
+	defer func() { config.Config = map[string]interface{}{} }()
+	config.Config = map[string]interface{}{config.OutputFormatConfigKey: "csv"}
+
+	var format string
+	cmd := &cobra.Command{Use: "test", Run: func(*cobra.Command, []string) {}}
+	outputFormatFlag(cmd.Flags(), &format, "table", "csv", "json")
+
+	if err := initOutputFormatHook(cmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := format, "csv"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	cmd.Flags().Set("format", "json")
+	if err := initOutputFormatHook(cmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := format, "json"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	format = "table"
+	cmd = &cobra.Command{Use: "test", Run: func(*cobra.Command, []string) {}}
+	outputFormatFlag(cmd.Flags(), &format, "table", "json")
+	if err := initOutputFormatHook(cmd, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := format, "table"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestLowFreeIPsSubnets(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddResource(
+		resourcetest.Subnet("sub_1").Prop(p.FreeIPs, 250).Build(),
+		resourcetest.Subnet("sub_2").Prop(p.FreeIPs, 3).Build(),
+		resourcetest.Subnet("sub_3").Prop(p.FreeIPs, 0).Build(),
+		resourcetest.Subnet("sub_4").Build(),
+	)
+	low, err := lowFreeIPsSubnets(g, 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := low, []string{"sub_2", "sub_3"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
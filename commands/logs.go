@@ -0,0 +1,113 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws"
+	"github.com/wallix/awless/aws/tailers"
+	"github.com/wallix/awless/logger"
+)
+
+var (
+	logsFollowFlag    bool
+	logsFilterFlag    string
+	logsSinceFlag     time.Duration
+	logsUntilFlag     time.Duration
+	logsFrequencyFlag time.Duration
+)
+
+func init() {
+	RootCmd.AddCommand(logsCmd)
+
+	logsCmd.Flags().BoolVarP(&logsFollowFlag, "follow", "f", false, "Keep polling and printing new log events")
+	logsCmd.Flags().StringVar(&logsFilterFlag, "filter", "", "CloudWatch Logs filter pattern of the events to print (ex: ERROR, '{ $.level = \"error\" }')")
+	logsCmd.Flags().DurationVar(&logsSinceFlag, "since", 10*time.Minute, "Print the events more recent than this duration (ex: 30m, 2h)")
+	logsCmd.Flags().DurationVar(&logsUntilFlag, "until", 0, "Print the events older than this duration (ex: 5m), without --follow")
+	logsCmd.Flags().DurationVar(&logsFrequencyFlag, "frequency", 2*time.Second, "Polling frequency with --follow")
+}
+
+var logsCmd = &cobra.Command{
+	Use:   "logs REFERENCE",
+	Short: "Print the CloudWatch logs of a resource (ex: a Lambda function) or of a log group, optionally following them live",
+	Example: `  awless logs my-function --follow
+  awless logs my-function --since 2h --filter ERROR
+  awless logs /aws/lambda/my-function --since 1h --until 30m`,
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("REFERENCE required: a resource (ex: a function name) or a log group name")
+		}
+		if logsFollowFlag && logsUntilFlag > 0 {
+			return errors.New("--until can not be used with --follow")
+		}
+		if logsUntilFlag > 0 && logsUntilFlag >= logsSinceFlag {
+			return fmt.Errorf("--until (%s) must be shorter than --since (%s)", logsUntilFlag, logsSinceFlag)
+		}
+		if aws.CloudWatchLogs == nil {
+			return errors.New("CloudWatch Logs unavailable")
+		}
+
+		group, err := resolveLogGroup(args[0])
+		exitOn(err)
+		logger.Verbosef("printing events of log group %s", group)
+
+		now := time.Now()
+		var end time.Time
+		if logsUntilFlag > 0 {
+			end = now.Add(-logsUntilFlag)
+		}
+		tailer := awstailers.NewLogsTailer(aws.CloudWatchLogs, group, logsFilterFlag, now.Add(-logsSinceFlag), end, logsFollowFlag, logsFrequencyFlag)
+		exitOn(tailer.Tail(os.Stdout))
+		return nil
+	},
+}
+
+func resolveLogGroup(ref string) (string, error) {
+	if aws.IsLogGroupName(ref) {
+		return ref, nil
+	}
+	resources := resolveResourceFromRef(ref)
+	if len(resources) == 0 {
+		return "", fmt.Errorf("resource with reference %s not found: give a log group name (ex: /aws/lambda/my-function)", deprefix(ref))
+	}
+	var groups []string
+	var lastErr error
+	for _, res := range resources {
+		group, err := aws.LogGroupOf(res)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		groups = append(groups, group)
+	}
+	switch len(groups) {
+	case 0:
+		return "", lastErr
+	case 1:
+		return groups[0], nil
+	default:
+		return "", fmt.Errorf("%d resources with logs found with reference %s: give the log group name", len(groups), deprefix(ref))
+	}
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws"
+	awsdriver "github.com/wallix/awless/aws/driver"
+	"github.com/wallix/awless/cloud"
+	p "github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/database"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template"
+)
+
+const manifestVersion = 1
+
+var deleteManifestFlag string
+
+func init() {
+	generateCmd.AddCommand(generateManifestCmd)
+}
+
+// manifest lists the resources created by a template run, in creation order
+type manifest struct {
+	Version    int                 `json:"version"`
+	TemplateID string              `json:"template_id"`
+	Region     string              `json:"region"`
+	Resources  []*manifestResource `json:"resources"`
+}
+
+// manifestResource is a created resource with the params of its creation, in template syntax
+type manifestResource struct {
+	Type   string            `json:"type"`
+	ID     string            `json:"id"`
+	Params map[string]string `json:"params,omitempty"`
+}
+
+var generateManifestCmd = &cobra.Command{
+	Use:     "manifest [TEMPLATEID]",
+	Short:   "Generate the manifest of the resources created by the last template run (or by the given one), to delete them later with `awless delete --manifest`",
+	Example: "  awless generate manifest > run.json\n  awless generate manifest 01BA7RV6ES86PZYCM3H28WM6KZ > run.json",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		var loaded *template.TemplateExecution
+		exitOn(database.Execute(func(db *database.DB) (terr error) {
+			if len(args) > 0 {
+				loaded, terr = db.GetTemplate(args[0])
+			} else {
+				loaded, terr = lastTemplateRun(db)
+			}
+			return
+		}))
+		m := buildManifest(loaded)
+		if len(m.Resources) == 0 {
+			logger.Warningf("template run %s created no deletable resource", loaded.ID)
+		}
+		b, err := json.MarshalIndent(m, "", "  ")
+		exitOn(err)
+		fmt.Println(string(b))
+	},
+}
+
+// buildManifest returns the resources successfully created by an executed template that can be deleted.
+// Sensitive params (ex: password) are left out
+func buildManifest(tplExec *template.TemplateExecution) *manifest {
+	m := &manifest{Version: manifestVersion, TemplateID: tplExec.ID, Region: tplExec.Locale}
+	for _, cmd := range tplExec.CommandNodesIterator() {
+		if cmd.Action != "create" || cmd.CmdErr != nil {
+			continue
+		}
+		id, ok := cmd.CmdResult.(string)
+		if !ok || id == "" {
+			continue
+		}
+		if _, ok := awsdriver.AWSLookupDefinitions("delete" + cmd.Entity); !ok {
+			continue
+		}
+		res := &manifestResource{Type: cmd.Entity, ID: id, Params: make(map[string]string)}
+		for k, v := range cmd.Params {
+			if !isSensitiveKey(k) {
+				res.Params[k] = template.QuoteParamIfNeeded(v)
+			}
+		}
+		m.Resources = append(m.Resources, res)
+	}
+	return m
+}
+
+func loadManifest(path string) (*manifest, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m := &manifest{}
+	if err = json.Unmarshal(b, m); err != nil {
+		return nil, fmt.Errorf("manifest %s: %s", path, err)
+	}
+	if m.Version > manifestVersion {
+		return nil, fmt.Errorf("manifest %s: version %d unsupported by this awless (up to %d): upgrade awless", path, m.Version, manifestVersion)
+	}
+	return m, nil
+}
+
+// manifestDeletionTemplate returns the template deleting the resources of the manifest in
+// reverse creation order, so that resources are deleted before the ones they depend on.
+// The required params not found in the manifest are left as holes (ex: {image.delete-snapshots})
+func manifestDeletionTemplate(resources []*manifestResource) (*template.Template, error) {
+	var lines []string
+	for i := len(resources) - 1; i >= 0; i-- {
+		res := resources[i]
+		def, ok := awsdriver.AWSLookupDefinitions("delete" + res.Type)
+		if !ok {
+			return nil, fmt.Errorf("cannot delete %s %s: unsupported", res.Type, res.ID)
+		}
+		var params []string
+		for _, param := range def.Required() {
+			value, ok := res.Params[param]
+			switch param {
+			case "id", "arn", "url":
+				value, ok = template.QuoteParamIfNeeded(res.ID), true
+			case "name":
+				if !ok {
+					value, ok = template.QuoteParamIfNeeded(res.ID), true
+				}
+			}
+			if ok {
+				params = append(params, fmt.Sprintf("%s=%s", param, value))
+			} else {
+				params = append(params, fmt.Sprintf("%s={%s.%s}", param, res.Type, param))
+			}
+		}
+		lines = append(lines, fmt.Sprintf("delete %s %s", res.Type, strings.Join(params, " ")))
+	}
+	if len(lines) == 0 {
+		return nil, errors.New("no resources to delete")
+	}
+	return template.Parse(strings.Join(lines, "\n"))
+}
+
+// existingManifestResources returns the resources of the manifest still existing, reporting
+// the missing ones. Resources whose type can not be fetched are kept
+func existingManifestResources(resources []*manifestResource, fetch func(resourceType string) (*graph.Graph, error)) []*manifestResource {
+	graphs := make(map[string]*graph.Graph)
+	var existing []*manifestResource
+	for _, res := range resources {
+		g, done := graphs[res.Type]
+		if !done {
+			var err error
+			if g, err = fetch(res.Type); err != nil {
+				logger.Verbosef("cannot check existence of %s resources: %s", res.Type, err)
+			}
+			graphs[res.Type] = g
+		}
+		if g != nil && !manifestResourceExists(g, res) {
+			logger.Infof("skipping %s %s: not found, already deleted", res.Type, res.ID)
+			continue
+		}
+		existing = append(existing, res)
+	}
+	return existing
+}
+
+func manifestResourceExists(g *graph.Graph, res *manifestResource) bool {
+	if r, err := g.GetResource(res.Type, res.ID); err == nil && r != nil {
+		return true
+	}
+	if found, _ := g.FindResourcesByProperty(p.Arn, res.ID); len(found) > 0 {
+		return true
+	}
+	if name, ok := res.Params["name"]; ok {
+		if found, _ := g.FindResourcesByProperty(p.Name, name); len(found) > 0 {
+			for _, r := range found {
+				if r.Type() == res.Type {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func fetchResourcesOfType(resourceType string) (*graph.Graph, error) {
+	srv, ok := cloud.ServiceRegistry[aws.ServicePerResourceType[resourceType]]
+	if !ok {
+		return nil, fmt.Errorf("no service for %s", resourceType)
+	}
+	return srv.FetchByType(resourceType)
+}
+
+func runManifestDeletion(path string) error {
+	m, err := loadManifest(path)
+	if err != nil {
+		return err
+	}
+	if m.Region != "" && m.Region != config.GetAWSRegion() {
+		logger.Errorf("The resources of this manifest were created in region %s. You are currently in region %s", m.Region, config.GetAWSRegion())
+		logger.Infof("You can delete them using the region flag: `awless delete --manifest %s -r %s`", path, m.Region)
+		return errors.New("region mismatched")
+	}
+	resources := existingManifestResources(m.Resources, fetchResourcesOfType)
+	if len(resources) == 0 {
+		logger.Info("all the resources of the manifest are already deleted")
+		return nil
+	}
+	tpl, err := manifestDeletionTemplate(resources)
+	if err != nil {
+		return err
+	}
+	tplExec := &template.TemplateExecution{
+		Template: tpl,
+		Locale:   config.GetAWSRegion(),
+		Source:   tpl.String(),
+	}
+	return runTemplate(tplExec, config.Defaults)
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/template"
+)
+
+func TestManifestDeletion(t *testing.T) {
+	tpl, err := template.Parse(`create vpc cidr=10.0.0.0/16
+create subnet cidr=10.0.0.0/24 vpc=vpc-1
+create user name=bob password=s3cr3t
+create instance name=web subnet=subnet-1 image=ami-1 type=t2.micro count=1
+create image instance=i-1 name=web-image
+create bucket name=my-bucket`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := []interface{}{"vpc-1", "subnet-1", "AIDA1", "i-1", "ami-2", nil}
+	for i, cmd := range tpl.CommandNodesIterator() {
+		cmd.CmdResult = results[i]
+	}
+	tpl.CommandNodesIterator()[5].CmdErr = errors.New("bucket already exists")
+	tplExec := &template.TemplateExecution{Template: tpl, Locale: "eu-west-1"}
+	tplExec.ID = "01BA7RV6ES86PZYCM3H28WM6KZ"
+
+	m := buildManifest(tplExec)
+	if got, want := m.Region, "eu-west-1"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	var ids []string
+	for _, res := range m.Resources {
+		ids = append(ids, res.ID)
+	}
+	if got, want := ids, []string{"vpc-1", "subnet-1", "AIDA1", "i-1", "ami-2"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if _, ok := m.Resources[2].Params["password"]; ok {
+		t.Fatal("sensitive param in manifest")
+	}
+
+	fetch := func(resourceType string) (*graph.Graph, error) {
+		g := graph.NewGraph()
+		switch resourceType {
+		case "vpc":
+			return g, g.AddResource(graph.InitResource(cloud.Vpc, "vpc-1"))
+		case "instance":
+			return g, nil
+		}
+		return nil, errors.New("unsupported")
+	}
+	existing := existingManifestResources(m.Resources, fetch)
+	ids = nil
+	for _, res := range existing {
+		ids = append(ids, res.ID)
+	}
+	if got, want := ids, []string{"vpc-1", "subnet-1", "AIDA1", "ami-2"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	deletion, err := manifestDeletionTemplate(existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "delete image delete-snapshots={image.delete-snapshots} id=ami-2\ndelete user name=bob\ndelete subnet id=subnet-1\ndelete vpc id=vpc-1"
+	if got, want := deletion.String(), expected; got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws"
+	"github.com/wallix/awless/cloud"
+	p "github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/sync"
+)
+
+const (
+	publicFinding       = "public"
+	orphanFinding       = "orphan"
+	noncompliantFinding = "noncompliant"
+)
+
+var metricsPerResourceFlag bool
+
+func init() {
+	RootCmd.AddCommand(metricsCmd)
+	metricsCmd.Flags().BoolVar(&metricsPerResourceFlag, "per-resource", false, "Also export a metric per resource of each finding (labeled with the resource id)")
+}
+
+var metricsCmd = &cobra.Command{
+	Use:               "metrics",
+	Short:             "Export resources counts and findings of the local graphs as Prometheus metrics",
+	Long:              "Export, in the Prometheus text exposition format, the resources counts and findings of the local graphs (as of the last sync) labeled by account, region and type.\n\nFindings are: public resources (see `awless list public`), orphan resources (unattached volumes, unassociated elastic IPs and security groups applied on nothing) and non-compliant resources (databases and functions past their end of life, see `awless list eol`).",
+	Example:           "  awless metrics\n  awless metrics --per-resource > /var/lib/node_exporter/awless.prom",
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		g, err := sync.LoadAllGraphs()
+		exitOn(err)
+
+		var account string
+		if access, ok := aws.AccessService.(*aws.Access); ok {
+			if me, err := access.GetIdentity(); err != nil {
+				logger.Verbosef("metrics: cannot resolve account: %s", err)
+			} else {
+				account = me.Account
+			}
+		}
+
+		metrics, err := graphMetrics(g, time.Now().UTC())
+		exitOn(err)
+		exitOn(printMetrics(os.Stdout, metrics, map[string]string{"account": account, "region": config.GetAWSRegion()}, metricsPerResourceFlag))
+	},
+}
+
+type resourceFinding struct {
+	Type, ID, Finding string
+}
+
+type graphMetricsResult struct {
+	counts   map[string]int
+	findings []*resourceFinding
+}
+
+// graphMetrics counts the resources of the graph per type and collects their findings
+func graphMetrics(g *graph.Graph, now time.Time) (*graphMetricsResult, error) {
+	result := &graphMetricsResult{counts: make(map[string]int)}
+	for _, srvName := range aws.ServiceNames {
+		for _, resType := range aws.ResourceTypesPerServiceName()[srvName] {
+			resources, err := g.GetAllResources(resType)
+			if err != nil {
+				return result, err
+			}
+			result.counts[resType] += len(resources)
+		}
+	}
+
+	seen := make(map[resourceFinding]bool)
+	add := func(resType, id, finding string) {
+		f := resourceFinding{Type: resType, ID: id, Finding: finding}
+		if !seen[f] {
+			seen[f] = true
+			result.findings = append(result.findings, &f)
+		}
+	}
+
+	exposures, err := findPublicExposures(g)
+	if err != nil {
+		return result, err
+	}
+	for _, e := range exposures {
+		add(e.Type, e.ID, publicFinding)
+	}
+
+	orphans, err := findOrphans(g)
+	if err != nil {
+		return result, err
+	}
+	for _, o := range orphans {
+		add(o.Type(), o.Id(), orphanFinding)
+	}
+
+	eols, err := findEOLVersions([]*graph.Graph{g}, config.GetConfigWithPrefix(aws.EOLConfigPrefix), now, 0)
+	if err != nil {
+		return result, err
+	}
+	for _, e := range eols {
+		if e.Past {
+			add(e.Type, e.ID, noncompliantFinding)
+		}
+	}
+
+	sort.Slice(result.findings, func(i, j int) bool {
+		fi, fj := result.findings[i], result.findings[j]
+		if fi.Finding != fj.Finding {
+			return fi.Finding < fj.Finding
+		}
+		if fi.Type != fj.Type {
+			return fi.Type < fj.Type
+		}
+		return fi.ID < fj.ID
+	})
+	return result, nil
+}
+
+// findOrphans returns the resources left behind, used by nothing: unattached volumes,
+// unassociated elastic IPs and non default security groups applied on no resource
+func findOrphans(g *graph.Graph) ([]*graph.Resource, error) {
+	var orphans []*graph.Resource
+
+	volumes, err := g.GetAllResources(cloud.Volume)
+	if err != nil {
+		return orphans, err
+	}
+	for _, v := range volumes {
+		if state, _ := v.Properties[p.State].(string); state == "available" {
+			orphans = append(orphans, v)
+		}
+	}
+
+	ips, err := g.GetAllResources(cloud.ElasticIP)
+	if err != nil {
+		return orphans, err
+	}
+	for _, ip := range ips {
+		if assoc, _ := ip.Properties[p.Association].(string); assoc == "" {
+			orphans = append(orphans, ip)
+		}
+	}
+
+	groups, err := g.GetAllResources(cloud.SecurityGroup)
+	if err != nil {
+		return orphans, err
+	}
+	for _, sg := range groups {
+		if name, _ := sg.Properties[p.Name].(string); name == "default" {
+			continue
+		}
+		appliedOn, err := g.ListResourcesAppliedOn(sg)
+		if err != nil {
+			return orphans, err
+		}
+		if len(appliedOn) == 0 {
+			orphans = append(orphans, sg)
+		}
+	}
+
+	return orphans, nil
+}
+
+// printMetrics writes the metrics in the Prometheus text exposition format
+func printMetrics(w io.Writer, metrics *graphMetricsResult, labels map[string]string, perResource bool) error {
+	var buff bytes.Buffer
+
+	fmt.Fprintln(&buff, "# HELP awless_resources Number of resources in the local graphs.")
+	fmt.Fprintln(&buff, "# TYPE awless_resources gauge")
+	var types []string
+	for t := range metrics.counts {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	for _, t := range types {
+		fmt.Fprintf(&buff, "awless_resources%s %d\n", metricLabels(labels, "type", t), metrics.counts[t])
+	}
+
+	fmt.Fprintln(&buff, "# HELP awless_findings Number of resources with a finding (public, orphan or noncompliant).")
+	fmt.Fprintln(&buff, "# TYPE awless_findings gauge")
+	counts := make(map[[2]string]int)
+	for _, finding := range []string{noncompliantFinding, orphanFinding, publicFinding} {
+		for _, t := range types {
+			if metrics.counts[t] > 0 {
+				counts[[2]string{finding, t}] = 0
+			}
+		}
+	}
+	for _, f := range metrics.findings {
+		counts[[2]string{f.Finding, f.Type}]++
+	}
+	var keys [][2]string
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	for _, k := range keys {
+		fmt.Fprintf(&buff, "awless_findings%s %d\n", metricLabels(labels, "finding", k[0], "type", k[1]), counts[k])
+	}
+
+	if perResource {
+		fmt.Fprintln(&buff, "# HELP awless_finding Resource with a finding (public, orphan or noncompliant).")
+		fmt.Fprintln(&buff, "# TYPE awless_finding gauge")
+		for _, f := range metrics.findings {
+			fmt.Fprintf(&buff, "awless_finding%s 1\n", metricLabels(labels, "finding", f.Finding, "id", f.ID, "type", f.Type))
+		}
+	}
+
+	_, err := w.Write(buff.Bytes())
+	return err
+}
+
+// metricLabels formats the labels and the extra label key/values sorted by name
+func metricLabels(labels map[string]string, extra ...string) string {
+	all := make(map[string]string)
+	for k, v := range labels {
+		all[k] = v
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		all[extra[i]] = extra[i+1]
+	}
+	var names []string
+	for k := range all {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var pairs []string
+	for _, k := range names {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, k, escapeLabelValue(all[k])))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func escapeLabelValue(v string) string {
+	return labelValueReplacer.Replace(v)
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	p "github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+)
+
+func TestGraphMetrics(t *testing.T) {
+	g := graph.NewGraph()
+	sgUsed := resourcetest.SecurityGroup("sg_used").Build()
+	inst := resourcetest.Instance("inst_1").Build()
+	g.AddResource(
+		inst, sgUsed,
+		resourcetest.SecurityGroup("sg_default").Prop(p.Name, "default").Build(),
+		resourcetest.SecurityGroup("sg_unused").Build(),
+		resourcetest.Volume("vol_1").Prop(p.State, "available").Build(),
+		resourcetest.Volume("vol_2").Prop(p.State, "in-use").Build(),
+		resourcetest.Database("db_1").Prop(p.Public, true).Prop(p.Engine, "postgres").Prop(p.EngineVersion, "9.3.25").Build(),
+	)
+	g.AddAppliesOnRelation(sgUsed, inst)
+
+	metrics, err := graphMetrics(g, time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var w bytes.Buffer
+	if err = printMetrics(&w, metrics, map[string]string{"account": "123456789012", "region": "eu-west-1"}, true); err != nil {
+		t.Fatal(err)
+	}
+	out := w.String()
+	for _, line := range []string{
+		`awless_resources{account="123456789012",region="eu-west-1",type="securitygroup"} 3`,
+		`awless_resources{account="123456789012",region="eu-west-1",type="volume"} 2`,
+		`awless_resources{account="123456789012",region="eu-west-1",type="bucket"} 0`,
+		`awless_findings{account="123456789012",finding="orphan",region="eu-west-1",type="securitygroup"} 1`,
+		`awless_findings{account="123456789012",finding="orphan",region="eu-west-1",type="volume"} 1`,
+		`awless_findings{account="123456789012",finding="public",region="eu-west-1",type="database"} 1`,
+		`awless_findings{account="123456789012",finding="noncompliant",region="eu-west-1",type="database"} 1`,
+		`awless_findings{account="123456789012",finding="public",region="eu-west-1",type="instance"} 0`,
+		`awless_finding{account="123456789012",finding="orphan",id="sg_unused",region="eu-west-1",type="securitygroup"} 1`,
+		`awless_finding{account="123456789012",finding="orphan",id="vol_1",region="eu-west-1",type="volume"} 1`,
+		"# TYPE awless_findings gauge",
+	} {
+		if !strings.Contains(out, line+"\n") {
+			t.Fatalf("missing line %s in\n%s", line, out)
+		}
+	}
+	if strings.Contains(out, `id="sg_default"`) || strings.Contains(out, `id="vol_2"`) {
+		t.Fatalf("unexpected finding in\n%s", out)
+	}
+}
+
+func TestMetricLabelsEscaping(t *testing.T) {
+	if got, want := metricLabels(map[string]string{"region": "eu"}, "id", "a\"b\\c\nd"), `{id="a\"b\\c\nd",region="eu"}`; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
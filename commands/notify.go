@@ -0,0 +1,97 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	stdsync "sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+	"github.com/wallix/awless/aws"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template"
+)
+
+// notifiedOperation is the long operation in progress whose outcome is sent
+// to the sinks configured with aws.notify.*, once, when it ends or when the command exits on error
+var notifiedOperation struct {
+	mu    stdsync.Mutex
+	name  string
+	start time.Time
+}
+
+func startNotifiedOperation(name string) {
+	notifiedOperation.mu.Lock()
+	defer notifiedOperation.mu.Unlock()
+	notifiedOperation.name, notifiedOperation.start = name, time.Now()
+}
+
+// renameNotifiedOperation names the operation in progress once its identifier is known (ex: the ID of a run template)
+func renameNotifiedOperation(name string) {
+	notifiedOperation.mu.Lock()
+	defer notifiedOperation.mu.Unlock()
+	if notifiedOperation.name != "" {
+		notifiedOperation.name = name
+	}
+}
+
+func endNotifiedOperation(err error) {
+	notifiedOperation.mu.Lock()
+	name, start := notifiedOperation.name, notifiedOperation.start
+	notifiedOperation.name = ""
+	notifiedOperation.mu.Unlock()
+	if name == "" {
+		return
+	}
+
+	n := aws.NewOperationNotification(name, start, err)
+	n.Region = config.GetAWSRegion()
+	var snsAPI snsiface.SNSAPI
+	if messaging, ok := aws.MessagingService.(*aws.Messaging); ok {
+		snsAPI = messaging.SNSAPI
+	}
+	for _, notifier := range aws.Notifiers(config.Config, snsAPI, n.Outcome) {
+		if err := notifier.Notify(n); err != nil {
+			logger.Warningf("cannot notify %s: %s", notifier, err)
+		} else {
+			logger.ExtraVerbosef("notified %s of %s %s", notifier, n.Operation, n.Outcome)
+		}
+	}
+}
+
+// templateRunError returns the error of the first failed command of an executed template, if any
+func templateRunError(tpl *template.Template) error {
+	if !tpl.HasErrors() {
+		return nil
+	}
+	var failed int
+	var first error
+	for _, cmd := range tpl.CommandNodesIterator() {
+		if cmd.CmdErr != nil {
+			failed++
+			if first == nil {
+				first = fmt.Errorf("%s: %s", cmd.String(), cmd.CmdErr)
+			}
+		}
+	}
+	if failed == 1 {
+		return first
+	}
+	return fmt.Errorf("%d commands failed, first: %s", failed, first)
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/sync"
+)
+
+func init() {
+	listCmd.AddCommand(listPreviousGenCmd)
+}
+
+var listPreviousGenCmd = &cobra.Command{
+	Use:     "previous-gen",
+	Short:   "List instances and databases running on previous generation types, with their suggested current equivalent",
+	Long:    fmt.Sprintf("List EC2 instances and RDS databases running on previous generation instance types or classes, with their suggested current generation equivalent, often cheaper and faster.\n\nThe built-in families table can be overridden or extended with config keys prefixed with '%s' followed by the family (prefixed with db. for RDS), set to the suggested family or to 'none'.", aws.PreviousGenConfigPrefix),
+	Example: "  awless list previous-gen\n  awless list previous-gen --format json\n  awless config set aws.prevgen.m5 m6i",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		var graphs []*graph.Graph
+		for _, resType := range []string{cloud.Instance, cloud.Database} {
+			if localGlobalFlag {
+				graphs = append(graphs, sync.LoadCurrentLocalGraph(aws.ServicePerResourceType[resType]))
+				continue
+			}
+			srv, err := cloud.GetServiceForType(resType)
+			exitOn(err)
+			g, err := srv.FetchByType(resType)
+			exitOn(err)
+			graphs = append(graphs, g)
+		}
+
+		findings, err := findPreviousGenTypes(graphs, config.GetConfigWithPrefix(aws.PreviousGenConfigPrefix))
+		exitOn(err)
+		exitOn(printPreviousGenFindings(os.Stdout, findings))
+	},
+}
+
+type previousGenFinding struct {
+	Type      string `json:"type"`
+	ID        string `json:"id"`
+	Name      string `json:"name,omitempty"`
+	Current   string `json:"current"`
+	Suggested string `json:"suggested"`
+}
+
+// findPreviousGenTypes returns the instances and databases, not terminated nor deleted,
+// running on previous generation types, sorted by type then id
+func findPreviousGenTypes(graphs []*graph.Graph, overrides map[string]interface{}) ([]*previousGenFinding, error) {
+	typeProps := map[string]string{cloud.Instance: properties.Type, cloud.Database: properties.Class}
+
+	var findings []*previousGenFinding
+	seen := make(map[string]bool)
+	for _, g := range graphs {
+		for _, resType := range []string{cloud.Instance, cloud.Database} {
+			resources, err := g.GetAllResources(resType)
+			if err != nil {
+				return nil, err
+			}
+			for _, r := range resources {
+				if seen[r.Id()] {
+					continue
+				}
+				switch r.Properties[properties.State] {
+				case "terminated", "shutting-down", "deleting":
+					continue
+				}
+				current, _ := r.Properties[typeProps[resType]].(string)
+				suggested, ok := aws.SuggestCurrentGenType(current, overrides)
+				if !ok {
+					continue
+				}
+				seen[r.Id()] = true
+				name, _ := r.Properties[properties.Name].(string)
+				findings = append(findings, &previousGenFinding{Type: resType, ID: r.Id(), Name: name, Current: current, Suggested: suggested})
+			}
+		}
+	}
+
+	sort.Slice(findings, func(i, j int) bool {
+		if findings[i].Type != findings[j].Type {
+			return findings[i].Type > findings[j].Type
+		}
+		return findings[i].ID < findings[j].ID
+	})
+
+	return findings, nil
+}
+
+func printPreviousGenFindings(w io.Writer, findings []*previousGenFinding) error {
+	if listIDsOnlyFlag {
+		for _, f := range findings {
+			fmt.Fprintln(w, f.ID)
+		}
+		return nil
+	}
+	switch listingFormat {
+	case "json":
+		return json.NewEncoder(w).Encode(findings)
+	case "table":
+	default:
+		return fmt.Errorf("unsupported format '%s' for previous-gen: use table or json", listingFormat)
+	}
+
+	if len(findings) == 0 {
+		fmt.Fprintln(w, "No instance or database on previous generation types found")
+		return nil
+	}
+
+	table := tablewriter.NewWriter(w)
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAutoWrapText(false)
+	if !noHeadersFlag {
+		table.SetHeader([]string{"Type", "Id", "Name", "Current", "Suggested"})
+	}
+	for _, f := range findings {
+		table.Append([]string{f.Type, f.ID, f.Name, f.Current, f.Suggested})
+	}
+	table.Render()
+
+	return nil
+}
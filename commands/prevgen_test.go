@@ -0,0 +1,68 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+)
+
+func TestFindPreviousGenTypes(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddResource(
+		resourcetest.Instance("i-old").Prop(properties.Name, "web").Prop(properties.Type, "m4.large").Prop(properties.State, "running").Build(),
+		resourcetest.Instance("i-burst").Prop(properties.Type, "t2.micro").Prop(properties.State, "stopped").Build(),
+		resourcetest.Instance("i-gone").Prop(properties.Type, "m3.medium").Prop(properties.State, "terminated").Build(),
+		resourcetest.Instance("i-recent").Prop(properties.Type, "m5.large").Build(),
+		resourcetest.Instance("i-custom").Prop(properties.Type, "c5.xlarge").Build(),
+		resourcetest.Database("db-old").Prop(properties.Class, "db.r4.2xlarge").Build(),
+		resourcetest.Database("db-recent").Prop(properties.Class, "db.r5.large").Build(),
+	)
+	overrides := map[string]interface{}{"aws.prevgen.c5": "c6i", "aws.prevgen.t2": "none"}
+
+	findings, err := findPreviousGenTypes([]*graph.Graph{g, g}, overrides)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []*previousGenFinding{
+		{Type: cloud.Instance, ID: "i-custom", Current: "c5.xlarge", Suggested: "c6i.xlarge"},
+		{Type: cloud.Instance, ID: "i-old", Name: "web", Current: "m4.large", Suggested: "m5.large"},
+		{Type: cloud.Database, ID: "db-old", Current: "db.r4.2xlarge", Suggested: "db.r5.2xlarge"},
+	}
+	if got, want := findings, expected; !reflect.DeepEqual(got, want) {
+		for _, f := range got {
+			t.Logf("%#v", f)
+		}
+		t.Fatalf("got %d findings, want %d", len(got), len(want))
+	}
+
+	defer func(format string) { listingFormat = format }(listingFormat)
+	listingFormat = "json"
+	var buf bytes.Buffer
+	if err := printPreviousGenFindings(&buf, findings[:1]); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), `[{"type":"instance","id":"i-custom","current":"c5.xlarge","suggested":"c6i.xlarge"}]`+"\n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
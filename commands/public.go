@@ -0,0 +1,247 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws"
+	"github.com/wallix/awless/cloud"
+	p "github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/sync"
+)
+
+var publicExposureResourceTypes = []string{
+	cloud.Instance, cloud.SecurityGroup, cloud.Bucket, cloud.Database,
+	cloud.LoadBalancer, cloud.Snapshot, cloud.Image,
+	cloud.Queue, cloud.Topic, cloud.Function, cloud.Repository,
+}
+
+func init() {
+	listCmd.AddCommand(listPublicCmd)
+}
+
+var listPublicCmd = &cobra.Command{
+	Use:   "public",
+	Short: "List publicly reachable resources across services, with their exposure vector",
+	Long:  "List publicly reachable resources across services: instances with a public IP behind security groups open to the world, buckets granted to all users, publicly accessible databases, internet-facing loadbalancers, public snapshots and images, and resources (buckets, queues, topics, functions, repositories) whose resource-based policy allows anyone without condition",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		g := graph.NewGraph()
+		for _, resType := range publicExposureResourceTypes {
+			if localGlobalFlag {
+				g.AddGraph(sync.LoadCurrentLocalGraph(aws.ServicePerResourceType[resType]))
+				continue
+			}
+			srv, err := cloud.GetServiceForType(resType)
+			exitOn(err)
+			gph, err := srv.FetchByType(resType)
+			exitOn(err)
+			g.AddGraph(gph)
+		}
+
+		exposures, err := findPublicExposures(g)
+		exitOn(err)
+
+		exitOn(printPublicExposures(os.Stdout, exposures))
+	},
+}
+
+type publicExposure struct {
+	Type   string `json:"type"`
+	ID     string `json:"id"`
+	Name   string `json:"name,omitempty"`
+	Vector string `json:"vector"`
+}
+
+func findPublicExposures(g *graph.Graph) ([]*publicExposure, error) {
+	var exposures []*publicExposure
+	add := func(res *graph.Resource, format string, a ...interface{}) {
+		name, _ := res.Properties[p.Name].(string)
+		exposures = append(exposures, &publicExposure{Type: res.Type(), ID: res.Id(), Name: name, Vector: fmt.Sprintf(format, a...)})
+	}
+
+	openGroups := make(map[string][]string)
+	groups, err := g.GetAllResources(cloud.SecurityGroup)
+	if err != nil {
+		return exposures, err
+	}
+	for _, sg := range groups {
+		if open := worldOpenRules(sg); len(open) > 0 {
+			openGroups[sg.Id()] = open
+		}
+	}
+
+	instances, err := g.GetAllResources(cloud.Instance)
+	if err != nil {
+		return exposures, err
+	}
+	for _, inst := range instances {
+		ip, _ := inst.Properties[p.PublicIP].(string)
+		if ip == "" {
+			continue
+		}
+		sgs, _ := inst.Properties[p.SecurityGroups].([]string)
+		for _, sg := range sgs {
+			if open, ok := openGroups[sg]; ok {
+				add(inst, "public IP %s with security group %s open to the world on %s", ip, sg, strings.Join(open, ", "))
+			}
+		}
+	}
+
+	buckets, err := g.GetAllResources(cloud.Bucket)
+	if err != nil {
+		return exposures, err
+	}
+	for _, b := range buckets {
+		grants, _ := b.Properties[p.Grants].([]*graph.Grant)
+		for _, grant := range grants {
+			switch {
+			case strings.HasSuffix(grant.Grantee.GranteeID, "/global/AllUsers"):
+				add(b, "ACL grants %s to everyone (AllUsers)", grant.Permission)
+			case strings.HasSuffix(grant.Grantee.GranteeID, "/global/AuthenticatedUsers"):
+				add(b, "ACL grants %s to any AWS account (AuthenticatedUsers)", grant.Permission)
+			}
+		}
+	}
+
+	databases, err := g.GetAllResources(cloud.Database)
+	if err != nil {
+		return exposures, err
+	}
+	for _, db := range databases {
+		if public, _ := db.Properties[p.Public].(bool); public {
+			add(db, "publicly accessible endpoint %v", db.Properties[p.PublicDNS])
+		}
+	}
+
+	lbs, err := g.GetAllResources(cloud.LoadBalancer)
+	if err != nil {
+		return exposures, err
+	}
+	for _, lb := range lbs {
+		if scheme, _ := lb.Properties[p.Scheme].(string); scheme == "internet-facing" {
+			add(lb, "internet-facing with DNS %v", lb.Properties[p.PublicDNS])
+		}
+	}
+
+	for _, resType := range []string{cloud.Snapshot, cloud.Image} {
+		all, err := g.GetAllResources(resType)
+		if err != nil {
+			return exposures, err
+		}
+		for _, res := range all {
+			if public, _ := res.Properties[p.Public].(bool); public {
+				add(res, "%s shared publicly with all AWS accounts", resType)
+			}
+		}
+	}
+
+	for _, resType := range []string{cloud.Bucket, cloud.Queue, cloud.Topic, cloud.Function, cloud.Repository} {
+		all, err := g.GetAllResources(resType)
+		if err != nil {
+			return exposures, err
+		}
+		for _, res := range all {
+			doc, _ := res.Properties[p.ResourcePolicy].(string)
+			if doc == "" {
+				continue
+			}
+			grants, err := aws.ResourcePolicyGrants(doc)
+			if err != nil {
+				return exposures, fmt.Errorf("%s %s: %s", resType, res.Id(), err)
+			}
+			for _, grant := range grants {
+				if grant.Public() {
+					add(res, "resource policy allows %s to everyone", strings.Join(grant.Actions, ", "))
+				}
+			}
+		}
+	}
+
+	sort.Slice(exposures, func(i, j int) bool {
+		if exposures[i].Type != exposures[j].Type {
+			return exposures[i].Type < exposures[j].Type
+		}
+		return exposures[i].ID < exposures[j].ID
+	})
+
+	return exposures, nil
+}
+
+func worldOpenRules(sg *graph.Resource) (open []string) {
+	rules, _ := sg.Properties[p.InboundRules].([]*graph.FirewallRule)
+	for _, r := range rules {
+		for _, n := range r.IPRanges {
+			if ones, _ := n.Mask.Size(); ones != 0 {
+				continue
+			}
+			switch {
+			case r.Protocol == "any" || r.PortRange.Any:
+				open = append(open, fmt.Sprintf("%s:any", r.Protocol))
+			case r.PortRange.FromPort == r.PortRange.ToPort:
+				open = append(open, fmt.Sprintf("%s:%d", r.Protocol, r.PortRange.FromPort))
+			default:
+				open = append(open, fmt.Sprintf("%s:%d-%d", r.Protocol, r.PortRange.FromPort, r.PortRange.ToPort))
+			}
+			break
+		}
+	}
+	return
+}
+
+func printPublicExposures(w io.Writer, exposures []*publicExposure) error {
+	switch {
+	case listOnlyIDs || listIDsOnlyFlag:
+		for _, e := range exposures {
+			fmt.Fprintln(w, e.ID)
+		}
+		return nil
+	case listingFormat == "json":
+		return json.NewEncoder(w).Encode(exposures)
+	case listingFormat != "table":
+		return fmt.Errorf("unsupported format '%s' for public resources: use table or json", listingFormat)
+	}
+
+	if len(exposures) == 0 {
+		fmt.Fprintln(w, "No publicly exposed resources found")
+		return nil
+	}
+
+	table := tablewriter.NewWriter(w)
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAutoWrapText(false)
+	if !noHeadersFlag {
+		table.SetHeader([]string{"Type", "ID", "Name", "Exposure"})
+	}
+	for _, e := range exposures {
+		table.Append([]string{e.Type, e.ID, e.Name, e.Vector})
+	}
+	table.Render()
+
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	p "github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+)
+
+func TestFindPublicExposures(t *testing.T) {
+	_, world, _ := net.ParseCIDR("0.0.0.0/0")
+	_, private, _ := net.ParseCIDR("10.0.0.0/8")
+
+	g := graph.NewGraph()
+	g.AddResource(
+		resourcetest.SecurityGroup("sg_open").Prop(p.InboundRules, []*graph.FirewallRule{
+			{Protocol: "tcp", PortRange: graph.PortRange{FromPort: 22, ToPort: 22}, IPRanges: []*net.IPNet{world}},
+			{Protocol: "tcp", PortRange: graph.PortRange{FromPort: 5432, ToPort: 5432}, IPRanges: []*net.IPNet{private}},
+		}).Build(),
+		resourcetest.SecurityGroup("sg_private").Prop(p.InboundRules, []*graph.FirewallRule{
+			{Protocol: "any", PortRange: graph.PortRange{Any: true}, IPRanges: []*net.IPNet{private}},
+		}).Build(),
+		resourcetest.Instance("inst_1").Prop(p.Name, "web").Prop(p.PublicIP, "1.2.3.4").Prop(p.SecurityGroups, []string{"sg_open"}).Build(),
+		resourcetest.Instance("inst_2").Prop(p.PublicIP, "2.3.4.5").Prop(p.SecurityGroups, []string{"sg_private"}).Build(),
+		resourcetest.Instance("inst_3").Prop(p.SecurityGroups, []string{"sg_open"}).Build(),
+		resourcetest.Bucket("bucket_1").Prop(p.Grants, []*graph.Grant{
+			{Permission: "READ", Grantee: graph.Grantee{GranteeID: "http://acs.amazonaws.com/groups/global/AllUsers", GranteeType: "Group"}},
+			{Permission: "FULL_CONTROL", Grantee: graph.Grantee{GranteeID: "123", GranteeType: "CanonicalUser"}},
+		}).Build(),
+		resourcetest.Bucket("bucket_2").Prop(p.ResourcePolicy, `{"Statement":[{"Effect":"Allow","Principal":"*","Action":["s3:GetObject","s3:ListBucket"]}]}`).Build(),
+		resourcetest.Bucket("bucket_3").Build(),
+		resourcetest.Queue("queue_1").Prop(p.ResourcePolicy, `{"Statement":{"Effect":"Allow","Principal":{"AWS":"*"},"Action":"sqs:SendMessage","Condition":{"ArnEquals":{"aws:SourceArn":"topic_arn"}}}}`).Build(),
+		resourcetest.Database("db_1").Prop(p.Public, true).Prop(p.PublicDNS, "db_1.rds.amazonaws.com").Build(),
+		resourcetest.Database("db_2").Prop(p.Public, false).Build(),
+		resourcetest.LoadBalancer("lb_1").Prop(p.Scheme, "internet-facing").Prop(p.PublicDNS, "lb_1.elb.amazonaws.com").Build(),
+		resourcetest.LoadBalancer("lb_2").Prop(p.Scheme, "internal").Build(),
+		resourcetest.Snapshot("snap_1").Prop(p.Public, true).Build(),
+		resourcetest.Image("ami_1").Prop(p.Public, false).Build(),
+	)
+
+	exposures, err := findPublicExposures(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*publicExposure{
+		{Type: "bucket", ID: "bucket_1", Vector: "ACL grants READ to everyone (AllUsers)"},
+		{Type: "bucket", ID: "bucket_2", Vector: "resource policy allows s3:GetObject, s3:ListBucket to everyone"},
+		{Type: "database", ID: "db_1", Vector: "publicly accessible endpoint db_1.rds.amazonaws.com"},
+		{Type: "instance", ID: "inst_1", Name: "web", Vector: "public IP 1.2.3.4 with security group sg_open open to the world on tcp:22"},
+		{Type: "loadbalancer", ID: "lb_1", Vector: "internet-facing with DNS lb_1.elb.amazonaws.com"},
+		{Type: "snapshot", ID: "snap_1", Vector: "snapshot shared publicly with all AWS accounts"},
+	}
+	if got, want := exposures, expected; !reflect.DeepEqual(got, want) {
+		for _, e := range got {
+			t.Logf("%#v", e)
+		}
+		t.Fatalf("got %d exposures, want %d", len(got), len(want))
+	}
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws"
+	awsconfig "github.com/wallix/awless/aws/config"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/database"
+	"github.com/wallix/awless/logger"
+)
+
+const enabledRegionsCacheTTL = 24 * time.Hour
+
+var (
+	listRegionsLatencyFlag bool
+	listRegionsRefreshFlag bool
+)
+
+func init() {
+	listRegionsCmd.Flags().BoolVar(&listRegionsLatencyFlag, "latency", false, "Measure the round-trip latency to the EC2 endpoint of each enabled region")
+	listRegionsCmd.Flags().BoolVar(&listRegionsRefreshFlag, "refresh", false, "Refresh the regions enabled for the account instead of using the cached ones")
+	listCmd.AddCommand(listRegionsCmd)
+}
+
+var listRegionsCmd = &cobra.Command{
+	Use:     "regions",
+	Short:   "List all AWS regions, marking the ones enabled for your account",
+	Long:    fmt.Sprintf("List all AWS regions, marking the ones enabled for your account (i.e. opted-in).\n\nThe regions enabled for the account are cached locally for %s.", enabledRegionsCacheTTL),
+	Example: "  awless list regions\n  awless list regions --latency",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		enabled, err := enabledRegions(config.GetAWSProfile(), listRegionsRefreshFlag)
+		exitOn(err)
+
+		regions := buildRegionsStatus(awsconfig.AllRegions(), enabled, config.GetAWSRegion())
+		if listRegionsLatencyFlag {
+			measureRegionsLatency(regions)
+		}
+
+		exitOn(printRegions(os.Stdout, regions))
+	},
+}
+
+type enabledRegionsCache struct {
+	Time    time.Time `json:"time"`
+	Regions []string  `json:"regions"`
+}
+
+// enabledRegions returns the regions enabled for the account of the profile,
+// from the local cache when fresh enough, or from EC2 otherwise
+func enabledRegions(profile string, refresh bool) ([]string, error) {
+	key := "regions.enabled." + profile
+	cache := &enabledRegionsCache{}
+
+	if !refresh {
+		if err := database.Execute(func(db *database.DB) error {
+			b, err := db.GetBytes(key)
+			if err != nil || len(b) == 0 {
+				return err
+			}
+			return json.Unmarshal(b, cache)
+		}); err != nil {
+			logger.Verbosef("cannot read cached enabled regions: %s", err)
+		}
+		if len(cache.Regions) > 0 && time.Since(cache.Time) < enabledRegionsCacheTTL {
+			logger.ExtraVerbosef("using enabled regions cached at %s", cache.Time.Format(time.Stamp))
+			return cache.Regions, nil
+		}
+	}
+
+	infra, ok := aws.InfraService.(*aws.Infra)
+	if !ok {
+		return nil, aws.ErrMockUnsupported
+	}
+	regions, err := infra.EnabledRegions()
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := json.Marshal(&enabledRegionsCache{Time: time.Now().UTC(), Regions: regions})
+	if err != nil {
+		return regions, nil
+	}
+	if err = database.Execute(func(db *database.DB) error {
+		return db.SetBytes(key, b)
+	}); err != nil {
+		logger.Verbosef("cannot cache enabled regions: %s", err)
+	}
+
+	return regions, nil
+}
+
+type regionStatus struct {
+	Region  string        `json:"region"`
+	Enabled bool          `json:"enabled"`
+	Current bool          `json:"current,omitempty"`
+	Latency time.Duration `json:"latency,omitempty"`
+	Error   string        `json:"error,omitempty"`
+}
+
+func buildRegionsStatus(all, enabled []string, current string) []*regionStatus {
+	isEnabled := make(map[string]bool)
+	for _, r := range enabled {
+		isEnabled[r] = true
+	}
+
+	var regions []*regionStatus
+	seen := make(map[string]bool)
+	for _, r := range append(all, enabled...) {
+		if seen[r] {
+			continue
+		}
+		seen[r] = true
+		regions = append(regions, &regionStatus{Region: r, Enabled: isEnabled[r], Current: r == current})
+	}
+
+	return regions
+}
+
+func measureRegionsLatency(regions []*regionStatus) {
+	var wg sync.WaitGroup
+	for _, r := range regions {
+		if !r.Enabled {
+			continue
+		}
+		wg.Add(1)
+		go func(r *regionStatus) {
+			defer wg.Done()
+			latency, err := aws.RegionLatency(r.Region, 5*time.Second)
+			if err != nil {
+				r.Error = err.Error()
+				return
+			}
+			r.Latency = latency
+		}(r)
+	}
+	wg.Wait()
+}
+
+func printRegions(w io.Writer, regions []*regionStatus) error {
+	if listIDsOnlyFlag {
+		for _, r := range regions {
+			fmt.Fprintln(w, r.Region)
+		}
+		return nil
+	}
+	switch listingFormat {
+	case "json":
+		return json.NewEncoder(w).Encode(regions)
+	case "csv", "tsv":
+		csvw := csv.NewWriter(w)
+		if listingFormat == "tsv" {
+			csvw.Comma = '\t'
+		}
+		if !noHeadersFlag {
+			if err := csvw.Write([]string{"Region", "Enabled", "Current", "Latency"}); err != nil {
+				return err
+			}
+		}
+		for _, r := range regions {
+			if err := csvw.Write([]string{r.Region, fmt.Sprint(r.Enabled), fmt.Sprint(r.Current), formatLatency(r)}); err != nil {
+				return err
+			}
+		}
+		csvw.Flush()
+		return csvw.Error()
+	case "table":
+	default:
+		return fmt.Errorf("unsupported format '%s' for regions: use table, csv, tsv or json", listingFormat)
+	}
+
+	table := tablewriter.NewWriter(w)
+	table.SetBorders(tablewriter.Border{Left: true, Top: false, Right: true, Bottom: false})
+	table.SetCenterSeparator("|")
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAutoWrapText(false)
+	if !noHeadersFlag {
+		header := []string{"Region", "Enabled"}
+		if listRegionsLatencyFlag {
+			header = append(header, "Latency")
+		}
+		table.SetHeader(header)
+	}
+	for _, r := range regions {
+		name := r.Region
+		if r.Current {
+			name = renderGreenFn(name + " (current)")
+		}
+		enabled := "no"
+		if r.Enabled {
+			enabled = "yes"
+		}
+		row := []string{name, enabled}
+		if listRegionsLatencyFlag {
+			row = append(row, formatLatency(r))
+		}
+		table.Append(row)
+	}
+	table.Render()
+
+	return nil
+}
+
+func formatLatency(r *regionStatus) string {
+	switch {
+	case r.Error != "":
+		return "unreachable"
+	case r.Latency == 0:
+		return ""
+	default:
+		return r.Latency.Round(time.Millisecond).String()
+	}
+}
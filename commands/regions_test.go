@@ -0,0 +1,67 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/wallix/awless/database"
+)
+
+func TestRegionsStatus(t *testing.T) {
+	regions := buildRegionsStatus([]string{"ap-east-1", "eu-west-1", "us-east-1"}, []string{"eu-west-1", "us-east-1", "xx-new-1"}, "eu-west-1")
+
+	expected := []*regionStatus{
+		{Region: "ap-east-1"},
+		{Region: "eu-west-1", Enabled: true, Current: true},
+		{Region: "us-east-1", Enabled: true},
+		{Region: "xx-new-1", Enabled: true},
+	}
+	if got, want := regions, expected; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestEnabledRegionsCache(t *testing.T) {
+	home, err := ioutil.TempDir("", "awless-regions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(home)
+	os.Setenv("__AWLESS_HOME", home)
+	defer os.Unsetenv("__AWLESS_HOME")
+
+	b, _ := json.Marshal(&enabledRegionsCache{Time: time.Now().Add(-time.Hour), Regions: []string{"eu-west-1", "us-east-1"}})
+	if err := database.Execute(func(db *database.DB) error {
+		return db.SetBytes("regions.enabled.myprofile", b)
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	regions, err := enabledRegions("myprofile", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := regions, []string{"eu-west-1", "us-east-1"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/database"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template"
+)
+
+func init() {
+	RootCmd.AddCommand(retryCmd)
+}
+
+var retryCmd = &cobra.Command{
+	Use:               "retry [TEMPLATEID]",
+	Short:             "Run again the failed and remaining steps of the last template run (or of the given one), reusing the results of the succeeded steps",
+	Example:           "  awless retry\n  awless retry 01BA7RV6ES86PZYCM3H28WM6KZ",
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, initSyncerHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade),
+
+	RunE: func(c *cobra.Command, args []string) error {
+		var loaded *template.TemplateExecution
+		exitOn(database.Execute(func(db *database.DB) (terr error) {
+			if len(args) > 0 {
+				loaded, terr = db.GetTemplate(args[0])
+			} else {
+				loaded, terr = lastTemplateRun(db)
+			}
+			return
+		}))
+
+		if loc := loaded.Locale; loc != "" && loc != config.GetAWSRegion() {
+			logger.Errorf("This template was originally run in region %s. You are currently in region %s", loc, config.GetAWSRegion())
+			logger.Infof("You can retry it using the region flag: `awless retry %s -r %s`", loaded.ID, loc)
+			exitOn(errors.New("region mismatched"))
+		}
+
+		if loaded.Path != "" {
+			content, err := getTemplateText(loaded.Path)
+			exitOn(err)
+			if templateDigest(content) != loaded.Digest {
+				exitOn(fmt.Errorf("template %s changed since run %s: refusing to retry, run it again with `awless run`", loaded.Path, loaded.ID))
+			}
+		}
+
+		source, err := template.Parse(loaded.Source)
+		exitOn(err)
+		retry, err := template.RetryFailed(source, loaded.Template)
+		if err != nil {
+			exitOn(fmt.Errorf("cannot retry template run %s: %s", loaded.ID, err))
+		}
+		logger.Infof("retrying the failed and remaining steps of template run %s", loaded.ID)
+
+		tplExec := &template.TemplateExecution{
+			Template: retry,
+			Locale:   config.GetAWSRegion(),
+			Source:   retry.String(),
+		}
+		exitOn(runTemplate(tplExec, loaded.Fillers))
+
+		return nil
+	},
+}
+
+func lastTemplateRun(db *database.DB) (*template.TemplateExecution, error) {
+	all, err := db.ListTemplates()
+	if err != nil {
+		return nil, err
+	}
+	for i := len(all) - 1; i >= 0; i-- {
+		if all[i].Err == nil {
+			return all[i].TplExec, nil
+		}
+	}
+	return nil, errors.New("no template run found in awless log")
+}
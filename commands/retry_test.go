@@ -0,0 +1,66 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/wallix/awless/database"
+	"github.com/wallix/awless/template"
+)
+
+func TestLastTemplateRun(t *testing.T) {
+	home, err := ioutil.TempDir("", "awless-retry")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(home)
+	os.Setenv("__AWLESS_HOME", home)
+	defer os.Unsetenv("__AWLESS_HOME")
+
+	err = database.Execute(func(db *database.DB) error {
+		if _, err := lastTemplateRun(db); err == nil {
+			t.Fatal("expected error")
+		}
+		for _, id := range []string{"01BA7RV6ES86PZYCM3H28WM6KZ", "01BA7RV6ES86PZYCM3H28WM6MA"} {
+			tpl := template.MustParse("create vpc cidr=10.0.0.0/16")
+			tpl.ID = id
+			if err := db.AddTemplate(&template.TemplateExecution{Template: tpl, Source: tpl.String(), Path: "/tmp/vpc.aws", Digest: templateDigest([]byte("create vpc cidr=10.0.0.0/16"))}); err != nil {
+				return err
+			}
+		}
+		last, err := lastTemplateRun(db)
+		if err != nil {
+			return err
+		}
+		if got, want := last.ID, "01BA7RV6ES86PZYCM3H28WM6MA"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		if got, want := last.Path, "/tmp/vpc.aws"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		if got, want := last.Digest, templateDigest([]byte("create vpc cidr=10.0.0.0/16")); got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
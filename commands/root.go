@@ -44,6 +44,7 @@ func init() {
 	RootCmd.PersistentFlags().BoolVarP(&forceGlobalFlag, "force", "f", false, "Force the command and bypass any confirmation prompt")
 	RootCmd.PersistentFlags().StringVarP(&awsRegionGlobalFlag, "aws-region", "r", "", "Overwrite AWS region")
 	RootCmd.PersistentFlags().StringVarP(&awsProfileGlobalFlag, "aws-profile", "p", "", "Overwrite AWS profile")
+	RootCmd.PersistentFlags().DurationVar(&timeoutGlobalFlag, "timeout", 0, "Abort the command when not completed within this duration (ex: 10m)")
 	RootCmd.Flags().BoolVar(&versionGlobalFlag, "version", false, "Print awless version")
 
 	cobra.AddTemplateFunc("IsCmdAnnotatedOneliner", IsCmdAnnotatedOneliner)
@@ -135,6 +136,38 @@ __awless_get_conf_keys()
 		COMPREPLY=( $( compgen -W "${all_keys_output[*]}" -- "$cur" ) )
 		fi
 }
+__awless_get_tag_keys()
+{
+		local all_keys_output
+		if all_keys_output=$(awless completion tag-keys 2>/dev/null); then
+		COMPREPLY=( $( compgen -W "${all_keys_output[*]}" -- "$cur" ) )
+		fi
+}
+__awless_get_tag_values()
+{
+		local all_values_output
+		if all_values_output=$(awless completion tag-values 2>/dev/null); then
+		COMPREPLY=( $( compgen -W "${all_values_output[*]}" -- "$cur" ) )
+		fi
+}
+__awless_get_tags()
+{
+		local key all_output
+		if [[ "$prev" == "=" ]]; then
+				key="${words[cword-2]}"
+				if all_output=$(awless completion tag-values "$key" 2>/dev/null); then
+				COMPREPLY=( $( compgen -W "${all_output[*]}" -- "$cur" ) )
+				fi
+		elif [[ "$cur" == *=* ]]; then
+				key="${cur%%=*}"
+				if all_output=$(awless completion tag-values "$key" 2>/dev/null); then
+				COMPREPLY=( $( compgen -P "${key}=" -W "${all_output[*]}" -- "${cur#*=}" ) )
+				fi
+		elif all_output=$(awless completion tag-keys 2>/dev/null); then
+				COMPREPLY=( $( compgen -S "=" -W "${all_output[*]}" -- "$cur" ) )
+				compopt -o nospace 2>/dev/null
+		fi
+}
 
 __custom_func() {
     case ${last_command} in
@@ -19,6 +19,8 @@ package commands
 import (
 	"bufio"
 	"bytes"
+	"context"
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -26,11 +28,13 @@ import (
 	"io/ioutil"
 	"net/http"
 	"os"
+	"path/filepath"
 	"regexp"
 	"sort"
 	"strings"
 	stdsync "sync"
 	"text/tabwriter"
+	"time"
 
 	"github.com/chzyer/readline"
 	"github.com/spf13/cobra"
@@ -39,6 +43,7 @@ import (
 	"github.com/wallix/awless/aws/doc"
 	"github.com/wallix/awless/aws/driver"
 	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
 	"github.com/wallix/awless/config"
 	"github.com/wallix/awless/database"
 	"github.com/wallix/awless/graph"
@@ -46,17 +51,37 @@ import (
 	"github.com/wallix/awless/sync"
 	"github.com/wallix/awless/template"
 	"github.com/wallix/awless/template/driver"
+	"golang.org/x/crypto/ssh/terminal"
 )
 
 var scheduleRunInFlag string
 var scheduleRevertInFlag string
 var listRemoteTemplatesFlag bool
+var templateTimeoutFlag time.Duration
+var stepTimeoutFlag time.Duration
+var revertOnTimeoutFlag bool
+var forceProtectedFlag bool
+var instancesSelectorFlag string
+var batchSizeFlag int
+var batchDelayFlag time.Duration
+var waitInstancesStateFlag bool
+var waitInstancesTimeoutFlag time.Duration
+var requiredPermissionsFlag bool
+var stepByStepFlag bool
+var valuesFileFlag string
 
 func init() {
 	RootCmd.AddCommand(runCmd)
 	runCmd.Flags().BoolVar(&listRemoteTemplatesFlag, "list", false, "List templates available at https://github.com/wallix/awless-templates")
 	runCmd.Flags().StringVar(&scheduleRunInFlag, "run-in", "", "Postpone the execution of this template")
 	runCmd.Flags().StringVar(&scheduleRevertInFlag, "revert-in", "", "Schedule the revertion of this template")
+	runCmd.Flags().DurationVar(&templateTimeoutFlag, "template-timeout", 0, "Fail the template run when not completed within this duration (ex: 30m)")
+	runCmd.Flags().DurationVar(&stepTimeoutFlag, "step-timeout", 0, "Fail the template run when any of its steps is not completed within this duration (ex: 5m)")
+	runCmd.Flags().BoolVar(&revertOnTimeoutFlag, "revert-on-timeout", false, "Revert the steps already done when the template run times out")
+	runCmd.Flags().BoolVar(&forceProtectedFlag, "force-protected", false, "Allow deleting resources tagged as protected (awless:protected=true)")
+	runCmd.Flags().BoolVar(&requiredPermissionsFlag, "required-permissions", false, "Print the IAM policy granting the actions needed to run the template, without running it")
+	runCmd.Flags().BoolVar(&stepByStepFlag, "step", false, "Print each step with its resolved parameters and ask whether to run it, skip it or abort before running it")
+	runCmd.Flags().StringVar(&valuesFileFlag, "values", "", "File of template values, one key=value per line, possibly encrypted with awless values encrypt. Values given on the command line take precedence")
 
 	var actions []string
 	for a := range awsdriver.DriverSupportedActions() {
@@ -70,6 +95,20 @@ func init() {
 		cmd := createDriverCommands(action, entities)
 		cmd.PersistentFlags().StringVar(&scheduleRunInFlag, "run-in", "", "Postpone the execution of this command")
 		cmd.PersistentFlags().StringVar(&scheduleRevertInFlag, "revert-in", "", "Schedule the revertion of this command")
+		cmd.PersistentFlags().DurationVar(&stepTimeoutFlag, "step-timeout", 0, "Fail the command when not completed within this duration (ex: 5m)")
+		cmd.PersistentFlags().BoolVar(&requiredPermissionsFlag, "required-permissions", false, "Print the IAM policy granting the actions needed to run this command, without running it")
+		if action == "delete" {
+			cmd.PersistentFlags().BoolVar(&forceProtectedFlag, "force-protected", false, "Allow deleting resources tagged as protected (awless:protected=true)")
+			cmd.Flags().StringVar(&deleteManifestFlag, "manifest", "", "Delete the resources of a manifest made with `awless generate manifest`, in reverse creation order")
+			cmd.PersistentFlags().BoolVar(&cascadeDeleteFlag, "cascade", false, "Delete a vpc after its dependents (instances, load balancers, NAT and internet gateways, subnets, route tables, security groups), in dependency order")
+		}
+		if action == "start" || action == "stop" || action == "reboot" {
+			cmd.PersistentFlags().StringVar(&instancesSelectorFlag, "selector", "", fmt.Sprintf("Select the instances to %s from the local graph given tags (ex: --selector tag.Env=dev,tag.Team=web)", action))
+			cmd.PersistentFlags().IntVar(&batchSizeFlag, "batch", 0, fmt.Sprintf("Number of instances to %s at once (default: all at once)", action))
+			cmd.PersistentFlags().DurationVar(&batchDelayFlag, "delay", 0, "Time to wait between two batches of instances (ex: 30s)")
+			cmd.PersistentFlags().BoolVar(&waitInstancesStateFlag, "wait", false, fmt.Sprintf("Wait for the instances to be %s before returning", map[string]string{"start": "running", "stop": "stopped", "reboot": "running with status checks passing"}[action]))
+			cmd.PersistentFlags().DurationVar(&waitInstancesTimeoutFlag, "wait-timeout", 0, "Maximum time to wait for the instances with --wait (default 5m)")
+		}
 		RootCmd.AddCommand(cmd)
 	}
 }
@@ -77,7 +116,7 @@ func init() {
 var runCmd = &cobra.Command{
 	Use:               "run PATH",
 	Short:             "Run a template given a filepath or a URL (prefixed with http)",
-	Example:           "  awless run ~/templates/my-infra.txt\n  awless run https://raw.githubusercontent.com/wallix/awless-templates/master/create_vpc.awls\n  awless run repo:create_vpc",
+	Example:           "  awless run ~/templates/my-infra.txt\n  awless run https://raw.githubusercontent.com/wallix/awless-templates/master/create_vpc.awls\n  awless run repo:create_vpc\n  awless run ~/templates/my-infra.txt --values secrets.enc",
 	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, initSyncerHook),
 	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade),
 
@@ -101,18 +140,43 @@ var runCmd = &cobra.Command{
 		extraParams, err := template.ParseParams(strings.Join(args[1:], " "))
 		exitOn(err)
 
+		fileValues := make(map[string]interface{})
+		if valuesFileFlag != "" {
+			fileValues, err = loadValuesFile(valuesFileFlag)
+			exitOn(err)
+		}
+
 		tplExec := &template.TemplateExecution{
 			Template: templ,
 			Locale:   config.GetAWSRegion(),
 			Source:   templ.String(),
+			Path:     templatePath(args[0]),
+			Digest:   templateDigest(content),
 		}
 
-		exitOn(runTemplate(tplExec, config.Defaults, extraParams))
+		exitOn(runTemplate(tplExec, config.Defaults, fileValues, extraParams))
 
 		return nil
 	},
 }
 
+// requiredPermissionsPolicy returns the policy granting the IAM actions of the drivers run by the template,
+// on all resources since the resources created by the template are not known in advance
+func requiredPermissionsPolicy(tpl *template.Template) (*policyDocument, error) {
+	var defs []string
+	for _, cmd := range tpl.CommandNodesIterator() {
+		defs = append(defs, cmd.Action+cmd.Entity)
+	}
+	actions, err := awsdriver.RequiredPermissions(defs...)
+	if err != nil {
+		return nil, err
+	}
+	return &policyDocument{
+		Version:   "2012-10-17",
+		Statement: []*policyStatement{{Effect: "Allow", Action: actions, Resource: "*"}},
+	}, nil
+}
+
 func missingHolesStdinFunc() func(string) interface{} {
 	var count int
 	return func(hole string) (response interface{}) {
@@ -187,12 +251,35 @@ func (l *onceLoader) mustLoad() *graph.Graph {
 var allGraphsOnce = &onceLoader{}
 
 func runTemplate(tplExec *template.TemplateExecution, fillers ...map[string]interface{}) error {
+	if requiredPermissionsFlag {
+		policy, err := requiredPermissionsPolicy(tplExec.Template)
+		exitOn(err)
+		b, err := json.MarshalIndent(policy, "", "  ")
+		exitOn(err)
+		fmt.Println(string(b))
+		return nil
+	}
+
+	if stepByStepFlag {
+		if isSchedulingMode() {
+			exitOn(errors.New("--step can not be used with --run-in or --revert-in"))
+		}
+		if !terminal.IsTerminal(int(os.Stdin.Fd())) {
+			exitOn(errors.New("--step needs an interactive terminal"))
+		}
+	}
+
 	env := template.NewEnv()
 	env.Log = logger.DefaultLogger
 	env.AddFillers(fillers...)
 	env.DefLookupFunc = awsdriver.AWSLookupDefinitions
 	env.AliasFunc = resolveAliasFunc
+	env.ArnFunc = resolveArnFunc
+	awsdriver.SecurityGroupNameResolver = resolveSecurityGroupName
 	env.MissingHolesFunc = missingHolesStdinFunc()
+	if aws.ParamStore != nil {
+		env.ParamStoreFunc = aws.ParamStore.Get
+	}
 
 	if len(env.Fillers) > 0 {
 		logger.ExtraVerbosef("default/given holes fillers: %s", sprintProcessedParams(env.Fillers))
@@ -205,12 +292,13 @@ func runTemplate(tplExec *template.TemplateExecution, fillers ...map[string]inte
 	tplExec.Fillers = env.GetProcessedFillers()
 
 	validateTemplate(tplExec.Template)
+	checkProtectedResources(tplExec.Template)
 
 	var drivers []driver.Driver
 	for _, s := range cloud.ServiceRegistry {
 		drivers = append(drivers, s.Drivers()...)
 	}
-	awsDriver := driver.NewMultiDriver(drivers...)
+	awsDriver := driver.NewConcurrentMultiDriver(config.GetApplyConcurrency(), drivers...)
 
 	awsDriver.SetLogger(logger.DefaultLogger)
 
@@ -242,22 +330,42 @@ func runTemplate(tplExec *template.TemplateExecution, fillers ...map[string]inte
 	}
 
 	if strings.TrimSpace(yesorno) == "y" {
-		me, err := aws.AccessService.(*aws.Access).GetIdentity()
-		if err != nil {
-			logger.Warningf("cannot resolve template author identity: %s", err)
-		} else {
-			tplExec.Author = me.ResourcePath
-			logger.ExtraVerbosef("resolved template author: %s", tplExec.Author)
+		if access, ok := aws.AccessService.(*aws.Access); ok {
+			me, err := access.GetIdentity()
+			if err != nil {
+				logger.Warningf("cannot resolve template author identity: %s", err)
+			} else {
+				tplExec.Author = me.ResourcePath
+				logger.ExtraVerbosef("resolved template author: %s", tplExec.Author)
+			}
 		}
 
 		if isSchedulingMode() {
 			exitOn(scheduleTemplate(tplExec.Template, scheduleRunInFlag, scheduleRevertInFlag))
 			return nil
 		}
-		tplExec.Template, err = tplExec.Template.Run(awsDriver)
+		startNotifiedOperation("run template")
+		ctx := commandCtx
+		if templateTimeoutFlag > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, templateTimeoutFlag)
+			defer cancel()
+		}
+		var confirm template.StepConfirmer
+		if stepByStepFlag {
+			confirm = stepConfirmer(os.Stdin, os.Stdout)
+		}
+		tplExec.Template, err = tplExec.Template.RunStepByStep(ctx, awsDriver, stepTimeoutFlag, confirm)
 		if err != nil {
 			logger.Errorf("Running template error: %s", err)
 		}
+		tagCreatedResources(tplExec.Template, awsDriver, templateTags())
+
+		if err = database.Execute(func(db *database.DB) error {
+			return db.AddAuditEntries(auditEntries(tplExec, time.Now().UTC())...)
+		}); err != nil {
+			logger.Errorf("Cannot write executed actions in awless audit log: %s", err)
+		}
 
 		printer := template.NewDefaultPrinter(os.Stdout)
 		printer.RenderKO = renderRedFn
@@ -270,7 +378,25 @@ func runTemplate(tplExec *template.TemplateExecution, fillers ...map[string]inte
 			logger.Errorf("Cannot save executed template in awless logs: %s", err)
 		}
 
+		timedOut, isTimedOut := tplExec.Template.TimedOut()
+		if isTimedOut {
+			fmt.Println()
+			logger.Errorf("step `%s` %s", timedOut, timedOut.CmdErr)
+		}
+		renameNotifiedOperation(fmt.Sprintf("run template %s", tplExec.Template.ID))
+		endNotifiedOperation(templateRunError(tplExec.Template))
+
 		if template.IsRevertible(tplExec.Template) {
+			if isTimedOut && revertOnTimeoutFlag {
+				logger.Info("Reverting the steps done before timeout")
+				reverted, err := tplExec.Template.Revert()
+				exitOn(err)
+				return runTemplate(&template.TemplateExecution{
+					Template: reverted,
+					Locale:   config.GetAWSRegion(),
+					Source:   reverted.String(),
+				})
+			}
 			fmt.Println()
 			logger.Infof("Revert this template with `awless revert %s`", tplExec.Template.ID)
 		}
@@ -281,12 +407,82 @@ func runTemplate(tplExec *template.TemplateExecution, fillers ...map[string]inte
 	return nil
 }
 
+func stepConfirmer(in io.Reader, out io.Writer) template.StepConfirmer {
+	scanner := bufio.NewScanner(in)
+	return func(step *template.Step) template.StepDecision {
+		fmt.Fprintf(out, "\n%s\n", renderGreenFn(step))
+		for {
+			fmt.Fprint(out, "Run this step? (y)es/(s)kip/(a)bort: ")
+			if !scanner.Scan() {
+				fmt.Fprintln(out)
+				return template.StepAbort
+			}
+			switch strings.ToLower(strings.TrimSpace(scanner.Text())) {
+			case "y", "yes":
+				return template.StepRun
+			case "s", "skip":
+				if err := step.SkipError(); err != nil {
+					logger.Error(err)
+					continue
+				}
+				logger.Infof("skipping `%s`", step)
+				return template.StepSkip
+			case "a", "abort":
+				return template.StepAbort
+			}
+		}
+	}
+}
+
+func checkProtectedResources(tpl *template.Template) {
+	fetched := make(map[string]*graph.Graph)
+	rule := &template.ProtectedResourceValidator{LookupGraph: func(key string) (*graph.Graph, bool) {
+		if g, ok := fetched[key]; ok {
+			return g, g != nil
+		}
+		fetched[key] = nil
+		srv, err := cloud.GetServiceForType(key)
+		if err != nil {
+			return nil, false
+		}
+		g, err := srv.FetchByType(key)
+		if err != nil {
+			logger.Warningf("cannot check protection of %s resources: %s", key, err)
+			return nil, false
+		}
+		fetched[key] = g
+		return g, true
+	}}
+
+	errs := tpl.Validate(rule)
+	if len(errs) == 0 {
+		return
+	}
+	for _, err := range errs {
+		if forceProtectedFlag {
+			logger.Warning(err)
+		} else {
+			logger.Error(err)
+		}
+	}
+	if !forceProtectedFlag {
+		exitOn(fmt.Errorf("refusing to delete %d protected resource(s): use --force-protected to delete them anyway", len(errs)))
+	}
+}
+
 func validateTemplate(tpl *template.Template) {
 	unicityRule := &template.UniqueNameValidator{LookupGraph: func(key string) (*graph.Graph, bool) {
 		g := sync.LoadCurrentLocalGraph(aws.ServicePerResourceType[key])
 		return g, true
 	}}
 
+	if errs := tpl.Validate(&template.WeightRangeValidator{}); len(errs) > 0 {
+		for _, err := range errs {
+			logger.Error(err)
+		}
+		exitOn(fmt.Errorf("%d invalid weight(s)", len(errs)))
+	}
+
 	errs := tpl.Validate(unicityRule, &template.ParamIsSetValidator{Action: "create", Entity: "instance", Param: "keypair", WarningMessage: "This instance has no access keypair. You might not be able to connect to it. Use `awless create instance keypair=my-keypair ...`"})
 
 	if len(errs) > 0 {
@@ -306,6 +502,10 @@ func createDriverCommands(action string, entities []string) *cobra.Command {
 		PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, initSyncerHook),
 		PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if action == "delete" && deleteManifestFlag != "" {
+				exitOn(runManifestDeletion(deleteManifestFlag))
+				return nil
+			}
 			if len(args) == 0 {
 				return fmt.Errorf("missing ENTITY")
 			}
@@ -317,6 +517,14 @@ func createDriverCommands(action string, entities []string) *cobra.Command {
 				return invalidEntityErr
 			}
 
+			if cascadeDeleteFlag {
+				if resources[0].Type() != cloud.Vpc {
+					return errors.New("--cascade is only supported with vpc")
+				}
+				exitOn(runVpcCascadeDeletion(resources[0].Id()))
+				return nil
+			}
+
 			templDef, ok := awsdriver.AWSLookupDefinitions(fmt.Sprintf("%s%s", action, resources[0].Type()))
 			if !ok {
 				return invalidEntityErr
@@ -342,6 +550,20 @@ func createDriverCommands(action string, entities []string) *cobra.Command {
 		}
 		run := func(def template.Definition) func(cmd *cobra.Command, args []string) error {
 			return func(cmd *cobra.Command, args []string) error {
+				if cascadeDeleteFlag {
+					if def.Entity != cloud.Vpc {
+						return errors.New("--cascade is only supported with vpc")
+					}
+					vpc, err := cascadeVpcID(args)
+					exitOn(err)
+					exitOn(runVpcCascadeDeletion(vpc))
+					return nil
+				}
+				if instancesSelectorFlag != "" || batchSizeFlag > 0 || batchDelayFlag > 0 || waitInstancesStateFlag || waitInstancesTimeoutFlag > 0 {
+					instancesArgs, err := instancesStateArgs(def)
+					exitOn(err)
+					args = append(args, instancesArgs...)
+				}
 				text := fmt.Sprintf("%s %s %s", def.Action, def.Entity, strings.Join(args, " "))
 
 				templ, err := template.Parse(text)
@@ -410,6 +632,66 @@ func createDriverCommands(action string, entities []string) *cobra.Command {
 	return actionCmd
 }
 
+// instancesStateArgs returns the template params of the instances selected with --selector,
+// of their staggering with --batch and --delay and of the wait for their state with --wait
+func instancesStateArgs(def template.Definition) ([]string, error) {
+	if def.Entity != cloud.Instance {
+		return nil, fmt.Errorf("--selector, --batch, --delay and --wait are only supported with instances")
+	}
+	var args []string
+	if instancesSelectorFlag != "" {
+		filters, err := parseSelector(instancesSelectorFlag)
+		if err != nil {
+			return nil, err
+		}
+		g := sync.LoadCurrentLocalGraph(aws.ServicePerResourceType[cloud.Instance])
+		selected, err := g.Filter(cloud.Instance, filters...)
+		if err != nil {
+			return nil, err
+		}
+		instances, err := selected.GetAllResources(cloud.Instance)
+		if err != nil {
+			return nil, err
+		}
+		if len(instances) == 0 {
+			return nil, fmt.Errorf("no instance matching selector '%s' in local graph (run `awless sync` if needed)", instancesSelectorFlag)
+		}
+		var ids []string
+		for _, inst := range instances {
+			ids = append(ids, inst.Id())
+		}
+		sort.Strings(ids)
+		logger.Infof("%d instances selected with '%s'", len(ids), instancesSelectorFlag)
+		args = append(args, "id="+strings.Join(ids, ","))
+	}
+	if batchSizeFlag > 0 {
+		args = append(args, fmt.Sprintf("batch=%d", batchSizeFlag))
+	}
+	if batchDelayFlag > 0 {
+		args = append(args, fmt.Sprintf("delay=%s", batchDelayFlag))
+	}
+	if waitInstancesStateFlag {
+		args = append(args, "wait=true")
+	}
+	if waitInstancesTimeoutFlag > 0 {
+		args = append(args, fmt.Sprintf("wait-timeout=%s", waitInstancesTimeoutFlag))
+	}
+	return args, nil
+}
+
+// parseSelector parses comma separated tag conditions (ex: tag.Env=dev,tag.Team=web)
+func parseSelector(selector string) ([]graph.FilterFn, error) {
+	var filters []graph.FilterFn
+	for _, cond := range strings.Split(selector, ",") {
+		splits := strings.SplitN(strings.TrimSpace(cond), "=", 2)
+		if len(splits) != 2 || !strings.HasPrefix(splits[0], "tag.") || len(splits[0]) == len("tag.") {
+			return nil, fmt.Errorf("invalid selector '%s': expecting tag.Key=Value", cond)
+		}
+		filters = append(filters, graph.BuildTagFilterFunc(strings.TrimPrefix(splits[0], "tag."), splits[1]))
+	}
+	return filters, nil
+}
+
 func runSyncFor(tpl *template.Template) {
 	if !config.GetAutosync() {
 		return
@@ -428,6 +710,18 @@ func runSyncFor(tpl *template.Template) {
 	}
 }
 
+// resolveSecurityGroupName returns the id of the security group with the given name in the VPC, from the local model
+func resolveSecurityGroupName(name, vpc string) string {
+	gph := sync.LoadCurrentLocalGraph(aws.ServicePerResourceType[cloud.SecurityGroup])
+	resources, err := gph.ResolveResources(&graph.And{Resolvers: []graph.Resolver{
+		&graph.ByType{Typ: cloud.SecurityGroup}, &graph.ByProperty{Key: properties.Name, Value: name}, &graph.ByProperty{Key: properties.Vpc, Value: vpc},
+	}})
+	if err != nil || len(resources) != 1 {
+		return ""
+	}
+	return resources[0].Id()
+}
+
 func resolveAliasFunc(entity, key, alias string) string {
 	gph := sync.LoadCurrentLocalGraph(aws.ServicePerResourceType[entity])
 	resType := key
@@ -455,12 +749,46 @@ func resolveAliasFunc(entity, key, alias string) string {
 	return ""
 }
 
+// resolveArnFunc returns the id of the resource given as an ARN, resolved against the local
+// graphs or, failing that, derived from the ARN. It returns empty when the ARN cannot be resolved
+func resolveArnFunc(entity, key, arn string) string {
+	g, err := sync.LoadAllGraphs()
+	if err != nil {
+		return ""
+	}
+	resources, err := resolveArn(g, arn)
+	if err != nil {
+		return ""
+	}
+	var matching []*graph.Resource
+	for _, res := range resources {
+		if key != "id" || res.Type() == entity {
+			matching = append(matching, res)
+		}
+	}
+	if len(matching) == 1 {
+		return matching[0].Id()
+	}
+
+	parsed, err := aws.ParseArn(arn)
+	if err != nil {
+		return ""
+	}
+	if typ, id, err := parsed.ResourceTypeAndId(); err == nil && (key != "id" || typ == entity) {
+		return id
+	}
+	return ""
+}
+
 func sprintProcessedParams(processed map[string]interface{}) string {
 	if len(processed) == 0 {
 		return "<none>"
 	}
 	var str []string
 	for k, v := range processed {
+		if isSensitiveKey(k) {
+			v = redactedValue
+		}
 		str = append(str, fmt.Sprintf("%s=%v", k, v))
 	}
 	return strings.Join(str, ", ")
@@ -513,6 +841,21 @@ func getTemplateText(path string) (content []byte, err error) {
 	return content, nil
 }
 
+// templatePath returns the path to read again the template given to run
+func templatePath(path string) string {
+	if strings.HasPrefix(path, "repo:") || strings.HasPrefix(path, "http") {
+		return path
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
+
+func templateDigest(content []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(content))
+}
+
 func removeComments(b []byte) []byte {
 	scn := bufio.NewScanner(bytes.NewReader(b))
 	var cleaned bytes.Buffer
@@ -0,0 +1,104 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/template"
+)
+
+func TestParseSelector(t *testing.T) {
+	filters, err := parseSelector("tag.Env=dev, tag.Team=web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	match := graph.InitResource("instance", "inst_1")
+	match.Properties["Tags"] = []string{"Env=dev", "Team=web"}
+	other := graph.InitResource("instance", "inst_2")
+	other.Properties["Tags"] = []string{"Env=dev", "Team=db"}
+
+	g := graph.NewGraph()
+	g.AddResource(match, other)
+	selected, err := g.Filter("instance", filters...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	instances, _ := selected.GetAllResources("instance")
+	if got, want := len(instances), 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := instances[0].Id(), "inst_1"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+
+	for _, invalid := range []string{"Env=dev", "tag.=dev", "tag.Env"} {
+		if _, err := parseSelector(invalid); err == nil {
+			t.Fatalf("%s: expected error", invalid)
+		}
+	}
+}
+
+func TestRequiredPermissionsPolicy(t *testing.T) {
+	tpl := template.MustParse("vpc = create vpc cidr=10.0.0.0/16 name=test\nsubnet = create subnet cidr=10.0.0.0/24 vpc=$vpc\ncreate instance image=ami-12 type=t2.micro subnet=$subnet count=1 name=web\nstart instance id=i-1234")
+	policy, err := requiredPermissionsPolicy(tpl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &policyDocument{Version: "2012-10-17", Statement: []*policyStatement{{
+		Effect:   "Allow",
+		Action:   []string{"ec2:CreateSubnet", "ec2:CreateTags", "ec2:CreateVpc", "ec2:DescribeInstances", "ec2:RunInstances", "ec2:StartInstances", "iam:PassRole"},
+		Resource: "*",
+	}}}
+	if got, want := policy, expected; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got.Statement[0], want.Statement[0])
+	}
+}
+
+func TestStepConfirmer(t *testing.T) {
+	tpl, err := template.Parse("vpc = create vpc cidr=10.0.0.0/16")
+	if err != nil {
+		t.Fatal(err)
+	}
+	step := &template.Step{Ident: "vpc", Command: tpl.CommandNodesIterator()[0]}
+	tcases := []struct {
+		in           string
+		referencedBy []string
+		exp          template.StepDecision
+	}{
+		{in: "y\n", exp: template.StepRun},
+		{in: "\nunknown\nYes\n", exp: template.StepRun},
+		{in: "s\n", exp: template.StepSkip},
+		{in: "s\na\n", referencedBy: []string{"create subnet vpc=$vpc"}, exp: template.StepAbort},
+		{in: "", exp: template.StepAbort},
+	}
+	for i, tcase := range tcases {
+		step.ReferencedBy = tcase.referencedBy
+		var out bytes.Buffer
+		confirm := stepConfirmer(strings.NewReader(tcase.in), &out)
+		if got, want := confirm(step), tcase.exp; got != want {
+			t.Fatalf("%d: got %d, want %d", i, got, want)
+		}
+		if !strings.Contains(out.String(), "vpc = create vpc cidr=10.0.0.0/16\n") {
+			t.Fatalf("%d: step not printed in %q", i, out.String())
+		}
+	}
+}
@@ -58,7 +58,11 @@ var awsImagesCmd = &cobra.Command{
 			exitOn(fmt.Errorf("expecting image query string. Expecting: %s (with everything optional expect for the owner)", aws.ImageQuerySpec))
 		}
 
-		resolver := &aws.ImageResolver{aws.InfraService.(*aws.Infra)}
+		infra, ok := aws.InfraService.(*aws.Infra)
+		if !ok {
+			exitOn(aws.ErrMockUnsupported)
+		}
+		resolver := &aws.ImageResolver{infra}
 
 		query, err := aws.ParseImageQuery(args[0])
 		exitOn(err)
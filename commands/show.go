@@ -18,15 +18,18 @@ package commands
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/wallix/awless/aws"
 	"github.com/wallix/awless/cloud"
+	p "github.com/wallix/awless/cloud/properties"
 	"github.com/wallix/awless/config"
 	"github.com/wallix/awless/console"
 	"github.com/wallix/awless/graph"
@@ -37,22 +40,40 @@ import (
 var (
 	listAllSiblingsFlag          bool
 	showPropertiesValuesOnlyFlag []string
+	showMaxDepthFlag             int
+	showPolicyFlag               bool
+	showPolicyVersionFlag        string
+	showPolicyDiffVersionFlag    string
+	showTimelineFlag             bool
+	showTimelineDaysFlag         int
 )
 
 func init() {
 	RootCmd.AddCommand(showCmd)
 	showCmd.Flags().BoolVar(&listAllSiblingsFlag, "siblings", false, "List all the resource's siblings")
 	showCmd.Flags().StringSliceVar(&showPropertiesValuesOnlyFlag, "values-for", []string{}, "Output values only for given properties keys")
+	showCmd.Flags().IntVar(&showMaxDepthFlag, "max-depth", graph.DefaultMaxDepth, "Maximum depth of the relations displayed")
+	showCmd.Flags().BoolVar(&showPolicyFlag, "policy", false, "Show the resource-based policy of the resource (bucket, queue, topic, function, repository) and its cross-account principals")
+	showCmd.Flags().StringVar(&showPolicyVersionFlag, "version", "", "Show the given version of a managed policy document rather than its default version (implies --policy)")
+	showCmd.Flags().StringVar(&showPolicyDiffVersionFlag, "diff-version", "", "Show the changes of a managed policy document from the given version to the default (or --version) one (implies --policy)")
+	showCmd.Flags().BoolVar(&showTimelineFlag, "timeline", false, "Show the chronological history of the changes made on the resource and by whom (CloudTrail)")
+	showCmd.Flags().IntVar(&showTimelineDaysFlag, "timeline-days", aws.CloudTrailLookupMaxDays, fmt.Sprintf("Number of days of CloudTrail events in the timeline (at most %d)", aws.CloudTrailLookupMaxDays))
+	outputFormatFlag(showCmd.Flags(), &listingFormat, "table", "json")
 }
 
 var showCmd = &cobra.Command{
 	Use:   "show REFERENCE",
-	Short: "Show a resource and its interrelations given a REFERENCE: id or name",
+	Short: "Show a resource and its interrelations given a REFERENCE: id, name or ARN",
 	Example: `  awless show i-8d43b21b            # show an instance via its ref
   awless show AIDAJ3Z24GOKHTZO4OIX6 # show a user via its ref
   awless show jsmith                # show a user via its ref,
-  awless show @jsmith               # forcing search by name`,
-	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, initSyncerHook),
+  awless show @jsmith               # forcing search by name
+  awless show arn:aws:ec2:us-east-1:123456789012:instance/i-8d43b21b # show an instance via its ARN
+  awless show my-bucket --policy    # show the bucket policy
+  awless show my-role --policy      # show the trust and inline policies of a role
+  awless show arn:aws:iam::123456789012:policy/my-policy --diff-version v2 # show the changes since version v2 of a managed policy
+  awless show i-8d43b21b --timeline # show who changed the instance and when`,
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initOutputFormatHook, initCloudServicesHook, initSyncerHook),
 	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade),
 
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -63,6 +84,14 @@ var showCmd = &cobra.Command{
 		ref := args[0]
 		notFound := fmt.Sprintf("resource with reference %s not found", deprefix(ref))
 
+		if showPolicyVersionFlag != "" || showPolicyDiffVersionFlag != "" {
+			showPolicyFlag = true
+		}
+		if showPolicyFlag && isManagedPolicyArn(ref) {
+			showManagedPolicy(ref)
+			return nil
+		}
+
 		var resource *graph.Resource
 		var gph *graph.Graph
 
@@ -72,7 +101,7 @@ var showCmd = &cobra.Command{
 			logger.Info(notFound)
 			return nil
 		} else if resource == nil {
-			runFullSync()
+			runSyncForRef(ref)
 
 			if resource, gph = findResourceInLocalGraphs(ref); resource == nil {
 				logger.Info(notFound)
@@ -95,6 +124,14 @@ var showCmd = &cobra.Command{
 				showResourceValuesOnlyFor(resource, showPropertiesValuesOnlyFlag)
 				return nil
 			}
+			if showPolicyFlag {
+				showPolicies(resource)
+				return nil
+			}
+			if showTimelineFlag {
+				showResourceTimeline(resource)
+				return nil
+			}
 			showResource(resource, gph)
 		}
 
@@ -143,8 +180,12 @@ func showResource(resource *graph.Resource, gph *graph.Graph) {
 
 	exitOn(displayer.Print(os.Stdout))
 
+	if listingFormat == "json" {
+		return
+	}
+
 	var parents []*graph.Resource
-	err = gph.Accept(&graph.ParentsVisitor{From: resource, Each: graph.VisitorCollectFunc(&parents)})
+	err = gph.Accept(&graph.ParentsVisitor{From: resource, Each: graph.VisitorCollectFunc(&parents), MaxDepth: showMaxDepthFlag})
 	exitOn(err)
 
 	var parentsW bytes.Buffer
@@ -176,7 +217,7 @@ func showResource(resource *graph.Resource, gph *graph.Graph) {
 		fmt.Fprintf(&childrenW, "%s↳ %s\n", tabs.String(), display)
 		return nil
 	}
-	err = gph.Accept(&graph.ChildrenVisitor{From: resource, Each: printWithTabs, IncludeFrom: true})
+	err = gph.Accept(&graph.ChildrenVisitor{From: resource, Each: printWithTabs, IncludeFrom: true, MaxDepth: showMaxDepthFlag})
 	exitOn(err)
 
 	if len(parents) > 0 || hasChildren {
@@ -191,12 +232,376 @@ func showResource(resource *graph.Resource, gph *graph.Graph) {
 
 	dependingOn, err := gph.ListResourcesDependingOn(resource)
 	exitOn(err)
-	printResourceList(renderCyanBoldFn("Depending on"), dependingOn)
+	printResourceList(renderCyanBoldFn("Depending on"), excludeType(dependingOn, cloud.Finding))
 
 	var siblings []*graph.Resource
 	err = gph.Accept(&graph.SiblingsVisitor{From: resource, Each: graph.VisitorCollectFunc(&siblings)})
 	exitOn(err)
 	printResourceList(renderCyanBoldFn("Siblings"), siblings, "display all with flag --siblings")
+
+	printInferredRelations(resource)
+	printAccessPolicies(resource, gph)
+	printFindings(resource)
+}
+
+func excludeType(resources []*graph.Resource, typ string) (out []*graph.Resource) {
+	for _, r := range resources {
+		if r.Type() != typ {
+			out = append(out, r)
+		}
+	}
+	return
+}
+
+// printFindings displays the GuardDuty findings applying on the resource. Findings
+// are synced with the infra but may apply on resources of other services (ex: buckets, access keys)
+func printFindings(resource *graph.Resource) {
+	if resource.Type() == cloud.Finding {
+		return
+	}
+	all, err := sync.LoadAllGraphs()
+	if err != nil {
+		logger.Verbosef("cannot load graphs to list findings: %s", err)
+		return
+	}
+	dependingOn, err := all.ListResourcesDependingOn(resource)
+	if err != nil {
+		logger.Verbosef("cannot list findings: %s", err)
+		return
+	}
+	var findings []*graph.Resource
+	for _, r := range dependingOn {
+		if r.Type() == cloud.Finding {
+			findings = append(findings, r)
+		}
+	}
+	if len(findings) == 0 {
+		return
+	}
+	sort.Slice(findings, func(i, j int) bool {
+		si, _ := findings[i].Properties[p.Severity].(string)
+		sj, _ := findings[j].Properties[p.Severity].(string)
+		return aws.FindingSeverityRank(si) > aws.FindingSeverityRank(sj)
+	})
+	fmt.Println(renderCyanBoldFn("\n# Findings:"))
+	for _, f := range findings {
+		severity, _ := f.Properties[p.Severity].(string)
+		title, _ := f.Properties[p.Name].(string)
+		fmt.Printf("\t[%s] %s (%s)\n", severity, title, f.Id())
+	}
+}
+
+// printInferredRelations displays the relations of the resource found by the inference rules
+// on all the local graphs, as they may relate resources of different services
+func printInferredRelations(resource *graph.Resource) {
+	all, err := sync.LoadAllGraphs()
+	if err != nil {
+		logger.Verbosef("cannot load graphs to infer relations: %s", err)
+		return
+	}
+	relations, err := aws.InferRelations(all, config.GetConfigWithPrefix(aws.InferConfigPrefix))
+	if err != nil {
+		logger.Verbosef("cannot infer relations: %s", err)
+		return
+	}
+	var lines []string
+	for _, rel := range relations {
+		switch {
+		case rel.From.Same(resource):
+			lines = append(lines, fmt.Sprintf("applies on %s (%s)", rel.To, rel.Rule))
+		case rel.To.Same(resource):
+			lines = append(lines, fmt.Sprintf("applied by %s (%s)", rel.From, rel.Rule))
+		}
+	}
+	if len(lines) > 0 {
+		sort.Strings(lines)
+		fmt.Printf("\n%s: %s\n", renderCyanBoldFn("Inferred"), strings.Join(lines, ", "))
+	}
+}
+
+// printAccessPolicies summarizes the managed and inline policies of a user, group or role.
+// For a user, policies inherited from its groups are also listed
+func printAccessPolicies(resource *graph.Resource, gph *graph.Graph) {
+	lines := accessPolicyLines(resource, gph)
+	if len(lines) == 0 {
+		return
+	}
+	fmt.Println(renderCyanBoldFn("\n# Policies:"))
+	for _, l := range lines {
+		fmt.Println(l)
+	}
+}
+
+func accessPolicyLines(resource *graph.Resource, gph *graph.Graph) (lines []string) {
+	switch resource.Type() {
+	case cloud.User, cloud.Group, cloud.Role:
+	default:
+		return
+	}
+	describe := func(r *graph.Resource, prefix string) {
+		managed, _ := r.Properties[p.ManagedPolicies].([]string)
+		inlines, _ := r.Properties[p.InlinePolicies].([]string)
+		if len(managed) > 0 {
+			lines = append(lines, fmt.Sprintf("%smanaged: %s", prefix, strings.Join(sortedCopy(managed), ", ")))
+		}
+		if len(inlines) > 0 {
+			lines = append(lines, fmt.Sprintf("%sinline: %s", prefix, strings.Join(sortedCopy(inlines), ", ")))
+		}
+	}
+	describe(resource, "")
+
+	if resource.Type() != cloud.User {
+		return
+	}
+	groups, _ := resource.Properties[p.Groups].([]string)
+	for _, name := range sortedCopy(groups) {
+		found, err := gph.ResolveResources(&graph.And{Resolvers: []graph.Resolver{
+			&graph.ByProperty{Key: p.Name, Value: name},
+			&graph.ByType{Typ: cloud.Group},
+		}})
+		if err != nil || len(found) != 1 {
+			lines = append(lines, fmt.Sprintf("via group %s: unknown group", name))
+			continue
+		}
+		describe(found[0], fmt.Sprintf("via group %s: ", name))
+	}
+	return
+}
+
+func sortedCopy(s []string) []string {
+	c := append([]string(nil), s...)
+	sort.Strings(c)
+	return c
+}
+
+func showPolicies(resource *graph.Resource) {
+	switch resource.Type() {
+	case cloud.Policy:
+		arn, _ := resource.Properties[p.Arn].(string)
+		showManagedPolicy(arn)
+		return
+	case cloud.User, cloud.Group, cloud.Role:
+		if showPolicyVersionFlag != "" || showPolicyDiffVersionFlag != "" {
+			exitOn(errors.New("--version and --diff-version apply to managed policies"))
+		}
+		showIdentityPolicies(resource)
+		return
+	}
+	if showPolicyVersionFlag != "" || showPolicyDiffVersionFlag != "" {
+		exitOn(errors.New("--version and --diff-version apply to managed policies"))
+	}
+	showResourcePolicy(resource)
+}
+
+func isManagedPolicyArn(ref string) bool {
+	return strings.HasPrefix(ref, "arn:") && strings.Contains(ref, ":policy/")
+}
+
+func accessService() *aws.Access {
+	access, ok := aws.AccessService.(*aws.Access)
+	if !ok {
+		exitOn(aws.ErrMockUnsupported)
+	}
+	return access
+}
+
+// showManagedPolicy shows the normalized document of the default (or --version) version
+// of a managed policy or, with --diff-version, its changes since the given version
+func showManagedPolicy(arn string) {
+	access := accessService()
+	version, err := access.PolicyVersionDocument(arn, showPolicyVersionFlag)
+	exitOn(err)
+	doc, err := aws.NormalizePolicyDocument(version.Document)
+	exitOn(err)
+
+	if showPolicyDiffVersionFlag == "" {
+		if listingFormat == "json" {
+			fmt.Println(doc)
+			return
+		}
+		title := fmt.Sprintf("# %s (version %s", arn, version.ID)
+		if version.Default {
+			title += ", default"
+		}
+		fmt.Println(renderCyanBoldFn(title + ")"))
+		fmt.Println(doc)
+		return
+	}
+
+	from, err := access.PolicyVersionDocument(arn, showPolicyDiffVersionFlag)
+	exitOn(err)
+	fromDoc, err := aws.NormalizePolicyDocument(from.Document)
+	exitOn(err)
+	fmt.Println(renderCyanBoldFn(fmt.Sprintf("# %s: version %s to %s", arn, from.ID, version.ID)))
+	if fromDoc == doc {
+		fmt.Println("no changes")
+		return
+	}
+	for _, line := range aws.DiffLines(fromDoc, doc) {
+		switch {
+		case strings.HasPrefix(line, "+"):
+			fmt.Println(renderGreenFn(line))
+		case strings.HasPrefix(line, "-"):
+			fmt.Println(renderRedFn(line))
+		default:
+			fmt.Println(line)
+		}
+	}
+}
+
+// showIdentityPolicies shows the normalized trust policy of a role and inline policies of a user, group or role
+func showIdentityPolicies(resource *graph.Resource) {
+	access := accessService()
+	name, _ := resource.Properties[p.Name].(string)
+	if resource.Type() == cloud.Role {
+		trust, err := access.RoleTrustPolicy(name)
+		exitOn(err)
+		doc, err := aws.NormalizePolicyDocument(trust)
+		exitOn(err)
+		fmt.Println(renderCyanBoldFn("# Trust policy:"))
+		fmt.Println(doc)
+	}
+	inlines, _ := resource.Properties[p.InlinePolicies].([]string)
+	for _, policy := range inlines {
+		raw, err := access.InlinePolicyDocument(resource.Type(), name, policy)
+		exitOn(err)
+		doc, err := aws.NormalizePolicyDocument(raw)
+		exitOn(err)
+		fmt.Println(renderCyanBoldFn(fmt.Sprintf("# Inline policy %s:", policy)))
+		fmt.Println(doc)
+	}
+	if resource.Type() != cloud.Role && len(inlines) == 0 {
+		logger.Infof("no inline policies for %s", resource)
+	}
+}
+
+func showResourcePolicy(resource *graph.Resource) {
+	doc, _ := resource.Properties[p.ResourcePolicy].(string)
+	if doc == "" {
+		logger.Infof("no resource-based policy for %s", resource)
+		return
+	}
+
+	if listingFormat == "json" {
+		fmt.Println(doc)
+		return
+	}
+
+	normalized, err := aws.NormalizePolicyDocument(doc)
+	exitOn(err)
+	fmt.Println(normalized)
+
+	account := resourceAccount(resource)
+	if account == "" {
+		logger.Verbosef("cannot resolve account of %s: skipping cross-account principals", resource)
+		return
+	}
+	principals, err := aws.CrossAccountPrincipals(doc, account)
+	exitOn(err)
+	if len(principals) > 0 {
+		fmt.Println(renderCyanBoldFn("\n# Cross-account principals:"))
+		for _, princ := range principals {
+			if princ == "*" {
+				princ += " (anyone)"
+			}
+			fmt.Println(renderRedFn(princ))
+		}
+	}
+}
+
+func showResourceTimeline(resource *graph.Resource) {
+	if showTimelineDaysFlag < 1 || showTimelineDaysFlag > aws.CloudTrailLookupMaxDays {
+		exitOn(fmt.Errorf("--timeline-days must be between 1 and %d (CloudTrail event history lookback)", aws.CloudTrailLookupMaxDays))
+	}
+	if aws.CloudTrail == nil {
+		exitOn(aws.ErrMockUnsupported)
+	}
+
+	names := []string{resource.Id()}
+	for _, key := range []string{p.Name, p.Arn} {
+		if v, ok := resource.Properties[key].(string); ok && v != "" && v != resource.Id() {
+			names = append(names, v)
+		}
+	}
+
+	end := time.Now().UTC()
+	start := end.AddDate(0, 0, -showTimelineDaysFlag)
+	logger.Verbosef("looking up CloudTrail events of %s from %s to %s", strings.Join(names, ", "), start.Format(time.RFC3339), end.Format(time.RFC3339))
+	timeline, err := aws.CloudTrail.ResourceTimeline(names, start, end)
+	exitOn(err)
+
+	if listingFormat == "json" {
+		b, err := json.MarshalIndent(timeline, "", "  ")
+		exitOn(err)
+		fmt.Println(string(b))
+		return
+	}
+	if len(timeline) == 0 {
+		logger.Infof("no change recorded on %s in the last %d days of CloudTrail events of the current region", resource, showTimelineDaysFlag)
+		return
+	}
+	for _, e := range timeline {
+		line := fmt.Sprintf("%s  %s  %s", e.Time.Local().Format(time.RFC3339), renderCyanBoldFn(fmt.Sprintf("%s:%s", strings.TrimSuffix(e.Source, ".amazonaws.com"), e.Name)), e.Actor)
+		if e.SourceIP != "" {
+			line += fmt.Sprintf(" (from %s)", e.SourceIP)
+		}
+		if e.ErrorCode != "" {
+			line += " " + renderRedFn(e.ErrorCode)
+		}
+		fmt.Println(line)
+	}
+}
+
+// resourceAccount returns the account owning the resource, defaulting to the account of the current credentials
+func resourceAccount(resource *graph.Resource) string {
+	if arn, ok := resource.Properties[p.Arn].(string); ok {
+		if account := aws.ArnAccount(arn); account != "" {
+			return account
+		}
+	}
+	if account, ok := resource.Properties[p.Account].(string); ok && account != "" {
+		return account
+	}
+	if access, ok := aws.AccessService.(*aws.Access); ok {
+		if me, err := access.GetIdentity(); err == nil {
+			return me.Account
+		}
+	}
+	return ""
+}
+
+// runSyncForRef syncs only the service of the resource when the reference is an ARN
+// of a known resource type, falling back on a full sync otherwise
+func runSyncForRef(ref string) {
+	if !aws.IsArn(ref) || !config.GetAutosync() {
+		runFullSync()
+		return
+	}
+	parsed, err := aws.ParseArn(ref)
+	if err != nil {
+		logger.Verbose(err)
+		runFullSync()
+		return
+	}
+	typ, _, err := parsed.ResourceTypeAndId()
+	if err != nil {
+		logger.Verbose(err)
+		runFullSync()
+		return
+	}
+	srv, ok := cloud.ServiceRegistry[aws.ServicePerResourceType[typ]]
+	if !ok {
+		runFullSync()
+		return
+	}
+	if region := config.GetAWSRegion(); parsed.Region != "" && parsed.Region != region {
+		logger.Warningf("resource of ARN is in region %s, not in current region %s (use `-r %s`)", parsed.Region, region, parsed.Region)
+	}
+
+	logger.Infof("cannot resolve resource - syncing %s service", srv.Name())
+	if _, err := sync.DefaultSyncer.Sync(srv); err != nil {
+		logger.Verbose(err)
+	}
 }
 
 func runFullSync() {
@@ -249,6 +654,12 @@ func resolveResourceFromRef(ref string) []*graph.Resource {
 	name := deprefix(ref)
 	byName := &graph.ByProperty{Key: "Name", Value: name}
 
+	if aws.IsArn(ref) {
+		rs, err := resolveArn(g, ref)
+		exitOn(err)
+		return rs
+	}
+
 	if strings.HasPrefix(ref, "@") {
 		logger.Verbosef("prefixed with @: forcing research by name '%s'", name)
 		rs, err := g.ResolveResources(byName)
@@ -272,6 +683,24 @@ func resolveResourceFromRef(ref string) []*graph.Resource {
 	}
 }
 
+// resolveArn resolves an ARN first against the Arn property of resources,
+// then against the resource type and id derived from the ARN
+func resolveArn(g *graph.Graph, arn string) ([]*graph.Resource, error) {
+	rs, err := g.ResolveResources(&graph.ByProperty{Key: p.Arn, Value: arn})
+	if err != nil || len(rs) > 0 {
+		return rs, err
+	}
+	parsed, err := aws.ParseArn(arn)
+	if err != nil {
+		return nil, nil
+	}
+	typ, id, err := parsed.ResourceTypeAndId()
+	if err != nil || id == "" {
+		return nil, nil
+	}
+	return g.ResolveResources(&graph.And{Resolvers: []graph.Resolver{&graph.ByType{Typ: typ}, &graph.ById{Id: id}}})
+}
+
 func deprefix(s string) string {
 	return strings.TrimPrefix(s, "@")
 }
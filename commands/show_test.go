@@ -0,0 +1,59 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+)
+
+func TestAccessPolicyLines(t *testing.T) {
+	g := graph.NewGraph()
+	admins := resourcetest.Group("grp-1").Prop(properties.Name, "admins").Prop(properties.ManagedPolicies, []string{"AdministratorAccess"}).Build()
+	devs := resourcetest.Group("grp-2").Prop(properties.Name, "devs").Prop(properties.InlinePolicies, []string{"deploy"}).Build()
+	user := resourcetest.User("usr-1").Prop(properties.Name, "jdoe").
+		Prop(properties.ManagedPolicies, []string{"ReadOnlyAccess", "IAMUserChangePassword"}).
+		Prop(properties.InlinePolicies, []string{"s3-logs"}).
+		Prop(properties.Groups, []string{"devs", "admins", "gone"}).Build()
+	role := resourcetest.Role("role-1").Prop(properties.InlinePolicies, []string{"assume"}).Build()
+	g.AddResource(admins, devs, user, role)
+
+	tcases := []struct {
+		res *graph.Resource
+		exp []string
+	}{
+		{res: user, exp: []string{
+			"managed: IAMUserChangePassword, ReadOnlyAccess",
+			"inline: s3-logs",
+			"via group admins: managed: AdministratorAccess",
+			"via group devs: inline: deploy",
+			"via group gone: unknown group",
+		}},
+		{res: admins, exp: []string{"managed: AdministratorAccess"}},
+		{res: role, exp: []string{"inline: assume"}},
+		{res: resourcetest.Instance("inst-1").Build(), exp: nil},
+	}
+	for i, tcase := range tcases {
+		if got, want := accessPolicyLines(tcase.res, g), tcase.exp; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%d: got %#v, want %#v", i, got, want)
+		}
+	}
+}
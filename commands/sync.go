@@ -17,13 +17,21 @@ limitations under the License.
 package commands
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"sort"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/wallix/awless/aws"
+	awsconfig "github.com/wallix/awless/aws/config"
 	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/console"
 	"github.com/wallix/awless/graph"
 	"github.com/wallix/awless/logger"
 	"github.com/wallix/awless/sync"
@@ -31,8 +39,15 @@ import (
 
 var (
 	servicesToSyncFlags map[string]*bool
+	syncProgressFlag    bool
+	syncPlanFlag        bool
+	syncAccountsFlag    []string
+
+	syncConcurrentRegionsFlag int
 )
 
+const syncCmdName = "sync"
+
 func init() {
 	RootCmd.AddCommand(syncCmd)
 
@@ -41,18 +56,23 @@ func init() {
 		servicesToSyncFlags[service] = new(bool)
 		syncCmd.Flags().BoolVar(servicesToSyncFlags[service], service, false, fmt.Sprintf("Sync '%s' service only", service))
 	}
+	syncCmd.Flags().BoolVar(&syncProgressFlag, "progress", false, "Display the fetching progress of each service (updated in place on a terminal)")
+	syncCmd.Flags().BoolVar(&syncPlanFlag, "plan", false, "Estimate the API calls and duration of the sync of each service from the resources of the last sync, without syncing")
+	syncCmd.Flags().IntVar(&syncConcurrentRegionsFlag, "concurrent-regions", sync.DefaultConcurrentRegions, "Maximum number of regions (or accounts) synced at once when syncing all regions (or several accounts)")
+	syncCmd.Flags().StringSliceVar(&syncAccountsFlag, "accounts", nil, fmt.Sprintf("Sync the given accounts, assuming in each the role of the '%s' config file", aws.RoleMapConfigKey))
 }
 
 var syncCmd = &cobra.Command{
-	Use:               "sync",
+	Use:               syncCmdName,
 	Short:             "Manual sync of your remote resources to your local rdf store. For example when auto sync unset",
+	Example:           "  awless sync\n  awless sync --infra --progress\n  awless sync -r all    # sync every region enabled for the account\n  awless sync -r all --concurrent-regions 2\n  awless sync --plan    # estimate the cost of a sync\n  awless sync --accounts 123456789012,210987654321",
 	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, initSyncerHook),
 	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade),
 
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if extraVerboseGlobalFlag {
 			logger.DefaultLogger.SetVerbose(logger.ExtraVerboseF)
-		} else {
+		} else if !syncProgressFlag {
 			logger.DefaultLogger.SetVerbose(logger.VerboseF) //Forcing verbose to display sync info
 		}
 
@@ -72,24 +92,204 @@ var syncCmd = &cobra.Command{
 		for _, service := range services {
 			localGraphs[service.Name()] = sync.LoadCurrentLocalGraph(service.Name())
 		}
+		allRegions := config.GetAWSRegion() == awsconfig.AllEnabledRegions
+		if len(syncAccountsFlag) > 0 && (allRegions || syncPlanFlag) {
+			exitOn(errors.New("--accounts cannot be combined with --plan or with all regions"))
+		}
+		if syncConcurrentRegionsFlag < 1 {
+			exitOn(fmt.Errorf("invalid --concurrent-regions %d: expecting at least 1", syncConcurrentRegionsFlag))
+		}
+		sync.DefaultSyncer.SetConcurrentRegions(syncConcurrentRegionsFlag)
+		if syncPlanFlag {
+			regionsCount := 1
+			if allRegions {
+				regions, rerr := syncedRegions()
+				exitOn(rerr)
+				regionsCount = len(regions)
+			}
+			var estimates []*aws.SyncEstimate
+			for _, service := range services {
+				estimates = append(estimates, aws.EstimateSync(service, localGraphs[service.Name()], config.GetConfigWithPrefix("aws."), regionsCount))
+			}
+			printSyncPlan(os.Stdout, estimates)
+			return nil
+		}
 		logger.Info("running sync: fetching remote resources for local store")
+		if syncProgressFlag {
+			var names []string
+			if !allRegions {
+				for _, service := range services {
+					names = append(names, service.Name())
+				}
+			}
+			sync.DefaultSyncer.SetProgress(console.NewTerminalProgress(os.Stderr, names...))
+		}
 		start := time.Now()
+		startNotifiedOperation("sync")
 
-		graphs, err := sync.DefaultSyncer.Sync(services...)
+		var graphs map[string]*graph.Graph
+		var err error
+		if len(syncAccountsFlag) > 0 {
+			accounts := mappedAccounts(syncAccountsFlag)
+			logger.Infof("syncing %d accounts: %s", len(accounts), strings.Join(accounts, ", "))
+			graphs, err = sync.DefaultSyncer.SyncRegions(accounts, nil, accountServicesFn(cloud.Services(services).Names()))
+		} else if allRegions {
+			regions, rerr := syncedRegions()
+			exitOn(rerr)
+			logger.Infof("syncing %d regions: %s", len(regions), strings.Join(regions, ", "))
+			graphs, err = sync.DefaultSyncer.SyncRegions(regions, aws.GlobalServiceNames, regionServicesFn(cloud.Services(services).Names()))
+		} else {
+			graphs, err = sync.DefaultSyncer.Sync(services...)
+		}
 		if err != nil {
 			logger.Verbose(err)
 		}
+		endNotifiedOperation(err)
 
+		counts := make(map[string]int)
 		for k, g := range graphs {
-			displaySyncStats(k, g)
+			for rt, n := range displaySyncStats(k, g) {
+				counts[rt] += n
+			}
 		}
 		logger.Infof("sync took %s", time.Since(start))
 
+		crossed := checkSyncBudget(counts, config.GetSyncBudget())
+		for _, msg := range crossed {
+			logger.Warning(msg)
+		}
+		if len(crossed) > 0 {
+			exitOn(fmt.Errorf("%d resource count(s) out of the %s config", len(crossed), config.SyncBudgetConfigKey))
+		}
+
 		return nil
 	},
 }
 
-func displaySyncStats(serviceName string, g *graph.Graph) {
+// syncedRegions returns the regions enabled for the account, noting the skipped ones
+func syncedRegions() ([]string, error) {
+	enabled, err := enabledRegions(config.GetAWSProfile(), false)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list the regions enabled for the account: %s", err)
+	}
+	isEnabled := make(map[string]bool)
+	for _, r := range enabled {
+		isEnabled[r] = true
+	}
+	var skipped []string
+	for _, r := range awsconfig.AllRegions() {
+		if !isEnabled[r] {
+			skipped = append(skipped, r)
+		}
+	}
+	if len(skipped) > 0 {
+		logger.Infof("skipping regions not enabled for the account: %s", strings.Join(skipped, ", "))
+	}
+	return enabled, nil
+}
+
+// regionServicesFn returns the services with the given names in a region
+func regionServicesFn(names []string) sync.RegionServices {
+	selected := make(map[string]bool)
+	for _, name := range names {
+		selected[name] = true
+	}
+	return func(region string) ([]cloud.Service, error) {
+		all, err := aws.RegionServices(config.GetConfigWithPrefix("aws."), region, logger.DefaultLogger)
+		if err != nil {
+			return nil, err
+		}
+		var services []cloud.Service
+		for _, srv := range all {
+			if selected[srv.Name()] {
+				services = append(services, srv)
+			}
+		}
+		return services, nil
+	}
+}
+
+// mappedAccounts returns the accounts having a role in the role map, warning about the skipped ones
+func mappedAccounts(accounts []string) []string {
+	roles, err := aws.ConfigRoleMap(config.GetConfigWithPrefix("aws."))
+	exitOn(err)
+	var mapped []string
+	for _, account := range accounts {
+		if _, err := roles.RoleFor(account); err != nil {
+			logger.Warningf("skipping account %s: %s", account, err)
+			continue
+		}
+		mapped = append(mapped, account)
+	}
+	if len(mapped) == 0 {
+		exitOn(errors.New("no account to sync"))
+	}
+	return mapped
+}
+
+// accountServicesFn returns the services with the given names in an account
+func accountServicesFn(names []string) sync.RegionServices {
+	selected := make(map[string]bool)
+	for _, name := range names {
+		selected[name] = true
+	}
+	return func(account string) ([]cloud.Service, error) {
+		all, err := aws.AccountServices(config.GetConfigWithPrefix("aws."), account, logger.DefaultLogger)
+		if err != nil {
+			return nil, err
+		}
+		var services []cloud.Service
+		for _, srv := range all {
+			if selected[srv.Name()] {
+				services = append(services, srv)
+			}
+		}
+		return services, nil
+	}
+}
+
+// printSyncPlan displays the sync estimates of the services, heaviest first
+func printSyncPlan(w io.Writer, estimates []*aws.SyncEstimate) {
+	sort.Slice(estimates, func(i, j int) bool {
+		if estimates[i].Calls == estimates[j].Calls {
+			return estimates[i].Service < estimates[j].Service
+		}
+		return estimates[i].Calls > estimates[j].Calls
+	})
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "SERVICE\tRESOURCES\tAPI CALLS\tDURATION\tHEAVIEST")
+	var totalCalls int
+	var longest time.Duration
+	var unknown []string
+	for _, est := range estimates {
+		if est.Calls == 0 {
+			fmt.Fprintf(tw, "%s\t-\t0\t-\tsync disabled\n", est.Service)
+			continue
+		}
+		resources := fmt.Sprint(est.Resources)
+		if !est.Known {
+			resources = "?"
+			unknown = append(unknown, est.Service)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s (%d calls)\n", est.Service, resources, est.Calls, est.Duration.Round(time.Second/10), est.Heaviest, est.HeaviestCalls)
+		totalCalls += est.Calls
+		if est.Duration > longest {
+			longest = est.Duration
+		}
+	}
+	tw.Flush()
+	fmt.Fprintf(w, "\n~%d API calls in ~%s (services are synced concurrently, at ~%s per call)\n", totalCalls, longest.Round(time.Second/10), aws.EstimatedCallLatency)
+	if len(estimates) > 0 && estimates[0].Calls > 0 {
+		fmt.Fprintf(w, "heaviest: %s, scope the sync with --%s or the other service flags\n", estimates[0].Service, estimates[0].Service)
+	}
+	if len(unknown) > 0 {
+		fmt.Fprintf(w, "never synced (estimate is a lower bound): %s\n", strings.Join(unknown, ", "))
+	}
+}
+
+// displaySyncStats displays and returns the number of resources per type of the synced service
+func displaySyncStats(serviceName string, g *graph.Graph) map[string]int {
+	counts := make(map[string]int)
 	var strs []string
 	for rt, service := range aws.ServicePerResourceType {
 		if service == serviceName {
@@ -98,6 +298,7 @@ func displaySyncStats(serviceName string, g *graph.Graph) {
 				continue
 			}
 			nbRes := len(res)
+			counts[rt] = nbRes
 			if nbRes > 1 {
 				strs = append(strs, fmt.Sprintf("%d %s", nbRes, cloud.PluralizeResource(rt)))
 			} else {
@@ -106,4 +307,31 @@ func displaySyncStats(serviceName string, g *graph.Graph) {
 		}
 	}
 	logger.Infof("-> %s: %s", serviceName, strings.Join(strs, ", "))
+	return counts
+}
+
+// checkSyncBudget returns the crossed budgets given the synced resources counts per type.
+// Budgets of resource types not synced (ex: service disabled) are not checked
+func checkSyncBudget(counts map[string]int, budgets map[string]config.ResourceBudget) (crossed []string) {
+	var types []string
+	for t := range budgets {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	for _, t := range types {
+		rt := cloud.SingularizeResource(t)
+		if _, ok := aws.ServicePerResourceType[rt]; !ok {
+			logger.Warningf("%s config: unknown resource type '%s'", config.SyncBudgetConfigKey, t)
+			continue
+		}
+		count, synced := counts[rt]
+		if !synced {
+			logger.Verbosef("%s config: %s not synced, budget not checked", config.SyncBudgetConfigKey, cloud.PluralizeResource(rt))
+			continue
+		}
+		if budget := budgets[t]; budget.Crossed(count) {
+			crossed = append(crossed, fmt.Sprintf("%d %s synced, expected %s", count, cloud.PluralizeResource(rt), budget))
+		}
+	}
+	return
 }
@@ -0,0 +1,43 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/wallix/awless/config"
+)
+
+func TestCheckSyncBudget(t *testing.T) {
+	counts := map[string]int{"instance": 600, "subnet": 3, "bucket": 0, "vpc": 2}
+	budgets := map[string]config.ResourceBudget{
+		"instances": {Min: 10, Max: 500},
+		"subnet":    {Min: 5, Max: -1},
+		"bucket":    {Min: -1, Max: 100},
+		"vpc":       {Min: 1, Max: 2},
+		"function":  {Min: 1, Max: -1},
+		"unknown":   {Min: 1, Max: -1},
+	}
+	expected := []string{
+		"600 instances synced, expected between 10 and 500",
+		"3 subnets synced, expected at least 5",
+	}
+	if got, want := checkSyncBudget(counts, budgets), expected; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/chzyer/readline"
 	"github.com/wallix/awless/aws"
 	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
 	"github.com/wallix/awless/graph"
 	"github.com/wallix/awless/template"
 )
@@ -129,3 +130,45 @@ func init() {
 		resourcesTypesWithPlural = append(resourcesTypesWithPlural, r, cloud.PluralizeResource(r))
 	}
 }
+
+// tagKeys returns the sorted set of the tag keys of all the resources of the graph
+func tagKeys(g *graph.Graph) ([]string, error) {
+	return collectTags(g, func(k, v string) (string, bool) { return k, true })
+}
+
+// tagValues returns the sorted set of the values of the given tag key across
+// all the resources of the graph, or of all tags when the key is empty
+func tagValues(g *graph.Graph, key string) ([]string, error) {
+	return collectTags(g, func(k, v string) (string, bool) { return v, key == "" || k == key })
+}
+
+func collectTags(g *graph.Graph, collect func(k, v string) (string, bool)) ([]string, error) {
+	resources, err := g.GetAllResources(aws.ResourceTypes...)
+	if err != nil {
+		return nil, err
+	}
+
+	unique := make(map[string]bool)
+	for _, res := range resources {
+		tags, ok := res.Properties[properties.Tags].([]string)
+		if !ok {
+			continue
+		}
+		for _, t := range tags {
+			splits := strings.SplitN(t, "=", 2)
+			if len(splits) != 2 {
+				continue
+			}
+			if s, ok := collect(splits[0], splits[1]); ok && s != "" {
+				unique[s] = true
+			}
+		}
+	}
+
+	var out []string
+	for s := range unique {
+		out = append(out, s)
+	}
+	sort.Strings(out)
+	return out, nil
+}
@@ -60,6 +60,38 @@ func TestAutoCompletion(t *testing.T) {
 	})
 }
 
+func TestTagsAutoCompletion(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddResource(resourcetest.Instance("1").Prop(p.Tags, []string{"Env=Production", "Dept=Marketing"}).Build())
+	g.AddResource(resourcetest.Instance("2").Prop(p.Tags, []string{"Env=Staging", "Formula=a=b"}).Build())
+	g.AddResource(resourcetest.Subnet("3").Prop(p.Tags, []string{"Env=Production", "Empty="}).Build())
+	g.AddResource(resourcetest.Subnet("4").Build())
+
+	keys, err := tagKeys(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := keys, []string{"Dept", "Empty", "Env", "Formula"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	values, err := tagValues(g, "Env")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := values, []string{"Production", "Staging"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	values, err = tagValues(g, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := values, []string{"Marketing", "Production", "Staging", "a=b"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
 func toRune(arr ...string) [][]rune {
 	out := make([][]rune, len(arr))
 	for i, s := range arr {
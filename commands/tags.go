@@ -0,0 +1,159 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template"
+	"github.com/wallix/awless/template/driver"
+)
+
+// taggableEntities are created by templates with an EC2 id that can be tagged
+var taggableEntities = map[string]bool{
+	"dhcpoptions": true, "elasticip": true, "fleet": true, "instance": true, "internetgateway": true, "natgateway": true, "networkacl": true,
+	"prefixlist": true, "routetable": true, "securitygroup": true, "snapshot": true, "subnet": true, "volume": true, "vpc": true,
+}
+
+// Environment variables of the common CI systems (GitHub Actions, GitLab CI, CircleCI, Travis CI,
+// Bitbucket Pipelines, Jenkins), in lookup order
+var (
+	gitCommitEnvVars = []string{"GITHUB_SHA", "CI_COMMIT_SHA", "CIRCLE_SHA1", "TRAVIS_COMMIT", "BITBUCKET_COMMIT", "GIT_COMMIT"}
+	gitBranchEnvVars = []string{"GITHUB_HEAD_REF", "GITHUB_REF_NAME", "CI_COMMIT_REF_NAME", "CIRCLE_BRANCH", "TRAVIS_BRANCH", "BITBUCKET_BRANCH", "GIT_BRANCH"}
+	ciBuildEnvVars   = []string{"GITHUB_RUN_ID", "CI_PIPELINE_ID", "CIRCLE_BUILD_NUM", "TRAVIS_BUILD_NUMBER", "BITBUCKET_BUILD_NUMBER", "BUILD_NUMBER"}
+)
+
+// templateTags returns the tags to add to the resources created by templates: the default
+// tags of the config merged over the git and CI context tags when enabled
+func templateTags() map[string]string {
+	tags := make(map[string]string)
+	if config.GetContextTagsEnabled() {
+		for k, v := range contextTags(os.Getenv, runGit) {
+			tags[k] = v
+		}
+	}
+	for k, v := range config.GetDefaultTags() {
+		tags[k] = v
+	}
+	return tags
+}
+
+// contextTags returns the GitCommit, GitBranch and CIBuild tags of the current run, read from the CI
+// environment variables or else from git. Tags of an unknown context are left out
+func contextTags(getenv func(string) string, git func(...string) string) map[string]string {
+	firstEnv := func(names []string) string {
+		for _, name := range names {
+			if v := strings.TrimSpace(getenv(name)); v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+	tags := make(map[string]string)
+	commit, branch := firstEnv(gitCommitEnvVars), firstEnv(gitBranchEnvVars)
+	if commit == "" {
+		commit = git("rev-parse", "HEAD")
+	}
+	if branch == "" {
+		if branch = git("rev-parse", "--abbrev-ref", "HEAD"); branch == "HEAD" { // detached
+			branch = ""
+		}
+	}
+	if commit != "" {
+		tags["GitCommit"] = commit
+	}
+	if branch != "" {
+		tags["GitBranch"] = branch
+	}
+	if build := firstEnv(ciBuildEnvVars); build != "" {
+		tags["CIBuild"] = build
+	}
+	return tags
+}
+
+func runGit(args ...string) string {
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// tagCreatedResources tags the resources successfully created by the template with the given tags,
+// except the keys explicitly tagged by the template on a resource (ex: Name)
+func tagCreatedResources(tpl *template.Template, d driver.Driver, tags map[string]string) {
+	if len(tags) == 0 {
+		return
+	}
+	explicit := make(map[string]map[string]bool)
+	explicitTag := func(id, key string) {
+		if explicit[id] == nil {
+			explicit[id] = make(map[string]bool)
+		}
+		explicit[id][key] = true
+	}
+	var created []string
+	for _, cmd := range tpl.CommandNodesIterator() {
+		if cmd.Action != "create" || cmd.CmdErr != nil {
+			continue
+		}
+		if cmd.Entity == "tag" {
+			if res, ok := cmd.Params["resource"].(string); ok {
+				explicitTag(res, fmt.Sprint(cmd.Params["key"]))
+			}
+			continue
+		}
+		id, ok := cmd.CmdResult.(string)
+		if !ok || id == "" || !taggableEntities[cmd.Entity] {
+			continue
+		}
+		if _, ok := cmd.Params["name"]; ok {
+			explicitTag(id, "Name")
+		}
+		created = append(created, id)
+	}
+	if len(created) == 0 {
+		return
+	}
+
+	createTag, err := d.Lookup("create", "tag")
+	if err != nil {
+		logger.Warningf("cannot tag created resources: %s", err)
+		return
+	}
+	var keys []string
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, id := range created {
+		for _, k := range keys {
+			if explicit[id][k] {
+				continue
+			}
+			if _, err := createTag(map[string]interface{}{"resource": id, "key": k, "value": tags[k]}); err != nil {
+				logger.Warningf("cannot tag %s with %s: %s", id, k, err)
+			}
+		}
+	}
+}
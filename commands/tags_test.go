@@ -0,0 +1,118 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/template"
+	"github.com/wallix/awless/template/driver"
+)
+
+func TestContextTags(t *testing.T) {
+	tcases := []struct {
+		env  map[string]string
+		git  map[string]string
+		want map[string]string
+	}{
+		{
+			env:  map[string]string{"GITHUB_SHA": "abc123", "GITHUB_REF_NAME": "main", "GITHUB_RUN_ID": "42"},
+			git:  map[string]string{"rev-parse HEAD": "def456"},
+			want: map[string]string{"GitCommit": "abc123", "GitBranch": "main", "CIBuild": "42"},
+		},
+		{
+			env:  map[string]string{"CI_COMMIT_SHA": "abc123", "CI_COMMIT_REF_NAME": "feature", "CI_PIPELINE_ID": "7"},
+			want: map[string]string{"GitCommit": "abc123", "GitBranch": "feature", "CIBuild": "7"},
+		},
+		{
+			git:  map[string]string{"rev-parse HEAD": "def456", "rev-parse --abbrev-ref HEAD": "dev"},
+			want: map[string]string{"GitCommit": "def456", "GitBranch": "dev"},
+		},
+		{
+			git:  map[string]string{"rev-parse HEAD": "def456", "rev-parse --abbrev-ref HEAD": "HEAD"},
+			want: map[string]string{"GitCommit": "def456"},
+		},
+		{
+			want: map[string]string{},
+		},
+	}
+	for i, tcase := range tcases {
+		getenv := func(name string) string { return tcase.env[name] }
+		git := func(args ...string) string {
+			key := args[0]
+			for _, a := range args[1:] {
+				key += " " + a
+			}
+			return tcase.git[key]
+		}
+		if got, want := contextTags(getenv, git), tcase.want; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%d: got %v, want %v", i+1, got, want)
+		}
+	}
+}
+
+type tagsDriver struct {
+	tagged []string
+}
+
+func (d *tagsDriver) Lookup(lookups ...string) (driver.DriverFn, error) {
+	return func(params map[string]interface{}) (interface{}, error) {
+		d.tagged = append(d.tagged, params["resource"].(string)+":"+params["key"].(string)+"="+params["value"].(string))
+		return nil, nil
+	}, nil
+}
+func (d *tagsDriver) SetDryRun(bool)           {}
+func (d *tagsDriver) SetLogger(*logger.Logger) {}
+
+func TestTagCreatedResources(t *testing.T) {
+	tpl, err := template.Parse(`create vpc cidr=10.0.0.0/16
+create subnet cidr=10.0.0.0/24 vpc=vpc-1 name=front
+create tag resource=subnet-1 key=Team value=front
+create user name=bob
+create instance subnet=subnet-1 image=ami-1 type=t2.micro count=1
+create volume availabilityzone=eu-west-1a size=10`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := []interface{}{"vpc-1", "subnet-1", nil, "AIDA1", "i-1", nil}
+	for i, cmd := range tpl.CommandNodesIterator() {
+		cmd.CmdResult = results[i]
+	}
+	tpl.CommandNodesIterator()[5].CmdErr = errors.New("volume limit exceeded")
+
+	d := &tagsDriver{}
+	tagCreatedResources(tpl, d, map[string]string{"Name": "default", "Team": "ops", "GitCommit": "abc123"})
+	sort.Strings(d.tagged)
+	exp := []string{
+		"i-1:GitCommit=abc123", "i-1:Name=default", "i-1:Team=ops",
+		"subnet-1:GitCommit=abc123",
+		"vpc-1:GitCommit=abc123", "vpc-1:Name=default", "vpc-1:Team=ops",
+	}
+	if got, want := d.tagged, exp; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	d = &tagsDriver{}
+	tagCreatedResources(tpl, d, map[string]string{})
+	if len(d.tagged) > 0 {
+		t.Fatalf("unexpected tags %v", d.tagged)
+	}
+}
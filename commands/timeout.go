@@ -0,0 +1,62 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wallix/awless/aws"
+)
+
+// timeoutGracePeriod lets the in progress AWS calls return their cancellation
+// before the command is aborted
+const timeoutGracePeriod = 5 * time.Second
+
+var (
+	timeoutGlobalFlag time.Duration
+
+	commandStart  = time.Now()
+	commandCtx    = context.Background()
+	cancelCommand context.CancelFunc
+)
+
+func initCommandDeadline() {
+	if timeoutGlobalFlag <= 0 || commandCtx.Done() != nil {
+		return
+	}
+	commandCtx, cancelCommand = context.WithDeadline(context.Background(), commandStart.Add(timeoutGlobalFlag))
+	aws.SetRequestsContext(commandCtx)
+
+	go func() {
+		<-commandCtx.Done()
+		time.Sleep(timeoutGracePeriod)
+		exitOn(commandCtx.Err())
+	}()
+}
+
+func commandTimedOut() bool {
+	return commandCtx.Err() == context.DeadlineExceeded
+}
+
+func timeoutError() error {
+	if op := aws.LastOperation(); op != "" {
+		return fmt.Errorf("command timed out after %s (in progress: %s)", timeoutGlobalFlag, op)
+	}
+	return fmt.Errorf("command timed out after %s", timeoutGlobalFlag)
+}
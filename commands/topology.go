@@ -0,0 +1,197 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/cloud"
+	p "github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/logger"
+)
+
+func init() {
+	showCmd.AddCommand(showTopologyCmd)
+}
+
+var showTopologyCmd = &cobra.Command{
+	Use:     "topology VPC",
+	Short:   "Show the topology of a VPC as a tree: subnets by availability zone, their instances, route tables and gateways",
+	Example: "  awless show topology vpc-1234abcd\n  awless show topology @my-vpc",
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 {
+			return errors.New("VPC reference (id or name) required")
+		}
+
+		vpc, gph := findResourceInLocalGraphs(args[0])
+		if vpc == nil {
+			logger.Infof("vpc with reference %s not found", deprefix(args[0]))
+			return nil
+		}
+		if vpc.Type() != cloud.Vpc {
+			return fmt.Errorf("%s is a %s, not a vpc", vpc, vpc.Type())
+		}
+
+		exitOn(printVpcTopology(os.Stdout, gph, vpc))
+		return nil
+	},
+}
+
+// printVpcTopology writes the tree of a VPC: its gateways, then its subnets grouped by
+// availability zone with their route table, NAT gateways and instances
+func printVpcTopology(w io.Writer, g *graph.Graph, vpc *graph.Resource) error {
+	var children []*graph.Resource
+	if err := g.Accept(&graph.ChildrenVisitor{From: vpc, Each: graph.VisitorCollectFunc(&children), MaxDepth: 1}); err != nil {
+		return err
+	}
+	dependingOnVpc, err := g.ListResourcesDependingOn(vpc)
+	if err != nil {
+		return err
+	}
+
+	var subnets, gateways []*graph.Resource
+	var mainRouteTable *graph.Resource
+	for _, r := range append(children, dependingOnVpc...) {
+		switch r.Type() {
+		case cloud.Subnet:
+			subnets = append(subnets, r)
+		case cloud.InternetGateway:
+			gateways = append(gateways, r)
+		case cloud.RouteTable:
+			if main, _ := r.Properties[p.Main].(bool); main {
+				mainRouteTable = r
+			}
+		}
+	}
+	sort.Sort(byTypeAndString{gateways})
+
+	root := &topologyNode{label: describeTopologyResource(vpc, p.CIDR)}
+	if len(gateways) > 0 {
+		gws := &topologyNode{label: "gateways"}
+		for _, gw := range gateways {
+			gws.add(describeTopologyResource(gw))
+		}
+		root.children = append(root.children, gws)
+	}
+
+	subnetsPerZone := make(map[string][]*graph.Resource)
+	var zones []string
+	for _, s := range subnets {
+		zone, _ := s.Properties[p.AvailabilityZone].(string)
+		if zone == "" {
+			zone = "unknown zone"
+		}
+		if _, ok := subnetsPerZone[zone]; !ok {
+			zones = append(zones, zone)
+		}
+		subnetsPerZone[zone] = append(subnetsPerZone[zone], s)
+	}
+	sort.Strings(zones)
+
+	for _, zone := range zones {
+		zoneNode := root.add(zone)
+		zoneSubnets := subnetsPerZone[zone]
+		sort.Sort(byTypeAndString{zoneSubnets})
+		for _, subnet := range zoneSubnets {
+			label := describeTopologyResource(subnet, p.CIDR)
+			if public, _ := subnet.Properties[p.Public].(bool); public {
+				label += " public"
+			}
+			subnetNode := zoneNode.add(label)
+
+			dependingOn, err := g.ListResourcesDependingOn(subnet)
+			if err != nil {
+				return err
+			}
+			var routeTable *graph.Resource
+			var natGateways []*graph.Resource
+			for _, r := range dependingOn {
+				switch r.Type() {
+				case cloud.RouteTable:
+					routeTable = r
+				case cloud.NatGateway:
+					natGateways = append(natGateways, r)
+				}
+			}
+			switch {
+			case routeTable != nil:
+				subnetNode.add("route table: " + describeTopologyResource(routeTable))
+			case mainRouteTable != nil:
+				subnetNode.add("route table: " + describeTopologyResource(mainRouteTable) + " (main)")
+			}
+			sort.Sort(byTypeAndString{natGateways})
+			for _, nat := range natGateways {
+				subnetNode.add(describeTopologyResource(nat, p.State))
+			}
+
+			var instances []*graph.Resource
+			if err := g.Accept(&graph.ChildrenVisitor{From: subnet, Each: graph.VisitorCollectFunc(&instances), MaxDepth: 1}); err != nil {
+				return err
+			}
+			sort.Sort(byTypeAndString{instances})
+			for _, inst := range instances {
+				if inst.Type() == cloud.Instance {
+					subnetNode.add(describeTopologyResource(inst, p.PrivateIP, p.PublicIP, p.State))
+				}
+			}
+		}
+	}
+
+	root.print(w, "", "")
+	return nil
+}
+
+// describeTopologyResource returns the string of a resource followed by the given properties values when set
+func describeTopologyResource(r *graph.Resource, props ...string) string {
+	parts := []string{r.String()}
+	for _, prop := range props {
+		if v, ok := r.Properties[prop]; ok && fmt.Sprint(v) != "" {
+			parts = append(parts, fmt.Sprint(v))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+type topologyNode struct {
+	label    string
+	children []*topologyNode
+}
+
+func (n *topologyNode) add(label string) *topologyNode {
+	child := &topologyNode{label: label}
+	n.children = append(n.children, child)
+	return child
+}
+
+func (n *topologyNode) print(w io.Writer, prefix, childPrefix string) {
+	fmt.Fprintf(w, "%s%s\n", prefix, n.label)
+	for i, child := range n.children {
+		if i == len(n.children)-1 {
+			child.print(w, childPrefix+"└── ", childPrefix+"    ")
+		} else {
+			child.print(w, childPrefix+"├── ", childPrefix+"│   ")
+		}
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"testing"
+
+	p "github.com/wallix/awless/cloud/properties"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/graph/resourcetest"
+)
+
+func TestPrintVpcTopology(t *testing.T) {
+	g := graph.NewGraph()
+	vpc := resourcetest.VPC("vpc_1").Prop(p.Name, "prod").Prop(p.CIDR, "10.0.0.0/16").Build()
+	otherVpc := resourcetest.VPC("vpc_2").Build()
+	pub := resourcetest.Subnet("sub_pub").Prop(p.AvailabilityZone, "eu-west-1a").Prop(p.CIDR, "10.0.1.0/24").Prop(p.Public, true).Build()
+	priv := resourcetest.Subnet("sub_priv").Prop(p.AvailabilityZone, "eu-west-1b").Prop(p.CIDR, "10.0.2.0/24").Build()
+	empty := resourcetest.Subnet("sub_empty").Prop(p.AvailabilityZone, "eu-west-1a").Build()
+	igw := resourcetest.InternetGw("igw_1").Build()
+	nat := resourcetest.NatGw("nat_1").Prop(p.State, "available").Build()
+	mainRt := resourcetest.RouteTable("rt_main").Prop(p.Main, true).Build()
+	pubRt := resourcetest.RouteTable("rt_pub").Prop(p.Main, false).Build()
+	web := resourcetest.Instance("inst_web").Prop(p.Name, "web").Prop(p.PrivateIP, "10.0.1.10").Prop(p.PublicIP, "1.2.3.4").Prop(p.State, "running").Build()
+	db := resourcetest.Instance("inst_db").Prop(p.PrivateIP, "10.0.2.20").Prop(p.State, "stopped").Build()
+	other := resourcetest.Instance("inst_other").Build()
+	otherSub := resourcetest.Subnet("sub_other").Build()
+	g.AddResource(vpc, otherVpc, pub, priv, empty, igw, nat, mainRt, pubRt, web, db, other, otherSub)
+
+	for _, sub := range []*graph.Resource{pub, priv, empty} {
+		g.AddParentRelation(vpc, sub)
+	}
+	g.AddParentRelation(otherVpc, otherSub)
+	g.AddParentRelation(otherSub, other)
+	g.AddParentRelation(vpc, mainRt)
+	g.AddParentRelation(vpc, pubRt)
+	g.AddParentRelation(vpc, nat)
+	g.AddParentRelation(pub, web)
+	g.AddParentRelation(priv, db)
+	g.AddAppliesOnRelation(igw, vpc)
+	g.AddAppliesOnRelation(pubRt, pub)
+	g.AddAppliesOnRelation(nat, pub)
+
+	var w bytes.Buffer
+	if err := printVpcTopology(&w, g, vpc); err != nil {
+		t.Fatal(err)
+	}
+	expected := `@prod[vpc] 10.0.0.0/16
+├── gateways
+│   └── igw_1[internetgateway]
+├── eu-west-1a
+│   ├── sub_empty[subnet]
+│   │   └── route table: rt_main[routetable] (main)
+│   └── sub_pub[subnet] 10.0.1.0/24 public
+│       ├── route table: rt_pub[routetable]
+│       ├── nat_1[natgateway] available
+│       └── @web[instance] 10.0.1.10 1.2.3.4 running
+└── eu-west-1b
+    └── sub_priv[subnet] 10.0.2.0/24
+        ├── route table: rt_main[routetable] (main)
+        └── inst_db[instance] 10.0.2.20 stopped
+`
+	if got, want := w.String(), expected; got != want {
+		t.Fatalf("got\n%s\nwant\n%s", got, want)
+	}
+}
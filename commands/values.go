@@ -0,0 +1,299 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/wallix/awless/aws"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/template"
+)
+
+// Encrypted values files start with this header followed by the encryption mode,
+// then the base64 encoded ciphertext of the plain values file
+const (
+	encryptedValuesHeader = "awless-encrypted:"
+	kmsValuesMode         = "kms"
+	localValuesMode       = "local"
+)
+
+var (
+	valuesKMSKeyFlag string
+	valuesOutputFlag string
+)
+
+// localValuesKeyFile holds the AES-256 key of the values files encrypted without KMS
+var localValuesKeyFile = filepath.Join(config.KeysDir, "values.key")
+
+func init() {
+	RootCmd.AddCommand(valuesCmd)
+	valuesCmd.AddCommand(valuesEncryptCmd)
+	valuesCmd.AddCommand(valuesDecryptCmd)
+	valuesEncryptCmd.Flags().StringVar(&valuesKMSKeyFlag, "kms-key", "", "KMS key (id, ARN or alias/...) to encrypt with. Without it, the local key ~/.awless/keys/values.key is used (created if missing)")
+	valuesEncryptCmd.Flags().StringVarP(&valuesOutputFlag, "output", "o", "", "Write the encrypted file to this path instead of stdout")
+}
+
+var valuesCmd = &cobra.Command{
+	Use:   "values",
+	Short: "Encrypt or decrypt the values files given to `awless run --values`",
+}
+
+var valuesEncryptCmd = &cobra.Command{
+	Use:   "encrypt FILE",
+	Short: "Encrypt a values file with a KMS key or the local values key",
+	Example: `  awless values encrypt secrets.txt --kms-key alias/awless -o secrets.enc
+  awless values encrypt secrets.txt > secrets.enc`,
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("missing FILE arg")
+		}
+		content, err := ioutil.ReadFile(args[0])
+		exitOn(err)
+		if isEncryptedValues(content) {
+			exitOn(fmt.Errorf("%s is already encrypted", args[0]))
+		}
+		_, err = parseValues(content)
+		exitOn(err)
+
+		encrypted, err := encryptValues(content, valuesKMSKeyFlag, valuesKMS(), localValuesKeyFile)
+		exitOn(err)
+
+		if valuesOutputFlag == "" {
+			os.Stdout.Write(encrypted)
+			return nil
+		}
+		exitOn(ioutil.WriteFile(valuesOutputFlag, encrypted, 0600))
+		return nil
+	},
+}
+
+var valuesDecryptCmd = &cobra.Command{
+	Use:               "decrypt FILE",
+	Short:             "Print the plain content of an encrypted values file",
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("missing FILE arg")
+		}
+		content, err := ioutil.ReadFile(args[0])
+		exitOn(err)
+		if !isEncryptedValues(content) {
+			exitOn(fmt.Errorf("%s is not encrypted", args[0]))
+		}
+		plain, err := decryptValues(content, valuesKMS(), localValuesKeyFile)
+		exitOn(err)
+		os.Stdout.Write(plain)
+		return nil
+	},
+}
+
+// kmsCipher encrypts and decrypts with KMS keys (see aws.KeyManagement)
+type kmsCipher interface {
+	Encrypt(keyID string, plaintext []byte) ([]byte, error)
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
+
+func valuesKMS() kmsCipher {
+	if aws.KMS == nil {
+		return nil
+	}
+	return aws.KMS
+}
+
+// loadValuesFile returns the template fillers of a values file, decrypting it first
+// when encrypted. Values are given one per line as in the command line (ex: instance.type=t2.micro)
+func loadValuesFile(path string) (map[string]interface{}, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if isEncryptedValues(content) {
+		if content, err = decryptValues(content, valuesKMS(), localValuesKeyFile); err != nil {
+			return nil, fmt.Errorf("values file %s: %s", path, err)
+		}
+	}
+	values, err := parseValues(content)
+	if err != nil {
+		return nil, fmt.Errorf("values file %s: %s", path, err)
+	}
+	return values, nil
+}
+
+func parseValues(content []byte) (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		params, err := template.ParseParams(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", n, err)
+		}
+		for k, v := range params {
+			values[k] = v
+		}
+	}
+	return values, scanner.Err()
+}
+
+func isEncryptedValues(content []byte) bool {
+	return bytes.HasPrefix(content, []byte(encryptedValuesHeader))
+}
+
+// encryptValues encrypts the plain values with the given KMS key or, when empty, with the local key
+func encryptValues(plain []byte, kmsKey string, kms kmsCipher, localKeyPath string) ([]byte, error) {
+	var mode string
+	var ciphertext []byte
+	var err error
+	if kmsKey != "" {
+		if kms == nil {
+			return nil, errors.New("kms is not available")
+		}
+		mode = kmsValuesMode
+		ciphertext, err = kms.Encrypt(kmsKey, plain)
+	} else {
+		mode = localValuesMode
+		var key []byte
+		if key, err = readLocalValuesKey(localKeyPath, true); err == nil {
+			ciphertext, err = sealLocal(key, plain)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var buff bytes.Buffer
+	fmt.Fprintf(&buff, "%s%s\n", encryptedValuesHeader, mode)
+	encoded := base64.StdEncoding.EncodeToString(ciphertext)
+	for len(encoded) > 76 {
+		fmt.Fprintln(&buff, encoded[:76])
+		encoded = encoded[76:]
+	}
+	fmt.Fprintln(&buff, encoded)
+	return buff.Bytes(), nil
+}
+
+// decryptValues returns the plain values of an encrypted values file
+func decryptValues(content []byte, kms kmsCipher, localKeyPath string) ([]byte, error) {
+	lines := strings.SplitN(string(content), "\n", 2)
+	mode := strings.TrimSpace(strings.TrimPrefix(lines[0], encryptedValuesHeader))
+	var body string
+	if len(lines) > 1 {
+		body = strings.Join(strings.Fields(lines[1]), "")
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(body)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encrypted content: %s", err)
+	}
+	if len(ciphertext) == 0 {
+		return nil, errors.New("empty encrypted content")
+	}
+
+	switch mode {
+	case kmsValuesMode:
+		if kms == nil {
+			return nil, errors.New("encrypted with kms but kms is not available")
+		}
+		return kms.Decrypt(ciphertext)
+	case localValuesMode:
+		key, err := readLocalValuesKey(localKeyPath, false)
+		if err != nil {
+			return nil, err
+		}
+		return openLocal(key, ciphertext)
+	default:
+		return nil, fmt.Errorf("unknown encryption '%s': expecting '%s' or '%s'", mode, kmsValuesMode, localValuesMode)
+	}
+}
+
+func readLocalValuesKey(path string, create bool) ([]byte, error) {
+	key, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) && create {
+		key = make([]byte, 32)
+		if _, err = io.ReadFull(rand.Reader, key); err != nil {
+			return nil, err
+		}
+		if err = ioutil.WriteFile(path, key, 0600); err != nil {
+			return nil, err
+		}
+		return key, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("local values key: %s", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("local values key %s: expecting 32 bytes, got %d", path, len(key))
+	}
+	return key, nil
+}
+
+// sealLocal encrypts with AES-256-GCM, prefixing the ciphertext with its nonce
+func sealLocal(key, plain []byte) ([]byte, error) {
+	gcm, err := newLocalGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plain, nil), nil
+}
+
+func openLocal(key, ciphertext []byte) ([]byte, error) {
+	gcm, err := newLocalGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errors.New("invalid encrypted content: too short")
+	}
+	plain, err := gcm.Open(nil, ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, errors.New("cannot decrypt with the local values key: wrong key or altered content")
+	}
+	return plain, nil
+}
+
+func newLocalGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type fakeKMS struct {
+	keyID string
+}
+
+func (k *fakeKMS) Encrypt(keyID string, plain []byte) ([]byte, error) {
+	k.keyID = keyID
+	return append([]byte("kms:"), plain...), nil
+}
+
+func (k *fakeKMS) Decrypt(ciphertext []byte) ([]byte, error) {
+	if !bytes.HasPrefix(ciphertext, []byte("kms:")) {
+		return nil, errors.New("invalid ciphertext")
+	}
+	return bytes.TrimPrefix(ciphertext, []byte("kms:")), nil
+}
+
+func TestEncryptedValues(t *testing.T) {
+	dir, err := ioutil.TempDir("", "awless-values")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	keyPath := filepath.Join(dir, "values.key")
+	plain := []byte("# db\ndb.password=s3cr3t\n\ninstance.type=t2.micro\nsubnet.cidr=10.0.0.0/24\n")
+
+	t.Run("local key", func(t *testing.T) {
+		encrypted, err := encryptValues(plain, "", nil, keyPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := strings.SplitN(string(encrypted), "\n", 2)[0], "awless-encrypted:local"; got != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+		if !isEncryptedValues(encrypted) || isEncryptedValues(plain) {
+			t.Fatal("unexpected encrypted detection")
+		}
+		if bytes.Contains(encrypted, []byte("s3cr3t")) {
+			t.Fatal("plain secret in encrypted content")
+		}
+		if _, err := os.Stat(keyPath); err != nil {
+			t.Fatalf("local key not created: %s", err)
+		}
+		decrypted, err := decryptValues(encrypted, nil, keyPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := decrypted, plain; !bytes.Equal(got, want) {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+
+		altered := append([]byte{}, encrypted...)
+		altered[len(altered)-4] ^= 1
+		if _, err := decryptValues(altered, nil, keyPath); err == nil {
+			t.Fatal("expected error on altered content")
+		}
+		if _, err := decryptValues(encrypted, nil, filepath.Join(dir, "missing.key")); err == nil {
+			t.Fatal("expected error on missing local key")
+		}
+	})
+
+	t.Run("kms", func(t *testing.T) {
+		kms := &fakeKMS{}
+		encrypted, err := encryptValues(plain, "alias/awless", kms, keyPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := kms.keyID, "alias/awless"; got != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+		if !bytes.HasPrefix(encrypted, []byte("awless-encrypted:kms\n")) {
+			t.Fatalf("unexpected header: %q", encrypted)
+		}
+		decrypted, err := decryptValues(encrypted, kms, keyPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := decrypted, plain; !bytes.Equal(got, want) {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+		if _, err := decryptValues(encrypted, nil, keyPath); err == nil {
+			t.Fatal("expected error without kms")
+		}
+	})
+
+	t.Run("unknown mode", func(t *testing.T) {
+		if _, err := decryptValues([]byte("awless-encrypted:rot13\nYWJj\n"), nil, keyPath); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("load file", func(t *testing.T) {
+		encrypted, err := encryptValues(plain, "", nil, keyPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(dir, "values.enc")
+		if err = ioutil.WriteFile(path, encrypted, 0600); err != nil {
+			t.Fatal(err)
+		}
+		defer func(orig string) { localValuesKeyFile = orig }(localValuesKeyFile)
+		localValuesKeyFile = keyPath
+
+		values, err := loadValuesFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		exp := map[string]interface{}{"db.password": "s3cr3t", "instance.type": "t2.micro", "subnet.cidr": "10.0.0.0/24"}
+		if got, want := values, exp; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %#v, want %#v", got, want)
+		}
+		if got, want := sprintProcessedParams(map[string]interface{}{"db.password": "s3cr3t"}), "db.password=<redacted>"; got != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	})
+}
+
+func TestParseValues(t *testing.T) {
+	if _, err := parseValues([]byte("instance.type=t2.micro\nname=my instance\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Fatalf("expected error on line 2, got %v", err)
+	}
+}
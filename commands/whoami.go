@@ -55,7 +55,11 @@ var whoamiCmd = &cobra.Command{
 			return
 		}
 
-		me, err := aws.AccessService.(*aws.Access).GetIdentity()
+		access, ok := aws.AccessService.(*aws.Access)
+		if !ok {
+			exitOn(aws.ErrMockUnsupported)
+		}
+		me, err := access.GetIdentity()
 		exitOn(err)
 
 		if me.IsRoot() {
@@ -89,7 +93,7 @@ var whoamiCmd = &cobra.Command{
 
 		fmt.Printf("Username: %s, Id: %s, Account: %s\n", me.Resource, me.UserId, me.Account)
 
-		policies, err := aws.AccessService.(*aws.Access).GetUserPolicies(me.Resource)
+		policies, err := access.GetUserPolicies(me.Resource)
 		if err != nil {
 			logger.Error(err)
 			return
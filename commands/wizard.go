@@ -0,0 +1,228 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/chzyer/readline"
+	"github.com/spf13/cobra"
+	awsdoc "github.com/wallix/awless/aws/doc"
+	awsdriver "github.com/wallix/awless/aws/driver"
+	"github.com/wallix/awless/config"
+	"github.com/wallix/awless/template"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+var (
+	wizardFileFlag string
+	wizardRunFlag  bool
+)
+
+func init() {
+	RootCmd.AddCommand(wizardCmd)
+
+	wizardCmd.Flags().StringVarP(&wizardFileFlag, "file", "f", "", "Template file to write (default: ACTION-ENTITY.aws)")
+	wizardCmd.Flags().BoolVar(&wizardRunFlag, "run", false, "Run the template once written")
+}
+
+var wizardCmd = &cobra.Command{
+	Use:   "wizard ACTION ENTITY",
+	Short: "Build a template file step by step, prompting for each parameter with its documentation and default value",
+	Example: `  awless wizard create instance
+  awless wizard create bucket --file bucket.aws
+  awless wizard create securitygroup --run`,
+	PersistentPreRun:  applyHooks(initLoggerHook, initAwlessEnvHook, initCloudServicesHook, initSyncerHook),
+	PersistentPostRun: applyHooks(verifyNewVersionHook, onVersionUpgrade),
+
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 2 {
+			return errors.New("ACTION and ENTITY required (ex: create instance)")
+		}
+		def, ok := awsdriver.AWSLookupDefinitions(args[0] + args[1])
+		if !ok {
+			return fmt.Errorf("unsupported '%s %s': see `awless %s -h` for supported entities", args[0], args[1], args[0])
+		}
+		if !terminal.IsTerminal(int(os.Stdin.Fd())) {
+			return errors.New("wizard needs an interactive terminal")
+		}
+
+		path := wizardFileFlag
+		if path == "" {
+			path = fmt.Sprintf("%s-%s.aws", def.Action, def.Entity)
+		}
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("%s already exists: give another file with --file", path)
+		}
+
+		fmt.Printf("Building template '%s %s' (Ctrl+D to abort, Tab for completion)\n", def.Action, def.Entity)
+		tpl, err := wizardTemplate(def, config.Defaults, readlineAsk, os.Stdout)
+		exitOn(err)
+
+		content := tpl.String() + "\n"
+		exitOn(writeNewFile(path, []byte(content)))
+		fmt.Printf("\n%s\nTemplate written to %s\n", renderGreenFn(tpl), path)
+
+		if !wizardRunFlag {
+			fmt.Printf("Run it with `awless run %s`\n", path)
+			return nil
+		}
+		tplExec := &template.TemplateExecution{
+			Template: tpl,
+			Locale:   config.GetAWSRegion(),
+			Source:   tpl.String(),
+			Path:     templatePath(path),
+			Digest:   templateDigest([]byte(content)),
+		}
+		exitOn(runTemplate(tplExec, config.Defaults))
+		return nil
+	},
+}
+
+// wizardAsk returns the line answered to the prompt about a hole (ex: instance.subnet)
+type wizardAsk func(prompt, hole string) (string, error)
+
+// wizardTemplate prompts for the required params of the definition, proposing the defaults,
+// then optionally for its extra params, and returns the resulting template
+func wizardTemplate(def template.Definition, defaults map[string]interface{}, ask wizardAsk, out io.Writer) (*template.Template, error) {
+	var params []string
+	for _, param := range sortedParams(def.Required()) {
+		hole := def.Entity + "." + param
+		printParamDoc(out, def, param)
+		prompt := fmt.Sprintf("%s? ", param)
+		defaultValue, hasDefault := defaults[hole]
+		if hasDefault {
+			prompt = fmt.Sprintf("%s [%v]? ", param, defaultValue)
+		}
+		for {
+			line, err := ask(prompt, hole)
+			if err != nil {
+				return nil, err
+			}
+			if line == "" && hasDefault {
+				line = fmt.Sprint(defaultValue)
+			}
+			if err = validateWizardValue(hole, param, line); err != nil {
+				fmt.Fprintf(out, "invalid value: %s\n", err)
+				continue
+			}
+			params = append(params, fmt.Sprintf("%s=%s", param, line))
+			break
+		}
+	}
+
+	if len(def.Extra()) > 0 {
+		fmt.Fprintf(out, "Optional parameters: %s\n", strings.Join(def.Extra(), ", "))
+		answer, err := ask("Set optional parameters? (y/N) ", "")
+		if err != nil {
+			return nil, err
+		}
+		if strings.ToLower(answer) == "y" {
+			for _, param := range sortedParams(def.Extra()) {
+				hole := def.Entity + "." + param
+				printParamDoc(out, def, param)
+				for {
+					line, err := ask(fmt.Sprintf("%s (Enter to skip)? ", param), hole)
+					if err != nil {
+						return nil, err
+					}
+					if line == "" {
+						break
+					}
+					if err = validateWizardValue(hole, param, line); err != nil {
+						fmt.Fprintf(out, "invalid value: %s\n", err)
+						continue
+					}
+					params = append(params, fmt.Sprintf("%s=%s", param, line))
+					break
+				}
+			}
+		}
+	}
+
+	return template.Parse(fmt.Sprintf("%s %s %s", def.Action, def.Entity, strings.Join(params, " ")))
+}
+
+func sortedParams(params []string) []string {
+	sorted := append([]string{}, params...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+func printParamDoc(out io.Writer, def template.Definition, param string) {
+	if doc, ok := awsdoc.TemplateParamsDoc(def.Name(), param); ok {
+		fmt.Fprintf(out, "%s: %s\n", param, doc)
+	}
+}
+
+// validateWizardValue checks the syntax of a param value and, unless a reference,
+// an alias or a hole, its value with the parser of the matching defaults (ex: instance.type)
+func validateWizardValue(hole, param, value string) error {
+	isRef := strings.HasPrefix(value, "$") || strings.HasPrefix(value, "@") || strings.HasPrefix(value, "{")
+	switch {
+	case value == "":
+		return errors.New("value required")
+	case !isRef && !isQuoted(value) && !template.MatchStringParamValue(value):
+		return errors.New("string contains spaces or special characters: surround it with quotes")
+	}
+	if _, err := template.ParseParams(fmt.Sprintf("%s=%s", param, value)); err != nil {
+		return err
+	}
+	if isRef {
+		return nil
+	}
+	return config.ValidateDefault(hole, value)
+}
+
+func readlineAsk(prompt, hole string) (string, error) {
+	conf := &readline.Config{Prompt: prompt, InterruptPrompt: "^C", EOFPrompt: "exit"}
+	if hole != "" {
+		conf.AutoComplete = holeAutoCompletion(allGraphsOnce.mustLoad(), hole)
+	}
+	l, err := readline.NewEx(conf)
+	if err != nil {
+		return "", err
+	}
+	defer l.Close()
+
+	line, err := l.Readline()
+	switch err {
+	case readline.ErrInterrupt, io.EOF:
+		return "", errors.New("wizard aborted")
+	case nil:
+		return strings.TrimSpace(line), nil
+	default:
+		return "", err
+	}
+}
+
+func writeNewFile(path string, content []byte) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(content); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
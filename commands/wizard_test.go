@@ -0,0 +1,73 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package commands
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	awsdriver "github.com/wallix/awless/aws/driver"
+)
+
+func TestWizardTemplate(t *testing.T) {
+	def, ok := awsdriver.AWSLookupDefinitions("createinstance")
+	if !ok {
+		t.Fatal("missing create instance definition")
+	}
+	answers := []string{
+		"",           // count: default
+		"ami-123456", // image
+		"my instance", "'my instance'",
+		"@my-subnet",
+		"nodot", "", // type: invalid then default
+		"y", // optional params
+		"", "", "my-keypair", "", "", "$sg", "",
+	}
+	var prompts []string
+	ask := func(prompt, hole string) (string, error) {
+		prompts = append(prompts, prompt)
+		if len(answers) == 0 {
+			t.Fatalf("no answer for %q after %v", prompt, prompts)
+		}
+		answer := answers[0]
+		answers = answers[1:]
+		return answer, nil
+	}
+	var out bytes.Buffer
+	tpl, err := wizardTemplate(def, map[string]interface{}{"instance.count": 1, "instance.type": "t2.micro"}, ask, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(answers) != 0 {
+		t.Fatalf("unused answers: %v", answers)
+	}
+
+	expected := "create instance count=1 image=ami-123456 keypair=my-keypair name='my instance' securitygroup=$sg subnet=@my-subnet type=t2.micro"
+	if got, want := tpl.String(), expected; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := prompts[0], "count [1]? "; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if got, want := strings.Count(out.String(), "invalid value"), 2; got != want {
+		t.Fatalf("got %d, want %d in\n%s", got, want, out.String())
+	}
+	if !strings.Contains(out.String(), "subnet: The ID of the subnet to launch the instance into") {
+		t.Fatalf("expected params doc in\n%s", out.String())
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/wallix/awless/aws"
 	"github.com/wallix/awless/aws/config"
@@ -31,6 +32,15 @@ const (
 	schedulerURL                   = "scheduler.url"
 	RegionConfigKey                = "aws.region"
 	ProfileConfigKey               = "aws.profile"
+	OutputFormatConfigKey          = "output.format"
+	subnetFreeIPsThresholdKey      = "subnet.freeips.threshold"
+	defaultTagsConfigKey           = "tags.default"
+	contextTagsConfigKey           = "tags.context"
+	snapshotsRetentionConfigKey    = "snapshots.retention"
+	applyConcurrencyConfigKey      = "aws.apply.concurrency"
+	CostTagsConfigKey              = "cost.tags"
+	credentialsRefreshWindowKey    = "aws.credentials.refresh.window"
+	SyncBudgetConfigKey            = "sync.budget"
 
 	//Config prefix
 	awsCloudPrefix = "aws."
@@ -41,7 +51,7 @@ const (
 
 var configDefinitions = map[string]*Definition{
 	autosyncConfigKey:              {help: "Automatically synchronize your cloud locally", defaultValue: "true", parseParamFn: parseBool},
-	RegionConfigKey:                {help: "AWS region", parseParamFn: awsconfig.ParseRegion, stdinParamProviderFn: awsconfig.StdinRegionSelector, onUpdateFns: []onUpdateFunc{awsconfig.WarningChangeRegion, runSyncWithUpdatedRegion}},
+	RegionConfigKey:                {help: "AWS region (all: every region enabled for the account, for sync only)", parseParamFn: awsconfig.ParseRegion, stdinParamProviderFn: awsconfig.StdinRegionSelector, onUpdateFns: []onUpdateFunc{awsconfig.WarningChangeRegion, runSyncWithUpdatedRegion}},
 	ProfileConfigKey:               {help: "AWS profile", defaultValue: "default"},
 	"aws.infra.sync":               {help: "Sync AWS EC2/ELBv2 service (when empty: true)", defaultValue: "true", parseParamFn: parseBool},
 	"aws.access.sync":              {help: "Sync AWS IAM service (when empty: true)", defaultValue: "true", parseParamFn: parseBool},
@@ -54,8 +64,32 @@ var configDefinitions = map[string]*Definition{
 	"aws.dns.sync":                 {help: "Sync AWS Route53 service (when empty: true)", defaultValue: "true", parseParamFn: parseBool},
 	"aws.cdn.sync":                 {help: "Sync AWS CloudFront service (when empty: true)", defaultValue: "true", parseParamFn: parseBool},
 	"aws.cloudformation.sync":      {help: "Sync AWS CloudFormation service (when empty: true)", defaultValue: "true", parseParamFn: parseBool},
+	"aws.mock":                     {help: "Serve canned resources and simulate actions instead of calling AWS (when empty: false)", defaultValue: "false", parseParamFn: parseBool},
+	"aws.mock.fixtures":            {help: "Graph file or directory of .triples files served in mock mode (when empty: built-in resources)"},
+	"aws.notify.topic":             {help: "ARN of a SNS topic notified when a sync or a template run completes or fails"},
+	"aws.notify.webhook":           {help: "URL to which a JSON notification is posted when a sync or a template run completes or fails"},
+	"aws.notify.command":           {help: "Shell command run with a JSON notification on stdin when a sync or a template run completes or fails"},
+	"aws.timeout.read":             {help: "Timeout of the AWS API calls reading resources (ex: 30s; when empty: no timeout)", parseParamFn: parseOptionalDuration},
+	"aws.timeout.write":            {help: "Timeout of the AWS API calls creating, updating or deleting resources (ex: 2m; when empty: no timeout)", parseParamFn: parseOptionalDuration},
+	"aws.timeout.upload":           {help: "Timeout of the AWS API calls uploading S3 objects or Lambda code (ex: 30m; when empty: no timeout)", parseParamFn: parseOptionalDuration},
+	"aws.credentials.cache":        {help: "Cache on disk the temporary credentials of credential_process and assumed roles between runs (when empty: true)", defaultValue: "true", parseParamFn: parseBool},
+	credentialsRefreshWindowKey:    {help: "How long before they expire the temporary credentials of credential_process and assumed roles are renewed (ex: 2m); must be less than their lifetime (when empty: 5m)", defaultValue: "5m", parseParamFn: parseOptionalDuration},
+	"aws.s3.endpoint":              {help: "Endpoint of a S3 compatible storage (ex: http://localhost:9000 for MinIO; when empty: AWS S3)"},
+	"aws.s3.signing.region":        {help: "Region for which S3 requests are signed, for S3 compatible storages (when empty: aws.region)"},
+	"aws.s3.pathstyle":             {help: "Address S3 buckets in the URL path rather than in the host, as most S3 compatible storages need (when empty: false)", defaultValue: "false", parseParamFn: parseBool},
+	"aws.fetch.nonfatal.codes":     {help: "Comma separated AWS error codes fetched as empty results rather than sync failures, or none (when empty: codes of services not enabled for the account)"},
+	"aws.accounts.rolemap":         {help: "JSON file mapping account ids to the role (and external id) assumed in them by multi-account operations (ex: sync --accounts)"},
+	applyConcurrencyConfigKey:      {help: "Maximum number of AWS mutations (template actions) run at once; 0 does not bound them", defaultValue: "10", parseParamFn: parseInt},
+	CostTagsConfigKey:              {help: "Comma separated tag keys expected in cost reports and checked by `awless show cost-tags` (when empty: all the tag keys of the resources)"},
+	"aws.notify.on":                {help: "When to notify: always or failure (when empty: always)", defaultValue: "always", parseParamFn: parseNotifyOn},
 	checkUpgradeFrequencyConfigKey: {help: "Upgrade check frequency (hours); a negative value disables check", defaultValue: "8", parseParamFn: parseInt},
 	schedulerURL:                   {help: "URL used by awless CLI to interact with pre-installed awless-scheduler", defaultValue: "http://localhost:8082"},
+	OutputFormatConfigKey:          {help: "Default output format of list, show, history and cost commands (table, wide, csv, tsv or json); overridden by --format", defaultValue: "table", parseParamFn: parseOutputFormat},
+	subnetFreeIPsThresholdKey:      {help: "Number of free IP addresses under which subnets are flagged when listed", defaultValue: "16", parseParamFn: parseInt},
+	defaultTagsConfigKey:           {help: "Comma separated Key=Value tags added to the EC2 resources created by templates (ex: Team=web,Env=dev)", parseParamFn: parseTags},
+	SyncBudgetConfigKey:            {help: "Comma separated type=min:max counts of resources expected after a sync, either bound being optional (ex: instance=10:500,bucket=:100); `awless sync` warns and exits non-zero when crossed", parseParamFn: parseSyncBudget},
+	snapshotsRetentionConfigKey:    {help: "Number of days of sync snapshots kept by `awless graph compact`; 0 keeps them all", defaultValue: "90", parseParamFn: parseInt},
+	contextTagsConfigKey:           {help: "Tag the EC2 resources created by templates with the git commit, branch and CI build running awless (GitCommit, GitBranch, CIBuild) (when empty: false)", defaultValue: "false", parseParamFn: parseBool},
 }
 
 var defaultsDefinitions = map[string]*Definition{
@@ -97,6 +131,7 @@ func LoadConfig() error {
 		}
 		return
 	})
+	resetVolatileSources()
 
 	return err
 }
@@ -144,6 +179,7 @@ func Set(key, value string) error {
 	}); err != nil {
 		return err
 	}
+	forgetVolatileSource(key)
 
 	if def != nil {
 		for _, fn := range def.onUpdateFns {
@@ -184,8 +220,16 @@ func Get(key string) (interface{}, bool) {
 }
 
 func SetVolatile(key, value string) error {
-	_, _, _, err := setVolatile(key, value)
-	return err
+	return SetVolatileFrom(RuntimeSource, key, value)
+}
+
+// ValidateDefault checks a value with the parser of a defaults key, if any (ex: instance.type)
+func ValidateDefault(key, value string) error {
+	if def, ok := defaultsDefinitions[key]; ok && def.parseParamFn != nil {
+		_, err := def.parseParamFn(value)
+		return err
+	}
+	return nil
 }
 
 func InteractiveSet(key string) error {
@@ -208,6 +252,119 @@ func parseBool(i string) (interface{}, error) {
 	return b, nil
 }
 
+func parseOutputFormat(s string) (interface{}, error) {
+	for _, f := range OutputFormats {
+		if s == f {
+			return s, nil
+		}
+	}
+	return s, fmt.Errorf("invalid value, expected one of %s, got '%s'", strings.Join(OutputFormats, ", "), s)
+}
+
+func parseOptionalDuration(s string) (interface{}, error) {
+	if s == "" {
+		return s, nil
+	}
+	if _, err := time.ParseDuration(s); err != nil {
+		return s, fmt.Errorf("invalid value, expected a duration (ex: 30s, 5m), got '%s'", s)
+	}
+	return s, nil
+}
+
+func parseNotifyOn(s string) (interface{}, error) {
+	switch s {
+	case "always", "failure":
+		return s, nil
+	}
+	return s, fmt.Errorf("invalid value, expected always or failure, got '%s'", s)
+}
+
+func parseSyncBudget(s string) (interface{}, error) {
+	if _, err := splitSyncBudget(s); err != nil {
+		return s, err
+	}
+	return s, nil
+}
+
+// ResourceBudget bounds the number of resources of a type expected after a sync.
+// A negative bound is not checked
+type ResourceBudget struct {
+	Min, Max int
+}
+
+// Crossed returns whether the given number of resources is out of the budget
+func (b ResourceBudget) Crossed(count int) bool {
+	return (b.Min >= 0 && count < b.Min) || (b.Max >= 0 && count > b.Max)
+}
+
+func (b ResourceBudget) String() string {
+	switch {
+	case b.Min < 0:
+		return fmt.Sprintf("at most %d", b.Max)
+	case b.Max < 0:
+		return fmt.Sprintf("at least %d", b.Min)
+	default:
+		return fmt.Sprintf("between %d and %d", b.Min, b.Max)
+	}
+}
+
+func splitSyncBudget(s string) (map[string]ResourceBudget, error) {
+	budgets := make(map[string]ResourceBudget)
+	invalid := fmt.Errorf("invalid value, expected comma separated type=min:max budgets with at least one bound (ex: instance=10:500,bucket=:100), got '%s'", s)
+	for _, kv := range strings.Split(s, ",") {
+		if strings.TrimSpace(kv) == "" {
+			continue
+		}
+		splits := strings.SplitN(kv, "=", 2)
+		if len(splits) != 2 || strings.TrimSpace(splits[0]) == "" {
+			return budgets, invalid
+		}
+		bounds := strings.Split(splits[1], ":")
+		if len(bounds) != 2 {
+			return budgets, invalid
+		}
+		budget := ResourceBudget{Min: -1, Max: -1}
+		for i, bound := range []*int{&budget.Min, &budget.Max} {
+			b := strings.TrimSpace(bounds[i])
+			if b == "" {
+				continue
+			}
+			n, err := strconv.Atoi(b)
+			if err != nil || n < 0 {
+				return budgets, invalid
+			}
+			*bound = n
+		}
+		if budget.Min < 0 && budget.Max < 0 || budget.Max >= 0 && budget.Min > budget.Max {
+			return budgets, invalid
+		}
+		budgets[strings.TrimSpace(splits[0])] = budget
+	}
+	return budgets, nil
+}
+
+func parseTags(s string) (interface{}, error) {
+	if _, err := splitTags(s); err != nil {
+		return s, err
+	}
+	return s, nil
+}
+
+func splitTags(s string) (map[string]string, error) {
+	tags := make(map[string]string)
+	for _, kv := range strings.Split(s, ",") {
+		if strings.TrimSpace(kv) == "" {
+			continue
+		}
+		splits := strings.SplitN(kv, "=", 2)
+		if len(splits) != 2 || strings.TrimSpace(splits[0]) == "" {
+			return tags, fmt.Errorf("invalid value, expected comma separated Key=Value tags, got '%s'", s)
+		}
+		tags[strings.TrimSpace(splits[0])] = strings.TrimSpace(splits[1])
+	}
+	return tags, nil
+}
+
 func parseInt(a string) (interface{}, error) {
 	i, err := strconv.Atoi(a)
 	if err != nil {
@@ -0,0 +1,99 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Sources of the effective config values, from the lowest to the highest precedence
+const (
+	GlobalConfigSource = "global config"
+	ProjectFileSource  = "project file"
+	EnvSource          = "env"
+	FlagSource         = "flag"
+	RuntimeSource      = "runtime"
+)
+
+var (
+	volatileSources = make(map[string]string)
+	savedValues     = make(map[string]interface{})
+)
+
+// EffectiveValue is a config value as used by the current run, with the source it came from
+// and, when overridden for the run, the value saved in the global config
+type EffectiveValue struct {
+	Key        string
+	Value      interface{}
+	Source     string
+	Saved      interface{}
+	Overridden bool
+}
+
+// SetVolatileFrom overrides, without saving it, a config value and records the source of the override
+// (ex: "flag --aws-region", "env AWS_DEFAULT_REGION", "project file ./awless.yaml")
+func SetVolatileFrom(source, key, value string) error {
+	saved, wasSet := Get(key)
+	if _, _, _, err := setVolatile(key, value); err != nil {
+		return err
+	}
+	if _, ok := volatileSources[key]; !ok {
+		if wasSet {
+			savedValues[key] = saved
+		} else {
+			savedValues[key] = nil
+		}
+	}
+	volatileSources[key] = source
+	return nil
+}
+
+// EffectiveConfig returns, sorted by key, the config and template defaults values used by the current run
+func EffectiveConfig() []*EffectiveValue {
+	values := make(map[string]*EffectiveValue)
+	for _, m := range []map[string]interface{}{Defaults, Config} {
+		for k, v := range m {
+			values[k] = &EffectiveValue{Key: k, Value: v, Source: GlobalConfigSource, Saved: v}
+		}
+	}
+	for k, source := range volatileSources {
+		ev, ok := values[k]
+		if !ok {
+			continue
+		}
+		ev.Source = source
+		ev.Saved = savedValues[k]
+		ev.Overridden = fmt.Sprint(ev.Saved) != fmt.Sprint(ev.Value)
+	}
+	var effective []*EffectiveValue
+	for _, v := range values {
+		effective = append(effective, v)
+	}
+	sort.Slice(effective, func(i, j int) bool { return effective[i].Key < effective[j].Key })
+	return effective
+}
+
+func forgetVolatileSource(key string) {
+	delete(volatileSources, key)
+	delete(savedValues, key)
+}
+
+func resetVolatileSources() {
+	volatileSources = make(map[string]string)
+	savedValues = make(map[string]interface{})
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEffectiveConfig(t *testing.T) {
+	defer func(c, d map[string]interface{}) { Config, Defaults = c, d; resetVolatileSources() }(Config, Defaults)
+	resetVolatileSources()
+	Config = map[string]interface{}{"aws.region": "eu-west-1", "aws.profile": "default"}
+	Defaults = map[string]interface{}{"instance.type": "t2.micro"}
+
+	if err := SetVolatileFrom(ProjectFileSource+" awless.yaml", "aws.region", "us-east-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetVolatileFrom(FlagSource+" --aws-region", "aws.region", "us-west-2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetVolatileFrom(EnvSource+" AWS_DEFAULT_PROFILE", "aws.profile", "default"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*EffectiveValue{
+		{Key: "aws.profile", Value: "default", Source: "env AWS_DEFAULT_PROFILE", Saved: "default"},
+		{Key: "aws.region", Value: "us-west-2", Source: "flag --aws-region", Saved: "eu-west-1", Overridden: true},
+		{Key: "instance.type", Value: "t2.micro", Source: GlobalConfigSource, Saved: "t2.micro"},
+	}
+	if got, want := EffectiveConfig(), expected; !reflect.DeepEqual(got, want) {
+		for i := range got {
+			t.Logf("%#v", got[i])
+		}
+		t.Fatalf("got %d values, want %d", len(got), len(want))
+	}
+}
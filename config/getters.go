@@ -26,6 +26,86 @@ func GetAWSProfile() string {
 	return ""
 }
 
+// OutputFormats are the supported values of the output format config
+var OutputFormats = []string{"table", "wide", "csv", "tsv", "json"}
+
+// GetOutputFormat returns the output format set in config (default to table)
+func GetOutputFormat() (string, error) {
+	f, ok := Config[OutputFormatConfigKey]
+	if !ok || f == "" {
+		return "table", nil
+	}
+	format := fmt.Sprint(f)
+	if _, err := parseOutputFormat(format); err != nil {
+		return "table", fmt.Errorf("config %s: %s. Fix it with `awless config set %s table`", OutputFormatConfigKey, err, OutputFormatConfigKey)
+	}
+	return format, nil
+}
+
+// GetSubnetFreeIPsThreshold returns the number of free IP addresses under which subnets are flagged (default to 16)
+func GetSubnetFreeIPsThreshold() int {
+	if threshold, ok := Config[subnetFreeIPsThresholdKey].(int); ok {
+		return threshold
+	}
+	return 16
+}
+
+// GetDefaultTags returns the tags added to the resources created by templates
+func GetDefaultTags() map[string]string {
+	s, _ := Config[defaultTagsConfigKey].(string)
+	tags, err := splitTags(s)
+	if err != nil {
+		return map[string]string{}
+	}
+	return tags
+}
+
+// GetContextTagsEnabled returns whether the resources created by templates are tagged
+// with their git and CI context (default to false)
+func GetContextTagsEnabled() bool {
+	enabled, _ := Config[contextTagsConfigKey].(bool)
+	return enabled
+}
+
+// GetSnapshotsRetention returns how long sync snapshots are kept when compacting the
+// local store (default to 90 days, 0 keeps them all)
+func GetSnapshotsRetention() time.Duration {
+	if days, ok := Config[snapshotsRetentionConfigKey].(int); ok {
+		return time.Duration(days) * 24 * time.Hour
+	}
+	return 90 * 24 * time.Hour
+}
+
+// GetApplyConcurrency returns the maximum number of template actions run at once
+// (default to 10, 0 does not bound them)
+func GetApplyConcurrency() int {
+	if limit, ok := Config[applyConcurrencyConfigKey].(int); ok {
+		return limit
+	}
+	return 10
+}
+
+// GetCostTags returns the tag keys expected in cost reports (default to none, i.e. all tag keys)
+func GetCostTags() (keys []string) {
+	s, _ := Config[CostTagsConfigKey].(string)
+	for _, k := range strings.Split(s, ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			keys = append(keys, k)
+		}
+	}
+	return
+}
+
+// GetSyncBudget returns the expected number of resources per type checked after a sync (default to none)
+func GetSyncBudget() map[string]ResourceBudget {
+	s, _ := Config[SyncBudgetConfigKey].(string)
+	budgets, err := splitSyncBudget(s)
+	if err != nil {
+		return map[string]ResourceBudget{}
+	}
+	return budgets
+}
+
 func GetAutosync() bool {
 	if autoSync, ok := Config[autosyncConfigKey].(bool); ok {
 		return autoSync
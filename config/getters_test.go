@@ -46,3 +46,100 @@ func TestGetSyncEnabled(t *testing.T) {
 		}
 	})
 }
+
+func TestGetOutputFormat(t *testing.T) {
+	defer func() { Config = map[string]interface{}{} }()
+
+	Config = map[string]interface{}{}
+	if got, err := GetOutputFormat(); err != nil || got != "table" {
+		t.Fatalf("got %s (err %v), want table", got, err)
+	}
+
+	Config[OutputFormatConfigKey] = "json"
+	if got, err := GetOutputFormat(); err != nil || got != "json" {
+		t.Fatalf("got %s (err %v), want json", got, err)
+	}
+
+	Config[OutputFormatConfigKey] = "yaml"
+	if _, err := GetOutputFormat(); err == nil {
+		t.Fatal("expected error for unsupported format")
+	}
+	if _, err := parseOutputFormat("yaml"); err == nil {
+		t.Fatal("expected error for unsupported format")
+	}
+}
+
+func TestGetCostTags(t *testing.T) {
+	defer func() { Config = map[string]interface{}{} }()
+
+	Config = map[string]interface{}{}
+	if got := GetCostTags(); len(got) != 0 {
+		t.Fatalf("got %v, want none", got)
+	}
+
+	Config[CostTagsConfigKey] = "CostCenter, Team,,Project "
+	if got, want := GetCostTags(), []string{"CostCenter", "Team", "Project"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestGetSyncBudget(t *testing.T) {
+	defer func() { Config = map[string]interface{}{} }()
+
+	Config = map[string]interface{}{}
+	if got, want := GetSyncBudget(), map[string]ResourceBudget{}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	Config[SyncBudgetConfigKey] = "instance=10:500, bucket=:100,volume=2:,"
+	expected := map[string]ResourceBudget{"instance": {Min: 10, Max: 500}, "bucket": {Min: -1, Max: 100}, "volume": {Min: 2, Max: -1}}
+	if got, want := GetSyncBudget(), expected; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	tcases := []struct {
+		budget  ResourceBudget
+		count   int
+		crossed bool
+	}{
+		{expected["instance"], 9, true},
+		{expected["instance"], 10, false},
+		{expected["instance"], 500, false},
+		{expected["instance"], 501, true},
+		{expected["bucket"], 0, false},
+		{expected["bucket"], 101, true},
+		{expected["volume"], 1, true},
+		{expected["volume"], 1000, false},
+	}
+	for i, tcase := range tcases {
+		if got, want := tcase.budget.Crossed(tcase.count), tcase.crossed; got != want {
+			t.Fatalf("%d: got %t, want %t", i+1, got, want)
+		}
+	}
+
+	for _, invalid := range []string{"instance", "instance=10", "instance=:", "instance=a:10", "instance=-1:10", "instance=10:5", "=1:2", "instance=1:2:3"} {
+		if _, err := parseSyncBudget(invalid); err == nil {
+			t.Fatalf("expected error for '%s'", invalid)
+		}
+	}
+}
+
+func TestGetDefaultTags(t *testing.T) {
+	defer func() { Config = map[string]interface{}{} }()
+
+	Config = map[string]interface{}{}
+	if got, want := GetDefaultTags(), map[string]string{}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	Config[defaultTagsConfigKey] = "Team=web, Env=dev,Empty="
+	if got, want := GetDefaultTags(), map[string]string{"Team": "web", "Env": "dev", "Empty": ""}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	for _, invalid := range []string{"Team", "=web", "Team=web,Env"} {
+		if _, err := parseTags(invalid); err == nil {
+			t.Fatalf("expected error for '%s'", invalid)
+		}
+	}
+}
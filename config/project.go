@@ -0,0 +1,133 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ProjectFilenames are the names of the project config files, looked up
+// in the working directory then in its parents
+var ProjectFilenames = []string{"awless.yaml", ".awless"}
+
+// FindProjectFile returns the path of the project config file of the closest
+// directory from dir, or an empty path if none
+func FindProjectFile(dir string) string {
+	for {
+		for _, name := range ProjectFilenames {
+			path := filepath.Join(dir, name)
+			// ~/.awless is the awless home directory, not a project file
+			if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+				return path
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// LoadProjectConfig overrides, without saving them, the config values set in the project
+// config file of dir (or of a parent). It returns the path of the file loaded, if any
+func LoadProjectConfig(dir string) (string, error) {
+	path := FindProjectFile(dir)
+	if path == "" {
+		return "", nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return path, fmt.Errorf("project config: %s", err)
+	}
+	defer f.Close()
+
+	values, err := parseProjectConfig(f)
+	if err != nil {
+		return path, fmt.Errorf("project config %s: %s", path, err)
+	}
+	var keys []string
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if !isProjectConfigKey(k) {
+			return path, fmt.Errorf("project config %s: '%s' can not be set per project (only %s, %s, %s and sync settings)", path, k, RegionConfigKey, ProfileConfigKey, OutputFormatConfigKey)
+		}
+		if err := SetVolatileFrom(ProjectFileSource+" "+path, k, values[k]); err != nil {
+			return path, fmt.Errorf("project config %s: %s: %s", path, k, err)
+		}
+	}
+	return path, nil
+}
+
+func isProjectConfigKey(key string) bool {
+	switch key {
+	case RegionConfigKey, ProfileConfigKey, OutputFormatConfigKey, autosyncConfigKey:
+		return true
+	}
+	_, ok := configDefinitions[key]
+	return ok && strings.HasSuffix(key, ".sync")
+}
+
+// parseProjectConfig reads the 'key: value' lines of a project config file (a YAML subset).
+// Keys can be nested by indentation: 'aws:' followed by an indented 'region: eu-west-1' sets aws.region
+func parseProjectConfig(r io.Reader) (map[string]string, error) {
+	type parent struct {
+		indent int
+		key    string
+	}
+	var parents []parent
+	values := make(map[string]string)
+
+	scanner := bufio.NewScanner(r)
+	for num := 1; scanner.Scan(); num++ {
+		line := scanner.Text()
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+		if trimmed := strings.TrimSpace(line); trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		splits := strings.SplitN(strings.TrimSpace(line), ":", 2)
+		if len(splits) != 2 || strings.TrimSpace(splits[0]) == "" {
+			return nil, fmt.Errorf("line %d: expected 'key: value', got '%s'", num, strings.TrimSpace(line))
+		}
+		for len(parents) > 0 && parents[len(parents)-1].indent >= indent {
+			parents = parents[:len(parents)-1]
+		}
+		key := strings.TrimSpace(splits[0])
+		if len(parents) > 0 {
+			key = parents[len(parents)-1].key + "." + key
+		}
+		value := strings.Trim(strings.TrimSpace(splits[1]), `"'`)
+		if value == "" {
+			parents = append(parents, parent{indent: indent, key: key})
+			continue
+		}
+		values[key] = value
+	}
+	return values, scanner.Err()
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestProjectConfig(t *testing.T) {
+	root, err := ioutil.TempDir("", "awless-project")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+	subdir := filepath.Join(root, "deploy", "prod")
+	if err := os.MkdirAll(subdir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	// a directory named .awless (ex: awless home) is not a project file
+	if err := os.Mkdir(filepath.Join(root, "deploy", ".awless"), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	content := `# per project AWS context
+aws:
+  region: eu-west-3   # Paris
+  profile: "staging"
+  storage:
+    s3object.sync: true
+output.format: json
+autosync: false
+`
+	if err := ioutil.WriteFile(filepath.Join(root, "awless.yaml"), []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func(defs map[string]*Definition, conf map[string]interface{}) { configDefinitions, Config = defs, conf }(configDefinitions, Config)
+	configDefinitions = map[string]*Definition{
+		RegionConfigKey:             {},
+		ProfileConfigKey:            {},
+		OutputFormatConfigKey:       {parseParamFn: parseOutputFormat},
+		autosyncConfigKey:           {parseParamFn: parseBool},
+		"aws.storage.s3object.sync": {parseParamFn: parseBool},
+	}
+	Config = map[string]interface{}{RegionConfigKey: "us-east-1", "aws.mock": false}
+
+	path, err := LoadProjectConfig(subdir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := path, filepath.Join(root, "awless.yaml"); got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	expected := map[string]interface{}{
+		RegionConfigKey:             "eu-west-3",
+		ProfileConfigKey:            "staging",
+		OutputFormatConfigKey:       "json",
+		autosyncConfigKey:           false,
+		"aws.storage.s3object.sync": true,
+		"aws.mock":                  false,
+	}
+	if got, want := Config, expected; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(subdir, ".awless"), []byte("aws.mock: true\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadProjectConfig(subdir); err == nil {
+		t.Fatal("expected error with key not settable per project")
+	}
+	if err := ioutil.WriteFile(filepath.Join(subdir, ".awless"), []byte("aws.region eu-west-1\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadProjectConfig(subdir); err == nil {
+		t.Fatal("expected error with invalid line")
+	}
+}
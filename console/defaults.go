@@ -22,6 +22,9 @@ import (
 	"github.com/wallix/awless/cloud/properties"
 )
 
+// SubnetFreeIPsThreshold is the number of free IP addresses under which subnets are flagged
+var SubnetFreeIPsThreshold = 16
+
 var DefaultsColumnDefinitions = map[string][]ColumnDefinition{
 	//EC2
 	cloud.Instance: {
@@ -52,6 +55,10 @@ var DefaultsColumnDefinitions = map[string][]ColumnDefinition{
 		StringColumnDefinition{Prop: properties.ID},
 		StringColumnDefinition{Prop: properties.Name},
 		StringColumnDefinition{Prop: properties.CIDR},
+		LowValueColumnDefinition{
+			StringColumnDefinition: StringColumnDefinition{Prop: properties.FreeIPs, Friendly: "Free IPs"},
+			Threshold:              &SubnetFreeIPsThreshold,
+		},
 		StringColumnDefinition{Prop: properties.AvailabilityZone, Friendly: "Zone"},
 		ColoredValueColumnDefinition{
 			StringColumnDefinition: StringColumnDefinition{Prop: properties.Default, Friendly: "Default"},
@@ -78,6 +85,60 @@ var DefaultsColumnDefinitions = map[string][]ColumnDefinition{
 		StringColumnDefinition{Prop: properties.Name},
 		StringColumnDefinition{Prop: properties.Vpcs},
 	},
+	cloud.DhcpOptions: {
+		StringColumnDefinition{Prop: properties.ID},
+		StringColumnDefinition{Prop: properties.Name},
+		StringColumnDefinition{Prop: properties.DomainName},
+		StringColumnDefinition{Prop: properties.NameServers},
+		StringColumnDefinition{Prop: properties.NTPServers},
+	},
+	cloud.NetworkAcl: {
+		StringColumnDefinition{Prop: properties.ID},
+		StringColumnDefinition{Prop: properties.Name},
+		StringColumnDefinition{Prop: properties.Vpc},
+		StringColumnDefinition{Prop: properties.Default},
+		StringColumnDefinition{Prop: properties.Subnets},
+		FirewallRulesColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: properties.InboundRules, Friendly: "Inbound"}},
+		FirewallRulesColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: properties.OutboundRules, Friendly: "Outbound"}},
+	},
+	cloud.PlacementGroup: {
+		StringColumnDefinition{Prop: properties.Name},
+		StringColumnDefinition{Prop: properties.Strategy},
+		StringColumnDefinition{Prop: properties.State},
+	},
+	cloud.PrefixList: {
+		StringColumnDefinition{Prop: properties.ID},
+		StringColumnDefinition{Prop: properties.Name},
+		StringColumnDefinition{Prop: properties.Owner},
+		StringColumnDefinition{Prop: properties.IPType, Friendly: "Family"},
+		StringColumnDefinition{Prop: properties.State},
+		StringColumnDefinition{Prop: properties.Entries},
+		StringColumnDefinition{Prop: properties.MaxEntries, Friendly: "Max"},
+	},
+	cloud.DedicatedHost: {
+		StringColumnDefinition{Prop: properties.ID},
+		StringColumnDefinition{Prop: properties.AvailabilityZone, Friendly: "Zone"},
+		StringColumnDefinition{Prop: properties.Type},
+		StringColumnDefinition{Prop: properties.State},
+		StringColumnDefinition{Prop: properties.Utilization, Friendly: "Utilization (%)"},
+		StringColumnDefinition{Prop: properties.AvailableCapacity, Friendly: "Available"},
+		StringColumnDefinition{Prop: properties.TotalCapacity, Friendly: "Capacity"},
+		StringColumnDefinition{Prop: properties.Instances},
+	},
+	cloud.Fleet: {
+		StringColumnDefinition{Prop: properties.ID},
+		StringColumnDefinition{Prop: properties.Kind},
+		StringColumnDefinition{Prop: properties.Type},
+		StringColumnDefinition{Prop: properties.State},
+		StringColumnDefinition{Prop: properties.ActivityStatus, Friendly: "Activity"},
+		StringColumnDefinition{Prop: properties.Strategy},
+		StringColumnDefinition{Prop: properties.TargetCapacity, Friendly: "Target"},
+		StringColumnDefinition{Prop: properties.OnDemandCapacity, Friendly: "OnDemand"},
+		StringColumnDefinition{Prop: properties.SpotCapacity, Friendly: "Spot"},
+		StringColumnDefinition{Prop: properties.FulfilledCapacity, Friendly: "Fulfilled"},
+		StringColumnDefinition{Prop: properties.Instances},
+		TimeColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: properties.Created}},
+	},
 	cloud.NatGateway: {
 		StringColumnDefinition{Prop: properties.ID},
 		StringColumnDefinition{Prop: properties.State},
@@ -149,6 +210,15 @@ var DefaultsColumnDefinitions = map[string][]ColumnDefinition{
 		TimeColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: properties.Created}},
 		StorageColumnDefinition{Unit: gb, StringColumnDefinition: StringColumnDefinition{Prop: properties.Size}},
 	},
+	cloud.Finding: {
+		StringColumnDefinition{Prop: properties.ID},
+		StringColumnDefinition{Prop: properties.Severity},
+		StringColumnDefinition{Prop: properties.SeverityScore, Friendly: "Score"},
+		StringColumnDefinition{Prop: properties.Name, Friendly: "Title"},
+		StringColumnDefinition{Prop: properties.AffectedResource, Friendly: "Resource"},
+		StringColumnDefinition{Prop: properties.Occurrences},
+		TimeColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: properties.Modified}},
+	},
 	// Loadbalancer
 	cloud.LoadBalancer: {
 		StringColumnDefinition{Prop: properties.Name},
@@ -232,8 +302,19 @@ var DefaultsColumnDefinitions = map[string][]ColumnDefinition{
 		StringColumnDefinition{Prop: properties.URI},
 		TimeColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: properties.Created}},
 		StringColumnDefinition{Prop: properties.Account},
+		StringColumnDefinition{Prop: properties.ScanOnPush},
 		StringColumnDefinition{Prop: properties.Arn},
 	},
+	cloud.ContainerImage: {
+		StringColumnDefinition{Prop: properties.Repository},
+		SliceColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: properties.ImageTags, Friendly: "Tags"}},
+		StringColumnDefinition{Prop: properties.Hash, Friendly: "Digest"},
+		TimeColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: properties.Created, Friendly: "Pushed"}},
+		StorageColumnDefinition{Unit: b, StringColumnDefinition: StringColumnDefinition{Prop: properties.Size}},
+		StringColumnDefinition{Prop: properties.ScanStatus},
+		StringColumnDefinition{Prop: properties.Vulnerabilities},
+		SliceColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: properties.ScanFindings, Friendly: "Findings"}},
+	},
 	cloud.ContainerCluster: {
 		StringColumnDefinition{Prop: properties.Name},
 		StringColumnDefinition{Prop: properties.State},
@@ -340,12 +421,22 @@ var DefaultsColumnDefinitions = map[string][]ColumnDefinition{
 		StringColumnDefinition{Prop: properties.RecordCount, Friendly: "Nb Records"},
 		StringColumnDefinition{Prop: properties.CallerReference},
 	},
+	cloud.HealthCheck: {
+		StringColumnDefinition{Prop: properties.ID},
+		StringColumnDefinition{Prop: properties.Type},
+		StringColumnDefinition{Prop: properties.Endpoint},
+		StringColumnDefinition{Prop: properties.Health},
+		StringColumnDefinition{Prop: properties.CheckInterval, Friendly: "Interval (s)"},
+		StringColumnDefinition{Prop: properties.UnhealthyThresholdCount, Friendly: "Failures"},
+	},
 	cloud.Record: {
 		StringColumnDefinition{Prop: properties.ID, Friendly: "AwlessId"},
 		StringColumnDefinition{Prop: properties.Type},
 		StringColumnDefinition{Prop: properties.Name},
 		SliceColumnDefinition{StringColumnDefinition{Prop: properties.Records}},
 		StringColumnDefinition{Prop: properties.TTL},
+		StringColumnDefinition{Prop: properties.Set, Friendly: "Identifier"},
+		StringColumnDefinition{Prop: properties.Weight},
 	},
 	// Lamba
 	cloud.Function: {
@@ -357,6 +448,24 @@ var DefaultsColumnDefinitions = map[string][]ColumnDefinition{
 		TimeColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: properties.Modified}},
 		StringColumnDefinition{Prop: properties.Description},
 	},
+	cloud.Stream: {
+		StringColumnDefinition{Prop: properties.Name},
+		StringColumnDefinition{Prop: properties.State},
+		StringColumnDefinition{Prop: properties.Mode},
+		StringColumnDefinition{Prop: properties.Shards},
+		StringColumnDefinition{Prop: properties.RetentionPeriod, Friendly: "Retention (h)"},
+		SliceColumnDefinition{StringColumnDefinition{Prop: properties.Consumers}},
+		TimeColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: properties.Created}},
+	},
+	cloud.DeliveryStream: {
+		StringColumnDefinition{Prop: properties.Name},
+		StringColumnDefinition{Prop: properties.State},
+		StringColumnDefinition{Prop: properties.DestinationType, Friendly: "Destination Type"},
+		StringColumnDefinition{Prop: properties.Destination},
+		StorageColumnDefinition{Unit: mb, StringColumnDefinition: StringColumnDefinition{Prop: properties.BufferSize, Friendly: "Buffer"}},
+		StringColumnDefinition{Prop: properties.BufferInterval, Friendly: "Interval (s)"},
+		TimeColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: properties.Created}},
+	},
 	//Monitoring
 	cloud.Metric: {
 		StringColumnDefinition{Prop: properties.ID},
@@ -393,3 +502,181 @@ var DefaultsColumnDefinitions = map[string][]ColumnDefinition{
 		TimeColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: properties.Modified}},
 	},
 }
+
+// DefaultsWideColumnDefinitions are the less common columns appended per resource type
+// to the default ones with `awless list --format wide` (or `-o wide`)
+var DefaultsWideColumnDefinitions = map[string][]ColumnDefinition{
+	//EC2
+	cloud.Instance: {
+		StringColumnDefinition{Prop: properties.Vpc},
+		StringColumnDefinition{Prop: properties.Subnet},
+		StringColumnDefinition{Prop: properties.Image},
+		StringColumnDefinition{Prop: properties.SecurityGroups, Friendly: "SecGroups"},
+		StringColumnDefinition{Prop: properties.Architecture, Friendly: "Arch"},
+		StringColumnDefinition{Prop: properties.Lifecycle},
+		StringColumnDefinition{Prop: properties.Profile},
+		StringColumnDefinition{Prop: properties.PublicDNS, Friendly: "Public DNS"},
+	},
+	cloud.SecurityGroup: {
+		StringColumnDefinition{Prop: properties.Owner},
+	},
+	cloud.Image: {
+		StringColumnDefinition{Prop: properties.Snapshots},
+		StringColumnDefinition{Prop: properties.Description},
+	},
+	cloud.Snapshot: {
+		StringColumnDefinition{Prop: properties.Public},
+		StringColumnDefinition{Prop: properties.Description},
+	},
+	// Loadbalancer
+	cloud.LoadBalancer: {
+		StringColumnDefinition{Prop: properties.Type},
+		StringColumnDefinition{Prop: properties.IPType, Friendly: "IP Type"},
+		StringColumnDefinition{Prop: properties.AvailabilityZones, Friendly: "Zones"},
+		StringColumnDefinition{Prop: properties.Subnets},
+	},
+	cloud.TargetGroup: {
+		StringColumnDefinition{Prop: properties.CheckTimeout, Friendly: "Check Timeout"},
+		StringColumnDefinition{Prop: properties.HealthyThresholdCount, Friendly: "Healthy Threshold"},
+		StringColumnDefinition{Prop: properties.UnhealthyThresholdCount, Friendly: "Unhealthy Threshold"},
+	},
+	// Database
+	cloud.Database: {
+		StringColumnDefinition{Prop: properties.MultiAZ, Friendly: "Multi AZ"},
+		StringColumnDefinition{Prop: properties.StorageType},
+		StringColumnDefinition{Prop: properties.Encrypted},
+		StringColumnDefinition{Prop: properties.DBSubnetGroup, Friendly: "SubnetGroup"},
+		StringColumnDefinition{Prop: properties.BackupRetentionPeriod, Friendly: "Backup Retention"},
+		StringColumnDefinition{Prop: properties.PublicDNS, Friendly: "Endpoint"},
+	},
+	//Autoscaling
+	cloud.ScalingGroup: {
+		StringColumnDefinition{Prop: properties.MinSize, Friendly: "Min"},
+		StringColumnDefinition{Prop: properties.MaxSize, Friendly: "Max"},
+		StringColumnDefinition{Prop: properties.HealthCheckType, Friendly: "HealthCheck"},
+		StringColumnDefinition{Prop: properties.DefaultCooldown, Friendly: "Cooldown"},
+	},
+	// IAM
+	cloud.User: {
+		StringColumnDefinition{Prop: properties.Path},
+		StringColumnDefinition{Prop: properties.Groups},
+		StringColumnDefinition{Prop: properties.ManagedPolicies, Friendly: "Managed"},
+		StringColumnDefinition{Prop: properties.InlinePolicies, Friendly: "Inline"},
+	},
+	cloud.Role: {
+		StringColumnDefinition{Prop: properties.Path},
+		StringColumnDefinition{Prop: properties.ManagedPolicies, Friendly: "Managed"},
+		StringColumnDefinition{Prop: properties.InlinePolicies, Friendly: "Inline"},
+	},
+	cloud.Group: {
+		StringColumnDefinition{Prop: properties.ManagedPolicies, Friendly: "Managed"},
+		StringColumnDefinition{Prop: properties.InlinePolicies, Friendly: "Inline"},
+	},
+	// S3
+	cloud.Bucket: {
+		StringColumnDefinition{Prop: properties.ReplicationRole, Friendly: "Replication Role"},
+	},
+	// Dns
+	cloud.HealthCheck: {
+		StringColumnDefinition{Prop: properties.CallerReference},
+		StringColumnDefinition{Prop: properties.Version},
+	},
+	cloud.Record: {
+		StringColumnDefinition{Prop: properties.Failover},
+		StringColumnDefinition{Prop: properties.Region},
+		StringColumnDefinition{Prop: properties.HealthCheck},
+	},
+	// Lambda
+	cloud.Function: {
+		StringColumnDefinition{Prop: properties.Handler},
+		StringColumnDefinition{Prop: properties.Timeout},
+		StringColumnDefinition{Prop: properties.Role},
+	},
+	cloud.Stream: {
+		StringColumnDefinition{Prop: properties.Encryption},
+		StringColumnDefinition{Prop: properties.Arn},
+	},
+	cloud.DeliveryStream: {
+		StringColumnDefinition{Prop: properties.Type},
+		StringColumnDefinition{Prop: properties.Source},
+	},
+}
+
+// DefaultsRelationDefinitions are the relation columns displayed per resource type
+// with `awless list --with-relations`
+var DefaultsRelationDefinitions = map[string][]RelationColumnDefinition{
+	//EC2
+	cloud.Instance: {
+		{Type: cloud.Subnet},
+		{Type: cloud.SecurityGroup, Friendly: "SecGroups"},
+		{Type: cloud.Volume, Friendly: "Volumes"},
+		{Type: cloud.ElasticIP, Friendly: "EIPs"},
+		{Type: cloud.TargetGroup, Friendly: "TargetGroups"},
+	},
+	cloud.Vpc: {
+		{Type: cloud.Subnet, Friendly: "Subnets"},
+		{Type: cloud.InternetGateway, Friendly: "Gateways"},
+		{Type: cloud.DhcpOptions, Friendly: "DHCP"},
+	},
+	cloud.Subnet: {
+		{Type: cloud.Vpc},
+		{Type: cloud.RouteTable, Friendly: "RouteTables"},
+		{Type: cloud.NetworkAcl, Friendly: "ACLs"},
+		{Type: cloud.Instance, Friendly: "Instances"},
+	},
+	cloud.SecurityGroup: {
+		{Type: cloud.Vpc},
+		{Type: cloud.Instance, Friendly: "Instances"},
+		{Type: cloud.LoadBalancer, Friendly: "LoadBalancers"},
+		{Type: cloud.Database, Friendly: "Databases"},
+	},
+	cloud.RouteTable: {
+		{Type: cloud.Vpc},
+		{Type: cloud.Subnet, Friendly: "Subnets"},
+	},
+	cloud.NetworkAcl: {
+		{Type: cloud.Vpc},
+		{Type: cloud.Subnet, Friendly: "Subnets"},
+	},
+	cloud.NatGateway: {
+		{Type: cloud.Vpc},
+		{Type: cloud.Subnet},
+	},
+	cloud.Volume: {
+		{Type: cloud.Instance},
+		{Type: cloud.Snapshot, Friendly: "Snapshots"},
+	},
+	// Loadbalancer
+	cloud.LoadBalancer: {
+		{Type: cloud.Vpc},
+		{Type: cloud.SecurityGroup, Friendly: "SecGroups"},
+		{Type: cloud.Listener, Friendly: "Listeners"},
+		{Type: cloud.TargetGroup, Friendly: "TargetGroups"},
+	},
+	cloud.TargetGroup: {
+		{Type: cloud.LoadBalancer, Friendly: "LoadBalancers"},
+		{Type: cloud.Instance, Friendly: "Instances"},
+	},
+	// Database
+	cloud.Database: {
+		{Type: cloud.SecurityGroup, Friendly: "SecGroups"},
+	},
+	//Autoscaling
+	cloud.ScalingGroup: {
+		{Type: cloud.Subnet, Friendly: "Subnets"},
+		{Type: cloud.Instance, Friendly: "Instances"},
+		{Type: cloud.TargetGroup, Friendly: "TargetGroups"},
+	},
+	//IAM
+	cloud.User: {
+		{Type: cloud.Group, Friendly: "Groups"},
+		{Type: cloud.Policy, Friendly: "Policies"},
+	},
+	cloud.Role: {
+		{Type: cloud.Policy, Friendly: "Policies"},
+	},
+	cloud.Group: {
+		{Type: cloud.User, Friendly: "Users"},
+		{Type: cloud.Policy, Friendly: "Policies"},
+	},
+}
@@ -60,6 +60,8 @@ type Builder struct {
 	dataSource      interface{}
 	root            *graph.Resource
 	noHeaders       bool
+	relations       *graph.Graph
+	page, pageSize  int
 }
 
 func (b *Builder) SetSource(i interface{}) *Builder {
@@ -67,39 +69,80 @@ func (b *Builder) SetSource(i interface{}) *Builder {
 	return b
 }
 
-func (b *Builder) buildGraphFilters() (funcs []graph.FilterFn, err error) {
-	for _, f := range b.filters {
-		splits := strings.SplitN(f, "=", 2)
-		if len(splits) == 2 {
-			name, val := strings.TrimSpace(strings.Title(splits[0])), strings.TrimSpace(splits[1])
-			key := ColumnDefinitions(b.headers).resolveKey(name)
+// filterValues are the values given to a filter key
+type filterValues struct {
+	key    string
+	values []string
+}
 
-			if key != "" {
-				funcs = append(funcs, graph.BuildPropertyFilterFunc(key, val))
-			} else {
-				var allowed []string
-				for _, h := range b.headers {
-					allowed = append(allowed, h.propKey())
-				}
-				err = fmt.Errorf("Invalid filter key '%s'. Expecting any of: %s. (Note: filter keys/values are case insensitive)", name, strings.Join(allowed, ", "))
+// groupFilters groups the values given to a same key. Flags split their values on commas,
+// so in `Env=dev,staging` the value `staging` comes as an entry without key, appended to the preceding one
+func groupFilters(fs []string) (groups []*filterValues) {
+	byKey := make(map[string]*filterValues)
+	var last *filterValues
+	for _, f := range fs {
+		splits := strings.SplitN(f, "=", 2)
+		if len(splits) != 2 {
+			if last != nil && strings.TrimSpace(f) != "" {
+				last.values = append(last.values, strings.TrimSpace(f))
 			}
+			continue
+		}
+		key, val := strings.TrimSpace(splits[0]), strings.TrimSpace(splits[1])
+		if last = byKey[key]; last == nil {
+			last = &filterValues{key: key}
+			byKey[key] = last
+			groups = append(groups, last)
+		}
+		last.values = append(last.values, val)
+	}
+	return
+}
+
+const tagFilterPrefix = "tag."
+
+func (b *Builder) buildGraphFilters() (funcs []graph.FilterFn, err error) {
+	for _, f := range groupFilters(b.filters) {
+		if strings.HasPrefix(strings.ToLower(f.key), tagFilterPrefix) && len(f.key) > len(tagFilterPrefix) {
+			funcs = append(funcs, buildTagValuesFilter(f.key[len(tagFilterPrefix):], f.values))
+			continue
 		}
+		name := strings.Title(f.key)
+		key := ColumnDefinitions(b.headers).resolveKey(name)
 
+		if key != "" {
+			var any []graph.FilterFn
+			for _, val := range f.values {
+				any = append(any, graph.BuildPropertyFilterFunc(key, val))
+			}
+			funcs = append(funcs, graph.Or(any...))
+		} else {
+			var allowed []string
+			for _, h := range b.headers {
+				allowed = append(allowed, h.propKey())
+			}
+			err = fmt.Errorf("Invalid filter key '%s'. Expecting any of: %s, or tag.<key> (Note: filter keys/values are case insensitive, except tags)", name, strings.Join(allowed, ", "))
+		}
 	}
 	return
 }
 
 func (b *Builder) buildGraphTagFilters() (funcs []graph.FilterFn) {
-	for _, f := range b.tagFilters {
-		splits := strings.SplitN(f, "=", 2)
-		if len(splits) == 2 {
-			key, val := strings.TrimSpace(splits[0]), strings.TrimSpace(splits[1])
-			funcs = append(funcs, graph.BuildTagFilterFunc(key, val))
-		}
+	for _, f := range groupFilters(b.tagFilters) {
+		funcs = append(funcs, buildTagValuesFilter(f.key, f.values))
 	}
 	return
 }
 
+// buildTagValuesFilter matches the resources tagged with the key and any of the values
+func buildTagValuesFilter(key string, values []string) graph.FilterFn {
+	var any []graph.FilterFn
+	for _, val := range values {
+		any = append(any, graph.BuildTagFilterFunc(key, val))
+	}
+	return graph.Or(any...)
+}
+
 func (b *Builder) buildGraphTagKeyFilters() (funcs []graph.FilterFn) {
 	for _, k := range b.tagKeyFilters {
 		funcs = append(funcs, graph.BuildTagKeyFilterFunc(k))
@@ -115,14 +158,14 @@ func (b *Builder) buildGraphTagValueFilters() (funcs []graph.FilterFn) {
 }
 
 func (b *Builder) Build() (Displayer, error) {
-	base := fromGraphDisplayer{sorter: &defaultSorter{sortBy: b.sort}, rdfType: b.rdfType, headers: b.headers, maxwidth: b.maxwidth, noHeaders: b.noHeaders}
+	base := fromGraphDisplayer{sorter: &defaultSorter{sortBy: b.sort}, rdfType: b.rdfType, headers: b.headers, maxwidth: b.maxwidth, noHeaders: b.noHeaders, page: b.page, pageSize: b.pageSize}
 
 	switch b.dataSource.(type) {
 	case *graph.Graph:
 		if b.rdfType == "" {
 			gph := b.dataSource.(*graph.Graph)
 			switch b.format {
-			case "table":
+			case "table", "wide":
 				dis := &multiResourcesTableDisplayer{base}
 				dis.setGraph(gph)
 				return dis, nil
@@ -134,6 +177,10 @@ func (b *Builder) Build() (Displayer, error) {
 				dis := &porcelainDisplayer{base}
 				dis.setGraph(gph)
 				return dis, nil
+			case "ids":
+				dis := &idsDisplayer{base}
+				dis.setGraph(gph)
+				return dis, nil
 			default:
 				fmt.Fprintf(os.Stderr, "unknown format '%s', display as 'table'\n", b.format)
 				dis := &multiResourcesTableDisplayer{base}
@@ -143,6 +190,13 @@ func (b *Builder) Build() (Displayer, error) {
 		}
 
 		filteredGraph := b.dataSource.(*graph.Graph)
+		base.headers = resolvePrefixLists(b.headers, filteredGraph)
+		if b.format == "wide" {
+			base.headers = withWideColumns(base.headers, b.rdfType)
+		}
+		if b.relations != nil {
+			base.headers = withRelations(base.headers, b.rdfType, DefaultsRelationDefinitions[b.rdfType], b.relations)
+		}
 
 		if filters, err := b.buildGraphFilters(); len(filters) > 0 && err == nil {
 			filteredGraph, err = filteredGraph.Filter(b.rdfType, filters...)
@@ -190,7 +244,11 @@ func (b *Builder) Build() (Displayer, error) {
 			dis := &porcelainDisplayer{base}
 			dis.setGraph(filteredGraph)
 			return dis, nil
-		case "table":
+		case "ids":
+			dis := &idsDisplayer{base}
+			dis.setGraph(filteredGraph)
+			return dis, nil
+		case "table", "wide":
 			dis := &tableDisplayer{base}
 			dis.setGraph(filteredGraph)
 			return dis, nil
@@ -201,6 +259,13 @@ func (b *Builder) Build() (Displayer, error) {
 			return dis, nil
 		}
 	case *graph.Resource:
+		switch b.format {
+		case "json":
+			return &jsonResourceDisplayer{r: b.dataSource.(*graph.Resource)}, nil
+		case "table":
+		default:
+			fmt.Fprintf(os.Stderr, "unknown format '%s', display as 'table'\n", b.format)
+		}
 		dis := &tableResourceDisplayer{headers: b.headers, maxwidth: b.maxwidth}
 		dis.SetResource(b.dataSource.(*graph.Resource))
 		return dis, nil
@@ -301,6 +366,17 @@ func WithIDsOnly(only bool) optsFn {
 	}
 }
 
+// WithIDsFormat prints only the ids of the resources, one per line (see idsDisplayer).
+// It overrides the format and the ids only option
+func WithIDsFormat(ids bool) optsFn {
+	return func(b *Builder) *Builder {
+		if ids {
+			b.format = "ids"
+		}
+		return b
+	}
+}
+
 func WithSortBy(sortingBy ...string) optsFn {
 	return func(b *Builder) *Builder {
 		indexes, err := resolveSortIndexes(b.headers, sortingBy...)
@@ -342,15 +418,33 @@ func WithNoHeaders(nh bool) optsFn {
 	}
 }
 
+// WithRelations adds to the columns of the resource type its relation columns, drawn from the given graph
+func WithRelations(g *graph.Graph) optsFn {
+	return func(b *Builder) *Builder {
+		b.relations = g
+		return b
+	}
+}
+
+// WithPage displays only the given page (from 1) of the sorted rows of tables.
+// Machine formats (csv, tsv, json) always display all the rows
+func WithPage(page, size int) optsFn {
+	return func(b *Builder) *Builder {
+		b.page, b.pageSize = page, size
+		return b
+	}
+}
+
 type table [][]interface{}
 
 type fromGraphDisplayer struct {
 	sorter
-	g         *graph.Graph
-	rdfType   string
-	headers   []ColumnDefinition
-	maxwidth  int
-	noHeaders bool
+	g              *graph.Graph
+	rdfType        string
+	headers        []ColumnDefinition
+	maxwidth       int
+	noHeaders      bool
+	page, pageSize int
 }
 
 func (d *fromGraphDisplayer) setGraph(g *graph.Graph) {
@@ -511,6 +605,15 @@ func (d *tableDisplayer) Print(w io.Writer) error {
 		markColumnAsc = d.sorter.columns()[0]
 	}
 
+	var pageFooter string
+	if d.page > 0 && d.pageSize > 0 {
+		values, pageFooter = pageOf(values, d.page, d.pageSize)
+		if len(values) == 0 {
+			fmt.Fprint(w, pageFooter)
+			return nil
+		}
+	}
+
 	columnsToDisplay := d.headers
 	maxWidthNoWraping := 1
 	if d.maxwidth != 0 {
@@ -572,9 +675,28 @@ func (d *tableDisplayer) Print(w io.Writer) error {
 			fmt.Fprint(w, color.New(color.FgRed).SprintfFunc()("Columns truncated to fit terminal: %s\n", strings.Join(hiddenColumns, ", ")))
 		}
 	}
+	fmt.Fprint(w, pageFooter)
 	return nil
 }
 
+// pageOf returns the rows of the page (from 1) and a footer locating the page among all rows
+func pageOf(values table, page, size int) (table, string) {
+	total := len(values)
+	pages := (total + size - 1) / size
+	if page > pages {
+		return nil, fmt.Sprintf("Page %d out of range: %d rows in %d pages of %d\n", page, total, pages, size)
+	}
+	from, to := (page-1)*size, page*size
+	if to > total {
+		to = total
+	}
+	footer := fmt.Sprintf("Page %d/%d (rows %d-%d of %d)", page, pages, from+1, to, total)
+	if page < pages {
+		footer += fmt.Sprintf(", next: --page %d", page+1)
+	}
+	return values[from:to], footer + "\n"
+}
+
 type porcelainDisplayer struct {
 	fromGraphDisplayer
 }
@@ -627,6 +749,57 @@ func (d *porcelainDisplayer) Print(w io.Writer) error {
 	return err
 }
 
+// idsDisplayer prints only the ids of the resources, one per line, to be piped into other commands.
+// Resources are sorted and paged as in tables, without any header or footer
+type idsDisplayer struct {
+	fromGraphDisplayer
+}
+
+func (d *idsDisplayer) Print(w io.Writer) error {
+	if d.rdfType == "" {
+		var ids []string
+		for t := range DefaultsColumnDefinitions {
+			resources, err := d.g.GetAllResources(t)
+			if err != nil {
+				return err
+			}
+			for _, res := range resources {
+				ids = append(ids, res.Id())
+			}
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			fmt.Fprintln(w, id)
+		}
+		return nil
+	}
+
+	resources, err := d.g.GetAllResources(d.rdfType)
+	if err != nil {
+		return err
+	}
+
+	idCol := len(d.headers)
+	values := make(table, len(resources))
+	for i, res := range resources {
+		values[i] = make([]interface{}, idCol+1)
+		for j, h := range d.headers {
+			values[i][j] = res.Properties[h.propKey()]
+		}
+		values[i][idCol] = res.Id()
+	}
+
+	d.sorter.sort(values)
+	if d.page > 0 && d.pageSize > 0 {
+		values, _ = pageOf(values, d.page, d.pageSize)
+	}
+
+	for _, row := range values {
+		fmt.Fprintln(w, row[idCol])
+	}
+	return nil
+}
+
 type multiResourcesTableDisplayer struct {
 	fromGraphDisplayer
 }
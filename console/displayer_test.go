@@ -18,6 +18,10 @@ package console
 
 import (
 	"bytes"
+	"net"
+	"reflect"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -635,6 +639,65 @@ func TestFilter(t *testing.T) {
 		}
 		compareJSON(t, w.String(), expected)
 	})
+
+	t.Run("Filter several values of a key", func(t *testing.T) {
+		var w bytes.Buffer
+		displayer, _ := BuildOptions(
+			WithRdfType("subnet"),
+			WithFormat("json"),
+			WithFilters([]string{"vpc=vpc_2", "vpc_3", "public=false"}),
+		).SetSource(g).Build()
+		expected := `[{"ID":"sub_2","Public":false,"Vpc":"vpc_2"}]`
+		if err := displayer.Print(&w); err != nil {
+			t.Fatal(err)
+		}
+		compareJSON(t, w.String(), expected)
+	})
+}
+
+func TestTagFilter(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddResource(
+		resourcetest.Instance("inst_1").Prop(p.Tags, []string{"Env=dev", "Dept=web"}).Build(),
+		resourcetest.Instance("inst_2").Prop(p.Tags, []string{"Env=staging", "Dept=web"}).Build(),
+		resourcetest.Instance("inst_3").Prop(p.Tags, []string{"Env=production", "Dept=web"}).Build(),
+		resourcetest.Instance("inst_4").Prop(p.Tags, []string{"Env=dev", "Dept=data"}).Build(),
+		resourcetest.Instance("inst_5").Build(),
+	)
+
+	tcases := []struct {
+		filters, tagFilters []string
+		exp                 []string
+	}{
+		{filters: []string{"tag.Env=dev"}, exp: []string{"inst_1", "inst_4"}},
+		{filters: []string{"tag.Env=dev", "staging"}, exp: []string{"inst_1", "inst_2", "inst_4"}},
+		{filters: []string{"tag.Env=dev", "tag.Env=staging"}, exp: []string{"inst_1", "inst_2", "inst_4"}},
+		{filters: []string{"tag.Env=dev", "staging", "tag.Dept=web"}, exp: []string{"inst_1", "inst_2"}},
+		{filters: []string{"tag.env=dev"}, exp: nil},
+		{tagFilters: []string{"Env=dev", "production"}, exp: []string{"inst_1", "inst_3", "inst_4"}},
+		{tagFilters: []string{"Env=dev", "Dept=data"}, exp: []string{"inst_4"}},
+	}
+	for i, tcase := range tcases {
+		displayer, err := BuildOptions(
+			WithRdfType("instance"),
+			WithFormat("json"),
+			WithIDsOnly(true),
+			WithFilters(tcase.filters),
+			WithTagFilters(tcase.tagFilters),
+		).SetSource(g).Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var w bytes.Buffer
+		if err := displayer.Print(&w); err != nil {
+			t.Fatal(err)
+		}
+		got := strings.Fields(w.String())
+		sort.Strings(got)
+		if !reflect.DeepEqual(got, tcase.exp) && !(len(got) == 0 && len(tcase.exp) == 0) {
+			t.Fatalf("%d: got %v, want %v", i+1, got, tcase.exp)
+		}
+	}
 }
 
 func TestCompareInterface(t *testing.T) {
@@ -884,3 +947,270 @@ func TestNoHeadersDisplay(t *testing.T) {
 		t.Fatalf("got \n%s\n\nwant\n\n%s\n", got, want)
 	}
 }
+
+func TestPageDisplay(t *testing.T) {
+	g := createInfraGraph()
+	headers := []ColumnDefinition{
+		StringColumnDefinition{Prop: "ID"},
+		StringColumnDefinition{Prop: "Name"},
+	}
+
+	tcases := []struct {
+		page     int
+		expected string
+	}{
+		{1, "|  ID ▲  |  NAME  |\n|--------|--------|\n| inst_1 | redis  |\n| inst_2 | django |\nPage 1/2 (rows 1-2 of 3), next: --page 2\n"},
+		{2, "|  ID ▲  |  NAME  |\n|--------|--------|\n| inst_3 | apache |\nPage 2/2 (rows 3-3 of 3)\n"},
+		{3, "Page 3 out of range: 3 rows in 2 pages of 2\n"},
+	}
+	for _, tcase := range tcases {
+		displayer, _ := BuildOptions(
+			WithHeaders(headers),
+			WithRdfType("instance"),
+			WithPage(tcase.page, 2),
+		).SetSource(g).Build()
+		var w bytes.Buffer
+		if err := displayer.Print(&w); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := w.String(), tcase.expected; got != want {
+			t.Fatalf("page %d: got \n%q\n\nwant\n\n%q\n", tcase.page, got, want)
+		}
+	}
+
+	displayer, _ := BuildOptions(
+		WithHeaders(headers),
+		WithRdfType("instance"),
+		WithFormat("csv"),
+		WithPage(1, 2),
+	).SetSource(g).Build()
+	var w bytes.Buffer
+	if err := displayer.Print(&w); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := w.String(), "ID,Name\ninst_1,redis\ninst_2,django\ninst_3,apache\n"; got != want {
+		t.Fatalf("got \n%q\n\nwant\n\n%q\n", got, want)
+	}
+}
+
+func TestIDsDisplay(t *testing.T) {
+	g := createInfraGraph()
+	headers := []ColumnDefinition{
+		StringColumnDefinition{Prop: "ID"},
+		StringColumnDefinition{Prop: "Name"},
+		StringColumnDefinition{Prop: "State"},
+	}
+
+	tcases := []struct {
+		filters  []string
+		sortBy   []string
+		page     int
+		expected string
+	}{
+		{expected: "inst_1\ninst_2\ninst_3\n"},
+		{sortBy: []string{"name"}, expected: "inst_3\ninst_2\ninst_1\n"},
+		{filters: []string{"state=running"}, expected: "inst_1\ninst_3\n"},
+		{page: 2, expected: "inst_3\n"},
+		{page: 3, expected: ""},
+		{filters: []string{"state=terminated"}, expected: ""},
+	}
+	for i, tcase := range tcases {
+		sortBy := tcase.sortBy
+		if len(sortBy) == 0 {
+			sortBy = []string{"id"}
+		}
+		displayer, err := BuildOptions(
+			WithHeaders(headers),
+			WithRdfType("instance"),
+			WithFormat("ids"),
+			WithFilters(tcase.filters),
+			WithSortBy(sortBy...),
+			WithPage(tcase.page, 2),
+		).SetSource(g).Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var w bytes.Buffer
+		if err := displayer.Print(&w); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := w.String(), tcase.expected; got != want {
+			t.Fatalf("%d: got %q, want %q", i+1, got, want)
+		}
+	}
+}
+
+func TestPrefixListsDisplay(t *testing.T) {
+	_, cidr, _ := net.ParseCIDR("10.0.0.0/16")
+	g := graph.NewGraph()
+	g.AddResource(
+		resourcetest.PrefixList("pl-1").Prop(p.Name, "corp-vpn").Build(),
+		resourcetest.SecurityGroup("sg-1").Prop(p.InboundRules, []*graph.FirewallRule{
+			{PortRange: graph.PortRange{FromPort: 22, ToPort: 22}, Protocol: "tcp", IPRanges: []*net.IPNet{cidr}, PrefixLists: []string{"pl-1", "pl-2"}},
+		}).Build(),
+		resourcetest.RouteTable("rt-1").Prop(p.Routes, []*graph.Route{
+			{DestinationPrefixListId: "pl-1", Targets: []*graph.RouteTarget{{Type: graph.GatewayTarget, Ref: "vgw-1"}}},
+		}).Build(),
+	)
+
+	displayer, err := BuildOptions(
+		WithHeaders([]ColumnDefinition{
+			StringColumnDefinition{Prop: p.ID},
+			FirewallRulesColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: p.InboundRules, Friendly: "Inbound"}},
+		}),
+		WithRdfType("securitygroup"),
+		WithFormat("csv"),
+	).SetSource(g).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var w bytes.Buffer
+	if err = displayer.Print(&w); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := w.String(), "ID,Inbound\nsg-1,[10.0.0.0/16;pl-1(corp-vpn);pl-2](tcp:22) \n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	displayer, err = BuildOptions(
+		WithHeaders([]ColumnDefinition{
+			StringColumnDefinition{Prop: p.ID},
+			RoutesColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: p.Routes}},
+		}),
+		WithRdfType("routetable"),
+		WithFormat("csv"),
+	).SetSource(g).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Reset()
+	if err = displayer.Print(&w); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := w.String(), "ID,Routes\nrt-1,pl-1(corp-vpn)->gw:vgw-1 \n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestRelationsDisplay(t *testing.T) {
+	g := graph.NewGraph()
+	subnet := resourcetest.Subnet("sub-1").Build()
+	inst1 := resourcetest.Instance("inst-1").Prop(p.Name, "web").Build()
+	inst2 := resourcetest.Instance("inst-2").Prop(p.Name, "db").Build()
+	sg1, sg2 := resourcetest.SecurityGroup("sg-1").Build(), resourcetest.SecurityGroup("sg-2").Build()
+	g.AddResource(subnet, inst1, inst2, sg1, sg2)
+	g.AddParentRelation(subnet, inst1)
+	g.AddAppliesOnRelation(sg2, inst1)
+	g.AddAppliesOnRelation(sg1, inst1)
+	g.AddAppliesOnRelation(sg1, inst2)
+
+	displayer, err := BuildOptions(
+		WithHeaders([]ColumnDefinition{StringColumnDefinition{Prop: p.ID}, StringColumnDefinition{Prop: p.Name}}),
+		WithRdfType("instance"),
+		WithFormat("csv"),
+		WithRelations(g),
+	).SetSource(g).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var w bytes.Buffer
+	if err = displayer.Print(&w); err != nil {
+		t.Fatal(err)
+	}
+	expected := "ID,Name,Subnet,SecGroups,Volumes,EIPs,TargetGroups\n" +
+		"inst-1,web,sub-1,sg-1 sg-2,,,\n" +
+		"inst-2,db,,sg-1,,,\n"
+	if got, want := w.String(), expected; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	displayer, err = BuildOptions(
+		WithHeaders([]ColumnDefinition{StringColumnDefinition{Prop: p.ID}}),
+		WithRdfType("securitygroup"),
+		WithFormat("csv"),
+		WithRelations(g),
+	).SetSource(g).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Reset()
+	if err = displayer.Print(&w); err != nil {
+		t.Fatal(err)
+	}
+	expected = "ID,Vpc,Instances,LoadBalancers,Databases\n" +
+		"sg-1,,inst-1 inst-2,,\n" +
+		"sg-2,,inst-1,,\n"
+	if got, want := w.String(), expected; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestWideDisplay(t *testing.T) {
+	g := graph.NewGraph()
+	g.AddResource(
+		resourcetest.Role("role-1").Prop(p.Name, "deployer").Prop(p.Path, "/ci/").Prop(p.ManagedPolicies, []string{"ReadOnlyAccess"}).Build(),
+		resourcetest.Role("role-2").Prop(p.Name, "lambda").Prop(p.Path, "/").Prop(p.InlinePolicies, []string{"logs"}).Build(),
+	)
+	headers := []ColumnDefinition{StringColumnDefinition{Prop: p.ID}, StringColumnDefinition{Prop: p.Name}}
+
+	displayer, err := BuildOptions(
+		WithHeaders(headers),
+		WithRdfType("role"),
+		WithFormat("wide"),
+	).SetSource(g).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var w bytes.Buffer
+	if err = displayer.Print(&w); err != nil {
+		t.Fatal(err)
+	}
+	expected := `|  ID ▲  |   NAME   | PATH |     MANAGED      | INLINE |
+|--------|----------|------|------------------|--------|
+| role-1 | deployer | /ci/ | [ReadOnlyAccess] |        |
+| role-2 | lambda   | /    |                  | [logs] |
+`
+	if got, want := w.String(), expected; got != want {
+		t.Fatalf("got \n%s\n\nwant\n\n%s\n", got, want)
+	}
+
+	displayer, err = BuildOptions(
+		WithHeaders(headers),
+		WithRdfType("role"),
+		WithFormat("table"),
+	).SetSource(g).Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Reset()
+	if err = displayer.Print(&w); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(w.String(), "MANAGED") {
+		t.Fatalf("unexpected wide column in table output:\n%s", w.String())
+	}
+	if got, want := len(headers), 2; got != want {
+		t.Fatalf("wide columns must not alter the given headers: got %d, want %d", got, want)
+	}
+}
+
+func TestLowValueColumnDefinition(t *testing.T) {
+	defer func(noColor bool) { color.NoColor = noColor }(color.NoColor)
+	color.NoColor = false
+
+	threshold := 16
+	def := LowValueColumnDefinition{StringColumnDefinition: StringColumnDefinition{Prop: p.FreeIPs}, Threshold: &threshold}
+	if got, want := def.format(250), "250"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if got, want := def.format(nil), ""; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if got, want := def.format(3), color.New(color.FgRed).SprintFunc()("3"); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	threshold = 2
+	if got, want := def.format(3), "3"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
@@ -20,10 +20,13 @@ import (
 	"bytes"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/fatih/color"
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/cloud/properties"
 	"github.com/wallix/awless/graph"
 )
 
@@ -92,6 +95,20 @@ func (h ColoredValueColumnDefinition) format(i interface{}) string {
 	return str
 }
 
+// LowValueColumnDefinition flags in red the integer values lower than the one pointed by Threshold
+type LowValueColumnDefinition struct {
+	StringColumnDefinition
+	Threshold *int
+}
+
+func (h LowValueColumnDefinition) format(i interface{}) string {
+	str := h.StringColumnDefinition.format(i)
+	if v, ok := i.(int); ok && h.Threshold != nil && v < *h.Threshold {
+		return color.New(color.FgRed).SprintFunc()(str)
+	}
+	return str
+}
+
 type ARNLastValueColumnDefinition struct {
 	StringColumnDefinition
 	Separator string
@@ -192,8 +209,13 @@ func (h StorageColumnDefinition) format(i interface{}) string {
 	return "invalid size"
 }
 
+// defaultNetworkAclRuleNumber is the number of the last rule of network ACLs, denying what no rule matched
+const defaultNetworkAclRuleNumber = 32767
+
 type FirewallRulesColumnDefinition struct {
 	StringColumnDefinition
+	// PrefixLists names the prefix lists referenced by the rules (by id)
+	PrefixLists map[string]string
 }
 
 func (h FirewallRulesColumnDefinition) format(i interface{}) string {
@@ -206,12 +228,25 @@ func (h FirewallRulesColumnDefinition) format(i interface{}) string {
 	}
 	var w bytes.Buffer
 
+	if len(ii) > 0 && ii[0].Number > 0 { // network ACL rules display in evaluation order
+		ii = append([]*graph.FirewallRule{}, ii...)
+		graph.FirewallRules(ii).Sort()
+	}
 	for _, r := range ii {
+		switch {
+		case r.Number == defaultNetworkAclRuleNumber:
+			w.WriteString(fmt.Sprintf("#*:%s", r.Action))
+		case r.Number > 0:
+			w.WriteString(fmt.Sprintf("#%d:%s", r.Number, r.Action))
+		}
 		w.WriteString("[")
 		var netStrings []string
 		for _, net := range r.IPRanges {
 			netStrings = append(netStrings, net.String())
 		}
+		for _, pl := range r.PrefixLists {
+			netStrings = append(netStrings, prefixListName(h.PrefixLists, pl))
+		}
 		w.WriteString(strings.Join(netStrings, ";"))
 
 		w.WriteString("](")
@@ -234,6 +269,8 @@ func (h FirewallRulesColumnDefinition) format(i interface{}) string {
 
 type RoutesColumnDefinition struct {
 	StringColumnDefinition
+	// PrefixLists names the prefix lists the routes have as destination (by id)
+	PrefixLists map[string]string
 }
 
 func (h RoutesColumnDefinition) format(i interface{}) string {
@@ -256,6 +293,9 @@ func (h RoutesColumnDefinition) format(i interface{}) string {
 		if r.DestinationIPv6 != nil {
 			w.WriteString(r.DestinationIPv6.String())
 		}
+		if r.DestinationPrefixListId != "" {
+			w.WriteString(prefixListName(h.PrefixLists, r.DestinationPrefixListId))
+		}
 		w.WriteString("->")
 		if len(r.Targets) > 1 {
 			w.WriteString("[")
@@ -288,6 +328,145 @@ func (h RoutesColumnDefinition) format(i interface{}) string {
 	return w.String()
 }
 
+// prefixListName returns the name of the prefix list with the id 'pl-123(name)' when known
+func prefixListName(names map[string]string, id string) string {
+	if name, ok := names[id]; ok {
+		return fmt.Sprintf("%s(%s)", id, name)
+	}
+	return id
+}
+
+// resolvePrefixLists returns the headers with the rules and routes columns
+// naming the prefix lists of the graph they reference
+func resolvePrefixLists(headers []ColumnDefinition, g *graph.Graph) []ColumnDefinition {
+	var names map[string]string
+	resolved := make([]ColumnDefinition, len(headers))
+	for i, h := range headers {
+		switch hh := h.(type) {
+		case FirewallRulesColumnDefinition:
+			if names == nil {
+				names = prefixListNames(g)
+			}
+			hh.PrefixLists = names
+			h = hh
+		case RoutesColumnDefinition:
+			if names == nil {
+				names = prefixListNames(g)
+			}
+			hh.PrefixLists = names
+			h = hh
+		}
+		resolved[i] = h
+	}
+	return resolved
+}
+
+func prefixListNames(g *graph.Graph) map[string]string {
+	names := make(map[string]string)
+	lists, err := g.GetAllResources(cloud.PrefixList)
+	if err != nil {
+		return names
+	}
+	for _, pl := range lists {
+		if name, ok := pl.Properties[properties.Name].(string); ok && name != "" {
+			names[pl.Id()] = name
+		}
+	}
+	return names
+}
+
+// RelationColumnDefinition displays the ids of the resources of a type related to the listed
+// resource in the graph: its parents and children, and the resources applying on it or it applies on
+type RelationColumnDefinition struct {
+	Type, Friendly string
+
+	related map[string][]string
+}
+
+func (h RelationColumnDefinition) format(i interface{}) string {
+	if i == nil {
+		return ""
+	}
+	return strings.Join(h.related[fmt.Sprint(i)], " ")
+}
+func (h RelationColumnDefinition) propKey() string { return properties.ID }
+func (h RelationColumnDefinition) title(displayAscSymbol bool) string {
+	t := h.Friendly
+	if t == "" {
+		t = strings.Title(h.Type)
+	}
+	if displayAscSymbol {
+		t += ascSymbol
+	}
+	return t
+}
+
+// withWideColumns returns the headers followed by the wide columns of the resource type
+func withWideColumns(headers []ColumnDefinition, rdfType string) []ColumnDefinition {
+	return append(append([]ColumnDefinition{}, headers...), DefaultsWideColumnDefinitions[rdfType]...)
+}
+
+// withRelations returns the headers followed by the relation columns of the resource type,
+// filled from the relations of the graph
+func withRelations(headers []ColumnDefinition, rdfType string, relations []RelationColumnDefinition, g *graph.Graph) []ColumnDefinition {
+	if len(relations) == 0 {
+		return headers
+	}
+	resolved := append([]ColumnDefinition{}, headers...)
+	related := relatedResources(g, rdfType)
+	for _, rel := range relations {
+		rel.related = make(map[string][]string)
+		for id, byType := range related {
+			rel.related[id] = byType[rel.Type]
+		}
+		resolved = append(resolved, rel)
+	}
+	return resolved
+}
+
+// relatedResources returns per resource of the type the sorted ids of its related resources by type
+func relatedResources(g *graph.Graph, rdfType string) map[string]map[string][]string {
+	related := make(map[string]map[string][]string)
+	resources, err := g.GetAllResources(rdfType)
+	if err != nil {
+		return related
+	}
+	for _, res := range resources {
+		var all []*graph.Resource
+		collect := graph.VisitorCollectFunc(&all)
+		if err := g.Accept(&graph.ParentsVisitor{From: res, Each: collect, MaxDepth: 1}); err != nil {
+			continue
+		}
+		if err := g.Accept(&graph.ChildrenVisitor{From: res, Each: collect, MaxDepth: 1}); err != nil {
+			continue
+		}
+		appliedOn, err := g.ListResourcesAppliedOn(res)
+		if err != nil {
+			continue
+		}
+		dependingOn, err := g.ListResourcesDependingOn(res)
+		if err != nil {
+			continue
+		}
+		all = append(append(all, appliedOn...), dependingOn...)
+
+		byType := make(map[string][]string)
+		seen := make(map[string]bool)
+		for _, r := range all {
+			if seen[r.Id()] {
+				continue
+			}
+			seen[r.Id()] = true
+			byType[r.Type()] = append(byType[r.Type()], r.Id())
+		}
+		for _, ids := range byType {
+			sort.Strings(ids)
+		}
+		related[res.Id()] = byType
+	}
+	return related
+}
+
 type GrantsColumnDefinition struct {
 	StringColumnDefinition
 }
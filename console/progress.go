@@ -0,0 +1,92 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package console
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// Progress displays the status of concurrent tasks (i.e. the fetching of services).
+// It is safe for concurrent use. In place, one status line per task is redrawn on each update;
+// otherwise each update is written as a plain line.
+type Progress struct {
+	mu      sync.Mutex
+	out     io.Writer
+	inPlace bool
+	names   []string
+	status  map[string]string
+	width   int
+	lines   int
+}
+
+func NewProgress(out io.Writer, inPlace bool, names ...string) *Progress {
+	p := &Progress{out: out, inPlace: inPlace, names: names, status: make(map[string]string)}
+	for _, n := range names {
+		if len(n) > p.width {
+			p.width = len(n)
+		}
+	}
+	return p
+}
+
+// NewTerminalProgress returns a progress updating in place when out is a terminal
+func NewTerminalProgress(out *os.File, names ...string) *Progress {
+	return NewProgress(out, terminal.IsTerminal(int(out.Fd())), names...)
+}
+
+func (p *Progress) Update(name, status string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.isKnown(name) {
+		p.names = append(p.names, name)
+		if len(name) > p.width {
+			p.width = len(name)
+		}
+	}
+	p.status[name] = status
+
+	if !p.inPlace {
+		fmt.Fprintf(p.out, "%s: %s\n", name, status)
+		return
+	}
+	p.redraw()
+}
+
+func (p *Progress) isKnown(name string) bool {
+	for _, n := range p.names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Progress) redraw() {
+	if p.lines > 0 {
+		fmt.Fprintf(p.out, "\033[%dA", p.lines)
+	}
+	for _, n := range p.names {
+		fmt.Fprintf(p.out, "\r\033[2K%-*s  %s\n", p.width, n, p.status[n])
+	}
+	p.lines = len(p.names)
+}
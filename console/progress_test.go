@@ -0,0 +1,73 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package console
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestProgress(t *testing.T) {
+	t.Run("plain lines", func(t *testing.T) {
+		var buff bytes.Buffer
+		p := NewProgress(&buff, false, "infra", "access")
+		p.Update("infra", "fetching...")
+		p.Update("access", "fetching...")
+		p.Update("infra", "done")
+
+		if got, want := buff.String(), "infra: fetching...\naccess: fetching...\ninfra: done\n"; got != want {
+			t.Fatalf("got\n%q\nwant\n%q", got, want)
+		}
+	})
+
+	t.Run("in place", func(t *testing.T) {
+		var buff bytes.Buffer
+		p := NewProgress(&buff, true, "infra", "dns")
+		p.Update("infra", "fetching...")
+		p.Update("dns", "done")
+
+		expected := "\r\033[2Kinfra  fetching...\n\r\033[2Kdns    \n" +
+			"\033[2A\r\033[2Kinfra  fetching...\n\r\033[2Kdns    done\n"
+		if got, want := buff.String(), expected; got != want {
+			t.Fatalf("got\n%q\nwant\n%q", got, want)
+		}
+	})
+
+	t.Run("concurrent updates", func(t *testing.T) {
+		var buff bytes.Buffer
+		p := NewProgress(&buff, false)
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				p.Update(fmt.Sprintf("service%d", i), "done")
+			}(i)
+		}
+		wg.Wait()
+
+		if got, want := strings.Count(buff.String(), "done\n"), 20; got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+		if got, want := len(p.names), 20; got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+	})
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package console
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"sort"
@@ -88,3 +89,14 @@ func (d *tableResourceDisplayer) Print(w io.Writer) error {
 func (d *tableResourceDisplayer) SetResource(r *graph.Resource) {
 	d.r = r
 }
+
+type jsonResourceDisplayer struct {
+	r *graph.Resource
+}
+
+func (d *jsonResourceDisplayer) Print(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", " ")
+
+	return enc.Encode(d.r.Properties)
+}
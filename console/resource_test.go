@@ -64,4 +64,15 @@ func TestResourceDisplay(t *testing.T) {
 	if got, want := w.String(), expected; got != want {
 		t.Fatalf("got \n%s\n\nwant\n\n%s\n", got, want)
 	}
+
+	displayer, _ = BuildOptions(
+		WithHeaders(headers),
+		WithFormat("json"),
+	).SetSource(r).Build()
+
+	w.Reset()
+	if err := displayer.Print(&w); err != nil {
+		t.Fatal(err)
+	}
+	compareJSON(t, w.String(), `{"ID": "inst_1", "Name": "instance 1"}`)
 }
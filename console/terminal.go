@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
 	"os/signal"
 
 	"golang.org/x/crypto/ssh"
@@ -42,6 +43,38 @@ func GetTerminalHeight() int {
 	return h
 }
 
+// defaultPager quits when the output fits the screen and keeps the colors
+const defaultPager = "less -FRX"
+
+// StartPager returns a writer piping to the PAGER command (or less) when out is a terminal,
+// and out itself otherwise. Call the returned func once written to wait for the pager to quit
+func StartPager(out *os.File) (io.Writer, func() error) {
+	noPager := func() error { return nil }
+	if !terminal.IsTerminal(int(out.Fd())) {
+		return out, noPager
+	}
+	pager := os.Getenv("PAGER")
+	if pager == "" {
+		if _, err := exec.LookPath("less"); err != nil {
+			return out, noPager
+		}
+		pager = defaultPager
+	}
+	cmd := exec.Command("sh", "-c", pager)
+	cmd.Stdout, cmd.Stderr = out, os.Stderr
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		return out, noPager
+	}
+	if err = cmd.Start(); err != nil {
+		return out, noPager
+	}
+	return in, func() error {
+		in.Close()
+		return cmd.Wait()
+	}
+}
+
 func InteractiveTerminal(client *ssh.Client) error {
 	session, err := client.NewSession()
 	if err != nil {
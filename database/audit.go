@@ -0,0 +1,92 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/boltdb/bolt"
+)
+
+const AUDIT_BUCKET = "audit"
+
+// AuditEntry records a mutating action run through a driver
+type AuditEntry struct {
+	Time       time.Time         `json:"time"`
+	TemplateID string            `json:"templateId"`
+	Identity   string            `json:"identity"`
+	Region     string            `json:"region"`
+	Action     string            `json:"action"`
+	Entity     string            `json:"entity"`
+	Params     map[string]string `json:"params"`
+	ResourceID string            `json:"resourceId,omitempty"`
+	Error      string            `json:"error,omitempty"`
+}
+
+// AddAuditEntries appends entries to the audit log. The audit log is append only:
+// entries cannot be modified nor deleted
+func (db *DB) AddAuditEntries(entries ...*AuditEntry) error {
+	return db.bolt.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte(AUDIT_BUCKET))
+		if err != nil {
+			return fmt.Errorf("create bucket %s: %s", AUDIT_BUCKET, err)
+		}
+
+		for _, e := range entries {
+			b, err := json.Marshal(e)
+			if err != nil {
+				return err
+			}
+			seq, err := bucket.NextSequence()
+			if err != nil {
+				return err
+			}
+			key := make([]byte, 8)
+			binary.BigEndian.PutUint64(key, seq)
+			if err = bucket.Put(key, b); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ListAuditEntries returns the entries of the audit log in the order they were added
+func (db *DB) ListAuditEntries() ([]*AuditEntry, error) {
+	var entries []*AuditEntry
+
+	err := db.bolt.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(AUDIT_BUCKET))
+		if b == nil {
+			return nil
+		}
+
+		return b.ForEach(func(k, v []byte) error {
+			e := &AuditEntry{}
+			if err := json.Unmarshal(v, e); err != nil {
+				return fmt.Errorf("audit entry %d: %s", binary.BigEndian.Uint64(k), err)
+			}
+			entries = append(entries, e)
+			return nil
+		})
+	})
+
+	return entries, err
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package database
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAuditEntries(t *testing.T) {
+	db, close := newTestDb()
+	defer close()
+
+	entries, err := db.ListAuditEntries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(entries), 0; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	var expected []*AuditEntry
+	for i := 0; i < 12; i++ {
+		expected = append(expected, &AuditEntry{Time: now, TemplateID: "tpl_1", Action: "create", Entity: "instance", Params: map[string]string{"name": fmt.Sprintf("inst_%d", i)}})
+	}
+	expected[11].Error = "create instance: access denied"
+
+	if err = db.AddAuditEntries(expected[:10]...); err != nil {
+		t.Fatal(err)
+	}
+	if err = db.AddAuditEntries(expected[10:]...); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err = db.ListAuditEntries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := entries, expected; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
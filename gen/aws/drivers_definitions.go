@@ -35,6 +35,8 @@ type driver struct {
 	Input, Output, ApiMethod, OutputExtractor string
 	DryRunUnsupported                         bool
 	ManualFuncDefinition                      bool
+	// IdempotencyToken is the field of the input receiving the client token, if the AWS call supports it
+	IdempotencyToken string
 }
 
 func (d *driver) RequiredKeys() []string {
@@ -51,10 +53,23 @@ func (d *driver) ExtraKeys() []string {
 	for _, p := range d.ExtraParams {
 		keys = append(keys, p.TemplateName)
 	}
+	if d.IdempotencyToken != "" {
+		keys = append(keys, "client-token")
+	}
 
 	return sortUnique(keys)
 }
 
+// HasTagParams returns whether some params of the driver are set as tags, through an additional call
+func (d *driver) HasTagParams() bool {
+	for _, p := range append(d.RequiredParams, d.ExtraParams...) {
+		if p.AsAwsTag {
+			return true
+		}
+	}
+	return false
+}
+
 type driversDef struct {
 	Api     string
 	Drivers []driver
@@ -125,7 +140,7 @@ var DriversDefs = []driversDef{
 
 			// INSTANCES
 			{
-				Action: "create", Entity: cloud.Instance, ApiMethod: "RunInstances", Input: "RunInstancesInput", Output: "Reservation", OutputExtractor: "aws.StringValue(output.Instances[0].InstanceId)",
+				Action: "create", Entity: cloud.Instance, ApiMethod: "RunInstances", Input: "RunInstancesInput", Output: "Reservation", OutputExtractor: "aws.StringValue(output.Instances[0].InstanceId)", IdempotencyToken: "ClientToken",
 				RequiredParams: []param{
 					{AwsField: "ImageId", TemplateName: "image", AwsType: "awsstr"},
 					{AwsField: "MaxCount", TemplateName: "count", AwsType: "awsint64"},
@@ -160,15 +175,39 @@ var DriversDefs = []driversDef{
 				},
 			},
 			{
-				Action: "start", Entity: cloud.Instance, ApiMethod: "StartInstances", Input: "StartInstancesInput", Output: "StartInstancesOutput", OutputExtractor: "aws.StringValue(output.StartingInstances[0].InstanceId)",
+				Action: "start", Entity: cloud.Instance, ManualFuncDefinition: true,
 				RequiredParams: []param{
-					{AwsField: "InstanceIds", TemplateName: "id", AwsType: "awsstringslice"},
+					{TemplateName: "id"},
+				},
+				ExtraParams: []param{
+					{TemplateName: "batch"},
+					{TemplateName: "delay"},
+					{TemplateName: "wait"},
+					{TemplateName: "wait-timeout"},
 				},
 			},
 			{
-				Action: "stop", Entity: cloud.Instance, ApiMethod: "StopInstances", Input: "StopInstancesInput", Output: "StopInstancesOutput", OutputExtractor: "aws.StringValue(output.StoppingInstances[0].InstanceId)",
+				Action: "stop", Entity: cloud.Instance, ManualFuncDefinition: true,
 				RequiredParams: []param{
-					{AwsField: "InstanceIds", TemplateName: "id", AwsType: "awsstringslice"},
+					{TemplateName: "id"},
+				},
+				ExtraParams: []param{
+					{TemplateName: "batch"},
+					{TemplateName: "delay"},
+					{TemplateName: "wait"},
+					{TemplateName: "wait-timeout"},
+				},
+			},
+			{
+				Action: "reboot", Entity: cloud.Instance, ManualFuncDefinition: true,
+				RequiredParams: []param{
+					{TemplateName: "id"},
+				},
+				ExtraParams: []param{
+					{TemplateName: "batch"},
+					{TemplateName: "delay"},
+					{TemplateName: "wait"},
+					{TemplateName: "wait-timeout"},
 				},
 			},
 			{
@@ -181,11 +220,15 @@ var DriversDefs = []driversDef{
 			},
 			// Security Group
 			{
-				Action: "create", Entity: cloud.SecurityGroup, ApiMethod: "CreateSecurityGroup", Input: "CreateSecurityGroupInput", Output: "CreateSecurityGroupOutput", OutputExtractor: "aws.StringValue(output.GroupId)",
+				Action: "create", Entity: cloud.SecurityGroup, ManualFuncDefinition: true,
 				RequiredParams: []param{
-					{AwsField: "GroupName", TemplateName: "name", AwsType: "awsstr"},
-					{AwsField: "VpcId", TemplateName: "vpc", AwsType: "awsstr"},
-					{AwsField: "Description", TemplateName: "description", AwsType: "awsstr"},
+					{TemplateName: "name"},
+					{TemplateName: "vpc"},
+					{TemplateName: "description"},
+				},
+				ExtraParams: []param{
+					{TemplateName: "inbound-rules"},
+					{TemplateName: "outbound-rules"},
 				},
 			},
 			{
@@ -235,15 +278,18 @@ var DriversDefs = []driversDef{
 			},
 			// IMAGES
 			{
-				Action: "copy", Entity: cloud.Image, ApiMethod: "CopyImage", Input: "CopyImageInput", Output: "CopyImageOutput", OutputExtractor: "aws.StringValue(output.ImageId)",
+				Action: "copy", Entity: cloud.Image, ManualFuncDefinition: true, IdempotencyToken: "ClientToken",
 				RequiredParams: []param{
-					{AwsField: "Name", TemplateName: "name", AwsType: "awsstr"},
-					{AwsField: "SourceImageId", TemplateName: "source-id", AwsType: "awsstr"},
-					{AwsField: "SourceRegion", TemplateName: "source-region", AwsType: "awsstr"},
+					{TemplateName: "name"},
+					{TemplateName: "source-id"},
 				},
 				ExtraParams: []param{
-					{AwsField: "Encrypted", TemplateName: "encrypted", AwsType: "awsbool"},
-					{AwsField: "Description", TemplateName: "description", AwsType: "awsstr"},
+					{TemplateName: "source-region"},
+					{TemplateName: "to-region"},
+					{TemplateName: "encrypted"},
+					{TemplateName: "kmskey"},
+					{TemplateName: "description"},
+					{TemplateName: "wait"},
 				},
 			},
 			{
@@ -327,14 +373,17 @@ var DriversDefs = []driversDef{
 				},
 			},
 			{
-				Action: "copy", Entity: cloud.Snapshot, ApiMethod: "CopySnapshot", Input: "CopySnapshotInput", Output: "CopySnapshotOutput", OutputExtractor: "aws.StringValue(output.SnapshotId)",
+				Action: "copy", Entity: cloud.Snapshot, ManualFuncDefinition: true,
 				RequiredParams: []param{
-					{AwsField: "SourceSnapshotId", TemplateName: "source-id", AwsType: "awsstr"},
-					{AwsField: "SourceRegion", TemplateName: "source-region", AwsType: "awsstr"},
+					{TemplateName: "source-id"},
 				},
 				ExtraParams: []param{
-					{AwsField: "Encrypted", TemplateName: "encrypted", AwsType: "awsbool"},
-					{AwsField: "Description", TemplateName: "description", AwsType: "awsstr"},
+					{TemplateName: "source-region"},
+					{TemplateName: "to-region"},
+					{TemplateName: "encrypted"},
+					{TemplateName: "kmskey"},
+					{TemplateName: "description"},
+					{TemplateName: "wait"},
 				},
 			},
 			// INTERNET GATEWAYS
@@ -363,10 +412,13 @@ var DriversDefs = []driversDef{
 			},
 			// NAT GATEWAYS
 			{
-				Action: "create", Entity: cloud.NatGateway, ApiMethod: "CreateNatGateway", Input: "CreateNatGatewayInput", Output: "CreateNatGatewayOutput", OutputExtractor: "aws.StringValue(output.NatGateway.NatGatewayId)", DryRunUnsupported: true,
+				Action: "create", Entity: cloud.NatGateway, ManualFuncDefinition: true, IdempotencyToken: "ClientToken",
 				RequiredParams: []param{
-					{AwsField: "AllocationId", TemplateName: "elasticip-id", AwsType: "awsstr"},
-					{AwsField: "SubnetId", TemplateName: "subnet", AwsType: "awsstr"},
+					{TemplateName: "elasticip-id"},
+					{TemplateName: "subnet"},
+				},
+				ExtraParams: []param{
+					{TemplateName: "update-routes"},
 				},
 			},
 			{
@@ -383,6 +435,156 @@ var DriversDefs = []driversDef{
 					{TemplateName: "timeout"},
 				},
 			},
+			// FLEET
+			{
+				Action: "create", Entity: cloud.Fleet, ManualFuncDefinition: true, IdempotencyToken: "ClientToken",
+				RequiredParams: []param{
+					{TemplateName: "target-capacity"},
+				},
+				ExtraParams: []param{
+					{TemplateName: "allocation-strategy"},
+					{TemplateName: "image"},
+					{TemplateName: "keypair"},
+					{TemplateName: "launch-template"},
+					{TemplateName: "launch-template-version"},
+					{TemplateName: "on-demand-capacity"},
+					{TemplateName: "request-type"},
+					{TemplateName: "role"},
+					{TemplateName: "securitygroup"},
+					{TemplateName: "spot-price"},
+					{TemplateName: "subnet"},
+					{TemplateName: "type"},
+				},
+			},
+			{
+				Action: "update", Entity: cloud.Fleet, ManualFuncDefinition: true,
+				RequiredParams: []param{
+					{TemplateName: "id"},
+				},
+				ExtraParams: []param{
+					{TemplateName: "excess-capacity-termination"},
+					{TemplateName: "on-demand-capacity"},
+					{TemplateName: "target-capacity"},
+				},
+			},
+			{
+				Action: "delete", Entity: cloud.Fleet, ManualFuncDefinition: true,
+				RequiredParams: []param{
+					{TemplateName: "id"},
+				},
+				ExtraParams: []param{
+					{TemplateName: "terminate-instances"},
+				},
+			},
+			// DHCP OPTIONS
+			{
+				Action: "create", Entity: cloud.DhcpOptions, ManualFuncDefinition: true,
+				ExtraParams: []param{
+					{TemplateName: "domain-name"},
+					{TemplateName: "domain-name-servers"},
+					{TemplateName: "ntp-servers"},
+				},
+			},
+			{
+				Action: "delete", Entity: cloud.DhcpOptions, ApiMethod: "DeleteDhcpOptions", Input: "DeleteDhcpOptionsInput", Output: "DeleteDhcpOptionsOutput",
+				RequiredParams: []param{
+					{AwsField: "DhcpOptionsId", TemplateName: "id", AwsType: "awsstr"},
+				},
+			},
+			{
+				Action: "attach", Entity: cloud.DhcpOptions, ApiMethod: "AssociateDhcpOptions", Input: "AssociateDhcpOptionsInput", Output: "AssociateDhcpOptionsOutput",
+				RequiredParams: []param{
+					{AwsField: "DhcpOptionsId", TemplateName: "id", AwsType: "awsstr"},
+					{AwsField: "VpcId", TemplateName: "vpc", AwsType: "awsstr"},
+				},
+			},
+			// PLACEMENT GROUPS
+			{
+				Action: "create", Entity: cloud.PlacementGroup, ApiMethod: "CreatePlacementGroup", Input: "CreatePlacementGroupInput", Output: "CreatePlacementGroupOutput", OutputExtractor: "aws.StringValue(input.GroupName)",
+				RequiredParams: []param{
+					{AwsField: "GroupName", TemplateName: "name", AwsType: "awsstr"},
+					{AwsField: "Strategy", TemplateName: "strategy", AwsType: "awsstr"},
+				},
+			},
+			{
+				Action: "delete", Entity: cloud.PlacementGroup, ApiMethod: "DeletePlacementGroup", Input: "DeletePlacementGroupInput", Output: "DeletePlacementGroupOutput",
+				RequiredParams: []param{
+					{AwsField: "GroupName", TemplateName: "name", AwsType: "awsstr"},
+				},
+			},
+			// PREFIX LISTS
+			{
+				Action: "create", Entity: cloud.PrefixList, ManualFuncDefinition: true,
+				RequiredParams: []param{
+					{TemplateName: "name"},
+					{TemplateName: "max-entries"},
+				},
+				ExtraParams: []param{
+					{TemplateName: "cidrs"},
+					{TemplateName: "family"},
+				},
+			},
+			{
+				Action: "update", Entity: cloud.PrefixList, ManualFuncDefinition: true,
+				RequiredParams: []param{
+					{TemplateName: "id"},
+				},
+				ExtraParams: []param{
+					{TemplateName: "add-cidrs"},
+					{TemplateName: "remove-cidrs"},
+					{TemplateName: "name"},
+				},
+			},
+			{
+				Action: "delete", Entity: cloud.PrefixList, ManualFuncDefinition: true,
+				RequiredParams: []param{
+					{TemplateName: "id"},
+				},
+			},
+			// NETWORK ACLS
+			{
+				Action: "create", Entity: cloud.NetworkAcl, ApiMethod: "CreateNetworkAcl", Input: "CreateNetworkAclInput", Output: "CreateNetworkAclOutput", OutputExtractor: "aws.StringValue(output.NetworkAcl.NetworkAclId)",
+				RequiredParams: []param{
+					{AwsField: "VpcId", TemplateName: "vpc", AwsType: "awsstr"},
+				},
+			},
+			{
+				Action: "delete", Entity: cloud.NetworkAcl, ApiMethod: "DeleteNetworkAcl", Input: "DeleteNetworkAclInput", Output: "DeleteNetworkAclOutput",
+				RequiredParams: []param{
+					{AwsField: "NetworkAclId", TemplateName: "id", AwsType: "awsstr"},
+				},
+			},
+			{
+				Action: "attach", Entity: cloud.NetworkAcl, ManualFuncDefinition: true,
+				RequiredParams: []param{
+					{TemplateName: "id"},
+					{TemplateName: "subnet"},
+				},
+			},
+			{
+				Action: "create", Entity: "networkaclrule", ManualFuncDefinition: true,
+				RequiredParams: []param{
+					{TemplateName: "id"},
+					{TemplateName: "number"},
+					{TemplateName: "action"},
+					{TemplateName: "protocol"},
+					{TemplateName: "cidr"},
+				},
+				ExtraParams: []param{
+					{TemplateName: "portrange"},
+					{TemplateName: "outbound"},
+				},
+			},
+			{
+				Action: "delete", Entity: "networkaclrule", ManualFuncDefinition: true,
+				RequiredParams: []param{
+					{TemplateName: "id"},
+					{TemplateName: "number"},
+				},
+				ExtraParams: []param{
+					{TemplateName: "outbound"},
+				},
+			},
 			// ROUTE TABLES
 			{
 				Action: "create", Entity: cloud.RouteTable, ApiMethod: "CreateRouteTable", Input: "CreateRouteTableInput", Output: "CreateRouteTableOutput", OutputExtractor: "aws.StringValue(output.RouteTable.RouteTableId)",
@@ -588,6 +790,30 @@ var DriversDefs = []driversDef{
 					{AwsField: "Targets[0]Id", TemplateName: "id", AwsType: "awsslicestruct"},
 				},
 			},
+			// Load balancer stack: loadbalancer + targetgroup + registered targets + listener
+			{
+				Action: "create", Entity: "loadbalancerstack", ManualFuncDefinition: true,
+				RequiredParams: []param{
+					{TemplateName: "name"},
+					{TemplateName: "subnets"},
+					{TemplateName: "instances"},
+					{TemplateName: "port"},
+				},
+				ExtraParams: []param{
+					{TemplateName: "protocol"}, // HTTP (default) or HTTPS
+					{TemplateName: "listenerport"},
+					{TemplateName: "certificate"},
+					{TemplateName: "securitygroups"},
+					{TemplateName: "scheme"},
+					{TemplateName: "healthcheckpath"},
+				},
+			},
+			{
+				Action: "delete", Entity: "loadbalancerstack", ManualFuncDefinition: true,
+				RequiredParams: []param{
+					{TemplateName: "id"},
+				},
+			},
 		},
 	},
 	{
@@ -782,6 +1008,17 @@ var DriversDefs = []driversDef{
 					{AwsField: "RegistryId", TemplateName: "account", AwsType: "awsstr"},
 				},
 			},
+			{
+				Action: "update", Entity: cloud.Repository, ManualFuncDefinition: true,
+				RequiredParams: []param{
+					{TemplateName: "name"},
+				},
+				ExtraParams: []param{
+					{TemplateName: "account"},
+					{TemplateName: "lifecycle-policy-file"},
+					{TemplateName: "scan-on-push"},
+				},
+			},
 			// Registry
 			{
 				Action: "authenticate", Entity: cloud.Registry, ManualFuncDefinition: true,
@@ -810,7 +1047,7 @@ var DriversDefs = []driversDef{
 				},
 			},
 			{
-				Action: "start", Entity: cloud.ContainerService, ApiMethod: "CreateService", Input: "CreateServiceInput", Output: "CreateServiceOutput", DryRunUnsupported: true,
+				Action: "start", Entity: cloud.ContainerService, ApiMethod: "CreateService", Input: "CreateServiceInput", Output: "CreateServiceOutput", DryRunUnsupported: true, IdempotencyToken: "ClientToken",
 				RequiredParams: []param{
 					{AwsField: "Cluster", TemplateName: "cluster", AwsType: "awsstr"},
 					{AwsField: "ServiceName", TemplateName: "deployment-name", AwsType: "awsstr"},
@@ -1060,6 +1297,34 @@ var DriversDefs = []driversDef{
 					{TemplateName: "role"},
 				},
 			},
+
+			// INLINE POLICY
+			{
+				Action: "create", Entity: "inlinepolicy", DryRunUnsupported: true, ManualFuncDefinition: true,
+				RequiredParams: []param{
+					{TemplateName: "name"},
+				},
+				ExtraParams: []param{
+					{TemplateName: "user"},
+					{TemplateName: "group"},
+					{TemplateName: "role"},
+					{TemplateName: "document"},
+					{TemplateName: "effect"},
+					{TemplateName: "action"},
+					{TemplateName: "resource"},
+				},
+			},
+			{
+				Action: "delete", Entity: "inlinepolicy", DryRunUnsupported: true, ManualFuncDefinition: true,
+				RequiredParams: []param{
+					{TemplateName: "name"},
+				},
+				ExtraParams: []param{
+					{TemplateName: "user"},
+					{TemplateName: "group"},
+					{TemplateName: "role"},
+				},
+			},
 		},
 	},
 	{
@@ -1086,6 +1351,11 @@ var DriversDefs = []driversDef{
 					{TemplateName: "redirect-hostname"},
 					{TemplateName: "index-suffix"},
 					{TemplateName: "enforce-https"},
+					{TemplateName: "replication"},
+					{TemplateName: "replication-destination"},
+					{TemplateName: "replication-role"},
+					{TemplateName: "replication-prefix"},
+					{TemplateName: "replication-storageclass"},
 				},
 			},
 			{
@@ -1105,6 +1375,7 @@ var DriversDefs = []driversDef{
 				ExtraParams: []param{
 					{AwsField: "Key", TemplateName: "name", AwsType: "awsstr"},
 					{AwsField: "ACL", TemplateName: "acl", AwsType: "awsstr"},
+					{TemplateName: "concurrency"},
 				},
 			},
 			{
@@ -1221,6 +1492,8 @@ var DriversDefs = []driversDef{
 				},
 				ExtraParams: []param{
 					{TemplateName: "comment"},
+					{TemplateName: "weight"},
+					{TemplateName: "identifier"},
 				},
 			},
 			{
@@ -1232,6 +1505,56 @@ var DriversDefs = []driversDef{
 					{TemplateName: "value"},
 					{TemplateName: "ttl"},
 				},
+				ExtraParams: []param{
+					{TemplateName: "weight"},
+					{TemplateName: "identifier"},
+				},
+			},
+			{
+				Action: "create", Entity: cloud.HealthCheck, DryRunUnsupported: true, ApiMethod: "CreateHealthCheck", Input: "CreateHealthCheckInput", Output: "CreateHealthCheckOutput", OutputExtractor: "aws.StringValue(output.HealthCheck.Id)", IdempotencyToken: "CallerReference",
+				RequiredParams: []param{
+					{AwsField: "HealthCheckConfig.Type", TemplateName: "type", AwsType: "awsstr"},
+				},
+				ExtraParams: []param{
+					{AwsField: "HealthCheckConfig.FullyQualifiedDomainName", TemplateName: "host", AwsType: "awsstr"},
+					{AwsField: "HealthCheckConfig.IPAddress", TemplateName: "ip", AwsType: "awsstr"},
+					{AwsField: "HealthCheckConfig.Port", TemplateName: "port", AwsType: "awsint64"},
+					{AwsField: "HealthCheckConfig.ResourcePath", TemplateName: "path", AwsType: "awsstr"},
+					{AwsField: "HealthCheckConfig.SearchString", TemplateName: "search-string", AwsType: "awsstr"},
+					{AwsField: "HealthCheckConfig.RequestInterval", TemplateName: "interval", AwsType: "awsint64"},
+					{AwsField: "HealthCheckConfig.FailureThreshold", TemplateName: "failure-threshold", AwsType: "awsint64"},
+					{AwsField: "HealthCheckConfig.Regions", TemplateName: "regions", AwsType: "awsstringslice"},
+					{AwsField: "HealthCheckConfig.Inverted", TemplateName: "inverted", AwsType: "awsbool"},
+					{AwsField: "HealthCheckConfig.ChildHealthChecks", TemplateName: "children", AwsType: "awsstringslice"},
+					{AwsField: "HealthCheckConfig.HealthThreshold", TemplateName: "healthy-threshold", AwsType: "awsint64"},
+					{AwsField: "HealthCheckConfig.AlarmIdentifier.Name", TemplateName: "alarm", AwsType: "awsstr"},
+					{AwsField: "HealthCheckConfig.AlarmIdentifier.Region", TemplateName: "alarm-region", AwsType: "awsstr"},
+				},
+			},
+			{
+				Action: "update", Entity: cloud.HealthCheck, DryRunUnsupported: true, ApiMethod: "UpdateHealthCheck", Input: "UpdateHealthCheckInput", Output: "UpdateHealthCheckOutput",
+				RequiredParams: []param{
+					{AwsField: "HealthCheckId", TemplateName: "id", AwsType: "awsstr"},
+				},
+				ExtraParams: []param{
+					{AwsField: "FullyQualifiedDomainName", TemplateName: "host", AwsType: "awsstr"},
+					{AwsField: "IPAddress", TemplateName: "ip", AwsType: "awsstr"},
+					{AwsField: "Port", TemplateName: "port", AwsType: "awsint64"},
+					{AwsField: "ResourcePath", TemplateName: "path", AwsType: "awsstr"},
+					{AwsField: "SearchString", TemplateName: "search-string", AwsType: "awsstr"},
+					{AwsField: "FailureThreshold", TemplateName: "failure-threshold", AwsType: "awsint64"},
+					{AwsField: "Regions", TemplateName: "regions", AwsType: "awsstringslice"},
+					{AwsField: "Inverted", TemplateName: "inverted", AwsType: "awsbool"},
+					{AwsField: "ChildHealthChecks", TemplateName: "children", AwsType: "awsstringslice"},
+					{AwsField: "HealthThreshold", TemplateName: "healthy-threshold", AwsType: "awsint64"},
+					{AwsField: "HealthCheckVersion", TemplateName: "version", AwsType: "awsint64"},
+				},
+			},
+			{
+				Action: "delete", Entity: cloud.HealthCheck, DryRunUnsupported: true, ApiMethod: "DeleteHealthCheck", Input: "DeleteHealthCheckInput", Output: "DeleteHealthCheckOutput",
+				RequiredParams: []param{
+					{AwsField: "HealthCheckId", TemplateName: "id", AwsType: "awsstr"},
+				},
 			},
 		},
 	},
@@ -1266,6 +1589,32 @@ var DriversDefs = []driversDef{
 					{AwsField: "Qualifier", TemplateName: "version", AwsType: "awsstr"},
 				},
 			},
+			// STREAM
+			{
+				Action: "create", Entity: cloud.Stream, ManualFuncDefinition: true,
+				RequiredParams: []param{
+					{TemplateName: "name"},
+				},
+				ExtraParams: []param{
+					{TemplateName: "shards"},
+				},
+			},
+			{
+				Action: "update", Entity: cloud.Stream, ManualFuncDefinition: true,
+				RequiredParams: []param{
+					{TemplateName: "name"},
+					{TemplateName: "shards"},
+				},
+			},
+			{
+				Action: "delete", Entity: cloud.Stream, ManualFuncDefinition: true,
+				RequiredParams: []param{
+					{TemplateName: "name"},
+				},
+				ExtraParams: []param{
+					{TemplateName: "force"},
+				},
+			},
 		},
 	},
 	{
@@ -1361,7 +1710,22 @@ var DriversDefs = []driversDef{
 				Action: "update", Entity: cloud.Distribution, ManualFuncDefinition: true,
 				RequiredParams: []param{
 					{AwsField: "Id", TemplateName: "id", AwsType: "awsstr"},
-					{AwsField: "DistributionConfig.Enabled", TemplateName: "enable", AwsType: "awsbool"},
+				},
+				ExtraParams: []param{
+					{TemplateName: "certificate"},
+					{TemplateName: "compress"},
+					{TemplateName: "default-ttl"},
+					{TemplateName: "enable"},
+					{TemplateName: "forward-cookies"},
+					{TemplateName: "forward-queries"},
+					{TemplateName: "https-behaviour"},
+					{TemplateName: "max-ttl"},
+					{TemplateName: "min-ttl"},
+					{TemplateName: "origin-domain"},
+					{TemplateName: "origin-id"},
+					{TemplateName: "origin-path"},
+					{TemplateName: "path-pattern"},
+					{TemplateName: "target-origin"},
 				},
 			},
 			{
@@ -1376,7 +1740,7 @@ var DriversDefs = []driversDef{
 		Api: "cloudformation",
 		Drivers: []driver{
 			{
-				Action: "create", Entity: cloud.Stack, DryRunUnsupported: true, ApiMethod: "CreateStack", Input: "CreateStackInput", Output: "CreateStackOutput", OutputExtractor: "aws.StringValue(output.StackId)",
+				Action: "create", Entity: cloud.Stack, DryRunUnsupported: true, ApiMethod: "CreateStack", Input: "CreateStackInput", Output: "CreateStackOutput", OutputExtractor: "aws.StringValue(output.StackId)", IdempotencyToken: "ClientRequestToken",
 				RequiredParams: []param{
 					{AwsField: "StackName", TemplateName: "name", AwsType: "awsstr"},
 					{AwsField: "TemplateBody", TemplateName: "template-file", AwsType: "awsfiletostring"},
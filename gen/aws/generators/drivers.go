@@ -115,6 +115,18 @@ var APIPerTemplateDefName = map[string]string {
 {{- end }}
 }
 
+// APICallsPerTemplateDefName are the AWS API calls made by the generated drivers
+// (see ManualDriverAPICalls for the drivers written by hand)
+var APICallsPerTemplateDefName = map[string][]string {
+{{- range $, $service := . }}
+  {{- range $, $def := $service.Drivers }}
+  {{- if not $def.ManualFuncDefinition }}
+  "{{ $def.Action }}{{ $def.Entity }}": { "{{ $def.ApiMethod }}"{{ if $def.HasTagParams }}, "CreateTags"{{ end }} },
+  {{- end }}
+  {{- end }}
+{{- end }}
+}
+
 var AWSTemplatesDefinitions = map[string]template.Definition{
 {{- range $, $service := . }}
 {{- range $index, $def := $service.Drivers }}
@@ -253,7 +265,7 @@ func (d *{{ Title $service.Api }}Driver) {{ Title $def.Action }}_{{ Title $def.E
 		}
 	}
 
-	return nil, fmt.Errorf("dry run: {{ $def.Action }} {{ $def.Entity }}: %s", err)
+	return nil, fmt.Errorf("dry run: {{ $def.Action }} {{ $def.Entity }}: %w", err)
 }
 {{ end }}
 // This function was auto generated
@@ -284,13 +296,21 @@ func (d *{{ Title $service.Api }}Driver) {{ Title $def.Action }}_{{ Title $def.E
 			{{- end }}
 		{{- end }}
 	{{- end }}
+	{{- if ne $def.IdempotencyToken "" }}
+
+	// Idempotency token
+	err = setIdempotencyToken(params, "{{ $def.Action }} {{ $def.Entity }}", input, "{{ $def.IdempotencyToken }}")
+	if err != nil {
+		return nil, err
+	}
+	{{- end }}
 
 	start := time.Now()
 	var output *{{ $service.Api }}.{{ $def.Output }}
 	output, err = d.{{ $def.ApiMethod }}(input)
 	output = output
 	if err != nil {
-		return nil, fmt.Errorf("{{ $def.Action }} {{ $def.Entity }}: %s", err)
+		return nil, fmt.Errorf("{{ $def.Action }} {{ $def.Entity }}: %w", err)
 	}
 	d.logger.ExtraVerbosef("{{ $service.Api }}.{{ $def.ApiMethod }} call took %s", time.Since(start))
 
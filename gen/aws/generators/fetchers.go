@@ -32,6 +32,7 @@ func generateFetcherFuncs() {
 		"ToUpper":        strings.ToUpper,
 		"Join":           strings.Join,
 		"ApiToInterface": aws.ApiToInterface,
+		"InputType":      aws.InputType,
 	}).Parse(fetchersTempl)
 
 	if err != nil {
@@ -284,14 +285,48 @@ func (s *{{ Title $service.Name }}) FetchByType(t string) (*graph.Graph, error)
   }
 }
 
+func (s *{{ Title $service.Name }}) FetchResource(t, id string) (*graph.Graph, error) {
+	var fetched []interface{}
+  switch t {
+  {{- range $index, $fetcher := $service.Fetchers }}
+  case "{{ $fetcher.ResourceType }}":
+		{{- if $fetcher.IdsField }}
+		input := &{{ $fetcher.Input }}
+		input.{{ $fetcher.IdsField }} = []*string{awssdk.String(id)}
+		_, resources, err := s.fetch_{{ $fetcher.ResourceType }}_graph(input)
+		{{- else }}
+		_, resources, err := s.fetch_all_{{ $fetcher.ResourceType }}_graph()
+		{{- end }}
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resources {
+			fetched = append(fetched, r)
+		}
+  {{- end }}
+  default:
+    return nil, fmt.Errorf("aws {{ $service.Name }}: unsupported fetch for type %s", t)
+  }
+	return resourceGraph(t, id, fetched)
+}
+
 {{ range $index, $fetcher := $service.Fetchers }}
 {{- if not $fetcher.ManualFetcher }}
+{{- if $fetcher.IdsField }}
 func (s *{{ Title $service.Name }}) fetch_all_{{ $fetcher.ResourceType }}_graph() (*graph.Graph, []*{{ $fetcher.AWSType }}, error) {
+	return s.fetch_{{ $fetcher.ResourceType }}_graph(&{{ $fetcher.Input }})
+}
+
+func (s *{{ Title $service.Name }}) fetch_{{ $fetcher.ResourceType }}_graph(input *{{ InputType $fetcher.Input }}) (*graph.Graph, []*{{ $fetcher.AWSType }}, error) {
+{{- else }}
+func (s *{{ Title $service.Name }}) fetch_all_{{ $fetcher.ResourceType }}_graph() (*graph.Graph, []*{{ $fetcher.AWSType }}, error) {
+	input := &{{ $fetcher.Input }}
+{{- end }}
   g := graph.NewGraph()
 	var cloudResources []*{{ $fetcher.AWSType }}
 	{{- if $fetcher.Multipage }}
 	var badResErr error
-	err := s.{{ $fetcher.ApiMethod }}(&{{ $fetcher.Input }},
+	err := s.{{ $fetcher.ApiMethod }}(input,
 		func(out *{{ $fetcher.Output }}, lastPage bool) (shouldContinue bool) {
 			{{- if ne $fetcher.OutputsContainers "" }}
 			for _, all := range out.{{ $fetcher.OutputsContainers }} {
@@ -321,7 +356,7 @@ func (s *{{ Title $service.Name }}) fetch_all_{{ $fetcher.ResourceType }}_graph(
 	return g, cloudResources, badResErr
 	{{- else }}
 	
-  out, err := s.{{ ApiToInterface $fetcher.Api }}.{{ $fetcher.ApiMethod }}(&{{ $fetcher.Input }})
+  out, err := s.{{ ApiToInterface $fetcher.Api }}.{{ $fetcher.ApiMethod }}(input)
   if err != nil {
     return nil, cloudResources, err
   }
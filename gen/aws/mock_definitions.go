@@ -43,6 +43,10 @@ var mocksDefs = []*mockDef{
 			{FuncType: "list", AWSType: "ec2.InternetGateway", ApiMethod: "DescribeInternetGateways", Input: "ec2.DescribeInternetGatewaysInput", Output: "ec2.DescribeInternetGatewaysOutput", OutputsExtractor: "InternetGateways"},
 			{FuncType: "list", AWSType: "ec2.NatGateway", ApiMethod: "DescribeNatGateways", Input: "ec2.DescribeNatGatewaysInput", Output: "ec2.DescribeNatGatewaysOutput", OutputsExtractor: "NatGateways"},
 			{FuncType: "list", AWSType: "ec2.RouteTable", ApiMethod: "DescribeRouteTables", Input: "ec2.DescribeRouteTablesInput", Output: "ec2.DescribeRouteTablesOutput", OutputsExtractor: "RouteTables"},
+			{FuncType: "list", AWSType: "ec2.DhcpOptions", ApiMethod: "DescribeDhcpOptions", Input: "ec2.DescribeDhcpOptionsInput", Output: "ec2.DescribeDhcpOptionsOutput", OutputsExtractor: "DhcpOptions"},
+			{FuncType: "list", AWSType: "ec2.PlacementGroup", ApiMethod: "DescribePlacementGroups", Input: "ec2.DescribePlacementGroupsInput", Output: "ec2.DescribePlacementGroupsOutput", OutputsExtractor: "PlacementGroups"},
+			{FuncType: "list", AWSType: "ec2.Host", ApiMethod: "DescribeHosts", Input: "ec2.DescribeHostsInput", Output: "ec2.DescribeHostsOutput", OutputsExtractor: "Hosts"},
+			{FuncType: "list", AWSType: "ec2.NetworkAcl", ApiMethod: "DescribeNetworkAcls", Input: "ec2.DescribeNetworkAclsInput", Output: "ec2.DescribeNetworkAclsOutput", OutputsExtractor: "NetworkAcls"},
 			{FuncType: "list", AWSType: "ec2.AvailabilityZone", ApiMethod: "DescribeAvailabilityZones", Input: "ec2.DescribeAvailabilityZonesInput", Output: "ec2.DescribeAvailabilityZonesOutput", OutputsExtractor: "AvailabilityZones"},
 			{FuncType: "list", AWSType: "ec2.Image", ApiMethod: "DescribeImages", Input: "ec2.DescribeImagesInput", Output: "ec2.DescribeImagesOutput", OutputsExtractor: "Images"},
 			{FuncType: "list", AWSType: "ec2.ImportImageTask", ApiMethod: "DescribeImportImageTasks", Input: "ec2.DescribeImportImageTasksInput", Output: "ec2.DescribeImportImageTasksOutput", OutputsExtractor: "ImportImageTasks"},
@@ -57,6 +61,7 @@ var mocksDefs = []*mockDef{
 			{FuncType: "list", AWSType: "elbv2.TargetGroup", ApiMethod: "DescribeTargetGroups", Input: "elbv2.DescribeTargetGroupsInput", Output: "elbv2.DescribeTargetGroupsOutput", OutputsExtractor: "TargetGroups"},
 			{FuncType: "list", AWSType: "elbv2.Listener", Manual: true},
 			{FuncType: "list", AWSType: "elbv2.TargetHealthDescription", Manual: true, MockFieldType: "mapslice"},
+			{FuncType: "list", AWSType: "elbv2.TagDescription", Manual: true},
 		},
 	},
 	{
@@ -92,6 +97,8 @@ var mocksDefs = []*mockDef{
 			{FuncType: "list", AWSType: "s3.Bucket", Manual: true, MockFieldType: "mapslice"},
 			{FuncType: "list", AWSType: "s3.Object", Manual: true, MockFieldType: "mapslice"},
 			{FuncType: "list", AWSType: "s3.Grant", Manual: true, MockFieldType: "mapslice"},
+			{FuncType: "list", AWSType: "*s3.ReplicationConfiguration", MockField: "replications", Manual: true, MockFieldType: "map"},
+			{FuncType: "list", AWSType: "string", MockField: "policies", Manual: true, MockFieldType: "map"},
 		},
 	},
 	{
@@ -113,6 +120,7 @@ var mocksDefs = []*mockDef{
 		Funcs: []*mockFuncDef{
 			{FuncType: "list", AWSType: "route53.HostedZone", ApiMethod: "ListHostedZonesPages", Input: "route53.ListHostedZonesInput", Output: "route53.ListHostedZonesOutput", OutputsExtractor: "HostedZones", Multipage: true, NextPageMarker: "NextMarker"},
 			{FuncType: "list", AWSType: "route53.ResourceRecordSet", Manual: true, MockFieldType: "mapslice"},
+			{FuncType: "list", AWSType: "route53.HealthCheck", ApiMethod: "ListHealthChecksPages", Input: "route53.ListHealthChecksInput", Output: "route53.ListHealthChecksOutput", OutputsExtractor: "HealthChecks", Multipage: true, NextPageMarker: "NextMarker"},
 		},
 	},
 	{
@@ -0,0 +1,112 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/wallix/awless/cloud/rdf"
+	tstore "github.com/wallix/triplestore"
+)
+
+// propertyNodeClasses are the types of the nodes holding the structured properties of a
+// resource (ex: the rules of a security group), which only exist through the resource
+var propertyNodeClasses = map[string]bool{
+	rdf.NetFirewallRule:    true,
+	rdf.NetRoute:           true,
+	rdf.Grant:              true,
+	rdf.KeyValue:           true,
+	rdf.DistributionOrigin: true,
+	rdf.ReplicationRule:    true,
+}
+
+// Compact removes the dangling triples of the graph: triples of nodes no resource leads to
+// (ex: the rules of a refreshed security group), and relations to resources not in the graph.
+// It returns the number of triples removed
+func (g *Graph) Compact() int {
+	dangling := danglingTriples(g.store.Snapshot())
+	g.store.Remove(dangling...)
+	return len(dangling)
+}
+
+// CompactFile compacts the graph persisted in a file, also dropping its duplicate triples.
+// It returns the number of triples of the file before and after
+func CompactFile(path string) (before, after int, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	ts, err := NewDecoder(f).Decode()
+	f.Close()
+	if err != nil {
+		return 0, 0, err
+	}
+	g := NewGraph()
+	g.store.Add(ts...)
+	g.Compact()
+	b, err := g.Marshal()
+	if err != nil {
+		return 0, 0, err
+	}
+	if err = ioutil.WriteFile(path, b, 0600); err != nil {
+		return 0, 0, err
+	}
+	return len(ts), g.store.Snapshot().Count(), nil
+}
+
+func danglingTriples(snap tstore.RDFGraph) []tstore.Triple {
+	live := make(map[string]bool)
+	var queue []string
+	for _, tri := range snap.WithPredicate(rdf.RdfType) {
+		if class, _ := tri.Object().Resource(); !propertyNodeClasses[class] {
+			live[tri.Subject()] = true
+			queue = append(queue, tri.Subject())
+		}
+	}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, tri := range snap.WithSubject(node) {
+			if isRelationOrType(tri.Predicate()) {
+				continue
+			}
+			if obj, ok := tri.Object().Resource(); ok && !live[obj] {
+				live[obj] = true
+				queue = append(queue, obj)
+			}
+		}
+	}
+
+	var dangling []tstore.Triple
+	for _, tri := range snap.Triples() {
+		if !live[tri.Subject()] {
+			dangling = append(dangling, tri)
+			continue
+		}
+		if pred := tri.Predicate(); pred == rdf.ParentOf || pred == rdf.ApplyOn {
+			if obj, _ := tri.Object().Resource(); !live[obj] {
+				dangling = append(dangling, tri)
+			}
+		}
+	}
+	return dangling
+}
+
+func isRelationOrType(pred string) bool {
+	return pred == rdf.RdfType || pred == rdf.ParentOf || pred == rdf.ApplyOn
+}
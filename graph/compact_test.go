@@ -0,0 +1,76 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"net"
+	"testing"
+
+	"github.com/wallix/awless/cloud/rdf"
+	tstore "github.com/wallix/triplestore"
+)
+
+func TestCompact(t *testing.T) {
+	_, anywhere, _ := net.ParseCIDR("0.0.0.0/0")
+
+	g := NewGraph()
+	vpc := InitResource("vpc", "vpc_1")
+	sg := InitResource("securitygroup", "sg_1")
+	sg.Properties["InboundRules"] = []*FirewallRule{{Protocol: "tcp", PortRange: PortRange{FromPort: 22, ToPort: 22}, IPRanges: []*net.IPNet{anywhere}}}
+	bucket := InitResource("bucket", "b_1")
+	bucket.Properties["Grants"] = []*Grant{{Permission: "READ", Grantee: Grantee{GranteeID: "u_1", GranteeType: "CanonicalUser"}}}
+	g.AddResource(vpc, sg, bucket)
+	g.AddParentRelation(vpc, sg)
+	live := g.store.Snapshot().Count()
+
+	refreshed := NewGraph()
+	sg = InitResource("securitygroup", "sg_1")
+	sg.Properties["InboundRules"] = []*FirewallRule{{Protocol: "tcp", PortRange: PortRange{FromPort: 443, ToPort: 443}, IPRanges: []*net.IPNet{anywhere}}}
+	refreshed.AddResource(sg)
+	refreshed.AddParentRelation(vpc, sg)
+	g.RefreshResource("sg_1", refreshed) // leaves the previous rule behind
+	g.store.Add(
+		tstore.SubjPred("orphan", "cloud:name").StringLiteral("no type"),
+		tstore.SubjPred("vpc_1", rdf.ParentOf).Resource("subnet_gone"),
+	)
+
+	removed := g.Compact()
+	if removed < 2+4 { // orphan, relation to subnet_gone and at least the triples of the previous rule
+		t.Fatalf("got %d triples removed", removed)
+	}
+	if got, want := g.store.Snapshot().Count(), live; got != want {
+		t.Fatalf("got %d triples, want %d", got, want)
+	}
+
+	res, err := g.GetResource("securitygroup", "sg_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rules := res.Properties["InboundRules"].([]*FirewallRule); len(rules) != 1 || rules[0].PortRange.FromPort != 443 {
+		t.Fatalf("unexpected rules %v", rules)
+	}
+	res, err = g.GetResource("bucket", "b_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if grants := res.Properties["Grants"].([]*Grant); len(grants) != 1 || grants[0].Grantee.GranteeID != "u_1" {
+		t.Fatalf("unexpected grants %v", grants)
+	}
+	if got := g.Compact(); got != 0 {
+		t.Fatalf("got %d triples removed compacting again", got)
+	}
+}
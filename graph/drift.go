@@ -0,0 +1,119 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"reflect"
+	"sort"
+)
+
+// PropertyChange is a property whose value changed between a snapshot and the current state
+type PropertyChange struct {
+	Property          string
+	Snapshot, Current interface{}
+}
+
+// ResourceDrift lists the changes of a resource since a snapshot. A resource
+// removed or created since the snapshot has no current or no snapshot version
+type ResourceDrift struct {
+	Resource          *Resource
+	Snapshot, Current *Resource
+	Changes           []*PropertyChange
+}
+
+// Removed returns whether the resource no longer exists
+func (d *ResourceDrift) Removed() bool {
+	return d.Current == nil
+}
+
+// Created returns whether the resource did not exist in the snapshot
+func (d *ResourceDrift) Created() bool {
+	return d.Snapshot == nil
+}
+
+// Drift compares the resources of the given types in a snapshot graph and in the current
+// graph, returning the created, removed and changed resources sorted by type and id
+func Drift(snapshot, current *Graph, typs ...string) ([]*ResourceDrift, error) {
+	before, err := snapshot.GetAllResources(typs...)
+	if err != nil {
+		return nil, err
+	}
+	after, err := current.GetAllResources(typs...)
+	if err != nil {
+		return nil, err
+	}
+	currents := make(map[string]*Resource)
+	for _, res := range after {
+		currents[res.Type()+"/"+res.Id()] = res
+	}
+
+	var drifts []*ResourceDrift
+	for _, snap := range before {
+		key := snap.Type() + "/" + snap.Id()
+		cur, ok := currents[key]
+		if !ok {
+			drifts = append(drifts, &ResourceDrift{Resource: snap, Snapshot: snap})
+			continue
+		}
+		delete(currents, key)
+		if changes := propertyChanges(snap, cur); len(changes) > 0 {
+			drifts = append(drifts, &ResourceDrift{Resource: cur, Snapshot: snap, Current: cur, Changes: changes})
+		}
+	}
+	for _, cur := range currents {
+		drifts = append(drifts, &ResourceDrift{Resource: cur, Current: cur})
+	}
+
+	sort.Slice(drifts, func(i, j int) bool {
+		if drifts[i].Resource.Type() != drifts[j].Resource.Type() {
+			return drifts[i].Resource.Type() < drifts[j].Resource.Type()
+		}
+		return drifts[i].Resource.Id() < drifts[j].Resource.Id()
+	})
+	return drifts, nil
+}
+
+func propertyChanges(snap, cur *Resource) []*PropertyChange {
+	keys := make(map[string]bool)
+	for k := range snap.Properties {
+		keys[k] = true
+	}
+	for k := range cur.Properties {
+		keys[k] = true
+	}
+	var changes []*PropertyChange
+	for k := range keys {
+		before, after := snap.Properties[k], cur.Properties[k]
+		sortListProperty(before)
+		sortListProperty(after)
+		if !reflect.DeepEqual(before, after) {
+			changes = append(changes, &PropertyChange{Property: k, Snapshot: before, Current: after})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Property < changes[j].Property })
+	return changes
+}
+
+// sortListProperty sorts list values, whose order in the store is not significant
+func sortListProperty(v interface{}) {
+	switch list := v.(type) {
+	case []string:
+		sort.Strings(list)
+	case []*FirewallRule:
+		FirewallRules(list).Sort()
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"net"
+	"testing"
+)
+
+func TestDrift(t *testing.T) {
+	_, anywhere, _ := net.ParseCIDR("0.0.0.0/0")
+	_, private, _ := net.ParseCIDR("10.0.0.0/16")
+
+	snapshot := NewGraph()
+	inst := InitResource("instance", "inst_1")
+	inst.Properties["Type"] = "t2.micro"
+	inst.Properties["State"] = "running"
+	sg := InitResource("securitygroup", "sg_1")
+	sg.Properties["InboundRules"] = []*FirewallRule{
+		{Protocol: "tcp", PortRange: PortRange{FromPort: 443, ToPort: 443}, IPRanges: []*net.IPNet{anywhere}},
+		{Protocol: "tcp", PortRange: PortRange{FromPort: 22, ToPort: 22}, IPRanges: []*net.IPNet{private}},
+	}
+	snapshot.AddResource(inst, sg, InitResource("instance", "inst_removed"), InitResource("subnet", "sub_1"))
+
+	current := NewGraph()
+	inst = InitResource("instance", "inst_1")
+	inst.Properties["Type"] = "t2.large"
+	inst.Properties["State"] = "running"
+	sg = InitResource("securitygroup", "sg_1")
+	sg.Properties["InboundRules"] = []*FirewallRule{ // same rules, another order
+		{Protocol: "tcp", PortRange: PortRange{FromPort: 22, ToPort: 22}, IPRanges: []*net.IPNet{private}},
+		{Protocol: "tcp", PortRange: PortRange{FromPort: 443, ToPort: 443}, IPRanges: []*net.IPNet{anywhere}},
+	}
+	current.AddResource(inst, sg, InitResource("instance", "inst_created"), InitResource("subnet", "sub_1"))
+
+	drifts, err := Drift(snapshot, current, "instance", "securitygroup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(drifts), 3; got != want {
+		t.Fatalf("got %d drifts, want %d: %v", got, want, drifts)
+	}
+	if got, want := drifts[0].Resource.Id(), "inst_1"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := len(drifts[0].Changes), 1; got != want {
+		t.Fatalf("got %d changes, want %d", got, want)
+	}
+	if c := drifts[0].Changes[0]; c.Property != "Type" || c.Snapshot != "t2.micro" || c.Current != "t2.large" {
+		t.Fatalf("unexpected change %+v", c)
+	}
+	if got, want := drifts[1].Resource.Id(), "inst_created"; got != want || !drifts[1].Created() {
+		t.Fatalf("got %s (created: %t), want created %s", got, drifts[1].Created(), want)
+	}
+	if got, want := drifts[2].Resource.Id(), "inst_removed"; got != want || !drifts[2].Removed() {
+		t.Fatalf("got %s (removed: %t), want removed %s", got, drifts[2].Removed(), want)
+	}
+}
@@ -89,6 +89,11 @@ func BuildTagValueFilterFunc(value string) FilterFn {
 	}
 }
 
+// Or returns a filter matching the resources matched by any of the filters
+func Or(filters ...FilterFn) FilterFn {
+	return applyOr(filters...)
+}
+
 func applyAnd(filters ...FilterFn) FilterFn {
 	return func(r *Resource) bool {
 		include := true
@@ -0,0 +1,95 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	tstore "github.com/wallix/triplestore"
+)
+
+// FormatVersion is the version of the format of persisted graphs. Graphs persisted
+// before versioning (i.e. without header) are read as version 1
+const FormatVersion = 2
+
+// formatHeader prefixes persisted graphs, followed by the format version and a newline
+const formatHeader = "awless-graph:"
+
+// ErrUnsupportedFormat is returned when loading a graph persisted by a newer awless
+var ErrUnsupportedFormat = errors.New("unsupported graph format")
+
+type migration func([]tstore.Triple) ([]tstore.Triple, error)
+
+// migrations upgrade the triples of a graph persisted with a version to the next one
+var migrations = map[int]migration{
+	// version 2 only introduced the header
+	1: func(ts []tstore.Triple) ([]tstore.Triple, error) { return ts, nil },
+}
+
+func writeFormatHeader(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "%s%d\n", formatHeader, FormatVersion)
+	return err
+}
+
+type versionedDecoder struct {
+	r io.Reader
+}
+
+// NewDecoder returns a decoder of persisted graphs triples, whatever their format version
+func NewDecoder(r io.Reader) tstore.Decoder {
+	return &versionedDecoder{r: r}
+}
+
+// Decode reads the format version of the persisted graph, decodes its triples
+// and migrates them to the current version
+func (d *versionedDecoder) Decode() ([]tstore.Triple, error) {
+	br := bufio.NewReader(d.r)
+	version := 1
+	if prefix, _ := br.Peek(len(formatHeader)); bytes.Equal(prefix, []byte(formatHeader)) {
+		line, err := br.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("read graph format header: %s", err)
+		}
+		if version, err = strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, formatHeader))); err != nil {
+			return nil, fmt.Errorf("read graph format header: invalid version in '%s'", strings.TrimSpace(line))
+		}
+	}
+	if version > FormatVersion {
+		return nil, fmt.Errorf("%w: graph persisted in format version %d, this awless reads up to version %d. Upgrade awless or run `awless sync` to overwrite your local model", ErrUnsupportedFormat, version, FormatVersion)
+	}
+
+	ts, err := tstore.NewBinaryDecoder(br).Decode()
+	if err != nil {
+		return nil, err
+	}
+	for ; version < FormatVersion; version++ {
+		migrate, ok := migrations[version]
+		if !ok {
+			return nil, fmt.Errorf("%w: no migration of graph format version %d", ErrUnsupportedFormat, version)
+		}
+		if ts, err = migrate(ts); err != nil {
+			return nil, fmt.Errorf("migrate graph format version %d: %s", version, err)
+		}
+	}
+	return ts, nil
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package graph
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	tstore "github.com/wallix/triplestore"
+)
+
+func TestGraphFormatVersioning(t *testing.T) {
+	g := NewGraph()
+	g.AddResource(InitResource("instance", "inst_1"), InitResource("subnet", "sub_1"))
+	g.AddParentRelation(InitResource("subnet", "sub_1"), InitResource("instance", "inst_1"))
+
+	// snapshot persisted before format versioning: binary triples without header
+	var v1 bytes.Buffer
+	if err := tstore.NewBinaryEncoder(&v1).Encode(g.store.Snapshot().Triples()...); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("load v1 snapshot", func(t *testing.T) {
+		loaded := NewGraph()
+		if err := loaded.Unmarshal(v1.Bytes()); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := tstore.Triples(loaded.store.Snapshot().Triples()), tstore.Triples(g.store.Snapshot().Triples()); !got.Equal(want) {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+	})
+
+	t.Run("persist with header", func(t *testing.T) {
+		b, err := g.Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.HasPrefix(b, []byte("awless-graph:2\n")) {
+			t.Fatalf("got %q, want version header", b[:20])
+		}
+
+		loaded := NewGraph()
+		if err := loaded.UnmarshalMultiple(bytes.NewReader(b), bytes.NewReader(v1.Bytes())); err != nil {
+			t.Fatal(err)
+		}
+		res, err := loaded.GetAllResources("instance", "subnet")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := len(res), 2; got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+	})
+
+	t.Run("refuse newer format", func(t *testing.T) {
+		v3 := io.MultiReader(bytes.NewBufferString("awless-graph:3\n"), bytes.NewReader(v1.Bytes()))
+		if err := NewGraph().UnmarshalMultiple(v3); !errors.Is(err, ErrUnsupportedFormat) {
+			t.Fatalf("got %v, want unsupported format error", err)
+		}
+		if err := NewGraph().Unmarshal([]byte("awless-graph:two\n")); err == nil {
+			t.Fatal("expected error with invalid version")
+		}
+	})
+}
@@ -40,7 +40,8 @@ func NewGraphFromFile(filepath string) (*Graph, error) {
 	if err != nil {
 		return g, err
 	}
-	ts, err := tstore.NewBinaryDecoder(f).Decode()
+	defer f.Close()
+	ts, err := NewDecoder(f).Decode()
 	if err != nil {
 		return g, err
 	}
@@ -64,6 +65,52 @@ func (g *Graph) AddGraph(other *Graph) {
 	g.store.Add(other.store.Snapshot().Triples()...)
 }
 
+// RefreshResource replaces the properties and parent of the resource of the given id
+// with the ones found in the other graph, then adds the relations of the other graph
+func (g *Graph) RefreshResource(id string, other *Graph) {
+	snap := g.store.Snapshot()
+	var stale []tstore.Triple
+	for _, tri := range snap.WithSubject(id) {
+		if pred := tri.Predicate(); pred != rdf.ParentOf && pred != rdf.ApplyOn {
+			stale = append(stale, tri)
+		}
+	}
+	stale = append(stale, snap.WithPredObj(rdf.ParentOf, tstore.Resource(id))...)
+	g.store.Remove(stale...)
+	g.AddGraph(other)
+}
+
+// Subgraph returns the resources of the given types with their properties,
+// and the relations between them
+func (g *Graph) Subgraph(typs ...string) (*Graph, error) {
+	sub := NewGraph()
+	resources, err := g.GetAllResources(typs...)
+	if err != nil {
+		return sub, err
+	}
+
+	selected := make(map[string]bool)
+	for _, res := range resources {
+		selected[res.Id()] = true
+	}
+
+	snap := g.store.Snapshot()
+	for _, res := range resources {
+		if err := sub.AddResource(res); err != nil {
+			return sub, err
+		}
+		for _, pred := range []string{rdf.ParentOf, rdf.ApplyOn} {
+			for _, tri := range snap.WithSubjPred(res.Id(), pred) {
+				if id, ok := tri.Object().Resource(); ok && selected[id] {
+					sub.store.Add(tri)
+				}
+			}
+		}
+	}
+
+	return sub, nil
+}
+
 func (g *Graph) AddParentRelation(parent, child *Resource) error {
 	return g.addRelation(parent, child, rdf.ParentOf)
 }
@@ -180,7 +227,7 @@ func (g *Graph) Accept(v Visitor) error {
 }
 
 func (g *Graph) Unmarshal(data []byte) error {
-	ts, err := tstore.NewBinaryDecoder(bytes.NewReader(data)).Decode()
+	ts, err := NewDecoder(bytes.NewReader(data)).Decode()
 	if err != nil {
 		return err
 	}
@@ -189,7 +236,7 @@ func (g *Graph) Unmarshal(data []byte) error {
 }
 
 func (g *Graph) UnmarshalMultiple(readers ...io.Reader) error {
-	dec := tstore.NewDatasetDecoder(tstore.NewBinaryDecoder, readers...)
+	dec := tstore.NewDatasetDecoder(NewDecoder, readers...)
 	ts, err := dec.Decode()
 	if err != nil {
 		return err
@@ -208,6 +255,9 @@ func (g *Graph) MustMarshal() string {
 
 func (g *Graph) Marshal() ([]byte, error) {
 	var buff bytes.Buffer
+	if err := writeFormatHeader(&buff); err != nil {
+		return nil, err
+	}
 	err := tstore.NewBinaryEncoder(&buff).Encode(g.store.Snapshot().Triples()...)
 	return buff.Bytes(), err
 }
@@ -63,3 +63,65 @@ func TestAddGraphRelation(t *testing.T) {
 		}
 	})
 }
+
+func TestSubgraph(t *testing.T) {
+	g := NewGraph()
+	region, vpc, inst, user := InitResource("region", "eu-west-1"), InitResource("vpc", "vpc_1"), InitResource("instance", "inst_1"), InitResource("user", "usr_1")
+	vpc.Properties["Name"] = "main"
+	g.AddResource(region, vpc, inst, user)
+	g.AddParentRelation(region, vpc)
+	g.AddParentRelation(vpc, inst)
+	g.AddParentRelation(region, user)
+	g.AddAppliesOnRelation(user, inst)
+
+	sub, err := g.Subgraph("region", "vpc", "instance")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expTriples := tstore.Triples([]tstore.Triple{
+		tstore.SubjPred("eu-west-1", "rdf:type").Resource("cloud-owl:Region"),
+		tstore.SubjPred("vpc_1", "rdf:type").Resource("cloud-owl:Vpc"),
+		tstore.SubjPred("vpc_1", "cloud:name").StringLiteral("main"),
+		tstore.SubjPred("inst_1", "rdf:type").Resource("cloud-owl:Instance"),
+		tstore.SubjPred("eu-west-1", "cloud-rel:parentOf").Resource("vpc_1"),
+		tstore.SubjPred("vpc_1", "cloud-rel:parentOf").Resource("inst_1"),
+	})
+	if got, want := tstore.Triples(sub.store.Snapshot().Triples()), expTriples; !got.Equal(want) {
+		t.Fatalf("got\n%q\nwant\n%q\n", got, want)
+	}
+}
+
+func TestRefreshResource(t *testing.T) {
+	g := NewGraph()
+	subnet1, subnet2, inst, sg, vol := InitResource("subnet", "subnet_1"), InitResource("subnet", "subnet_2"), InitResource("instance", "inst_1"), InitResource("securitygroup", "sg_1"), InitResource("volume", "vol_1")
+	inst.Properties["Name"] = "old"
+	inst.Properties["State"] = "running"
+	g.AddResource(subnet1, subnet2, inst, sg, vol)
+	g.AddParentRelation(subnet1, inst)
+	g.AddParentRelation(inst, vol)
+	g.AddAppliesOnRelation(sg, inst)
+
+	fetched := NewGraph()
+	refreshed := InitResource("instance", "inst_1")
+	refreshed.Properties["Name"] = "new"
+	fetched.AddResource(refreshed)
+	fetched.AddParentRelation(subnet2, refreshed)
+
+	g.RefreshResource("inst_1", fetched)
+
+	expTriples := tstore.Triples([]tstore.Triple{
+		tstore.SubjPred("subnet_1", "rdf:type").Resource("cloud-owl:Subnet"),
+		tstore.SubjPred("subnet_2", "rdf:type").Resource("cloud-owl:Subnet"),
+		tstore.SubjPred("sg_1", "rdf:type").Resource("cloud-owl:Securitygroup"),
+		tstore.SubjPred("vol_1", "rdf:type").Resource("cloud-owl:Volume"),
+		tstore.SubjPred("inst_1", "rdf:type").Resource("cloud-owl:Instance"),
+		tstore.SubjPred("inst_1", "cloud:name").StringLiteral("new"),
+		tstore.SubjPred("subnet_2", "cloud-rel:parentOf").Resource("inst_1"),
+		tstore.SubjPred("inst_1", "cloud-rel:parentOf").Resource("vol_1"),
+		tstore.SubjPred("sg_1", "cloud-rel:applyOn").Resource("inst_1"),
+	})
+	if got, want := tstore.Triples(g.store.Snapshot().Triples()), expTriples; !got.Equal(want) {
+		t.Fatalf("got\n%q\nwant\n%q\n", got, want)
+	}
+}
@@ -80,6 +80,17 @@ func getPropertyValue(gph tstore.RDFGraph, propObj tstore.Object, prop string) (
 			return nil, err
 		}
 		return o, nil
+	case definedBy == rdf.RdfsList && dataType == rdf.ReplicationRule:
+		id, ok := propObj.Resource()
+		if !ok {
+			return nil, fmt.Errorf("get property '%s': object not resource identifier", prop)
+		}
+		r := &ReplicationRule{}
+		err := r.unmarshalFromTriples(gph, id)
+		if err != nil {
+			return nil, err
+		}
+		return r, nil
 	default:
 		return "", fmt.Errorf("get property value: %s is neither literal nor class, nor list", definedBy)
 	}
@@ -183,6 +183,16 @@ func (res *Resource) marshalFullRDF() ([]tstore.Triple, error) {
 					triples = append(triples, tstore.SubjPred(res.id, propId).Resource(keyValId))
 					triples = append(triples, o.marshalToTriples(keyValId)...)
 				}
+			case rdf.ReplicationRule:
+				list, ok := value.([]*ReplicationRule)
+				if !ok {
+					return triples, fmt.Errorf("resource %s: marshalling property '%s': expected a replication rule slice, got a %T", res, key, value)
+				}
+				for _, r := range list {
+					ruleId := randomRdfId()
+					triples = append(triples, tstore.SubjPred(res.id, propId).Resource(ruleId))
+					triples = append(triples, r.marshalToTriples(ruleId)...)
+				}
 			case rdf.Grant:
 			default:
 				return triples, fmt.Errorf("resource %s: marshalling property '%s': unexpected rdfs:DataType: %s", res, key, dataType)
@@ -274,6 +284,13 @@ func (res *Resource) unmarshalFullRdf(gph tstore.RDFGraph) error {
 				}
 				list = append(list, propVal.(*DistributionOrigin))
 				res.Properties[propKey] = list
+			case rdf.ReplicationRule:
+				list, ok := res.Properties[propKey].([]*ReplicationRule)
+				if !ok {
+					list = []*ReplicationRule{}
+				}
+				list = append(list, propVal.(*ReplicationRule))
+				res.Properties[propKey] = list
 			default:
 				return fmt.Errorf("unmarshalling property: unexpected datatype %s", dataType)
 			}
@@ -196,6 +196,7 @@ func TestMarshalUnmarshalFirewallRules(t *testing.T) {
 		}).prop(
 		"OutboundRules", []*FirewallRule{
 			{PortRange: PortRange{Any: true}, Protocol: "icmp", IPRanges: []*net.IPNet{localhost, {IP: net.ParseIP("::1"), Mask: net.CIDRMask(128, 128)}}},
+			{PortRange: PortRange{FromPort: 443, ToPort: 443}, Protocol: "tcp", PrefixLists: []string{"pl-63a5400a", "pl-02cd2c6b"}},
 		}).build()
 	g := NewGraph()
 	triples, err := r.marshalFullRDF()
@@ -218,6 +219,41 @@ func TestMarshalUnmarshalFirewallRules(t *testing.T) {
 	}
 }
 
+func TestMarshalUnmarshalNetworkACLRules(t *testing.T) {
+	_, all, _ := net.ParseCIDR("0.0.0.0/0")
+	_, subnetcidr, _ := net.ParseCIDR("10.192.24.0/24")
+	r := testResource("acl-1", "networkacl").prop(properties.ID, "acl-1").prop(
+		"InboundRules", []*FirewallRule{
+			{Number: 32767, Action: "deny", PortRange: PortRange{Any: true}, Protocol: "any", IPRanges: []*net.IPNet{all}},
+			{Number: 200, Action: "deny", PortRange: PortRange{FromPort: 22, ToPort: 22}, Protocol: "tcp", IPRanges: []*net.IPNet{all}},
+			{Number: 100, Action: "allow", PortRange: PortRange{FromPort: 22, ToPort: 22}, Protocol: "tcp", IPRanges: []*net.IPNet{subnetcidr}},
+		}).build()
+	g := NewGraph()
+	triples, err := r.marshalFullRDF()
+	if err != nil {
+		t.Fatal(err)
+	}
+	g.store.Add(triples...)
+	rawRes := InitResource(r.Type(), r.Id())
+	if err = rawRes.unmarshalFullRdf(g.store.Snapshot()); err != nil {
+		t.Fatal(err)
+	}
+	rules := rawRes.Properties["InboundRules"].([]*FirewallRule)
+	FirewallRules(rules).Sort()
+	FirewallRules(r.Properties["InboundRules"].([]*FirewallRule)).Sort()
+
+	if got, want := rawRes, r; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got\n%#v\nwant\n%#v\n", got, want)
+	}
+	var numbers []int64
+	for _, rule := range rules {
+		numbers = append(numbers, rule.Number)
+	}
+	if got, want := numbers, []int64{100, 200, 32767}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
 func TestMarshalUnmarshalRouteTables(t *testing.T) {
 	_, subnet1cidr, _ := net.ParseCIDR("10.192.24.0/24")
 	_, subnet2cidr, _ := net.ParseCIDR("10.20.24.0/24")
@@ -49,6 +49,34 @@ func NatGw(id string) *rBuilder {
 	return new("natgateway", id).Prop(properties.ID, id)
 }
 
+func DhcpOptions(id string) *rBuilder {
+	return new("dhcpoptions", id).Prop(properties.ID, id)
+}
+
+func NetworkAcl(id string) *rBuilder {
+	return new("networkacl", id).Prop(properties.ID, id)
+}
+
+func PlacementGroup(id string) *rBuilder {
+	return new("placementgroup", id).Prop(properties.ID, id)
+}
+
+func DedicatedHost(id string) *rBuilder {
+	return new("dedicatedhost", id).Prop(properties.ID, id)
+}
+
+func Fleet(id string) *rBuilder {
+	return new("fleet", id).Prop(properties.ID, id)
+}
+
+func ContainerImage(id string) *rBuilder {
+	return new("containerimage", id).Prop(properties.ID, id)
+}
+
+func PrefixList(id string) *rBuilder {
+	return new("prefixlist", id).Prop(properties.ID, id)
+}
+
 func RouteTable(id string) *rBuilder {
 	return new("routetable", id).Prop(properties.ID, id)
 }
@@ -97,6 +125,10 @@ func Record(id string) *rBuilder {
 	return new("record", id).Prop(properties.ID, id)
 }
 
+func HealthCheck(id string) *rBuilder {
+	return new("healthcheck", id).Prop(properties.ID, id)
+}
+
 func ScalingGroup(id string) *rBuilder {
 	return new("scalinggroup", id).Prop(properties.ID, id)
 }
@@ -121,6 +153,14 @@ func Function(id string) *rBuilder {
 	return new("function", id).Prop(properties.ID, id)
 }
 
+func Stream(id string) *rBuilder {
+	return new("stream", id).Prop(properties.ID, id)
+}
+
+func DeliveryStream(id string) *rBuilder {
+	return new("deliverystream", id).Prop(properties.ID, id)
+}
+
 func Alarm(id string) *rBuilder {
 	return new("alarm", id).Prop(properties.ID, id)
 }
@@ -161,6 +201,26 @@ func ContainerInstance(id string) *rBuilder {
 	return new("containerinstance", id).Prop(properties.ID, id)
 }
 
+func Database(id string) *rBuilder {
+	return new("database", id).Prop(properties.ID, id)
+}
+
+func DbSubnetGroup(id string) *rBuilder {
+	return new("dbsubnetgroup", id).Prop(properties.ID, id)
+}
+
+func Volume(id string) *rBuilder {
+	return new("volume", id).Prop(properties.ID, id)
+}
+
+func Finding(id string) *rBuilder {
+	return new("finding", id).Prop(properties.ID, id)
+}
+
+func Snapshot(id string) *rBuilder {
+	return new("snapshot", id).Prop(properties.ID, id)
+}
+
 func (b *rBuilder) Prop(key string, value interface{}) *rBuilder {
 	b.props[key] = value
 	return b
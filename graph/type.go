@@ -34,8 +34,12 @@ func (rules FirewallRules) Sort() {
 		sort.Slice(r.IPRanges, func(i int, j int) bool {
 			return r.IPRanges[i].String() < r.IPRanges[j].String()
 		})
+		sort.Strings(r.PrefixLists)
 	}
 	sort.Slice(rules, func(i int, j int) bool {
+		if rules[i].Number != rules[j].Number {
+			return rules[i].Number < rules[j].Number
+		}
 		return rules[i].String() < rules[j].String()
 	})
 }
@@ -44,6 +48,14 @@ type FirewallRule struct {
 	PortRange PortRange    `predicate:"net:portRange"`
 	Protocol  string       `predicate:"net:protocol"`
 	IPRanges  []*net.IPNet `predicate:"net:cidr"` // IPv4 or IPv6 range
+
+	// Network ACL rules only: rules are evaluated in increasing number order
+	// and the first matching rule applies its action (allow or deny)
+	Number int64
+	Action string
+
+	// Security group rules only: ids of the managed prefix lists the rule applies to
+	PrefixLists []string
 }
 
 func (r *FirewallRule) Contains(ip string) bool {
@@ -57,6 +69,12 @@ func (r *FirewallRule) Contains(ip string) bool {
 }
 
 func (r *FirewallRule) String() string {
+	if r.Number > 0 {
+		return fmt.Sprintf("Number:%d; Action:%s; PortRange:%+v; Protocol:%s; IPRanges:%+v", r.Number, r.Action, r.PortRange, r.Protocol, r.IPRanges)
+	}
+	if len(r.PrefixLists) > 0 {
+		return fmt.Sprintf("PortRange:%+v; Protocol:%s; IPRanges:%+v; PrefixLists:%v", r.PortRange, r.Protocol, r.IPRanges, r.PrefixLists)
+	}
 	return fmt.Sprintf("PortRange:%+v; Protocol:%s; IPRanges:%+v", r.PortRange, r.Protocol, r.IPRanges)
 }
 
@@ -64,6 +82,15 @@ func (r *FirewallRule) marshalToTriples(id string) []tstore.Triple {
 	var triples []tstore.Triple
 	triples = append(triples, tstore.SubjPred(id, rdf.RdfType).Resource(rdf.NetFirewallRule))
 	triples = append(triples, tstore.TriplesFromStruct(id, r)...)
+	if r.Number > 0 {
+		triples = append(triples, tstore.SubjPred(id, rdf.NetRuleNumber).IntegerLiteral(int(r.Number)))
+	}
+	if r.Action != "" {
+		triples = append(triples, tstore.SubjPred(id, rdf.NetRuleAction).StringLiteral(r.Action))
+	}
+	for _, pl := range r.PrefixLists {
+		triples = append(triples, tstore.SubjPred(id, rdf.NetRulePrefixList).StringLiteral(pl))
+	}
 	return triples
 }
 
@@ -98,6 +125,27 @@ func (r *FirewallRule) unmarshalFromTriples(g tstore.RDFGraph, id string) error
 		}
 		r.IPRanges = append(r.IPRanges, cidr)
 	}
+
+	if numberTs := g.WithSubjPred(id, rdf.NetRuleNumber); len(numberTs) > 0 {
+		number, err := tstore.ParseInteger(numberTs[0].Object())
+		if err != nil {
+			return fmt.Errorf("unmarshal firewall rule: number: %s", err)
+		}
+		r.Number = int64(number)
+	}
+	if actionTs := g.WithSubjPred(id, rdf.NetRuleAction); len(actionTs) > 0 {
+		if r.Action, err = extractUniqueLiteralTextFromTriples(actionTs); err != nil {
+			return fmt.Errorf("unmarshal firewall rule: action: %s", err)
+		}
+	}
+	for _, plT := range g.WithSubjPred(id, rdf.NetRulePrefixList) {
+		pl, err := tstore.ParseString(plT.Object())
+		if err != nil {
+			return fmt.Errorf("unmarshal firewall rule: prefix list: %s", err)
+		}
+		r.PrefixLists = append(r.PrefixLists, pl)
+	}
+	sort.Strings(r.PrefixLists)
 	return nil
 }
 
@@ -431,6 +479,66 @@ func (o *DistributionOrigin) unmarshalFromTriples(gph tstore.RDFGraph, id string
 	return nil
 }
 
+type ReplicationRule struct {
+	ID           string `predicate:"cloud:id"`
+	Status       string `predicate:"cloud:state"`
+	Prefix       string `predicate:"cloud:pathPrefix"`
+	Destination  string `predicate:"cloud:bucketName"`
+	StorageClass string `predicate:"cloud:class"`
+}
+
+func (r *ReplicationRule) String() string {
+	var elems []string
+	if r.ID != "" {
+		elems = append(elems, "ID:"+r.ID)
+	}
+	elems = append(elems, "Destination:"+r.Destination)
+	if r.Prefix != "" {
+		elems = append(elems, "Prefix:"+r.Prefix)
+	}
+	if r.StorageClass != "" {
+		elems = append(elems, "StorageClass:"+r.StorageClass)
+	}
+	if r.Status != "" {
+		elems = append(elems, "Status:"+r.Status)
+	}
+	return fmt.Sprintf("[%s]", strings.Join(elems, ","))
+}
+
+func (r *ReplicationRule) marshalToTriples(id string) []tstore.Triple {
+	var triples []tstore.Triple
+
+	triples = append(triples, tstore.SubjPred(id, rdf.RdfType).Resource(rdf.ReplicationRule))
+	triples = append(triples, tstore.TriplesFromStruct(id, r)...)
+
+	return triples
+}
+
+func (r *ReplicationRule) unmarshalFromTriples(gph tstore.RDFGraph, id string) error {
+	var err error
+	r.ID, err = extractUniqueLiteralTextFromGraph(gph, id, rdf.ID)
+	if err != nil {
+		return fmt.Errorf("unmarshal ReplicationRule: extract id: %s", err)
+	}
+	r.Status, err = extractUniqueLiteralTextFromGraph(gph, id, rdf.State)
+	if err != nil {
+		return fmt.Errorf("unmarshal ReplicationRule: extract Status: %s", err)
+	}
+	r.Prefix, err = extractUniqueLiteralTextFromGraph(gph, id, rdf.PathPrefix)
+	if err != nil {
+		return fmt.Errorf("unmarshal ReplicationRule: extract Prefix: %s", err)
+	}
+	r.Destination, err = extractUniqueLiteralTextFromGraph(gph, id, rdf.Bucket)
+	if err != nil {
+		return fmt.Errorf("unmarshal ReplicationRule: extract Destination: %s", err)
+	}
+	r.StorageClass, err = extractUniqueLiteralTextFromGraph(gph, id, rdf.Class)
+	if err != nil {
+		return fmt.Errorf("unmarshal ReplicationRule: extract StorageClass: %s", err)
+	}
+	return nil
+}
+
 func extractUniqueLiteralTextFromGraph(gph tstore.RDFGraph, subj, pred string) (string, error) {
 	ts := gph.WithSubjPred(subj, pred)
 	if len(ts) != 1 {
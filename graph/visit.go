@@ -37,10 +37,15 @@ func VisitorCollectFunc(collect *[]*Resource) visitEachFunc {
 	}
 }
 
+// DefaultMaxDepth bounds the traversals of visitors with no explicit MaxDepth,
+// so that densely connected graphs cannot make them explode
+const DefaultMaxDepth = 64
+
 type ParentsVisitor struct {
 	From        *Resource
 	Each        visitEachFunc
 	IncludeFrom bool
+	MaxDepth    int
 }
 
 func (v *ParentsVisitor) Visit(g *Graph) error {
@@ -49,13 +54,14 @@ func (v *ParentsVisitor) Visit(g *Graph) error {
 		return err
 	}
 
-	return visitBottomUp(g.store.Snapshot(), startNode, foreach)
+	return walk(g.store.Snapshot(), startNode, parentNodes, foreach, v.MaxDepth)
 }
 
 type ChildrenVisitor struct {
 	From        *Resource
 	Each        visitEachFunc
 	IncludeFrom bool
+	MaxDepth    int
 }
 
 func (v *ChildrenVisitor) Visit(g *Graph) error {
@@ -63,7 +69,24 @@ func (v *ChildrenVisitor) Visit(g *Graph) error {
 	if err != nil {
 		return err
 	}
-	return visitTopDown(g.store.Snapshot(), startNode, foreach)
+	return walk(g.store.Snapshot(), startNode, childNodes, foreach, v.MaxDepth)
+}
+
+// AppliedOnVisitor visits transitively the resources the From resource applies on
+// (ex: instance -> securitygroup -> securitygroup ...)
+type AppliedOnVisitor struct {
+	From        *Resource
+	Each        visitEachFunc
+	IncludeFrom bool
+	MaxDepth    int
+}
+
+func (v *AppliedOnVisitor) Visit(g *Graph) error {
+	startNode, foreach, err := prepareRDFVisit(g, v.From, v.Each, v.IncludeFrom)
+	if err != nil {
+		return err
+	}
+	return walk(g.store.Snapshot(), startNode, appliedOnNodes, foreach, v.MaxDepth)
 }
 
 type SiblingsVisitor struct {
@@ -103,58 +126,72 @@ func prepareRDFVisit(g *Graph, root *Resource, each visitEachFunc, includeRoot b
 	return rootNode, foreach, nil
 }
 
-func visitTopDown(snap tstore.RDFGraph, root string, each func(tstore.RDFGraph, string, int) error, distances ...int) error {
-	var dist int
-	if len(distances) > 0 {
-		dist = distances[0]
-	}
+type nextNodesFunc func(snap tstore.RDFGraph, node string) ([]string, error)
 
-	if err := each(snap, root, dist); err != nil {
-		return err
+func childNodes(snap tstore.RDFGraph, node string) ([]string, error) {
+	return objectNodes(snap, node, rdf.ParentOf)
+}
+
+func parentNodes(snap tstore.RDFGraph, node string) ([]string, error) {
+	var parents []string
+	for _, tri := range snap.WithPredObj(rdf.ParentOf, tstore.Resource(node)) {
+		parents = append(parents, tri.Subject())
 	}
+	return parents, nil
+}
 
-	triples := snap.WithSubjPred(root, rdf.ParentOf)
+func appliedOnNodes(snap tstore.RDFGraph, node string) ([]string, error) {
+	return objectNodes(snap, node, rdf.ApplyOn)
+}
 
-	var childs []string
-	for _, tri := range triples {
+func objectNodes(snap tstore.RDFGraph, node, pred string) ([]string, error) {
+	var nodes []string
+	for _, tri := range snap.WithSubjPred(node, pred) {
 		n, ok := tri.Object().Resource()
 		if !ok {
-			return fmt.Errorf("object is not a resource identifier")
+			return nodes, fmt.Errorf("object is not a resource identifier")
 		}
-		childs = append(childs, n)
+		nodes = append(nodes, n)
 	}
-
-	sort.Strings(childs)
-
-	for _, child := range childs {
-		visitTopDown(snap, child, each, dist+1)
-	}
-
-	return nil
+	return nodes, nil
 }
 
-func visitBottomUp(snap tstore.RDFGraph, startNode string, each func(tstore.RDFGraph, string, int) error, distances ...int) error {
-	var dist int
-	if len(distances) > 0 {
-		dist = distances[0]
+// walk visits depth first, in sorted order, the nodes reachable from root through next,
+// each node being visited only once and no further than maxDepth (DefaultMaxDepth if not positive)
+func walk(snap tstore.RDFGraph, root string, next nextNodesFunc, each func(tstore.RDFGraph, string, int) error, maxDepth int) error {
+	if maxDepth <= 0 {
+		maxDepth = DefaultMaxDepth
 	}
+	visited := make(map[string]bool)
 
-	if err := each(snap, startNode, dist); err != nil {
-		return err
-	}
-	triples := snap.WithPredObj(rdf.ParentOf, tstore.Resource(startNode))
-	var parents []string
-	for _, tri := range triples {
-		parents = append(parents, tri.Subject())
-	}
+	var visit func(node string, depth int) error
+	visit = func(node string, depth int) error {
+		visited[node] = true
+		if err := each(snap, node, depth); err != nil {
+			return err
+		}
+		if depth >= maxDepth {
+			return nil
+		}
 
-	sort.Strings(parents)
+		nodes, err := next(snap, node)
+		if err != nil {
+			return err
+		}
+		sort.Strings(nodes)
 
-	for _, child := range parents {
-		visitBottomUp(snap, child, each, dist+1)
+		for _, n := range nodes {
+			if visited[n] {
+				continue
+			}
+			if err := visit(n, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
 	}
 
-	return nil
+	return visit(root, 0)
 }
 
 func visitSiblings(snap tstore.RDFGraph, start string, each func(tstore.RDFGraph, string, int) error, distances ...int) error {
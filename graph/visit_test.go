@@ -17,6 +17,7 @@ limitations under the License.
 package graph_test
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -69,3 +70,95 @@ func TestCollectors(t *testing.T) {
 	}
 
 }
+
+func TestVisitCyclicGraph(t *testing.T) {
+	g := graph.NewGraph()
+	sg1 := graph.InitResource("securitygroup", "sg_1")
+	sg2 := graph.InitResource("securitygroup", "sg_2")
+	sg3 := graph.InitResource("securitygroup", "sg_3")
+	v1 := graph.InitResource("vpc", "vpc_1")
+	v2 := graph.InitResource("vpc", "vpc_2")
+	if err := g.AddResource(sg1, sg2, sg3, v1, v2); err != nil {
+		t.Fatal(err)
+	}
+	g.AddAppliesOnRelation(sg1, sg2)
+	g.AddAppliesOnRelation(sg2, sg3)
+	g.AddAppliesOnRelation(sg3, sg1)
+	g.AddAppliesOnRelation(sg3, sg3)
+	g.AddParentRelation(v1, v2)
+	g.AddParentRelation(v2, v1)
+
+	var collect []*graph.Resource
+	var depths []int
+	collectWithDepth := func(res *graph.Resource, depth int) error {
+		collect = append(collect, res)
+		depths = append(depths, depth)
+		return nil
+	}
+	tcases := []struct {
+		vis    graph.Visitor
+		exp    []*graph.Resource
+		depths []int
+	}{
+		{vis: &graph.AppliedOnVisitor{From: sg1, Each: collectWithDepth, IncludeFrom: true}, exp: []*graph.Resource{sg1, sg2, sg3}, depths: []int{0, 1, 2}},
+		{vis: &graph.AppliedOnVisitor{From: sg2, Each: collectWithDepth}, exp: []*graph.Resource{sg3, sg1}, depths: []int{1, 2}},
+		{vis: &graph.AppliedOnVisitor{From: sg1, Each: collectWithDepth, MaxDepth: 1}, exp: []*graph.Resource{sg2}, depths: []int{1}},
+		{vis: &graph.ChildrenVisitor{From: v1, Each: collectWithDepth, IncludeFrom: true}, exp: []*graph.Resource{v1, v2}, depths: []int{0, 1}},
+		{vis: &graph.ParentsVisitor{From: v2, Each: collectWithDepth}, exp: []*graph.Resource{v1}, depths: []int{1}},
+	}
+
+	for i, tcase := range tcases {
+		collect, depths = []*graph.Resource{}, []int{}
+
+		if err := g.Accept(tcase.vis); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := collect, tcase.exp; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%d. got %#v, want %#v", i, got, want)
+		}
+		if got, want := depths, tcase.depths; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%d. got %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestVisitMaxDepth(t *testing.T) {
+	g := graph.NewGraph()
+	var chain []*graph.Resource
+	for i := 0; i < graph.DefaultMaxDepth+10; i++ {
+		res := graph.InitResource("subnet", fmt.Sprintf("sub_%03d", i))
+		if err := g.AddResource(res); err != nil {
+			t.Fatal(err)
+		}
+		if i > 0 {
+			g.AddParentRelation(chain[i-1], res)
+		}
+		chain = append(chain, res)
+	}
+
+	var count, deepest int
+	countEach := func(res *graph.Resource, depth int) error {
+		count++
+		if depth > deepest {
+			deepest = depth
+		}
+		return nil
+	}
+	if err := g.Accept(&graph.ChildrenVisitor{From: chain[0], Each: countEach, IncludeFrom: true}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := deepest, graph.DefaultMaxDepth; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := count, graph.DefaultMaxDepth+1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+
+	count, deepest = 0, 0
+	if err := g.Accept(&graph.ParentsVisitor{From: chain[len(chain)-1], Each: countEach, MaxDepth: 3}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := count, 3; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/sync/repo"
+)
+
+// CompactStats reports what the compaction of the local store removed
+type CompactStats struct {
+	Files                         int
+	TriplesBefore, TriplesRemoved int
+	SnapshotsPruned               int
+	BytesBefore, BytesAfter       int64
+}
+
+// CompactLocalStore rewrites the local graphs without dangling nor duplicate triples and
+// prunes the snapshots older than the retention (none when the retention is 0)
+func CompactLocalStore(retention time.Duration) (*CompactStats, error) {
+	stats := &CompactStats{BytesBefore: dirSize(repo.Dir())}
+
+	var compacted []string
+	for _, path := range LocalGraphFiles() {
+		before, after, err := graph.CompactFile(path)
+		if err != nil {
+			return stats, fmt.Errorf("compacting %s: %s", path, err)
+		}
+		stats.Files++
+		stats.TriplesBefore += before
+		stats.TriplesRemoved += before - after
+		compacted = append(compacted, filepath.Base(path))
+	}
+	if DefaultSyncer != nil && len(compacted) > 0 {
+		if err := DefaultSyncer.Commit(compacted...); err != nil {
+			return stats, fmt.Errorf("storing compacted graphs: %s", err)
+		}
+	}
+
+	if retention > 0 {
+		pruned, err := repo.Prune(time.Now().Add(-retention))
+		if err != nil {
+			return stats, fmt.Errorf("pruning snapshots: %s", err)
+		}
+		stats.SnapshotsPruned = pruned
+	}
+
+	stats.BytesAfter = dirSize(repo.Dir())
+	return stats, nil
+}
+
+func dirSize(dir string) (size int64) {
+	filepath.Walk(dir, func(_ string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return
+}
@@ -19,6 +19,7 @@ package repo
 import (
 	"bytes"
 	"fmt"
+	"os"
 	"os/exec"
 )
 
@@ -28,13 +29,15 @@ type gitCmd struct {
 }
 
 func newGit(workdir string, envs ...string) *gitCmd {
-	return &gitCmd{dir: workdir}
+	return &gitCmd{dir: workdir, env: envs}
 }
 
 func (g *gitCmd) run(args ...string) (string, error) {
 	cmd := exec.Command("git", args...)
 	cmd.Dir = g.dir
-	cmd.Env = g.env
+	if len(g.env) > 0 {
+		cmd.Env = append(os.Environ(), g.env...)
+	}
 
 	out, err := cmd.Output()
 	if err != nil {
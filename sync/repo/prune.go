@@ -0,0 +1,92 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type snapshot struct {
+	hash, tree, subject string
+	authored, committed int64
+}
+
+// Prune rewrites the history of the local graphs repository without the snapshots synced
+// before the given time, then garbage collects them. The last snapshot is always kept.
+// It returns the number of snapshots removed
+func Prune(before time.Time) (int, error) {
+	dir := Dir()
+	if !IsGitInstalled() {
+		return 0, nil
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".git")); os.IsNotExist(err) {
+		return 0, nil
+	}
+	out, err := newGit(dir).run("log", "--reverse", "--format=%H%x00%T%x00%at%x00%ct%x00%s")
+	if err != nil {
+		return 0, nil // no commit yet
+	}
+	var all []*snapshot
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.SplitN(line, "\x00", 5)
+		if len(fields) != 5 {
+			continue
+		}
+		s := &snapshot{hash: fields[0], tree: fields[1], subject: fields[4]}
+		s.authored, _ = strconv.ParseInt(fields[2], 10, 64)
+		s.committed, _ = strconv.ParseInt(fields[3], 10, 64)
+		all = append(all, s)
+	}
+
+	first := len(all) - 1
+	for first > 0 && all[first-1].committed >= before.Unix() {
+		first--
+	}
+	if first <= 0 {
+		return 0, nil
+	}
+
+	var parent string
+	for _, s := range all[first:] {
+		args := append([]string{}, awlessCommitter...)
+		args = append(args, "commit-tree", s.tree, "-m", s.subject)
+		if parent != "" {
+			args = append(args, "-p", parent)
+		}
+		env := []string{fmt.Sprintf("GIT_AUTHOR_DATE=@%d +0000", s.authored), fmt.Sprintf("GIT_COMMITTER_DATE=@%d +0000", s.committed)}
+		hash, err := newGit(dir, env...).run(args...)
+		if err != nil {
+			return 0, err
+		}
+		parent = strings.TrimSpace(hash)
+	}
+	if _, err := newGit(dir).run("update-ref", "HEAD", parent); err != nil {
+		return 0, err
+	}
+	if _, err := newGit(dir).run("reflog", "expire", "--expire=now", "--all"); err != nil {
+		return 0, err
+	}
+	if _, err := newGit(dir).run("gc", "--prune=now", "--quiet"); err != nil {
+		return 0, err
+	}
+	return first, nil
+}
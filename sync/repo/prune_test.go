@@ -0,0 +1,83 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repo
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrune(t *testing.T) {
+	if !IsGitInstalled() {
+		t.Skip("git not installed")
+	}
+	dir, err := ioutil.TempDir("", "awless-prune")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	os.Setenv("__AWLESS_RDF_DIR", dir)
+	defer os.Unsetenv("__AWLESS_RDF_DIR")
+
+	if _, err = newGitRepo(dir); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	for i, age := range []time.Duration{30 * 24 * time.Hour, 20 * 24 * time.Hour, 2 * 24 * time.Hour, time.Hour} {
+		if err = ioutil.WriteFile(filepath.Join(dir, "infra.triples"), []byte(fmt.Sprint(i)), 0600); err != nil {
+			t.Fatal(err)
+		}
+		date := fmt.Sprintf("@%d +0000", now.Add(-age).Unix())
+		if _, err = newGit(dir).run("add", "infra.triples"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = newGit(dir, "GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date).run(append(awlessCommitter, "commit", "-m", fmt.Sprintf("sync %d", i))...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pruned, err := Prune(now.Add(-7 * 24 * time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := pruned, 2; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	out, err := newGit(dir).run("log", "--format=%s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.Fields(strings.TrimSpace(out)), []string{"sync", "3", "sync", "2"}; strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if b, _ := ioutil.ReadFile(filepath.Join(dir, "infra.triples")); string(b) != "3" {
+		t.Fatalf("got %q, want last synced graph", b)
+	}
+
+	pruned, err = Prune(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := pruned, 1; got != want {
+		t.Fatalf("got %d, want %d (the last snapshot is kept)", got, want)
+	}
+}
@@ -63,7 +63,12 @@ type gitRepo struct {
 	path  string
 }
 
+// Dir returns the directory of the local graphs. It can be overridden with
+// __AWLESS_RDF_DIR (ex: to keep mock mode graphs apart from the real ones)
 func Dir() string {
+	if dir := os.Getenv("__AWLESS_RDF_DIR"); dir != "" {
+		return dir
+	}
 	return filepath.Join(os.Getenv("__AWLESS_HOME"), "aws", "rdf")
 }
 
@@ -169,7 +174,7 @@ func unmarshalIntoGraph(g *graph.Graph, commit *object.Commit, filename string)
 		if err != nil {
 			return err
 		}
-		g.Unmarshal([]byte(contents))
+		return g.Unmarshal([]byte(contents))
 	}
 	return nil
 }
@@ -37,14 +37,32 @@ const fileExt = ".triples"
 
 var DefaultSyncer Syncer
 
+// DefaultConcurrentRegions is the number of regions synced at once by SyncRegions
+const DefaultConcurrentRegions = 4
+
 type Syncer interface {
 	repo.Repo
 	Sync(...cloud.Service) (map[string]*graph.Graph, error)
+	SyncRegions(regions []string, globals []string, servicesOf RegionServices) (map[string]*graph.Graph, error)
+	SyncResource(srv cloud.Service, t, id string) (*graph.Graph, error)
+	SetProgress(Progress)
+	SetConcurrentRegions(int)
+}
+
+// Progress is notified of the status of each service being synced.
+// Updates come concurrently from the fetching goroutines.
+type Progress interface {
+	Update(service, status string)
 }
 
+// RegionServices returns the services to sync in a region
+type RegionServices func(region string) ([]cloud.Service, error)
+
 type syncer struct {
 	repo.Repo
-	logger *logger.Logger
+	logger            *logger.Logger
+	progress          Progress
+	concurrentRegions int
 }
 
 func NewSyncer(l ...*logger.Logger) Syncer {
@@ -64,7 +82,119 @@ func NewSyncer(l ...*logger.Logger) Syncer {
 	return s
 }
 
+func (s *syncer) SetProgress(p Progress) {
+	s.progress = p
+}
+
+// SetConcurrentRegions bounds the number of regions synced at once by SyncRegions.
+// It does not bound the services fetched concurrently in each region
+func (s *syncer) SetConcurrentRegions(n int) {
+	s.concurrentRegions = n
+}
+
 func (s *syncer) Sync(services ...cloud.Service) (map[string]*graph.Graph, error) {
+	graphs, allErrors := s.fetch("", services...)
+	allErrors = append(allErrors, s.store(graphs)...)
+	return graphs, concatErrors(allErrors)
+}
+
+// SyncRegions syncs the services of each region and stores the resources of all the regions
+// merged per service. The global services (ex: access) are fetched once, in the first region.
+// Regions are synced concurrently, at most by the concurrent regions setting at a time
+func (s *syncer) SyncRegions(regions []string, globals []string, servicesOf RegionServices) (map[string]*graph.Graph, error) {
+	isGlobal := make(map[string]bool)
+	for _, name := range globals {
+		isGlobal[name] = true
+	}
+	globalAssigned := make(map[string]bool)
+
+	var allErrors []error
+	toSync := make(map[string][]cloud.Service)
+	var synced []string
+	for _, region := range regions {
+		services, err := servicesOf(region)
+		if err != nil {
+			allErrors = append(allErrors, fmt.Errorf("region %s: %s", region, err))
+			continue
+		}
+		for _, srv := range services {
+			if globalAssigned[srv.Name()] {
+				s.logger.ExtraVerbosef("sync: global service %s already fetched in another region", srv.Name())
+				continue
+			}
+			if isGlobal[srv.Name()] {
+				globalAssigned[srv.Name()] = true
+			}
+			toSync[region] = append(toSync[region], srv)
+		}
+		synced = append(synced, region)
+	}
+
+	concurrency := s.concurrentRegions
+	if concurrency < 1 {
+		concurrency = DefaultConcurrentRegions
+	}
+	var (
+		mu     gosync.Mutex
+		wg     gosync.WaitGroup
+		done   int
+		sem    = make(chan struct{}, concurrency)
+		graphs = make(map[string]*graph.Graph)
+	)
+	for _, region := range synced {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(region string) {
+			defer func() { <-sem; wg.Done() }()
+			start := time.Now()
+			fetched, errs := s.fetch(region+" ", toSync[region]...)
+			mu.Lock()
+			defer mu.Unlock()
+			allErrors = append(allErrors, errs...)
+			for name, g := range fetched {
+				if merged, ok := graphs[name]; ok {
+					merged.AddGraph(g)
+				} else {
+					graphs[name] = g
+				}
+			}
+			done++
+			status := "synced"
+			if len(errs) > 0 {
+				status = "synced with errors"
+			}
+			s.logger.Infof("region %s %s in %s (%d/%d)", region, status, time.Since(start).Round(time.Second/10), done, len(synced))
+		}(region)
+	}
+	wg.Wait()
+
+	allErrors = append(allErrors, s.store(graphs)...)
+	return graphs, concatErrors(allErrors)
+}
+
+// SyncResource fetches the resource of the given type and id and refreshes it in place
+// in the local graph of its service. It returns the graph of the fetched resource
+func (s *syncer) SyncResource(srv cloud.Service, t, id string) (*graph.Graph, error) {
+	fetcher, ok := srv.(cloud.ResourceFetcher)
+	if !ok {
+		return nil, fmt.Errorf("service %s cannot fetch a single resource", srv.Name())
+	}
+	fetched, err := fetcher.FetchResource(t, id)
+	if err != nil {
+		return nil, err
+	}
+
+	local := LoadCurrentLocalGraph(srv.Name())
+	local.RefreshResource(id, fetched)
+	if errs := s.store(map[string]*graph.Graph{srv.Name(): local}); len(errs) > 0 {
+		return fetched, concatErrors(errs)
+	}
+	return fetched, nil
+}
+
+// fetch fetches concurrently the resources of the services, reporting the progress of each
+// service prefixed with the given label
+func (s *syncer) fetch(label string, services ...cloud.Service) (map[string]*graph.Graph, []error) {
 	graphs := make(map[string]*graph.Graph)
 	var workers gosync.WaitGroup
 
@@ -86,6 +216,7 @@ func (s *syncer) Sync(services ...cloud.Service) (map[string]*graph.Graph, error
 		go func(srv cloud.Service) {
 			defer workers.Done()
 			start := time.Now()
+			s.updateProgress(label+srv.Name(), "fetching...")
 			g, err := srv.FetchResources()
 			resultc <- &result{name: srv.Name(), gph: g, start: start, err: err}
 		}(service)
@@ -106,9 +237,11 @@ Loop:
 				break Loop
 			}
 			if res.err != nil {
-				allErrors = append(allErrors, fmt.Errorf("syncing %s: %s", res.name, res.err))
+				allErrors = append(allErrors, fmt.Errorf("syncing %s%s: %s", label, res.name, res.err))
+				s.updateProgress(label+res.name, "done with errors")
 			} else {
-				logger.ExtraVerbosef("sync: fetched %s service took %s", res.name, time.Since(res.start))
+				logger.ExtraVerbosef("sync: fetched %s%s service took %s", label, res.name, time.Since(res.start))
+				s.updateProgress(label+res.name, fmt.Sprintf("done in %s", time.Since(res.start)))
 			}
 			if res.gph != nil {
 				graphs[res.name] = res.gph
@@ -116,6 +249,11 @@ Loop:
 		}
 	}
 
+	return graphs, allErrors
+}
+
+// store writes the graphs of the services in the local repository and commits them
+func (s *syncer) store(graphs map[string]*graph.Graph) (allErrors []error) {
 	var filenames []string
 
 	for name, g := range graphs {
@@ -135,7 +273,13 @@ Loop:
 		allErrors = append(allErrors, fmt.Errorf("storing %s: %s", strings.Join(filenames, ", "), err))
 	}
 
-	return graphs, concatErrors(allErrors)
+	return
+}
+
+func (s *syncer) updateProgress(service, status string) {
+	if s.progress != nil {
+		s.progress.Update(service, status)
+	}
 }
 
 func concatErrors(errs []error) error {
@@ -155,14 +299,22 @@ func LoadCurrentLocalGraph(serviceName string) *graph.Graph {
 	path := filepath.Join(repo.Dir(), fmt.Sprintf("%s%s", serviceName, fileExt))
 	g, err := graph.NewGraphFromFile(path)
 	if err != nil {
+		if !os.IsNotExist(err) {
+			logger.Warningf("cannot load local %s model: %s", serviceName, err)
+		}
 		return graph.NewGraph()
 	}
 	return g
 }
 
+// LocalGraphFiles returns the paths of the graph files of the last sync
+func LocalGraphFiles() []string {
+	files, _ := filepath.Glob(filepath.Join(repo.Dir(), fmt.Sprintf("*%s", fileExt)))
+	return files
+}
+
 func LoadAllGraphs() (*graph.Graph, error) {
-	path := filepath.Join(repo.Dir(), fmt.Sprintf("*%s", fileExt))
-	files, _ := filepath.Glob(path)
+	files := LocalGraphFiles()
 
 	g := graph.NewGraph()
 
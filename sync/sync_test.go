@@ -0,0 +1,178 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sync
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	gosync "sync"
+	"testing"
+	"time"
+
+	"github.com/wallix/awless/cloud"
+	"github.com/wallix/awless/graph"
+	"github.com/wallix/awless/logger"
+	"github.com/wallix/awless/sync/repo"
+	"github.com/wallix/awless/template/driver"
+)
+
+func TestSyncRegions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "syncregions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	os.Setenv("__AWLESS_RDF_DIR", dir)
+	defer os.Unsetenv("__AWLESS_RDF_DIR")
+
+	var mu gosync.Mutex
+	fetched := make(map[string]int)
+	servicesOf := func(region string) ([]cloud.Service, error) {
+		return []cloud.Service{
+			&regionService{name: "infra", region: region, mu: &mu, fetched: fetched},
+			&regionService{name: "access", region: region, mu: &mu, fetched: fetched},
+		}, nil
+	}
+
+	commits := &commitsRepo{}
+	s := &syncer{Repo: commits, logger: logger.DiscardLogger}
+	graphs, err := s.SyncRegions([]string{"eu-west-1", "us-east-1"}, []string{"access"}, servicesOf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expFetched := map[string]int{"eu-west-1/infra": 1, "us-east-1/infra": 1, "eu-west-1/access": 1}
+	if got, want := fetched, expFetched; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	instances, err := graphs["infra"].GetAllResources(cloud.Instance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, inst := range instances {
+		ids = append(ids, inst.Id())
+	}
+	sort.Strings(ids)
+	if got, want := ids, []string{"eu-west-1-infra", "us-east-1-infra"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	sort.Strings(commits.files)
+	if got, want := commits.files, []string{"access.triples", "infra.triples"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "infra.triples")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSyncRegionsConcurrency(t *testing.T) {
+	dir, err := ioutil.TempDir("", "syncregions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	os.Setenv("__AWLESS_RDF_DIR", dir)
+	defer os.Unsetenv("__AWLESS_RDF_DIR")
+
+	regions := []string{"eu-west-1", "eu-west-2", "eu-west-3", "us-east-1", "us-east-2", "us-west-1", "us-west-2"}
+	var mu gosync.Mutex
+	var active, maxActive int
+	fetched := make(map[string]int)
+	servicesOf := func(region string) ([]cloud.Service, error) {
+		return []cloud.Service{
+			&regionService{name: "infra", region: region, mu: &mu, fetched: fetched, active: &active, maxActive: &maxActive},
+			&regionService{name: "access", region: region, mu: &mu, fetched: fetched, active: &active, maxActive: &maxActive},
+		}, nil
+	}
+
+	s := &syncer{Repo: &commitsRepo{}, logger: logger.DiscardLogger}
+	s.SetConcurrentRegions(2)
+	graphs, err := s.SyncRegions(regions, []string{"access"}, servicesOf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if maxActive < 2 || maxActive > 3 {
+		t.Fatalf("got %d services fetched at once, want between 2 and 3 (2 regions, global service fetched once)", maxActive)
+	}
+	if got, want := fetched["eu-west-1/access"], 1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	if got, want := len(fetched), len(regions)+1; got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+	instances, err := graphs["infra"].GetAllResources(cloud.Instance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(instances), len(regions); got != want {
+		t.Fatalf("got %d, want %d", got, want)
+	}
+}
+
+type regionService struct {
+	name, region string
+	mu           *gosync.Mutex
+	fetched      map[string]int
+
+	active, maxActive *int
+}
+
+func (s *regionService) Name() string             { return s.name }
+func (s *regionService) Drivers() []driver.Driver { return nil }
+func (s *regionService) ResourceTypes() []string  { return nil }
+func (s *regionService) IsSyncDisabled() bool     { return false }
+func (s *regionService) FetchByType(t string) (*graph.Graph, error) {
+	return s.FetchResources()
+}
+
+func (s *regionService) FetchResources() (*graph.Graph, error) {
+	s.mu.Lock()
+	s.fetched[s.region+"/"+s.name]++
+	if s.active != nil {
+		*s.active++
+		if *s.active > *s.maxActive {
+			*s.maxActive = *s.active
+		}
+	}
+	s.mu.Unlock()
+	if s.active != nil {
+		time.Sleep(10 * time.Millisecond)
+		s.mu.Lock()
+		*s.active--
+		s.mu.Unlock()
+	}
+	g := graph.NewGraph()
+	return g, g.AddResource(graph.InitResource(cloud.Instance, s.region+"-"+s.name))
+}
+
+type commitsRepo struct {
+	files []string
+}
+
+func (r *commitsRepo) Commit(files ...string) error {
+	r.files = append(r.files, files...)
+	return nil
+}
+func (r *commitsRepo) List() ([]*repo.Rev, error)                { return nil, nil }
+func (r *commitsRepo) LoadRev(version string) (*repo.Rev, error) { return &repo.Rev{}, nil }
@@ -13,7 +13,9 @@ type Env struct {
 	Fillers          map[string]interface{}
 	DefLookupFunc    DefinitionLookupFunc
 	AliasFunc        func(entity, key, alias string) string
+	ArnFunc          func(entity, key, arn string) string
 	MissingHolesFunc func(string) interface{}
+	ParamStoreFunc   func(name string, decrypt bool) (string, error)
 	Log              *logger.Logger
 
 	processedFillers map[string]interface{}
@@ -70,7 +72,9 @@ var (
 		resolveMissingHolesPass,
 		replaceVariableValuePass,
 		removeValueStatementsPass,
+		resolveParamStorePass,
 		resolveAliasPass,
+		resolveArnPass,
 	}
 
 	NormalCompileMode = append(
@@ -209,9 +213,13 @@ func checkReferencesDeclaration(tpl *Template, env *Env) (*Template, *Env, error
 	unusedRefs := make(map[string]bool)
 
 	var each = func(cmd *ast.CommandNode) error {
-		for _, ref := range cmd.Refs {
+		for _, used := range cmd.Refs {
+			ref := used
 			if _, ok := knownRefs[ref]; !ok {
-				return fmt.Errorf("using reference '$%s' but '%s' is undefined in template\n", ref, ref)
+				ref = declaredRef(used)
+			}
+			if _, ok := knownRefs[ref]; !ok {
+				return fmt.Errorf("using reference '$%s' but '%s' is undefined in template\n", used, ref)
 			}
 			if _, ok := unusedRefs[ref]; ok {
 				delete(unusedRefs, ref)
@@ -256,6 +264,15 @@ func checkReferencesDeclaration(tpl *Template, env *Env) (*Template, *Env, error
 	return tpl, env, nil
 }
 
+// declaredRef returns the declared identifier of a reference,
+// stripping any named output suffix (i.e. $stack.dns refers to $stack)
+func declaredRef(ref string) string {
+	if i := strings.Index(ref, "."); i > 0 {
+		return ref[:i]
+	}
+	return ref
+}
+
 func replaceVariableValuePass(tpl *Template, env *Env) (*Template, *Env, error) {
 	toReplace := make(map[string]interface{})
 
@@ -325,6 +342,67 @@ func resolveMissingHolesPass(tpl *Template, env *Env) (*Template, *Env, error) {
 	return tpl, env, nil
 }
 
+const (
+	paramStorePrefix        = "ssm:"
+	paramStoreDecryptPrefix = "ssm+decrypt:"
+)
+
+// resolveParamStorePass fetches the values of params referencing the SSM Parameter Store
+// (i.e. ssm:/path/to/param or ssm+decrypt:/path/to/secret for SecureString).
+// Values are kept aside on the template and only substituted to the params when running,
+// so that they never get displayed nor persisted.
+func resolveParamStorePass(tpl *Template, env *Env) (*Template, *Env, error) {
+	var errs []string
+	each := func(cmd *ast.CommandNode) {
+		for k, v := range cmd.Params {
+			s, ok := v.(string)
+			if !ok {
+				continue
+			}
+			name, decrypt, isParamStore := parseParamStoreRef(s)
+			if !isParamStore {
+				continue
+			}
+			if _, done := tpl.paramStore[s]; done {
+				continue
+			}
+			if env.ParamStoreFunc == nil {
+				errs = append(errs, fmt.Sprintf("cannot resolve '%s' for key %s: no parameter store available", s, k))
+				continue
+			}
+			env.Log.ExtraVerbosef("ssm: resolving parameter %s for key %s", name, k)
+			actual, err := env.ParamStoreFunc(name, decrypt)
+			if err != nil {
+				errs = append(errs, err.Error())
+				continue
+			}
+			if tpl.paramStore == nil {
+				tpl.paramStore = make(map[string]interface{})
+			}
+			tpl.paramStore[s] = actual
+			delete(cmd.Holes, k)
+		}
+	}
+
+	tpl.visitCommandNodes(each)
+
+	if len(errs) > 0 {
+		return tpl, env, fmt.Errorf("cannot resolve parameter store values: %s", strings.Join(errs, "; "))
+	}
+
+	return tpl, env, nil
+}
+
+func parseParamStoreRef(s string) (name string, decrypt bool, ok bool) {
+	switch {
+	case strings.HasPrefix(s, paramStoreDecryptPrefix):
+		return strings.TrimPrefix(s, paramStoreDecryptPrefix), true, true
+	case strings.HasPrefix(s, paramStorePrefix):
+		return strings.TrimPrefix(s, paramStorePrefix), false, true
+	}
+	return "", false, false
+}
+
 func resolveAliasPass(tpl *Template, env *Env) (*Template, *Env, error) {
 	var emptyResolv []string
 	each := func(cmd *ast.CommandNode) {
@@ -358,6 +436,28 @@ func resolveAliasPass(tpl *Template, env *Env) (*Template, *Env, error) {
 	return tpl, env, nil
 }
 
+// resolveArnPass replaces the ARNs given as 'id' or 'resource' params by the ids of the resources.
+// Unresolved ARNs are left as is since many drivers accept ARNs
+func resolveArnPass(tpl *Template, env *Env) (*Template, *Env, error) {
+	if env.ArnFunc == nil {
+		return tpl, env, nil
+	}
+	each := func(cmd *ast.CommandNode) {
+		for k, v := range cmd.Params {
+			if s, ok := v.(string); ok && strings.HasPrefix(s, "arn:") && (k == "id" || k == "resource") {
+				if actual := env.ArnFunc(cmd.Entity, k, s); actual != "" && actual != s {
+					env.Log.ExtraVerbosef("arn: resolved '%s' to '%s' for key %s", s, actual, k)
+					cmd.Params[k] = actual
+				}
+			}
+		}
+	}
+
+	tpl.visitCommandNodes(each)
+
+	return tpl, env, nil
+}
+
 func failOnUnresolvedHoles(tpl *Template, env *Env) (*Template, *Env, error) {
 	var unresolved []string
 	tpl.visitCommandNodes(func(cmd *ast.CommandNode) {
@@ -1,6 +1,7 @@
 package template
 
 import (
+	"fmt"
 	"reflect"
 	"strings"
 	"testing"
@@ -168,6 +169,8 @@ func TestCheckReferencesDeclarationPass(t *testing.T) {
 		{"create instance subnet=$sub\nsub = create subnet", "'sub' is undefined in template"},
 		{"create instance\nip = 127.0.0.1", "unused reference 'ip'"},
 		{"new_inst = create instance autoref=$new_inst\n", "'new_inst' is undefined in template"},
+		{"stack = create loadbalancerstack\ncreate record value=$stack.dns", ""},
+		{"stack = create loadbalancerstack\ncreate record value=$other.dns", "'other' is undefined in template"},
 	}
 
 	for i, tcase := range tcases {
@@ -315,6 +318,78 @@ func TestResolveAliasPass(t *testing.T) {
 	assertCmdParams(t, tpl, map[string]interface{}{"subnet": "sub-12345", "ami": "ami-12345", "count": 3})
 }
 
+func TestResolveArnPass(t *testing.T) {
+	tpl := MustParse("delete instance id=arn:aws:ec2:us-east-1:123456789012:instance/i-12345\nattach policy arn=arn:aws:iam::aws:policy/ReadOnlyAccess user=jsmith\ndelete loadbalancer id=arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/app/my-lb/50dc6c495c0c9188")
+
+	env := NewEnv()
+	env.ArnFunc = func(e, k, v string) string {
+		vals := map[string]string{
+			"arn:aws:ec2:us-east-1:123456789012:instance/i-12345": "i-12345",
+			"arn:aws:iam::aws:policy/ReadOnlyAccess":              "ANPAJRLDHWVO4IJSKAGHK",
+		}
+		return vals[v]
+	}
+
+	pass := newMultiPass(resolveArnPass)
+
+	tpl, _, err := pass.compile(tpl, env)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assertCmdParams(t, tpl,
+		map[string]interface{}{"id": "i-12345"},
+		map[string]interface{}{"arn": "arn:aws:iam::aws:policy/ReadOnlyAccess", "user": "jsmith"},
+		map[string]interface{}{"id": "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/app/my-lb/50dc6c495c0c9188"},
+	)
+}
+
+func TestResolveParamStorePass(t *testing.T) {
+	env := NewEnv()
+	env.ParamStoreFunc = func(name string, decrypt bool) (string, error) {
+		vals := map[string]string{
+			"/app/db/name":     "mydb",
+			"/app/db/password": "encrypted",
+		}
+		if decrypt {
+			vals["/app/db/password"] = "s3cr3t"
+		}
+		if v, ok := vals[name]; ok {
+			return v, nil
+		}
+		return "", fmt.Errorf("ssm parameter '%s' not found", name)
+	}
+
+	t.Run("resolve only when running", func(t *testing.T) {
+		tpl := MustParse("pass = ssm+decrypt:/app/db/password\ncreate database dbname=ssm:/app/db/name password=$pass")
+		pass := newMultiPass(replaceVariableValuePass, removeValueStatementsPass, resolveParamStorePass)
+
+		tpl, _, err := pass.compile(tpl, env)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := tpl.String(), "create database dbname=ssm:/app/db/name password=ssm+decrypt:/app/db/password"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		var params map[string]interface{}
+		tpl.visitCommandNodes(func(cmd *ast.CommandNode) {
+			params = tpl.withParamStoreValues(cmd.Params)
+		})
+		if got, want := params, map[string]interface{}{"dbname": "mydb", "password": "s3cr3t"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("fail on missing parameter", func(t *testing.T) {
+		tpl := MustParse("create database dbname=ssm:/app/unknown")
+		_, _, err := resolveParamStorePass(tpl, env)
+		if err == nil || !strings.Contains(err.Error(), "'/app/unknown' not found") {
+			t.Fatalf("expected missing parameter error, got %v", err)
+		}
+	})
+}
+
 func TestResolveHolesPass(t *testing.T) {
 	tpl := MustParse("create instance count={instance.count} type={instance.type}")
 
@@ -33,14 +33,34 @@ type Driver interface {
 
 type DriverFn func(map[string]interface{}) (interface{}, error)
 
+// Outputs can be returned by a DriverFn producing several values.
+// Main is the command result; each named value is referenceable
+// in templates as $ident.name
+type Outputs struct {
+	Main  interface{}
+	Named map[string]interface{}
+}
+
 type MultiDriver struct {
 	drivers []Driver
+	slots   chan struct{}
 }
 
 func NewMultiDriver(drivers ...Driver) Driver {
 	return &MultiDriver{drivers: drivers}
 }
 
+// NewConcurrentMultiDriver returns a multi driver running at most limit driver functions
+// at once across all its drivers, whatever the callers running them concurrently.
+// A limit <= 0 does not bound them
+func NewConcurrentMultiDriver(limit int, drivers ...Driver) Driver {
+	d := &MultiDriver{drivers: drivers}
+	if limit > 0 {
+		d.slots = make(chan struct{}, limit)
+	}
+	return d
+}
+
 func (d *MultiDriver) SetDryRun(dry bool) {
 	for _, dr := range d.drivers {
 		dr.SetDryRun(dry)
@@ -69,8 +89,20 @@ func (d *MultiDriver) Lookup(lookups ...string) (driverFn DriverFn, err error) {
 	case 0:
 		return nil, fmt.Errorf("function corresponding to '%v' not found in drivers", lookups)
 	case 1:
-		return funcs[0], nil
+		return d.bounded(funcs[0]), nil
 	default:
 		return nil, fmt.Errorf("%d functions corresponding to '%v' found in drivers", len(funcs), lookups)
 	}
 }
+
+// bounded returns the driver function waiting for a free slot before running, if the driver is bounded
+func (d *MultiDriver) bounded(fn DriverFn) DriverFn {
+	if d.slots == nil {
+		return fn
+	}
+	return func(params map[string]interface{}) (interface{}, error) {
+		d.slots <- struct{}{}
+		defer func() { <-d.slots }()
+		return fn(params)
+	}
+}
@@ -3,7 +3,10 @@ package driver_test
 import (
 	"errors"
 	"reflect"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/wallix/awless/logger"
 	"github.com/wallix/awless/template/driver"
@@ -93,6 +96,76 @@ func TestMultiDriver(t *testing.T) {
 
 }
 
+func TestConcurrentMultiDriver(t *testing.T) {
+	var running, max int32
+	step := func(map[string]interface{}) (interface{}, error) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&max)
+			if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+				break
+			}
+		}
+		time.Sleep(2 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return nil, nil
+	}
+	newDriver := func(entity string) *mockDriver {
+		return &mockDriver{
+			lookupFn: func(lookups ...string) (driver.DriverFn, error) {
+				if lookups[1] != entity {
+					return nil, driver.ErrDriverFnNotFound
+				}
+				return step, nil
+			},
+		}
+	}
+
+	limit := 3
+	d := driver.NewConcurrentMultiDriver(limit, newDriver("instance"), newDriver("bucket"))
+
+	// a wide DAG: all its steps are independent and run at once
+	var wg sync.WaitGroup
+	for i := 0; i < 40; i++ {
+		entity := "instance"
+		if i%2 == 0 {
+			entity = "bucket"
+		}
+		fn, err := d.Lookup("create", entity)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn(nil)
+		}()
+	}
+	wg.Wait()
+
+	if got, want := int(atomic.LoadInt32(&max)), limit; got != want {
+		t.Fatalf("got %d steps running at once, want %d", got, want)
+	}
+
+	max = 0
+	d = driver.NewConcurrentMultiDriver(0, newDriver("instance"))
+	for i := 0; i < 10; i++ {
+		fn, err := d.Lookup("create", "instance")
+		if err != nil {
+			t.Fatal(err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn(nil)
+		}()
+	}
+	wg.Wait()
+	if got := atomic.LoadInt32(&max); got < 2 {
+		t.Fatalf("got %d steps running at once, want unbounded", got)
+	}
+}
+
 type mockDriver struct {
 	dryRun   bool
 	logger   *logger.Logger
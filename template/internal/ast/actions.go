@@ -12,8 +12,9 @@ const (
 
 	Check Action = "check"
 
-	Start Action = "start"
-	Stop  Action = "stop"
+	Start  Action = "start"
+	Stop   Action = "stop"
+	Reboot Action = "reboot"
 
 	Attach Action = "attach"
 	Detach Action = "detach"
@@ -32,6 +33,7 @@ var actions = map[Action]struct{}{
 	Check:        {},
 	Start:        {},
 	Stop:         {},
+	Reboot:       {},
 	Attach:       {},
 	Detach:       {},
 	Copy:         {},
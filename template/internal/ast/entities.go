@@ -18,18 +18,25 @@ var entities = map[Entity]struct{}{
 	"database":            {},
 	"distribution":        {},
 	"dbsubnetgroup":       {},
+	"dhcpoptions":         {},
 	"elasticip":           {},
+	"fleet":               {},
 	"function":            {},
 	"group":               {},
 	"instance":            {},
 	"image":               {},
 	"internetgateway":     {},
 	"natgateway":          {},
+	"networkacl":          {},
+	"networkaclrule":      {},
+	"placementgroup":      {},
+	"prefixlist":          {},
 	"instanceprofile":     {},
 	"keypair":             {},
 	"launchconfiguration": {},
 	"listener":            {},
 	"loadbalancer":        {},
+	"loadbalancerstack":   {},
 	"loginprofile":        {},
 	"policy":              {},
 	"queue":               {},
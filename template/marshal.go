@@ -13,6 +13,9 @@ type TemplateExecution struct {
 	*Template
 	Author, Source, Locale string
 	Fillers                map[string]interface{}
+
+	// Path and Digest identify the template file run, if any
+	Path, Digest string
 }
 
 func (t *TemplateExecution) MarshalJSON() ([]byte, error) {
@@ -21,6 +24,8 @@ func (t *TemplateExecution) MarshalJSON() ([]byte, error) {
 	out.Author = t.Author
 	out.Source = t.Source
 	out.Locale = t.Locale
+	out.Path = t.Path
+	out.Digest = t.Digest
 	out.Fillers = t.Fillers
 	if out.Fillers == nil {
 		out.Fillers = make(map[string]interface{}, 0) // friendlier for json, avoiding "fillers": null,
@@ -62,6 +67,8 @@ func (t *TemplateExecution) UnmarshalJSON(b []byte) error {
 	t.Source = v.Source
 	t.Locale = v.Locale
 	t.Author = v.Author
+	t.Path = v.Path
+	t.Digest = v.Digest
 	t.Fillers = v.Fillers
 
 	tpl := &Template{ID: v.ID, AST: &ast.AST{
@@ -97,6 +104,8 @@ type toJSON struct {
 	Author   string                 `json:"author,omitempty"`
 	Source   string                 `json:"source"`
 	Locale   string                 `json:"locale"`
+	Path     string                 `json:"path,omitempty"`
+	Digest   string                 `json:"digest,omitempty"`
 	Fillers  map[string]interface{} `json:"fillers"`
 	Commands []command              `json:"commands"`
 }
@@ -15,6 +15,8 @@ func TestTemplateExecutionUnmarshalFromJSON(t *testing.T) {
 	err := tplExec.UnmarshalJSON([]byte(`{
 		"source": "create stuff",
 		"locale": "eu-west-2",
+		"path": "/tmp/stuff.aws",
+		"digest": "1a2b3c",
 		"fillers": {
 			"mykey": "myvalue",
 			"mysecondkey": "mysecondvalue"
@@ -50,6 +52,12 @@ func TestTemplateExecutionUnmarshalFromJSON(t *testing.T) {
 	if got, want := tplExec.Locale, "eu-west-2"; got != want {
 		t.Fatalf("got %s, want %s", got, want)
 	}
+	if got, want := tplExec.Path, "/tmp/stuff.aws"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+	if got, want := tplExec.Digest, "1a2b3c"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
 
 	if got, want := cmds[0].CmdResult, "vpc-12345"; got != want {
 		t.Fatalf("got %v, want %v", got, want)
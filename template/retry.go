@@ -0,0 +1,91 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/wallix/awless/template/internal/ast"
+)
+
+// RetryFailed returns the template completing a failed run of source: the steps that
+// succeeded in the executed template are left out and their results replace the
+// references to them in the remaining steps, starting with the failed one
+func RetryFailed(source, executed *Template) (*Template, error) {
+	done := executed.CommandNodesIterator()
+	var failed bool
+	for _, cmd := range done {
+		if cmd.CmdErr != nil {
+			failed = true
+		}
+	}
+	if !failed {
+		return nil, errors.New("no failed step to retry")
+	}
+
+	vars := make(map[string]interface{})
+	succeeded := make(map[string]bool)
+	retry := &Template{AST: &ast.AST{}}
+	var i int
+	for _, sts := range source.Statements {
+		ident, cmd := commandOf(sts)
+		if cmd == nil {
+			retry.Statements = append(retry.Statements, sts.Clone())
+			continue
+		}
+		if i < len(done) && done[i].CmdErr == nil {
+			if done[i].Action != cmd.Action || done[i].Entity != cmd.Entity {
+				return nil, fmt.Errorf("executed step `%s` does not match template step `%s`", done[i], cmd)
+			}
+			if ident != "" {
+				vars[ident] = done[i].CmdResult
+				succeeded[ident] = true
+			}
+			i++
+			continue
+		}
+		i++
+		clone := sts.Clone()
+		_, clonedCmd := commandOf(clone)
+		clonedCmd.ProcessRefs(vars)
+		for _, ref := range clonedCmd.Refs {
+			if dot := strings.Index(ref, "."); dot > 0 && succeeded[ref[:dot]] {
+				return nil, fmt.Errorf("step `%s` references $%s, an output of a succeeded step that is not recorded", clonedCmd, ref)
+			}
+		}
+		retry.Statements = append(retry.Statements, clone)
+	}
+	if i < len(done) {
+		return nil, fmt.Errorf("executed template has %d steps, more than the %d of its source", len(done), i)
+	}
+
+	return retry, nil
+}
+
+func commandOf(sts *ast.Statement) (string, *ast.CommandNode) {
+	switch n := sts.Node.(type) {
+	case *ast.CommandNode:
+		return "", n
+	case *ast.DeclarationNode:
+		if cmd, ok := n.Expr.(*ast.CommandNode); ok {
+			return n.Ident, cmd
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRetryFailed(t *testing.T) {
+	source := MustParse("vpc = create vpc cidr=10.0.0.0/16\nsub = create subnet vpc=$vpc cidr={subnet.cidr}\ncreate instance subnet=$sub")
+	executed := func(results ...interface{}) *Template {
+		tpl := MustParse("create vpc cidr=10.0.0.0/16\ncreate subnet vpc=vpc-1234 cidr=10.0.1.0/24")
+		for i, cmd := range tpl.CommandNodesIterator() {
+			if err, ok := results[i].(error); ok {
+				cmd.CmdErr = err
+			} else {
+				cmd.CmdResult = results[i]
+			}
+		}
+		return tpl
+	}
+
+	t.Run("skip succeeded steps", func(t *testing.T) {
+		retry, err := RetryFailed(source, executed("vpc-1234", errors.New("throttled")))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := retry.String(), "sub = create subnet cidr={subnet.cidr} vpc=vpc-1234\ncreate instance subnet=$sub"; got != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	})
+	t.Run("retry from first step", func(t *testing.T) {
+		retry, err := RetryFailed(source, MustParse("create vpc cidr=10.0.0.0/16"))
+		if err == nil {
+			t.Fatalf("expected error, got %s", retry)
+		}
+		failed := MustParse("create vpc cidr=10.0.0.0/16")
+		failed.CommandNodesIterator()[0].CmdErr = errors.New("throttled")
+		if retry, err = RetryFailed(source, failed); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := retry.String(), source.String(); got != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	})
+	t.Run("no failed step", func(t *testing.T) {
+		if _, err := RetryFailed(source, executed("vpc-1234", "subnet-1234")); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+	t.Run("template mismatch", func(t *testing.T) {
+		if _, err := RetryFailed(MustParse("create vpc cidr=10.0.0.0/16\ncreate instance subnet=sub-1234"), executed("vpc-1234", errors.New("throttled"))); err != nil {
+			t.Fatal(err)
+		}
+		_, err := RetryFailed(MustParse("create subnet cidr=10.0.0.0/16\ncreate instance subnet=sub-1234"), executed("vpc-1234", errors.New("throttled")))
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+	t.Run("unrecorded named output", func(t *testing.T) {
+		source := MustParse("vpc = create vpc cidr=10.0.0.0/16\ncreate subnet vpc=$vpc.id cidr=10.0.1.0/24")
+		if _, err := RetryFailed(source, executed("vpc-1234", errors.New("throttled"))); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
@@ -87,6 +87,12 @@ func (te *Template) Revert() (*Template, error) {
 						}
 						params = append(params, fmt.Sprintf("%s=%v", k, quoteParamIfNeeded(v)))
 					}
+				case "networkaclrule":
+					for k, v := range cmd.Params {
+						if k == "id" || k == "number" || k == "outbound" {
+							params = append(params, fmt.Sprintf("%s=%v", k, quoteParamIfNeeded(v)))
+						}
+					}
 				case "database":
 					params = append(params, fmt.Sprintf("id=%s", quoteParamIfNeeded(cmd.CmdResult)))
 					params = append(params, "skip-snapshot=true")
@@ -116,11 +122,14 @@ func (te *Template) Revert() (*Template, error) {
 				case "container":
 					params = append(params, fmt.Sprintf("name=%s", quoteParamIfNeeded(cmd.Params["name"])))
 					params = append(params, fmt.Sprintf("service=%s", quoteParamIfNeeded(cmd.Params["service"])))
-				case "bucket", "launchconfiguration", "scalinggroup", "alarm", "dbsubnetgroup", "keypair":
+				case "bucket", "launchconfiguration", "scalinggroup", "alarm", "dbsubnetgroup", "keypair", "placementgroup":
 					params = append(params, fmt.Sprintf("name=%s", quoteParamIfNeeded(cmd.CmdResult)))
 					if cmd.Entity == "scalinggroup" {
 						params = append(params, "force=true")
 					}
+				case "fleet":
+					params = append(params, fmt.Sprintf("id=%s", quoteParamIfNeeded(cmd.CmdResult)))
+					params = append(params, "terminate-instances=true")
 				default:
 					params = append(params, fmt.Sprintf("id=%s", quoteParamIfNeeded(cmd.CmdResult)))
 				}
@@ -216,6 +225,10 @@ func isRevertible(cmd *ast.CommandNode) bool {
 		return false
 	}
 
+	if cmd.Action == "attach" && cmd.Entity == "networkacl" {
+		return false
+	}
+
 	if cmd.Entity == "record" && (cmd.Action == "create" || cmd.Action == "delete") {
 		return true
 	}
@@ -251,10 +264,23 @@ func isRevertible(cmd *ast.CommandNode) bool {
 	}
 
 	return cmd.Action == "attach" || cmd.Action == "detach" || cmd.Action == "check" ||
-		(cmd.Action == "create" && cmd.Entity == "tag") || (cmd.Action == "create" && cmd.Entity == "route")
+		(cmd.Action == "create" && cmd.Entity == "tag") || (cmd.Action == "create" && cmd.Entity == "route") ||
+		(cmd.Action == "create" && cmd.Entity == "networkaclrule")
+}
+
+// QuoteParamIfNeeded returns a param value in template syntax, quoted if needed
+func QuoteParamIfNeeded(param interface{}) string {
+	return quoteParamIfNeeded(param)
 }
 
 func quoteParamIfNeeded(param interface{}) string {
+	if list, ok := param.([]string); ok {
+		var quoted []string
+		for _, elem := range list {
+			quoted = append(quoted, quoteParamIfNeeded(elem))
+		}
+		return strings.Join(quoted, ",")
+	}
 	input := fmt.Sprint(param)
 	if ast.SimpleStringValue.MatchString(input) {
 		return input
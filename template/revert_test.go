@@ -207,6 +207,19 @@ attach volume device=/dev/sdh id=vol-12345 instance=i-12345`
 		}
 	})
 
+	t.Run("Revert create networkaclrule", func(t *testing.T) {
+		tpl := MustParse("create networkaclrule action=allow cidr=10.0.0.0/16 id=acl-12345 number=100 outbound=true portrange=443 protocol=tcp")
+		reverted, err := tpl.Revert()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		exp := `delete networkaclrule id=acl-12345 number=100 outbound=true`
+		if got, want := reverted.String(), exp; got != want {
+			t.Fatalf("got: %s\nwant: %s\n", got, want)
+		}
+	})
+
 	t.Run("Revert attach instance", func(t *testing.T) {
 		tpl := MustParse("attach instance id=i-123456 port=80 targetgroup=mytargetgrouparn")
 		reverted, err := tpl.Revert()
@@ -238,6 +251,22 @@ delete scalinggroup force=true name=my-scalinggroup`
 		}
 	})
 
+	t.Run("Delete fleet with its instances", func(t *testing.T) {
+		tpl := MustParse("create fleet target-capacity=2")
+		for _, cmd := range tpl.CommandNodesIterator() {
+			cmd.CmdResult = "sfr-1234"
+		}
+		reverted, err := tpl.Revert()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		exp := `delete fleet id=sfr-1234 terminate-instances=true`
+		if got, want := reverted.String(), exp; got != want {
+			t.Fatalf("got: %s\nwant: %s\n", got, want)
+		}
+	})
+
 	t.Run("Revert create accesskey", func(t *testing.T) {
 		tpl := MustParse("create accesskey user=myuser")
 		for _, cmd := range tpl.CommandNodesIterator() {
@@ -369,6 +398,7 @@ func TestCmdNodeIsRevertible(t *testing.T) {
 		{line: "delete record", revertible: true},
 		{line: "copy image", result: "any", revertible: true},
 		{line: "detach routetable", revertible: false},
+		{line: "attach networkacl", result: "any", revertible: false},
 		{line: "start alarm", revertible: true},
 		{line: "stop alarm", revertible: true},
 		{line: "start containerservice", revertible: true},
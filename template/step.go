@@ -0,0 +1,96 @@
+/*
+Copyright 2017 WALLIX
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package template
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/wallix/awless/template/internal/ast"
+)
+
+// ErrStepAborted is returned when a step by step run is aborted
+var ErrStepAborted = errors.New("template run aborted")
+
+// StepDecision is the decision taken before running a step in step by step mode
+type StepDecision int
+
+const (
+	StepRun StepDecision = iota
+	StepSkip
+	StepAbort
+)
+
+// StepConfirmer decides whether a step is run, skipped or aborts the run
+type StepConfirmer func(*Step) StepDecision
+
+// Step is a template step about to run, with its references resolved
+type Step struct {
+	Ident   string
+	Command *ast.CommandNode
+
+	// ReferencedBy lists the later steps using the output of this step,
+	// which then can not be skipped
+	ReferencedBy []string
+}
+
+func (s *Step) String() string {
+	if s.Ident != "" {
+		return fmt.Sprintf("%s = %s", s.Ident, s.Command)
+	}
+	return s.Command.String()
+}
+
+// SkipError checks whether the step can be skipped
+func (s *Step) SkipError() error {
+	if len(s.ReferencedBy) == 0 {
+		return nil
+	}
+	return fmt.Errorf("cannot skip `%s`: $%s is referenced by later steps: %s", s, s.Ident, strings.Join(s.ReferencedBy, ", "))
+}
+
+func (s *Template) confirmStep(confirm StepConfirmer, index int, ident string, cmd *ast.CommandNode) (bool, error) {
+	step := &Step{Ident: ident, Command: cmd}
+	if ident != "" {
+		step.ReferencedBy = referencesTo(ident, s.Statements[index+1:])
+	}
+	switch confirm(step) {
+	case StepRun:
+		return true, nil
+	case StepSkip:
+		return false, step.SkipError()
+	default:
+		return false, ErrStepAborted
+	}
+}
+
+func referencesTo(ident string, statements []*ast.Statement) (steps []string) {
+	for _, sts := range statements {
+		_, cmd := commandOf(sts)
+		if cmd == nil {
+			continue
+		}
+		for _, ref := range cmd.Refs {
+			if ref == ident || strings.HasPrefix(ref, ident+".") {
+				steps = append(steps, sts.String())
+				break
+			}
+		}
+	}
+	return
+}
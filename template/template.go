@@ -17,6 +17,7 @@ limitations under the License.
 package template
 
 import (
+	"context"
 	"crypto/rand"
 	"fmt"
 	"strings"
@@ -30,15 +31,32 @@ import (
 type Template struct {
 	ID string
 	*ast.AST
+
+	paramStore map[string]interface{}
 }
 
 func (s *Template) Run(d driver.Driver) (*Template, error) {
+	return s.RunWithContext(context.Background(), d, 0)
+}
+
+// RunWithContext runs the template until ctx is done, bounding each step
+// with stepTimeout when positive. A step exceeding its timeout, or the one running
+// when ctx expires, fails with a *TimeoutError and stops the run. As drivers are not
+// cancellable, the underlying call of a timed out step is left to complete in the background
+func (s *Template) RunWithContext(ctx context.Context, d driver.Driver, stepTimeout time.Duration) (*Template, error) {
+	return s.RunStepByStep(ctx, d, stepTimeout, nil)
+}
+
+// RunStepByStep runs the template as RunWithContext does, asking confirm before
+// each step whether to run it, skip it or abort the run. Skipped steps are left
+// out of the returned template. A nil confirm runs all the steps
+func (s *Template) RunStepByStep(ctx context.Context, d driver.Driver, stepTimeout time.Duration, confirm StepConfirmer) (*Template, error) {
 	vars := map[string]interface{}{}
 
-	current := &Template{AST: &ast.AST{}}
+	current := &Template{AST: &ast.AST{}, paramStore: s.paramStore}
 	current.ID = ulid.MustNew(ulid.Timestamp(time.Now()), rand.Reader).String()
 
-	for _, sts := range s.Statements {
+	for i, sts := range s.Statements {
 		clone := sts.Clone()
 		current.Statements = append(current.Statements, clone)
 		switch clone.Node.(type) {
@@ -49,10 +67,22 @@ func (s *Template) Run(d driver.Driver) (*Template, error) {
 				return current, err
 			}
 			cmd.ProcessRefs(vars)
+			if confirm != nil {
+				if run, err := s.confirmStep(confirm, i, "", cmd); err != nil || !run {
+					current.Statements = current.Statements[:len(current.Statements)-1]
+					if err != nil {
+						return current, err
+					}
+					continue
+				}
+			}
 
-			if cmd.CmdResult, cmd.CmdErr = fn(cmd.Params); cmd.CmdErr != nil {
+			if cmd.CmdResult, cmd.CmdErr = runStep(ctx, stepTimeout, fn, s.withParamStoreValues(cmd.Params)); cmd.CmdErr != nil {
 				return current, nil
 			}
+			if out, ok := cmd.CmdResult.(*driver.Outputs); ok {
+				cmd.CmdResult = out.Main
+			}
 		case *ast.DeclarationNode:
 			ident := clone.Node.(*ast.DeclarationNode).Ident
 			expr := clone.Node.(*ast.DeclarationNode).Expr
@@ -64,10 +94,25 @@ func (s *Template) Run(d driver.Driver) (*Template, error) {
 					return current, err
 				}
 				cmd.ProcessRefs(vars)
+				if confirm != nil {
+					if run, err := s.confirmStep(confirm, i, ident, cmd); err != nil || !run {
+						current.Statements = current.Statements[:len(current.Statements)-1]
+						if err != nil {
+							return current, err
+						}
+						continue
+					}
+				}
 
-				if cmd.CmdResult, cmd.CmdErr = fn(cmd.Params); cmd.CmdErr != nil {
+				if cmd.CmdResult, cmd.CmdErr = runStep(ctx, stepTimeout, fn, s.withParamStoreValues(cmd.Params)); cmd.CmdErr != nil {
 					return current, nil
 				}
+				if out, ok := cmd.CmdResult.(*driver.Outputs); ok {
+					cmd.CmdResult = out.Main
+					for name, v := range out.Named {
+						vars[ident+"."+name] = v
+					}
+				}
 				vars[ident] = cmd.CmdResult
 			}
 		}
@@ -76,6 +121,69 @@ func (s *Template) Run(d driver.Driver) (*Template, error) {
 	return current, nil
 }
 
+// TimeoutError is the error of a template step that did not complete in time
+type TimeoutError struct {
+	Ran   time.Duration
+	Cause error
+}
+
+func (e *TimeoutError) Error() string {
+	if e.Ran == 0 {
+		return fmt.Sprintf("not run: %s", e.Cause)
+	}
+	return fmt.Sprintf("timed out after %s: %s", e.Ran, e.Cause)
+}
+
+func (e *TimeoutError) Unwrap() error { return e.Cause }
+
+func runStep(ctx context.Context, timeout time.Duration, fn driver.DriverFn, params map[string]interface{}) (interface{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, &TimeoutError{Cause: err}
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	if ctx.Done() == nil {
+		return fn(params)
+	}
+
+	type result struct {
+		out interface{}
+		err error
+	}
+	done := make(chan result, 1)
+	start := time.Now()
+	go func() {
+		out, err := fn(params)
+		done <- result{out, err}
+	}()
+
+	select {
+	case res := <-done:
+		return res.out, res.err
+	case <-ctx.Done():
+		return nil, &TimeoutError{Ran: time.Since(start), Cause: ctx.Err()}
+	}
+}
+
+func (s *Template) withParamStoreValues(params map[string]interface{}) map[string]interface{} {
+	if len(s.paramStore) == 0 {
+		return params
+	}
+	resolved := make(map[string]interface{}, len(params))
+	for k, v := range params {
+		resolved[k] = v
+		if str, ok := v.(string); ok {
+			if actual, found := s.paramStore[str]; found {
+				resolved[k] = actual
+			}
+		}
+	}
+	return resolved
+}
+
 func (s *Template) DryRun(d driver.Driver) error {
 	defer d.SetDryRun(false)
 	d.SetDryRun(true)
@@ -108,6 +216,16 @@ func (s *Template) Validate(rules ...Validator) (all []error) {
 	return
 }
 
+// TimedOut returns the step of an executed template that timed out, if any
+func (t *Template) TimedOut() (*ast.CommandNode, bool) {
+	for _, cmd := range t.CommandNodesIterator() {
+		if _, ok := cmd.CmdErr.(*TimeoutError); ok {
+			return cmd, true
+		}
+	}
+	return nil, false
+}
+
 func (t *Template) HasErrors() bool {
 	for _, cmd := range t.CommandNodesIterator() {
 		if cmd.CmdErr != nil {
@@ -246,6 +364,10 @@ func (d *Errors) add(err error) {
 	d.errs = append(d.errs, err)
 }
 
+func (d *Errors) Unwrap() []error {
+	return d.errs
+}
+
 func (d *Errors) Error() string {
 	var all []string
 	for _, err := range d.errs {
@@ -17,10 +17,12 @@ limitations under the License.
 package template
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/wallix/awless/logger"
 	"github.com/wallix/awless/template/driver"
@@ -212,7 +214,181 @@ func TestRunDriverOnTemplate(t *testing.T) {
 			t.Fatal(err)
 		}
 	})
+
+	t.Run("Driver named outputs", func(t *testing.T) {
+		s := &Template{AST: &ast.AST{}}
+
+		s.Statements = append(s.Statements, &ast.Statement{Node: &ast.DeclarationNode{
+			Ident: "stack",
+			Expr: &ast.CommandNode{
+				Action: "create", Entity: "loadbalancerstack",
+				Params: map[string]interface{}{"name": "mystack"},
+			}}}, &ast.Statement{Node: &ast.CommandNode{
+			Action: "create", Entity: "record",
+			Refs: map[string]string{"value": "stack.dns"},
+		}},
+		)
+
+		mDriver := &mockDriver{prefix: "mynew", expects: []*expectation{{
+			action: "create", entity: "loadbalancerstack",
+			expectedParams: map[string]interface{}{"name": "mystack"},
+			namedOutputs:   map[string]interface{}{"dns": "mystack.elb.amazonaws.com"},
+		}, {
+			action: "create", entity: "record",
+			expectedParams: map[string]interface{}{"value": "mystack.elb.amazonaws.com"},
+		},
+		},
+		}
+
+		executedTemplate, err := s.Run(mDriver)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := mDriver.lookupsCalled(); err != nil {
+			t.Fatal(err)
+		}
+		if executedTemplate.HasErrors() {
+			t.Fatalf("unexpected errors in %s", executedTemplate)
+		}
+		modifiedDecl := executedTemplate.Statements[0].Node.(*ast.DeclarationNode)
+		if got, want := modifiedDecl.Expr.Result(), "mynewloadbalancerstack"; got != want {
+			t.Fatalf("got %#v, want %#v", got, want)
+		}
+	})
+
+	t.Run("Step timeout", func(t *testing.T) {
+		s := &Template{AST: &ast.AST{}}
+
+		s.Statements = append(s.Statements, &ast.Statement{Node: &ast.CommandNode{
+			Action: "create", Entity: "vpc",
+			Params: map[string]interface{}{"cidr": "10.0.0.0/16"},
+		}}, &ast.Statement{Node: &ast.CommandNode{
+			Action: "create", Entity: "subnet",
+			Params: map[string]interface{}{"cidr": "10.0.0.0/24"},
+		}}, &ast.Statement{Node: &ast.CommandNode{
+			Action: "create", Entity: "instance",
+			Params: map[string]interface{}{"count": 1},
+		}},
+		)
+
+		mDriver := &mockDriver{prefix: "mynew", expects: []*expectation{{
+			action: "create", entity: "vpc",
+			expectedParams: map[string]interface{}{"cidr": "10.0.0.0/16"},
+		}, {
+			action: "create", entity: "subnet",
+			expectedParams: map[string]interface{}{"cidr": "10.0.0.0/24"},
+			delay:          time.Second,
+		},
+		},
+		}
+
+		executedTemplate, err := s.RunWithContext(context.Background(), mDriver, 50*time.Millisecond)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := len(executedTemplate.Statements), 2; got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+		if got, want := executedTemplate.CommandNodesIterator()[0].Result(), "mynewvpc"; got != want {
+			t.Fatalf("got %#v, want %#v", got, want)
+		}
+		cmd, ok := executedTemplate.TimedOut()
+		if !ok {
+			t.Fatalf("expected a timed out step in %s", executedTemplate)
+		}
+		if got, want := cmd.Entity, "subnet"; got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+		if !errors.Is(cmd.Err(), context.DeadlineExceeded) {
+			t.Fatalf("got %s, want deadline exceeded", cmd.Err())
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		executedTemplate, err = s.RunWithContext(ctx, mDriver, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cmd, ok := executedTemplate.TimedOut(); !ok || cmd.Entity != "vpc" {
+			t.Fatalf("expected first step not to run in %s", executedTemplate)
+		}
+	})
 }
+func TestRunStepByStep(t *testing.T) {
+	tpl, err := Parse("vpc = create vpc cidr=10.0.0.0/16\ncreate tag resource=$vpc key=Env value=dev\ndelete subnet id=sub-1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+	decide := func(decisions ...StepDecision) (StepConfirmer, *[]string) {
+		var asked []string
+		return func(step *Step) StepDecision {
+			asked = append(asked, step.String())
+			d := decisions[0]
+			decisions = decisions[1:]
+			return d
+		}, &asked
+	}
+	newDriver := func() *mockDriver {
+		return &mockDriver{prefix: "new", expects: []*expectation{
+			{action: "create", entity: "vpc", expectedParams: map[string]interface{}{"cidr": "10.0.0.0/16"}},
+			{action: "create", entity: "tag", expectedParams: map[string]interface{}{"resource": "newvpc", "key": "Env", "value": "dev"}},
+			{action: "delete", entity: "subnet", expectedParams: map[string]interface{}{"id": "sub-1234"}},
+		}}
+	}
+
+	t.Run("run and skip", func(t *testing.T) {
+		confirm, asked := decide(StepRun, StepSkip, StepRun)
+		ran, err := tpl.RunStepByStep(context.Background(), newDriver(), 0, confirm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, cmd := range ran.CommandNodesIterator() {
+			if cmd.Err() != nil {
+				t.Fatal(cmd.Err())
+			}
+		}
+		if got, want := *asked, []string{"vpc = create vpc cidr=10.0.0.0/16", "create tag key=Env resource=newvpc value=dev", "delete subnet id=sub-1234"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+		if got, want := ran.String(), "vpc = create vpc cidr=10.0.0.0/16\ndelete subnet id=sub-1234"; got != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	})
+	t.Run("abort", func(t *testing.T) {
+		confirm, asked := decide(StepRun, StepAbort)
+		ran, err := tpl.RunStepByStep(context.Background(), newDriver(), 0, confirm)
+		if got, want := err, ErrStepAborted; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+		if got, want := len(*asked), 2; got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+		if got, want := ran.String(), "vpc = create vpc cidr=10.0.0.0/16"; got != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	})
+	t.Run("can not skip step referenced later", func(t *testing.T) {
+		var referencedBy []string
+		confirm := func(step *Step) StepDecision {
+			referencedBy = step.ReferencedBy
+			return StepSkip
+		}
+		ran, err := tpl.RunStepByStep(context.Background(), newDriver(), 0, confirm)
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		if got, want := err.Error(), "cannot skip `vpc = create vpc cidr=10.0.0.0/16`: $vpc is referenced by later steps: create tag key=Env resource=$vpc value=dev"; got != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+		if got, want := referencedBy, []string{"create tag key=Env resource=$vpc value=dev"}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+		if got, want := len(ran.Statements), 0; got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+	})
+}
+
 func TestGetTemplateUniqueDefinitions(t *testing.T) {
 	text := "create instance name=nemo\ncreate keypair name=mykey\ncreate tag key=mine\ncreate instance\ncreate keypair"
 	tpl := MustParse(text)
@@ -241,6 +417,8 @@ type expectation struct {
 	lookupDone     bool
 	action, entity string
 	expectedParams map[string]interface{}
+	namedOutputs   map[string]interface{}
+	delay          time.Duration
 }
 
 type mockDriver struct {
@@ -264,9 +442,13 @@ func (r *mockDriver) Lookup(lookups ...string) (driver.DriverFn, error) {
 			expect.lookupDone = true
 
 			return func(params map[string]interface{}) (interface{}, error) {
+				time.Sleep(expect.delay)
 				if got, want := expect.expectedParams, params; !reflect.DeepEqual(got, want) {
 					return nil, fmt.Errorf("[%s %s] params mismatch: expected %v, got %v", expect.action, expect.entity, got, want)
 				}
+				if expect.namedOutputs != nil {
+					return &driver.Outputs{Main: r.prefix + expect.entity, Named: expect.namedOutputs}, nil
+				}
 				return r.prefix + expect.entity, nil
 			}, nil
 		}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"strconv"
 
 	"github.com/wallix/awless/graph"
 )
@@ -61,3 +62,117 @@ func (v *ParamIsSetValidator) Execute(t *Template) (errs []error) {
 	}
 	return
 }
+
+// ProtectedTag marks the resources that delete commands must not remove (ex: awless:protected=true)
+const ProtectedTag = "awless:protected"
+
+// ProtectedResourceValidator reports the resources tagged as protected
+// that are targeted (by ID or name) by the delete commands of the template
+type ProtectedResourceValidator struct {
+	LookupGraph LookupGraphFunc
+}
+
+func (v *ProtectedResourceValidator) Execute(t *Template) (errs []error) {
+	for _, cmd := range t.CommandNodesIterator() {
+		if cmd.Action != "delete" {
+			continue
+		}
+		g, ok := v.LookupGraph(cmd.Entity)
+		if !ok {
+			continue
+		}
+		for _, target := range deleteTargets(cmd.Params) {
+			resources, err := g.GetAllResources(cmd.Entity)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			for _, r := range resources {
+				if name, _ := r.Properties["Name"].(string); r.Id() != target && name != target {
+					continue
+				}
+				if isProtected(r) {
+					errs = append(errs, fmt.Errorf("%s %s is protected (tagged %s=true)", r.Type(), target, ProtectedTag))
+				}
+			}
+		}
+	}
+	return
+}
+
+func deleteTargets(params map[string]interface{}) (targets []string) {
+	for _, key := range []string{"id", "name"} {
+		switch v := params[key].(type) {
+		case string:
+			targets = append(targets, v)
+		case []interface{}:
+			for _, e := range v {
+				targets = append(targets, fmt.Sprint(e))
+			}
+		}
+	}
+	return
+}
+
+func isProtected(r *graph.Resource) bool {
+	tags, _ := r.Properties["Tags"].([]string)
+	for _, tag := range tags {
+		if tag == ProtectedTag+"=true" {
+			return true
+		}
+	}
+	return false
+}
+
+// WeightRange bounds the weight param of the commands on an entity
+type WeightRange struct {
+	Min, Max int64
+}
+
+// WeightRanges are the weights accepted by the services per entity. Route53 routes to the
+// records of a weighted set in proportion of their weight to the sum of the set weights
+var WeightRanges = map[string]WeightRange{
+	"record": {Min: 0, Max: 255},
+}
+
+// CheckWeight returns an error when a weight is not an integer in the range of the entity
+func CheckWeight(entity string, weight interface{}) error {
+	r, ok := WeightRanges[entity]
+	if !ok {
+		return fmt.Errorf("%s: weight not supported", entity)
+	}
+	var w int64
+	switch v := weight.(type) {
+	case int:
+		w = int64(v)
+	case int64:
+		w = v
+	case string:
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%s: weight '%s' is not an integer", entity, v)
+		}
+		w = parsed
+	default:
+		return fmt.Errorf("%s: weight '%v' is not an integer", entity, weight)
+	}
+	if w < r.Min || w > r.Max {
+		return fmt.Errorf("%s: weight %d out of range [%d, %d]", entity, w, r.Min, r.Max)
+	}
+	return nil
+}
+
+// WeightRangeValidator reports the weights of the template commands out of the range of their entity.
+// Weights referencing variables are checked once resolved by the drivers
+type WeightRangeValidator struct{}
+
+func (v *WeightRangeValidator) Execute(t *Template) (errs []error) {
+	for _, cmd := range t.CommandNodesIterator() {
+		if weight, ok := cmd.Params["weight"]; ok {
+			if err := CheckWeight(cmd.Entity, weight); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return
+}
@@ -60,4 +60,55 @@ func TestValidation(t *testing.T) {
 			t.Fatalf("got %d, want %d", got, want)
 		}
 	})
+
+	t.Run("Validate protected resources", func(t *testing.T) {
+		text := `delete instance id=inst_1
+		delete instance id=inst_2
+		delete bucket name=my_bucket
+		create instance name=inst_3`
+
+		g := graph.NewGraph()
+		g.AddResource(
+			resourcetest.Instance("inst_1").Prop("Tags", []string{"Env=prod", "awless:protected=true"}).Build(),
+			resourcetest.Instance("inst_2").Prop("Tags", []string{"awless:protected=false"}).Build(),
+			resourcetest.Instance("inst_3").Prop("Tags", []string{"awless:protected=true"}).Build(),
+			resourcetest.Bucket("my_bucket").Prop("Tags", []string{"awless:protected=true"}).Build(),
+		)
+
+		tpl := template.MustParse(text)
+
+		lookup := func(key string) (*graph.Graph, bool) { return g, true }
+		rule := &template.ProtectedResourceValidator{lookup}
+
+		errs := tpl.Validate(rule)
+		if got, want := len(errs), 2; got != want {
+			t.Fatalf("got %d, want %d", got, want)
+		}
+		if got, want := errs[0].Error(), "instance inst_1 is protected (tagged awless:protected=true)"; got != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+		if got, want := errs[1].Error(), "bucket my_bucket is protected (tagged awless:protected=true)"; got != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("Validate weight ranges", func(t *testing.T) {
+		text := `create record zone=Z1 name=www.example.com type=A value=1.2.3.4 ttl=60 identifier=blue weight=90
+		create record zone=Z1 name=www.example.com type=A value=5.6.7.8 ttl=60 identifier=green weight=300
+		create record zone=Z1 name=api.example.com type=A value=5.6.7.8 ttl=60 identifier=green weight=$canary
+		create instance name=inst_1 weight=10`
+
+		tpl := template.MustParse(text)
+
+		errs := tpl.Validate(&template.WeightRangeValidator{})
+		if got, want := len(errs), 2; got != want {
+			t.Fatalf("got %d, want %d: %v", got, want, errs)
+		}
+		if got, want := errs[0].Error(), "record: weight 300 out of range [0, 255]"; got != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+		if got, want := errs[1].Error(), "instance: weight not supported"; got != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	})
 }
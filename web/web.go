@@ -190,7 +190,7 @@ func loadLocalTriples() ([]tstore.Triple, error) {
 		readers = append(readers, reader)
 	}
 
-	dec := tstore.NewDatasetDecoder(tstore.NewBinaryDecoder, readers...)
+	dec := tstore.NewDatasetDecoder(graph.NewDecoder, readers...)
 	return dec.Decode()
 }
 